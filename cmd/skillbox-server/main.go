@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,10 +15,13 @@ import (
 	"github.com/devs-group/skillbox/internal/artifacts"
 	"github.com/devs-group/skillbox/internal/backfill"
 	"github.com/devs-group/skillbox/internal/config"
+	"github.com/devs-group/skillbox/internal/grpcserver"
+	"github.com/devs-group/skillbox/internal/leader"
 	"github.com/devs-group/skillbox/internal/registry"
 	"github.com/devs-group/skillbox/internal/runner"
 	"github.com/devs-group/skillbox/internal/sandbox"
 	"github.com/devs-group/skillbox/internal/scanner"
+	"github.com/devs-group/skillbox/internal/signing"
 	"github.com/devs-group/skillbox/internal/store"
 )
 
@@ -39,11 +43,19 @@ func main() {
 	case "error":
 		level = slog.LevelError
 	}
-	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level})))
+	// "skillbox-server mcp" serves MCP over stdio, so stdout carries the
+	// protocol and logs go to stderr.
+	mcpMode := len(os.Args) > 1 && os.Args[1] == "mcp"
+	logOut := os.Stdout
+	if mcpMode {
+		logOut = os.Stderr
+	}
+	slog.SetDefault(slog.New(slog.NewJSONHandler(logOut, &slog.HandlerOptions{Level: level})))
 
 	slog.Info("starting skillbox server",
 		"version", "dev",
 		"port", cfg.APIPort,
+		"grpc_port", cfg.GRPCPort,
 	)
 
 	// Initialize database
@@ -60,9 +72,8 @@ func main() {
 		slog.Error("failed to initialize skill registry", "error", err)
 		os.Exit(1)
 	}
-
-	// Repoint legacy 0.0.0 skills to 1.0.0 and backfill the active-version pointer.
-	backfill.SkillVersions(context.Background(), db, reg, slog.Default())
+	// Re-check publisher signatures before every skill is executed.
+	reg.SetVerifier(signing.NewVerifier(db))
 
 	// Initialize artifact collector (MinIO)
 	collector, err := artifacts.New(cfg.S3Endpoint, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3BucketExecs, cfg.S3UseSSL)
@@ -74,11 +85,6 @@ func main() {
 	// Initialize OpenSandbox client
 	sbClient := sandbox.New(cfg.OpenSandboxURL, cfg.OpenSandboxAPIKey, nil)
 
-	// Clean up orphaned sandboxes from previous runs
-	if err := runner.CleanupOrphans(context.Background(), sbClient); err != nil {
-		slog.Warn("orphan cleanup failed", "error", err)
-	}
-
 	// Initialize session manager for sandbox shell API
 	sessMgr := sandbox.NewSessionManager(sbClient, db, collector, cfg)
 
@@ -102,6 +108,15 @@ func main() {
 			slog.Error("failed to initialize security scanner", "error", scannerErr)
 			os.Exit(1)
 		}
+		if cfg.ScannerOSVDir != "" {
+			vulnDB, err := scanner.LoadVulnDB(cfg.ScannerOSVDir, slog.Default())
+			if err != nil {
+				slog.Error("failed to load OSV vulnerability database", "dir", cfg.ScannerOSVDir, "error", err)
+				os.Exit(1)
+			}
+			pipeline.SetVulnDB(vulnDB)
+			slog.Info("loaded OSV vulnerability database", "dir", cfg.ScannerOSVDir, "advisories", vulnDB.Len())
+		}
 		sc = pipeline
 		slog.Info("security scanner enabled",
 			"timeout", cfg.ScannerTimeout,
@@ -115,13 +130,22 @@ func main() {
 	// Initialize runner
 	r := runner.New(cfg, sbClient, reg, db, collector)
 
+	if mcpMode {
+		if err := serveMCPStdio(db, reg, r, sessMgr); err != nil {
+			slog.Error("mcp server error", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Initialize background scan worker.
 	var scanWorker *scanner.Worker
 	if cfg.ScannerEnabled {
 		scanWorker = scanner.NewWorker(scanner.WorkerConfig{
-			Registry: reg,
-			Scanner:  sc,
-			Logger:   slog.Default(),
+			Registry:     reg,
+			Scanner:      sc,
+			Logger:       slog.Default(),
+			PollInterval: 30 * time.Second,
 			UpdateStatus: func(ctx context.Context, tenantID, name, version, status string, result json.RawMessage) error {
 				return db.UpdateSkillStatus(ctx, tenantID, name, version, status, result)
 			},
@@ -143,15 +167,55 @@ func main() {
 				}
 				return cfg.ApprovalPolicy, nil
 			},
+			GetVulnThreshold: func(ctx context.Context, tenantID string) (string, error) {
+				cfg, err := db.GetScannerConfig(ctx, tenantID)
+				if err != nil {
+					return "", err
+				}
+				return cfg.VulnBlockSeverity, nil
+			},
 			OnAvailable: func(ctx context.Context, tenantID, name, version string) error {
 				return db.SetActiveVersion(ctx, tenantID, name, version)
 			},
+			RunTests: r.RunTests,
+			SaveTestResults: func(ctx context.Context, tenantID, name, version string, results json.RawMessage) error {
+				return db.SetSkillTestResults(ctx, tenantID, name, version, results)
+			},
+			SaveSBOM: func(ctx context.Context, tenantID, name, version string, sbom *scanner.SBOM, deps []scanner.Dependency) error {
+				data, err := json.MarshalIndent(sbom, "", "  ")
+				if err != nil {
+					return err
+				}
+				if err := reg.PutSBOM(ctx, tenantID, name, version, data); err != nil {
+					return err
+				}
+				rows := make([]store.SkillDependency, len(deps))
+				for i, d := range deps {
+					rows[i] = store.SkillDependency{
+						Ecosystem: d.Ecosystem, Package: d.Name, PackageVersion: d.Version,
+						Specifier: d.Specifier, FilePath: d.FilePath,
+					}
+				}
+				return db.ReplaceSkillDependencies(ctx, tenantID, name, version, rows)
+			},
 		})
 		slog.Info("background scan worker initialized")
 	}
 
-	// Build router
-	router := api.NewRouter(cfg, db, r, reg, sc, sessMgr, pipeline, scanWorker, collector)
+	// Build router and gRPC server; both authenticate through one
+	// authenticator so they share its cache.
+	auth := api.NewAuthenticator(cfg, db)
+	defer auth.Close() //nolint:errcheck
+	router := api.NewRouter(cfg, db, auth, r, reg, sc, sessMgr, pipeline, scanWorker, collector)
+	grpcSrv := grpcserver.New(grpcserver.Config{
+		Store:       db,
+		Auth:        auth,
+		Runner:      r,
+		Registry:    reg,
+		Objects:     collector,
+		MaxFileSize: cfg.MaxSkillSize,
+		Workspaces:  sessMgr,
+	})
 
 	// Create HTTP server
 	srv := &http.Server{
@@ -165,13 +229,44 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
-	// Start background scan worker goroutine.
+	// Singleton background jobs run on one replica at a time; the others
+	// take over if it dies.
+	elector := leader.New(db, cfg.ReplicaID, cfg.LeaderRetryInterval, slog.Default())
+	elector.Register("skill-backfill", func(ctx context.Context) {
+		// Repoint legacy 0.0.0 skills to 1.0.0 and backfill the active-version pointer.
+		backfill.SkillVersions(ctx, db, reg, slog.Default())
+	})
+	elector.Register("sandbox-cleanup", func(ctx context.Context) {
+		cleanup := func() {
+			// Execution sandboxes older than the longest possible run are orphans.
+			if err := runner.CleanupOrphans(ctx, sbClient, runner.OrphanMinAge(cfg)); err != nil {
+				slog.Warn("orphan cleanup failed", "error", err)
+			}
+			sessMgr.CleanupOrphans(ctx, cfg.SandboxSessionTTL)
+			sessMgr.PruneSnapshots(ctx)
+		}
+		cleanup()
+		ticker := time.NewTicker(5 * time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				cleanup()
+			}
+		}
+	})
 	if scanWorker != nil {
-		go scanWorker.Start(ctx)
-		slog.Info("background scan worker started")
+		elector.Register("scan-worker", scanWorker.Start)
 	}
+	electorDone := make(chan struct{})
+	go func() {
+		defer close(electorDone)
+		elector.Run(ctx)
+	}()
 
-	// Start background session sandbox cleanup goroutine
+	// Start background cleanup of sandboxes idle on this replica
 	go func() {
 		ticker := time.NewTicker(5 * time.Minute)
 		defer ticker.Stop()
@@ -185,6 +280,21 @@ func main() {
 		}
 	}()
 
+	// Renew session sandbox leases well before they lapse so other
+	// replicas do not take over sandboxes this one is serving.
+	go func() {
+		ticker := time.NewTicker(cfg.SessionLeaseTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				sessMgr.RenewLeases(context.Background())
+			}
+		}
+	}()
+
 	// Start HTTP server
 	go func() {
 		slog.Info("http server listening", "addr", srv.Addr)
@@ -194,6 +304,20 @@ func main() {
 		}
 	}()
 
+	// Start gRPC server
+	grpcLis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		slog.Error("failed to listen for grpc", "error", err)
+		os.Exit(1)
+	}
+	go func() {
+		slog.Info("grpc server listening", "addr", grpcLis.Addr().String())
+		if err := grpcSrv.Serve(grpcLis); err != nil {
+			slog.Error("grpc server error", "error", err)
+			os.Exit(1)
+		}
+	}()
+
 	<-ctx.Done()
 	slog.Info("shutting down servers...")
 
@@ -201,11 +325,18 @@ func main() {
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Sync and destroy all managed session sandboxes
+	// Sync session sandboxes this replica holds and release their leases
 	sessMgr.Shutdown(shutdownCtx)
 
+	// Wait for singleton jobs to stop so their roles are released
+	select {
+	case <-electorDone:
+	case <-shutdownCtx.Done():
+	}
+
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		slog.Error("http server shutdown error", "error", err)
 	}
+	grpcSrv.Stop(shutdownCtx)
 	slog.Info("servers stopped")
 }
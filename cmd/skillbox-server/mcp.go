@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/devs-group/skillbox/internal/mcpserver"
+	"github.com/devs-group/skillbox/internal/registry"
+	"github.com/devs-group/skillbox/internal/runner"
+	"github.com/devs-group/skillbox/internal/sandbox"
+	"github.com/devs-group/skillbox/internal/store"
+	"github.com/devs-group/skillbox/internal/tools"
+)
+
+// serveMCPStdio serves the MCP tools of the tenant that owns
+// SKILLBOX_API_KEY over stdin and stdout. Workspace tools use
+// SKILLBOX_SESSION_ID, or a fresh session for each process.
+func serveMCPStdio(db *store.Store, reg *registry.Registry, r *runner.Runner, sm *sandbox.SessionManager) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	apiKey := os.Getenv("SKILLBOX_API_KEY")
+	if apiKey == "" {
+		return errors.New("SKILLBOX_API_KEY is required")
+	}
+	hash := sha256.Sum256([]byte(apiKey))
+	key, err := db.ValidateKey(ctx, hex.EncodeToString(hash[:]))
+	if err != nil {
+		return fmt.Errorf("validate API key: %w", err)
+	}
+	if key == nil {
+		return errors.New("SKILLBOX_API_KEY is invalid or revoked")
+	}
+
+	sessionID := os.Getenv("SKILLBOX_SESSION_ID")
+	if sessionID == "" {
+		b := make([]byte, 8)
+		_, _ = rand.Read(b)
+		sessionID = "mcp-" + hex.EncodeToString(b)
+	}
+
+	slog.Info("serving mcp over stdio", "tenant_id", key.TenantID, "session_id", sessionID)
+	err = mcpserver.New(tools.NewCatalog(db, reg), r, sm).ServeStdio(ctx, key.TenantID, sessionID)
+
+	// Sync the workspace back to storage before the sandbox is released.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	sm.Shutdown(shutdownCtx)
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
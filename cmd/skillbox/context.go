@@ -0,0 +1,300 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/devs-group/skillbox/internal/cli"
+)
+
+// Context state, resolved by applyContext before a command runs.
+var (
+	flagContext   string
+	activeConfig  *cli.Config
+	activeContext *cli.Context
+)
+
+// applyContext selects the context named by --context, or the current one
+// in the config file, and fills in --server, --api-key and --tenant from it
+// where neither the flag nor its environment variable is set.
+func applyContext(cmd *cobra.Command) error {
+	cfg, err := cli.LoadConfig()
+	if err != nil {
+		return err
+	}
+	activeConfig = cfg
+
+	name := flagContext
+	if name == "" {
+		name = cfg.CurrentContext
+	}
+	if name == "" {
+		return nil
+	}
+	ctx := cfg.Find(name)
+	if ctx == nil {
+		return &exitError{code: exitNotFound, err: fmt.Errorf("context %q not found — see `skillbox context list`", name)}
+	}
+	activeContext = ctx
+
+	inherit := func(flag, env, value string, target *string) {
+		if value != "" && !cmd.Flags().Changed(flag) && os.Getenv(env) == "" {
+			*target = value
+		}
+	}
+	inherit("server", "SKILLBOX_SERVER_URL", ctx.Server, &flagServer)
+	inherit("tenant", "SKILLBOX_TENANT_ID", ctx.Tenant, &flagTenant)
+	if ctx.Auth == cli.AuthAPIKey {
+		inherit("api-key", "SKILLBOX_API_KEY", ctx.APIKey, &flagAPIKey)
+	}
+	return nil
+}
+
+// loadCredentials returns the device-auth tokens of the active context, or
+// those in credentials.json when no context is in use, refreshing an
+// expired access token.
+func loadCredentials() (*cli.Credentials, error) {
+	if activeContext == nil {
+		return cli.LoadCredentials()
+	}
+	return activeConfig.ContextCredentials(activeContext.Name)
+}
+
+// bearerToken returns the token API requests authenticate with: the API
+// key, or the access token of an active device-auth context.
+func bearerToken() string {
+	if flagAPIKey != "" || activeContext == nil || activeContext.Auth != cli.AuthDeviceAuth {
+		return flagAPIKey
+	}
+	creds, err := loadCredentials()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: context %q: %s — run `skillbox login`\n", activeContext.Name, err) //nolint:errcheck
+		return ""
+	}
+	return creds.AccessToken
+}
+
+// --------------------------------------------------------------------
+// skillbox context
+// --------------------------------------------------------------------
+
+func newContextCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "context",
+		Short: "Manage named server contexts",
+		Long: `Contexts are named servers stored in ~/.config/skillbox/config.json,
+each with its own server URL, authentication (an API key or device-auth
+tokens from "skillbox login") and default tenant. Commands use the
+current context, or the one given with --context. Flags and environment
+variables override its values.`,
+		// Skip applyContext so that a broken current context can be fixed.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateOutput()
+		},
+	}
+
+	cmd.AddCommand(
+		newContextAddCmd(),
+		newContextUseCmd(),
+		newContextListCmd(),
+		newContextDeleteCmd(),
+	)
+	return cmd
+}
+
+// --------------------------------------------------------------------
+// skillbox context add
+// --------------------------------------------------------------------
+
+func newContextAddCmd() *cobra.Command {
+	var (
+		deviceAuth bool
+		hydraURL   string
+		clientID   string
+		use        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add a context for a server",
+		Long: `Add a context with the server, API key and tenant given by --server,
+--api-key and --tenant (or their environment variables). With
+--device-auth the context authenticates with tokens from "skillbox login"
+instead of an API key. The first context added becomes the current one.`,
+		Example: `  skillbox context add prod --server https://skillbox.example.com --api-key sk_live_... --tenant acme
+  skillbox context add staging --server https://staging.example.com --device-auth --use`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if err := cli.ValidateContextName(name); err != nil {
+				return usageError(err)
+			}
+			if deviceAuth == (flagAPIKey != "") {
+				return usageError(fmt.Errorf("pass either --api-key or --device-auth"))
+			}
+
+			cfg, err := cli.LoadConfig()
+			if err != nil {
+				return err
+			}
+			if cfg.Find(name) != nil {
+				return &exitError{code: exitConflict, err: fmt.Errorf("context %q already exists", name)}
+			}
+
+			ctx := cli.Context{Name: name, Server: flagServer, Tenant: flagTenant}
+			if deviceAuth {
+				ctx.Auth = cli.AuthDeviceAuth
+				ctx.HydraURL = hydraURL
+				ctx.ClientID = clientID
+			} else {
+				ctx.Auth = cli.AuthAPIKey
+				ctx.APIKey = flagAPIKey
+			}
+			cfg.Contexts = append(cfg.Contexts, ctx)
+			if use || cfg.CurrentContext == "" {
+				cfg.CurrentContext = name
+			}
+			if err := cli.SaveConfig(cfg); err != nil {
+				return err
+			}
+
+			fmt.Printf("Added context %s (%s)\n", name, ctx.Server)
+			if cfg.CurrentContext == name {
+				fmt.Printf("Switched to context %s\n", name)
+			}
+			if deviceAuth {
+				fmt.Printf("Run `skillbox login --context %s` to authenticate\n", name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&deviceAuth, "device-auth", false, "Authenticate with `skillbox login` instead of an API key")
+	cmd.Flags().StringVar(&hydraURL, "hydra-url", envOrDefault("SKILLBOX_HYDRA_URL", "http://localhost:4444"), "Hydra public URL for --device-auth")
+	cmd.Flags().StringVar(&clientID, "client-id", cli.DefaultClientID, "OAuth2 client ID for --device-auth")
+	cmd.Flags().BoolVar(&use, "use", false, "Make the new context the current one")
+	return cmd
+}
+
+// --------------------------------------------------------------------
+// skillbox context use
+// --------------------------------------------------------------------
+
+func newContextUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <name>",
+		Short: "Switch the current context",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := cli.LoadConfig()
+			if err != nil {
+				return err
+			}
+			if cfg.Find(args[0]) == nil {
+				return &exitError{code: exitNotFound, err: fmt.Errorf("context %q not found", args[0])}
+			}
+			cfg.CurrentContext = args[0]
+			if err := cli.SaveConfig(cfg); err != nil {
+				return err
+			}
+			fmt.Printf("Switched to context %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+// --------------------------------------------------------------------
+// skillbox context list
+// --------------------------------------------------------------------
+
+// contextSummary is a row of `skillbox context list`. It leaves out API
+// keys and tokens.
+type contextSummary struct {
+	Name     string `json:"name"`
+	Current  bool   `json:"current"`
+	Server   string `json:"server"`
+	Auth     string `json:"auth"`
+	Tenant   string `json:"tenant"`
+	User     string `json:"user,omitempty"`
+	LoggedIn bool   `json:"logged_in"`
+}
+
+func newContextListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List contexts; the current one is marked with *",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := cli.LoadConfig()
+			if err != nil {
+				return err
+			}
+
+			rows := make([]contextSummary, 0, len(cfg.Contexts))
+			for _, c := range cfg.Contexts {
+				row := contextSummary{
+					Name:    c.Name,
+					Current: c.Name == cfg.CurrentContext,
+					Server:  c.Server,
+					Auth:    c.Auth,
+					Tenant:  c.Tenant,
+				}
+				if c.Auth == cli.AuthAPIKey {
+					row.LoggedIn = c.APIKey != ""
+				} else if c.Credentials != nil && c.Credentials.AccessToken != "" {
+					row.User = c.Credentials.Email
+					row.LoggedIn = true
+				}
+				rows = append(rows, row)
+			}
+
+			if !structuredOutput() && len(rows) == 0 {
+				fmt.Println("No contexts. Add one with `skillbox context add`.")
+				return nil
+			}
+			return printOutput(rows, func(w io.Writer) {
+				fmt.Fprintln(w, "CURRENT\tNAME\tSERVER\tAUTH\tTENANT\tUSER") //nolint:errcheck
+				for _, r := range rows {
+					current := ""
+					if r.Current {
+						current = "*"
+					}
+					user := r.User
+					if r.Auth == cli.AuthDeviceAuth && !r.LoggedIn {
+						user = "(not logged in)"
+					}
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", current, r.Name, r.Server, r.Auth, r.Tenant, user) //nolint:errcheck
+				}
+			})
+		},
+	}
+}
+
+// --------------------------------------------------------------------
+// skillbox context delete
+// --------------------------------------------------------------------
+
+func newContextDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a context and its stored credentials",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := cli.LoadConfig()
+			if err != nil {
+				return err
+			}
+			if !cfg.Delete(args[0]) {
+				return &exitError{code: exitNotFound, err: fmt.Errorf("context %q not found", args[0])}
+			}
+			if err := cli.SaveConfig(cfg); err != nil {
+				return err
+			}
+			fmt.Printf("Deleted context %s\n", args[0])
+			return nil
+		},
+	}
+}
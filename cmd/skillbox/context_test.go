@@ -0,0 +1,145 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/devs-group/skillbox/internal/cli"
+)
+
+func TestApplyContext_Precedence(t *testing.T) {
+	contexts := &cli.Config{
+		CurrentContext: "prod",
+		Contexts: []cli.Context{
+			{Name: "prod", Server: "https://prod.example.com", Auth: cli.AuthAPIKey, APIKey: "sk-prod", Tenant: "acme"},
+			{Name: "dev", Server: "https://dev.example.com", Auth: cli.AuthDeviceAuth, Tenant: "dev-team"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		config     *cli.Config
+		env        map[string]string
+		args       []string
+		wantServer string
+		wantAPIKey string
+		wantTenant string
+		wantActive string // "" = no active context
+		wantCode   int    // exit code of the error, 0 = no error
+	}{
+		{
+			name:       "no contexts",
+			config:     &cli.Config{},
+			wantServer: "http://localhost:8080",
+		},
+		{
+			name:       "current context",
+			config:     contexts,
+			wantServer: "https://prod.example.com",
+			wantAPIKey: "sk-prod",
+			wantTenant: "acme",
+			wantActive: "prod",
+		},
+		{
+			name:       "flags override the context",
+			config:     contexts,
+			args:       []string{"--server", "https://flag.example.com", "--api-key", "sk-flag", "--tenant", "flag-tenant"},
+			wantServer: "https://flag.example.com",
+			wantAPIKey: "sk-flag",
+			wantTenant: "flag-tenant",
+			wantActive: "prod",
+		},
+		{
+			name:       "environment overrides the context",
+			config:     contexts,
+			env:        map[string]string{"SKILLBOX_SERVER_URL": "https://env.example.com", "SKILLBOX_API_KEY": "sk-env"},
+			wantServer: "https://env.example.com",
+			wantAPIKey: "sk-env",
+			wantTenant: "acme",
+			wantActive: "prod",
+		},
+		{
+			name:       "flag overrides the environment",
+			config:     contexts,
+			env:        map[string]string{"SKILLBOX_SERVER_URL": "https://env.example.com"},
+			args:       []string{"--server", "https://flag.example.com"},
+			wantServer: "https://flag.example.com",
+			wantAPIKey: "sk-prod",
+			wantTenant: "acme",
+			wantActive: "prod",
+		},
+		{
+			name:       "--context selects another context",
+			config:     contexts,
+			args:       []string{"--context", "dev"},
+			wantServer: "https://dev.example.com",
+			wantTenant: "dev-team",
+			wantActive: "dev",
+		},
+		{
+			name:       "SKILLBOX_CONTEXT selects another context",
+			config:     contexts,
+			env:        map[string]string{"SKILLBOX_CONTEXT": "dev"},
+			wantServer: "https://dev.example.com",
+			wantTenant: "dev-team",
+			wantActive: "dev",
+		},
+		{
+			name:       "device-auth context keeps an explicit API key",
+			config:     contexts,
+			env:        map[string]string{"SKILLBOX_CONTEXT": "dev", "SKILLBOX_API_KEY": "sk-env"},
+			wantServer: "https://dev.example.com",
+			wantAPIKey: "sk-env",
+			wantTenant: "dev-team",
+			wantActive: "dev",
+		},
+		{
+			name:     "unknown context",
+			config:   contexts,
+			args:     []string{"--context", "staging"},
+			wantCode: exitNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			for _, k := range []string{"SKILLBOX_SERVER_URL", "SKILLBOX_API_KEY", "SKILLBOX_TENANT_ID", "SKILLBOX_CONTEXT"} {
+				t.Setenv(k, tt.env[k])
+			}
+			if err := cli.SaveConfig(tt.config); err != nil {
+				t.Fatal(err)
+			}
+			activeConfig, activeContext = nil, nil
+
+			// Flag defaults are read from the environment when the root
+			// command is built.
+			root := newRootCmd()
+			if err := root.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags: %v", err)
+			}
+			err := applyContext(root)
+
+			if tt.wantCode != 0 {
+				var exitErr *exitError
+				if !errors.As(err, &exitErr) || exitErr.code != tt.wantCode {
+					t.Fatalf("applyContext = %v, want exit code %d", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyContext: %v", err)
+			}
+			if flagServer != tt.wantServer || flagAPIKey != tt.wantAPIKey || flagTenant != tt.wantTenant {
+				t.Errorf("server, api key, tenant = %q, %q, %q; want %q, %q, %q",
+					flagServer, flagAPIKey, flagTenant, tt.wantServer, tt.wantAPIKey, tt.wantTenant)
+			}
+			active := ""
+			if activeContext != nil {
+				active = activeContext.Name
+			}
+			if active != tt.wantActive {
+				t.Errorf("active context = %q, want %q", active, tt.wantActive)
+			}
+		})
+	}
+}
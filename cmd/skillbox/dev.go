@@ -0,0 +1,429 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"net/url"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/devs-group/skillbox/internal/sandboxenv"
+	"github.com/devs-group/skillbox/internal/skill"
+	skillbox "github.com/devs-group/skillbox/sdks/go"
+)
+
+// --------------------------------------------------------------------
+// skillbox dev
+// --------------------------------------------------------------------
+
+// devPollInterval is how often `skillbox dev` checks the skill directory
+// for changes.
+const devPollInterval = 500 * time.Millisecond
+
+// devOptions holds the flags of `skillbox dev`.
+type devOptions struct {
+	input      json.RawMessage
+	env        map[string]string
+	entrypoint string
+	timeout    time.Duration
+	download   string
+	files      map[string][]byte // input files, relative to the input directory
+}
+
+func newDevCmd() *cobra.Command {
+	var (
+		input      string
+		envVars    []string
+		inputFiles []string
+		entrypoint string
+		timeout    time.Duration
+		download   string
+		once       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "dev <dir>",
+		Short: "Run a skill directory locally and re-run it on every change",
+		Long: `Execute a skill directory on this machine without a server.
+
+The skill is copied into a local sandbox tree with the same layout as an
+execution sandbox (scripts/, input.json, input/, out/output.json and
+out/files/), and runs with the same SANDBOX_* environment, env var
+filtering and entrypoint selection as the server. The result is printed
+exactly like "skillbox run"; files_url points at the local output
+directory. Paths are local, so skills must locate them through the
+SANDBOX_* variables rather than hard-coding /sandbox.
+
+The skill runs again whenever a file in the directory changes. Press
+Ctrl-C to stop, or pass --once to run a single time.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := devOptions{
+				entrypoint: entrypoint,
+				timeout:    timeout,
+				download:   download,
+			}
+			if input != "" {
+				if !json.Valid([]byte(input)) {
+					return fmt.Errorf("invalid --input: not valid JSON")
+				}
+				opts.input = json.RawMessage(input)
+			}
+			if len(envVars) > 0 {
+				opts.env = make(map[string]string, len(envVars))
+				for _, kv := range envVars {
+					parts := strings.SplitN(kv, "=", 2)
+					if len(parts) != 2 {
+						return fmt.Errorf("invalid --env value %q: must be KEY=VALUE", kv)
+					}
+					opts.env[parts[0]] = parts[1]
+				}
+			}
+			if len(inputFiles) > 0 {
+				opts.files = make(map[string][]byte, len(inputFiles))
+				for _, p := range inputFiles {
+					data, err := os.ReadFile(p)
+					if err != nil {
+						return fmt.Errorf("read --file: %w", err)
+					}
+					opts.files[filepath.Base(p)] = data
+				}
+			}
+
+			dir, err := filepath.Abs(args[0])
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stat(filepath.Join(dir, "SKILL.md")); os.IsNotExist(err) {
+				return fmt.Errorf("SKILL.md not found in %s", args[0])
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			if once {
+				return devRunOnce(ctx, dir, opts, 1)
+			}
+			return devWatch(ctx, dir, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&input, "input", "", "JSON input payload")
+	cmd.Flags().StringArrayVar(&envVars, "env", nil, "Environment variables as KEY=VALUE (repeatable)")
+	cmd.Flags().StringArrayVar(&inputFiles, "file", nil, "File to place in the input directory under its base name (repeatable)")
+	cmd.Flags().StringVar(&entrypoint, "entrypoint", "", "Entrypoint relative to the skill directory (default: auto-detect)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Execution timeout (default: the skill's timeout, or 2m)")
+	cmd.Flags().StringVar(&download, "download", "", "Directory to copy output files to after each run")
+	cmd.Flags().BoolVar(&once, "once", false, "Run once and exit instead of watching for changes")
+
+	return cmd
+}
+
+// devRunOnce executes the skill in dir a single time and prints the result.
+// The local sandbox tree is removed before returning.
+func devRunOnce(ctx context.Context, dir string, opts devOptions, n int) error {
+	root, err := os.MkdirTemp("", "skillbox-dev-*")
+	if err != nil {
+		return fmt.Errorf("create sandbox directory: %w", err)
+	}
+	defer os.RemoveAll(root) //nolint:errcheck
+
+	return devRunAndPrint(ctx, dir, root, opts, n)
+}
+
+// devWatch runs the skill in dir, then polls the directory and runs it
+// again after every change until ctx is cancelled. A run's local sandbox
+// tree is kept until the next run starts, so its files_url stays valid.
+func devWatch(ctx context.Context, dir string, opts devOptions) error {
+	var root string
+	defer func() {
+		if root != "" {
+			os.RemoveAll(root) //nolint:errcheck
+		}
+	}()
+
+	state := dirState(dir)
+	for n := 1; ; n++ {
+		if root != "" {
+			os.RemoveAll(root) //nolint:errcheck
+		}
+		var err error
+		root, err = os.MkdirTemp("", "skillbox-dev-*")
+		if err != nil {
+			return fmt.Errorf("create sandbox directory: %w", err)
+		}
+
+		if err := devRunAndPrint(ctx, dir, root, opts, n); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err) //nolint:errcheck
+		}
+		fmt.Fprintf(os.Stderr, "Watching %s for changes (Ctrl-C to stop)...\n", dir) //nolint:errcheck
+
+		ticker := time.NewTicker(devPollInterval)
+		for changed := false; !changed; {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return nil
+			case <-ticker.C:
+				next := dirState(dir)
+				if !maps.Equal(state, next) {
+					state, changed = next, true
+				}
+			}
+		}
+		ticker.Stop()
+		fmt.Fprintln(os.Stderr, "Change detected, re-running...") //nolint:errcheck
+	}
+}
+
+// fileStamp identifies a version of a file for change detection.
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// dirState records the size and modification time of every file under
+// dir. Unreadable entries are skipped; they reappear once readable.
+func dirState(dir string) map[string]fileStamp {
+	state := make(map[string]fileStamp)
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		state[path] = fileStamp{size: info.Size(), modTime: info.ModTime()}
+		return nil
+	})
+	return state
+}
+
+// devRunAndPrint runs the skill and prints the result like `skillbox run`.
+func devRunAndPrint(ctx context.Context, dir, root string, opts devOptions, n int) error {
+	layout := sandboxenv.Layout{
+		Root: filepath.Join(root, "sandbox"),
+		Home: filepath.Join(root, "home"),
+	}
+	result, err := devRun(ctx, dir, layout, opts)
+	if err != nil {
+		return err
+	}
+	result.ExecutionID = fmt.Sprintf("dev-%d", n)
+
+	if err := printJSON(result); err != nil {
+		return err
+	}
+
+	if opts.download != "" && result.HasFiles() {
+		fmt.Fprintf(os.Stderr, "Copying files to %s...\n", opts.download) //nolint:errcheck
+		if err := copyTree(layout.FilesDir(), opts.download); err != nil {
+			return fmt.Errorf("download files: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Files downloaded to %s\n", opts.download) //nolint:errcheck
+	}
+	return nil
+}
+
+// devRun executes the skill in dir inside the local sandbox tree l,
+// mirroring the runner: the skill is copied to the scripts directory, the
+// entrypoint is resolved (or the code-runner entrypoint generated), and the
+// command runs from the sandbox root with the contract environment.
+func devRun(ctx context.Context, dir string, l sandboxenv.Layout, opts devOptions) (*skillbox.RunResult, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	if err != nil {
+		return nil, fmt.Errorf("read SKILL.md: %w", err)
+	}
+	sk, err := skill.ParseSkillMD(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse SKILL.md: %w", err)
+	}
+
+	input := opts.input
+	if input == nil {
+		input = json.RawMessage("{}")
+	}
+	if err := copyTree(dir, l.ScriptsDir()); err != nil {
+		return nil, fmt.Errorf("copy skill files: %w", err)
+	}
+	for _, d := range []string{l.InputDir(), l.FilesDir(), l.Home} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.WriteFile(l.InputFile(), input, 0o644); err != nil {
+		return nil, err
+	}
+	for name, content := range opts.files {
+		p := filepath.Join(l.InputDir(), filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(p, content, 0o644); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &skillbox.RunResult{Status: "failed"}
+	env, err := sandboxenv.Env(l, input, sk.Instructions, opts.env)
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+
+	lang := sk.Lang
+	entrypoint := opts.entrypoint
+	if entrypoint == "" {
+		entrypoint, _ = sandboxenv.FindEntrypoint(l.ScriptsDir())
+	}
+	if entrypoint == "" {
+		if err := os.WriteFile(filepath.Join(l.ScriptsDir(), "main.py"), sandboxenv.CodeRunnerEntrypoint(), 0o755); err != nil {
+			return nil, fmt.Errorf("write generated entrypoint: %w", err)
+		}
+		entrypoint = "main.py"
+		if lang == "" {
+			lang = skill.LangPython
+		}
+	}
+	if lang == "" {
+		lang = skill.InferLangFromEntrypoint(entrypoint)
+	}
+	_, reqErr := os.Stat(filepath.Join(l.ScriptsDir(), "requirements.txt"))
+	shellCmd := sandboxenv.ShellCommand(l, lang, entrypoint, reqErr == nil)
+
+	timeout := opts.timeout
+	if timeout <= 0 {
+		timeout = sk.Timeout
+	}
+	if timeout <= 0 {
+		timeout = 2 * time.Minute
+	}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr strings.Builder
+	c := exec.CommandContext(runCtx, "sh", "-c", shellCmd)
+	c.Dir = l.Root
+	c.Env = []string{"PATH=" + os.Getenv("PATH")}
+	for k, v := range env {
+		c.Env = append(c.Env, k+"="+v)
+	}
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	c.WaitDelay = 2 * time.Second
+
+	start := time.Now()
+	runErr := c.Run()
+	result.DurationMs = time.Since(start).Milliseconds()
+
+	result.Logs = stdout.String()
+	if stderr.Len() > 0 {
+		if len(result.Logs) > 0 {
+			result.Logs += "\n"
+		}
+		result.Logs += stderr.String()
+	}
+
+	if out, err := os.ReadFile(l.OutputFile()); err == nil && json.Valid(out) {
+		result.Output = json.RawMessage(out)
+	}
+	files, err := listTree(l.FilesDir())
+	if err != nil {
+		return nil, fmt.Errorf("list output files: %w", err)
+	}
+	if len(files) > 0 {
+		result.FilesURL = (&url.URL{Scheme: "file", Path: filepath.ToSlash(l.FilesDir())}).String()
+		result.FilesList = files
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case runCtx.Err() == context.DeadlineExceeded:
+		result.Status = "timeout"
+		result.Error = fmt.Sprintf("execution timed out after %s", timeout)
+	case ctx.Err() != nil:
+		return nil, ctx.Err()
+	case runErr == nil:
+		result.Status = "success"
+	case errors.As(runErr, &exitErr):
+		result.Error = fmt.Sprintf("command exited with code %d", exitErr.ExitCode())
+	default:
+		return nil, fmt.Errorf("run %q: %w", shellCmd, runErr)
+	}
+	return result, nil
+}
+
+// listTree returns the slash-separated paths of the regular files under
+// dir, relative to dir.
+func listTree(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	return files, err
+}
+
+// copyTree copies the regular files under src to dst, preserving their
+// relative paths and permissions.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close() //nolint:errcheck
+		out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close() //nolint:errcheck
+			return err
+		}
+		return out.Close()
+	})
+}
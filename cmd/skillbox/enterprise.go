@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -31,8 +32,24 @@ func newLoginCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "login",
 		Short: "Authenticate with the Skillbox registry via device authorization",
-		Args:  cobra.NoArgs,
+		Long: `Authenticate via device authorization. With a device-auth context in
+use, the tokens are stored in that context and its Hydra URL and client
+ID are used unless given as flags; otherwise they are stored in
+~/.config/skillbox/credentials.json.`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if activeContext != nil {
+				if activeContext.Auth != cli.AuthDeviceAuth {
+					return usageError(fmt.Errorf("context %q authenticates with an API key", activeContext.Name))
+				}
+				if !cmd.Flags().Changed("hydra-url") && activeContext.HydraURL != "" {
+					hydraURL = activeContext.HydraURL
+				}
+				if !cmd.Flags().Changed("client-id") && activeContext.ClientID != "" {
+					clientID = activeContext.ClientID
+				}
+			}
+
 			// 1. Start device auth flow.
 			dar, err := cli.StartDeviceAuth(hydraURL, clientID)
 			if err != nil {
@@ -80,8 +97,19 @@ func newLoginCmd() *cobra.Command {
 				ExpiresAt:    time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second),
 				Email:        userInfo.Email,
 				TenantID:     userInfo.TenantID,
+				HydraURL:     hydraURL,
+				ClientID:     clientID,
 			}
-			if err := cli.SaveCredentials(creds); err != nil {
+			if activeContext != nil {
+				activeContext.Credentials = creds
+				if activeContext.Tenant == "" {
+					activeContext.Tenant = creds.TenantID
+				}
+				err = cli.SaveConfig(activeConfig)
+			} else {
+				err = cli.SaveCredentials(creds)
+			}
+			if err != nil {
 				return err
 			}
 
@@ -92,7 +120,7 @@ func newLoginCmd() *cobra.Command {
 
 	cmd.Flags().StringVar(&inviteCode, "invite", "", "Invitation code to redeem")
 	cmd.Flags().StringVar(&hydraURL, "hydra-url", envOrDefault("SKILLBOX_HYDRA_URL", "http://localhost:4444"), "Hydra public URL")
-	cmd.Flags().StringVar(&clientID, "client-id", "skillbox-cli", "OAuth2 client ID")
+	cmd.Flags().StringVar(&clientID, "client-id", cli.DefaultClientID, "OAuth2 client ID")
 
 	return cmd
 }
@@ -105,9 +133,16 @@ func newLogoutCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "logout",
 		Short: "Clear stored credentials",
-		Args:  cobra.NoArgs,
+		Long: `Clear the device-auth tokens of the context in use, or
+~/.config/skillbox/credentials.json when no context is in use.`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := cli.ClearCredentials(); err != nil {
+			if activeContext != nil {
+				activeContext.Credentials = nil
+				if err := cli.SaveConfig(activeConfig); err != nil {
+					return err
+				}
+			} else if err := cli.ClearCredentials(); err != nil {
 				return err
 			}
 			fmt.Println("Logged out successfully")
@@ -130,77 +165,60 @@ func newAddCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "add <skill_name>",
 		Short: "Install a skill from the registry",
-		Args:  cobra.ExactArgs(1),
+		Long: `Download a skill version from the marketplace, extract it into
+.claude/skills/<name> (or ~/.claude/skills/<name> with --global) and
+record its version and sha256 integrity in skill-lock.json.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			skillName := args[0]
+			if err := validateSkillName(skillName); err != nil {
+				return err
+			}
 
 			// 1. Ensure the user is logged in.
-			creds, err := cli.LoadCredentials()
+			creds, err := loadCredentials()
 			if err != nil {
 				return fmt.Errorf("not logged in: %w — run `skillbox login` first", err)
 			}
 
 			// 2. Check if already installed (unless --force).
-			if !force && cli.IsInstalled(skillName) {
+			if !force && cli.IsInstalled(skillName, global) {
 				return fmt.Errorf("skill %q is already installed — use --force to reinstall", skillName)
 			}
 
-			// 3. Fetch skill metadata from marketplace.
-			skillMeta, err := fetchSkillMeta(flagServer, creds.AccessToken, skillName)
+			// 3. Fetch the metadata of the version to install, the latest
+			// without --version.
+			skillMeta, err := fetchSkillMeta(flagServer, creds.AccessToken, skillName, version)
 			if err != nil {
 				return fmt.Errorf("fetch skill metadata: %w", err)
 			}
 
-			// 4. Check approval status.
+			// 4. Check approval status; approvals are per version.
 			if !skillMeta.Approved {
-				if err := requestApproval(flagServer, creds.AccessToken, skillName); err != nil {
+				if err := requestApproval(flagServer, creds.AccessToken, skillName, skillMeta.Version); err != nil {
 					return fmt.Errorf("request approval: %w", err)
 				}
-				fmt.Printf("Approval requested. Run `skillbox add %s` again after admin approves.\n", skillName)
+				fmt.Printf("Approval of %s@%s requested. Run `skillbox add %s` again after admin approves.\n", skillName, skillMeta.Version, skillName)
 				return nil
 			}
 
-			// 5. Install the skill (placeholder SKILL.md for now).
-			installPath := cli.InstallPath(skillName, global)
-			installDir := filepath.Dir(installPath)
-			if err := os.MkdirAll(installDir, 0700); err != nil {
-				return fmt.Errorf("create skill directory: %w", err)
-			}
-
-			// Write a placeholder SKILL.md.
-			useVersion := version
-			if useVersion == "" {
-				useVersion = skillMeta.Version
-			}
-			content := fmt.Sprintf("---\nname: %s\nversion: %s\ndescription: %s\n---\n\n# %s\n\nInstalled from registry.\n",
-				skillName, useVersion, skillMeta.Description, skillName)
-
-			if err := os.WriteFile(installPath, []byte(content), 0600); err != nil {
-				return fmt.Errorf("write SKILL.md: %w", err)
-			}
-
-			// 6. Update lock file.
-			scope := "project"
-			if global {
-				scope = "global"
+			// 5. Download, verify and extract the approved version, then
+			// record it.
+			installed, err := installSkill(creds.AccessToken, skillName, skillMeta.Version, "", global)
+			if err != nil {
+				return err
 			}
-			if err := cli.AddToLockFile(cli.InstalledSkill{
-				Name:        skillName,
-				Version:     useVersion,
-				Provider:    skillMeta.Provider,
-				Scope:       scope,
-				Path:        installPath,
-				InstalledAt: time.Now().UTC(),
-			}); err != nil {
+			installed.Provider = skillMeta.Provider
+			if err := cli.AddToLockFile(*installed); err != nil {
 				return fmt.Errorf("update lock file: %w", err)
 			}
 
-			fmt.Printf("Installed %s to %s\n", skillName, installPath)
+			fmt.Printf("Installed %s@%s to %s\n", skillName, installed.Version, filepath.Dir(installed.Path))
 			return nil
 		},
 	}
 
-	cmd.Flags().BoolVar(&global, "global", false, "Install skill globally (~/.claude/skills/)")
+	cmd.Flags().BoolVar(&global, "global", false, "Install skill globally (~/.claude/skills/, ~/.config/skillbox/skill-lock.json)")
 	cmd.Flags().BoolVar(&force, "force", false, "Reinstall even if already installed")
 	cmd.Flags().StringVar(&version, "version", "", "Skill version to install")
 
@@ -214,26 +232,29 @@ func newAddCmd() *cobra.Command {
 func newListInstalledCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "list",
-		Short: "List locally installed skills",
+		Short: "List locally installed skills (project and global)",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			lf, err := cli.LoadLockFile()
-			if err != nil {
-				return err
+			var skills []cli.InstalledSkill
+			for _, global := range []bool{false, true} {
+				lf, err := cli.LoadLockFile(global)
+				if err != nil {
+					return err
+				}
+				skills = append(skills, lf.Skills...)
 			}
 
-			if len(lf.Skills) == 0 {
+			if structuredOutput() {
+				return printStructured(skills)
+			}
+			if len(skills) == 0 {
 				fmt.Println("No skills installed.")
 				return nil
 			}
 
-			if flagOutput == "json" {
-				return printJSON(lf.Skills)
-			}
-
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 			fmt.Fprintln(w, "NAME\tVERSION\tPROVIDER\tSCOPE\tINSTALLED_AT") //nolint:errcheck
-			for _, s := range lf.Skills {
+			for _, s := range skills {
 				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", //nolint:errcheck
 					s.Name, s.Version, s.Provider, s.Scope, s.InstalledAt.Format(time.RFC3339))
 			}
@@ -247,38 +268,39 @@ func newListInstalledCmd() *cobra.Command {
 // --------------------------------------------------------------------
 
 func newRemoveCmd() *cobra.Command {
-	return &cobra.Command{
+	var global bool
+
+	cmd := &cobra.Command{
 		Use:   "remove <skill_name>",
 		Short: "Remove an installed skill",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			skillName := args[0]
+			if err := validateSkillName(skillName); err != nil {
+				return err
+			}
 
-			lf, err := cli.LoadLockFile()
+			lf, err := cli.LoadLockFile(global)
 			if err != nil {
 				return err
 			}
 
 			// Find the skill in the lock file.
-			var found *cli.InstalledSkill
-			for i := range lf.Skills {
-				if lf.Skills[i].Name == skillName {
-					found = &lf.Skills[i]
-					break
-				}
-			}
+			found := lf.Find(skillName)
 			if found == nil {
-				return fmt.Errorf("skill %q is not installed", skillName)
+				return &exitError{code: exitNotFound, err: fmt.Errorf("skill %q is not installed (%s scope)", skillName, cli.Scope(global))}
 			}
 
-			// Remove the skill directory.
-			skillDir := filepath.Dir(found.Path)
+			// Remove the skill directory. It is derived from the name,
+			// not the path recorded in the lock file, so an edited lock
+			// file cannot point it elsewhere.
+			skillDir := filepath.Dir(cli.InstallPath(skillName, global))
 			if err := os.RemoveAll(skillDir); err != nil {
 				return fmt.Errorf("remove skill directory: %w", err)
 			}
 
 			// Update lock file.
-			if err := cli.RemoveFromLockFile(skillName); err != nil {
+			if err := cli.RemoveFromLockFile(skillName, global); err != nil {
 				return fmt.Errorf("update lock file: %w", err)
 			}
 
@@ -286,6 +308,9 @@ func newRemoveCmd() *cobra.Command {
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&global, "global", false, "Remove a globally installed skill")
+	return cmd
 }
 
 // --------------------------------------------------------------------
@@ -302,7 +327,7 @@ type skillMetaResponse struct {
 	Version     string `json:"version"`
 	Description string `json:"description"`
 	Provider    string `json:"provider"`
-	Approved    bool   `json:"approved"`
+	Approved    bool   `json:"is_approved"`
 }
 
 func fetchUserMe(serverURL, token string) (*userMeResponse, int, error) {
@@ -358,9 +383,15 @@ func redeemInvite(serverURL, token, inviteCode string) error {
 	return nil
 }
 
-func fetchSkillMeta(serverURL, token, skillName string) (*skillMetaResponse, error) {
-	url := strings.TrimRight(serverURL, "/") + "/v1/marketplace/skills/" + skillName
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+// fetchSkillMeta returns the marketplace metadata of a skill version, the
+// latest if version is empty, including whether it is approved for the
+// caller's tenant.
+func fetchSkillMeta(serverURL, token, skillName, version string) (*skillMetaResponse, error) {
+	u := strings.TrimRight(serverURL, "/") + "/v1/marketplace/skills/" + url.PathEscape(skillName)
+	if version != "" {
+		u += "?version=" + url.QueryEscape(version)
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -432,8 +463,8 @@ func newSearchCmd() *cobra.Command {
 				return fmt.Errorf("failed to parse response: %w", err)
 			}
 
-			if flagOutput == "json" {
-				return printJSON(result)
+			if structuredOutput() {
+				return printStructured(result)
 			}
 
 			// Table output.
@@ -459,10 +490,15 @@ func newSearchCmd() *cobra.Command {
 	return cmd
 }
 
-func requestApproval(serverURL, token, skillName string) error {
-	url := strings.TrimRight(serverURL, "/") + "/v1/approvals"
-	payload := fmt.Sprintf(`{"skill_name":%q}`, skillName)
-	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(payload))
+// requestApproval asks the tenant's admins to approve one version of a
+// skill.
+func requestApproval(serverURL, token, skillName, version string) error {
+	u := strings.TrimRight(serverURL, "/") + "/v1/approvals"
+	payload, err := json.Marshal(map[string]string{"skill_name": skillName, "skill_version": version})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(payload))
 	if err != nil {
 		return err
 	}
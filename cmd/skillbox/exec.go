@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	skillbox "github.com/devs-group/skillbox/sdks/go"
+)
+
+// executionFailed returns an error exiting with exitFailed when status is
+// a terminal state other than success.
+func executionFailed(id, status, msg string) error {
+	if status == "success" || status == "running" {
+		return nil
+	}
+	if msg == "" {
+		return &exitError{code: exitFailed, err: fmt.Errorf("execution %s %s", id, status)}
+	}
+	return &exitError{code: exitFailed, err: fmt.Errorf("execution %s %s: %s", id, status, msg)}
+}
+
+// --------------------------------------------------------------------
+// skillbox exec list
+// --------------------------------------------------------------------
+
+func newExecListCmd() *cobra.Command {
+	var filter skillbox.ExecutionFilter
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List recent executions, newest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			execs, err := client.ListExecutions(ctx, filter)
+			if err != nil {
+				return err
+			}
+
+			return printOutput(execs, func(w io.Writer) {
+				fmt.Fprintln(w, "ID\tSKILL\tVERSION\tSTATUS\tDURATION\tCREATED") //nolint:errcheck
+				for _, e := range execs {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%dms\t%s\n", //nolint:errcheck
+						e.ExecutionID, e.SkillName, e.SkillVersion, e.Status, e.DurationMs, e.CreatedAt)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&filter.Skill, "skill", "", "Only executions of this skill")
+	cmd.Flags().StringVar(&filter.Status, "status", "", "Only executions in this status: running, success, failed, timeout, cancelled")
+	cmd.Flags().IntVar(&filter.Limit, "limit", 20, "Maximum number of executions (up to 100)")
+	cmd.Flags().IntVar(&filter.Offset, "offset", 0, "Number of executions to skip")
+
+	return cmd
+}
+
+// --------------------------------------------------------------------
+// skillbox exec get
+// --------------------------------------------------------------------
+
+func newExecGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <execution-id>",
+		Short: "Show the status and result of an execution",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			result, err := client.GetExecution(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			return printOutput(result, func(w io.Writer) {
+				fmt.Fprintf(w, "ID:\t%s\n", result.ExecutionID)        //nolint:errcheck
+				fmt.Fprintf(w, "STATUS:\t%s\n", result.Status)         //nolint:errcheck
+				fmt.Fprintf(w, "DURATION:\t%dms\n", result.DurationMs) //nolint:errcheck
+				if result.Error != "" {
+					fmt.Fprintf(w, "ERROR:\t%s\n", result.Error) //nolint:errcheck
+				}
+				if len(result.Output) > 0 && string(result.Output) != "null" {
+					fmt.Fprintf(w, "OUTPUT:\t%s\n", result.Output) //nolint:errcheck
+				}
+				if len(result.FilesList) > 0 {
+					fmt.Fprintf(w, "FILES:\t%s\n", strings.Join(result.FilesList, ", ")) //nolint:errcheck
+				}
+			})
+		},
+	}
+}
+
+// --------------------------------------------------------------------
+// skillbox exec cancel
+// --------------------------------------------------------------------
+
+func newExecCancelCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel <execution-id>",
+		Short: "Stop a running execution",
+		Long: `Stop a running execution. It finishes with status "cancelled" shortly
+after. Exits with code 5 when the execution is not running.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			if err := client.CancelExecution(ctx, args[0]); err != nil {
+				return err
+			}
+
+			if structuredOutput() {
+				return printStructured(map[string]string{"execution_id": args[0], "status": "cancelling"})
+			}
+			fmt.Printf("Cancelling execution %s\n", args[0])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	skillbox "github.com/devs-group/skillbox/sdks/go"
+)
+
+// --------------------------------------------------------------------
+// skillbox file (parent)
+// --------------------------------------------------------------------
+
+func newFileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "file",
+		Short: "Manage stored files: upload, download, list, versions, delete",
+	}
+
+	cmd.AddCommand(
+		newFileUploadCmd(),
+		newFileDownloadCmd(),
+		newFileListCmd(),
+		newFileVersionsCmd(),
+		newFileDeleteCmd(),
+	)
+
+	return cmd
+}
+
+// printFiles writes file records as JSON, YAML or a table.
+func printFiles(files []skillbox.FileInfo) error {
+	return printOutput(files, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tNAME\tVERSION\tSIZE\tSESSION\tEXECUTION\tCREATED") //nolint:errcheck
+		for _, f := range files {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\t%s\n", //nolint:errcheck
+				f.ID, f.Name, f.Version, f.SizeBytes, f.SessionID, f.ExecutionID, f.CreatedAt)
+		}
+	})
+}
+
+// --------------------------------------------------------------------
+// skillbox file upload
+// --------------------------------------------------------------------
+
+func newFileUploadCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "upload <path>",
+		Short: "Upload a file and print its record",
+		Long: `Upload a local file. Pass the printed ID to "skillbox run" input files
+or to "skillbox file download".`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			info, err := client.UploadFile(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			return printFiles([]skillbox.FileInfo{*info})
+		},
+	}
+}
+
+// --------------------------------------------------------------------
+// skillbox file download
+// --------------------------------------------------------------------
+
+func newFileDownloadCmd() *cobra.Command {
+	var dest string
+
+	cmd := &cobra.Command{
+		Use:   "download <file-id>",
+		Short: "Download the content of a file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			if dest == "" {
+				info, err := client.GetFile(ctx, args[0])
+				if err != nil {
+					return err
+				}
+				dest = info.Name
+			}
+			if err := client.DownloadFile(ctx, args[0], dest); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Downloaded %s to %s\n", args[0], dest) //nolint:errcheck
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&dest, "dest", "O", "", "Destination path (default: the file's name in the current directory)")
+	return cmd
+}
+
+// --------------------------------------------------------------------
+// skillbox file list
+// --------------------------------------------------------------------
+
+func newFileListCmd() *cobra.Command {
+	var filter skillbox.FileFilter
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List stored files, newest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			files, err := client.ListFiles(ctx, filter)
+			if err != nil {
+				return err
+			}
+			return printFiles(files)
+		},
+	}
+
+	cmd.Flags().StringVar(&filter.SessionID, "session", "", "Only files of this session")
+	cmd.Flags().StringVar(&filter.ExecutionID, "execution", "", "Only files produced by this execution")
+	cmd.Flags().IntVar(&filter.Limit, "limit", 50, "Maximum number of files")
+	cmd.Flags().IntVar(&filter.Offset, "offset", 0, "Number of files to skip")
+
+	return cmd
+}
+
+// --------------------------------------------------------------------
+// skillbox file versions
+// --------------------------------------------------------------------
+
+func newFileVersionsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "versions <file-id>",
+		Short: "List the versions of a file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			files, err := client.ListFileVersions(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			return printFiles(files)
+		},
+	}
+}
+
+// --------------------------------------------------------------------
+// skillbox file delete
+// --------------------------------------------------------------------
+
+func newFileDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <file-id>",
+		Short: "Delete a file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			if err := client.DeleteFile(ctx, args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Deleted file %s\n", args[0])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/devs-group/skillbox/internal/scaffold"
+)
+
+// --------------------------------------------------------------------
+// skillbox init
+// --------------------------------------------------------------------
+
+func newInitCmd() *cobra.Command {
+	var (
+		lang        string
+		mode        string
+		template    string
+		description string
+		dir         string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "init <name>",
+		Short: "Create a new skill directory from a template",
+		Long: `Generate a complete skill directory: SKILL.md, an entrypoint that reads
+the input and writes output.json, a dependency manifest, sample test
+cases under tests/ and a README.
+
+--template selects a built-in template (executable: ` + strings.Join(scaffold.Templates(scaffold.ModeExecutable), ", ") + `;
+cognitive: ` + strings.Join(scaffold.Templates(scaffold.ModeCognitive), ", ") + `). Any other name is fetched from the server: a
+skill pushed with "kind: template" in its SKILL.md, optionally pinned as
+name@version. A registry template defines its own language and mode.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if dir == "" {
+				dir = name
+			}
+
+			var files map[string][]byte
+			var err error
+			if template == "" || scaffold.IsBuiltin(template) {
+				files, err = scaffold.Generate(scaffold.Options{
+					Name:        name,
+					Description: description,
+					Lang:        lang,
+					Mode:        mode,
+					Template:    template,
+				})
+			} else {
+				if cmd.Flags().Changed("lang") || cmd.Flags().Changed("mode") {
+					return fmt.Errorf("--lang and --mode cannot be used with registry template %q", template)
+				}
+				files, err = fetchTemplate(template, name)
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := scaffold.Write(dir, files); err != nil {
+				return err
+			}
+
+			paths := make([]string, 0, len(files))
+			for p := range files {
+				paths = append(paths, p)
+			}
+			sort.Strings(paths)
+			fmt.Printf("Created %s in %s\n", name, dir)
+			for _, p := range paths {
+				fmt.Printf("  %s\n", filepath.ToSlash(p))
+			}
+			fmt.Printf("\nNext: skillbox dev %s, then skillbox skill test %s\n", dir, dir)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&lang, "lang", "python", "Language: python, node, bash")
+	cmd.Flags().StringVar(&mode, "mode", "executable", "Mode: executable, cognitive")
+	cmd.Flags().StringVar(&template, "template", "", "Built-in template or registry template name[@version] (default: basic)")
+	cmd.Flags().StringVar(&description, "description", "", "Skill description for SKILL.md")
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory to create (default: ./<name>)")
+
+	return cmd
+}
+
+// fetchTemplate downloads a template package from the server and turns it
+// into the files of a new skill called name.
+func fetchTemplate(ref, name string) (map[string][]byte, error) {
+	tplName, version, ok := strings.Cut(ref, "@")
+	if !ok {
+		version = "latest"
+	}
+
+	client := newClient()
+	ctx, cancel := contextWithTimeout()
+	defer cancel()
+
+	entries, err := client.GetSkillFiles(ctx, tplName, version)
+	if err != nil {
+		return nil, fmt.Errorf("fetch template %s: %w", ref, err)
+	}
+	files := make(map[string][]byte, len(entries))
+	for _, e := range entries {
+		files[e.Path] = []byte(e.Content)
+	}
+	return scaffold.FromTemplate(files, name)
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/devs-group/skillbox/internal/cli"
+	"github.com/devs-group/skillbox/internal/skill"
+)
+
+// installSkill downloads a version of a marketplace skill (the latest if
+// version is empty), checks the archive against the digest the server
+// sent and, when integrity is set, against the lock file, and extracts it
+// into the install directory of the scope. Provider is left for the
+// caller to fill in.
+func installSkill(token, name, version, integrity string, global bool) (*cli.InstalledSkill, error) {
+	if err := validateSkillName(name); err != nil {
+		return nil, err
+	}
+	data, served, digest, err := fetchSkillArchive(flagServer, token, name, version)
+	if err != nil {
+		return nil, fmt.Errorf("download %s: %w", name, err)
+	}
+	if digest != "" {
+		if err := cli.VerifyDigest(data, digest); err != nil {
+			return nil, fmt.Errorf("download %s: %w", name, err)
+		}
+	}
+	if integrity != "" {
+		if err := cli.VerifyDigest(data, integrity); err != nil {
+			return nil, &exitError{code: exitConflict, err: fmt.Errorf("%s@%s does not match skill-lock.json: %w", name, served, err)}
+		}
+	}
+
+	path := cli.InstallPath(name, global)
+	if err := cli.ExtractArchive(data, filepath.Dir(path)); err != nil {
+		return nil, fmt.Errorf("install %s: %w", name, err)
+	}
+	return &cli.InstalledSkill{
+		Name:        name,
+		Version:     served,
+		Scope:       cli.Scope(global),
+		Path:        path,
+		Integrity:   cli.Digest(data),
+		InstalledAt: time.Now().UTC(),
+	}, nil
+}
+
+// validateSkillName rejects anything but a valid skill name. Names become
+// directories under .claude/skills and come from arguments and from
+// skill-lock.json, which a cloned repository supplies.
+func validateSkillName(name string) error {
+	if err := skill.ValidateName(name); err != nil {
+		return &exitError{code: exitUsage, err: fmt.Errorf("skill %w", err)}
+	}
+	return nil
+}
+
+// fetchSkillArchive downloads the archive of a marketplace skill version.
+// It returns the archive, the version the server resolved and the digest
+// it reported.
+func fetchSkillArchive(serverURL, token, skillName, version string) (data []byte, served, digest string, err error) {
+	u := strings.TrimRight(serverURL, "/") + "/v1/marketplace/skills/" + url.PathEscape(skillName) + "/download"
+	if version != "" {
+		u += "?version=" + url.QueryEscape(version)
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, "", "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, "", "", &exitError{code: exitUnavailable, err: err}
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", "", err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusForbidden:
+		return nil, "", "", &exitError{code: exitAuth, err: fmt.Errorf("not approved for your tenant — run `skillbox add %s` to request approval", skillName)}
+	case http.StatusNotFound:
+		return nil, "", "", &exitError{code: exitNotFound, err: fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))}
+	default:
+		return nil, "", "", fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	served = resp.Header.Get("X-Skillbox-Version")
+	if served == "" {
+		served = version
+	}
+	return body, served, resp.Header.Get("X-Skillbox-Digest"), nil
+}
+
+// --------------------------------------------------------------------
+// skillbox install
+// --------------------------------------------------------------------
+
+func newInstallCmd() *cobra.Command {
+	var global bool
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install the skills recorded in skill-lock.json",
+		Long: `Reinstall every skill in the lock file of the scope at its recorded
+version and fail if an archive's sha256 differs from the recorded
+integrity. Use this after cloning a project that commits skill-lock.json.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			creds, err := loadCredentials()
+			if err != nil {
+				return fmt.Errorf("not logged in: %w — run `skillbox login` first", err)
+			}
+
+			lf, err := cli.LoadLockFile(global)
+			if err != nil {
+				return err
+			}
+			if len(lf.Skills) == 0 {
+				fmt.Printf("No skills in %s\n", cli.LockFilePath(global))
+				return nil
+			}
+			for _, locked := range lf.Skills {
+				if err := validateSkillName(locked.Name); err != nil {
+					return fmt.Errorf("%s: %w", cli.LockFilePath(global), err)
+				}
+			}
+
+			changed := false
+			for i, locked := range lf.Skills {
+				installed, err := installSkill(creds.AccessToken, locked.Name, locked.Version, locked.Integrity, global)
+				if err != nil {
+					return err
+				}
+				if locked.Integrity == "" {
+					// Entry written before integrity was recorded.
+					lf.Skills[i].Integrity = installed.Integrity
+					lf.Skills[i].Path = installed.Path
+					changed = true
+				}
+				fmt.Printf("Installed %s@%s\n", locked.Name, installed.Version)
+			}
+			if changed {
+				return cli.SaveLockFile(lf, global)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&global, "global", false, "Use the global lock file (~/.config/skillbox/skill-lock.json)")
+	return cmd
+}
+
+// --------------------------------------------------------------------
+// skillbox outdated
+// --------------------------------------------------------------------
+
+// outdatedSkill is a row of `skillbox outdated`.
+type outdatedSkill struct {
+	Name    string `json:"name"`
+	Scope   string `json:"scope"`
+	Current string `json:"current"`
+	Latest  string `json:"latest"`
+}
+
+// findOutdated returns the skills in the lock file of a scope whose latest
+// marketplace version is newer than the installed one. With names, only
+// those skills are checked.
+func findOutdated(token string, global bool, names []string) ([]outdatedSkill, error) {
+	lf, err := cli.LoadLockFile(global)
+	if err != nil {
+		return nil, err
+	}
+	var out []outdatedSkill
+	for _, s := range lf.Skills {
+		if len(names) > 0 && !slices.Contains(names, s.Name) {
+			continue
+		}
+		meta, err := fetchSkillMeta(flagServer, token, s.Name, "")
+		if err != nil {
+			return nil, fmt.Errorf("fetch %s metadata: %w", s.Name, err)
+		}
+		if skill.CompareVersions(meta.Version, s.Version) > 0 {
+			out = append(out, outdatedSkill{Name: s.Name, Scope: s.Scope, Current: s.Version, Latest: meta.Version})
+		}
+	}
+	return out, nil
+}
+
+func newOutdatedCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "outdated",
+		Short: "List installed skills with a newer version in the marketplace",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			creds, err := loadCredentials()
+			if err != nil {
+				return fmt.Errorf("not logged in: %w — run `skillbox login` first", err)
+			}
+
+			outdated := []outdatedSkill{}
+			for _, global := range []bool{false, true} {
+				found, err := findOutdated(creds.AccessToken, global, nil)
+				if err != nil {
+					return err
+				}
+				outdated = append(outdated, found...)
+			}
+
+			if !structuredOutput() && len(outdated) == 0 {
+				fmt.Println("All installed skills are up to date.")
+				return nil
+			}
+			return printOutput(outdated, func(w io.Writer) {
+				fmt.Fprintln(w, "NAME\tSCOPE\tCURRENT\tLATEST") //nolint:errcheck
+				for _, o := range outdated {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", o.Name, o.Scope, o.Current, o.Latest) //nolint:errcheck
+				}
+			})
+		},
+	}
+}
+
+// --------------------------------------------------------------------
+// skillbox update
+// --------------------------------------------------------------------
+
+func newUpdateCmd() *cobra.Command {
+	var global bool
+
+	cmd := &cobra.Command{
+		Use:   "update [skill_name...]",
+		Short: "Update installed skills to their latest version",
+		Long: `Install the latest marketplace version of every outdated skill in the
+scope, or of the named ones, and record the new version and integrity
+in skill-lock.json.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			creds, err := loadCredentials()
+			if err != nil {
+				return fmt.Errorf("not logged in: %w — run `skillbox login` first", err)
+			}
+
+			for _, name := range args {
+				if err := validateSkillName(name); err != nil {
+					return err
+				}
+				if !cli.IsInstalled(name, global) {
+					return &exitError{code: exitNotFound, err: fmt.Errorf("skill %q is not installed (%s scope)", name, cli.Scope(global))}
+				}
+			}
+
+			outdated, err := findOutdated(creds.AccessToken, global, args)
+			if err != nil {
+				return err
+			}
+			if len(outdated) == 0 {
+				fmt.Println("All installed skills are up to date.")
+				return nil
+			}
+
+			lf, err := cli.LoadLockFile(global)
+			if err != nil {
+				return err
+			}
+			for _, o := range outdated {
+				installed, err := installSkill(creds.AccessToken, o.Name, o.Latest, "", global)
+				if err != nil {
+					return err
+				}
+				if locked := lf.Find(o.Name); locked != nil {
+					installed.Provider = locked.Provider
+				}
+				if err := cli.AddToLockFile(*installed); err != nil {
+					return fmt.Errorf("update lock file: %w", err)
+				}
+				fmt.Printf("Updated %s %s -> %s\n", o.Name, o.Current, installed.Version)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&global, "global", false, "Update globally installed skills")
+	return cmd
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/devs-group/skillbox/internal/cli"
+)
+
+func TestInstallCommands_RejectUnsafeNames(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	activeConfig, activeContext = nil, nil
+	if err := cli.SaveCredentials(&cli.Credentials{AccessToken: "at-1", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	}))
+	defer srv.Close()
+	prev := flagServer
+	flagServer = srv.URL
+	t.Cleanup(func() { flagServer = prev })
+
+	// .claude/skills/../../victim is the victim directory itself.
+	victim := filepath.Join("victim", "keep.txt")
+	if err := os.MkdirAll(filepath.Dir(victim), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(victim, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	const unsafe = "../../victim"
+	lock := &cli.LockFile{Skills: []cli.InstalledSkill{{Name: unsafe, Version: "1.0.0", Scope: cli.ScopeProject, Path: victim}}}
+	if err := cli.SaveLockFile(lock, false); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		cmd  *cobra.Command
+		args []string
+	}{
+		{"install from a crafted lock file", newInstallCmd(), nil},
+		{"add", newAddCmd(), []string{unsafe}},
+		{"update", newUpdateCmd(), []string{unsafe}},
+		{"remove", newRemoveCmd(), []string{unsafe}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cmd.RunE(tt.cmd, tt.args)
+			var exitErr *exitError
+			if !errors.As(err, &exitErr) || exitErr.code != exitUsage {
+				t.Fatalf("%s = %v, want exit code %d", tt.name, err, exitUsage)
+			}
+			if _, err := os.Stat(victim); err != nil {
+				t.Errorf("victim directory was touched: %v", err)
+			}
+		})
+	}
+}
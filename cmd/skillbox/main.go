@@ -9,7 +9,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -30,7 +29,7 @@ var (
 func main() {
 	if err := newRootCmd().Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", err) //nolint:errcheck
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
 
@@ -38,13 +37,14 @@ func main() {
 // Helpers
 // --------------------------------------------------------------------
 
-// newClient creates a Skillbox SDK client from the global flag values.
+// newClient creates a Skillbox SDK client from the global flag values and
+// the active context.
 func newClient() *skillbox.Client {
 	var opts []skillbox.Option
 	if flagTenant != "" {
 		opts = append(opts, skillbox.WithTenant(flagTenant))
 	}
-	return skillbox.New(flagServer, flagAPIKey, opts...)
+	return skillbox.New(flagServer, bearerToken(), opts...)
 }
 
 // contextWithTimeout returns a context with a 5-minute timeout.
@@ -86,12 +86,21 @@ func packageDir(dir string) (zipPath string, sk *skill.Skill, err error) {
 	}
 	defer f.Close() //nolint:errcheck
 
-	zw := zip.NewWriter(f)
-	defer zw.Close() //nolint:errcheck
+	if err := zipDir(f, dir); err != nil {
+		return "", nil, fmt.Errorf("create zip archive: %w", err)
+	}
+
+	return zipPath, sk, nil
+}
+
+// zipDir writes the files under dir to w as a zip archive, with paths
+// relative to dir.
+func zipDir(w io.Writer, dir string) error {
+	zw := zip.NewWriter(w)
 
 	absDir, err := filepath.Abs(dir)
 	if err != nil {
-		return "", nil, err
+		return err
 	}
 
 	err = filepath.Walk(absDir, func(path string, info os.FileInfo, walkErr error) error {
@@ -125,10 +134,9 @@ func packageDir(dir string) (zipPath string, sk *skill.Skill, err error) {
 		return err
 	})
 	if err != nil {
-		return "", nil, fmt.Errorf("create zip archive: %w", err)
+		return err
 	}
-
-	return zipPath, sk, nil
+	return zw.Close()
 }
 
 // --------------------------------------------------------------------
@@ -141,27 +149,54 @@ func newRootCmd() *cobra.Command {
 		Short:         "Skillbox CLI — manage and run skills",
 		SilenceUsage:  true,
 		SilenceErrors: true,
+		// With Args set, cobra reports an unknown subcommand through
+		// NoArgs, which usageArgs turns into exitUsage.
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(); err != nil {
+				return err
+			}
+			return applyContext(cmd)
+		},
 	}
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return usageError(err)
+	})
 
 	rootCmd.PersistentFlags().StringVarP(&flagServer, "server", "s", envOrDefault("SKILLBOX_SERVER_URL", "http://localhost:8080"), "Skillbox server URL")
 	rootCmd.PersistentFlags().StringVarP(&flagAPIKey, "api-key", "k", os.Getenv("SKILLBOX_API_KEY"), "API key for authentication")
 	rootCmd.PersistentFlags().StringVarP(&flagTenant, "tenant", "t", os.Getenv("SKILLBOX_TENANT_ID"), "Tenant ID for multi-tenancy")
-	rootCmd.PersistentFlags().StringVarP(&flagOutput, "output", "o", "", "Output format: json, table")
+	rootCmd.PersistentFlags().StringVarP(&flagOutput, "output", "o", "", "Output format: table, json, yaml")
+	rootCmd.PersistentFlags().StringVar(&flagContext, "context", os.Getenv("SKILLBOX_CONTEXT"), "Context to use instead of the current one")
 
 	rootCmd.AddCommand(
 		newRunCmd(),
+		newDevCmd(),
+		newInitCmd(),
 		newSkillCmd(),
 		newExecCmd(),
+		newFileCmd(),
+		newSessionCmd(),
+		newSandboxCmd(),
+		newShellCmd(),
 		newHealthCmd(),
 		newVersionCmd(),
+		newContextCmd(),
 		// Enterprise commands
 		newLoginCmd(),
 		newLogoutCmd(),
 		newAddCmd(),
+		newInstallCmd(),
+		newOutdatedCmd(),
+		newUpdateCmd(),
 		newListInstalledCmd(),
 		newRemoveCmd(),
 		newSearchCmd(),
 	)
+	usageArgs(rootCmd)
 
 	return rootCmd
 }
@@ -219,7 +254,7 @@ func newRunCmd() *cobra.Command {
 				return err
 			}
 
-			if err := printJSON(result); err != nil {
+			if err := printStructured(result); err != nil {
 				return err
 			}
 
@@ -231,7 +266,7 @@ func newRunCmd() *cobra.Command {
 				fmt.Fprintf(os.Stderr, "Files downloaded to %s\n", download) //nolint:errcheck
 			}
 
-			return nil
+			return executionFailed(result.ExecutionID, result.Status, result.Error)
 		},
 	}
 
@@ -250,14 +285,24 @@ func newRunCmd() *cobra.Command {
 func newSkillCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "skill",
-		Short: "Manage skills: package, push, list, lint",
+		Short: "Manage skills: package, push, list, get, versions, diff, activate, delete, pull, lint, scan, test, keygen, verify",
 	}
 
 	cmd.AddCommand(
 		newSkillPackageCmd(),
 		newSkillPushCmd(),
 		newSkillListCmd(),
+		newSkillGetCmd(),
+		newSkillVersionsCmd(),
+		newSkillDiffCmd(),
+		newSkillActivateCmd(),
+		newSkillDeleteCmd(),
+		newSkillPullCmd(),
 		newSkillLintCmd(),
+		newSkillScanCmd(),
+		newSkillTestCmd(),
+		newSkillKeygenCmd(),
+		newSkillVerifyCmd(),
 	)
 
 	return cmd
@@ -268,7 +313,9 @@ func newSkillCmd() *cobra.Command {
 // --------------------------------------------------------------------
 
 func newSkillPackageCmd() *cobra.Command {
-	return &cobra.Command{
+	var signKey string
+
+	cmd := &cobra.Command{
 		Use:   "package <dir>",
 		Short: "Package a skill directory into a zip archive",
 		Args:  cobra.ExactArgs(1),
@@ -287,9 +334,19 @@ func newSkillPackageCmd() *cobra.Command {
 			}
 
 			fmt.Printf("Packaged %s v%s -> %s\n", sk.Name, sk.Version, zipPath)
+			if signKey != "" {
+				sig, err := signZipFile(zipPath, signKey)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Signed with %s\n", sig.KeyID)
+			}
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&signKey, "sign", "", "Sign the archive with this ed25519 private key (see skill keygen)")
+	return cmd
 }
 
 // --------------------------------------------------------------------
@@ -297,7 +354,9 @@ func newSkillPackageCmd() *cobra.Command {
 // --------------------------------------------------------------------
 
 func newSkillPushCmd() *cobra.Command {
-	return &cobra.Command{
+	var signKey string
+
+	cmd := &cobra.Command{
 		Use:   "push <dir|zip>",
 		Short: "Push a skill to the server",
 		Args:  cobra.ExactArgs(1),
@@ -335,6 +394,29 @@ func newSkillPushCmd() *cobra.Command {
 				}
 			}
 
+			if signKey != "" {
+				if !info.IsDir() {
+					// Sign a copy rather than rewriting the user's archive.
+					data, err := os.ReadFile(target)
+					if err != nil {
+						return err
+					}
+					tmp, err := os.CreateTemp("", "skillbox-*.zip")
+					if err != nil {
+						return err
+					}
+					defer os.Remove(tmp.Name()) //nolint:errcheck
+					_ = tmp.Close()
+					if err := os.WriteFile(tmp.Name(), data, 0o600); err != nil {
+						return err
+					}
+					zipPath = tmp.Name()
+				}
+				if _, err := signZipFile(zipPath, signKey); err != nil {
+					return err
+				}
+			}
+
 			if err := client.RegisterSkill(ctx, zipPath); err != nil {
 				return err
 			}
@@ -343,6 +425,9 @@ func newSkillPushCmd() *cobra.Command {
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&signKey, "sign", "", "Sign the archive with this ed25519 private key before pushing")
+	return cmd
 }
 
 // --------------------------------------------------------------------
@@ -364,17 +449,12 @@ func newSkillListCmd() *cobra.Command {
 				return err
 			}
 
-			if flagOutput == "json" {
-				return printJSON(skills)
-			}
-
-			// Default to table output.
-			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			fmt.Fprintln(w, "NAME\tVERSION\tDESCRIPTION") //nolint:errcheck
-			for _, s := range skills {
-				fmt.Fprintf(w, "%s\t%s\t%s\n", s.Name, s.Version, s.Description) //nolint:errcheck
-			}
-			return w.Flush()
+			return printOutput(skills, func(w io.Writer) {
+				fmt.Fprintln(w, "NAME\tVERSION\tDESCRIPTION") //nolint:errcheck
+				for _, s := range skills {
+					fmt.Fprintf(w, "%s\t%s\t%s\n", s.Name, s.Version, s.Description) //nolint:errcheck
+				}
+			})
 		},
 	}
 }
@@ -431,14 +511,15 @@ func newSkillLintCmd() *cobra.Command {
 			check("version", sk.Version != "", "version is required")
 			check("description", sk.Description != "", "description is required")
 
-			// Check entrypoint existence.
+			// Check entrypoint existence. Cognitive skills have none: the
+			// agent's code runs through the generated code runner.
 			entrypoints := []string{
 				"scripts/main.py",
 				"scripts/main.js",
 				"scripts/main.sh",
 				"scripts/run.py",
 			}
-			entrypointFound := false
+			entrypointFound := sk.Mode == "cognitive"
 			for _, ep := range entrypoints {
 				if _, err := os.Stat(filepath.Join(dir, ep)); err == nil {
 					entrypointFound = true
@@ -471,7 +552,12 @@ func newExecCmd() *cobra.Command {
 		Short: "Manage executions",
 	}
 
-	cmd.AddCommand(newExecLogsCmd())
+	cmd.AddCommand(
+		newExecListCmd(),
+		newExecGetCmd(),
+		newExecLogsCmd(),
+		newExecCancelCmd(),
+	)
 	return cmd
 }
 
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	skillbox "github.com/devs-group/skillbox/sdks/go"
+)
+
+// --------------------------------------------------------------------
+// Output formats
+// --------------------------------------------------------------------
+
+// validateOutput rejects --output values other than json, table and yaml.
+func validateOutput() error {
+	switch flagOutput {
+	case "", "table", "json", "yaml":
+		return nil
+	}
+	return usageError(fmt.Errorf("invalid --output %q: use json, table or yaml", flagOutput))
+}
+
+// structuredOutput reports whether --output asks for machine-readable
+// output rather than a table or plain text.
+func structuredOutput() bool {
+	return flagOutput == "json" || flagOutput == "yaml"
+}
+
+// printStructured writes v as YAML with --output yaml and as JSON
+// otherwise.
+func printStructured(v any) error {
+	if flagOutput == "yaml" {
+		return printYAML(v)
+	}
+	return printJSON(v)
+}
+
+// printOutput writes v as JSON or YAML when --output asks for it, and
+// otherwise lets table write tab-separated rows that are aligned into
+// columns.
+func printOutput(v any, table func(w io.Writer)) error {
+	if structuredOutput() {
+		return printStructured(v)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	table(w)
+	return w.Flush()
+}
+
+// printYAML writes v to stdout as YAML. v goes through its JSON encoding
+// first so that the field names match the json output.
+func printYAML(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	plainStyle(&node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(buf.Bytes())
+	return err
+}
+
+// plainStyle drops the flow and quoting styles the YAML parser keeps from
+// the JSON source, so that the output is block-style YAML.
+func plainStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		plainStyle(c)
+	}
+}
+
+// --------------------------------------------------------------------
+// Exit codes
+// --------------------------------------------------------------------
+
+// Exit codes of the CLI. They are part of its interface for scripts; see
+// the CLI reference in the docs.
+const (
+	exitOK          = 0
+	exitGeneric     = 1 // any error not covered below
+	exitUsage       = 2 // invalid arguments or flags, or a request the server rejected as invalid
+	exitNotFound    = 3 // the skill, execution, file or session does not exist
+	exitAuth        = 4 // missing or invalid API key, or not permitted
+	exitConflict    = 5 // the resource is in the wrong state, e.g. skill not available
+	exitFailed      = 6 // the skill ran but did not succeed, or its tests failed
+	exitUnavailable = 7 // the server could not be reached, timed out or is overloaded
+)
+
+// exitError carries the exit code for an error that the SDK's sentinel
+// errors do not classify.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+func usageError(err error) error {
+	return &exitError{code: exitUsage, err: err}
+}
+
+// exitCode maps err to the process exit code.
+func exitCode(err error) int {
+	var ee *exitError
+	var urlErr *url.Error
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &ee):
+		return ee.code
+	case errors.Is(err, skillbox.ErrBadRequest), errors.Is(err, skillbox.ErrImageNotAllowed):
+		return exitUsage
+	case errors.Is(err, skillbox.ErrNotFound), errors.Is(err, skillbox.ErrSkillNotFound):
+		return exitNotFound
+	case errors.Is(err, skillbox.ErrUnauthorized), errors.Is(err, skillbox.ErrForbidden):
+		return exitAuth
+	case errors.Is(err, skillbox.ErrConflict), errors.Is(err, skillbox.ErrSkillNotAvailable),
+		errors.Is(err, skillbox.ErrSkillBlocked):
+		return exitConflict
+	case errors.Is(err, skillbox.ErrUnavailable), errors.Is(err, skillbox.ErrRateLimited),
+		errors.Is(err, skillbox.ErrTimeout), errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &urlErr):
+		return exitUnavailable
+	}
+	return exitGeneric
+}
+
+// usageArgs wraps the argument validators of cmd and its subcommands so
+// that wrong arguments exit with exitUsage.
+func usageArgs(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			if err := validate(cmd, args); err != nil {
+				return usageError(err)
+			}
+			return nil
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		usageArgs(sub)
+	}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	skillbox "github.com/devs-group/skillbox/sdks/go"
+)
+
+// --------------------------------------------------------------------
+// skillbox sandbox (parent)
+// --------------------------------------------------------------------
+
+func newSandboxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sandbox",
+		Short: "Work in a session sandbox: exec, read, write, ls, sync, destroy",
+		Long: `Run commands and read or write files in the sandbox of a session. The
+sandbox is created on first use; see "skillbox shell" for an interactive
+terminal.`,
+	}
+
+	cmd.AddCommand(
+		newSandboxExecCmd(),
+		newSandboxReadCmd(),
+		newSandboxWriteCmd(),
+		newSandboxLsCmd(),
+		newSandboxSyncCmd(),
+		newSandboxDestroyCmd(),
+	)
+
+	return cmd
+}
+
+// --------------------------------------------------------------------
+// skillbox sandbox exec
+// --------------------------------------------------------------------
+
+func newSandboxExecCmd() *cobra.Command {
+	var (
+		workdir string
+		timeout time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "exec <session-id> -- <command>...",
+		Short: "Run a bash command in the sandbox",
+		Long: `Run a bash command in the sandbox and print its stdout and stderr. The
+CLI exits with the command's exit status, so "skillbox sandbox exec"
+can stand in for the command in scripts.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			res, err := client.SandboxExecute(ctx, args[0], skillbox.SandboxExecRequest{
+				Command:   strings.Join(args[1:], " "),
+				WorkDir:   workdir,
+				TimeoutMs: int(timeout.Milliseconds()),
+			})
+			if err != nil {
+				return err
+			}
+
+			if structuredOutput() {
+				if err := printStructured(res); err != nil {
+					return err
+				}
+			} else {
+				fmt.Fprint(os.Stdout, res.Stdout) //nolint:errcheck
+				fmt.Fprint(os.Stderr, res.Stderr) //nolint:errcheck
+			}
+			if res.ExitCode != 0 {
+				return &exitError{code: res.ExitCode, err: fmt.Errorf("command exited with status %d", res.ExitCode)}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&workdir, "workdir", "", "Working directory (default: /sandbox/session)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Command timeout (default: 30s)")
+	return cmd
+}
+
+// --------------------------------------------------------------------
+// skillbox sandbox read
+// --------------------------------------------------------------------
+
+func newSandboxReadCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "read <session-id> <path>",
+		Short: "Print a file from the sandbox",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			content, err := client.SandboxReadFile(ctx, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			if structuredOutput() {
+				return printStructured(map[string]string{"path": args[1], "content": content})
+			}
+			fmt.Print(content)
+			return nil
+		},
+	}
+}
+
+// --------------------------------------------------------------------
+// skillbox sandbox write
+// --------------------------------------------------------------------
+
+func newSandboxWriteCmd() *cobra.Command {
+	var (
+		from       string
+		appendFlag bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "write <session-id> <path>",
+		Short: "Write stdin or a local file to a file in the sandbox",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var data []byte
+			var err error
+			if from == "" || from == "-" {
+				data, err = io.ReadAll(os.Stdin)
+			} else {
+				data, err = os.ReadFile(from)
+			}
+			if err != nil {
+				return err
+			}
+
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			if err := client.SandboxWriteFile(ctx, args[0], args[1], string(data), appendFlag); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Wrote %d bytes to %s\n", len(data), args[1]) //nolint:errcheck
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&from, "file", "f", "", `Local file to upload (default: stdin)`)
+	cmd.Flags().BoolVar(&appendFlag, "append", false, "Append instead of overwriting")
+	return cmd
+}
+
+// --------------------------------------------------------------------
+// skillbox sandbox ls
+// --------------------------------------------------------------------
+
+func newSandboxLsCmd() *cobra.Command {
+	var depth int
+
+	cmd := &cobra.Command{
+		Use:   "ls <session-id> [path]",
+		Short: "List a directory in the sandbox",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "/sandbox/session"
+			if len(args) == 2 {
+				dir = args[1]
+			}
+
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			entries, err := client.SandboxListDir(ctx, args[0], dir, depth)
+			if err != nil {
+				return err
+			}
+
+			return printOutput(entries, func(w io.Writer) {
+				fmt.Fprintln(w, "PATH\tSIZE") //nolint:errcheck
+				for _, e := range entries {
+					if e.IsDir {
+						fmt.Fprintf(w, "%s/\t-\n", e.Path) //nolint:errcheck
+					} else {
+						fmt.Fprintf(w, "%s\t%d\n", e.Path, e.Size) //nolint:errcheck
+					}
+				}
+			})
+		},
+	}
+
+	cmd.Flags().IntVar(&depth, "depth", 0, "Recursion depth (default: 2)")
+	return cmd
+}
+
+// --------------------------------------------------------------------
+// skillbox sandbox sync
+// --------------------------------------------------------------------
+
+func newSandboxSyncCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sync <session-id>",
+		Short: "Persist the sandbox workspace to the session's files",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			if err := client.SandboxSync(ctx, args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Synced sandbox of session %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+// --------------------------------------------------------------------
+// skillbox sandbox destroy
+// --------------------------------------------------------------------
+
+func newSandboxDestroyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "destroy <session-id>",
+		Short: "Tear down the sandbox of a session",
+		Long: `Tear down the running sandbox of a session. The session and the files
+persisted with "skillbox sandbox sync" are kept.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			if err := client.SandboxDestroy(ctx, args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Destroyed sandbox of session %s\n", args[0])
+			return nil
+		},
+	}
+}
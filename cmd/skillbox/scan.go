@@ -0,0 +1,214 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/devs-group/skillbox/internal/scanner"
+	"github.com/devs-group/skillbox/internal/version"
+)
+
+// --------------------------------------------------------------------
+// skillbox skill scan
+// --------------------------------------------------------------------
+
+func newSkillScanCmd() *cobra.Command {
+	var (
+		format       string
+		failOn       string
+		patternsFile string
+		ossfFeedDir  string
+		osvDir       string
+		blockAt      string
+		timeout      time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "scan <dir|zip>",
+		Short: "Run the server's security scan on a skill locally",
+		Long: `Run the security scanner the server applies on upload (ZIP safety
+checks, static patterns, dependencies, prompt injection and security
+analysis) without contacting a server. Unlike an upload, every finding
+is reported rather than stopping at the first blocking tier. The LLM
+analysis tier never runs locally.
+
+--format text prints one "file:line: severity code description" line per
+finding, json (or -o json/yaml) the scan result, and sarif a SARIF 2.1.0
+log for code scanning and editors. The command exits with code 6 when a
+finding is at or above --fail-on: block (default), flag, or none.
+
+With --osv-dir, pinned PyPI and npm dependencies are also matched against
+the known vulnerabilities in an OSV dump (JSON files or the all.zip
+archives from osv-vulnerabilities). Vulnerabilities rated at or above
+--block-severity block, as the server's vuln_block_severity setting does;
+the rest are flagged.`,
+		Example: `  skillbox skill scan ./my-skill
+  skillbox skill scan ./my-skill --format sarif > skillbox.sarif
+  skillbox skill scan my-skill-1.0.0.zip --fail-on flag
+  skillbox skill scan ./my-skill --osv-dir ./osv --block-severity high`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("format") && structuredOutput() {
+				format = flagOutput
+			}
+			switch format {
+			case "text", "json", "yaml", "sarif":
+			default:
+				return usageError(fmt.Errorf("invalid --format %q: use text, json or sarif", format))
+			}
+			switch failOn {
+			case "block", "flag", "none":
+			default:
+				return usageError(fmt.Errorf("invalid --fail-on %q: use block, flag or none", failOn))
+			}
+			if !scanner.ValidVulnThreshold(blockAt) {
+				return usageError(fmt.Errorf("invalid --block-severity %q: use low, medium, high, critical or none", blockAt))
+			}
+
+			data, root, err := readSkillArchive(args[0])
+			if err != nil {
+				return err
+			}
+			zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+			if err != nil {
+				return fmt.Errorf("open %s: %w", args[0], err)
+			}
+
+			logger := slog.New(slog.DiscardHandler)
+			p, err := scanner.New(timeout, logger, nil, patternsFile, ossfFeedDir)
+			if err != nil {
+				return err
+			}
+			if osvDir != "" {
+				vulnDB, err := scanner.LoadVulnDB(osvDir, logger)
+				if err != nil {
+					return err
+				}
+				p.SetVulnDB(vulnDB)
+			}
+			result, err := p.Lint(context.Background(), zr)
+			if err != nil {
+				return fmt.Errorf("scan: %w", err)
+			}
+			scanner.ApplyVulnThreshold(result, blockAt)
+
+			switch format {
+			case "sarif":
+				err = printJSON(scanner.ToSARIF(result, version.Version, root))
+			case "json":
+				err = printJSON(result)
+			case "yaml":
+				err = printYAML(result)
+			default:
+				printFindings(result, root)
+			}
+			if err != nil {
+				return err
+			}
+
+			if n := countFailing(result.Findings, failOn); n > 0 {
+				return &exitError{code: exitFailed, err: fmt.Errorf("scan found %d %s", n, plural(n, "issue", "issues"))}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text, json or sarif")
+	cmd.Flags().StringVar(&failOn, "fail-on", "block", "Lowest severity that fails the scan: block, flag or none")
+	cmd.Flags().StringVar(&patternsFile, "patterns", "", "Custom patterns file to merge with the built-in patterns")
+	cmd.Flags().StringVar(&ossfFeedDir, "ossf-feed", "", "Directory of an OSSF malicious-packages checkout to block")
+	cmd.Flags().StringVar(&osvDir, "osv-dir", "", "Directory of OSV advisories to match pinned dependencies against")
+	cmd.Flags().StringVar(&blockAt, "block-severity", scanner.DefaultVulnThreshold, "Lowest vulnerability severity that blocks: low, medium, high, critical or none")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "Scan timeout")
+	return cmd
+}
+
+// readSkillArchive returns the zip archive at path, or a directory zipped
+// the way `skillbox skill push` uploads it. root is the directory finding
+// paths are relative to, as seen from the working directory: the skill
+// directory itself, or "" for a zip.
+func readSkillArchive(src string) (data []byte, root string, err error) {
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil, "", err
+	}
+	if !info.IsDir() {
+		data, err = os.ReadFile(src)
+		return data, "", err
+	}
+	if _, err := os.Stat(filepath.Join(src, "SKILL.md")); err != nil {
+		return nil, "", fmt.Errorf("SKILL.md not found in %s", src)
+	}
+
+	var buf bytes.Buffer
+	if err := zipDir(&buf, src); err != nil {
+		return nil, "", fmt.Errorf("create zip archive: %w", err)
+	}
+	return buf.Bytes(), filepath.ToSlash(filepath.Clean(src)), nil
+}
+
+// printFindings writes findings as compiler-style lines, with paths under
+// root, that editors and terminals link to the file and line.
+func printFindings(result *scanner.ScanResult, root string) {
+	if len(result.Findings) == 0 {
+		fmt.Println("No findings.")
+		return
+	}
+
+	blocks := 0
+	for _, f := range result.Findings {
+		if f.Severity == scanner.SeverityBlock {
+			blocks++
+		}
+		loc := "(archive)"
+		if f.FilePath != "" {
+			loc = path.Join(root, f.FilePath)
+		}
+		if f.Line > 0 {
+			loc = fmt.Sprintf("%s:%d", loc, f.Line)
+		}
+		code := f.IssueCode
+		if code == "" {
+			code = f.Category
+		}
+		fmt.Printf("%s: %s %s %s\n", loc, strings.ToLower(string(f.Severity)), code, f.Description)
+		if f.MatchText != "" {
+			fmt.Printf("    match: %s\n", f.MatchText)
+		}
+		if f.Remediation != "" {
+			fmt.Printf("    fix: %s\n", f.Remediation)
+		}
+	}
+	fmt.Printf("\n%d %s (%d block, %d flag)\n", len(result.Findings),
+		plural(len(result.Findings), "finding", "findings"), blocks, len(result.Findings)-blocks)
+}
+
+// countFailing returns the number of findings at or above failOn.
+func countFailing(findings []scanner.Finding, failOn string) int {
+	n := 0
+	for _, f := range findings {
+		switch {
+		case failOn == "none":
+		case failOn == "flag", f.Severity == scanner.SeverityBlock:
+			n++
+		}
+	}
+	return n
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/spf13/cobra"
+)
+
+// --------------------------------------------------------------------
+// skillbox session (parent)
+// --------------------------------------------------------------------
+
+func newSessionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "session",
+		Short: "Inspect session workspaces: files, download, delete",
+	}
+
+	cmd.AddCommand(
+		newSessionFilesCmd(),
+		newSessionDownloadCmd(),
+		newSessionDeleteCmd(),
+	)
+
+	return cmd
+}
+
+// --------------------------------------------------------------------
+// skillbox session files
+// --------------------------------------------------------------------
+
+func newSessionFilesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "files <session-id>",
+		Short: "List the files in a session workspace",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			files, err := client.ListSessionFiles(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			return printFiles(files)
+		},
+	}
+}
+
+// --------------------------------------------------------------------
+// skillbox session download
+// --------------------------------------------------------------------
+
+func newSessionDownloadCmd() *cobra.Command {
+	var dest string
+
+	cmd := &cobra.Command{
+		Use:   "download <session-id> <filename>",
+		Short: "Download a file from a session workspace",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			body, err := client.GetSessionFile(ctx, args[0], args[1])
+			if err != nil {
+				return err
+			}
+			defer body.Close() //nolint:errcheck
+
+			if dest == "-" {
+				_, err := io.Copy(os.Stdout, body)
+				return err
+			}
+			if dest == "" {
+				dest = path.Base(args[1])
+			}
+			f, err := os.Create(dest)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(f, body); err != nil {
+				f.Close() //nolint:errcheck
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Downloaded %s to %s\n", args[1], dest) //nolint:errcheck
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&dest, "dest", "O", "", `Destination path, "-" for stdout (default: the file's base name)`)
+	return cmd
+}
+
+// --------------------------------------------------------------------
+// skillbox session delete
+// --------------------------------------------------------------------
+
+func newSessionDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <session-id> [filename]",
+		Short: "Delete a session and its files, or one file of it",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			if len(args) == 2 {
+				if err := client.DeleteSessionFile(ctx, args[0], args[1]); err != nil {
+					return err
+				}
+				fmt.Printf("Deleted %s from session %s\n", args[1], args[0])
+				return nil
+			}
+			if err := client.DeleteSession(ctx, args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Deleted session %s\n", args[0])
+			return nil
+		},
+	}
+}
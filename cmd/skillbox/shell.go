@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// --------------------------------------------------------------------
+// skillbox shell
+// --------------------------------------------------------------------
+
+// shellEvent mirrors the server's PTY event frames.
+type shellEvent struct {
+	Type             string `json:"type"`
+	Reason           string `json:"reason,omitempty"`
+	ExitCode         *int   `json:"exit_code,omitempty"`
+	TranscriptFileID string `json:"transcript_file_id,omitempty"`
+	Message          string `json:"message,omitempty"`
+}
+
+func newShellCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "shell <session>",
+		Short: "Attach an interactive shell to a sandbox session",
+		Long: `Open an interactive terminal in the sandbox for the given session ID.
+The sandbox is created if it does not exist yet. Exit the shell (or press
+Ctrl-D) to detach; the transcript is saved to the files API.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stdinFd := int(os.Stdin.Fd())
+			cols, rows := 80, 24
+			if term.IsTerminal(stdinFd) {
+				if w, h, err := term.GetSize(stdinFd); err == nil {
+					cols, rows = w, h
+				}
+			}
+
+			wsURL, err := shellURL(flagServer, args[0], cols, rows)
+			if err != nil {
+				return err
+			}
+			header := http.Header{}
+			if token := bearerToken(); token != "" {
+				header.Set("Authorization", "Bearer "+token)
+			}
+			if flagTenant != "" {
+				header.Set("X-Tenant-ID", flagTenant)
+			}
+
+			conn, resp, err := websocket.DefaultDialer.Dial(wsURL, header)
+			if err != nil {
+				if resp != nil {
+					return fmt.Errorf("connect to shell: %s", resp.Status)
+				}
+				return fmt.Errorf("connect to shell: %w", err)
+			}
+			defer conn.Close() //nolint:errcheck
+
+			if term.IsTerminal(stdinFd) {
+				state, err := term.MakeRaw(stdinFd)
+				if err != nil {
+					return fmt.Errorf("set raw mode: %w", err)
+				}
+				defer term.Restore(stdinFd, state) //nolint:errcheck
+			}
+
+			var writeMu sync.Mutex
+			send := func(msgType int, data []byte) error {
+				writeMu.Lock()
+				defer writeMu.Unlock()
+				return conn.WriteMessage(msgType, data)
+			}
+
+			// Terminal → shell.
+			go func() {
+				buf := make([]byte, 4096)
+				for {
+					n, err := os.Stdin.Read(buf)
+					if n > 0 {
+						if send(websocket.BinaryMessage, buf[:n]) != nil {
+							return
+						}
+					}
+					if err != nil {
+						return
+					}
+				}
+			}()
+
+			// Window size changes → shell.
+			winch := make(chan os.Signal, 1)
+			notifyResize(winch)
+			defer signal.Stop(winch)
+			go func() {
+				for range winch {
+					w, h, err := term.GetSize(stdinFd)
+					if err != nil {
+						continue
+					}
+					msg, _ := json.Marshal(map[string]any{"type": "resize", "cols": w, "rows": h})
+					_ = send(websocket.TextMessage, msg)
+				}
+			}()
+
+			// Shell → terminal, until the server closes the connection.
+			var exit *shellEvent
+			for {
+				msgType, data, err := conn.ReadMessage()
+				if err != nil {
+					break
+				}
+				if msgType == websocket.BinaryMessage {
+					os.Stdout.Write(data) //nolint:errcheck
+					continue
+				}
+				var ev shellEvent
+				if json.Unmarshal(data, &ev) != nil {
+					continue
+				}
+				switch ev.Type {
+				case "exit":
+					exit = &ev
+				case "error":
+					fmt.Fprintf(os.Stderr, "\r\nskillbox: %s\r\n", ev.Message) //nolint:errcheck
+				}
+			}
+
+			if exit == nil {
+				return fmt.Errorf("connection to shell lost")
+			}
+			fmt.Fprintf(os.Stderr, "\r\nShell ended (%s)", exit.Reason) //nolint:errcheck
+			if exit.TranscriptFileID != "" {
+				fmt.Fprintf(os.Stderr, ", transcript: %s", exit.TranscriptFileID) //nolint:errcheck
+			}
+			fmt.Fprint(os.Stderr, "\r\n") //nolint:errcheck
+			return nil
+		},
+	}
+}
+
+// shellURL builds the PTY WebSocket URL from the server's HTTP base URL.
+func shellURL(server, session string, cols, rows int) (string, error) {
+	u, err := url.Parse(strings.TrimRight(server, "/"))
+	if err != nil {
+		return "", fmt.Errorf("invalid server URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	default:
+		return "", fmt.Errorf("invalid server URL scheme %q", u.Scheme)
+	}
+	u.Path += "/v1/sandbox/" + url.PathEscape(session) + "/pty"
+	u.RawQuery = url.Values{
+		"cols": {strconv.Itoa(cols)},
+		"rows": {strconv.Itoa(rows)},
+	}.Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package main
+
+import "os"
+
+// notifyResize is a no-op on Windows, which has no SIGWINCH; the shell
+// keeps the size it was opened with.
+func notifyResize(chan<- os.Signal) {}
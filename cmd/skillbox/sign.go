@@ -0,0 +1,155 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/devs-group/skillbox/internal/signing"
+)
+
+// --------------------------------------------------------------------
+// skillbox skill keygen
+// --------------------------------------------------------------------
+
+func newSkillKeygenCmd() *cobra.Command {
+	var (
+		out   string
+		force bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "keygen",
+		Short: "Generate an ed25519 key pair for signing skills",
+		Long: `Generate an ed25519 key pair for signing skill archives. The private
+key is written to <out>.key (mode 0600) and the public key to <out>.pub.
+
+Sign with "skillbox skill package --sign <out>.key", and have a tenant
+admin register <out>.pub with POST /v1/admin/publisher-keys so the
+server trusts the signature.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keyPath, pubPath := out+".key", out+".pub"
+			if !force {
+				for _, p := range []string{keyPath, pubPath} {
+					if _, err := os.Stat(p); err == nil {
+						return &exitError{code: exitConflict, err: fmt.Errorf("%s already exists (use --force to overwrite)", p)}
+					}
+				}
+			}
+
+			privPEM, pubPEM, err := signing.GenerateKey()
+			if err != nil {
+				return fmt.Errorf("generate key: %w", err)
+			}
+			if err := os.WriteFile(keyPath, privPEM, 0o600); err != nil {
+				return fmt.Errorf("write private key: %w", err)
+			}
+			if err := os.WriteFile(pubPath, pubPEM, 0o644); err != nil {
+				return fmt.Errorf("write public key: %w", err)
+			}
+
+			pub, err := signing.ParsePublicKey(pubPEM)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Wrote %s and %s\n", keyPath, pubPath)
+			fmt.Printf("Key ID: %s\n", signing.KeyID(pub))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&out, "out", "skillbox-signing", "Path prefix for the .key and .pub files")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite existing key files")
+	return cmd
+}
+
+// --------------------------------------------------------------------
+// skillbox skill verify
+// --------------------------------------------------------------------
+
+func newSkillVerifyCmd() *cobra.Command {
+	var keyPath string
+
+	cmd := &cobra.Command{
+		Use:   "verify <dir|zip>",
+		Short: "Verify a skill's signature against a publisher key",
+		Long: `Verify that a signed skill archive was signed with the given public
+key and has not been modified since. Exits with code 6 when the skill is
+unsigned or the signature does not match.`,
+		Example: `  skillbox skill verify my-skill-1.0.0.zip --key skillbox-signing.pub`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pubPEM, err := os.ReadFile(keyPath)
+			if err != nil {
+				return usageError(fmt.Errorf("read public key: %w", err))
+			}
+			pub, err := signing.ParsePublicKey(pubPEM)
+			if err != nil {
+				return usageError(fmt.Errorf("%s: %w", keyPath, err))
+			}
+
+			data, _, err := readSkillArchive(args[0])
+			if err != nil {
+				return err
+			}
+			zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+			if err != nil {
+				return fmt.Errorf("open %s: %w", args[0], err)
+			}
+			sig, err := signing.Extract(zr)
+			if err != nil {
+				return &exitError{code: exitFailed, err: err}
+			}
+			if sig == nil {
+				return &exitError{code: exitFailed, err: fmt.Errorf("%s is not signed", args[0])}
+			}
+			if err := signing.Verify(zr, sig, pub); err != nil {
+				if errors.Is(err, signing.ErrInvalidSignature) {
+					return &exitError{code: exitFailed, err: err}
+				}
+				return err
+			}
+
+			if structuredOutput() {
+				return printStructured(sig)
+			}
+			fmt.Printf("Verified %s: signed by %s (%s)\n", args[0], sig.KeyID, sig.Digest)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&keyPath, "key", "", "PEM-encoded ed25519 public key of the publisher")
+	_ = cmd.MarkFlagRequired("key")
+	return cmd
+}
+
+// signZipFile signs the skill archive at zipPath in place with the
+// PEM-encoded private key at keyPath.
+func signZipFile(zipPath, keyPath string) (*signing.Signature, error) {
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("read signing key: %w", err)
+	}
+	priv, err := signing.ParsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", keyPath, err)
+	}
+
+	data, err := os.ReadFile(zipPath)
+	if err != nil {
+		return nil, err
+	}
+	signed, sig, err := signing.Sign(data, priv)
+	if err != nil {
+		return nil, fmt.Errorf("sign %s: %w", zipPath, err)
+	}
+	if err := os.WriteFile(zipPath, signed, 0o644); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/devs-group/skillbox/internal/scaffold"
+)
+
+// --------------------------------------------------------------------
+// skillbox skill get
+// --------------------------------------------------------------------
+
+func newSkillGetCmd() *cobra.Command {
+	var ver string
+
+	cmd := &cobra.Command{
+		Use:   "get <name>",
+		Short: "Show a skill's definition and instructions",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			sk, err := client.GetSkill(ctx, args[0], ver)
+			if err != nil {
+				return err
+			}
+
+			err = printOutput(sk, func(w io.Writer) {
+				fmt.Fprintf(w, "NAME:\t%s\n", sk.Name)               //nolint:errcheck
+				fmt.Fprintf(w, "VERSION:\t%s\n", sk.Version)         //nolint:errcheck
+				fmt.Fprintf(w, "DESCRIPTION:\t%s\n", sk.Description) //nolint:errcheck
+				fmt.Fprintf(w, "LANG:\t%s\n", sk.Lang)               //nolint:errcheck
+				fmt.Fprintf(w, "MODE:\t%s\n", sk.Mode)               //nolint:errcheck
+				if sk.Kind != "" {
+					fmt.Fprintf(w, "KIND:\t%s\n", sk.Kind) //nolint:errcheck
+				}
+				if sk.Image != "" {
+					fmt.Fprintf(w, "IMAGE:\t%s\n", sk.Image) //nolint:errcheck
+				}
+				if sk.Timeout != "" {
+					fmt.Fprintf(w, "TIMEOUT:\t%s\n", sk.Timeout) //nolint:errcheck
+				}
+			})
+			if err != nil || structuredOutput() || sk.Instructions == "" {
+				return err
+			}
+			fmt.Printf("\n%s\n", strings.TrimRight(sk.Instructions, "\n"))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&ver, "version", "latest", "Skill version")
+	return cmd
+}
+
+// --------------------------------------------------------------------
+// skillbox skill versions
+// --------------------------------------------------------------------
+
+func newSkillVersionsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "versions <name>",
+		Short: "List the versions of a skill with their review status",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			versions, err := client.ListSkillVersions(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			return printOutput(versions, func(w io.Writer) {
+				fmt.Fprintln(w, "VERSION\tSTATUS\tACTIVE\tBLOCKED\tUPLOADED\tSCAN") //nolint:errcheck
+				for _, v := range versions {
+					active := ""
+					if v.Active {
+						active = "*"
+					}
+					blocked := ""
+					if v.Blocked {
+						blocked = "yes"
+					}
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", //nolint:errcheck
+						v.Version, v.Status, active, blocked, v.UploadedAt.Format(time.RFC3339), v.ScanSummary)
+				}
+			})
+		},
+	}
+}
+
+// --------------------------------------------------------------------
+// skillbox skill diff
+// --------------------------------------------------------------------
+
+func newSkillDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff <name> [from] <to>",
+		Short: "Show the file changes between two versions of a skill",
+		Long: `Show the per-file changes between two versions of a skill. Without
+[from], the active version is compared with <to>.`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			var from, to string
+			if len(args) == 3 {
+				from, to = args[1], args[2]
+			} else {
+				to = args[1]
+			}
+
+			diff, err := client.SkillDiff(ctx, args[0], from, to)
+			if err != nil {
+				return err
+			}
+			if structuredOutput() {
+				return printStructured(diff)
+			}
+
+			for _, f := range diff.Files {
+				if f.Status == "unchanged" {
+					continue
+				}
+				fmt.Printf("--- %s@%s\n+++ %s@%s (%s)\n", f.Path, diff.From, f.Path, diff.To, f.Status)
+				for _, l := range f.Lines {
+					fmt.Printf("%s%s\n", l.Op, l.Text)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// --------------------------------------------------------------------
+// skillbox skill activate
+// --------------------------------------------------------------------
+
+func newSkillActivateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "activate <name> <version>",
+		Short: "Make a version the one that runs when no version is pinned",
+		Long: `Make <version> the active version of a skill, the one "latest"
+resolves to. Exits with code 5 when the version is not available.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			if err := client.SetActiveVersion(ctx, args[0], args[1]); err != nil {
+				return err
+			}
+			fmt.Printf("Activated %s@%s\n", args[0], args[1])
+			return nil
+		},
+	}
+}
+
+// --------------------------------------------------------------------
+// skillbox skill delete
+// --------------------------------------------------------------------
+
+func newSkillDeleteCmd() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "delete <name> [version]",
+		Short: "Delete one version of a skill, or all of them with --all",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if all == (len(args) == 2) {
+				return usageError(fmt.Errorf("pass either a version or --all"))
+			}
+
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			if all {
+				if err := client.DeleteSkillAllVersions(ctx, args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("Deleted all versions of %s\n", args[0])
+				return nil
+			}
+			if err := client.DeleteSkill(ctx, args[0], args[1]); err != nil {
+				return err
+			}
+			fmt.Printf("Deleted %s@%s\n", args[0], args[1])
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Delete every version and its history")
+	return cmd
+}
+
+// --------------------------------------------------------------------
+// skillbox skill pull
+// --------------------------------------------------------------------
+
+func newSkillPullCmd() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "pull <name>[@version]",
+		Short: "Download a skill's source files into a directory",
+		Long: `Download the source files of a skill version (the active version if
+none is given) into a new directory, ready for "skillbox dev" and
+"skillbox skill push".`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, ver, ok := strings.Cut(args[0], "@")
+			if !ok {
+				ver = "latest"
+			}
+			if dir == "" {
+				dir = name
+			}
+
+			client := newClient()
+			ctx, cancel := contextWithTimeout()
+			defer cancel()
+
+			entries, err := client.GetSkillFiles(ctx, name, ver)
+			if err != nil {
+				return err
+			}
+			files := make(map[string][]byte, len(entries))
+			for _, e := range entries {
+				if !filepath.IsLocal(filepath.FromSlash(e.Path)) {
+					return fmt.Errorf("skill file %q escapes the skill directory", e.Path)
+				}
+				files[e.Path] = []byte(e.Content)
+			}
+			if err := scaffold.Write(dir, files); err != nil {
+				return err
+			}
+			fmt.Printf("Pulled %s@%s into %s (%d files)\n", name, ver, dir, len(files))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory to create (default: ./<name>)")
+	return cmd
+}
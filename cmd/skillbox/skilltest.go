@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/devs-group/skillbox/internal/sandboxenv"
+	"github.com/devs-group/skillbox/internal/skilltest"
+)
+
+// --------------------------------------------------------------------
+// skillbox skill test
+// --------------------------------------------------------------------
+
+func newSkillTestCmd() *cobra.Command {
+	var envVars []string
+
+	cmd := &cobra.Command{
+		Use:   "test <dir>",
+		Short: "Run a skill's test cases locally",
+		Long: `Run the test cases under <dir>/tests the way "skillbox dev" runs the
+skill, and check each output against the case's expected.json,
+expected.subset.json or expected.schema.json. The server runs the same
+cases when a version is published; a version whose cases fail is marked
+test_failed instead of becoming available.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := filepath.Abs(args[0])
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stat(filepath.Join(dir, "SKILL.md")); os.IsNotExist(err) {
+				return fmt.Errorf("SKILL.md not found in %s", args[0])
+			}
+			cases, err := skilltest.Load(os.DirFS(dir))
+			if err != nil {
+				return err
+			}
+			if len(cases) == 0 {
+				fmt.Printf("No test cases found in %s\n", filepath.Join(args[0], skilltest.Dir))
+				return nil
+			}
+			var env map[string]string
+			if len(envVars) > 0 {
+				env = make(map[string]string, len(envVars))
+				for _, kv := range envVars {
+					parts := strings.SplitN(kv, "=", 2)
+					if len(parts) != 2 {
+						return fmt.Errorf("invalid --env value %q: must be KEY=VALUE", kv)
+					}
+					env[parts[0]] = parts[1]
+				}
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			report := &skilltest.Report{}
+			if !structuredOutput() {
+				fmt.Println("Testing", args[0])
+			}
+			for i := range cases {
+				res, err := runSkillTestCase(ctx, dir, &cases[i], env)
+				if err != nil {
+					return fmt.Errorf("test case %q: %w", cases[i].Name, err)
+				}
+				report.Add(res)
+				if structuredOutput() {
+					continue
+				}
+				if res.Passed {
+					fmt.Printf("  PASS  %s (%dms)\n", res.Name, res.DurationMs)
+				} else {
+					fmt.Printf("  FAIL  %s: %s\n", res.Name, res.Error)
+				}
+			}
+
+			if structuredOutput() {
+				if err := printStructured(report); err != nil {
+					return err
+				}
+			} else {
+				fmt.Printf("%d passed, %d failed\n", report.Passed, report.Failed)
+			}
+			if !report.OK() {
+				return &exitError{code: exitFailed, err: fmt.Errorf("tests failed")}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&envVars, "env", nil, "Environment variables as KEY=VALUE (repeatable)")
+	return cmd
+}
+
+// runSkillTestCase runs the skill in dir with the case's input and files in
+// a throwaway local sandbox tree and evaluates the result.
+func runSkillTestCase(ctx context.Context, dir string, c *skilltest.Case, env map[string]string) (skilltest.Result, error) {
+	root, err := os.MkdirTemp("", "skillbox-test-*")
+	if err != nil {
+		return skilltest.Result{}, fmt.Errorf("create sandbox directory: %w", err)
+	}
+	defer os.RemoveAll(root) //nolint:errcheck
+
+	layout := sandboxenv.Layout{
+		Root: filepath.Join(root, "sandbox"),
+		Home: filepath.Join(root, "home"),
+	}
+	result, err := devRun(ctx, dir, layout, devOptions{input: c.Input, env: env, files: c.Files})
+	if err != nil {
+		return skilltest.Result{}, err
+	}
+	return skilltest.Evaluate(c, result.Status, result.Error, result.Output, result.DurationMs), nil
+}
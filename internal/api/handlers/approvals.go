@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"net/http"
 
@@ -18,7 +19,8 @@ type createApprovalRequestBody struct {
 }
 
 // CreateApprovalRequest handles POST /v1/approvals.
-// Creates or updates an approval request for a skill.
+// Creates or updates an approval request for a skill. A missing or "latest"
+// skill_version is pinned to the newest available marketplace version.
 func CreateApprovalRequest(s *store.Store) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tenantID := middleware.GetTenantID(c)
@@ -40,15 +42,21 @@ func CreateApprovalRequest(s *store.Store) gin.HandlerFunc {
 			return
 		}
 
-		if req.SkillVersion == "" {
-			req.SkillVersion = "latest"
+		version, err := resolveApprovalVersion(c.Request.Context(), s, req.SkillName, req.SkillVersion)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				response.RespondError(c, http.StatusNotFound, "not_found", "skill not found")
+				return
+			}
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to resolve skill version")
+			return
 		}
 
 		ar := &store.ApprovalRequest{
 			TenantID:     tenantID,
 			UserID:       userID.(string),
 			SkillName:    req.SkillName,
-			SkillVersion: req.SkillVersion,
+			SkillVersion: version,
 		}
 
 		result, err := s.CreateApprovalRequest(c.Request.Context(), ar)
@@ -135,6 +143,21 @@ func UpdateApprovalRequest(s *store.Store) gin.HandlerFunc {
 			return
 		}
 
+		// Requests made before approvals were pinned to a version carry
+		// "latest"; approve the version that is latest now.
+		version := ar.SkillVersion
+		if req.Status == "approved" {
+			version, err = resolveApprovalVersion(c.Request.Context(), s, ar.SkillName, ar.SkillVersion)
+			if err != nil {
+				if errors.Is(err, store.ErrNotFound) {
+					response.RespondError(c, http.StatusNotFound, "not_found", "skill not found")
+					return
+				}
+				response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to resolve skill version")
+				return
+			}
+		}
+
 		if err := s.UpdateApprovalStatus(c.Request.Context(), id, req.Status, reviewerID.(string), req.Comment); err != nil {
 			if errors.Is(err, store.ErrNotFound) {
 				response.RespondError(c, http.StatusNotFound, "not_found", "approval request not found")
@@ -146,7 +169,7 @@ func UpdateApprovalRequest(s *store.Store) gin.HandlerFunc {
 
 		// When approved, also register the skill as approved for the tenant.
 		if req.Status == "approved" {
-			if err := s.ApproveSkillForTenant(c.Request.Context(), ar.TenantID, ar.SkillName, ar.SkillVersion, reviewerID.(string)); err != nil {
+			if err := s.ApproveSkillForTenant(c.Request.Context(), ar.TenantID, ar.SkillName, version, reviewerID.(string)); err != nil {
 				response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to approve skill for tenant")
 				return
 			}
@@ -155,3 +178,17 @@ func UpdateApprovalRequest(s *store.Store) gin.HandlerFunc {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	}
 }
+
+// resolveApprovalVersion returns the version an approval of version
+// applies to. Approvals are per version, so an empty version or "latest"
+// resolves to the newest available marketplace version of name.
+func resolveApprovalVersion(ctx context.Context, s *store.Store, name, version string) (string, error) {
+	if version != "" && version != "latest" {
+		return version, nil
+	}
+	rec, err := s.GetMarketplaceSkill(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return rec.Version, nil
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/devs-group/skillbox/internal/api/middleware"
+	"github.com/devs-group/skillbox/internal/api/response"
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+// RevokeAPIKey handles DELETE /v1/admin/api-keys/:id.
+// Revokes one of the tenant's API keys and evicts it from the auth cache.
+func RevokeAPIKey(auth *middleware.Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := middleware.GetTenantID(c)
+
+		id := c.Param("id")
+		if _, err := uuid.Parse(id); err != nil {
+			response.RespondError(c, http.StatusNotFound, "not_found", "api key not found or already revoked")
+			return
+		}
+
+		if err := auth.RevokeKey(c.Request.Context(), tenantID, id); err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				response.RespondError(c, http.StatusNotFound, "not_found", "api key not found or already revoked")
+				return
+			}
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to revoke api key")
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// AuthStats handles GET /v1/admin/auth/stats.
+// Returns auth cache hit rate and authentication latency.
+func AuthStats(auth *middleware.Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, auth.Metrics().Snapshot())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
@@ -97,6 +98,35 @@ func CreateExecution(r *runner.Runner) gin.HandlerFunc {
 	}
 }
 
+// ListExecutions handles GET /v1/executions.
+// It returns the tenant's executions, newest first, optionally filtered by
+// skill name and status.
+func ListExecutions(s *store.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+		offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+		execs, err := s.ListExecutions(c.Request.Context(), store.ExecutionFilter{
+			TenantID: middleware.GetTenantID(c),
+			Skill:    c.Query("skill"),
+			Status:   c.Query("status"),
+			Limit:    limit,
+			Offset:   offset,
+		})
+		if err != nil {
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to list executions")
+			return
+		}
+
+		// Always return an array, even if empty.
+		if execs == nil {
+			execs = []store.Execution{}
+		}
+
+		c.JSON(http.StatusOK, execs)
+	}
+}
+
 // GetExecution handles GET /v1/executions/:id.
 // It retrieves an execution record from the store and enforces tenant
 // isolation: the caller's tenant must match the execution's tenant.
@@ -149,3 +179,44 @@ func GetExecutionLogs(s *store.Store) gin.HandlerFunc {
 		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(exec.Logs))
 	}
 }
+
+// CancelExecution handles POST /v1/executions/:id/cancel.
+// It stops a running execution; the original request then returns the
+// result with status "cancelled". Executions run on the server that
+// received them, so a cancel that reaches another replica gets 409.
+func CancelExecution(r *runner.Runner, s *store.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+		if id == "" {
+			response.RespondError(c, http.StatusBadRequest, "bad_request", "execution id is required")
+			return
+		}
+
+		tenantID := middleware.GetTenantID(c)
+
+		exec, err := s.GetExecution(c.Request.Context(), id, tenantID)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				response.RespondError(c, http.StatusNotFound, "not_found", "execution not found")
+				return
+			}
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to retrieve execution")
+			return
+		}
+		if exec.Status != "running" {
+			response.RespondError(c, http.StatusConflict, "not_running", "execution is not running (status: "+exec.Status+")")
+			return
+		}
+
+		if err := r.Cancel(tenantID, id); err != nil {
+			if errors.Is(err, runner.ErrExecutionNotRunning) {
+				response.RespondError(c, http.StatusConflict, "not_running", "execution is not running on this server")
+				return
+			}
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to cancel execution")
+			return
+		}
+
+		c.JSON(http.StatusAccepted, cancelExecutionResponse{ExecutionID: id, Status: "cancelling"})
+	}
+}
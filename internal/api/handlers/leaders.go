@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devs-group/skillbox/internal/api/response"
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+// ListLeaders handles GET /v1/admin/leaders.
+// Returns which server replica holds each singleton background role.
+func ListLeaders(s *store.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		roles, err := s.ListLeaderRoles(c.Request.Context())
+		if err != nil {
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to list leader roles")
+			return
+		}
+
+		if roles == nil {
+			roles = []store.LeaderRole{}
+		}
+
+		c.JSON(http.StatusOK, roles)
+	}
+}
@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -9,6 +12,8 @@ import (
 
 	"github.com/devs-group/skillbox/internal/api/middleware"
 	"github.com/devs-group/skillbox/internal/api/response"
+	"github.com/devs-group/skillbox/internal/registry"
+	"github.com/devs-group/skillbox/internal/skill"
 	"github.com/devs-group/skillbox/internal/store"
 )
 
@@ -57,9 +62,10 @@ type marketplaceSkillResponse struct {
 	IsApproved *bool `json:"is_approved,omitempty"`
 }
 
-// GetMarketplaceSkill handles GET /v1/marketplace/skills/:name.
-// Returns detail for a public skill. If the user is authenticated, includes
-// the approval status for their tenant.
+// GetMarketplaceSkill handles GET /v1/marketplace/skills/:name?version=.
+// Returns detail for a public skill version (the latest available without
+// ?version). If the user is authenticated, includes the approval status of
+// that version for their tenant.
 func GetMarketplaceSkill(s *store.Store) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		name := c.Param("name")
@@ -67,8 +73,21 @@ func GetMarketplaceSkill(s *store.Store) gin.HandlerFunc {
 			response.RespondError(c, http.StatusBadRequest, "bad_request", "skill name is required")
 			return
 		}
+		version := c.Query("version")
+		if version != "" {
+			if err := skill.ValidateVersion(version); err != nil {
+				response.RespondError(c, http.StatusBadRequest, "bad_request", err.Error())
+				return
+			}
+		}
 
-		rec, err := s.GetMarketplaceSkill(c.Request.Context(), name)
+		var rec *store.SkillRecord
+		var err error
+		if version == "" {
+			rec, err = s.GetMarketplaceSkill(c.Request.Context(), name)
+		} else {
+			rec, err = s.GetMarketplaceSkillVersion(c.Request.Context(), name, version)
+		}
 		if err != nil {
 			if errors.Is(err, store.ErrNotFound) {
 				response.RespondError(c, http.StatusNotFound, "not_found", "skill not found")
@@ -82,9 +101,10 @@ func GetMarketplaceSkill(s *store.Store) gin.HandlerFunc {
 			SkillRecord: *rec,
 		}
 
-		// If user is authenticated, check approval status for their tenant.
+		// If user is authenticated, check approval status of this version
+		// for their tenant.
 		if tenantID, exists := c.Get(middleware.ContextKeyTenantID); exists {
-			approved, err := s.IsSkillApprovedForTenant(c.Request.Context(), tenantID.(string), name)
+			approved, err := s.IsSkillApprovedForTenant(c.Request.Context(), tenantID.(string), name, rec.Version)
 			if err == nil {
 				resp.IsApproved = &approved
 			}
@@ -93,3 +113,83 @@ func GetMarketplaceSkill(s *store.Store) gin.HandlerFunc {
 		c.JSON(http.StatusOK, resp)
 	}
 }
+
+// DownloadMarketplaceSkill handles GET /v1/marketplace/skills/:name/download?version=.
+// It streams the archive of a public skill version (the latest without
+// ?version) to an authenticated caller whose tenant has that version
+// approved. Versions that are not available are not found.
+// The X-Skillbox-Version header names the version served and
+// X-Skillbox-Digest carries "sha256:<hex>" of the archive, which the CLI
+// records in its lock file.
+func DownloadMarketplaceSkill(reg *registry.Registry, s *store.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+		if err := skill.ValidateName(name); err != nil {
+			response.RespondError(c, http.StatusBadRequest, "bad_request", err.Error())
+			return
+		}
+		version := c.Query("version")
+		if version != "" {
+			if err := skill.ValidateVersion(version); err != nil {
+				response.RespondError(c, http.StatusBadRequest, "bad_request", err.Error())
+				return
+			}
+		}
+
+		tenantID, ok := c.Get(middleware.ContextKeyTenantID)
+		if !ok {
+			response.RespondError(c, http.StatusUnauthorized, "unauthorized", "authentication is required to download skills")
+			return
+		}
+		ctx := c.Request.Context()
+
+		var rec *store.SkillRecord
+		var err error
+		if version == "" {
+			rec, err = s.GetMarketplaceSkill(ctx, name)
+		} else {
+			rec, err = s.GetMarketplaceSkillVersion(ctx, name, version)
+		}
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				response.RespondError(c, http.StatusNotFound, "not_found", "skill not found")
+				return
+			}
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to retrieve skill")
+			return
+		}
+
+		// Approval is per version: check the one being served, which for
+		// "latest" is only known now.
+		approved, err := s.IsSkillApprovedForTenant(ctx, tenantID.(string), name, rec.Version)
+		if err != nil {
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to check approval")
+			return
+		}
+		if !approved {
+			response.RespondError(c, http.StatusForbidden, "not_approved", "version "+rec.Version+" of the skill is not approved for your tenant")
+			return
+		}
+
+		rc, err := reg.Download(ctx, rec.TenantID, rec.Name, rec.Version)
+		if err != nil {
+			if errors.Is(err, registry.ErrSkillNotFound) {
+				response.RespondError(c, http.StatusNotFound, "not_found", "skill archive not found: "+name+"@"+rec.Version)
+				return
+			}
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to retrieve skill archive")
+			return
+		}
+		defer rc.Close() //nolint:errcheck
+
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to read skill archive")
+			return
+		}
+		sum := sha256.Sum256(data)
+		c.Header("X-Skillbox-Version", rec.Version)
+		c.Header("X-Skillbox-Digest", "sha256:"+hex.EncodeToString(sum[:]))
+		c.Data(http.StatusOK, "application/zip", data)
+	}
+}
@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/devs-group/skillbox/internal/api/middleware"
+	"github.com/devs-group/skillbox/internal/mcpserver"
+)
+
+// MCP handles /v1/mcp, the Model Context Protocol endpoint over
+// streamable HTTP. The caller's tenant decides which skills are listed as
+// tools; the optional X-Session-ID header selects the default workspace
+// session for the workspace tools.
+func MCP(srv *mcpserver.Server) gin.HandlerFunc {
+	h := srv.Handler()
+	return func(c *gin.Context) {
+		ctx := mcpserver.WithTenant(c.Request.Context(), middleware.GetTenantID(c), c.GetHeader("X-Session-ID"))
+		h.ServeHTTP(c.Writer, c.Request.WithContext(ctx))
+	}
+}
@@ -0,0 +1,702 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devs-group/skillbox/internal/api/openapi"
+	"github.com/devs-group/skillbox/internal/api/response"
+	"github.com/devs-group/skillbox/internal/authcache"
+	"github.com/devs-group/skillbox/internal/github"
+	"github.com/devs-group/skillbox/internal/runner"
+	"github.com/devs-group/skillbox/internal/sandbox"
+	"github.com/devs-group/skillbox/internal/scanner"
+	"github.com/devs-group/skillbox/internal/skill"
+	"github.com/devs-group/skillbox/internal/store"
+	"github.com/devs-group/skillbox/internal/tools"
+)
+
+// OpenAPI handles GET /v1/openapi.json. The document is rendered once.
+func OpenAPI() gin.HandlerFunc {
+	body, err := MarshalOpenAPI()
+	return func(c *gin.Context) {
+		if err != nil {
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to render OpenAPI document: "+err.Error())
+			return
+		}
+		c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+	}
+}
+
+// OpenAPIDocument describes every route NewRouter can register. Schemas
+// are generated from the request and response types of the handlers;
+// routes are checked against the router in tests.
+func OpenAPIDocument() (*openapi.Document, error) {
+	return openapi.Build(
+		openapi.Info{
+			Title:       "Skillbox API",
+			Description: "REST API for the Skillbox execution runtime — a self-hosted skill registry and sandbox execution engine for AI agents.",
+			Version:     "1.0.0",
+			License:     openapi.License{Name: "MIT"},
+		},
+		[]openapi.Server{{URL: "http://localhost:8080", Description: "Local development"}},
+		[]openapi.Tag{
+			{Name: "Health", Description: "Liveness and readiness probes"},
+			{Name: "Executions", Description: "Run skills and retrieve results"},
+			{Name: "Tools", Description: "Skills as LLM tools and the MCP endpoint"},
+			{Name: "Skills", Description: "Upload, list, and manage skills"},
+			{Name: "Files", Description: "Upload and manage input/output files"},
+			{Name: "Sessions", Description: "Persistent workspace files and snapshots"},
+			{Name: "Sandbox", Description: "Interactive sandbox shell commands"},
+			{Name: "Marketplace", Description: "Public skill marketplace and GitHub installs"},
+			{Name: "Users", Description: "Users, groups, and invite codes"},
+			{Name: "Approvals", Description: "Skill approval requests"},
+			{Name: "Admin", Description: "Scanner, review, and key administration"},
+		},
+		response.APIError{},
+		operations,
+	)
+}
+
+// MarshalOpenAPI renders the OpenAPI document as indented JSON, in the
+// form checked in at docs-site/openapi.json.
+func MarshalOpenAPI() ([]byte, error) {
+	doc, err := OpenAPIDocument()
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(body, '\n'), nil
+}
+
+// Response bodies the handlers build as gin.H. They exist for the
+// document only and must match the handlers.
+type (
+	healthResponse struct {
+		Status string `json:"status"`
+	}
+	readyResponse struct {
+		Status string            `json:"status"`
+		Checks map[string]string `json:"checks"`
+	}
+	statusResponse struct {
+		Status string `json:"status"`
+	}
+	cancelExecutionResponse struct {
+		ExecutionID string `json:"execution_id"`
+		Status      string `json:"status"`
+	}
+	skillAcceptedResponse struct {
+		Name        string `json:"name"`
+		Version     string `json:"version"`
+		Description string `json:"description,omitempty"`
+		Lang        string `json:"lang,omitempty"`
+		Mode        string `json:"mode,omitempty"`
+		Status      string `json:"status"`
+	}
+	validateSkillResponse struct {
+		Valid        bool   `json:"valid"`
+		SkillName    string `json:"skill_name"`
+		SkillVersion string `json:"skill_version"`
+		ScanTier     int    `json:"scan_tier"`
+		DurationMs   int64  `json:"duration_ms"`
+	}
+	skillDiffResponse struct {
+		Name  string     `json:"name"`
+		From  string     `json:"from"`
+		To    string     `json:"to"`
+		Files []fileDiff `json:"files"`
+	}
+	activeVersionResponse struct {
+		Name   string `json:"name"`
+		Active string `json:"active"`
+	}
+	skillReviewResponse struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		Status  string `json:"status"`
+	}
+	setPatternsResponse struct {
+		Status            string `json:"status"`
+		Message           string `json:"message,omitempty"`
+		BlockPatterns     int    `json:"block_patterns,omitempty"`
+		FlagPatterns      int    `json:"flag_patterns,omitempty"`
+		BlocklistPackages int    `json:"blocklist_packages,omitempty"`
+		PopularPackages   int    `json:"popular_packages,omitempty"`
+	}
+	marketplaceListResponse struct {
+		Skills []store.SkillRecord `json:"skills"`
+		Total  int                 `json:"total"`
+		Limit  int                 `json:"limit"`
+		Offset int                 `json:"offset"`
+	}
+	sandboxUploadFileResponse struct {
+		Status string `json:"status"`
+		Path   string `json:"path"`
+		Size   int    `json:"size"`
+	}
+)
+
+var (
+	sessionHeader = openapi.Param{Name: "X-Session-ID", In: "header", Required: true,
+		Description: "External ID of the session sandbox; created on first use"}
+	binaryBody = openapi.Schema{"type": "string", "format": "binary"}
+	anyBody    = openapi.Schema{}
+)
+
+var operations = []openapi.Operation{
+	// Health
+	{Method: http.MethodGet, Path: "/health", ID: "getHealth", Tag: "Health", Public: true,
+		Summary:     "Health check",
+		Description: "Returns 200 while the process is running.",
+		Responses:   []openapi.Response{{Status: 200, Description: "Server is healthy", Body: healthResponse{}}}},
+	{Method: http.MethodGet, Path: "/ready", ID: "getReady", Tag: "Health", Public: true,
+		Summary:     "Readiness check",
+		Description: "Reports whether every dependency, such as Postgres, is reachable.",
+		Responses: []openapi.Response{
+			{Status: 200, Description: "Server is ready", Body: readyResponse{}},
+			{Status: 503, Description: "A dependency is unreachable", Body: readyResponse{}},
+		}},
+	{Method: http.MethodGet, Path: "/v1/openapi.json", ID: "getOpenAPI", Tag: "Health", Public: true,
+		Summary:     "OpenAPI document",
+		Description: "Returns this document.",
+		Responses:   []openapi.Response{{Status: 200, Description: "OpenAPI 3.1 document", Body: anyBody}}},
+
+	// Executions
+	{Method: http.MethodPost, Path: "/v1/executions", ID: "createExecution", Tag: "Executions",
+		Summary:     "Create an execution",
+		Description: "Runs a skill in a sandbox and returns the result once it finishes.",
+		Request:     createExecutionRequest{},
+		Responses: []openapi.Response{
+			{Status: 200, Description: "Execution finished", Body: runner.RunResult{}},
+			{Status: 500, Description: "Execution failed", Body: runner.RunResult{}},
+		},
+		Errors: []int{400, 404, 409, 504}},
+	{Method: http.MethodGet, Path: "/v1/executions", ID: "listExecutions", Tag: "Executions",
+		Summary: "List executions",
+		Params: []openapi.Param{
+			{Name: "skill", Description: "Only executions of this skill"},
+			{Name: "status", Description: "running, success, failed, timeout, mounted, or cancelled"},
+			{Name: "limit", Type: "integer", Description: "Default 20, at most 100"},
+			{Name: "offset", Type: "integer"},
+		},
+		Responses: []openapi.Response{{Status: 200, Description: "Executions, newest first", Body: []store.Execution{}}},
+		Errors:    []int{500}},
+	{Method: http.MethodGet, Path: "/v1/executions/:id", ID: "getExecution", Tag: "Executions",
+		Summary:   "Get execution",
+		Responses: []openapi.Response{{Status: 200, Description: "Execution record", Body: store.Execution{}}},
+		Errors:    []int{400, 404, 500}},
+	{Method: http.MethodGet, Path: "/v1/executions/:id/logs", ID: "getExecutionLogs", Tag: "Executions",
+		Summary:   "Get execution logs",
+		Responses: []openapi.Response{{Status: 200, Description: "Plain-text logs", Body: openapi.Schema{"type": "string"}, ContentType: "text/plain"}},
+		Errors:    []int{400, 404, 500}},
+	{Method: http.MethodPost, Path: "/v1/executions/:id/cancel", ID: "cancelExecution", Tag: "Executions",
+		Summary:     "Cancel an execution",
+		Description: "Stops a running execution. The skill gets SIGTERM and the grace period to flush output; the execution then finishes with status cancelled.",
+		Responses:   []openapi.Response{{Status: 202, Description: "Cancellation requested", Body: cancelExecutionResponse{}}},
+		Errors:      []int{400, 404, 409, 500}},
+
+	// Tools
+	{Method: http.MethodGet, Path: "/v1/tools", ID: "listTools", Tag: "Tools",
+		Summary:     "List skills as tools",
+		Description: "Returns the tenant's runnable skills as tool definitions for an LLM provider.",
+		Params:      []openapi.Param{{Name: "format", Description: "openai (default), anthropic, gemini, or langchain"}},
+		Responses:   []openapi.Response{{Status: 200, Description: "Tool definitions", Body: tools.Export{}}},
+		Errors:      []int{400, 500}},
+	{Method: http.MethodPost, Path: "/v1/mcp", ID: "postMCP", Tag: "Tools",
+		Summary:     "MCP request",
+		Description: "Model Context Protocol over streamable HTTP. The body is a JSON-RPC message.",
+		Params:      []openapi.Param{{Name: "X-Session-ID", In: "header", Description: "Default session for the workspace tools"}},
+		Request:     anyBody,
+		Responses:   []openapi.Response{{Status: 200, Description: "JSON-RPC response or event stream"}}},
+	{Method: http.MethodGet, Path: "/v1/mcp", ID: "getMCP", Tag: "Tools",
+		Summary:   "MCP event stream",
+		Responses: []openapi.Response{{Status: 405, Description: "Not supported by the stateless server"}}},
+	{Method: http.MethodDelete, Path: "/v1/mcp", ID: "deleteMCP", Tag: "Tools",
+		Summary:   "End MCP session",
+		Responses: []openapi.Response{{Status: 405, Description: "Not supported by the stateless server"}}},
+
+	// Skills
+	{Method: http.MethodPost, Path: "/v1/skills", ID: "uploadSkill", Tag: "Skills",
+		Summary:     "Upload a skill",
+		Description: "Uploads a skill archive (.zip) with a SKILL.md. The skill stays pending until the security scan passes. A SKILL.sig publisher signature is verified against the tenant's trusted keys.",
+		Form:        []openapi.Param{{Name: "file", Type: "file", Required: true, Description: "Skill archive (.zip)"}},
+		RawBody:     []string{"application/zip", "application/octet-stream"},
+		Responses:   []openapi.Response{{Status: 202, Description: "Skill accepted for scanning", Body: skillAcceptedResponse{}}},
+		Errors:      []int{400, 403, 409, 413, 415, 422, 500}},
+	{Method: http.MethodPost, Path: "/v1/skills/from-fields", ID: "createSkillFromFields", Tag: "Skills",
+		Summary:     "Create a skill from fields",
+		Description: "Builds a skill archive from a name, description, and code.",
+		Request:     CreateFromFieldsRequest{},
+		Responses:   []openapi.Response{{Status: 202, Description: "Skill accepted for scanning", Body: skillAcceptedResponse{}}},
+		Errors:      []int{400, 403, 413, 422, 500}},
+	{Method: http.MethodPost, Path: "/v1/skills/validate", ID: "validateSkill", Tag: "Skills",
+		Summary:     "Validate a skill",
+		Description: "Parses and scans a skill archive without storing it.",
+		Form:        []openapi.Param{{Name: "file", Type: "file", Required: true, Description: "Skill archive (.zip)"}},
+		RawBody:     []string{"application/zip", "application/octet-stream"},
+		Responses:   []openapi.Response{{Status: 200, Description: "Skill is valid", Body: validateSkillResponse{}}},
+		Errors:      []int{400, 413, 415, 422, 500}},
+	{Method: http.MethodGet, Path: "/v1/skills", ID: "listSkills", Tag: "Skills",
+		Summary:   "List skills",
+		Params:    []openapi.Param{{Name: "status", Description: "Filter by status, or \"all\""}},
+		Responses: []openapi.Response{{Status: 200, Description: "Skill summaries", Body: []skill.SkillSummary{}}},
+		Errors:    []int{500}},
+	{Method: http.MethodGet, Path: "/v1/skills/:name/:version", ID: "getSkill", Tag: "Skills",
+		Summary:     "Get skill details",
+		Description: "Returns the skill's metadata and SKILL.md instructions. The version may be \"latest\".",
+		Responses:   []openapi.Response{{Status: 200, Description: "Skill metadata", Body: skill.SkillMetadata{}}},
+		Errors:      []int{400, 404, 500}},
+	{Method: http.MethodGet, Path: "/v1/skills/:name/:version/files", ID: "getSkillFiles", Tag: "Skills",
+		Summary:   "List skill files",
+		Params:    []openapi.Param{{Name: "path", Description: "Return only this file"}},
+		Responses: []openapi.Response{{Status: 200, Description: "Files with their content", Body: []SkillFileEntry{}}},
+		Errors:    []int{400, 404, 500}},
+	{Method: http.MethodGet, Path: "/v1/skills/:name/:version/sbom", ID: "getSkillSBOM", Tag: "Skills",
+		Summary:     "Get a skill's SBOM",
+		Description: "Returns the CycloneDX JSON SBOM generated when the version was scanned. The version may be \"latest\".",
+		Responses:   []openapi.Response{{Status: 200, Description: "CycloneDX SBOM", Body: scanner.SBOM{}, ContentType: "application/vnd.cyclonedx+json"}},
+		Errors:      []int{400, 404, 500}},
+	{Method: http.MethodGet, Path: "/v1/dependencies", ID: "listDependents", Tag: "Skills",
+		Summary:     "Find skills that depend on a package",
+		Description: "Lists every skill version whose SBOM includes the package.",
+		Params: []openapi.Param{
+			{Name: "package", Required: true, Description: "Package name"},
+			{Name: "ecosystem", Description: "PyPI or npm; both when omitted"},
+		},
+		Responses: []openapi.Response{{Status: 200, Description: "Skill versions and where they use the package", Body: []store.SkillDependency{}}},
+		Errors:    []int{400, 500}},
+	{Method: http.MethodDelete, Path: "/v1/skills/:name/:version", ID: "deleteSkill", Tag: "Skills",
+		Summary:   "Delete a skill version",
+		Responses: []openapi.Response{{Status: 204, Description: "Deleted"}},
+		Errors:    []int{400, 404, 500}},
+	{Method: http.MethodDelete, Path: "/v1/skills/:name", ID: "deleteSkillVersions", Tag: "Skills",
+		Summary:   "Delete all versions of a skill",
+		Responses: []openapi.Response{{Status: 204, Description: "Deleted"}},
+		Errors:    []int{400, 500}},
+	{Method: http.MethodPut, Path: "/v1/skills/:name/files", ID: "writeSkillFile", Tag: "Skills",
+		Summary:     "Write a skill file",
+		Description: "Writes one file and publishes the result as a new patch version.",
+		Request:     writeFileRequest{},
+		Responses:   []openapi.Response{{Status: 202, Description: "New version accepted for scanning", Body: skillAcceptedResponse{}}},
+		Errors:      []int{400, 403, 404, 413, 422, 500}},
+	{Method: http.MethodPut, Path: "/v1/skills/:name/files-batch", ID: "writeSkillFiles", Tag: "Skills",
+		Summary:     "Replace skill files",
+		Description: "Replaces the skill's file set and publishes it as a new patch version.",
+		Request:     writeFilesRequest{},
+		Responses:   []openapi.Response{{Status: 202, Description: "New version accepted for scanning", Body: skillAcceptedResponse{}}},
+		Errors:      []int{400, 403, 404, 413, 422, 500}},
+	{Method: http.MethodGet, Path: "/v1/skills/:name/versions", ID: "listSkillVersions", Tag: "Skills",
+		Summary:   "List skill versions",
+		Responses: []openapi.Response{{Status: 200, Description: "Versions, newest first", Body: []store.SkillVersionInfo{}}},
+		Errors:    []int{400, 500}},
+	{Method: http.MethodGet, Path: "/v1/skills/:name/diff", ID: "diffSkillVersions", Tag: "Skills",
+		Summary: "Diff two skill versions",
+		Params: []openapi.Param{
+			{Name: "from", Required: true},
+			{Name: "to", Required: true},
+		},
+		Responses: []openapi.Response{{Status: 200, Description: "Per-file line diff", Body: skillDiffResponse{}}},
+		Errors:    []int{400, 404}},
+	{Method: http.MethodPut, Path: "/v1/skills/:name/active", ID: "setActiveSkillVersion", Tag: "Skills",
+		Summary:   "Set the active version",
+		Request:   setActiveRequest{},
+		Responses: []openapi.Response{{Status: 200, Description: "Active version set", Body: activeVersionResponse{}}},
+		Errors:    []int{400, 404, 409, 500}},
+
+	// Admin
+	{Method: http.MethodGet, Path: "/v1/admin/scanner/stats", ID: "getScannerStats", Tag: "Admin",
+		Summary:   "Scanner metrics",
+		Responses: []openapi.Response{{Status: 200, Description: "Scanner metrics", Body: scanner.MetricsSnapshot{}}},
+		Errors:    []int{403}},
+	{Method: http.MethodGet, Path: "/v1/admin/scanner/patterns", ID: "getScannerPatterns", Tag: "Admin",
+		Summary:   "Get custom scanner patterns",
+		Responses: []openapi.Response{{Status: 200, Description: "Custom pattern overlay", Body: scanner.PatternFile{}}},
+		Errors:    []int{403}},
+	{Method: http.MethodPut, Path: "/v1/admin/scanner/patterns", ID: "setScannerPatterns", Tag: "Admin",
+		Summary:     "Set custom scanner patterns",
+		Description: "Replaces the custom pattern overlay. An empty pattern file clears it.",
+		Request:     scanner.PatternFile{},
+		Responses:   []openapi.Response{{Status: 200, Description: "Patterns loaded or cleared", Body: setPatternsResponse{}}},
+		Errors:      []int{400, 403, 500, 503}},
+	{Method: http.MethodGet, Path: "/v1/admin/scanner/config", ID: "getScannerConfig", Tag: "Admin",
+		Summary:   "Get scanner configuration",
+		Responses: []openapi.Response{{Status: 200, Description: "Tenant scanner configuration", Body: store.ScannerConfig{}}},
+		Errors:    []int{403, 500}},
+	{Method: http.MethodPut, Path: "/v1/admin/scanner/config", ID: "updateScannerConfig", Tag: "Admin",
+		Summary:   "Update scanner configuration",
+		Request:   updateScannerConfigRequest{},
+		Responses: []openapi.Response{{Status: 200, Description: "Updated configuration", Body: store.ScannerConfig{}}},
+		Errors:    []int{400, 403, 500}},
+	{Method: http.MethodGet, Path: "/v1/admin/skills/review", ID: "listSkillsForReview", Tag: "Admin",
+		Summary:   "List skills awaiting review",
+		Responses: []openapi.Response{{Status: 200, Description: "Skills in review", Body: []store.SkillRecord{}}},
+		Errors:    []int{403, 500}},
+	{Method: http.MethodPut, Path: "/v1/admin/skills/:name/:version/review", ID: "reviewSkill", Tag: "Admin",
+		Summary:   "Review a skill",
+		Request:   reviewRequest{},
+		Responses: []openapi.Response{{Status: 200, Description: "Review recorded", Body: skillReviewResponse{}}},
+		Errors:    []int{400, 403, 404, 500}},
+	{Method: http.MethodGet, Path: "/v1/admin/leaders", ID: "listLeaders", Tag: "Admin",
+		Summary:   "List leader roles",
+		Responses: []openapi.Response{{Status: 200, Description: "Background jobs and their leaders", Body: []store.LeaderRole{}}},
+		Errors:    []int{403, 500}},
+	{Method: http.MethodGet, Path: "/v1/admin/auth/stats", ID: "getAuthStats", Tag: "Admin",
+		Summary:   "Auth cache metrics",
+		Responses: []openapi.Response{{Status: 200, Description: "Auth cache metrics", Body: authcache.MetricsSnapshot{}}},
+		Errors:    []int{403}},
+	{Method: http.MethodDelete, Path: "/v1/admin/api-keys/:id", ID: "revokeAPIKey", Tag: "Admin",
+		Summary:   "Revoke an API key",
+		Responses: []openapi.Response{{Status: 204, Description: "Revoked"}},
+		Errors:    []int{403, 404, 500}},
+	{Method: http.MethodGet, Path: "/v1/admin/publisher-keys", ID: "listPublisherKeys", Tag: "Admin",
+		Summary:   "List trusted publisher keys",
+		Responses: []openapi.Response{{Status: 200, Description: "Keys trusted to sign skills", Body: []store.PublisherKey{}}},
+		Errors:    []int{403, 500}},
+	{Method: http.MethodPost, Path: "/v1/admin/publisher-keys", ID: "addPublisherKey", Tag: "Admin",
+		Summary:   "Trust a publisher key",
+		Request:   addPublisherKeyRequest{},
+		Responses: []openapi.Response{{Status: 201, Description: "Key registered", Body: store.PublisherKey{}}},
+		Errors:    []int{400, 403, 409, 500}},
+	{Method: http.MethodDelete, Path: "/v1/admin/publisher-keys/:key_id", ID: "deletePublisherKey", Tag: "Admin",
+		Summary:   "Remove a publisher key",
+		Responses: []openapi.Response{{Status: 204, Description: "Removed"}},
+		Errors:    []int{403, 404, 500}},
+
+	// Files
+	{Method: http.MethodPost, Path: "/v1/files", ID: "uploadFile", Tag: "Files",
+		Summary: "Upload a file",
+		Form: []openapi.Param{
+			{Name: "file", Type: "file", Required: true},
+			{Name: "name", Description: "Defaults to the uploaded file name"},
+		},
+		Responses: []openapi.Response{{Status: 201, Description: "File created", Body: store.File{}}},
+		Errors:    []int{400, 500}},
+	{Method: http.MethodGet, Path: "/v1/files", ID: "listFiles", Tag: "Files",
+		Summary: "List files",
+		Params: []openapi.Param{
+			{Name: "session_id"},
+			{Name: "execution_id"},
+			{Name: "limit", Type: "integer", Description: "Default 50"},
+			{Name: "offset", Type: "integer"},
+		},
+		Responses: []openapi.Response{{Status: 200, Description: "Files", Body: []store.File{}}},
+		Errors:    []int{500}},
+	{Method: http.MethodGet, Path: "/v1/files/:id", ID: "getFile", Tag: "Files",
+		Summary:   "Get file metadata",
+		Responses: []openapi.Response{{Status: 200, Description: "File metadata", Body: store.File{}}},
+		Errors:    []int{400, 404, 500}},
+	{Method: http.MethodGet, Path: "/v1/files/:id/download", ID: "downloadFile", Tag: "Files",
+		Summary:   "Download a file",
+		Responses: []openapi.Response{{Status: 200, Description: "File content", Body: binaryBody, ContentType: "application/octet-stream"}},
+		Errors:    []int{400, 404, 500}},
+	{Method: http.MethodPut, Path: "/v1/files/:id", ID: "updateFile", Tag: "Files",
+		Summary:     "Update a file",
+		Description: "Uploads new content as the next version of the file.",
+		Form:        []openapi.Param{{Name: "file", Type: "file", Required: true}},
+		Responses:   []openapi.Response{{Status: 200, Description: "New version", Body: store.File{}}},
+		Errors:      []int{400, 404, 500}},
+	{Method: http.MethodDelete, Path: "/v1/files/:id", ID: "deleteFile", Tag: "Files",
+		Summary:   "Delete a file",
+		Responses: []openapi.Response{{Status: 204, Description: "Deleted"}},
+		Errors:    []int{400, 404, 500}},
+	{Method: http.MethodGet, Path: "/v1/files/:id/versions", ID: "listFileVersions", Tag: "Files",
+		Summary:   "List file versions",
+		Responses: []openapi.Response{{Status: 200, Description: "Versions", Body: []store.File{}}},
+		Errors:    []int{400, 404, 500}},
+
+	// Sessions
+	{Method: http.MethodGet, Path: "/v1/sessions/:external_id/files", ID: "listSessionFiles", Tag: "Sessions",
+		Summary:   "List session files",
+		Responses: []openapi.Response{{Status: 200, Description: "Files", Body: []store.File{}}},
+		Errors:    []int{400, 500}},
+	{Method: http.MethodGet, Path: "/v1/sessions/:external_id/files/:filename", ID: "downloadSessionFile", Tag: "Sessions",
+		Summary:   "Download a session file",
+		Responses: []openapi.Response{{Status: 200, Description: "File content", Body: binaryBody, ContentType: "application/octet-stream"}},
+		Errors:    []int{400, 404, 500}},
+	{Method: http.MethodDelete, Path: "/v1/sessions/:external_id/files/:filename", ID: "deleteSessionFile", Tag: "Sessions",
+		Summary:   "Delete a session file",
+		Responses: []openapi.Response{{Status: 204, Description: "Deleted"}},
+		Errors:    []int{400, 404, 500}},
+	{Method: http.MethodDelete, Path: "/v1/sessions/:external_id", ID: "deleteSession", Tag: "Sessions",
+		Summary:   "Delete a session",
+		Responses: []openapi.Response{{Status: 204, Description: "Deleted"}},
+		Errors:    []int{400, 404, 500}},
+	{Method: http.MethodPost, Path: "/v1/sessions/:external_id/snapshots", ID: "createSnapshot", Tag: "Sessions",
+		Summary:   "Snapshot a session workspace",
+		Request:   CreateSnapshotRequest{},
+		Responses: []openapi.Response{{Status: 201, Description: "Snapshot created", Body: store.Snapshot{}}},
+		Errors:    []int{400, 404, 409, 413, 500}},
+	{Method: http.MethodGet, Path: "/v1/sessions/:external_id/snapshots", ID: "listSnapshots", Tag: "Sessions",
+		Summary:   "List snapshots",
+		Responses: []openapi.Response{{Status: 200, Description: "Snapshots", Body: []store.Snapshot{}}},
+		Errors:    []int{500}},
+	{Method: http.MethodDelete, Path: "/v1/sessions/:external_id/snapshots/:snapshot_id", ID: "deleteSnapshot", Tag: "Sessions",
+		Summary:   "Delete a snapshot",
+		Responses: []openapi.Response{{Status: 204, Description: "Deleted"}},
+		Errors:    []int{404, 500}},
+	{Method: http.MethodPost, Path: "/v1/sessions/:external_id/restore", ID: "restoreSnapshot", Tag: "Sessions",
+		Summary:   "Restore a snapshot",
+		Request:   RestoreSnapshotRequest{},
+		Responses: []openapi.Response{{Status: 200, Description: "Restored snapshot", Body: store.Snapshot{}}},
+		Errors:    []int{400, 404, 409, 500}},
+	{Method: http.MethodPost, Path: "/v1/sessions/:external_id/fork", ID: "forkSnapshot", Tag: "Sessions",
+		Summary:   "Fork a session from a snapshot",
+		Request:   ForkSnapshotRequest{},
+		Responses: []openapi.Response{{Status: 201, Description: "New session", Body: store.Session{}}},
+		Errors:    []int{400, 404, 409, 500}},
+
+	// Sandbox
+	{Method: http.MethodPost, Path: "/v1/sandbox/execute", ID: "sandboxExecute", Tag: "Sandbox",
+		Summary:   "Execute a command",
+		Params:    []openapi.Param{sessionHeader},
+		Request:   SandboxExecRequest{},
+		Responses: []openapi.Response{{Status: 200, Description: "Command result", Body: SandboxExecResponse{}}},
+		Errors:    []int{400, 500}},
+	{Method: http.MethodPost, Path: "/v1/sandbox/read-file", ID: "sandboxReadFile", Tag: "Sandbox",
+		Summary:   "Read a file",
+		Params:    []openapi.Param{sessionHeader},
+		Request:   SandboxReadRequest{},
+		Responses: []openapi.Response{{Status: 200, Description: "File content", Body: SandboxReadResponse{}}},
+		Errors:    []int{400, 500}},
+	{Method: http.MethodPost, Path: "/v1/sandbox/write-file", ID: "sandboxWriteFile", Tag: "Sandbox",
+		Summary:   "Write a file",
+		Params:    []openapi.Param{sessionHeader},
+		Request:   SandboxWriteRequest{},
+		Responses: []openapi.Response{{Status: 200, Description: "Written", Body: statusResponse{}}},
+		Errors:    []int{400, 500}},
+	{Method: http.MethodPost, Path: "/v1/sandbox/list-dir", ID: "sandboxListDir", Tag: "Sandbox",
+		Summary:   "List a directory",
+		Params:    []openapi.Param{sessionHeader},
+		Request:   SandboxListRequest{},
+		Responses: []openapi.Response{{Status: 200, Description: "Directory entries", Body: SandboxListResponse{}}},
+		Errors:    []int{400, 500}},
+	{Method: http.MethodPost, Path: "/v1/sandbox/search", ID: "sandboxSearch", Tag: "Sandbox",
+		Summary:   "Search files",
+		Params:    []openapi.Param{sessionHeader},
+		Request:   SandboxSearchRequest{},
+		Responses: []openapi.Response{{Status: 200, Description: "Matches", Body: sandbox.SearchResult{}}},
+		Errors:    []int{400, 500}},
+	{Method: http.MethodPost, Path: "/v1/sandbox/edit", ID: "sandboxEdit", Tag: "Sandbox",
+		Summary:     "Edit files",
+		Description: "Applies exact string replacements to one file or a unified diff to several.",
+		Params:      []openapi.Param{sessionHeader},
+		Request:     SandboxEditRequest{},
+		Responses:   []openapi.Response{{Status: 200, Description: "Edited files", Body: SandboxEditResponse{}}},
+		Errors:      []int{400, 409, 500}},
+	{Method: http.MethodPost, Path: "/v1/sandbox/sync", ID: "sandboxSync", Tag: "Sandbox",
+		Summary:   "Sync session files",
+		Params:    []openapi.Param{sessionHeader},
+		Responses: []openapi.Response{{Status: 200, Description: "Synced", Body: statusResponse{}}},
+		Errors:    []int{400, 500}},
+	{Method: http.MethodPost, Path: "/v1/sandbox/upload-skill", ID: "sandboxUploadSkill", Tag: "Sandbox",
+		Summary:   "Copy a skill into the sandbox",
+		Params:    []openapi.Param{sessionHeader},
+		Request:   UploadSkillRequest{},
+		Responses: []openapi.Response{{Status: 200, Description: "Uploaded files", Body: UploadSkillResponse{}}},
+		Errors:    []int{400, 404, 500}},
+	{Method: http.MethodPost, Path: "/v1/sandbox/upload-file", ID: "sandboxUploadFile", Tag: "Sandbox",
+		Summary: "Upload a binary file",
+		Params:  []openapi.Param{sessionHeader},
+		Form: []openapi.Param{
+			{Name: "path", Required: true, Description: "Target path in the sandbox"},
+			{Name: "file", Type: "file", Required: true},
+		},
+		Responses: []openapi.Response{{Status: 200, Description: "Uploaded", Body: sandboxUploadFileResponse{}}},
+		Errors:    []int{400, 500}},
+	{Method: http.MethodPost, Path: "/v1/sandbox/download-file", ID: "sandboxDownloadFile", Tag: "Sandbox",
+		Summary:   "Download a binary file",
+		Params:    []openapi.Param{sessionHeader},
+		Request:   SandboxReadRequest{},
+		Responses: []openapi.Response{{Status: 200, Description: "File content", Body: binaryBody, ContentType: "application/octet-stream"}},
+		Errors:    []int{400, 500}},
+	{Method: http.MethodPost, Path: "/v1/sandbox/processes", ID: "startProcess", Tag: "Sandbox",
+		Summary:   "Start a background process",
+		Params:    []openapi.Param{sessionHeader},
+		Request:   StartProcessRequest{},
+		Responses: []openapi.Response{{Status: 201, Description: "Process started", Body: sandbox.Process{}}},
+		Errors:    []int{400, 409, 500}},
+	{Method: http.MethodGet, Path: "/v1/sandbox/processes", ID: "listProcesses", Tag: "Sandbox",
+		Summary:   "List background processes",
+		Params:    []openapi.Param{sessionHeader},
+		Responses: []openapi.Response{{Status: 200, Description: "Processes", Body: []sandbox.Process{}}},
+		Errors:    []int{400, 500}},
+	{Method: http.MethodGet, Path: "/v1/sandbox/processes/:id", ID: "getProcess", Tag: "Sandbox",
+		Summary:   "Get a background process",
+		Params:    []openapi.Param{sessionHeader},
+		Responses: []openapi.Response{{Status: 200, Description: "Process", Body: sandbox.Process{}}},
+		Errors:    []int{400, 404, 500}},
+	{Method: http.MethodGet, Path: "/v1/sandbox/processes/:id/output", ID: "getProcessOutput", Tag: "Sandbox",
+		Summary: "Read process output",
+		Params: []openapi.Param{
+			sessionHeader,
+			{Name: "offset", Type: "integer", Description: "Byte offset to read from"},
+			{Name: "limit", Type: "integer", Description: "Maximum bytes to return"},
+		},
+		Responses: []openapi.Response{{Status: 200, Description: "Output chunk", Body: sandbox.ProcessOutput{}}},
+		Errors:    []int{400, 404, 500}},
+	{Method: http.MethodPost, Path: "/v1/sandbox/processes/:id/signal", ID: "signalProcess", Tag: "Sandbox",
+		Summary:   "Signal a background process",
+		Params:    []openapi.Param{sessionHeader},
+		Request:   SignalProcessRequest{},
+		Responses: []openapi.Response{{Status: 200, Description: "Signal sent", Body: statusResponse{}}},
+		Errors:    []int{400, 404, 409, 500}},
+	{Method: http.MethodPost, Path: "/v1/sandbox/processes/:id/wait", ID: "waitProcess", Tag: "Sandbox",
+		Summary:   "Wait for a background process",
+		Params:    []openapi.Param{sessionHeader},
+		Request:   WaitProcessRequest{},
+		Responses: []openapi.Response{{Status: 200, Description: "Process after it exited or the wait timed out", Body: sandbox.Process{}}},
+		Errors:    []int{400, 404, 500}},
+	{Method: http.MethodDelete, Path: "/v1/sandbox/:session", ID: "sandboxDestroy", Tag: "Sandbox",
+		Summary:   "Destroy a sandbox",
+		Responses: []openapi.Response{{Status: 204, Description: "Destroyed"}},
+		Errors:    []int{400, 404}},
+	{Method: http.MethodGet, Path: "/v1/sandbox/:session/pty", ID: "sandboxPTY", Tag: "Sandbox",
+		Summary:     "Interactive terminal",
+		Description: "Upgrades to a WebSocket bridged to a shell. Binary frames carry raw terminal I/O; text frames carry PTYControlMessage and PTYEventMessage JSON.",
+		Params: []openapi.Param{
+			{Name: "cols", Type: "integer", Description: "Default 80"},
+			{Name: "rows", Type: "integer", Description: "Default 24"},
+		},
+		Responses: []openapi.Response{{Status: 101, Description: "Switching to the WebSocket protocol"}},
+		Errors:    []int{400, 500}},
+	{Method: http.MethodPost, Path: "/v1/sandbox/:session/ports", ID: "registerPreviewPort", Tag: "Sandbox",
+		Summary:   "Expose a sandbox port",
+		Request:   RegisterPortRequest{},
+		Responses: []openapi.Response{{Status: 201, Description: "Preview URL", Body: RegisterPortResponse{}}},
+		Errors:    []int{400, 409, 500, 502}},
+	{Method: http.MethodGet, Path: "/v1/sandbox/:session/ports", ID: "listPreviewPorts", Tag: "Sandbox",
+		Summary:   "List exposed ports",
+		Responses: []openapi.Response{{Status: 200, Description: "Exposed ports", Body: []store.PreviewPort{}}},
+		Errors:    []int{500}},
+	{Method: http.MethodDelete, Path: "/v1/sandbox/:session/ports/:port", ID: "unregisterPreviewPort", Tag: "Sandbox",
+		Summary:   "Stop exposing a port",
+		Responses: []openapi.Response{{Status: 204, Description: "Removed"}},
+		Errors:    []int{400, 404, 500}},
+
+	// Marketplace
+	{Method: http.MethodGet, Path: "/v1/marketplace/skills", ID: "listMarketplaceSkills", Tag: "Marketplace", OptionalAuth: true,
+		Summary: "Search the marketplace",
+		Params: []openapi.Param{
+			{Name: "q"},
+			{Name: "limit", Type: "integer", Description: "Default 20"},
+			{Name: "offset", Type: "integer"},
+		},
+		Responses: []openapi.Response{{Status: 200, Description: "A page of public skills", Body: marketplaceListResponse{}}},
+		Errors:    []int{500}},
+	{Method: http.MethodGet, Path: "/v1/marketplace/skills/:name", ID: "getMarketplaceSkill", Tag: "Marketplace", OptionalAuth: true,
+		Summary:   "Get a marketplace skill",
+		Params:    []openapi.Param{{Name: "version", Description: "Default: the latest available version"}},
+		Responses: []openapi.Response{{Status: 200, Description: "Public skill; is_approved refers to this version", Body: marketplaceSkillResponse{}}},
+		Errors:    []int{400, 404, 500}},
+	{Method: http.MethodGet, Path: "/v1/marketplace/skills/:name/download", ID: "downloadMarketplaceSkill", Tag: "Marketplace", OptionalAuth: true,
+		Summary:     "Download a marketplace skill archive",
+		Description: "Requires authentication and approval of the served version for the caller's tenant. Only available versions are served. X-Skillbox-Version names the version served; X-Skillbox-Digest is sha256:<hex> of the archive.",
+		Params:      []openapi.Param{{Name: "version", Description: "Default: the latest available version"}},
+		Responses:   []openapi.Response{{Status: 200, Description: "Skill zip archive", Body: binaryBody, ContentType: "application/zip"}},
+		Errors:      []int{400, 401, 403, 404, 500}},
+	{Method: http.MethodGet, Path: "/v1/github/search", ID: "searchGitHub", Tag: "Marketplace", Public: true,
+		Summary: "Search GitHub for skills",
+		Params: []openapi.Param{
+			{Name: "q", Required: true},
+			{Name: "page", Type: "integer"},
+		},
+		Responses: []openapi.Response{{Status: 200, Description: "Matching SKILL.md files", Body: github.SearchResponse{}}},
+		Errors:    []int{400, 502}},
+	{Method: http.MethodGet, Path: "/v1/github/preview", ID: "previewGitHub", Tag: "Marketplace", Public: true,
+		Summary: "Preview a GitHub skill",
+		Params: []openapi.Param{
+			{Name: "owner", Required: true},
+			{Name: "repo", Required: true},
+			{Name: "path", Required: true, Description: "Path of the SKILL.md"},
+		},
+		Responses: []openapi.Response{{Status: 200, Description: "Parsed skill", Body: github.PreviewResponse{}}},
+		Errors:    []int{400, 422, 502}},
+	{Method: http.MethodPost, Path: "/v1/github/install", ID: "installFromGitHub", Tag: "Marketplace",
+		Summary:   "Install a skill from GitHub",
+		Request:   github.InstallRequest{},
+		Responses: []openapi.Response{{Status: 202, Description: "Skill accepted for scanning", Body: github.InstallResponse{}}},
+		Errors:    []int{400, 403, 409, 422, 502}},
+
+	// Users
+	{Method: http.MethodGet, Path: "/v1/users/me", ID: "getCurrentUser", Tag: "Users",
+		Summary:   "Get the current user",
+		Responses: []openapi.Response{{Status: 200, Description: "User", Body: store.User{}}},
+		Errors:    []int{404, 500}},
+	{Method: http.MethodGet, Path: "/v1/users", ID: "listUsers", Tag: "Users",
+		Summary:   "List users",
+		Responses: []openapi.Response{{Status: 200, Description: "Users of the tenant", Body: []store.User{}}},
+		Errors:    []int{403, 500}},
+	{Method: http.MethodPut, Path: "/v1/users/:id/role", ID: "updateUserRole", Tag: "Users",
+		Summary:   "Change a user's role",
+		Request:   updateUserRoleRequest{},
+		Responses: []openapi.Response{{Status: 200, Description: "Role changed", Body: statusResponse{}}},
+		Errors:    []int{400, 403, 404, 500}},
+	{Method: http.MethodPost, Path: "/v1/groups", ID: "createGroup", Tag: "Users",
+		Summary:   "Create a group",
+		Request:   createGroupRequest{},
+		Responses: []openapi.Response{{Status: 201, Description: "Group created", Body: store.Group{}}},
+		Errors:    []int{400, 403, 500}},
+	{Method: http.MethodGet, Path: "/v1/groups", ID: "listGroups", Tag: "Users",
+		Summary:   "List groups",
+		Responses: []openapi.Response{{Status: 200, Description: "Groups", Body: []store.Group{}}},
+		Errors:    []int{403, 500}},
+	{Method: http.MethodPut, Path: "/v1/groups/:id", ID: "updateGroup", Tag: "Users",
+		Summary:   "Update a group",
+		Request:   updateGroupRequest{},
+		Responses: []openapi.Response{{Status: 200, Description: "Updated group", Body: store.Group{}}},
+		Errors:    []int{400, 403, 404, 500}},
+	{Method: http.MethodDelete, Path: "/v1/groups/:id", ID: "deleteGroup", Tag: "Users",
+		Summary:   "Delete a group",
+		Responses: []openapi.Response{{Status: 204, Description: "Deleted"}},
+		Errors:    []int{400, 403, 404, 500}},
+	{Method: http.MethodPost, Path: "/v1/groups/:id/members", ID: "addGroupMember", Tag: "Users",
+		Summary:   "Add a group member",
+		Request:   addGroupMemberRequest{},
+		Responses: []openapi.Response{{Status: 200, Description: "Member added", Body: statusResponse{}}},
+		Errors:    []int{400, 403, 500}},
+	{Method: http.MethodDelete, Path: "/v1/groups/:id/members/:userId", ID: "removeGroupMember", Tag: "Users",
+		Summary:   "Remove a group member",
+		Responses: []openapi.Response{{Status: 204, Description: "Removed"}},
+		Errors:    []int{400, 403, 404, 500}},
+	{Method: http.MethodGet, Path: "/v1/groups/:id/members", ID: "listGroupMembers", Tag: "Users",
+		Summary:   "List group members",
+		Responses: []openapi.Response{{Status: 200, Description: "Members", Body: []store.User{}}},
+		Errors:    []int{400, 403, 500}},
+	{Method: http.MethodPost, Path: "/v1/invites", ID: "createInviteCode", Tag: "Users",
+		Summary:   "Create an invite code",
+		Responses: []openapi.Response{{Status: 201, Description: "Invite code", Body: store.InviteCode{}}},
+		Errors:    []int{403, 500}},
+	{Method: http.MethodGet, Path: "/v1/invites", ID: "listInviteCodes", Tag: "Users",
+		Summary:   "List invite codes",
+		Responses: []openapi.Response{{Status: 200, Description: "Invite codes", Body: []store.InviteCode{}}},
+		Errors:    []int{403, 500}},
+	{Method: http.MethodPost, Path: "/v1/invites/redeem", ID: "redeemInviteCode", Tag: "Users",
+		Summary:   "Redeem an invite code",
+		Request:   redeemInviteCodeRequest{},
+		Responses: []openapi.Response{{Status: 200, Description: "Redeemed invite", Body: store.InviteCode{}}},
+		Errors:    []int{400, 404, 500}},
+
+	// Approvals
+	{Method: http.MethodPost, Path: "/v1/approvals", ID: "createApprovalRequest", Tag: "Approvals",
+		Summary:     "Request approval for a skill",
+		Description: "Approvals are per version. Without skill_version, or with \"latest\", the request is for the latest available marketplace version.",
+		Request:     createApprovalRequestBody{},
+		Responses:   []openapi.Response{{Status: 201, Description: "Approval request", Body: store.ApprovalRequest{}}},
+		Errors:      []int{400, 404, 500}},
+	{Method: http.MethodGet, Path: "/v1/approvals", ID: "listApprovalRequests", Tag: "Approvals",
+		Summary:   "List approval requests",
+		Params:    []openapi.Param{{Name: "status"}},
+		Responses: []openapi.Response{{Status: 200, Description: "Approval requests", Body: []store.ApprovalRequest{}}},
+		Errors:    []int{500}},
+	{Method: http.MethodPut, Path: "/v1/approvals/:id", ID: "updateApprovalRequest", Tag: "Approvals",
+		Summary:   "Approve or reject a request",
+		Request:   updateApprovalRequestBody{},
+		Responses: []openapi.Response{{Status: 200, Description: "Request updated", Body: statusResponse{}}},
+		Errors:    []int{400, 403, 404, 500}},
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devs-group/skillbox/internal/api/middleware"
+	"github.com/devs-group/skillbox/internal/api/response"
+	"github.com/devs-group/skillbox/internal/signing"
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+// addPublisherKeyRequest is the JSON body for POST /v1/admin/publisher-keys.
+type addPublisherKeyRequest struct {
+	Name      string `json:"name" binding:"required"`
+	PublicKey string `json:"public_key" binding:"required"` // PEM-encoded ed25519 public key
+}
+
+// ListPublisherKeys handles GET /v1/admin/publisher-keys.
+// Returns the keys the tenant trusts to sign skills.
+func ListPublisherKeys(s *store.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := middleware.GetTenantID(c)
+
+		keys, err := s.ListPublisherKeys(c.Request.Context(), tenantID)
+		if err != nil {
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to list publisher keys")
+			return
+		}
+		if keys == nil {
+			keys = []store.PublisherKey{}
+		}
+
+		c.JSON(http.StatusOK, keys)
+	}
+}
+
+// AddPublisherKey handles POST /v1/admin/publisher-keys.
+// Registers a trusted publisher key; the key id is derived from the key.
+func AddPublisherKey(s *store.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := middleware.GetTenantID(c)
+
+		var req addPublisherKeyRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.RespondError(c, http.StatusBadRequest, "bad_request", "invalid request body: "+err.Error())
+			return
+		}
+		pub, err := signing.ParsePublicKey([]byte(req.PublicKey))
+		if err != nil {
+			response.RespondError(c, http.StatusBadRequest, "bad_request", "invalid public_key: "+err.Error())
+			return
+		}
+
+		key := &store.PublisherKey{
+			TenantID:  tenantID,
+			KeyID:     signing.KeyID(pub),
+			Name:      strings.TrimSpace(req.Name),
+			PublicKey: req.PublicKey,
+		}
+		if err := s.AddPublisherKey(c.Request.Context(), key); err != nil {
+			if errors.Is(err, store.ErrPublisherKeyExists) {
+				response.RespondError(c, http.StatusConflict, "conflict", "publisher key already registered: "+key.KeyID)
+				return
+			}
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to add publisher key")
+			return
+		}
+
+		c.JSON(http.StatusCreated, key)
+	}
+}
+
+// DeletePublisherKey handles DELETE /v1/admin/publisher-keys/:key_id.
+// Skills signed with the key fail verification from then on.
+func DeletePublisherKey(s *store.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := middleware.GetTenantID(c)
+
+		keyID := c.Param("key_id")
+		if err := s.DeletePublisherKey(c.Request.Context(), tenantID, keyID); err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				response.RespondError(c, http.StatusNotFound, "not_found", "publisher key not found: "+keyID)
+				return
+			}
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to delete publisher key")
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// respondSignatureError maps a signing.Verifier error to an API error.
+func respondSignatureError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, signing.ErrUnsigned):
+		response.RespondError(c, http.StatusUnprocessableEntity, "signature_required",
+			"this tenant only accepts skills signed with a registered publisher key")
+	case errors.Is(err, signing.ErrUntrustedKey):
+		response.RespondError(c, http.StatusUnprocessableEntity, "untrusted_signature", err.Error())
+	case errors.Is(err, signing.ErrInvalidSignature):
+		response.RespondError(c, http.StatusUnprocessableEntity, "invalid_signature", err.Error())
+	default:
+		response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to verify skill signature: "+err.Error())
+	}
+}
+
+// requireUnsignedAllowed rejects server-side skill edits for tenants that
+// only accept signed skills, since the server cannot sign on the
+// publisher's behalf. It reports whether the handler may continue.
+func requireUnsignedAllowed(c *gin.Context, s *store.Store, tenantID string) bool {
+	required, err := s.RequireSignedSkills(c.Request.Context(), tenantID)
+	if err != nil {
+		response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to get signing policy")
+		return false
+	}
+	if required {
+		respondSignatureError(c, signing.ErrUnsigned)
+		return false
+	}
+	return true
+}
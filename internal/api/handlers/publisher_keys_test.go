@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+
+	"github.com/devs-group/skillbox/internal/config"
+	"github.com/devs-group/skillbox/internal/signing"
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+func newTestKeyStore(t *testing.T) (*store.Store, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	return store.NewWithDB(db), mock, func() { db.Close() } //nolint:errcheck
+}
+
+func postPublisherKey(t *testing.T, s *store.Store, body map[string]any) *httptest.ResponseRecorder {
+	t.Helper()
+	raw, _ := json.Marshal(body)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/admin/publisher-keys", bytes.NewReader(raw))
+	c.Request.Header.Set("Content-Type", "application/json")
+	setTenantID(c, "tenant-1")
+	AddPublisherKey(s)(c)
+	return w
+}
+
+func TestAddPublisherKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s, mock, cleanup := newTestKeyStore(t)
+	defer cleanup()
+
+	_, pubPEM, err := signing.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	pub, _ := signing.ParsePublicKey(pubPEM)
+	keyID := signing.KeyID(pub)
+
+	mock.ExpectQuery("INSERT INTO sandbox.publisher_keys").
+		WithArgs("tenant-1", keyID, "release", string(pubPEM)).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+
+	w := postPublisherKey(t, s, map[string]any{"name": "release", "public_key": string(pubPEM)})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d; body: %s", w.Code, http.StatusCreated, w.Body.String())
+	}
+	var got store.PublisherKey
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.KeyID != keyID {
+		t.Errorf("key_id = %q, want %q", got.KeyID, keyID)
+	}
+
+	// Registering the same key again conflicts.
+	mock.ExpectQuery("INSERT INTO sandbox.publisher_keys").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}))
+
+	w = postPublisherKey(t, s, map[string]any{"name": "release", "public_key": string(pubPEM)})
+	if w.Code != http.StatusConflict {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusConflict)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAddPublisherKey_InvalidKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s, mock, cleanup := newTestKeyStore(t)
+	defer cleanup()
+
+	w := postPublisherKey(t, s, map[string]any{"name": "release", "public_key": "not a key"})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDeletePublisherKey_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s, mock, cleanup := newTestKeyStore(t)
+	defer cleanup()
+
+	mock.ExpectExec("DELETE FROM sandbox.publisher_keys").
+		WithArgs("tenant-1", "ed25519:0000000000000000").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodDelete, "/v1/admin/publisher-keys/ed25519:0000000000000000", nil)
+	c.Params = gin.Params{{Key: "key_id", Value: "ed25519:0000000000000000"}}
+	setTenantID(c, "tenant-1")
+	DeletePublisherKey(s)(c)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreateFromFields_RequiresSignature(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s, mock, cleanup := newTestKeyStore(t)
+	defer cleanup()
+
+	mock.ExpectQuery("SELECT 1 FROM sandbox.tenant_blocked_skills").
+		WillReturnRows(sqlmock.NewRows([]string{"?column?"}))
+	mock.ExpectQuery("SELECT require_signed FROM sandbox.scanner_config").
+		WithArgs("tenant-1").
+		WillReturnRows(sqlmock.NewRows([]string{"require_signed"}).AddRow(true))
+
+	raw, _ := json.Marshal(map[string]any{"name": "greet", "description": "says hi", "code": "print('hi')"})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/skills/from-fields", bytes.NewReader(raw))
+	c.Request.Header.Set("Content-Type", "application/json")
+	setTenantID(c, "tenant-1")
+	CreateFromFields(nil, s, &config.Config{MaxSkillSize: 10 << 20}, nil)(c)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want %d; body: %s", w.Code, http.StatusUnprocessableEntity, w.Body.String())
+	}
+	var e struct {
+		Error string `json:"error"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &e)
+	if e.Error != "signature_required" {
+		t.Errorf("error = %q, want %q", e.Error, "signature_required")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
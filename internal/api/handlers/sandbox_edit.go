@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devs-group/skillbox/internal/api/response"
+	"github.com/devs-group/skillbox/internal/sandbox"
+)
+
+// SandboxSearchRequest is the body for POST /v1/sandbox/search.
+type SandboxSearchRequest struct {
+	Path         string `json:"path,omitempty"`  // default: /sandbox/session
+	Query        string `json:"query,omitempty"` // empty: match file names only
+	Glob         string `json:"glob,omitempty"`  // e.g. "*.go" or "src/*.ts"
+	Regex        bool   `json:"regex,omitempty"`
+	IgnoreCase   bool   `json:"ignore_case,omitempty"`
+	ContextLines int    `json:"context_lines,omitempty"` // max: 5
+	MaxResults   int    `json:"max_results,omitempty"`   // default: 100, max: 1000
+}
+
+// SandboxEditRequest is the body for POST /v1/sandbox/edit. Exactly one of
+// Edits and Diff must be set.
+type SandboxEditRequest struct {
+	// Path is the file to edit. It is required with Edits and optional
+	// with Diff, where it overrides the path in a single-file diff.
+	Path  string                    `json:"path,omitempty"`
+	Edits []sandbox.EditReplacement `json:"edits,omitempty"`
+	Diff  string                    `json:"diff,omitempty"` // unified diff
+}
+
+// SandboxEditResponse is the response for POST /v1/sandbox/edit.
+type SandboxEditResponse struct {
+	Files []sandbox.FileEdit `json:"files"`
+}
+
+// Search handles POST /v1/sandbox/search.
+func (h *SandboxHandler) Search(c *gin.Context) {
+	key, ok := h.resolveSessionKey(c)
+	if !ok {
+		return
+	}
+
+	var req SandboxSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "invalid request body: "+err.Error())
+		return
+	}
+	if req.Path == "" {
+		req.Path = "/sandbox/session"
+	}
+	if err := sandbox.ValidateSandboxPath(req.Path); err != nil {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "invalid path: "+err.Error())
+		return
+	}
+
+	result, err := h.manager.Search(c.Request.Context(), key, sandbox.SearchOptions{
+		Path:         req.Path,
+		Glob:         req.Glob,
+		Query:        req.Query,
+		Regex:        req.Regex,
+		IgnoreCase:   req.IgnoreCase,
+		ContextLines: req.ContextLines,
+		MaxResults:   req.MaxResults,
+	})
+	if err != nil {
+		if errors.Is(err, sandbox.ErrInvalidPattern) {
+			response.RespondError(c, http.StatusBadRequest, "bad_request", err.Error())
+			return
+		}
+		response.RespondError(c, http.StatusInternalServerError, "search_error", "search failed: "+err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// Edit handles POST /v1/sandbox/edit. Edits are all-or-nothing: if any
+// replacement or hunk does not apply, nothing is written and the response
+// is 409 with the conflicts in details.
+func (h *SandboxHandler) Edit(c *gin.Context) {
+	key, ok := h.resolveSessionKey(c)
+	if !ok {
+		return
+	}
+
+	var req SandboxEditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "invalid request body: "+err.Error())
+		return
+	}
+	if (len(req.Edits) == 0) == (req.Diff == "") {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "exactly one of edits or diff is required")
+		return
+	}
+	if req.Path != "" {
+		if err := sandbox.ValidateSandboxPath(req.Path); err != nil {
+			response.RespondError(c, http.StatusBadRequest, "bad_request", "invalid path: "+err.Error())
+			return
+		}
+	}
+
+	var files []sandbox.FileEdit
+	var err error
+	if req.Diff != "" {
+		files, err = h.manager.ApplyPatch(c.Request.Context(), key, req.Diff, req.Path)
+	} else if req.Path == "" {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "path is required with edits")
+		return
+	} else {
+		var fe *sandbox.FileEdit
+		if fe, err = h.manager.EditFile(c.Request.Context(), key, req.Path, req.Edits); err == nil {
+			files = []sandbox.FileEdit{*fe}
+		}
+	}
+
+	var conflict *sandbox.EditConflictError
+	switch {
+	case errors.As(err, &conflict):
+		response.RespondErrorWithDetails(c, http.StatusConflict, "edit_conflict", err.Error(), conflict.Conflicts)
+	case errors.Is(err, sandbox.ErrInvalidPatch):
+		response.RespondError(c, http.StatusBadRequest, "bad_request", err.Error())
+	case err != nil:
+		response.RespondError(c, http.StatusBadRequest, "edit_error", "failed to edit file: "+err.Error())
+	default:
+		c.JSON(http.StatusOK, SandboxEditResponse{Files: files})
+	}
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devs-group/skillbox/internal/api/middleware"
+	"github.com/devs-group/skillbox/internal/api/response"
+	"github.com/devs-group/skillbox/internal/preview"
+	"github.com/devs-group/skillbox/internal/sandbox"
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+// maxPreviewPorts caps the ports a single session may expose.
+const maxPreviewPorts = 10
+
+// PreviewHandler groups the sandbox port preview handlers.
+type PreviewHandler struct {
+	manager *sandbox.SessionManager
+	store   *store.Store
+	proxy   *preview.Proxy
+}
+
+// NewPreviewHandler creates a handler with the session manager, store and
+// preview proxy dependencies.
+func NewPreviewHandler(sm *sandbox.SessionManager, s *store.Store, p *preview.Proxy) *PreviewHandler {
+	return &PreviewHandler{manager: sm, store: s, proxy: p}
+}
+
+// RegisterPortRequest is the body for POST /v1/sandbox/:session/ports.
+type RegisterPortRequest struct {
+	Port int `json:"port"`
+}
+
+// RegisterPortResponse is the response for POST /v1/sandbox/:session/ports.
+// Token is only returned here; registering the port again issues a new one.
+type RegisterPortResponse struct {
+	*store.PreviewPort
+	URL   string `json:"url"`
+	Token string `json:"token"`
+}
+
+// RegisterPort handles POST /v1/sandbox/:session/ports.
+func (h *PreviewHandler) RegisterPort(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	sessionID := c.Param("session")
+
+	var req RegisterPortRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "invalid request body: "+err.Error())
+		return
+	}
+	if req.Port < 1 || req.Port > 65535 {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "port must be between 1 and 65535")
+		return
+	}
+	if req.Port == sandbox.ExecDPort {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "port is reserved")
+		return
+	}
+
+	ms, err := h.manager.GetOrCreate(c.Request.Context(), tenantID, sessionID, sandbox.SandboxSessionOpts{})
+	if err != nil {
+		response.RespondError(c, http.StatusInternalServerError, "sandbox_error", "failed to get or create sandbox: "+err.Error())
+		return
+	}
+
+	existing, err := h.store.ListPreviewPorts(c.Request.Context(), tenantID, ms.ExternalID)
+	if err != nil {
+		response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to list ports: "+err.Error())
+		return
+	}
+	registered := false
+	for _, p := range existing {
+		registered = registered || p.Port == req.Port
+	}
+	if !registered && len(existing) >= maxPreviewPorts {
+		response.RespondError(c, http.StatusConflict, "too_many_ports",
+			"a session can expose at most "+strconv.Itoa(maxPreviewPorts)+" ports")
+		return
+	}
+
+	if _, err := h.manager.GetEndpoint(c.Request.Context(), ms.SandboxID, req.Port); err != nil {
+		response.RespondError(c, http.StatusBadGateway, "sandbox_error", "failed to expose port: "+err.Error())
+		return
+	}
+
+	token, hash, err := preview.NewToken()
+	if err != nil {
+		response.RespondError(c, http.StatusInternalServerError, "internal_error", err.Error())
+		return
+	}
+	pp, err := h.store.UpsertPreviewPort(c.Request.Context(), tenantID, ms.ExternalID, req.Port, hash)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			response.RespondError(c, http.StatusConflict, "sandbox_gone", "the session's sandbox was destroyed")
+			return
+		}
+		response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to register port: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, RegisterPortResponse{PreviewPort: pp, URL: h.proxy.URL(token), Token: token})
+}
+
+// ListPorts handles GET /v1/sandbox/:session/ports.
+func (h *PreviewHandler) ListPorts(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+
+	ports, err := h.store.ListPreviewPorts(c.Request.Context(), tenantID, c.Param("session"))
+	if err != nil {
+		response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to list ports: "+err.Error())
+		return
+	}
+	if ports == nil {
+		ports = []*store.PreviewPort{}
+	}
+	c.JSON(http.StatusOK, ports)
+}
+
+// UnregisterPort handles DELETE /v1/sandbox/:session/ports/:port.
+func (h *PreviewHandler) UnregisterPort(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	port, err := strconv.Atoi(c.Param("port"))
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "port must be a number")
+		return
+	}
+
+	hash, err := h.store.DeletePreviewPort(c.Request.Context(), tenantID, c.Param("session"), port)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			response.RespondError(c, http.StatusNotFound, "not_found", "port not registered")
+			return
+		}
+		response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to unregister port: "+err.Error())
+		return
+	}
+	h.proxy.Forget(hash)
+	c.Status(http.StatusNoContent)
+}
+
+// Serve handles ANY /preview/:token/*path, forwarding the request to the
+// sandbox port registered under token. It is not behind API
+// authentication: the token in the URL is the credential.
+func (h *PreviewHandler) Serve(c *gin.Context) {
+	h.proxy.ServePath(c.Writer, c.Request, c.Param("token"), c.Param("path"))
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devs-group/skillbox/internal/api/response"
+	"github.com/devs-group/skillbox/internal/sandbox"
+)
+
+// maxProcessWait caps how long POST /v1/sandbox/processes/:id/wait blocks.
+const maxProcessWait = 5 * time.Minute
+
+// StartProcessRequest is the body for POST /v1/sandbox/processes.
+type StartProcessRequest struct {
+	Command string `json:"command"`
+	WorkDir string `json:"workdir,omitempty"` // default: /sandbox/session
+}
+
+// SignalProcessRequest is the body for POST /v1/sandbox/processes/:id/signal.
+type SignalProcessRequest struct {
+	Signal string `json:"signal"` // e.g. TERM, INT, KILL
+}
+
+// WaitProcessRequest is the body for POST /v1/sandbox/processes/:id/wait.
+type WaitProcessRequest struct {
+	TimeoutMs int `json:"timeout_ms,omitempty"` // default: 30000, max: 300000
+}
+
+// StartProcess handles POST /v1/sandbox/processes.
+func (h *SandboxHandler) StartProcess(c *gin.Context) {
+	key, ok := h.resolveSessionKey(c)
+	if !ok {
+		return
+	}
+
+	var req StartProcessRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "invalid request body: "+err.Error())
+		return
+	}
+	if req.Command == "" {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "command is required")
+		return
+	}
+	if req.WorkDir == "" {
+		req.WorkDir = "/sandbox/session"
+	}
+	if err := sandbox.ValidateSandboxPath(req.WorkDir); err != nil {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "invalid workdir: "+err.Error())
+		return
+	}
+
+	p, err := h.manager.StartProcess(c.Request.Context(), key, req.Command, req.WorkDir)
+	if err != nil {
+		response.RespondError(c, http.StatusInternalServerError, "process_error", "failed to start process: "+err.Error())
+		return
+	}
+	c.JSON(http.StatusCreated, p)
+}
+
+// ListProcesses handles GET /v1/sandbox/processes.
+func (h *SandboxHandler) ListProcesses(c *gin.Context) {
+	key, ok := h.resolveSessionKey(c)
+	if !ok {
+		return
+	}
+
+	procs, err := h.manager.ListProcesses(c.Request.Context(), key)
+	if err != nil {
+		response.RespondError(c, http.StatusInternalServerError, "process_error", "failed to list processes: "+err.Error())
+		return
+	}
+	if procs == nil {
+		procs = []*sandbox.Process{}
+	}
+	c.JSON(http.StatusOK, procs)
+}
+
+// GetProcess handles GET /v1/sandbox/processes/:id.
+func (h *SandboxHandler) GetProcess(c *gin.Context) {
+	key, ok := h.resolveSessionKey(c)
+	if !ok {
+		return
+	}
+
+	p, err := h.manager.GetProcess(c.Request.Context(), key, c.Param("id"))
+	if err != nil {
+		respondProcessError(c, "failed to get process: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, p)
+}
+
+// ProcessOutput handles GET /v1/sandbox/processes/:id/output?offset=&limit=.
+func (h *SandboxHandler) ProcessOutput(c *gin.Context) {
+	key, ok := h.resolveSessionKey(c)
+	if !ok {
+		return
+	}
+
+	offset, err := strconv.ParseInt(c.DefaultQuery("offset", "0"), 10, 64)
+	if err != nil || offset < 0 {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "offset must be a non-negative integer")
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil || limit < 0 {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "limit must be a non-negative integer")
+		return
+	}
+
+	out, err := h.manager.ProcessOutput(c.Request.Context(), key, c.Param("id"), offset, limit)
+	if err != nil {
+		respondProcessError(c, "failed to read process output: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// SignalProcess handles POST /v1/sandbox/processes/:id/signal.
+func (h *SandboxHandler) SignalProcess(c *gin.Context) {
+	key, ok := h.resolveSessionKey(c)
+	if !ok {
+		return
+	}
+
+	var req SignalProcessRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "invalid request body: "+err.Error())
+		return
+	}
+	if req.Signal == "" {
+		req.Signal = "TERM"
+	}
+
+	if err := h.manager.SignalProcess(c.Request.Context(), key, c.Param("id"), req.Signal); err != nil {
+		respondProcessError(c, "failed to signal process: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// WaitProcess handles POST /v1/sandbox/processes/:id/wait. It returns the
+// process once it has exited, or its running state when the timeout
+// elapses first.
+func (h *SandboxHandler) WaitProcess(c *gin.Context) {
+	key, ok := h.resolveSessionKey(c)
+	if !ok {
+		return
+	}
+
+	var req WaitProcessRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.RespondError(c, http.StatusBadRequest, "bad_request", "invalid request body: "+err.Error())
+			return
+		}
+	}
+	timeout := time.Duration(req.TimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	timeout = min(timeout, maxProcessWait)
+
+	p, err := h.manager.WaitProcess(c.Request.Context(), key, c.Param("id"), timeout)
+	if err != nil {
+		respondProcessError(c, "failed to wait for process: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, p)
+}
+
+// respondProcessError maps process errors to HTTP statuses.
+func respondProcessError(c *gin.Context, prefix string, err error) {
+	switch {
+	case errors.Is(err, sandbox.ErrProcessNotFound):
+		response.RespondError(c, http.StatusNotFound, "not_found", "process not found")
+	case errors.Is(err, sandbox.ErrProcessExited):
+		response.RespondError(c, http.StatusConflict, "process_exited", "process has already exited")
+	case errors.Is(err, sandbox.ErrInvalidSignal):
+		response.RespondError(c, http.StatusBadRequest, "bad_request", err.Error())
+	default:
+		response.RespondError(c, http.StatusInternalServerError, "process_error", prefix+err.Error())
+	}
+}
@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"github.com/devs-group/skillbox/internal/api/middleware"
+	"github.com/devs-group/skillbox/internal/api/response"
+	"github.com/devs-group/skillbox/internal/sandbox"
+)
+
+const (
+	// ptyPingInterval keeps intermediaries from dropping a quiet connection.
+	ptyPingInterval = 30 * time.Second
+	// ptyWriteTimeout bounds a single frame write to the client.
+	ptyWriteTimeout = 10 * time.Second
+	// ptyMaxMessage caps a single client frame (stdin chunk or control message).
+	ptyMaxMessage = 64 << 10
+	// ptyMaxDimension rejects absurd terminal sizes.
+	ptyMaxDimension = 1000
+)
+
+// ptyUpgrader upgrades PTY requests to WebSocket. Origins are not checked:
+// the endpoint is authenticated by bearer token rather than cookies, so a
+// cross-site page cannot ride on ambient credentials.
+var ptyUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 32 << 10,
+	CheckOrigin:     func(*http.Request) bool { return true },
+}
+
+// PTYControlMessage is a client→server text frame. Binary frames carry raw
+// stdin; text frames carry one of these.
+type PTYControlMessage struct {
+	Type string `json:"type"`           // "stdin" or "resize"
+	Data string `json:"data,omitempty"` // stdin payload for type "stdin"
+	Cols int    `json:"cols,omitempty"` // for type "resize"
+	Rows int    `json:"rows,omitempty"` // for type "resize"
+}
+
+// PTYEventMessage is a server→client text frame. Binary frames carry raw
+// terminal output; text frames carry one of these.
+type PTYEventMessage struct {
+	Type             string `json:"type"`                         // "exit" or "error"
+	Reason           string `json:"reason,omitempty"`             // exit: "exited", "idle_timeout", "client_closed"
+	ExitCode         *int   `json:"exit_code,omitempty"`          // exit: shell exit code, when known
+	TranscriptFileID string `json:"transcript_file_id,omitempty"` // exit: ID in /v1/files
+	Message          string `json:"message,omitempty"`            // error: human-readable detail
+}
+
+// PTY handles GET /v1/sandbox/:session/pty.
+// It upgrades to a WebSocket bridged to an interactive shell in the
+// session's sandbox. The initial size comes from the cols/rows query
+// parameters. When the shell exits, the client disconnects or the PTY
+// idles out, the transcript is saved to the files API and an "exit" event
+// is sent before the connection closes.
+func (h *SandboxHandler) PTY(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	sessionID := c.Param("session")
+	if sessionID == "" {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "session ID is required")
+		return
+	}
+	cols, err := ptyDimension(c.DefaultQuery("cols", "80"))
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "invalid cols: "+err.Error())
+		return
+	}
+	rows, err := ptyDimension(c.DefaultQuery("rows", "24"))
+	if err != nil {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "invalid rows: "+err.Error())
+		return
+	}
+	if !websocket.IsWebSocketUpgrade(c.Request) {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "websocket upgrade required")
+		return
+	}
+
+	ms, err := h.manager.GetOrCreate(c.Request.Context(), tenantID, sessionID, sandbox.SandboxSessionOpts{})
+	if err != nil {
+		response.RespondError(c, http.StatusInternalServerError, "sandbox_error", "failed to get or create sandbox: "+err.Error())
+		return
+	}
+	key := tenantID + ":" + ms.ExternalID
+
+	// Start the shell before upgrading so setup failures surface as a
+	// normal JSON error response.
+	pty, err := h.manager.OpenPTY(c.Request.Context(), key, sandbox.PTYOpts{Cols: cols, Rows: rows})
+	if err != nil {
+		response.RespondError(c, http.StatusInternalServerError, "pty_error", "failed to start shell: "+err.Error())
+		return
+	}
+
+	conn, err := ptyUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already written an HTTP error response.
+		_ = pty.Close()
+		return
+	}
+	h.bridgePTY(conn, pty)
+}
+
+// bridgePTY relays between the WebSocket and the PTY until either side
+// ends, then saves the transcript and reports how the session ended.
+func (h *SandboxHandler) bridgePTY(conn *websocket.Conn, pty *sandbox.PTY) {
+	defer conn.Close() //nolint:errcheck
+	conn.SetReadLimit(ptyMaxMessage)
+
+	transcript := sandbox.NewTranscript(pty.Cols, pty.Rows)
+
+	// gorilla/websocket allows one concurrent writer; output, pings and the
+	// final event all go through send.
+	var writeMu sync.Mutex
+	send := func(msgType int, data []byte) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		_ = conn.SetWriteDeadline(time.Now().Add(ptyWriteTimeout))
+		return conn.WriteMessage(msgType, data)
+	}
+
+	// Shell → client.
+	outputDone := make(chan struct{})
+	go func() {
+		defer close(outputDone)
+		buf := make([]byte, 32<<10)
+		for {
+			n, err := pty.Read(buf)
+			if n > 0 {
+				transcript.Output(buf[:n])
+				if sendErr := send(websocket.BinaryMessage, buf[:n]); sendErr != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	// Client → shell.
+	clientDone := make(chan struct{})
+	go func() {
+		defer close(clientDone)
+		for {
+			msgType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if msgType == websocket.BinaryMessage {
+				if _, err := pty.Write(data); err != nil {
+					return
+				}
+				continue
+			}
+			var msg PTYControlMessage
+			if err := json.Unmarshal(data, &msg); err != nil {
+				sendPTYEvent(send, PTYEventMessage{Type: "error", Message: "invalid control message: " + err.Error()})
+				continue
+			}
+			switch msg.Type {
+			case "stdin":
+				if _, err := pty.Write([]byte(msg.Data)); err != nil {
+					return
+				}
+			case "resize":
+				if msg.Cols > ptyMaxDimension || msg.Rows > ptyMaxDimension {
+					sendPTYEvent(send, PTYEventMessage{Type: "error", Message: "terminal size too large"})
+					continue
+				}
+				if err := pty.Resize(msg.Cols, msg.Rows); err != nil {
+					if errors.Is(err, sandbox.ErrPTYClosed) {
+						return
+					}
+					sendPTYEvent(send, PTYEventMessage{Type: "error", Message: err.Error()})
+					continue
+				}
+				transcript.Resize(msg.Cols, msg.Rows)
+			default:
+				sendPTYEvent(send, PTYEventMessage{Type: "error", Message: "unknown control message type: " + msg.Type})
+			}
+		}
+	}()
+
+	ping := time.NewTicker(ptyPingInterval)
+	defer ping.Stop()
+
+	reason := "exited"
+wait:
+	for {
+		select {
+		case <-outputDone:
+		case <-clientDone:
+		case <-ping.C:
+			writeMu.Lock()
+			_ = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(ptyWriteTimeout))
+			writeMu.Unlock()
+			continue
+		}
+		// Either relay stopping while the shell is still running means the
+		// client went away.
+		select {
+		case <-pty.Done():
+		default:
+			reason = "client_closed"
+		}
+		break wait
+	}
+
+	_ = pty.Close()
+	<-outputDone
+	if pty.IdleTimedOut() {
+		reason = "idle_timeout"
+	}
+
+	event := PTYEventMessage{Type: "exit", Reason: reason}
+	if code, err := pty.Wait(); err == nil && reason == "exited" {
+		event.ExitCode = &code
+	}
+
+	saveCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if f, err := h.manager.SaveTranscript(saveCtx, pty, transcript); err != nil {
+		slog.Warn("sandbox pty: failed to save transcript", "pty_id", pty.ID, "error", err)
+	} else {
+		event.TranscriptFileID = f.ID
+	}
+
+	// Best-effort: after client_closed the peer is usually already gone.
+	sendPTYEvent(send, event)
+	writeMu.Lock()
+	_ = conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, reason),
+		time.Now().Add(ptyWriteTimeout))
+	writeMu.Unlock()
+}
+
+// sendPTYEvent writes a JSON event frame, ignoring failures: the peer is
+// either gone or about to be disconnected.
+func sendPTYEvent(send func(int, []byte) error, event PTYEventMessage) {
+	data, _ := json.Marshal(event)
+	_ = send(websocket.TextMessage, data)
+}
+
+// ptyDimension parses a terminal width or height query parameter.
+func ptyDimension(raw string) (int, error) {
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, err
+	}
+	if n <= 0 || n > ptyMaxDimension {
+		return 0, errors.New("must be between 1 and " + strconv.Itoa(ptyMaxDimension))
+	}
+	return n, nil
+}
@@ -2,11 +2,13 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/devs-group/skillbox/internal/api/middleware"
 	"github.com/devs-group/skillbox/internal/api/response"
+	"github.com/devs-group/skillbox/internal/scanner"
 	"github.com/devs-group/skillbox/internal/store"
 )
 
@@ -28,12 +30,14 @@ func GetScannerConfig(s *store.Store) gin.HandlerFunc {
 
 // updateScannerConfigRequest is the JSON body for PUT /v1/admin/scanner/config.
 type updateScannerConfigRequest struct {
-	ApprovalPolicy *string `json:"approval_policy"`
-	Tier1Enabled   *bool   `json:"tier1_enabled"`
-	Tier2Enabled   *bool   `json:"tier2_enabled"`
-	Tier3Enabled   *bool   `json:"tier3_enabled"`
-	Tier3APIKey    *string `json:"tier3_api_key"`
-	Tier3Model     *string `json:"tier3_model"`
+	ApprovalPolicy    *string `json:"approval_policy"`
+	Tier1Enabled      *bool   `json:"tier1_enabled"`
+	Tier2Enabled      *bool   `json:"tier2_enabled"`
+	Tier3Enabled      *bool   `json:"tier3_enabled"`
+	Tier3APIKey       *string `json:"tier3_api_key"`
+	Tier3Model        *string `json:"tier3_model"`
+	RequireSigned     *bool   `json:"require_signed"`
+	VulnBlockSeverity *string `json:"vuln_block_severity"`
 }
 
 // UpdateScannerConfig handles PUT /v1/admin/scanner/config.
@@ -60,6 +64,14 @@ func UpdateScannerConfig(s *store.Store) gin.HandlerFunc {
 			}
 		}
 
+		if req.VulnBlockSeverity != nil {
+			if !scanner.ValidVulnThreshold(*req.VulnBlockSeverity) {
+				response.RespondError(c, http.StatusBadRequest, "bad_request",
+					"vuln_block_severity must be 'low', 'medium', 'high', 'critical', or 'none'")
+				return
+			}
+		}
+
 		// Get current config, then apply partial updates.
 		current, err := s.GetScannerConfig(c.Request.Context(), tenantID)
 		if err != nil {
@@ -85,6 +97,12 @@ func UpdateScannerConfig(s *store.Store) gin.HandlerFunc {
 		if req.Tier3Model != nil {
 			current.Tier3Model = *req.Tier3Model
 		}
+		if req.RequireSigned != nil {
+			current.RequireSigned = *req.RequireSigned
+		}
+		if req.VulnBlockSeverity != nil {
+			current.VulnBlockSeverity = strings.ToLower(*req.VulnBlockSeverity)
+		}
 
 		if err := s.UpsertScannerConfig(c.Request.Context(), current); err != nil {
 			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to update scanner config")
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/devs-group/skillbox/internal/api/middleware"
+	"github.com/devs-group/skillbox/internal/api/response"
+	"github.com/devs-group/skillbox/internal/sandbox"
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+// snapshotNameRe restricts snapshot names to short, path-safe labels.
+var snapshotNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,127}$`)
+
+// CreateSnapshotRequest is the body for POST /v1/sessions/:external_id/snapshots.
+type CreateSnapshotRequest struct {
+	Name string `json:"name"`
+}
+
+// RestoreSnapshotRequest is the body for POST /v1/sessions/:external_id/restore.
+type RestoreSnapshotRequest struct {
+	SnapshotID string `json:"snapshot_id"`
+}
+
+// ForkSnapshotRequest is the body for POST /v1/sessions/:external_id/fork.
+type ForkSnapshotRequest struct {
+	SnapshotID string `json:"snapshot_id"`
+	SessionID  string `json:"session_id,omitempty"` // new external ID; generated when empty
+}
+
+// CreateSnapshot handles POST /v1/sessions/:external_id/snapshots.
+func (h *SessionsHandler) CreateSnapshot(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	externalID := c.Param("external_id")
+
+	var req CreateSnapshotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "invalid request body: "+err.Error())
+		return
+	}
+	if !snapshotNameRe.MatchString(req.Name) {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "name must be 1-128 characters of letters, digits, '.', '_' or '-'")
+		return
+	}
+
+	snap, err := h.manager.CreateSnapshot(c.Request.Context(), tenantID, externalID, req.Name)
+	if err != nil {
+		respondSnapshotError(c, "failed to create snapshot: ", err)
+		return
+	}
+	c.JSON(http.StatusCreated, snap)
+}
+
+// ListSnapshots handles GET /v1/sessions/:external_id/snapshots.
+func (h *SessionsHandler) ListSnapshots(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	externalID := c.Param("external_id")
+
+	snaps, err := h.manager.ListSnapshots(c.Request.Context(), tenantID, externalID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			// No session yet — return empty list.
+			c.JSON(http.StatusOK, []*store.Snapshot{})
+			return
+		}
+		response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to list snapshots: "+err.Error())
+		return
+	}
+	if snaps == nil {
+		snaps = []*store.Snapshot{}
+	}
+	c.JSON(http.StatusOK, snaps)
+}
+
+// DeleteSnapshot handles DELETE /v1/sessions/:external_id/snapshots/:snapshot_id.
+func (h *SessionsHandler) DeleteSnapshot(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+
+	err := h.manager.DeleteSnapshot(c.Request.Context(), tenantID, c.Param("external_id"), c.Param("snapshot_id"))
+	if err != nil {
+		respondSnapshotError(c, "failed to delete snapshot: ", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Restore handles POST /v1/sessions/:external_id/restore.
+func (h *SessionsHandler) Restore(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	externalID := c.Param("external_id")
+
+	var req RestoreSnapshotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "invalid request body: "+err.Error())
+		return
+	}
+	if req.SnapshotID == "" {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "snapshot_id is required")
+		return
+	}
+
+	snap, err := h.manager.RestoreSnapshot(c.Request.Context(), tenantID, externalID, req.SnapshotID)
+	if err != nil {
+		respondSnapshotError(c, "failed to restore snapshot: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, snap)
+}
+
+// Fork handles POST /v1/sessions/:external_id/fork.
+func (h *SessionsHandler) Fork(c *gin.Context) {
+	tenantID := middleware.GetTenantID(c)
+	externalID := c.Param("external_id")
+
+	var req ForkSnapshotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "invalid request body: "+err.Error())
+		return
+	}
+	if req.SnapshotID == "" {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "snapshot_id is required")
+		return
+	}
+	if req.SessionID == "" {
+		req.SessionID = uuid.NewString()
+	}
+	if req.SessionID == externalID {
+		response.RespondError(c, http.StatusBadRequest, "bad_request", "session_id must differ from the source session")
+		return
+	}
+
+	sess, err := h.manager.ForkSnapshot(c.Request.Context(), tenantID, externalID, req.SnapshotID, req.SessionID)
+	if err != nil {
+		respondSnapshotError(c, "failed to fork session: ", err)
+		return
+	}
+	c.JSON(http.StatusCreated, sess)
+}
+
+// respondSnapshotError maps session manager snapshot errors to responses.
+func respondSnapshotError(c *gin.Context, prefix string, err error) {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		response.RespondError(c, http.StatusNotFound, "not_found", "session or snapshot not found")
+	case errors.Is(err, sandbox.ErrSessionExists):
+		response.RespondError(c, http.StatusConflict, "session_exists", err.Error())
+	case errors.Is(err, sandbox.ErrSnapshotTooLarge):
+		response.RespondError(c, http.StatusRequestEntityTooLarge, "too_large", err.Error())
+	default:
+		response.RespondError(c, http.StatusInternalServerError, "internal_error", prefix+err.Error())
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/devs-group/skillbox/internal/api/middleware"
 	"github.com/devs-group/skillbox/internal/api/response"
 	"github.com/devs-group/skillbox/internal/artifacts"
+	"github.com/devs-group/skillbox/internal/sandbox"
 	"github.com/devs-group/skillbox/internal/store"
 )
 
@@ -18,11 +19,13 @@ import (
 type SessionsHandler struct {
 	store     *store.Store
 	artifacts *artifacts.Collector
+	manager   *sandbox.SessionManager // snapshot endpoints; may be nil
 }
 
 // NewSessionsHandler creates a handler with all required dependencies.
-func NewSessionsHandler(s *store.Store, col *artifacts.Collector) *SessionsHandler {
-	return &SessionsHandler{store: s, artifacts: col}
+// sm may be nil, in which case the snapshot endpoints are not routed.
+func NewSessionsHandler(s *store.Store, col *artifacts.Collector, sm *sandbox.SessionManager) *SessionsHandler {
+	return &SessionsHandler{store: s, artifacts: col, manager: sm}
 }
 
 // ListFiles handles GET /v1/sessions/:external_id/files.
@@ -204,6 +207,23 @@ func (h *SessionsHandler) Delete(c *gin.Context) {
 		}
 	}
 
+	// Delete snapshot bundles; their records go with the session row.
+	snaps, err := h.store.ListSnapshots(c.Request.Context(), tenantID, sess.ID)
+	if err != nil {
+		response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to list session snapshots for deletion: "+err.Error())
+		return
+	}
+	for _, snap := range snaps {
+		if h.artifacts != nil {
+			if delErr := h.artifacts.DeleteObject(c.Request.Context(), snap.S3Key); delErr != nil {
+				slog.Warn("session delete: failed to delete snapshot from storage",
+					"s3_key", snap.S3Key,
+					"error", delErr,
+				)
+			}
+		}
+	}
+
 	// Delete the session record.
 	if err := h.store.DeleteSession(c.Request.Context(), tenantID, externalID); err != nil {
 		response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to delete session: "+err.Error())
@@ -3,6 +3,7 @@ package handlers
 import (
 	"archive/zip"
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -18,6 +19,7 @@ import (
 	"github.com/devs-group/skillbox/internal/config"
 	"github.com/devs-group/skillbox/internal/registry"
 	"github.com/devs-group/skillbox/internal/scanner"
+	"github.com/devs-group/skillbox/internal/signing"
 	"github.com/devs-group/skillbox/internal/skill"
 	"github.com/devs-group/skillbox/internal/store"
 )
@@ -111,6 +113,15 @@ func UploadSkill(reg *registry.Registry, s *store.Store, cfg *config.Config, sc
 			response.RespondError(c, http.StatusForbidden, "skill_blocked", "Skill "+parsedSkill.Name+" has been blocked by an admin.")
 			return
 		}
+
+		// Publisher signature: must come from a trusted key, and is required
+		// when the tenant only accepts signed skills.
+		sig, err := signing.NewVerifier(s).VerifySkill(c.Request.Context(), tenantID, zipData)
+		if err != nil {
+			respondSignatureError(c, err)
+			return
+		}
+
 		// Append-only intake: when a non-declined version of this name already
 		// exists, mint the next-free version and scan it instead of rejecting.
 		version, appended, err := s.NextIntakeVersion(c.Request.Context(), tenantID, parsedSkill.Name, parsedSkill.Version)
@@ -118,6 +129,12 @@ func UploadSkill(reg *registry.Registry, s *store.Store, cfg *config.Config, sc
 			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to resolve skill version: "+err.Error())
 			return
 		}
+		if appended && sig != nil {
+			// Rewriting SKILL.md would invalidate the publisher's signature.
+			response.RespondError(c, http.StatusConflict, "version_exists",
+				"skill "+parsedSkill.Name+"@"+parsedSkill.Version+" already exists; bump the version and sign again")
+			return
+		}
 		if appended {
 			zipData, err = skill.RewriteZipVersion(zipData, version)
 			if err != nil {
@@ -169,6 +186,12 @@ func UploadSkill(reg *registry.Registry, s *store.Store, cfg *config.Config, sc
 		if err != nil {
 			_ = c.Error(err)
 		}
+		if sig != nil {
+			raw, _ := json.Marshal(sig)
+			if err := s.SetSkillSignature(c.Request.Context(), tenantID, parsedSkill.Name, version, raw); err != nil {
+				_ = c.Error(err)
+			}
+		}
 
 		// Queue async scan job.
 		if worker != nil {
@@ -360,8 +383,10 @@ func normalizeSkillZip(data []byte) ([]byte, error) {
 //
 // It performs the same security scanning as UploadSkill but does NOT store
 // the skill. This allows agents to pre-flight check skills before uploading.
-// Returns 200 with scan summary on pass, 422 on rejection.
-func ValidateSkill(cfg *config.Config, sc scanner.Scanner) gin.HandlerFunc {
+// Returns 200 with scan summary on pass, 422 on rejection. Known vulnerable
+// dependencies block at the tenant's vuln_block_severity, as in the scan
+// worker.
+func ValidateSkill(cfg *config.Config, sc scanner.Scanner, s *store.Store) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var zipData []byte
 		var err error
@@ -454,6 +479,12 @@ func ValidateSkill(cfg *config.Config, sc scanner.Scanner) gin.HandlerFunc {
 			response.RespondError(c, http.StatusInternalServerError, "internal_error", "security scan unavailable")
 			return
 		}
+		scannerCfg, err := s.GetScannerConfig(c.Request.Context(), middleware.GetTenantID(c))
+		if err != nil {
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to get scanner config")
+			return
+		}
+		scanner.ApplyVulnThreshold(scanResult, scannerCfg.VulnBlockSeverity)
 
 		if !scanResult.Pass {
 			scanID := uuid.NewString()
@@ -759,6 +790,8 @@ func GetSkill(reg *registry.Registry, s *store.Store) gin.HandlerFunc {
 			Timeout:      timeout,
 			Resources:    parsed.Resources,
 			Mode:         parsed.Mode,
+			Kind:         parsed.Kind,
+			InputSchema:  parsed.InputSchema,
 		})
 	}
 }
@@ -63,6 +63,9 @@ func CreateFromFields(reg *registry.Registry, s *store.Store, cfg *config.Config
 			response.RespondError(c, http.StatusForbidden, "skill_blocked", "Skill "+req.Name+" has been blocked by an admin.")
 			return
 		}
+		if !requireUnsignedAllowed(c, s, tenantID) {
+			return
+		}
 		// Append-only intake: when a non-declined version of this name already
 		// exists, mint the next-free version instead of rejecting.
 		version, _, err := s.NextIntakeVersion(c.Request.Context(), tenantID, req.Name, version)
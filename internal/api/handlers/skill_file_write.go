@@ -15,6 +15,7 @@ import (
 	"github.com/devs-group/skillbox/internal/config"
 	"github.com/devs-group/skillbox/internal/registry"
 	"github.com/devs-group/skillbox/internal/scanner"
+	"github.com/devs-group/skillbox/internal/signing"
 	"github.com/devs-group/skillbox/internal/skill"
 	"github.com/devs-group/skillbox/internal/store"
 )
@@ -56,6 +57,9 @@ func WriteSkillFile(reg *registry.Registry, s *store.Store, cfg *config.Config,
 			response.RespondError(c, http.StatusForbidden, "blocked", "skill is blocked: no new versions can be submitted")
 			return
 		}
+		if !requireUnsignedAllowed(c, s, tenantID) {
+			return
+		}
 
 		active, err := s.ResolveActiveVersion(c.Request.Context(), tenantID, name)
 		if err != nil {
@@ -184,6 +188,9 @@ func WriteSkillFiles(reg *registry.Registry, s *store.Store, cfg *config.Config,
 			response.RespondError(c, http.StatusForbidden, "blocked", "skill is blocked: no new versions can be submitted")
 			return
 		}
+		if !requireUnsignedAllowed(c, s, tenantID) {
+			return
+		}
 
 		active, err := s.ResolveActiveVersion(c.Request.Context(), tenantID, name)
 		if err != nil {
@@ -247,6 +254,10 @@ func buildZipFromTree(files []batchFile) ([]byte, string, string, error) {
 	var buf bytes.Buffer
 	w := zip.NewWriter(&buf)
 	for _, f := range files {
+		if f.Path == signing.SignatureFile {
+			// The server rewrites SKILL.md, so a signature would not match.
+			continue
+		}
 		fw, err := w.Create(f.Path)
 		if err != nil {
 			return nil, "", "", err
@@ -266,9 +277,10 @@ func buildZipFromTree(files []batchFile) ([]byte, string, string, error) {
 	return buf.Bytes(), desc, lang, nil
 }
 
-// repackageWithFile rebuilds a skill zip with one file replaced or added.
-// It returns the new archive plus the skill's description and lang parsed from
-// SKILL.md so the metadata row can be carried forward.
+// repackageWithFile rebuilds a skill zip with one file replaced or added,
+// dropping any publisher signature. It returns the new archive plus the
+// skill's description and lang parsed from SKILL.md so the metadata row can be
+// carried forward.
 func repackageWithFile(zipBytes []byte, filePath, content string) ([]byte, string, string, error) {
 	reader, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
 	if err != nil {
@@ -284,7 +296,8 @@ func repackageWithFile(zipBytes []byte, filePath, content string) ([]byte, strin
 			continue
 		}
 		entryName := strings.TrimPrefix(f.Name, "./")
-		if strings.Contains(entryName, "..") {
+		if strings.Contains(entryName, "..") || entryName == signing.SignatureFile {
+			// The edited version is no longer what the publisher signed.
 			continue
 		}
 		data := []byte(nil)
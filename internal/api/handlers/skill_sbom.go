@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devs-group/skillbox/internal/api/middleware"
+	"github.com/devs-group/skillbox/internal/api/response"
+	"github.com/devs-group/skillbox/internal/registry"
+	"github.com/devs-group/skillbox/internal/scanner"
+	"github.com/devs-group/skillbox/internal/skill"
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+// GetSkillSBOM handles GET /v1/skills/:name/:version/sbom.
+//
+// It returns the CycloneDX JSON SBOM the scan worker generated for the
+// version. The version may be "latest". Versions scanned before SBOMs
+// were generated have none and return 404.
+func GetSkillSBOM(reg *registry.Registry, s *store.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := middleware.GetTenantID(c)
+		name := c.Param("name")
+		version := c.Param("version")
+
+		if err := skill.ValidateName(name); err != nil {
+			response.RespondError(c, http.StatusBadRequest, "bad_request", err.Error())
+			return
+		}
+		if err := skill.ValidateVersion(version); err != nil {
+			response.RespondError(c, http.StatusBadRequest, "bad_request", err.Error())
+			return
+		}
+
+		if version == "latest" {
+			resolved, err := s.ResolveActiveVersion(c.Request.Context(), tenantID, name)
+			if err != nil {
+				if errors.Is(err, store.ErrNotFound) {
+					response.RespondError(c, http.StatusNotFound, "not_found", "skill not found: "+name+"@latest")
+					return
+				}
+				response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to resolve latest version: "+err.Error())
+				return
+			}
+			version = resolved
+		}
+
+		data, err := reg.GetSBOM(c.Request.Context(), tenantID, name, version)
+		if err != nil {
+			if errors.Is(err, registry.ErrSBOMNotFound) {
+				response.RespondError(c, http.StatusNotFound, "not_found", "no SBOM for skill "+name+"@"+version)
+				return
+			}
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to retrieve SBOM: "+err.Error())
+			return
+		}
+
+		c.Data(http.StatusOK, "application/vnd.cyclonedx+json", data)
+	}
+}
+
+// ListDependents handles GET /v1/dependencies.
+//
+// It answers "which skills depend on package X": every version of the
+// tenant's skills whose SBOM lists the package given by ?package=,
+// optionally narrowed to one ?ecosystem= (PyPI or npm). Package names
+// are matched the way each ecosystem compares them, so "Typing_Extensions"
+// finds the PyPI package typing-extensions.
+func ListDependents(s *store.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := middleware.GetTenantID(c)
+
+		pkg := strings.TrimSpace(c.Query("package"))
+		if pkg == "" {
+			response.RespondError(c, http.StatusBadRequest, "bad_request", "package query parameter is required")
+			return
+		}
+
+		var ecosystems []string
+		switch strings.ToLower(c.Query("ecosystem")) {
+		case "":
+			ecosystems = []string{scanner.EcosystemPyPI, scanner.EcosystemNPM}
+		case "pypi":
+			ecosystems = []string{scanner.EcosystemPyPI}
+		case "npm":
+			ecosystems = []string{scanner.EcosystemNPM}
+		default:
+			response.RespondError(c, http.StatusBadRequest, "bad_request", "ecosystem must be 'PyPI' or 'npm'")
+			return
+		}
+		refs := make([]store.PackageRef, len(ecosystems))
+		for i, eco := range ecosystems {
+			refs[i] = store.PackageRef{Ecosystem: eco, Package: scanner.NormalizePackageName(eco, pkg)}
+		}
+
+		deps, err := s.ListDependents(c.Request.Context(), tenantID, refs)
+		if err != nil {
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to list dependents")
+			return
+		}
+		if deps == nil {
+			deps = []store.SkillDependency{}
+		}
+
+		c.JSON(http.StatusOK, deps)
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+func getDependents(t *testing.T, s *store.Store, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v1/dependencies?"+query, nil)
+	setTenantID(c, "tenant-1")
+	ListDependents(s)(c)
+	return w
+}
+
+func TestListDependents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s, mock, cleanup := newTestKeyStore(t)
+	defer cleanup()
+
+	cols := []string{"name", "version", "status", "is_active", "ecosystem", "package", "package_version", "specifier", "file_path"}
+	mock.ExpectQuery("FROM sandbox.skill_dependencies").
+		WithArgs("tenant-1", pq.Array([]string{"typing-extensions"}), pq.Array([]string{"PyPI:typing-extensions"})).
+		WillReturnRows(sqlmock.NewRows(cols).
+			AddRow("pdf", "1.2.0", "available", true, "PyPI", "typing-extensions", "4.12.2", "", "requirements.txt"))
+
+	w := getDependents(t, s, "package=Typing_Extensions&ecosystem=pypi")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d; body: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var got []store.SkillDependency
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "pdf" || got[0].PackageVersion != "4.12.2" || !got[0].Active {
+		t.Errorf("dependents = %+v", got)
+	}
+
+	// Without an ecosystem both are searched; no match is an empty list.
+	mock.ExpectQuery("FROM sandbox.skill_dependencies").
+		WithArgs("tenant-1", pq.Array([]string{"left-pad", "left-pad"}), pq.Array([]string{"PyPI:left-pad", "npm:left-pad"})).
+		WillReturnRows(sqlmock.NewRows(cols))
+
+	w = getDependents(t, s, "package=left-pad")
+	if w.Code != http.StatusOK || w.Body.String() != "[]" {
+		t.Errorf("status = %d, body = %s; want 200 []", w.Code, w.Body.String())
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListDependents_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s, _, cleanup := newTestKeyStore(t)
+	defer cleanup()
+
+	for _, query := range []string{"", "package=x&ecosystem=maven"} {
+		if w := getDependents(t, s, query); w.Code != http.StatusBadRequest {
+			t.Errorf("%q: status = %d, want %d", query, w.Code, http.StatusBadRequest)
+		}
+	}
+}
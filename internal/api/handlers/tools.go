@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devs-group/skillbox/internal/api/middleware"
+	"github.com/devs-group/skillbox/internal/api/response"
+	"github.com/devs-group/skillbox/internal/tools"
+)
+
+// ListTools handles GET /v1/tools?format=openai|anthropic|gemini|langchain.
+// It returns the tenant's available skills as tool definitions in the
+// requested provider format (default: openai), together with the mapping
+// from tool names back to skills.
+func ListTools(cat *tools.Catalog) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		format, err := tools.ParseFormat(c.Query("format"))
+		if err != nil {
+			response.RespondError(c, http.StatusBadRequest, "bad_request", err.Error())
+			return
+		}
+
+		defs, err := cat.List(c.Request.Context(), middleware.GetTenantID(c))
+		if err != nil {
+			response.RespondError(c, http.StatusInternalServerError, "internal_error", "failed to list tools: "+err.Error())
+			return
+		}
+		c.JSON(http.StatusOK, tools.Render(defs, format))
+	}
+}
@@ -1,17 +1,21 @@
 package middleware
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/devs-group/skillbox/internal/api/response"
+	"github.com/devs-group/skillbox/internal/authcache"
 	"github.com/devs-group/skillbox/internal/store"
 )
 
@@ -35,14 +39,60 @@ func isJWT(token string) bool {
 	return strings.HasPrefix(token, "eyJ") && strings.Count(token, ".") == 2
 }
 
+// Authenticator validates bearer credentials: Hydra-issued JWTs through
+// token introspection and API keys through a SHA-256 hash lookup. When a
+// cache is configured, successful lookups are kept for a short TTL so
+// repeated requests skip Postgres and Hydra.
+type Authenticator struct {
+	store         *store.Store
+	introspectURL string
+	cache         authcache.Cache // nil disables caching
+	ttl           time.Duration
+	metrics       *authcache.Metrics
+}
+
+// NewAuthenticator creates an Authenticator. A nil cache or a zero ttl
+// disables caching.
+func NewAuthenticator(s *store.Store, hydraAdminURL string, cache authcache.Cache, ttl time.Duration) *Authenticator {
+	if ttl <= 0 {
+		cache = nil
+	}
+	return &Authenticator{
+		store: s,
+		// Hydra token introspection endpoint
+		introspectURL: strings.TrimRight(hydraAdminURL, "/") + "/admin/oauth2/introspect",
+		cache:         cache,
+		ttl:           ttl,
+		metrics:       &authcache.Metrics{},
+	}
+}
+
+// Metrics returns the authenticator's cache and latency metrics.
+func (a *Authenticator) Metrics() *authcache.Metrics {
+	return a.metrics
+}
+
+// Close releases the cache's connections, if it holds any. The
+// authenticator must not be used afterwards.
+func (a *Authenticator) Close() error {
+	if c, ok := a.cache.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
 // AuthMiddleware extracts a Bearer token from the Authorization header and
 // routes to either JWT validation (for Hydra-issued tokens) or SHA-256
-// API-key lookup. Both paths set ContextKeyTenantID so downstream handlers
-// work unchanged.
+// API-key lookup, without caching. See Authenticator.Required.
 func AuthMiddleware(s *store.Store, hydraAdminURL string) gin.HandlerFunc {
-	// Hydra token introspection endpoint
-	introspectURL := strings.TrimRight(hydraAdminURL, "/") + "/admin/oauth2/introspect"
+	return NewAuthenticator(s, hydraAdminURL, nil, 0).Required()
+}
 
+// Required extracts a Bearer token from the Authorization header and
+// routes to either JWT validation (for Hydra-issued tokens) or SHA-256
+// API-key lookup. Both paths set ContextKeyTenantID so downstream handlers
+// work unchanged.
+func (a *Authenticator) Required() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		header := c.GetHeader("Authorization")
 		if header == "" {
@@ -66,20 +116,24 @@ func AuthMiddleware(s *store.Store, hydraAdminURL string) gin.HandlerFunc {
 		}
 
 		if isJWT(token) {
-			authenticateJWT(c, s, introspectURL, token)
+			a.authenticateJWT(c, token)
 		} else {
-			authenticateAPIKey(c, s, token)
+			a.authenticateAPIKey(c, token)
 		}
 	}
 }
 
 // OptionalAuthMiddleware is like AuthMiddleware but does not reject
-// unauthenticated requests. If valid credentials are present, it sets
-// context keys; otherwise it passes through silently. Used for endpoints
-// like marketplace browse that enrich responses when authenticated.
+// unauthenticated requests. See Authenticator.Optional.
 func OptionalAuthMiddleware(s *store.Store, hydraAdminURL string) gin.HandlerFunc {
-	introspectURL := strings.TrimRight(hydraAdminURL, "/") + "/admin/oauth2/introspect"
+	return NewAuthenticator(s, hydraAdminURL, nil, 0).Optional()
+}
 
+// Optional is like Required but does not reject unauthenticated requests.
+// If valid credentials are present, it sets context keys; otherwise it
+// passes through silently. Used for endpoints like marketplace browse that
+// enrich responses when authenticated.
+func (a *Authenticator) Optional() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		header := c.GetHeader("Authorization")
 		if header == "" {
@@ -101,18 +155,70 @@ func OptionalAuthMiddleware(s *store.Store, hydraAdminURL string) gin.HandlerFun
 
 		// Try to authenticate but don't abort on failure
 		if isJWT(token) {
-			authenticateJWTOptional(c, s, introspectURL, token)
+			a.authenticateJWTOptional(c, token)
 		} else {
-			authenticateAPIKeyOptional(c, s, token)
+			a.authenticateAPIKeyOptional(c, token)
 		}
 		c.Next()
 	}
 }
 
+// ErrInvalidCredentials is returned by Authenticate when a token is
+// unknown, revoked, inactive, or not bound to a user. The wrapped message
+// says which.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// Principal is the caller identified by Authenticate.
+type Principal struct {
+	TenantID string
+	AuthType string
+	UserID   string        // set for JWTs
+	APIKey   *store.APIKey // set for API keys
+}
+
+// Authenticate validates a bearer token the same way Required does, for
+// transports that are not served by Gin. Errors other than
+// ErrInvalidCredentials are lookup failures.
+func (a *Authenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	if token == "" {
+		return nil, fmt.Errorf("%w: empty bearer token", ErrInvalidCredentials)
+	}
+
+	if !isJWT(token) {
+		key, err := a.lookupAPIKey(ctx, token)
+		if errors.Is(err, store.ErrNotFound) || (err == nil && key.RevokedAt != nil) {
+			return nil, fmt.Errorf("%w: invalid or revoked API key", ErrInvalidCredentials)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("validate API key: %w", err)
+		}
+		return &Principal{TenantID: key.TenantID, AuthType: AuthTypeAPIKey, APIKey: key}, nil
+	}
+
+	claims, err := a.introspect(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid token", ErrInvalidCredentials)
+	}
+	if !claims.Active {
+		return nil, fmt.Errorf("%w: token is inactive or expired", ErrInvalidCredentials)
+	}
+	if claims.Sub == "" {
+		return nil, fmt.Errorf("%w: token missing subject claim", ErrInvalidCredentials)
+	}
+	user, err := a.store.GetUserByKratosID(ctx, claims.Sub)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, fmt.Errorf("%w: user not found — redeem an invite code first", ErrInvalidCredentials)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolve user: %w", err)
+	}
+	return &Principal{TenantID: user.TenantID, AuthType: AuthTypeJWT, UserID: user.ID}, nil
+}
+
 // authenticateJWT validates a JWT token via Hydra introspection and resolves
 // the user from the Skillbox users table.
-func authenticateJWT(c *gin.Context, s *store.Store, introspectURL, token string) {
-	claims, err := introspectToken(introspectURL, token)
+func (a *Authenticator) authenticateJWT(c *gin.Context, token string) {
+	claims, err := a.introspect(c.Request.Context(), token)
 	if err != nil {
 		response.RespondError(c, http.StatusUnauthorized, "unauthorized", "invalid token")
 		c.Abort()
@@ -132,7 +238,7 @@ func authenticateJWT(c *gin.Context, s *store.Store, introspectURL, token string
 	}
 
 	// Resolve user by Kratos identity ID (the sub claim)
-	user, err := s.GetUserByKratosID(c.Request.Context(), claims.Sub)
+	user, err := a.store.GetUserByKratosID(c.Request.Context(), claims.Sub)
 	if err != nil {
 		if errors.Is(err, store.ErrNotFound) {
 			response.RespondError(c, http.StatusUnauthorized, "unauthorized", "user not found — redeem an invite code first")
@@ -151,13 +257,13 @@ func authenticateJWT(c *gin.Context, s *store.Store, introspectURL, token string
 }
 
 // authenticateJWTOptional is like authenticateJWT but silently skips on failure.
-func authenticateJWTOptional(c *gin.Context, s *store.Store, introspectURL, token string) {
-	claims, err := introspectToken(introspectURL, token)
+func (a *Authenticator) authenticateJWTOptional(c *gin.Context, token string) {
+	claims, err := a.introspect(c.Request.Context(), token)
 	if err != nil || !claims.Active || claims.Sub == "" {
 		return
 	}
 
-	user, err := s.GetUserByKratosID(c.Request.Context(), claims.Sub)
+	user, err := a.store.GetUserByKratosID(c.Request.Context(), claims.Sub)
 	if err != nil {
 		return
 	}
@@ -167,12 +273,9 @@ func authenticateJWTOptional(c *gin.Context, s *store.Store, introspectURL, toke
 	c.Set(ContextKeyAuthType, AuthTypeJWT)
 }
 
-// authenticateAPIKey validates a token via SHA-256 hash lookup (existing logic).
-func authenticateAPIKey(c *gin.Context, s *store.Store, token string) {
-	hash := sha256.Sum256([]byte(token))
-	hashHex := hex.EncodeToString(hash[:])
-
-	key, err := s.GetAPIKeyByHash(c.Request.Context(), hashHex)
+// authenticateAPIKey validates a token via SHA-256 hash lookup.
+func (a *Authenticator) authenticateAPIKey(c *gin.Context, token string) {
+	key, err := a.lookupAPIKey(c.Request.Context(), token)
 	if err != nil {
 		if errors.Is(err, store.ErrNotFound) {
 			response.RespondError(c, http.StatusUnauthorized, "unauthorized", "invalid or revoked API key")
@@ -197,11 +300,8 @@ func authenticateAPIKey(c *gin.Context, s *store.Store, token string) {
 }
 
 // authenticateAPIKeyOptional is like authenticateAPIKey but silently skips on failure.
-func authenticateAPIKeyOptional(c *gin.Context, s *store.Store, token string) {
-	hash := sha256.Sum256([]byte(token))
-	hashHex := hex.EncodeToString(hash[:])
-
-	key, err := s.GetAPIKeyByHash(c.Request.Context(), hashHex)
+func (a *Authenticator) authenticateAPIKeyOptional(c *gin.Context, token string) {
+	key, err := a.lookupAPIKey(c.Request.Context(), token)
 	if err != nil || key.RevokedAt != nil {
 		return
 	}
@@ -211,6 +311,111 @@ func authenticateAPIKeyOptional(c *gin.Context, s *store.Store, token string) {
 	c.Set(ContextKeyAuthType, AuthTypeAPIKey)
 }
 
+// lookupAPIKey resolves an API key by the SHA-256 hash of token, from the
+// cache if possible. Only keys that are not revoked are cached.
+func (a *Authenticator) lookupAPIKey(ctx context.Context, token string) (*store.APIKey, error) {
+	start := time.Now()
+	defer func() { a.metrics.RecordAPIKey(time.Since(start)) }()
+
+	hashHex := hashToken(token)
+	cacheKey := "apikey:" + hashHex
+
+	var cached store.APIKey
+	if a.cacheGet(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	key, err := a.store.GetAPIKeyByHash(ctx, hashHex)
+	if err == nil && key.RevokedAt == nil {
+		a.cacheSet(ctx, cacheKey, key, a.ttl)
+	}
+	return key, err
+}
+
+// introspect validates token with Hydra, from the cache if possible. Only
+// active tokens are cached, and never beyond their expiry.
+func (a *Authenticator) introspect(ctx context.Context, token string) (*introspectionResponse, error) {
+	start := time.Now()
+	defer func() { a.metrics.RecordJWT(time.Since(start)) }()
+
+	cacheKey := "jwt:" + hashToken(token)
+
+	var cached introspectionResponse
+	if a.cacheGet(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	claims, err := introspectToken(a.introspectURL, token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Active && claims.Sub != "" {
+		ttl := a.ttl
+		if claims.Exp > 0 {
+			ttl = min(ttl, time.Until(time.Unix(claims.Exp, 0)))
+		}
+		if ttl > 0 {
+			a.cacheSet(ctx, cacheKey, claims, ttl)
+		}
+	}
+	return claims, nil
+}
+
+// cacheGet decodes the entry under key into v and reports whether it was
+// found. Cache failures count as misses so authentication falls back to
+// the source of truth.
+func (a *Authenticator) cacheGet(ctx context.Context, key string, v any) bool {
+	if a.cache == nil {
+		return false
+	}
+	raw, ok, err := a.cache.Get(ctx, key)
+	if err != nil {
+		a.metrics.Errors.Add(1)
+	}
+	if err != nil || !ok || json.Unmarshal(raw, v) != nil {
+		a.metrics.Misses.Add(1)
+		return false
+	}
+	a.metrics.Hits.Add(1)
+	return true
+}
+
+// cacheSet stores v under key. Failures are counted and otherwise ignored.
+func (a *Authenticator) cacheSet(ctx context.Context, key string, v any, ttl time.Duration) {
+	if a.cache == nil {
+		return
+	}
+	raw, err := json.Marshal(v)
+	if err == nil {
+		err = a.cache.Set(ctx, key, raw, ttl)
+	}
+	if err != nil {
+		a.metrics.Errors.Add(1)
+	}
+}
+
+// RevokeKey revokes one of a tenant's API keys and drops it from the auth
+// cache. With a shared cache the key stops working on every replica at
+// once. It returns store.ErrNotFound if the tenant has no such active key.
+func (a *Authenticator) RevokeKey(ctx context.Context, tenantID, keyID string) error {
+	hash, err := a.store.RevokeKey(ctx, tenantID, keyID)
+	if err != nil {
+		return err
+	}
+	if a.cache != nil {
+		if err := a.cache.Delete(ctx, "apikey:"+hash); err != nil {
+			return fmt.Errorf("key revoked but cache invalidation failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// hashToken returns the hex SHA-256 of token, as stored in sandbox.api_keys.
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
 // introspectionResponse represents the response from Hydra's token introspection endpoint.
 type introspectionResponse struct {
 	Active   bool   `json:"active"`
@@ -1,14 +1,22 @@
 package middleware
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 
 	"github.com/devs-group/skillbox/internal/api/response"
+	"github.com/devs-group/skillbox/internal/authcache"
+	"github.com/devs-group/skillbox/internal/store"
 )
 
 func init() {
@@ -145,8 +153,167 @@ func TestIsJWT(t *testing.T) {
 	}
 }
 
-// Note: Testing a valid Bearer token that reaches the store lookup would
-// require either a mock store or a real database. The AuthMiddleware accepts
-// a *store.Store (concrete type, not an interface), so we cannot easily mock
-// GetAPIKeyByHash without a database connection. Those tests are deferred
-// until the store is refactored to use an interface.
+// apiKeyColumns mirrors the SELECT column order in store.GetAPIKeyByHash.
+var apiKeyColumns = []string{
+	"id", "key_hash", "tenant_id", "name", "is_service", "created_at", "revoked_at",
+}
+
+// authRequest runs handler for a request carrying token and returns the
+// response code.
+func authRequest(handler gin.HandlerFunc, token string) int {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Request.Header.Set("Authorization", "Bearer "+token)
+	handler(c)
+	if !c.IsAborted() {
+		return http.StatusOK
+	}
+	return w.Code
+}
+
+// TestAuthenticator_CachesAPIKeyUntilRevoked verifies that a validated key
+// is served from the cache and that RevokeKey evicts it immediately.
+func TestAuthenticator_CachesAPIKeyUntilRevoked(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	const token = "sk-test-cached"
+	hash := hashToken(token)
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT id, key_hash, tenant_id, name, is_service, created_at, revoked_at").
+		WithArgs(hash).
+		WillReturnRows(sqlmock.NewRows(apiKeyColumns).
+			AddRow("key-1", hash, "tenant-1", "ci", false, now, nil))
+	mock.ExpectQuery("UPDATE sandbox.api_keys").
+		WithArgs("key-1", "tenant-1").
+		WillReturnRows(sqlmock.NewRows([]string{"key_hash"}).AddRow(hash))
+	mock.ExpectQuery("SELECT id, key_hash, tenant_id, name, is_service, created_at, revoked_at").
+		WithArgs(hash).
+		WillReturnRows(sqlmock.NewRows(apiKeyColumns).
+			AddRow("key-1", hash, "tenant-1", "ci", false, now, &now))
+
+	auth := NewAuthenticator(store.NewWithDB(db), "http://localhost:4445", authcache.NewLRU(10), time.Minute)
+	handler := auth.Required()
+
+	for i := 0; i < 3; i++ {
+		if code := authRequest(handler, token); code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want 200", i, code)
+		}
+	}
+
+	if err := auth.RevokeKey(context.Background(), "tenant-1", "key-1"); err != nil {
+		t.Fatalf("RevokeKey: %v", err)
+	}
+	if code := authRequest(handler, token); code != http.StatusUnauthorized {
+		t.Errorf("after revoke: status = %d, want 401", code)
+	}
+
+	m := auth.Metrics().Snapshot()
+	if m.Hits != 2 || m.Misses != 2 {
+		t.Errorf("hits/misses = %d/%d, want 2/2", m.Hits, m.Misses)
+	}
+	if m.APIKey.Count != 4 {
+		t.Errorf("api key auth count = %d, want 4", m.APIKey.Count)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+// TestAuthenticator_ZeroTTLDisablesCache verifies that every request goes
+// to the store when caching is off.
+func TestAuthenticator_ZeroTTLDisablesCache(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	const token = "sk-test-uncached"
+	hash := hashToken(token)
+	for i := 0; i < 2; i++ {
+		mock.ExpectQuery("SELECT id, key_hash, tenant_id, name, is_service, created_at, revoked_at").
+			WithArgs(hash).
+			WillReturnRows(sqlmock.NewRows(apiKeyColumns).
+				AddRow("key-1", hash, "tenant-1", "ci", false, time.Now(), nil))
+	}
+
+	handler := NewAuthenticator(store.NewWithDB(db), "http://localhost:4445", authcache.NewLRU(10), 0).Required()
+	for i := 0; i < 2; i++ {
+		if code := authRequest(handler, token); code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want 200", i, code)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAuthenticator_CloseClosesRedis(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	auth := NewAuthenticator(store.NewWithDB(nil), "http://localhost:4445", authcache.NewRedis(client), time.Minute)
+	if err := auth.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := client.Ping(context.Background()).Err(); !errors.Is(err, redis.ErrClosed) {
+		t.Errorf("Ping after Close = %v, want %v", err, redis.ErrClosed)
+	}
+
+	// Authenticators without a cache or with an in-process one have
+	// nothing to release.
+	for _, cache := range []authcache.Cache{nil, authcache.NewLRU(10)} {
+		if err := NewAuthenticator(store.NewWithDB(nil), "http://localhost:4445", cache, time.Minute).Close(); err != nil {
+			t.Errorf("Close(%T) = %v", cache, err)
+		}
+	}
+}
+
+// TestAuthenticator_Authenticate verifies that Authenticate resolves API
+// keys to their tenant and reports revoked keys as invalid credentials.
+func TestAuthenticator_Authenticate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT id, key_hash, tenant_id, name, is_service, created_at, revoked_at").
+		WithArgs(hashToken("sk-valid")).
+		WillReturnRows(sqlmock.NewRows(apiKeyColumns).
+			AddRow("key-1", hashToken("sk-valid"), "tenant-1", "ci", true, now, nil))
+	mock.ExpectQuery("SELECT id, key_hash, tenant_id, name, is_service, created_at, revoked_at").
+		WithArgs(hashToken("sk-revoked")).
+		WillReturnRows(sqlmock.NewRows(apiKeyColumns).
+			AddRow("key-2", hashToken("sk-revoked"), "tenant-1", "old", false, now, &now))
+
+	auth := NewAuthenticator(store.NewWithDB(db), "http://localhost:4445", nil, 0)
+
+	p, err := auth.Authenticate(context.Background(), "sk-valid")
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if p.TenantID != "tenant-1" || p.AuthType != AuthTypeAPIKey || p.APIKey == nil || !p.APIKey.IsService {
+		t.Errorf("principal = %+v", p)
+	}
+
+	if _, err := auth.Authenticate(context.Background(), "sk-revoked"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("revoked key: err = %v, want ErrInvalidCredentials", err)
+	}
+	if _, err := auth.Authenticate(context.Background(), ""); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("empty token: err = %v, want ErrInvalidCredentials", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
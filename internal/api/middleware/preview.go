@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/devs-group/skillbox/internal/preview"
+)
+
+// PreviewHost returns middleware that hands requests for a host-based
+// preview (<token>.<SKILLBOX_PREVIEW_DOMAIN>) to the preview proxy before
+// any API routing, so every path on that host reaches the sandbox. Other
+// requests pass through untouched.
+func PreviewHost(p *preview.Proxy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := p.TokenFromHost(c.Request.Host)
+		if !ok {
+			c.Next()
+			return
+		}
+		p.ServeHost(c.Writer, c.Request, token)
+		c.Abort()
+	}
+}
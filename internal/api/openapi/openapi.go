@@ -0,0 +1,330 @@
+// Package openapi builds an OpenAPI 3.1 document from a table of
+// operations. Request and response schemas are derived from the Go types
+// the handlers bind and render, so the document follows the code instead
+// of being maintained by hand.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version is the OpenAPI version of generated documents.
+const Version = "3.1.0"
+
+// Schema is a JSON Schema object.
+type Schema = map[string]any
+
+// Operation describes one route.
+type Operation struct {
+	Method string
+	// Path is the route in gin syntax, e.g. /v1/skills/:name/:version.
+	// Path parameters are documented automatically.
+	Path        string
+	ID          string
+	Tag         string
+	Summary     string
+	Description string
+
+	// Public operations need no credentials. OptionalAuth operations
+	// accept them but also work anonymously.
+	Public       bool
+	OptionalAuth bool
+
+	Params []Param
+
+	// Request is the JSON request body: a zero value of the Go type the
+	// handler binds, or a Schema. Form describes a multipart/form-data
+	// body instead; RawBody lists media types accepted as a raw body in
+	// addition to either.
+	Request any
+	Form    []Param
+	RawBody []string
+
+	Responses []Response
+	// Errors lists the error statuses the operation returns, all with the
+	// standard error body.
+	Errors []int
+}
+
+// Param is a query, header, or form parameter.
+type Param struct {
+	Name        string
+	In          string // "query" (default) or "header"; ignored for form fields
+	Type        string // JSON Schema type, or "file" for a binary form field
+	Description string
+	Required    bool
+}
+
+// Response is a successful response.
+type Response struct {
+	Status      int
+	Description string
+	// Body is a zero value of the Go type the handler renders, or a
+	// Schema. Nil means the response has no body.
+	Body any
+	// ContentType defaults to application/json.
+	ContentType string
+}
+
+// Info is the document's info object.
+type Info struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description,omitempty"`
+	Version     string  `json:"version"`
+	License     License `json:"license"`
+}
+
+// License is the API license.
+type License struct {
+	Name string `json:"name"`
+}
+
+// Server is an API base URL.
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// Tag groups operations.
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Document is an OpenAPI document.
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Servers    []Server                         `json:"servers,omitempty"`
+	Security   []map[string][]string            `json:"security"`
+	Tags       []Tag                            `json:"tags"`
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components components                       `json:"components"`
+}
+
+type components struct {
+	Schemas         map[string]Schema `json:"schemas"`
+	SecuritySchemes map[string]Schema `json:"securitySchemes"`
+}
+
+type operation struct {
+	Tags        []string              `json:"tags"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Parameters  []parameter           `json:"parameters,omitempty"`
+	RequestBody *requestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]response   `json:"responses"`
+}
+
+type parameter struct {
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Schema      Schema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]mediaType `json:"content"`
+}
+
+type response struct {
+	Description string               `json:"description"`
+	Content     map[string]mediaType `json:"content,omitempty"`
+}
+
+type mediaType struct {
+	Schema Schema `json:"schema"`
+}
+
+// securityScheme is the name of the bearer token scheme.
+const securityScheme = "BearerAuth"
+
+// errorSchema is the component name of the standard error body.
+const errorSchema = "Error"
+
+// Build assembles a document from ops. errorBody is a zero value of the
+// standard error response type. It fails on duplicate routes or
+// operation IDs and on operations tagged with an undeclared tag.
+func Build(info Info, servers []Server, tags []Tag, errorBody any, ops []Operation) (*Document, error) {
+	doc := &Document{
+		OpenAPI:  Version,
+		Info:     info,
+		Servers:  servers,
+		Security: []map[string][]string{{securityScheme: {}}},
+		Tags:     tags,
+		Paths:    make(map[string]map[string]*operation),
+		Components: components{
+			SecuritySchemes: map[string]Schema{securityScheme: {
+				"type":        "http",
+				"scheme":      "bearer",
+				"description": "API key or access token passed as a Bearer token",
+			}},
+		},
+	}
+
+	declared := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		declared[t.Name] = true
+	}
+
+	g := newGenerator()
+	g.register(errorBody, errorSchema)
+
+	ids := make(map[string]bool, len(ops))
+	for _, op := range ops {
+		if !declared[op.Tag] {
+			return nil, fmt.Errorf("%s %s: undeclared tag %q", op.Method, op.Path, op.Tag)
+		}
+		if ids[op.ID] {
+			return nil, fmt.Errorf("%s %s: duplicate operation ID %q", op.Method, op.Path, op.ID)
+		}
+		ids[op.ID] = true
+
+		path, pathParams := convertPath(op.Path)
+		method := strings.ToLower(op.Method)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*operation)
+		}
+		if doc.Paths[path][method] != nil {
+			return nil, fmt.Errorf("%s %s: duplicate route", op.Method, op.Path)
+		}
+		doc.Paths[path][method] = g.operation(op, pathParams)
+	}
+
+	doc.Components.Schemas = g.components
+	return doc, nil
+}
+
+// Routes returns the method and gin path of every operation, sorted, in
+// the form "GET /v1/skills".
+func Routes(ops []Operation) []string {
+	out := make([]string, len(ops))
+	for i, op := range ops {
+		out[i] = op.Method + " " + op.Path
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (g *generator) operation(op Operation, pathParams []string) *operation {
+	o := &operation{
+		Tags:        []string{op.Tag},
+		Summary:     op.Summary,
+		Description: op.Description,
+		OperationID: op.ID,
+		Responses:   make(map[string]response),
+	}
+	switch {
+	case op.Public:
+		o.Security = []map[string][]string{}
+	case op.OptionalAuth:
+		o.Security = []map[string][]string{{}, {securityScheme: {}}}
+	}
+
+	for _, name := range pathParams {
+		o.Parameters = append(o.Parameters, parameter{Name: name, In: "path", Required: true, Schema: Schema{"type": "string"}})
+	}
+	for _, p := range op.Params {
+		in := p.In
+		if in == "" {
+			in = "query"
+		}
+		o.Parameters = append(o.Parameters, parameter{
+			Name:        p.Name,
+			In:          in,
+			Description: p.Description,
+			Required:    p.Required,
+			Schema:      paramSchema(p),
+		})
+	}
+
+	if op.Request != nil || op.Form != nil || op.RawBody != nil {
+		body := &requestBody{Required: true, Content: make(map[string]mediaType)}
+		if op.Request != nil {
+			body.Content["application/json"] = mediaType{Schema: g.schemaOf(op.Request)}
+		}
+		if op.Form != nil {
+			body.Content["multipart/form-data"] = mediaType{Schema: formSchema(op.Form)}
+		}
+		for _, ct := range op.RawBody {
+			body.Content[ct] = mediaType{Schema: Schema{"type": "string", "format": "binary"}}
+		}
+		o.RequestBody = body
+	}
+
+	for _, r := range op.Responses {
+		resp := response{Description: r.Description}
+		if r.Body != nil {
+			ct := r.ContentType
+			if ct == "" {
+				ct = "application/json"
+			}
+			resp.Content = map[string]mediaType{ct: {Schema: g.schemaOf(r.Body)}}
+		}
+		o.Responses[strconv.Itoa(r.Status)] = resp
+	}
+	errs := op.Errors
+	if !op.Public && !op.OptionalAuth {
+		errs = append([]int{http.StatusUnauthorized}, errs...)
+	}
+	for _, status := range errs {
+		o.Responses[strconv.Itoa(status)] = response{
+			Description: http.StatusText(status),
+			Content:     map[string]mediaType{"application/json": {Schema: ref(errorSchema)}},
+		}
+	}
+	return o
+}
+
+// convertPath turns gin path parameters into OpenAPI templates and
+// returns their names in order.
+func convertPath(p string) (string, []string) {
+	segs := strings.Split(p, "/")
+	var params []string
+	for i, s := range segs {
+		if len(s) > 1 && (s[0] == ':' || s[0] == '*') {
+			params = append(params, s[1:])
+			segs[i] = "{" + s[1:] + "}"
+		}
+	}
+	return strings.Join(segs, "/"), params
+}
+
+func paramSchema(p Param) Schema {
+	if p.Type == "file" {
+		return Schema{"type": "string", "format": "binary"}
+	}
+	t := p.Type
+	if t == "" {
+		t = "string"
+	}
+	return Schema{"type": t}
+}
+
+func formSchema(fields []Param) Schema {
+	props := make(Schema, len(fields))
+	var required []string
+	for _, f := range fields {
+		s := paramSchema(f)
+		if f.Description != "" {
+			s["description"] = f.Description
+		}
+		props[f.Name] = s
+		if f.Required {
+			required = append(required, f.Name)
+		}
+	}
+	s := Schema{"type": "object", "properties": props}
+	if required != nil {
+		s["required"] = required
+	}
+	return s
+}
@@ -0,0 +1,104 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type apiError struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type base struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type node struct {
+	*base
+	Name     string          `json:"name" binding:"required"`
+	Children []node          `json:"children,omitempty"`
+	Meta     json.RawMessage `json:"meta"`
+	Data     []byte          `json:"data"`
+	Secret   string          `json:"-"`
+	hidden   string          //nolint:unused
+}
+
+func TestBuild(t *testing.T) {
+	doc, err := Build(Info{Title: "t", Version: "1"}, nil, []Tag{{Name: "Nodes"}}, apiError{}, []Operation{
+		{Method: "POST", Path: "/v1/nodes/:id", ID: "createNode", Tag: "Nodes", Summary: "Create",
+			Request:   node{},
+			Responses: []Response{{Status: 201, Description: "Created", Body: []node{}}},
+			Errors:    []int{400}},
+		{Method: "GET", Path: "/health", ID: "health", Tag: "Nodes", Summary: "Health", Public: true,
+			Responses: []Response{{Status: 200, Description: "OK"}}},
+	})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	op := doc.Paths["/v1/nodes/{id}"]["post"]
+	if op == nil {
+		t.Fatalf("paths = %v", doc.Paths)
+	}
+	if len(op.Parameters) != 1 || op.Parameters[0].Name != "id" || op.Parameters[0].In != "path" {
+		t.Errorf("parameters = %+v", op.Parameters)
+	}
+	if _, ok := op.Responses["401"]; !ok {
+		t.Error("authenticated operation has no 401 response")
+	}
+	if doc.Paths["/health"]["get"].Security == nil {
+		t.Error("public operation should override security")
+	}
+
+	got, _ := json.Marshal(doc.Components.Schemas["Node"])
+	for _, want := range []string{
+		`"id":{"type":"string"}`,
+		`"created_at":{"format":"date-time","type":"string"}`,
+		`"children":{"items":{"$ref":"#/components/schemas/Node"},"type":"array"}`,
+		`"meta":{}`,
+		`"data":{"contentEncoding":"base64","type":"string"}`,
+		`"required":["name"]`,
+	} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("Node schema %s lacks %s", got, want)
+		}
+	}
+	if strings.Contains(string(got), "Secret") || strings.Contains(string(got), "hidden") {
+		t.Errorf("Node schema %s has skipped fields", got)
+	}
+}
+
+func TestBuild_Rejects(t *testing.T) {
+	tags := []Tag{{Name: "A"}}
+	for name, ops := range map[string][]Operation{
+		"duplicate ID": {
+			{Method: "GET", Path: "/a", ID: "x", Tag: "A"},
+			{Method: "GET", Path: "/b", ID: "x", Tag: "A"},
+		},
+		"duplicate route": {
+			{Method: "GET", Path: "/a/:id", ID: "x", Tag: "A"},
+			{Method: "GET", Path: "/a/:id", ID: "y", Tag: "A"},
+		},
+		"undeclared tag": {
+			{Method: "GET", Path: "/a", ID: "x", Tag: "B"},
+		},
+	} {
+		if _, err := Build(Info{}, nil, tags, apiError{}, ops); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestComponentNameClash(t *testing.T) {
+	g := newGenerator()
+	type Error struct{ A int }
+	g.register(apiError{}, "Error")
+	if got := g.component(reflect.TypeFor[Error]()); got != "OpenapiError" {
+		t.Errorf("component name = %q", got)
+	}
+}
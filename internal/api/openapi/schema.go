@@ -0,0 +1,167 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+	durationType   = reflect.TypeFor[time.Duration]()
+)
+
+// generator converts Go types to JSON Schema, emitting every named struct
+// as a component.
+type generator struct {
+	components map[string]Schema
+	names      map[reflect.Type]string
+}
+
+func newGenerator() *generator {
+	return &generator{components: make(map[string]Schema), names: make(map[reflect.Type]string)}
+}
+
+// register emits v's type as a component under name.
+func (g *generator) register(v any, name string) {
+	t := reflect.TypeOf(v)
+	g.names[t] = name
+	g.components[name] = g.structSchema(t)
+}
+
+// schemaOf returns the schema of v, which is either a Schema or a zero
+// value of a Go type.
+func (g *generator) schemaOf(v any) Schema {
+	if s, ok := v.(Schema); ok {
+		return s
+	}
+	return g.typeSchema(reflect.TypeOf(v))
+}
+
+// typeSchema follows encoding/json: the schema describes what
+// json.Marshal produces for t.
+func (g *generator) typeSchema(t reflect.Type) Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t {
+	case timeType:
+		return Schema{"type": "string", "format": "date-time"}
+	case rawMessageType:
+		return Schema{}
+	case durationType:
+		return Schema{"type": "integer", "description": "Duration in nanoseconds"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return Schema{"type": "string", "contentEncoding": "base64"}
+		}
+		return Schema{"type": "array", "items": g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return ref(g.component(t))
+	}
+	// Interfaces and anything else encoding/json renders dynamically.
+	return Schema{}
+}
+
+// component returns the component name of the named struct t, emitting
+// it on first use. Names clash when two packages use the same type name;
+// the later one is qualified with its package name.
+func (g *generator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := exported(t.Name())
+	if _, taken := g.components[name]; taken {
+		pkg := t.PkgPath()
+		name = exported(pkg[strings.LastIndex(pkg, "/")+1:]) + name
+	}
+	g.names[t] = name
+	g.components[name] = Schema{} // placeholder for recursive types
+	g.components[name] = g.structSchema(t)
+	return name
+}
+
+// structSchema describes a struct inline. Embedded structs without a JSON
+// name are flattened into it, as encoding/json does. Fields are required
+// when their binding tag says so.
+func (g *generator) structSchema(t reflect.Type) Schema {
+	props := make(Schema)
+	var required []string
+	g.addFields(t, props, &required)
+	s := Schema{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func (g *generator) addFields(t reflect.Type, props Schema, required *[]string) {
+	// Embedded fields first, so fields of t shadow promoted ones.
+	for _, embedded := range []bool{true, false} {
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if f.Anonymous != embedded {
+				continue
+			}
+			tag := f.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, _, _ := strings.Cut(tag, ",")
+
+			if f.Anonymous && name == "" {
+				ft := f.Type
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					g.addFields(ft, props, required)
+					continue
+				}
+			}
+			if !f.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			props[name] = g.typeSchema(f.Type)
+			if strings.Contains(f.Tag.Get("binding"), "required") {
+				*required = append(*required, name)
+			}
+		}
+	}
+}
+
+func ref(name string) Schema {
+	return Schema{"$ref": "#/components/schemas/" + name}
+}
+
+func exported(name string) string {
+	if name == "" {
+		return name
+	}
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
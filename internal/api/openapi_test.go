@@ -0,0 +1,122 @@
+package api
+
+import (
+	"bytes"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/devs-group/skillbox/internal/api/handlers"
+	"github.com/devs-group/skillbox/internal/artifacts"
+	"github.com/devs-group/skillbox/internal/preview"
+	"github.com/devs-group/skillbox/internal/sandbox"
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+var updateOpenAPI = flag.Bool("update", false, "rewrite docs-site/openapi.json")
+
+// openAPIFile is the checked-in document the docs site renders.
+const openAPIFile = "../../docs-site/openapi.json"
+
+// TestOpenAPI_CoversRoutes fails when a route is added to or removed
+// from NewRouter without updating the OpenAPI operations.
+func TestOpenAPI_CoversRoutes(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+	st := store.NewWithDB(db)
+	cfg := testConfig()
+	cfg.GitHubToken = "ghp-test" // registers the GitHub routes
+
+	// Every optional dependency is set so all routes are registered.
+	sm := sandbox.NewSessionManager(sandbox.New("http://unused", "", nil), st, nil, cfg)
+	router := NewRouter(cfg, st, NewAuthenticator(cfg, st), nil, nil, nil, sm, nil, nil, &artifacts.Collector{})
+
+	var routes []string
+	for _, r := range router.Routes() {
+		// Previews proxy any method to the sandboxed app; they are not
+		// part of the API.
+		if strings.HasPrefix(r.Path, preview.PathPrefix) {
+			continue
+		}
+		routes = append(routes, r.Method+" "+r.Path)
+	}
+	sort.Strings(routes)
+
+	doc, err := handlers.OpenAPIDocument()
+	if err != nil {
+		t.Fatalf("OpenAPIDocument: %v", err)
+	}
+	var documented []string
+	for path, ops := range doc.Paths {
+		for method := range ops {
+			documented = append(documented, strings.ToUpper(method)+" "+ginPath(path))
+		}
+	}
+	sort.Strings(documented)
+
+	want := make(map[string]bool, len(documented))
+	for _, r := range documented {
+		want[r] = true
+	}
+	for _, r := range routes {
+		if !want[r] {
+			t.Errorf("route %s is not documented", r)
+		}
+		delete(want, r)
+	}
+	for r := range want {
+		t.Errorf("documented route %s is not registered", r)
+	}
+}
+
+// ginPath converts an OpenAPI path template back to gin syntax. The only
+// wildcard route, the preview proxy, is not documented, so every
+// parameter is a named one.
+func ginPath(p string) string {
+	return strings.NewReplacer("{", ":", "}", "").Replace(p)
+}
+
+// TestOpenAPI_CheckedIn fails when docs-site/openapi.json is stale. Run
+// "make openapi" to regenerate it.
+func TestOpenAPI_CheckedIn(t *testing.T) {
+	body, err := handlers.MarshalOpenAPI()
+	if err != nil {
+		t.Fatalf("MarshalOpenAPI: %v", err)
+	}
+	if *updateOpenAPI {
+		if err := os.WriteFile(openAPIFile, body, 0o644); err != nil { //nolint:gosec // checked-in docs file
+			t.Fatal(err)
+		}
+		return
+	}
+	got, err := os.ReadFile(openAPIFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, body) {
+		t.Error("docs-site/openapi.json is out of date; run make openapi")
+	}
+}
+
+func TestOpenAPI_ServedWithoutAuth(t *testing.T) {
+	router, _, cleanup := setupRouter(t)
+	defer cleanup()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/openapi.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"openapi": "3.1.0"`) {
+		t.Errorf("body does not look like an OpenAPI document: %.100s", w.Body.String())
+	}
+}
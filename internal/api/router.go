@@ -1,32 +1,49 @@
 package api
 
 import (
+	"log/slog"
+
 	"github.com/gin-gonic/gin"
 
 	"github.com/devs-group/skillbox/internal/api/handlers"
 	"github.com/devs-group/skillbox/internal/api/middleware"
 	"github.com/devs-group/skillbox/internal/artifacts"
+	"github.com/devs-group/skillbox/internal/authcache"
 	"github.com/devs-group/skillbox/internal/config"
 	"github.com/devs-group/skillbox/internal/github"
+	"github.com/devs-group/skillbox/internal/mcpserver"
+	"github.com/devs-group/skillbox/internal/preview"
 	"github.com/devs-group/skillbox/internal/registry"
 	"github.com/devs-group/skillbox/internal/runner"
 	"github.com/devs-group/skillbox/internal/sandbox"
 	"github.com/devs-group/skillbox/internal/scanner"
 	"github.com/devs-group/skillbox/internal/store"
+	"github.com/devs-group/skillbox/internal/tools"
 )
 
 // NewRouter constructs the Gin engine with all routes, middleware, and
 // handler bindings. It wires up:
 //
 //   - /health and /ready (unauthenticated, for orchestrators)
+//   - /v1/openapi.json (unauthenticated, generated from handler types)
 //   - /v1/* (authenticated via Bearer token, tenant-scoped)
 //
 // The router uses gin.New() (no default middleware) and explicitly adds
 // Recovery and structured RequestLogger middleware so the log output is
 // fully controlled.
-func NewRouter(cfg *config.Config, s *store.Store, r *runner.Runner, reg *registry.Registry, sc scanner.Scanner, sm *sandbox.SessionManager, pipeline *scanner.Pipeline, worker *scanner.Worker, col ...*artifacts.Collector) *gin.Engine {
+func NewRouter(cfg *config.Config, s *store.Store, auth *middleware.Authenticator, r *runner.Runner, reg *registry.Registry, sc scanner.Scanner, sm *sandbox.SessionManager, pipeline *scanner.Pipeline, worker *scanner.Worker, col ...*artifacts.Collector) *gin.Engine {
 	engine := gin.New()
 	engine.Use(gin.Recovery())
+
+	// Sandbox port previews. Host-based previews are intercepted before
+	// any other middleware so the sandboxed app sees its own requests
+	// unmodified, including CORS preflights.
+	var previewProxy *preview.Proxy
+	if sm != nil {
+		previewProxy = preview.New(s, sm, cfg)
+		engine.Use(middleware.PreviewHost(previewProxy))
+	}
+
 	engine.Use(middleware.CORSMiddleware())
 	engine.Use(middleware.RequestLogger())
 
@@ -34,23 +51,41 @@ func NewRouter(cfg *config.Config, s *store.Store, r *runner.Runner, reg *regist
 	engine.GET("/health", handlers.Health(s))
 	engine.GET("/ready", handlers.Ready(s))
 
+	// OpenAPI document — public so client generators can fetch it.
+	engine.GET("/v1/openapi.json", handlers.OpenAPI())
+
 	// API v1 — requires valid API key and tenant context.
 	v1 := engine.Group("/v1")
-	v1.Use(middleware.AuthMiddleware(s, cfg.HydraAdminURL))
+	v1.Use(auth.Required())
 	v1.Use(middleware.TenantMiddleware())
 	{
 		// Execution endpoints
 		v1.POST("/executions", handlers.CreateExecution(r))
+		v1.GET("/executions", handlers.ListExecutions(s))
 		v1.GET("/executions/:id", handlers.GetExecution(s))
 		v1.GET("/executions/:id/logs", handlers.GetExecutionLogs(s))
+		v1.POST("/executions/:id/cancel", handlers.CancelExecution(r, s))
+
+		// Tool definitions for LLM providers and the MCP endpoint (streamable HTTP)
+		catalog := tools.NewCatalog(s, reg)
+		v1.GET("/tools", handlers.ListTools(catalog))
+		var workspaces mcpserver.Workspaces
+		if sm != nil {
+			workspaces = sm
+		}
+		mcpHandler := handlers.MCP(mcpserver.New(catalog, r, workspaces))
+		v1.POST("/mcp", mcpHandler)
+		v1.GET("/mcp", mcpHandler)
+		v1.DELETE("/mcp", mcpHandler)
 
 		// Skill management endpoints
 		v1.POST("/skills", handlers.UploadSkill(reg, s, cfg, sc, worker))
 		v1.POST("/skills/from-fields", handlers.CreateFromFields(reg, s, cfg, worker))
-		v1.POST("/skills/validate", handlers.ValidateSkill(cfg, sc))
+		v1.POST("/skills/validate", handlers.ValidateSkill(cfg, sc, s))
 		v1.GET("/skills", handlers.ListSkills(s, reg))
 		v1.GET("/skills/:name/:version", handlers.GetSkill(reg, s))
 		v1.GET("/skills/:name/:version/files", handlers.GetSkillFiles(reg, s))
+		v1.GET("/skills/:name/:version/sbom", handlers.GetSkillSBOM(reg, s))
 		v1.DELETE("/skills/:name/:version", handlers.DeleteSkill(reg, s))
 		v1.DELETE("/skills/:name", handlers.DeleteSkillVersions(reg, s))
 		v1.PUT("/skills/:name/files", handlers.WriteSkillFile(reg, s, cfg, worker))
@@ -58,8 +93,9 @@ func NewRouter(cfg *config.Config, s *store.Store, r *runner.Runner, reg *regist
 		v1.GET("/skills/:name/versions", handlers.ListSkillVersions(s))
 		v1.GET("/skills/:name/diff", handlers.SkillDiff(reg, s))
 		v1.PUT("/skills/:name/active", handlers.SetActiveSkillVersion(s))
+		v1.GET("/dependencies", handlers.ListDependents(s))
 
-		// Scanner admin endpoints — require admin token in addition to API key.
+		// Admin endpoints — require admin token in addition to API key.
 		admin := v1.Group("/admin")
 		admin.Use(middleware.AdminMiddleware(cfg.AdminToken))
 		{
@@ -70,6 +106,12 @@ func NewRouter(cfg *config.Config, s *store.Store, r *runner.Runner, reg *regist
 			admin.PUT("/scanner/config", handlers.UpdateScannerConfig(s))
 			admin.GET("/skills/review", handlers.ListSkillsForReview(s))
 			admin.PUT("/skills/:name/:version/review", handlers.ReviewSkill(reg, s))
+			admin.GET("/leaders", handlers.ListLeaders(s))
+			admin.GET("/auth/stats", handlers.AuthStats(auth))
+			admin.DELETE("/api-keys/:id", handlers.RevokeAPIKey(auth))
+			admin.GET("/publisher-keys", handlers.ListPublisherKeys(s))
+			admin.POST("/publisher-keys", handlers.AddPublisherKey(s))
+			admin.DELETE("/publisher-keys/:key_id", handlers.DeletePublisherKey(s))
 		}
 
 		// File/artifact endpoints
@@ -87,13 +129,20 @@ func NewRouter(cfg *config.Config, s *store.Store, r *runner.Runner, reg *regist
 			}
 
 			// Session workspace endpoints
-			sessionsHandler := handlers.NewSessionsHandler(s, col[0])
+			sessionsHandler := handlers.NewSessionsHandler(s, col[0], sm)
 			sessions := v1.Group("/sessions")
 			{
 				sessions.GET("/:external_id/files", sessionsHandler.ListFiles)
 				sessions.GET("/:external_id/files/:filename", sessionsHandler.DownloadFile)
 				sessions.DELETE("/:external_id/files/:filename", sessionsHandler.DeleteFile)
 				sessions.DELETE("/:external_id", sessionsHandler.Delete)
+				if sm != nil {
+					sessions.POST("/:external_id/snapshots", sessionsHandler.CreateSnapshot)
+					sessions.GET("/:external_id/snapshots", sessionsHandler.ListSnapshots)
+					sessions.DELETE("/:external_id/snapshots/:snapshot_id", sessionsHandler.DeleteSnapshot)
+					sessions.POST("/:external_id/restore", sessionsHandler.Restore)
+					sessions.POST("/:external_id/fork", sessionsHandler.Fork)
+				}
 			}
 		}
 
@@ -107,21 +156,37 @@ func NewRouter(cfg *config.Config, s *store.Store, r *runner.Runner, reg *regist
 				sbGroup.POST("/read-file", sandboxHandler.ReadFile)
 				sbGroup.POST("/write-file", sandboxHandler.WriteFile)
 				sbGroup.POST("/list-dir", sandboxHandler.ListDir)
+				sbGroup.POST("/search", sandboxHandler.Search)
+				sbGroup.POST("/edit", sandboxHandler.Edit)
 				sbGroup.POST("/sync", sandboxHandler.Sync)
 				sbGroup.POST("/upload-skill", sandboxHandler.UploadSkill)
 				sbGroup.POST("/upload-file", sandboxHandler.UploadFile)
 				sbGroup.POST("/download-file", sandboxHandler.DownloadFile)
+				sbGroup.POST("/processes", sandboxHandler.StartProcess)
+				sbGroup.GET("/processes", sandboxHandler.ListProcesses)
+				sbGroup.GET("/processes/:id", sandboxHandler.GetProcess)
+				sbGroup.GET("/processes/:id/output", sandboxHandler.ProcessOutput)
+				sbGroup.POST("/processes/:id/signal", sandboxHandler.SignalProcess)
+				sbGroup.POST("/processes/:id/wait", sandboxHandler.WaitProcess)
 				sbGroup.DELETE("/:session", sandboxHandler.Destroy)
+				sbGroup.GET("/:session/pty", sandboxHandler.PTY)
+
+				previewHandler := handlers.NewPreviewHandler(sm, s, previewProxy)
+				sbGroup.POST("/:session/ports", previewHandler.RegisterPort)
+				sbGroup.GET("/:session/ports", previewHandler.ListPorts)
+				sbGroup.DELETE("/:session/ports/:port", previewHandler.UnregisterPort)
+				engine.Any(preview.PathPrefix+":token/*path", previewHandler.Serve)
 			}
 		}
 	}
 
 	// Marketplace (public — auth optional)
 	marketplace := engine.Group("/v1/marketplace")
-	marketplace.Use(middleware.OptionalAuthMiddleware(s, cfg.HydraAdminURL))
+	marketplace.Use(auth.Optional())
 	{
 		marketplace.GET("/skills", handlers.ListMarketplaceSkills(s))
 		marketplace.GET("/skills/:name", handlers.GetMarketplaceSkill(s))
+		marketplace.GET("/skills/:name/download", handlers.DownloadMarketplaceSkill(reg, s))
 	}
 
 	// User endpoints (authenticated)
@@ -170,7 +235,7 @@ func NewRouter(cfg *config.Config, s *store.Store, r *runner.Runner, reg *regist
 			gh.GET("/preview", handlers.PreviewGitHub(ghMarketplace))
 		}
 		ghAuth := engine.Group("/v1/github")
-		ghAuth.Use(middleware.AuthMiddleware(s, cfg.HydraAdminURL))
+		ghAuth.Use(auth.Required())
 		ghAuth.Use(middleware.TenantMiddleware())
 		{
 			ghAuth.POST("/install", handlers.InstallFromGitHub(ghMarketplace, worker))
@@ -179,3 +244,19 @@ func NewRouter(cfg *config.Config, s *store.Store, r *runner.Runner, reg *regist
 
 	return engine
 }
+
+// NewAuthenticator creates the authenticator shared by the HTTP and gRPC
+// servers. Validated keys and tokens are cached in Redis when configured,
+// otherwise in process. Close the authenticator on shutdown to release the
+// Redis connections.
+func NewAuthenticator(cfg *config.Config, s *store.Store) *middleware.Authenticator {
+	if cfg.AuthCacheTTL <= 0 {
+		return middleware.NewAuthenticator(s, cfg.HydraAdminURL, nil, 0)
+	}
+	authCache, err := authcache.New(cfg.RedisURL, cfg.AuthCacheSize)
+	if err != nil {
+		slog.Warn("auth cache: falling back to in-memory cache", "error", err)
+		authCache = authcache.NewLRU(cfg.AuthCacheSize)
+	}
+	return middleware.NewAuthenticator(s, cfg.HydraAdminURL, authCache, cfg.AuthCacheTTL)
+}
@@ -1,11 +1,16 @@
 package api
 
 import (
+	"bytes"
 	"crypto/sha256"
+	"database/sql"
 	"encoding/hex"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +21,7 @@ import (
 	"github.com/devs-group/skillbox/internal/api/middleware"
 	"github.com/devs-group/skillbox/internal/api/response"
 	"github.com/devs-group/skillbox/internal/config"
+	"github.com/devs-group/skillbox/internal/registry"
 	"github.com/devs-group/skillbox/internal/store"
 )
 
@@ -75,7 +81,7 @@ func setupRouter(t *testing.T) (*gin.Engine, sqlmock.Sqlmock, func()) {
 	// Pass nil runner and nil registry since we are not testing execution
 	// or skill endpoints. Pass nil collector as well; the router skips
 	// file route registration when no collector is provided.
-	router := NewRouter(cfg, st, nil, nil, nil, nil, nil, nil)
+	router := NewRouter(cfg, st, NewAuthenticator(cfg, st), nil, nil, nil, nil, nil, nil)
 
 	return router, mock, func() { db.Close() } //nolint:errcheck
 }
@@ -1405,3 +1411,302 @@ func TestRoute_SkillsFromFields_DoesNotConflict(t *testing.T) {
 		})
 	}
 }
+
+// -----------------------------------------------------------------------
+// GET /v1/executions, POST /v1/executions/:id/cancel
+// -----------------------------------------------------------------------
+
+// executionColumns mirrors the SELECT column order in store.GetExecution /
+// ListExecutions.
+var executionColumns = []string{
+	"id", "skill_name", "skill_version", "tenant_id", "status",
+	"input", "output", "logs", "files_url", "files_list",
+	"duration_ms", "error", "partial", "created_at", "finished_at",
+}
+
+func TestRouter_ListExecutions_WithFilters(t *testing.T) {
+	router, mock, cleanup := setupRouter(t)
+	defer cleanup()
+
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	expectAuthLookup(mock, testToken)
+	mock.ExpectQuery("SELECT id, skill_name, skill_version, tenant_id, status").
+		WithArgs(testTenantID, "word-counter", "failed", 5, 10).
+		WillReturnRows(sqlmock.NewRows(executionColumns).
+			AddRow("exec-1", "word-counter", "1.0.0", testTenantID, "failed",
+				[]byte(`{}`), []byte(`null`), "boom", "", "{}", int64(12), "exit 1", false, now, now))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, authRequest(http.MethodGet, "/v1/executions?skill=word-counter&status=failed&limit=5&offset=10"))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d\nbody: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var execs []store.Execution
+	if err := json.Unmarshal(w.Body.Bytes(), &execs); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(execs) != 1 || execs[0].ID != "exec-1" || execs[0].Status != "failed" {
+		t.Errorf("executions = %+v", execs)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRouter_ListExecutions_EmptyReturnsArray(t *testing.T) {
+	router, mock, cleanup := setupRouter(t)
+	defer cleanup()
+
+	expectAuthLookup(mock, testToken)
+	mock.ExpectQuery("SELECT id, skill_name, skill_version, tenant_id, status").
+		WithArgs(testTenantID, "", "", 20, 0).
+		WillReturnRows(sqlmock.NewRows(executionColumns))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, authRequest(http.MethodGet, "/v1/executions"))
+
+	if w.Code != http.StatusOK || w.Body.String() != "[]" {
+		t.Errorf("status = %d, body = %s; want 200 []", w.Code, w.Body.String())
+	}
+}
+
+func TestRouter_CancelExecution_NotRunning_Returns409(t *testing.T) {
+	router, mock, cleanup := setupRouter(t)
+	defer cleanup()
+
+	now := time.Now()
+	expectAuthLookup(mock, testToken)
+	mock.ExpectQuery("SELECT id, skill_name, skill_version, tenant_id, status").
+		WithArgs("exec-1", testTenantID).
+		WillReturnRows(sqlmock.NewRows(executionColumns).
+			AddRow("exec-1", "word-counter", "1.0.0", testTenantID, "success",
+				[]byte(`{}`), []byte(`{"ok":true}`), "", "", "{}", int64(12), nil, false, now, now))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, authRequest(http.MethodPost, "/v1/executions/exec-1/cancel"))
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d\nbody: %s", w.Code, http.StatusConflict, w.Body.String())
+	}
+	if e := decodeError(t, w.Body.Bytes()); e.Error != "not_running" {
+		t.Errorf("error = %q, want not_running", e.Error)
+	}
+}
+
+func TestRouter_CancelExecution_NotFound_Returns404(t *testing.T) {
+	router, mock, cleanup := setupRouter(t)
+	defer cleanup()
+
+	expectAuthLookup(mock, testToken)
+	mock.ExpectQuery("SELECT id, skill_name, skill_version, tenant_id, status").
+		WithArgs("exec-404", testTenantID).
+		WillReturnRows(sqlmock.NewRows(executionColumns))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, authRequest(http.MethodPost, "/v1/executions/exec-404/cancel"))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestRouter_DownloadMarketplaceSkill_NoAuth_Returns401(t *testing.T) {
+	router, _, cleanup := setupRouter(t)
+	defer cleanup()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/marketplace/skills/pdf-tools/download", nil))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d\nbody: %s", w.Code, http.StatusUnauthorized, w.Body.String())
+	}
+}
+
+func TestRouter_DownloadMarketplaceSkill_NotApproved_Returns403(t *testing.T) {
+	router, mock, cleanup := setupRouter(t)
+	defer cleanup()
+
+	// Only 1.0.0 is approved; "latest" resolves to 1.2.0, which is not.
+	expectAuthLookup(mock, testToken)
+	mock.ExpectQuery("FROM sandbox.skills").
+		WithArgs("pdf-tools", "available").
+		WillReturnRows(marketplaceSkillRows("1.2.0"))
+	mock.ExpectQuery("SELECT EXISTS").
+		WithArgs(testTenantID, "pdf-tools", "1.2.0").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, authRequest(http.MethodGet, "/v1/marketplace/skills/pdf-tools/download"))
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d\nbody: %s", w.Code, http.StatusForbidden, w.Body.String())
+	}
+	if e := decodeError(t, w.Body.Bytes()); e.Error != "not_approved" {
+		t.Errorf("error = %q, want not_approved", e.Error)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRouter_DownloadMarketplaceSkill_UnavailableVersion_Returns404(t *testing.T) {
+	router, mock, cleanup := setupRouter(t)
+	defer cleanup()
+
+	// A quarantined version is filtered out by status, so no approval is
+	// checked and nothing is served.
+	expectAuthLookup(mock, testToken)
+	mock.ExpectQuery("FROM sandbox.skills").
+		WithArgs("pdf-tools", "1.3.0", "available").
+		WillReturnError(sql.ErrNoRows)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, authRequest(http.MethodGet, "/v1/marketplace/skills/pdf-tools/download?version=1.3.0"))
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d\nbody: %s", w.Code, http.StatusNotFound, w.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// marketplaceSkillRows returns a public pdf-tools record at version.
+func marketplaceSkillRows(version string) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"tenant_id", "name", "version", "description", "lang", "stars", "uploaded_at"}).
+		AddRow("public", "pdf-tools", version, "PDF tools", "python", 0, time.Now())
+}
+
+func TestRouter_DownloadMarketplaceSkill_InvalidVersion_Returns400(t *testing.T) {
+	router, _, cleanup := setupRouter(t)
+	defer cleanup()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/marketplace/skills/pdf-tools/download?version=../x", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+// userColumns mirrors the SELECT column order in store.GetUserByKratosID.
+var userColumns = []string{"id", "kratos_identity_id", "tenant_id", "email", "display_name", "role", "created_at", "updated_at"}
+
+// TestRouter_ApprovalFlow_RequestApproveDownload follows `skillbox add`: a
+// member requests approval of a skill without naming a version, an admin
+// approves the request, and the member downloads the skill.
+func TestRouter_ApprovalFlow_RequestApproveDownload(t *testing.T) {
+	// Hydra introspection: the token names the Kratos identity.
+	hydra := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sub := strings.Split(strings.TrimPrefix(r.FormValue("token"), "eyJ"), ".")[0]
+		_ = json.NewEncoder(w).Encode(map[string]any{"active": true, "sub": sub})
+	}))
+	defer hydra.Close()
+
+	// S3: the bucket exists and holds the archive of pdf-tools 1.2.0.
+	archive := []byte("PK\x05\x06" + strings.Repeat("\x00", 18))
+	s3 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Query().Has("location"):
+			_, _ = io.WriteString(w, `<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`)
+		case r.Method == http.MethodHead && strings.Trim(r.URL.Path, "/") == "skills":
+		case r.URL.Path == "/skills/public/skills/pdf-tools/1.2.0/skill.zip":
+			w.Header().Set("ETag", `"1"`)
+			w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+			w.Header().Set("Content-Length", strconv.Itoa(len(archive)))
+			_, _ = w.Write(archive)
+		default:
+			t.Errorf("unexpected S3 request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer s3.Close()
+	reg, err := registry.New(strings.TrimPrefix(s3.URL, "http://"), "key", "secret", "skills", false)
+	if err != nil {
+		t.Fatalf("registry.New: %v", err)
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+	st := store.NewWithDB(db)
+	cfg := testConfig()
+	cfg.HydraAdminURL = hydra.URL
+	router := NewRouter(cfg, st, NewAuthenticator(cfg, st), nil, reg, nil, nil, nil, nil)
+
+	now := time.Now()
+	expectUser := func(kratosID, id, role string) {
+		mock.ExpectQuery("FROM sandbox.users").
+			WithArgs(kratosID).
+			WillReturnRows(sqlmock.NewRows(userColumns).AddRow(id, kratosID, testTenantID, id+"@example.com", id, role, now, now))
+	}
+	approvalRow := func(status string) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "tenant_id", "user_id", "skill_name", "skill_version", "status", "also_requested_by",
+			"reviewed_by", "review_comment", "scan_result", "source", "source_url", "approval_scope", "created_at", "reviewed_at"}).
+			AddRow("req-1", testTenantID, "user-dev", "pdf-tools", "1.2.0", status, []byte("[]"),
+				nil, nil, nil, "marketplace", nil, "global", now, nil)
+	}
+	do := func(method, target, token, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	const devToken, adminToken = "eyJkratos-dev.payload.sig", "eyJkratos-admin.payload.sig"
+
+	// 1. The request is pinned to the latest available version.
+	expectUser("kratos-dev", "user-dev", "member")
+	mock.ExpectQuery("FROM sandbox.skills").
+		WithArgs("pdf-tools", store.SkillStatusAvailable).
+		WillReturnRows(marketplaceSkillRows("1.2.0"))
+	mock.ExpectQuery("INSERT INTO sandbox.approval_requests").
+		WithArgs(testTenantID, "user-dev", "pdf-tools", "1.2.0", "", nil, "").
+		WillReturnRows(approvalRow("pending"))
+	if w := do(http.MethodPost, "/v1/approvals", devToken, `{"skill_name":"pdf-tools"}`); w.Code != http.StatusCreated {
+		t.Fatalf("request approval: status = %d\nbody: %s", w.Code, w.Body.String())
+	}
+
+	// 2. Approving the request approves that version for the tenant.
+	expectUser("kratos-admin", "user-admin", "admin")
+	mock.ExpectQuery("FROM sandbox.users").
+		WithArgs("user-admin").
+		WillReturnRows(sqlmock.NewRows(userColumns).AddRow("user-admin", "kratos-admin", testTenantID, "admin@example.com", "admin", "admin", now, now))
+	mock.ExpectQuery("FROM sandbox.approval_requests").
+		WithArgs("req-1").
+		WillReturnRows(approvalRow("pending"))
+	mock.ExpectExec("UPDATE sandbox.approval_requests").
+		WithArgs("approved", "user-admin", "", "req-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO sandbox.tenant_approved_skills").
+		WithArgs(testTenantID, "pdf-tools", "1.2.0", "user-admin").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if w := do(http.MethodPut, "/v1/approvals/req-1", adminToken, `{"status":"approved"}`); w.Code != http.StatusOK {
+		t.Fatalf("approve: status = %d\nbody: %s", w.Code, w.Body.String())
+	}
+
+	// 3. The member downloads the approved latest version.
+	expectUser("kratos-dev", "user-dev", "member")
+	mock.ExpectQuery("FROM sandbox.skills").
+		WithArgs("pdf-tools", store.SkillStatusAvailable).
+		WillReturnRows(marketplaceSkillRows("1.2.0"))
+	mock.ExpectQuery("SELECT EXISTS").
+		WithArgs(testTenantID, "pdf-tools", "1.2.0").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	w := do(http.MethodGet, "/v1/marketplace/skills/pdf-tools/download", devToken, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("download: status = %d\nbody: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("X-Skillbox-Version"); got != "1.2.0" || !bytes.Equal(w.Body.Bytes(), archive) {
+		t.Errorf("download served %q (%d bytes), want 1.2.0", got, w.Body.Len())
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
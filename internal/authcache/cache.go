@@ -0,0 +1,40 @@
+// Package authcache caches successful credential checks so that API-key
+// and token authentication do not hit Postgres or Hydra on every request.
+//
+// Two backends are provided: an in-process LRU for single-replica
+// deployments and a Redis cache shared by all replicas. Only the Redis
+// backend makes a Delete visible to every replica at once; with the LRU,
+// other replicas keep a revoked entry until its TTL runs out.
+package authcache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Cache stores opaque values under string keys with a per-entry TTL.
+// Implementations are safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under key and whether it was found.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores val under key until ttl elapses.
+	Set(ctx context.Context, key string, val []byte, ttl time.Duration) error
+	// Delete removes key.
+	Delete(ctx context.Context, key string) error
+}
+
+// New returns a Redis-backed cache when redisURL is set and an LRU holding
+// up to lruSize entries otherwise.
+func New(redisURL string, lruSize int) (Cache, error) {
+	if redisURL == "" {
+		return NewLRU(lruSize), nil
+	}
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("authcache: parse redis url: %w", err)
+	}
+	return NewRedis(redis.NewClient(opts)), nil
+}
@@ -0,0 +1,58 @@
+package authcache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	l := NewLRU(2)
+
+	_ = l.Set(ctx, "a", []byte("1"), time.Minute)
+	_ = l.Set(ctx, "b", []byte("2"), time.Minute)
+	if _, ok, _ := l.Get(ctx, "a"); !ok {
+		t.Fatal("a missing before eviction")
+	}
+	_ = l.Set(ctx, "c", []byte("3"), time.Minute)
+
+	if _, ok, _ := l.Get(ctx, "b"); ok {
+		t.Error("b still cached, want it evicted as least recently used")
+	}
+	for _, k := range []string{"a", "c"} {
+		if _, ok, _ := l.Get(ctx, k); !ok {
+			t.Errorf("%s evicted, want it kept", k)
+		}
+	}
+	if n := l.Len(); n != 2 {
+		t.Errorf("Len = %d, want 2", n)
+	}
+}
+
+func TestLRU_ExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	l := NewLRU(10)
+
+	_ = l.Set(ctx, "short", []byte("x"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok, _ := l.Get(ctx, "short"); ok {
+		t.Error("expired entry returned")
+	}
+	if n := l.Len(); n != 0 {
+		t.Errorf("Len = %d after expiry, want 0", n)
+	}
+}
+
+func TestLRU_Delete(t *testing.T) {
+	ctx := context.Background()
+	l := NewLRU(10)
+
+	_ = l.Set(ctx, "k", []byte("v"), time.Minute)
+	_ = l.Delete(ctx, "k")
+
+	if _, ok, _ := l.Get(ctx, "k"); ok {
+		t.Error("deleted entry returned")
+	}
+}
@@ -0,0 +1,88 @@
+package authcache
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Metrics tracks auth cache effectiveness and authentication latency.
+// All operations are safe for concurrent use.
+type Metrics struct {
+	Hits   atomic.Int64
+	Misses atomic.Int64
+	Errors atomic.Int64 // backend failures; the lookup falls through to the source
+
+	APIKey latency
+	JWT    latency
+}
+
+// latency accumulates the durations of one authentication path.
+type latency struct {
+	count atomic.Int64
+	total atomic.Int64 // nanoseconds
+	max   atomic.Int64 // nanoseconds
+}
+
+func (l *latency) record(d time.Duration) {
+	l.count.Add(1)
+	l.total.Add(d.Nanoseconds())
+	for {
+		old := l.max.Load()
+		if d.Nanoseconds() <= old || l.max.CompareAndSwap(old, d.Nanoseconds()) {
+			break
+		}
+	}
+}
+
+func (l *latency) snapshot() LatencySnapshot {
+	n := l.count.Load()
+	var avg float64
+	if n > 0 {
+		avg = float64(l.total.Load()) / float64(n) / float64(time.Millisecond)
+	}
+	return LatencySnapshot{
+		Count:         n,
+		AvgDurationMs: avg,
+		MaxDurationMs: float64(l.max.Load()) / float64(time.Millisecond),
+	}
+}
+
+// RecordAPIKey records how long one API-key authentication took.
+func (m *Metrics) RecordAPIKey(d time.Duration) { m.APIKey.record(d) }
+
+// RecordJWT records how long one token authentication took.
+func (m *Metrics) RecordJWT(d time.Duration) { m.JWT.record(d) }
+
+// Snapshot returns a point-in-time copy of the metrics as a serializable struct.
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	hits, misses := m.Hits.Load(), m.Misses.Load()
+	var rate float64
+	if hits+misses > 0 {
+		rate = float64(hits) / float64(hits+misses)
+	}
+	return MetricsSnapshot{
+		Hits:    hits,
+		Misses:  misses,
+		Errors:  m.Errors.Load(),
+		HitRate: rate,
+		APIKey:  m.APIKey.snapshot(),
+		JWT:     m.JWT.snapshot(),
+	}
+}
+
+// MetricsSnapshot is a serializable point-in-time view of auth metrics.
+type MetricsSnapshot struct {
+	Hits    int64           `json:"cache_hits"`
+	Misses  int64           `json:"cache_misses"`
+	Errors  int64           `json:"cache_errors"`
+	HitRate float64         `json:"cache_hit_rate"`
+	APIKey  LatencySnapshot `json:"api_key"`
+	JWT     LatencySnapshot `json:"jwt"`
+}
+
+// LatencySnapshot summarizes the latency of one authentication path.
+type LatencySnapshot struct {
+	Count         int64   `json:"count"`
+	AvgDurationMs float64 `json:"avg_duration_ms"`
+	MaxDurationMs float64 `json:"max_duration_ms"`
+}
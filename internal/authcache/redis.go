@@ -0,0 +1,56 @@
+package authcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// keyPrefix namespaces auth cache entries in a shared Redis.
+const keyPrefix = "skillbox:auth:"
+
+// Redis is a Cache shared by all replicas that use the same Redis.
+type Redis struct {
+	client *redis.Client
+}
+
+// NewRedis wraps an existing Redis client.
+func NewRedis(client *redis.Client) *Redis {
+	return &Redis{client: client}
+}
+
+// Get implements Cache.
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	val, err := r.client.Get(ctx, keyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("authcache: redis get: %w", err)
+	}
+	return val, true, nil
+}
+
+// Set implements Cache.
+func (r *Redis) Set(ctx context.Context, key string, val []byte, ttl time.Duration) error {
+	if err := r.client.Set(ctx, keyPrefix+key, val, ttl).Err(); err != nil {
+		return fmt.Errorf("authcache: redis set: %w", err)
+	}
+	return nil
+}
+
+// Delete implements Cache.
+func (r *Redis) Delete(ctx context.Context, key string) error {
+	if err := r.client.Del(ctx, keyPrefix+key).Err(); err != nil {
+		return fmt.Errorf("authcache: redis delete: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying Redis client.
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
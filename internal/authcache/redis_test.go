@@ -0,0 +1,62 @@
+package authcache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRedis_SharedBetweenClients(t *testing.T) {
+	mr := miniredis.RunT(t)
+	ctx := context.Background()
+
+	a := NewRedis(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	b := NewRedis(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+
+	if err := a.Set(ctx, "apikey:abc", []byte(`{"ID":"k1"}`), time.Minute); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	got, ok, err := b.Get(ctx, "apikey:abc")
+	if err != nil || !ok || string(got) != `{"ID":"k1"}` {
+		t.Fatalf("Get on second client = %q, %v, %v", got, ok, err)
+	}
+	if !mr.Exists(keyPrefix + "apikey:abc") {
+		t.Errorf("key not stored under %q prefix", keyPrefix)
+	}
+
+	if err := b.Delete(ctx, "apikey:abc"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok, _ := a.Get(ctx, "apikey:abc"); ok {
+		t.Error("entry deleted through one client still visible through the other")
+	}
+}
+
+func TestRedis_ExpiresEntries(t *testing.T) {
+	mr := miniredis.RunT(t)
+	ctx := context.Background()
+	c := NewRedis(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+
+	_ = c.Set(ctx, "jwt:x", []byte("{}"), 30*time.Second)
+	mr.FastForward(31 * time.Second)
+
+	if _, ok, err := c.Get(ctx, "jwt:x"); ok || err != nil {
+		t.Errorf("Get after TTL = %v, %v, want miss", ok, err)
+	}
+}
+
+func TestNew_InvalidRedisURL(t *testing.T) {
+	if _, err := New("not-a-url://", 10); err == nil {
+		t.Error("New with invalid redis URL returned nil error")
+	}
+	c, err := New("", 10)
+	if err != nil {
+		t.Fatalf("New without redis URL: %v", err)
+	}
+	if _, ok := c.(*LRU); !ok {
+		t.Errorf("New without redis URL = %T, want *LRU", c)
+	}
+}
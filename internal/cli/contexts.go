@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Authentication methods of a context.
+const (
+	AuthAPIKey     = "api-key"
+	AuthDeviceAuth = "device-auth"
+)
+
+// Context is a named server the CLI can talk to, with the credentials and
+// default tenant to use for it.
+type Context struct {
+	Name   string `json:"name"`
+	Server string `json:"server"`
+	Auth   string `json:"auth"` // AuthAPIKey or AuthDeviceAuth
+	Tenant string `json:"tenant,omitempty"`
+
+	// APIKey is set for AuthAPIKey contexts.
+	APIKey string `json:"api_key,omitempty"`
+
+	// HydraURL and ClientID are where `skillbox login` authenticates
+	// AuthDeviceAuth contexts; Credentials holds the resulting tokens.
+	HydraURL    string       `json:"hydra_url,omitempty"`
+	ClientID    string       `json:"client_id,omitempty"`
+	Credentials *Credentials `json:"credentials,omitempty"`
+}
+
+// Config is the CLI config file: the known contexts and the one in use.
+type Config struct {
+	CurrentContext string    `json:"current_context"`
+	Contexts       []Context `json:"contexts"`
+}
+
+// ConfigPath returns ~/.config/skillbox/config.json.
+func ConfigPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "skillbox", "config.json")
+}
+
+// LoadConfig reads the config file. If the file does not exist an empty
+// Config is returned with no error.
+func LoadConfig() (*Config, error) {
+	data, err := os.ReadFile(ConfigPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Config{}, nil
+		}
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("decode config %s: %w", ConfigPath(), err)
+	}
+	return &cfg, nil
+}
+
+// SaveConfig writes the config file with 0600 permissions, since it holds
+// API keys and tokens. Parent directories are created with 0700.
+func SaveConfig(cfg *Config) error {
+	p := ConfigPath()
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(p, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	return nil
+}
+
+// ValidateContextName rejects empty names and names with whitespace or
+// slashes.
+func ValidateContextName(name string) error {
+	if name == "" || strings.ContainsAny(name, " \t\r\n/\\") {
+		return fmt.Errorf("invalid context name %q", name)
+	}
+	return nil
+}
+
+// Find returns the context called name, or nil.
+func (cfg *Config) Find(name string) *Context {
+	for i := range cfg.Contexts {
+		if cfg.Contexts[i].Name == name {
+			return &cfg.Contexts[i]
+		}
+	}
+	return nil
+}
+
+// Delete removes the context called name and reports whether it existed.
+// Deleting the current context leaves no context in use.
+func (cfg *Config) Delete(name string) bool {
+	for i := range cfg.Contexts {
+		if cfg.Contexts[i].Name == name {
+			cfg.Contexts = append(cfg.Contexts[:i], cfg.Contexts[i+1:]...)
+			if cfg.CurrentContext == name {
+				cfg.CurrentContext = ""
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// ContextCredentials returns the device-auth tokens of the context called
+// name. An expired access token is refreshed and the config is saved with
+// the new tokens.
+func (cfg *Config) ContextCredentials(name string) (*Credentials, error) {
+	ctx := cfg.Find(name)
+	if ctx == nil {
+		return nil, fmt.Errorf("context %q not found", name)
+	}
+	if ctx.Auth != AuthDeviceAuth {
+		return nil, fmt.Errorf("context %q uses %s authentication", name, ctx.Auth)
+	}
+	creds := ctx.Credentials
+	if creds == nil || creds.AccessToken == "" {
+		return nil, fmt.Errorf("no credentials for context %q", name)
+	}
+
+	if creds.Expired() {
+		if creds.HydraURL == "" {
+			creds.HydraURL, creds.ClientID = ctx.HydraURL, ctx.ClientID
+		}
+		if err := creds.Refresh(); err != nil {
+			return nil, err
+		}
+		if err := SaveConfig(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return creds, nil
+}
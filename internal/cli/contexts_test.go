@@ -0,0 +1,256 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// tempHome points the config and credential paths at a fresh directory.
+func tempHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	return home
+}
+
+// tokenServer is an httptest token endpoint. It answers refresh requests
+// for the refresh token "rt-1" with access token "at-2" and refresh token
+// "rt-2", rejects any other with invalid_grant, and counts the requests.
+func tokenServer(t *testing.T) (*httptest.Server, *int) {
+	t.Helper()
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/oauth2/token" || r.FormValue("grant_type") != "refresh_token" {
+			t.Errorf("unexpected request %s %s grant_type=%q", r.Method, r.URL.Path, r.FormValue("grant_type"))
+		}
+		if r.FormValue("refresh_token") != "rt-1" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant", "error_description": "token revoked"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(TokenResponse{AccessToken: "at-2", RefreshToken: "rt-2", ExpiresIn: 3600})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestSaveConfig_RoundTripAndPermissions(t *testing.T) {
+	home := tempHome(t)
+
+	cfg, err := LoadConfig()
+	if err != nil || cfg.CurrentContext != "" || len(cfg.Contexts) != 0 {
+		t.Fatalf("LoadConfig without a file = %+v, %v; want an empty config", cfg, err)
+	}
+
+	cfg.Contexts = []Context{{Name: "prod", Server: "https://skillbox.example.com", Auth: AuthAPIKey, APIKey: "sk-secret"}}
+	cfg.CurrentContext = "prod"
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig: %v", err)
+	}
+
+	fi, err := os.Stat(ConfigPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0o600 {
+		t.Errorf("config mode = %o, want 600", perm)
+	}
+	di, err := os.Stat(filepath.Join(home, ".config", "skillbox"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := di.Mode().Perm(); perm != 0o700 {
+		t.Errorf("config directory mode = %o, want 700", perm)
+	}
+
+	got, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if got.CurrentContext != "prod" || len(got.Contexts) != 1 || got.Contexts[0] != cfg.Contexts[0] {
+		t.Errorf("LoadConfig = %+v, want %+v", got, cfg)
+	}
+}
+
+func TestLoadConfig_Corrupt(t *testing.T) {
+	tempHome(t)
+	if err := os.MkdirAll(filepath.Dir(ConfigPath()), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ConfigPath(), []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected error for a corrupt config file")
+	}
+}
+
+func TestConfigDelete(t *testing.T) {
+	tests := []struct {
+		name        string
+		delete      string
+		wantFound   bool
+		wantCurrent string
+		wantLeft    int
+	}{
+		{"current context", "prod", true, "", 1},
+		{"other context", "dev", true, "prod", 1},
+		{"missing context", "staging", false, "prod", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{CurrentContext: "prod", Contexts: []Context{{Name: "prod"}, {Name: "dev"}}}
+			if found := cfg.Delete(tt.delete); found != tt.wantFound {
+				t.Errorf("Delete(%q) = %v, want %v", tt.delete, found, tt.wantFound)
+			}
+			if cfg.CurrentContext != tt.wantCurrent {
+				t.Errorf("CurrentContext = %q, want %q", cfg.CurrentContext, tt.wantCurrent)
+			}
+			if len(cfg.Contexts) != tt.wantLeft || cfg.Find(tt.delete) != nil {
+				t.Errorf("contexts = %+v, want %d without %q", cfg.Contexts, tt.wantLeft, tt.delete)
+			}
+		})
+	}
+}
+
+func TestCredentialsExpired(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresIn time.Duration
+		want      bool
+	}{
+		{"valid", time.Hour, false},
+		{"within refresh skew", refreshSkew / 2, true},
+		{"just outside refresh skew", refreshSkew + 5*time.Second, false},
+		{"expired", -time.Minute, true},
+	}
+	for _, tt := range tests {
+		c := &Credentials{ExpiresAt: time.Now().Add(tt.expiresIn)}
+		if got := c.Expired(); got != tt.want {
+			t.Errorf("%s: Expired() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestContextCredentials(t *testing.T) {
+	srv, calls := tokenServer(t)
+
+	tests := []struct {
+		name      string
+		ctx       Context
+		lookup    string
+		wantErr   bool
+		wantToken string
+		wantCalls int
+		wantSaved string // access token persisted in the config file
+	}{
+		{
+			name:      "valid token",
+			ctx:       deviceContext(srv.URL, "at-1", "rt-1", time.Hour),
+			wantToken: "at-1",
+		},
+		{
+			name:      "expires within the refresh skew",
+			ctx:       deviceContext(srv.URL, "at-1", "rt-1", refreshSkew/2),
+			wantToken: "at-2",
+			wantCalls: 1,
+			wantSaved: "at-2",
+		},
+		{
+			name:      "expired",
+			ctx:       deviceContext(srv.URL, "at-1", "rt-1", -time.Hour),
+			wantToken: "at-2",
+			wantCalls: 1,
+			wantSaved: "at-2",
+		},
+		{
+			name:      "refresh rejected",
+			ctx:       deviceContext(srv.URL, "at-1", "rt-revoked", -time.Hour),
+			wantErr:   true,
+			wantCalls: 1,
+		},
+		{
+			name:    "expired without refresh token",
+			ctx:     deviceContext(srv.URL, "at-1", "", -time.Hour),
+			wantErr: true,
+		},
+		{
+			name:    "api-key context",
+			ctx:     Context{Name: "prod", Auth: AuthAPIKey, APIKey: "sk-1"},
+			wantErr: true,
+		},
+		{
+			name:    "not logged in",
+			ctx:     Context{Name: "prod", Auth: AuthDeviceAuth, HydraURL: srv.URL},
+			wantErr: true,
+		},
+		{
+			name:    "unknown context",
+			ctx:     deviceContext(srv.URL, "at-1", "rt-1", time.Hour),
+			lookup:  "staging",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempHome(t)
+			*calls = 0
+			cfg := &Config{CurrentContext: tt.ctx.Name, Contexts: []Context{tt.ctx}}
+			if err := SaveConfig(cfg); err != nil {
+				t.Fatal(err)
+			}
+
+			lookup := tt.lookup
+			if lookup == "" {
+				lookup = tt.ctx.Name
+			}
+			creds, err := cfg.ContextCredentials(lookup)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ContextCredentials error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if *calls != tt.wantCalls {
+				t.Errorf("token endpoint called %d times, want %d", *calls, tt.wantCalls)
+			}
+			if !tt.wantErr && creds.AccessToken != tt.wantToken {
+				t.Errorf("access token = %q, want %q", creds.AccessToken, tt.wantToken)
+			}
+
+			saved, err := LoadConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			stored := saved.Find(tt.ctx.Name).Credentials
+			switch {
+			case tt.wantSaved != "":
+				if stored.AccessToken != tt.wantSaved || stored.RefreshToken != "rt-2" || stored.Expired() {
+					t.Errorf("saved credentials = %+v, want the refreshed tokens", stored)
+				}
+			case stored != nil && stored.AccessToken != tt.ctx.Credentials.AccessToken:
+				t.Errorf("saved credentials changed to %+v", stored)
+			}
+		})
+	}
+}
+
+// deviceContext returns a device-auth context called "prod" whose access
+// token expires in expiresIn.
+func deviceContext(hydraURL, accessToken, refreshToken string, expiresIn time.Duration) Context {
+	return Context{
+		Name:     "prod",
+		Server:   "https://skillbox.example.com",
+		Auth:     AuthDeviceAuth,
+		HydraURL: hydraURL,
+		ClientID: "skillbox-cli",
+		Credentials: &Credentials{
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+			ExpiresAt:    time.Now().Add(expiresIn),
+		},
+	}
+}
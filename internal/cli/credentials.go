@@ -21,10 +21,46 @@ type Credentials struct {
 	ExpiresAt    time.Time `json:"expires_at"`
 	Email        string    `json:"email"`
 	TenantID     string    `json:"tenant_id"`
+	// HydraURL and ClientID record where the tokens were issued, so that an
+	// expired access token can be refreshed.
+	HydraURL string `json:"hydra_url,omitempty"`
+	ClientID string `json:"client_id,omitempty"`
 }
 
-// LoadCredentials reads credentials from CredentialPath. It returns an error if
-// the file is missing or the access token has expired.
+// refreshSkew is how long before its expiry an access token is refreshed,
+// so that it does not expire in flight.
+const refreshSkew = 30 * time.Second
+
+// Expired reports whether the access token has expired or is about to.
+func (c *Credentials) Expired() bool {
+	return time.Now().Add(refreshSkew).After(c.ExpiresAt)
+}
+
+// Refresh replaces the access and refresh tokens with new ones from the
+// token endpoint the credentials were issued by.
+func (c *Credentials) Refresh() error {
+	if c.RefreshToken == "" || c.HydraURL == "" {
+		return fmt.Errorf("credentials expired at %s and cannot be refreshed", c.ExpiresAt.Format(time.RFC3339))
+	}
+	clientID := c.ClientID
+	if clientID == "" {
+		clientID = DefaultClientID
+	}
+	tok, err := RefreshAccessToken(c.HydraURL, clientID, c.RefreshToken)
+	if err != nil {
+		return err
+	}
+	c.AccessToken = tok.AccessToken
+	if tok.RefreshToken != "" {
+		c.RefreshToken = tok.RefreshToken
+	}
+	c.ExpiresAt = time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second)
+	return nil
+}
+
+// LoadCredentials reads credentials from CredentialPath. An expired access
+// token is refreshed and the new tokens are saved; it returns an error if the
+// file is missing or the token cannot be refreshed.
 func LoadCredentials() (*Credentials, error) {
 	data, err := os.ReadFile(CredentialPath())
 	if err != nil {
@@ -36,8 +72,13 @@ func LoadCredentials() (*Credentials, error) {
 		return nil, fmt.Errorf("corrupt credentials file: %w", err)
 	}
 
-	if time.Now().After(creds.ExpiresAt) {
-		return nil, fmt.Errorf("credentials expired at %s", creds.ExpiresAt.Format(time.RFC3339))
+	if creds.Expired() {
+		if err := creds.Refresh(); err != nil {
+			return nil, err
+		}
+		if err := SaveCredentials(&creds); err != nil {
+			return nil, err
+		}
 	}
 
 	return &creds, nil
@@ -10,6 +10,9 @@ import (
 	"time"
 )
 
+// DefaultClientID is the OAuth2 client the CLI authenticates as.
+const DefaultClientID = "skillbox-cli"
+
 // DeviceAuthResponse represents the response from the device authorization endpoint.
 type DeviceAuthResponse struct {
 	DeviceCode              string `json:"device_code"`
@@ -128,3 +131,40 @@ func PollForToken(hydraPublicURL, clientID, deviceCode string, interval int) (*T
 	}
 }
 
+
+// RefreshAccessToken exchanges a refresh token for a new access token using
+// the refresh_token grant. Hydra rotates refresh tokens, so callers must keep
+// the refresh token of the response.
+func RefreshAccessToken(hydraPublicURL, clientID, refreshToken string) (*TokenResponse, error) {
+	endpoint := strings.TrimRight(hydraPublicURL, "/") + "/oauth2/token"
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("client_id", clientID)
+	form.Set("refresh_token", refreshToken)
+
+	resp, err := http.PostForm(endpoint, form)
+	if err != nil {
+		return nil, fmt.Errorf("refresh request failed: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read refresh response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp tokenErrorResponse
+		if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error != "" {
+			return nil, fmt.Errorf("refresh failed: %s — %s", errResp.Error, errResp.ErrorDescription)
+		}
+		return nil, fmt.Errorf("refresh failed (HTTP %d): %s", resp.StatusCode, string(body))
+	}
+
+	var tok TokenResponse
+	if err := json.Unmarshal(body, &tok); err != nil {
+		return nil, fmt.Errorf("decode refresh response: %w", err)
+	}
+	return &tok, nil
+}
@@ -1,13 +1,32 @@
 package cli
 
 import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 )
 
+// Install scopes.
+const (
+	ScopeProject = "project"
+	ScopeGlobal  = "global"
+)
+
+// Limits for extracting a skill archive.
+const (
+	maxArchiveFiles = 1000
+	maxArchiveBytes = 256 << 20 // uncompressed
+)
+
 // InstalledSkill describes a skill that has been installed locally.
 type InstalledSkill struct {
 	Name        string    `json:"name"`
@@ -15,24 +34,54 @@ type InstalledSkill struct {
 	Provider    string    `json:"provider"`
 	Scope       string    `json:"scope"` // "project" or "global"
 	Path        string    `json:"path"`
+	Integrity   string    `json:"integrity"` // "sha256:<hex>" of the installed archive
 	InstalledAt time.Time `json:"installed_at"`
 }
 
-// LockFile holds the set of locally installed skills.
+// LockFile holds the set of skills installed in one scope.
 type LockFile struct {
 	Skills []InstalledSkill `json:"skills"`
+
+	// legacy holds project entries found in the global lock file, where
+	// project skills were recorded before they moved to ./skill-lock.json.
+	// Their project directory is unknown, so they are hidden from the
+	// global scope but written back unchanged.
+	legacy []InstalledSkill
+}
+
+// Find returns the entry for skillName, or nil.
+func (lf *LockFile) Find(skillName string) *InstalledSkill {
+	for i := range lf.Skills {
+		if lf.Skills[i].Name == skillName {
+			return &lf.Skills[i]
+		}
+	}
+	return nil
+}
+
+// Scope returns ScopeGlobal if global is set and ScopeProject otherwise.
+func Scope(global bool) string {
+	if global {
+		return ScopeGlobal
+	}
+	return ScopeProject
 }
 
-// LockFilePath returns ~/.config/skillbox/skill-lock.json.
-func LockFilePath() string {
+// LockFilePath returns the lock file of a scope: skill-lock.json in the
+// current directory for project skills, so it can be committed next to
+// .claude/skills, and ~/.config/skillbox/skill-lock.json for global ones.
+func LockFilePath(global bool) string {
+	if !global {
+		return "skill-lock.json"
+	}
 	home, _ := os.UserHomeDir()
 	return filepath.Join(home, ".config", "skillbox", "skill-lock.json")
 }
 
-// LoadLockFile reads the lock file. If the file does not exist an empty
-// LockFile is returned with no error.
-func LoadLockFile() (*LockFile, error) {
-	data, err := os.ReadFile(LockFilePath())
+// LoadLockFile reads the lock file of a scope. If the file does not exist
+// an empty LockFile is returned with no error.
+func LoadLockFile(global bool) (*LockFile, error) {
+	data, err := os.ReadFile(LockFilePath(global))
 	if err != nil {
 		if os.IsNotExist(err) {
 			return &LockFile{}, nil
@@ -44,23 +93,43 @@ func LoadLockFile() (*LockFile, error) {
 	if err := json.Unmarshal(data, &lf); err != nil {
 		return nil, fmt.Errorf("decode lock file: %w", err)
 	}
+	if global {
+		skills := lf.Skills[:0]
+		for _, s := range lf.Skills {
+			if s.Scope == ScopeProject {
+				lf.legacy = append(lf.legacy, s)
+			} else {
+				skills = append(skills, s)
+			}
+		}
+		lf.Skills = skills
+	}
 	return &lf, nil
 }
 
-// SaveLockFile writes the lock file with 0600 permissions, creating parent
-// directories (0700) as needed.
-func SaveLockFile(lf *LockFile) error {
-	p := LockFilePath()
+// SaveLockFile writes the lock file of a scope, creating parent directories
+// (0700) as needed. The global lock file is written with 0600 permissions,
+// the project one with 0644 so it can be shared.
+func SaveLockFile(lf *LockFile, global bool) error {
+	p := LockFilePath(global)
 	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
 		return fmt.Errorf("create config directory: %w", err)
 	}
 
-	data, err := json.MarshalIndent(lf, "", "  ")
+	out := lf
+	if len(lf.legacy) > 0 {
+		out = &LockFile{Skills: append(slices.Clone(lf.Skills), lf.legacy...)}
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	if err := os.WriteFile(p, data, 0600); err != nil {
+	perm := os.FileMode(0644)
+	if global {
+		perm = 0600
+	}
+	if err := os.WriteFile(p, append(data, '\n'), perm); err != nil {
 		return fmt.Errorf("write lock file: %w", err)
 	}
 	return nil
@@ -77,31 +146,27 @@ func InstallPath(skillName string, global bool) string {
 	return filepath.Join(".claude", "skills", skillName, "SKILL.md")
 }
 
-// AddToLockFile adds or updates a skill entry in the lock file.
+// AddToLockFile adds or updates a skill entry in the lock file of the
+// skill's scope.
 func AddToLockFile(skill InstalledSkill) error {
-	lf, err := LoadLockFile()
+	global := skill.Scope == ScopeGlobal
+	lf, err := LoadLockFile(global)
 	if err != nil {
 		return err
 	}
 
-	found := false
-	for i, s := range lf.Skills {
-		if s.Name == skill.Name {
-			lf.Skills[i] = skill
-			found = true
-			break
-		}
-	}
-	if !found {
+	if existing := lf.Find(skill.Name); existing != nil {
+		*existing = skill
+	} else {
 		lf.Skills = append(lf.Skills, skill)
 	}
 
-	return SaveLockFile(lf)
+	return SaveLockFile(lf, global)
 }
 
-// RemoveFromLockFile removes a skill by name from the lock file.
-func RemoveFromLockFile(skillName string) error {
-	lf, err := LoadLockFile()
+// RemoveFromLockFile removes a skill by name from the lock file of a scope.
+func RemoveFromLockFile(skillName string, global bool) error {
+	lf, err := LoadLockFile(global)
 	if err != nil {
 		return err
 	}
@@ -114,20 +179,138 @@ func RemoveFromLockFile(skillName string) error {
 	}
 	lf.Skills = filtered
 
-	return SaveLockFile(lf)
+	return SaveLockFile(lf, global)
 }
 
 // IsInstalled checks whether a skill with the given name is present in the
-// lock file.
-func IsInstalled(skillName string) bool {
-	lf, err := LoadLockFile()
+// lock file of a scope.
+func IsInstalled(skillName string, global bool) bool {
+	lf, err := LoadLockFile(global)
 	if err != nil {
 		return false
 	}
-	for _, s := range lf.Skills {
-		if s.Name == skillName {
-			return true
+	return lf.Find(skillName) != nil
+}
+
+// Digest returns the integrity string recorded for an archive:
+// "sha256:" followed by the hex SHA-256 of data.
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// VerifyDigest checks data against an integrity string from Digest.
+func VerifyDigest(data []byte, want string) error {
+	if !strings.HasPrefix(want, "sha256:") {
+		return fmt.Errorf("unsupported integrity %q", want)
+	}
+	if got := Digest(data); got != want {
+		return fmt.Errorf("integrity mismatch: got %s, want %s", got, want)
+	}
+	return nil
+}
+
+// ExtractArchive installs the skill zip in data into dir, replacing what
+// dir held before. Every entry is checked before anything is written:
+// entries must be regular files or directories with local paths, and the
+// archive must have a SKILL.md at its root. The files are extracted into a
+// sibling temporary directory that is renamed into place, so a failed
+// install leaves the previous one intact. The uncompressed size limit is
+// enforced on the bytes actually written, not on the sizes the archive
+// declares.
+func ExtractArchive(data []byte, dir string) error {
+	return extractArchive(data, dir, maxArchiveBytes)
+}
+
+// extractArchive is ExtractArchive with a limit of maxBytes uncompressed.
+func extractArchive(data []byte, dir string, maxBytes int64) error {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return fmt.Errorf("open skill archive: %w", err)
+	}
+	if len(zr.File) > maxArchiveFiles {
+		return fmt.Errorf("skill archive has %d entries (limit %d)", len(zr.File), maxArchiveFiles)
+	}
+
+	hasSkillMD := false
+	for _, f := range zr.File {
+		name := strings.TrimPrefix(f.Name, "./")
+		if !filepath.IsLocal(filepath.FromSlash(name)) {
+			return fmt.Errorf("skill archive entry %q escapes the skill directory", f.Name)
+		}
+		if mode := f.Mode(); !mode.IsRegular() && !mode.IsDir() {
+			return fmt.Errorf("skill archive entry %q is not a regular file", f.Name)
+		}
+		if name == "SKILL.md" {
+			hasSkillMD = true
+		}
+	}
+	if !hasSkillMD {
+		return fmt.Errorf("skill archive has no SKILL.md")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0700); err != nil {
+		return fmt.Errorf("create skill directory: %w", err)
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dir), "."+filepath.Base(dir)+"-*")
+	if err != nil {
+		return fmt.Errorf("create skill directory: %w", err)
+	}
+	defer os.RemoveAll(tmp) //nolint:errcheck
+
+	remaining := maxBytes
+	for _, f := range zr.File {
+		if err := extractFile(tmp, f, &remaining); err != nil {
+			return err
 		}
 	}
-	return false
+	if err := os.Chmod(tmp, 0755); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("remove previous install: %w", err)
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		return fmt.Errorf("move skill into place: %w", err)
+	}
+	return nil
+}
+
+// extractFile writes one checked archive entry below root and deducts the
+// bytes written from remaining, failing once the budget is exceeded.
+func extractFile(root string, f *zip.File, remaining *int64) error {
+	target := filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(f.Name, "./")))
+	if f.Mode().IsDir() {
+		return os.MkdirAll(target, 0755)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("open %s: %w", f.Name, err)
+	}
+	defer rc.Close() //nolint:errcheck
+
+	// Keep the executable bit; zip tools often record no mode at all.
+	perm := os.FileMode(0644)
+	if f.Mode()&0100 != 0 {
+		perm = 0755
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(out, io.LimitReader(rc, *remaining+1))
+	if err != nil {
+		out.Close() //nolint:errcheck
+		return fmt.Errorf("write %s: %w", f.Name, err)
+	}
+	if *remaining -= n; *remaining < 0 {
+		out.Close() //nolint:errcheck
+		return fmt.Errorf("skill archive exceeds the uncompressed size limit")
+	}
+	return out.Close()
 }
@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// buildArchive returns a zip archive of files, written in the given order.
+func buildArchive(t *testing.T, files ...[2]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range files {
+		fw, err := w.Create(f[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(f[1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// previousInstall creates dir holding an earlier version of a skill.
+func previousInstall(t *testing.T) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "skills", "pdf-tools")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// assertPreviousInstall checks that dir still holds only the earlier
+// version and that no temporary directory was left next to it.
+func assertPreviousInstall(t *testing.T, dir string) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	if err != nil || string(data) != "old" {
+		t.Errorf("previous SKILL.md = %q, %v; want it untouched", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "run.py")); !os.IsNotExist(err) {
+		t.Errorf("run.py was installed over the previous version")
+	}
+	siblings, err := os.ReadDir(filepath.Dir(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(siblings) != 1 {
+		t.Errorf("skills directory holds %d entries, want only the previous install", len(siblings))
+	}
+}
+
+func TestExtractArchive(t *testing.T) {
+	dir := previousInstall(t)
+	if err := os.WriteFile(filepath.Join(dir, "stale.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	data := buildArchive(t, [2]string{"SKILL.md", "new"}, [2]string{"./scripts/run.py", "print(1)"})
+	if err := ExtractArchive(data, dir); err != nil {
+		t.Fatalf("ExtractArchive: %v", err)
+	}
+
+	for name, want := range map[string]string{"SKILL.md": "new", "scripts/run.py": "print(1)"} {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(got) != want {
+			t.Errorf("%s = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "stale.txt")); !os.IsNotExist(err) {
+		t.Error("files of the previous install were kept")
+	}
+}
+
+func TestExtractArchive_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   [][2]string
+		wantErr string
+	}{
+		{"zip slip", [][2]string{{"SKILL.md", "x"}, {"../../evil.sh", "x"}}, "escapes"},
+		{"nested zip slip", [][2]string{{"SKILL.md", "x"}, {"scripts/../../evil.sh", "x"}}, "escapes"},
+		{"absolute path", [][2]string{{"SKILL.md", "x"}, {"/etc/cron.d/evil", "x"}}, "escapes"},
+		{"no SKILL.md", [][2]string{{"run.py", "x"}}, "no SKILL.md"},
+		{"SKILL.md not at the root", [][2]string{{"demo/SKILL.md", "x"}}, "no SKILL.md"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := previousInstall(t)
+			err := ExtractArchive(buildArchive(t, tt.files...), dir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ExtractArchive = %v, want error containing %q", err, tt.wantErr)
+			}
+			assertPreviousInstall(t, dir)
+		})
+	}
+}
+
+func TestExtractArchive_RollsBackOnFailure(t *testing.T) {
+	dir := previousInstall(t)
+
+	// SKILL.md is written before run.py exhausts the budget, so the
+	// failure happens halfway through extraction.
+	data := buildArchive(t, [2]string{"SKILL.md", "new"}, [2]string{"run.py", strings.Repeat("x", 20)})
+	err := extractArchive(data, dir, 10)
+	if err == nil || !strings.Contains(err.Error(), "size limit") {
+		t.Fatalf("extractArchive = %v, want size limit error", err)
+	}
+	assertPreviousInstall(t, dir)
+
+	// The budget counts bytes across files, not per file.
+	if err := extractArchive(data, dir, 23); err != nil {
+		t.Errorf("extractArchive within the budget: %v", err)
+	}
+}
+
+func TestVerifyDigest(t *testing.T) {
+	data := []byte("skill archive")
+	digest := Digest(data)
+	if !strings.HasPrefix(digest, "sha256:") || len(digest) != len("sha256:")+64 {
+		t.Fatalf("Digest = %q", digest)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    string
+		wantErr bool
+	}{
+		{"match", data, digest, false},
+		{"modified archive", []byte("skill archivE"), digest, true},
+		{"unsupported algorithm", data, "md5:" + strings.Repeat("0", 32), true},
+		{"empty integrity", data, "", true},
+	}
+	for _, tt := range tests {
+		if err := VerifyDigest(tt.data, tt.want); (err != nil) != tt.wantErr {
+			t.Errorf("%s: VerifyDigest = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestLoadLockFile_HidesLegacyProjectEntries(t *testing.T) {
+	tempHome(t)
+	t.Chdir(t.TempDir())
+
+	// Project skills used to be recorded in the global lock file.
+	old := &LockFile{Skills: []InstalledSkill{
+		{Name: "pdf-tools", Version: "1.0.0", Scope: ScopeProject},
+		{Name: "csv-tools", Version: "2.0.0", Scope: ScopeGlobal},
+	}}
+	if err := SaveLockFile(old, true); err != nil {
+		t.Fatal(err)
+	}
+
+	global, err := LoadLockFile(true)
+	if err != nil {
+		t.Fatalf("LoadLockFile(global): %v", err)
+	}
+	if len(global.Skills) != 1 || global.Skills[0].Name != "csv-tools" {
+		t.Errorf("global skills = %+v, want only csv-tools", global.Skills)
+	}
+	if IsInstalled("pdf-tools", true) || IsInstalled("pdf-tools", false) {
+		t.Error("legacy project entry is reported as installed")
+	}
+
+	// Changing the global lock file keeps the hidden entry.
+	if err := RemoveFromLockFile("csv-tools", true); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(LockFilePath(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"pdf-tools"`) || strings.Contains(string(data), `"csv-tools"`) {
+		t.Errorf("global lock file = %s, want only the legacy pdf-tools entry", data)
+	}
+}
@@ -17,6 +17,10 @@ type Config struct {
 	// Redis (optional — system degrades gracefully without it)
 	RedisURL string
 
+	// Authentication cache
+	AuthCacheTTL  time.Duration // how long validated API keys and tokens are cached; 0 disables
+	AuthCacheSize int           // max entries of the in-memory cache used when Redis is not configured
+
 	// S3 / MinIO
 	S3Endpoint        string
 	S3AccessKey       string
@@ -34,6 +38,7 @@ type Config struct {
 	// Execution limits
 	DefaultTimeout         time.Duration
 	MaxTimeout             time.Duration
+	TimeoutGracePeriod     time.Duration // SIGTERM-to-collection window after a timeout; 0 disables
 	DefaultMemory          int64   // bytes
 	MaxMemory              int64   // bytes — hard cap for skill-specified memory
 	DefaultCPU             float64 // fractional CPU (e.g. 0.5 = half a core)
@@ -46,6 +51,15 @@ type Config struct {
 	SandboxSessionTTL   time.Duration // idle TTL for session sandboxes
 	SandboxSessionImage string        // default image for session sandboxes
 	MaxSessionSandboxes int           // max concurrent session sandboxes per server
+	SandboxPTYIdleTimeout time.Duration // close interactive shells after this long without I/O
+	SnapshotMaxPerTenant  int           // workspace snapshots kept per tenant; oldest are pruned
+	SnapshotMaxAge        time.Duration // prune snapshots older than this; 0 keeps them indefinitely
+	SessionLeaseTTL       time.Duration // how long a replica owns a session sandbox without renewing
+
+	// Sandbox port previews
+	PreviewBaseURL     string // public URL of this server used to build path-based preview URLs; empty yields relative URLs
+	PreviewDomain      string // when set, previews are served from <token>.<domain> instead of /preview/<token>/
+	PreviewMaxBodySize int64  // bytes — max request body forwarded to a preview
 
 	// Ory (Identity & OAuth2)
 	KratosPublicURL string
@@ -70,12 +84,16 @@ type Config struct {
 	// Security scanner — custom patterns (optional)
 	ScannerPatternsFile string // path to custom patterns YAML (merged on top of defaults)
 	ScannerOSSFFeedDir  string // path to directory of OSV JSON files from OSSF malicious packages feed
+	ScannerOSVDir       string // path to an OSV dump (PyPI and npm) to match dependencies against known vulnerabilities
 
 	// Admin authentication
 	AdminToken string // static admin token for /v1/admin/* endpoints (env: SKILLBOX_ADMIN_TOKEN)
 
 	// Server
-	APIPort string
+	APIPort             string
+	GRPCPort            string
+	ReplicaID           string        // identifies this replica in session leases and leader roles; defaults to hostname-pid
+	LeaderRetryInterval time.Duration // how often followers retry and leaders re-check singleton background roles
 
 	// Observability
 	LogLevel string
@@ -136,6 +154,7 @@ func Load() (*Config, error) {
 		HydraAdminURL:     envOrDefault("SKILLBOX_HYDRA_ADMIN_URL", "http://localhost:4445"),
 		GitHubToken:       get("GITHUB_TOKEN"),
 		APIPort:           envOrDefault("SKILLBOX_API_PORT", "8080"),
+		GRPCPort:          envOrDefault("SKILLBOX_GRPC_PORT", "9090"),
 		LogLevel:          envOrDefault("SKILLBOX_LOG_LEVEL", "info"),
 	}
 
@@ -179,6 +198,13 @@ func Load() (*Config, error) {
 	if cfg.DefaultTimeout > cfg.MaxTimeout {
 		return nil, fmt.Errorf("SKILLBOX_DEFAULT_TIMEOUT (%s) exceeds SKILLBOX_MAX_TIMEOUT (%s)", cfg.DefaultTimeout, cfg.MaxTimeout)
 	}
+	cfg.TimeoutGracePeriod, err = time.ParseDuration(envOrDefault("SKILLBOX_TIMEOUT_GRACE_PERIOD", "10s"))
+	if err != nil {
+		return nil, fmt.Errorf("SKILLBOX_TIMEOUT_GRACE_PERIOD: %w", err)
+	}
+	if cfg.TimeoutGracePeriod < 0 {
+		return nil, fmt.Errorf("SKILLBOX_TIMEOUT_GRACE_PERIOD must not be negative, got %s", cfg.TimeoutGracePeriod)
+	}
 
 	// Memory — also store the raw string for passing to OpenSandbox.
 	defaultMemoryStr = envOrDefault("SKILLBOX_DEFAULT_MEMORY", "256Mi")
@@ -257,6 +283,86 @@ func Load() (*Config, error) {
 	}
 	cfg.MaxSessionSandboxes = maxSessions
 
+	// Interactive PTY idle timeout
+	cfg.SandboxPTYIdleTimeout, err = time.ParseDuration(envOrDefault("SKILLBOX_SANDBOX_PTY_IDLE_TIMEOUT", "15m"))
+	if err != nil {
+		return nil, fmt.Errorf("SKILLBOX_SANDBOX_PTY_IDLE_TIMEOUT: %w", err)
+	}
+	if cfg.SandboxPTYIdleTimeout <= 0 {
+		return nil, fmt.Errorf("SKILLBOX_SANDBOX_PTY_IDLE_TIMEOUT must be positive, got %s", cfg.SandboxPTYIdleTimeout)
+	}
+
+	// Session snapshot retention
+	maxSnapshots, err := strconv.Atoi(envOrDefault("SKILLBOX_SNAPSHOT_MAX_PER_TENANT", "50"))
+	if err != nil {
+		return nil, fmt.Errorf("SKILLBOX_SNAPSHOT_MAX_PER_TENANT: %w", err)
+	}
+	if maxSnapshots <= 0 {
+		return nil, fmt.Errorf("SKILLBOX_SNAPSHOT_MAX_PER_TENANT must be positive, got %d", maxSnapshots)
+	}
+	cfg.SnapshotMaxPerTenant = maxSnapshots
+
+	cfg.SnapshotMaxAge, err = time.ParseDuration(envOrDefault("SKILLBOX_SNAPSHOT_MAX_AGE", "0s"))
+	if err != nil {
+		return nil, fmt.Errorf("SKILLBOX_SNAPSHOT_MAX_AGE: %w", err)
+	}
+	if cfg.SnapshotMaxAge < 0 {
+		return nil, fmt.Errorf("SKILLBOX_SNAPSHOT_MAX_AGE must not be negative, got %s", cfg.SnapshotMaxAge)
+	}
+
+	// Session sandbox ownership across replicas
+	cfg.SessionLeaseTTL, err = time.ParseDuration(envOrDefault("SKILLBOX_SESSION_LEASE_TTL", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("SKILLBOX_SESSION_LEASE_TTL: %w", err)
+	}
+	if cfg.SessionLeaseTTL < time.Second {
+		return nil, fmt.Errorf("SKILLBOX_SESSION_LEASE_TTL must be at least 1s, got %s", cfg.SessionLeaseTTL)
+	}
+
+	// Sandbox port previews
+	cfg.PreviewBaseURL = strings.TrimRight(get("SKILLBOX_PREVIEW_BASE_URL"), "/")
+	cfg.PreviewDomain = strings.Trim(strings.ToLower(get("SKILLBOX_PREVIEW_DOMAIN")), ".")
+	cfg.PreviewMaxBodySize, err = strconv.ParseInt(envOrDefault("SKILLBOX_PREVIEW_MAX_BODY_SIZE", "10485760"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("SKILLBOX_PREVIEW_MAX_BODY_SIZE: %w", err)
+	}
+	if cfg.PreviewMaxBodySize <= 0 {
+		return nil, fmt.Errorf("SKILLBOX_PREVIEW_MAX_BODY_SIZE must be positive, got %d", cfg.PreviewMaxBodySize)
+	}
+
+	cfg.ReplicaID = get("SKILLBOX_REPLICA_ID")
+	if cfg.ReplicaID == "" {
+		host, err := os.Hostname()
+		if err != nil || host == "" {
+			host = "skillbox"
+		}
+		cfg.ReplicaID = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+
+	cfg.LeaderRetryInterval, err = time.ParseDuration(envOrDefault("SKILLBOX_LEADER_RETRY_INTERVAL", "10s"))
+	if err != nil {
+		return nil, fmt.Errorf("SKILLBOX_LEADER_RETRY_INTERVAL: %w", err)
+	}
+	if cfg.LeaderRetryInterval < time.Second {
+		return nil, fmt.Errorf("SKILLBOX_LEADER_RETRY_INTERVAL must be at least 1s, got %s", cfg.LeaderRetryInterval)
+	}
+
+	// Authentication cache
+	cfg.AuthCacheTTL, err = time.ParseDuration(envOrDefault("SKILLBOX_AUTH_CACHE_TTL", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("SKILLBOX_AUTH_CACHE_TTL: %w", err)
+	}
+	if cfg.AuthCacheTTL < 0 {
+		return nil, fmt.Errorf("SKILLBOX_AUTH_CACHE_TTL must not be negative, got %s", cfg.AuthCacheTTL)
+	}
+	cfg.AuthCacheSize, err = strconv.Atoi(envOrDefault("SKILLBOX_AUTH_CACHE_SIZE", "10000"))
+	if err != nil {
+		return nil, fmt.Errorf("SKILLBOX_AUTH_CACHE_SIZE: %w", err)
+	}
+	if cfg.AuthCacheSize <= 0 {
+		return nil, fmt.Errorf("SKILLBOX_AUTH_CACHE_SIZE must be positive, got %d", cfg.AuthCacheSize)
+	}
+
 	// Security scanner
 	scannerEnabled, err := parseBool(envOrDefault("SKILLBOX_SCANNER_ENABLED", "true"))
 	if err != nil {
@@ -303,6 +409,7 @@ func Load() (*Config, error) {
 	// Custom scanner patterns (optional).
 	cfg.ScannerPatternsFile = get("SKILLBOX_SCANNER_PATTERNS_FILE")
 	cfg.ScannerOSSFFeedDir = get("SKILLBOX_SCANNER_OSSF_FEED_DIR")
+	cfg.ScannerOSVDir = get("SKILLBOX_SCANNER_OSV_DIR")
 
 	return cfg, nil
 }
@@ -99,6 +99,9 @@ func TestLoad_Defaults(t *testing.T) {
 	if cfg.APIPort != "8080" {
 		t.Errorf("APIPort = %q, want %q", cfg.APIPort, "8080")
 	}
+	if cfg.GRPCPort != "9090" {
+		t.Errorf("GRPCPort = %q, want %q", cfg.GRPCPort, "9090")
+	}
 	if cfg.LogLevel != "info" {
 		t.Errorf("LogLevel = %q, want %q", cfg.LogLevel, "info")
 	}
@@ -126,6 +129,9 @@ func TestLoad_Defaults(t *testing.T) {
 	if cfg.MaxTimeout != 10*time.Minute {
 		t.Errorf("MaxTimeout = %v, want %v", cfg.MaxTimeout, 10*time.Minute)
 	}
+	if cfg.TimeoutGracePeriod != 10*time.Second {
+		t.Errorf("TimeoutGracePeriod = %v, want %v", cfg.TimeoutGracePeriod, 10*time.Second)
+	}
 	if cfg.DefaultMemory != 256*1024*1024 {
 		t.Errorf("DefaultMemory = %d, want %d", cfg.DefaultMemory, 256*1024*1024)
 	}
@@ -138,6 +144,33 @@ func TestLoad_Defaults(t *testing.T) {
 	if cfg.MaxSkillSize != 52428800 {
 		t.Errorf("MaxSkillSize = %d, want %d", cfg.MaxSkillSize, 52428800)
 	}
+	if cfg.SnapshotMaxPerTenant != 50 {
+		t.Errorf("SnapshotMaxPerTenant = %d, want 50", cfg.SnapshotMaxPerTenant)
+	}
+	if cfg.SnapshotMaxAge != 0 {
+		t.Errorf("SnapshotMaxAge = %v, want 0", cfg.SnapshotMaxAge)
+	}
+	if cfg.SessionLeaseTTL != 30*time.Second {
+		t.Errorf("SessionLeaseTTL = %v, want %v", cfg.SessionLeaseTTL, 30*time.Second)
+	}
+	if cfg.ReplicaID == "" {
+		t.Error("ReplicaID is empty, want hostname-derived default")
+	}
+	if cfg.LeaderRetryInterval != 10*time.Second {
+		t.Errorf("LeaderRetryInterval = %v, want %v", cfg.LeaderRetryInterval, 10*time.Second)
+	}
+	if cfg.AuthCacheTTL != 30*time.Second {
+		t.Errorf("AuthCacheTTL = %v, want %v", cfg.AuthCacheTTL, 30*time.Second)
+	}
+	if cfg.AuthCacheSize != 10000 {
+		t.Errorf("AuthCacheSize = %d, want %d", cfg.AuthCacheSize, 10000)
+	}
+	if cfg.PreviewBaseURL != "" || cfg.PreviewDomain != "" {
+		t.Errorf("PreviewBaseURL, PreviewDomain = %q, %q, want empty", cfg.PreviewBaseURL, cfg.PreviewDomain)
+	}
+	if cfg.PreviewMaxBodySize != 10485760 {
+		t.Errorf("PreviewMaxBodySize = %d, want %d", cfg.PreviewMaxBodySize, 10485760)
+	}
 
 	// Default image allowlist.
 	expectedImages := []string{"ghcr.io/devs-group/skillbox-sandbox:latest", "python:3.12", "python:3.12-slim", "python:3.11-slim", "node:20-slim", "node:18-slim", "bash:5"}
@@ -155,6 +188,7 @@ func TestLoad_CustomValues(t *testing.T) {
 	setRequiredEnv(t)
 
 	t.Setenv("SKILLBOX_API_PORT", "3000")
+	t.Setenv("SKILLBOX_GRPC_PORT", "3001")
 	t.Setenv("SKILLBOX_LOG_LEVEL", "debug")
 	t.Setenv("SKILLBOX_S3_BUCKET_SKILLS", "my-skills")
 	t.Setenv("SKILLBOX_S3_BUCKET_EXECUTIONS", "my-execs")
@@ -164,11 +198,22 @@ func TestLoad_CustomValues(t *testing.T) {
 	t.Setenv("SKILLBOX_IMAGE_ALLOWLIST", "alpine:3.19,ubuntu:22.04")
 	t.Setenv("SKILLBOX_DEFAULT_TIMEOUT", "30s")
 	t.Setenv("SKILLBOX_MAX_TIMEOUT", "5m")
+	t.Setenv("SKILLBOX_TIMEOUT_GRACE_PERIOD", "0s")
 	t.Setenv("SKILLBOX_DEFAULT_MEMORY", "1Gi")
 	t.Setenv("SKILLBOX_DEFAULT_CPU", "2.0")
 	t.Setenv("SKILLBOX_MAX_OUTPUT_SIZE", "2097152")
 	t.Setenv("SKILLBOX_MAX_SKILL_SIZE", "104857600")
 	t.Setenv("SKILLBOX_REDIS_URL", "redis://localhost:6379")
+	t.Setenv("SKILLBOX_SNAPSHOT_MAX_PER_TENANT", "5")
+	t.Setenv("SKILLBOX_SNAPSHOT_MAX_AGE", "720h")
+	t.Setenv("SKILLBOX_SESSION_LEASE_TTL", "1m")
+	t.Setenv("SKILLBOX_REPLICA_ID", "api-0")
+	t.Setenv("SKILLBOX_LEADER_RETRY_INTERVAL", "30s")
+	t.Setenv("SKILLBOX_AUTH_CACHE_TTL", "0s")
+	t.Setenv("SKILLBOX_AUTH_CACHE_SIZE", "500")
+	t.Setenv("SKILLBOX_PREVIEW_BASE_URL", "https://skillbox.example.com/")
+	t.Setenv("SKILLBOX_PREVIEW_DOMAIN", "Preview.Example.com")
+	t.Setenv("SKILLBOX_PREVIEW_MAX_BODY_SIZE", "1048576")
 
 	cfg, err := Load()
 	if err != nil {
@@ -178,6 +223,9 @@ func TestLoad_CustomValues(t *testing.T) {
 	if cfg.APIPort != "3000" {
 		t.Errorf("APIPort = %q, want %q", cfg.APIPort, "3000")
 	}
+	if cfg.GRPCPort != "3001" {
+		t.Errorf("GRPCPort = %q, want %q", cfg.GRPCPort, "3001")
+	}
 	if cfg.LogLevel != "debug" {
 		t.Errorf("LogLevel = %q, want %q", cfg.LogLevel, "debug")
 	}
@@ -216,6 +264,9 @@ func TestLoad_CustomValues(t *testing.T) {
 	if cfg.MaxTimeout != 5*time.Minute {
 		t.Errorf("MaxTimeout = %v, want %v", cfg.MaxTimeout, 5*time.Minute)
 	}
+	if cfg.TimeoutGracePeriod != 0 {
+		t.Errorf("TimeoutGracePeriod = %v, want 0", cfg.TimeoutGracePeriod)
+	}
 	if cfg.DefaultMemory != 1*1024*1024*1024 {
 		t.Errorf("DefaultMemory = %d, want %d", cfg.DefaultMemory, 1*1024*1024*1024)
 	}
@@ -228,4 +279,34 @@ func TestLoad_CustomValues(t *testing.T) {
 	if cfg.MaxSkillSize != 104857600 {
 		t.Errorf("MaxSkillSize = %d, want %d", cfg.MaxSkillSize, 104857600)
 	}
+	if cfg.SnapshotMaxPerTenant != 5 {
+		t.Errorf("SnapshotMaxPerTenant = %d, want 5", cfg.SnapshotMaxPerTenant)
+	}
+	if cfg.SnapshotMaxAge != 720*time.Hour {
+		t.Errorf("SnapshotMaxAge = %v, want %v", cfg.SnapshotMaxAge, 720*time.Hour)
+	}
+	if cfg.SessionLeaseTTL != time.Minute {
+		t.Errorf("SessionLeaseTTL = %v, want %v", cfg.SessionLeaseTTL, time.Minute)
+	}
+	if cfg.ReplicaID != "api-0" {
+		t.Errorf("ReplicaID = %q, want %q", cfg.ReplicaID, "api-0")
+	}
+	if cfg.LeaderRetryInterval != 30*time.Second {
+		t.Errorf("LeaderRetryInterval = %v, want %v", cfg.LeaderRetryInterval, 30*time.Second)
+	}
+	if cfg.AuthCacheTTL != 0 {
+		t.Errorf("AuthCacheTTL = %v, want 0", cfg.AuthCacheTTL)
+	}
+	if cfg.AuthCacheSize != 500 {
+		t.Errorf("AuthCacheSize = %d, want %d", cfg.AuthCacheSize, 500)
+	}
+	if cfg.PreviewBaseURL != "https://skillbox.example.com" {
+		t.Errorf("PreviewBaseURL = %q, want %q", cfg.PreviewBaseURL, "https://skillbox.example.com")
+	}
+	if cfg.PreviewDomain != "preview.example.com" {
+		t.Errorf("PreviewDomain = %q, want %q", cfg.PreviewDomain, "preview.example.com")
+	}
+	if cfg.PreviewMaxBodySize != 1048576 {
+		t.Errorf("PreviewMaxBodySize = %d, want %d", cfg.PreviewMaxBodySize, 1048576)
+	}
 }
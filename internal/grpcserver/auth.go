@@ -0,0 +1,108 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/devs-group/skillbox/internal/api/middleware"
+)
+
+// publicServices need no credentials, like /health and /ready.
+var publicServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+type tenantKey struct{}
+
+// tenantID returns the tenant an authenticated call acts for.
+func tenantID(ctx context.Context) string {
+	id, _ := ctx.Value(tenantKey{}).(string)
+	return id
+}
+
+// authenticator applies the REST API's authentication and tenant rules
+// to gRPC calls.
+type authenticator struct {
+	auth *middleware.Authenticator
+}
+
+func (a *authenticator) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate validates the bearer token in the call's metadata and
+// returns a context carrying the caller's tenant. Like TenantMiddleware,
+// an x-tenant-id entry must match the credentials' tenant unless they
+// belong to a service key.
+func (a *authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	for _, prefix := range publicServices {
+		if strings.HasPrefix(method, prefix) {
+			return ctx, nil
+		}
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	header := first(md, "authorization")
+	if header == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization metadata")
+	}
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata must use Bearer scheme")
+	}
+
+	p, err := a.auth.Authenticate(ctx, strings.TrimSpace(token))
+	if err != nil {
+		if errors.Is(err, middleware.ErrInvalidCredentials) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to validate credentials")
+	}
+
+	tenant := p.TenantID
+	if requested := first(md, "x-tenant-id"); requested != "" && requested != tenant {
+		if p.APIKey == nil || !p.APIKey.IsService {
+			return nil, status.Error(codes.PermissionDenied, "x-tenant-id does not match the API key's tenant")
+		}
+		tenant = requested
+	}
+	return context.WithValue(ctx, tenantKey{}, tenant), nil
+}
+
+func first(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// authenticatedStream replaces a stream's context with the authenticated
+// one.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,205 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/devs-group/skillbox/internal/runner"
+	"github.com/devs-group/skillbox/internal/skill"
+	"github.com/devs-group/skillbox/internal/store"
+	skillboxv1 "github.com/devs-group/skillbox/proto/skillbox/v1"
+)
+
+// logPollInterval is how often StreamExecutionLogs checks whether a
+// running execution has finished.
+var logPollInterval = 500 * time.Millisecond
+
+// logChunkSize is the size of the messages StreamExecutionLogs and
+// DownloadFile send.
+const logChunkSize = 32 << 10
+
+type executionService struct {
+	skillboxv1.UnimplementedExecutionServiceServer
+	store  *store.Store
+	runner Runner
+}
+
+// RunSkill mirrors handlers.CreateExecution.
+func (s *executionService) RunSkill(ctx context.Context, req *skillboxv1.RunSkillRequest) (*skillboxv1.RunSkillResponse, error) {
+	if req.GetSkill() == "" {
+		return nil, status.Error(codes.InvalidArgument, "'skill' is required")
+	}
+	// Validate skill name and version to prevent S3 path traversal.
+	if err := skill.ValidateName(req.GetSkill()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := skill.ValidateVersion(req.GetVersion()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	version := req.GetVersion()
+	if version == "" {
+		version = "latest"
+	}
+
+	var input json.RawMessage
+	if req.GetInput() != nil {
+		raw, err := protojson.Marshal(req.GetInput())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid input: "+err.Error())
+		}
+		input = raw
+	}
+
+	result, err := s.runner.Run(ctx, runner.RunRequest{
+		Skill:      req.GetSkill(),
+		Version:    version,
+		Input:      input,
+		Env:        req.GetEnv(),
+		InputFiles: req.GetInputFiles(),
+		SessionID:  req.GetSessionId(),
+		TenantID:   tenantID(ctx),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, runner.ErrSkillNotFound):
+			return nil, status.Error(codes.NotFound, "skill not found: "+req.GetSkill()+"@"+version)
+		case errors.Is(err, runner.ErrSkillNotAvailable):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, runner.ErrImageNotAllowed):
+			return nil, status.Error(codes.InvalidArgument, "skill image is not in the allowlist")
+		case errors.Is(err, runner.ErrTimeout):
+			return nil, status.Error(codes.DeadlineExceeded, "execution timed out")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	output, err := jsonValue(result.Output)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "invalid skill output: "+err.Error())
+	}
+	return &skillboxv1.RunSkillResponse{Result: &skillboxv1.RunResult{
+		ExecutionId: result.ExecutionID,
+		Status:      result.Status,
+		Output:      output,
+		FilesUrl:    result.FilesURL,
+		FilesList:   result.FilesList,
+		Logs:        result.Logs,
+		DurationMs:  result.DurationMs,
+		Error:       result.Error,
+		Partial:     result.Partial,
+	}}, nil
+}
+
+// GetExecution mirrors handlers.GetExecution.
+func (s *executionService) GetExecution(ctx context.Context, req *skillboxv1.GetExecutionRequest) (*skillboxv1.GetExecutionResponse, error) {
+	exec, err := s.getExecution(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	pb, err := executionProto(exec)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &skillboxv1.GetExecutionResponse{Execution: pb}, nil
+}
+
+// StreamExecutionLogs sends an execution's logs. The runner stores logs
+// when an execution finishes, so for a running execution the stream
+// waits until then.
+func (s *executionService) StreamExecutionLogs(req *skillboxv1.StreamExecutionLogsRequest, stream grpc.ServerStreamingServer[skillboxv1.StreamExecutionLogsResponse]) error {
+	ctx := stream.Context()
+	exec, err := s.getExecution(ctx, req.GetId())
+	if err != nil {
+		return err
+	}
+
+	if exec.Status == "running" {
+		ticker := time.NewTicker(logPollInterval)
+		defer ticker.Stop()
+		for exec.Status == "running" {
+			select {
+			case <-ctx.Done():
+				return status.FromContextError(ctx.Err()).Err()
+			case <-ticker.C:
+			}
+			if exec, err = s.getExecution(ctx, req.GetId()); err != nil {
+				return err
+			}
+		}
+	}
+
+	logs := []byte(exec.Logs)
+	for len(logs) > 0 {
+		n := min(len(logs), logChunkSize)
+		if err := stream.Send(&skillboxv1.StreamExecutionLogsResponse{Data: logs[:n]}); err != nil {
+			return err
+		}
+		logs = logs[n:]
+	}
+	return nil
+}
+
+func (s *executionService) getExecution(ctx context.Context, id string) (*store.Execution, error) {
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "execution id is required")
+	}
+	exec, err := s.store.GetExecution(ctx, id, tenantID(ctx))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "execution not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to retrieve execution")
+	}
+	return exec, nil
+}
+
+func executionProto(e *store.Execution) (*skillboxv1.Execution, error) {
+	input, err := jsonValue(e.Input)
+	if err != nil {
+		return nil, err
+	}
+	output, err := jsonValue(e.Output)
+	if err != nil {
+		return nil, err
+	}
+	pb := &skillboxv1.Execution{
+		Id:           e.ID,
+		SkillName:    e.SkillName,
+		SkillVersion: e.SkillVersion,
+		Status:       e.Status,
+		Input:        input,
+		Output:       output,
+		Logs:         e.Logs,
+		FilesUrl:     e.FilesURL,
+		FilesList:    e.FilesList,
+		DurationMs:   e.DurationMs,
+		Error:        e.Error,
+		Partial:      e.Partial,
+		CreatedAt:    timestamppb.New(e.CreatedAt),
+	}
+	if e.FinishedAt != nil {
+		pb.FinishedAt = timestamppb.New(*e.FinishedAt)
+	}
+	return pb, nil
+}
+
+// jsonValue converts raw JSON to a protobuf Value; empty input yields nil.
+func jsonValue(raw json.RawMessage) (*structpb.Value, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	v := &structpb.Value{}
+	if err := protojson.Unmarshal(raw, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
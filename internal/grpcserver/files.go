@@ -0,0 +1,201 @@
+package grpcserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/devs-group/skillbox/internal/store"
+	skillboxv1 "github.com/devs-group/skillbox/proto/skillbox/v1"
+)
+
+type fileService struct {
+	skillboxv1.UnimplementedFileServiceServer
+	store       *store.Store
+	objects     Objects
+	maxFileSize int64
+}
+
+// UploadFile mirrors FilesHandler.Upload. The content is buffered so the
+// size limit applies before anything is stored.
+func (s *fileService) UploadFile(stream grpc.ClientStreamingServer[skillboxv1.UploadFileRequest, skillboxv1.UploadFileResponse]) error {
+	ctx := stream.Context()
+	tenant := tenantID(ctx)
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	meta := first.GetMetadata()
+	if meta == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the file metadata")
+	}
+	name := meta.GetName()
+	if name == "" {
+		return status.Error(codes.InvalidArgument, "file name is required")
+	}
+	contentType := meta.GetContentType()
+	if contentType == "" {
+		contentType = mime.TypeByExtension(path.Ext(name))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	var buf bytes.Buffer
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if msg.GetMetadata() != nil {
+			return status.Error(codes.InvalidArgument, "file metadata sent twice")
+		}
+		if int64(buf.Len()+len(msg.GetChunk())) > s.maxFileSize {
+			return status.Errorf(codes.ResourceExhausted, "file exceeds the maximum size of %d bytes", s.maxFileSize)
+		}
+		buf.Write(msg.GetChunk())
+	}
+
+	s3Key := fmt.Sprintf("%s/files/%s/%s", tenant, uuid.New().String(), name)
+	size, err := s.objects.UploadObject(ctx, s3Key, &buf, int64(buf.Len()), contentType)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to upload file to storage")
+	}
+
+	created, err := s.store.CreateFile(ctx, &store.File{
+		TenantID:    tenant,
+		Name:        name,
+		ContentType: contentType,
+		SizeBytes:   size,
+		S3Key:       s3Key,
+		Version:     1,
+	})
+	if err != nil {
+		// Best-effort cleanup of the orphaned S3 object.
+		_ = s.objects.DeleteObject(ctx, s3Key)
+		return status.Error(codes.Internal, "failed to create file record")
+	}
+	return stream.SendAndClose(&skillboxv1.UploadFileResponse{File: fileProto(created)})
+}
+
+// ListFiles mirrors FilesHandler.List.
+func (s *fileService) ListFiles(ctx context.Context, req *skillboxv1.ListFilesRequest) (*skillboxv1.ListFilesResponse, error) {
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = 50
+	}
+	files, err := s.store.ListFiles(ctx, store.FileFilter{
+		TenantID:    tenantID(ctx),
+		SessionID:   req.GetSessionId(),
+		ExecutionID: req.GetExecutionId(),
+		Limit:       limit,
+		Offset:      int(req.GetOffset()),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list files")
+	}
+
+	resp := &skillboxv1.ListFilesResponse{Files: make([]*skillboxv1.File, len(files))}
+	for i, f := range files {
+		resp.Files[i] = fileProto(f)
+	}
+	return resp, nil
+}
+
+// GetFile mirrors FilesHandler.Get.
+func (s *fileService) GetFile(ctx context.Context, req *skillboxv1.GetFileRequest) (*skillboxv1.GetFileResponse, error) {
+	f, err := s.getFile(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &skillboxv1.GetFileResponse{File: fileProto(f)}, nil
+}
+
+// DownloadFile mirrors FilesHandler.Download.
+func (s *fileService) DownloadFile(req *skillboxv1.DownloadFileRequest, stream grpc.ServerStreamingServer[skillboxv1.DownloadFileResponse]) error {
+	ctx := stream.Context()
+	f, err := s.getFile(ctx, req.GetId())
+	if err != nil {
+		return err
+	}
+
+	reader, _, _, err := s.objects.DownloadObject(ctx, f.S3Key)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to download file from storage")
+	}
+	defer reader.Close() //nolint:errcheck
+
+	buf := make([]byte, logChunkSize)
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&skillboxv1.DownloadFileResponse{Chunk: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Internal, "failed to read file from storage")
+		}
+	}
+}
+
+// DeleteFile mirrors FilesHandler.Delete.
+func (s *fileService) DeleteFile(ctx context.Context, req *skillboxv1.DeleteFileRequest) (*skillboxv1.DeleteFileResponse, error) {
+	f, err := s.getFile(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.DeleteFile(ctx, f.ID, tenantID(ctx)); err != nil {
+		return nil, status.Error(codes.Internal, "failed to delete file record")
+	}
+	// Best-effort removal from S3; the DB record is already gone.
+	_ = s.objects.DeleteObject(ctx, f.S3Key)
+
+	return &skillboxv1.DeleteFileResponse{}, nil
+}
+
+func (s *fileService) getFile(ctx context.Context, id string) (*store.File, error) {
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "file id is required")
+	}
+	f, err := s.store.GetFile(ctx, id, tenantID(ctx))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "file not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to retrieve file")
+	}
+	return f, nil
+}
+
+func fileProto(f *store.File) *skillboxv1.File {
+	return &skillboxv1.File{
+		Id:          f.ID,
+		SessionId:   f.SessionID,
+		ExecutionId: f.ExecutionID,
+		Name:        f.Name,
+		ContentType: f.ContentType,
+		SizeBytes:   f.SizeBytes,
+		Version:     int32(f.Version), //nolint:gosec // file versions are small
+		ParentId:    f.ParentID,
+		CreatedAt:   timestamppb.New(f.CreatedAt),
+		UpdatedAt:   timestamppb.New(f.UpdatedAt),
+	}
+}
@@ -0,0 +1,138 @@
+package grpcserver
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/devs-group/skillbox/internal/sandbox"
+	skillboxv1 "github.com/devs-group/skillbox/proto/skillbox/v1"
+)
+
+type sandboxService struct {
+	skillboxv1.UnimplementedSandboxServiceServer
+	workspaces Workspaces
+}
+
+// sessionKey gets or creates the caller's session sandbox and returns its
+// session manager key, like SandboxHandler.resolveSessionKey.
+func (s *sandboxService) sessionKey(ctx context.Context, sessionID string) (string, error) {
+	if sessionID == "" {
+		return "", status.Error(codes.InvalidArgument, "session_id is required")
+	}
+	tenant := tenantID(ctx)
+	ms, err := s.workspaces.GetOrCreate(ctx, tenant, sessionID, sandbox.SandboxSessionOpts{})
+	if err != nil {
+		return "", status.Error(codes.Internal, "failed to get or create sandbox: "+err.Error())
+	}
+	return tenant + ":" + ms.ExternalID, nil
+}
+
+// Execute mirrors SandboxHandler.Execute.
+func (s *sandboxService) Execute(ctx context.Context, req *skillboxv1.ExecuteRequest) (*skillboxv1.ExecuteResponse, error) {
+	if req.GetCommand() == "" {
+		return nil, status.Error(codes.InvalidArgument, "command is required")
+	}
+	workdir := req.GetWorkdir()
+	if workdir == "" {
+		workdir = "/sandbox/session"
+	}
+	// Validate workdir to prevent directory traversal.
+	if err := sandbox.ValidateSandboxPath(workdir); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid workdir: "+err.Error())
+	}
+	timeout := int(req.GetTimeoutMs())
+	if timeout <= 0 {
+		timeout = 30000
+	}
+
+	key, err := s.sessionKey(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.workspaces.Execute(ctx, key, req.GetCommand(), workdir, timeout)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "command execution failed: "+err.Error())
+	}
+	return &skillboxv1.ExecuteResponse{
+		Stdout:   result.Stdout,
+		Stderr:   result.Stderr,
+		ExitCode: int32(result.ExitCode), //nolint:gosec // exit codes fit in 32 bits
+	}, nil
+}
+
+// ReadFile mirrors SandboxHandler.ReadFile.
+func (s *sandboxService) ReadFile(ctx context.Context, req *skillboxv1.ReadFileRequest) (*skillboxv1.ReadFileResponse, error) {
+	if req.GetPath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "path is required")
+	}
+	key, err := s.sessionKey(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.workspaces.ReadFile(ctx, key, req.GetPath())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "failed to read file: "+err.Error())
+	}
+	return &skillboxv1.ReadFileResponse{Content: data}, nil
+}
+
+// WriteFile mirrors SandboxHandler.WriteFile.
+func (s *sandboxService) WriteFile(ctx context.Context, req *skillboxv1.WriteFileRequest) (*skillboxv1.WriteFileResponse, error) {
+	if req.GetPath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "path is required")
+	}
+	key, err := s.sessionKey(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+
+	content := string(req.GetContent())
+	if req.GetAppend() {
+		// Read-concat-write, as over REST.
+		if existing, err := s.workspaces.ReadFile(ctx, key, req.GetPath()); err == nil {
+			content = string(existing) + content
+		}
+	}
+	if err := s.workspaces.WriteFile(ctx, key, req.GetPath(), content); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "failed to write file: "+err.Error())
+	}
+	return &skillboxv1.WriteFileResponse{}, nil
+}
+
+// ListDir mirrors SandboxHandler.ListDir.
+func (s *sandboxService) ListDir(ctx context.Context, req *skillboxv1.ListDirRequest) (*skillboxv1.ListDirResponse, error) {
+	if req.GetPath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "path is required")
+	}
+	maxDepth := int(req.GetMaxDepth())
+	if maxDepth <= 0 {
+		maxDepth = 2
+	}
+	key, err := s.sessionKey(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.workspaces.ListDir(ctx, key, req.GetPath(), maxDepth)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "failed to list directory: "+err.Error())
+	}
+
+	resp := &skillboxv1.ListDirResponse{Entries: make([]*skillboxv1.DirEntry, len(entries))}
+	for i, e := range entries {
+		resp.Entries[i] = &skillboxv1.DirEntry{Path: e.Path, IsDir: e.IsDir, Size: e.Size}
+	}
+	return resp, nil
+}
+
+// DestroySandbox mirrors SandboxHandler.Destroy.
+func (s *sandboxService) DestroySandbox(ctx context.Context, req *skillboxv1.DestroySandboxRequest) (*skillboxv1.DestroySandboxResponse, error) {
+	if req.GetSessionId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+	if err := s.workspaces.Destroy(ctx, tenantID(ctx)+":"+req.GetSessionId()); err != nil {
+		return nil, status.Error(codes.NotFound, "sandbox not found or already destroyed: "+err.Error())
+	}
+	return &skillboxv1.DestroySandboxResponse{}, nil
+}
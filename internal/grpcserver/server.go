@@ -0,0 +1,134 @@
+// Package grpcserver serves the Skillbox API over gRPC. The services
+// defined in proto/skillbox/v1 mirror the REST endpoints for executions,
+// skills, files, and sandbox sessions, and call the same runner, store,
+// registry, and session manager, so both transports behave alike.
+//
+// Calls authenticate with the same API keys and tokens as the REST API,
+// passed as "authorization: Bearer <token>" metadata. The standard health
+// and reflection services need no credentials, so orchestrators and tools
+// like grpcurl work out of the box.
+package grpcserver
+
+import (
+	"context"
+	"io"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/devs-group/skillbox/internal/api/middleware"
+	"github.com/devs-group/skillbox/internal/runner"
+	"github.com/devs-group/skillbox/internal/sandbox"
+	"github.com/devs-group/skillbox/internal/skill"
+	"github.com/devs-group/skillbox/internal/store"
+	skillboxv1 "github.com/devs-group/skillbox/proto/skillbox/v1"
+)
+
+// Runner executes skills.
+type Runner interface {
+	Run(ctx context.Context, req runner.RunRequest) (*runner.RunResult, error)
+}
+
+// Registry reads and removes skill archives.
+type Registry interface {
+	LoadAnySkillMD(ctx context.Context, tenantID, skillName, version string) (*skill.Skill, error)
+	Delete(ctx context.Context, tenantID, skillName, version string) error
+}
+
+// Objects stores file contents.
+type Objects interface {
+	UploadObject(ctx context.Context, key string, reader io.Reader, size int64, contentType string) (int64, error)
+	DownloadObject(ctx context.Context, key string) (io.ReadCloser, int64, string, error)
+	DeleteObject(ctx context.Context, key string) error
+}
+
+// Workspaces provides the session sandboxes behind SandboxService.
+type Workspaces interface {
+	GetOrCreate(ctx context.Context, tenantID, externalID string, opts sandbox.SandboxSessionOpts) (*sandbox.ManagedSandbox, error)
+	Execute(ctx context.Context, key string, command, workdir string, timeout int) (*sandbox.CommandResult, error)
+	ReadFile(ctx context.Context, key string, filePath string) ([]byte, error)
+	WriteFile(ctx context.Context, key string, filePath, content string) error
+	ListDir(ctx context.Context, key string, dirPath string, maxDepth int) ([]sandbox.DirEntry, error)
+	Destroy(ctx context.Context, key string) error
+}
+
+// Config holds the dependencies of a Server.
+type Config struct {
+	Store    *store.Store
+	Auth     *middleware.Authenticator
+	Runner   Runner
+	Registry Registry
+
+	// Objects enables FileService. Uploads larger than MaxFileSize bytes
+	// are rejected.
+	Objects     Objects
+	MaxFileSize int64
+
+	// Workspaces enables SandboxService.
+	Workspaces Workspaces
+}
+
+// Server is a gRPC server exposing the Skillbox services.
+type Server struct {
+	grpc   *grpc.Server
+	health *health.Server
+}
+
+// New creates a Server. FileService and SandboxService are only
+// registered when their dependencies are configured, like the matching
+// REST routes.
+func New(cfg Config) *Server {
+	a := &authenticator{auth: cfg.Auth}
+	gs := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(a.unary),
+		grpc.ChainStreamInterceptor(a.stream),
+	)
+
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(gs, hs)
+	reflection.Register(gs)
+
+	skillboxv1.RegisterExecutionServiceServer(gs, &executionService{store: cfg.Store, runner: cfg.Runner})
+	skillboxv1.RegisterSkillServiceServer(gs, &skillService{store: cfg.Store, registry: cfg.Registry})
+	if cfg.Objects != nil {
+		skillboxv1.RegisterFileServiceServer(gs, &fileService{store: cfg.Store, objects: cfg.Objects, maxFileSize: cfg.MaxFileSize})
+	}
+	if cfg.Workspaces != nil {
+		skillboxv1.RegisterSandboxServiceServer(gs, &sandboxService{workspaces: cfg.Workspaces})
+	}
+
+	// Report every registered service, and the server as a whole under
+	// the empty name, as serving.
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	for name := range gs.GetServiceInfo() {
+		hs.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+
+	return &Server{grpc: gs, health: hs}
+}
+
+// Serve accepts connections on lis until Stop is called.
+func (s *Server) Serve(lis net.Listener) error {
+	return s.grpc.Serve(lis)
+}
+
+// Stop marks the server as not serving, so health checks fail while it
+// drains, and waits for in-flight calls to finish. Calls still running
+// when ctx is done are cancelled.
+func (s *Server) Stop(ctx context.Context) {
+	s.health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.grpc.Stop()
+	}
+}
@@ -0,0 +1,256 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/devs-group/skillbox/internal/api/middleware"
+	"github.com/devs-group/skillbox/internal/runner"
+	"github.com/devs-group/skillbox/internal/sandbox"
+	"github.com/devs-group/skillbox/internal/store"
+	skillboxv1 "github.com/devs-group/skillbox/proto/skillbox/v1"
+)
+
+type fakeRunner struct {
+	got runner.RunRequest
+}
+
+func (f *fakeRunner) Run(_ context.Context, req runner.RunRequest) (*runner.RunResult, error) {
+	f.got = req
+	if req.Skill == "missing" {
+		return nil, runner.ErrSkillNotFound
+	}
+	return &runner.RunResult{ExecutionID: "exec-1", Status: "success", Output: json.RawMessage(`{"ok":true}`)}, nil
+}
+
+type fakeWorkspaces struct {
+	commands []string
+}
+
+func (f *fakeWorkspaces) GetOrCreate(_ context.Context, tenantID, externalID string, _ sandbox.SandboxSessionOpts) (*sandbox.ManagedSandbox, error) {
+	return &sandbox.ManagedSandbox{TenantID: tenantID, ExternalID: externalID}, nil
+}
+
+func (f *fakeWorkspaces) Execute(_ context.Context, key, command, workdir string, timeout int) (*sandbox.CommandResult, error) {
+	f.commands = append(f.commands, strings.Join([]string{key, command, workdir}, " "))
+	return &sandbox.CommandResult{Stdout: "hi\n", ExitCode: 3}, nil
+}
+
+func (f *fakeWorkspaces) ReadFile(context.Context, string, string) ([]byte, error) { return nil, nil }
+
+func (f *fakeWorkspaces) WriteFile(context.Context, string, string, string) error { return nil }
+
+func (f *fakeWorkspaces) ListDir(context.Context, string, string, int) ([]sandbox.DirEntry, error) {
+	return nil, nil
+}
+
+func (f *fakeWorkspaces) Destroy(context.Context, string) error { return nil }
+
+// dial starts a Server on an in-memory listener and returns a client
+// connection to it.
+func dial(t *testing.T, cfg Config) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := New(cfg)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(func() { srv.Stop(context.Background()) })
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// newStore returns a sqlmock-backed store and an authenticator using it.
+func newStore(t *testing.T) (*store.Store, *middleware.Authenticator, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	st := store.NewWithDB(db)
+	return st, middleware.NewAuthenticator(st, "http://localhost:4445", nil, 0), mock
+}
+
+// expectKey expects an API key lookup for token.
+func expectKey(mock sqlmock.Sqlmock, token, tenantID string, service bool) {
+	sum := sha256.Sum256([]byte(token))
+	hash := hex.EncodeToString(sum[:])
+	mock.ExpectQuery("SELECT id, key_hash, tenant_id, name, is_service, created_at, revoked_at").
+		WithArgs(hash).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "key_hash", "tenant_id", "name", "is_service", "created_at", "revoked_at"}).
+			AddRow("key-1", hash, tenantID, "ci", service, time.Now(), nil))
+}
+
+func withToken(token string, kv ...string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), append([]string{"authorization", "Bearer " + token}, kv...)...)
+}
+
+func TestHealthIsPublic(t *testing.T) {
+	st, auth, _ := newStore(t)
+	conn := dial(t, Config{Store: st, Auth: auth, Runner: &fakeRunner{}})
+	client := healthpb.NewHealthClient(conn)
+
+	for _, service := range []string{"", "skillbox.v1.ExecutionService"} {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Check(%q): %v", service, err)
+		}
+		if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Check(%q) = %v, want SERVING", service, resp.GetStatus())
+		}
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	st, auth, mock := newStore(t)
+	r := &fakeRunner{}
+	client := skillboxv1.NewExecutionServiceClient(dial(t, Config{Store: st, Auth: auth, Runner: r}))
+	req := &skillboxv1.RunSkillRequest{Skill: "echo"}
+
+	if _, err := client.RunSkill(context.Background(), req); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("no credentials: err = %v, want Unauthenticated", err)
+	}
+
+	expectKey(mock, "sk-user", "tenant-1", false)
+	if _, err := client.RunSkill(withToken("sk-user", "x-tenant-id", "tenant-2"), req); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("foreign tenant: err = %v, want PermissionDenied", err)
+	}
+
+	expectKey(mock, "sk-service", "tenant-1", true)
+	if _, err := client.RunSkill(withToken("sk-service", "x-tenant-id", "tenant-2"), req); err != nil {
+		t.Fatalf("service key: %v", err)
+	}
+	if r.got.TenantID != "tenant-2" {
+		t.Errorf("tenant = %q, want tenant-2", r.got.TenantID)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRunSkill(t *testing.T) {
+	st, auth, mock := newStore(t)
+	r := &fakeRunner{}
+	client := skillboxv1.NewExecutionServiceClient(dial(t, Config{Store: st, Auth: auth, Runner: r}))
+
+	input, _ := structpb.NewValue(map[string]any{"n": 1})
+	expectKey(mock, "sk-test", "tenant-1", false)
+	resp, err := client.RunSkill(withToken("sk-test"), &skillboxv1.RunSkillRequest{Skill: "echo", Input: input})
+	if err != nil {
+		t.Fatalf("RunSkill: %v", err)
+	}
+	if r.got.Version != "latest" || r.got.TenantID != "tenant-1" || string(r.got.Input) != `{"n":1}` {
+		t.Errorf("run request = %+v", r.got)
+	}
+	if got := resp.GetResult().GetOutput().GetStructValue().GetFields()["ok"].GetBoolValue(); !got {
+		t.Errorf("output = %v", resp.GetResult().GetOutput())
+	}
+
+	expectKey(mock, "sk-test", "tenant-1", false)
+	if _, err := client.RunSkill(withToken("sk-test"), &skillboxv1.RunSkillRequest{Skill: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("missing skill: err = %v, want NotFound", err)
+	}
+}
+
+func TestStreamExecutionLogs_WaitsForCompletion(t *testing.T) {
+	old := logPollInterval
+	logPollInterval = time.Millisecond
+	t.Cleanup(func() { logPollInterval = old })
+
+	st, auth, mock := newStore(t)
+	client := skillboxv1.NewExecutionServiceClient(dial(t, Config{Store: st, Auth: auth, Runner: &fakeRunner{}}))
+
+	cols := []string{"id", "skill_name", "skill_version", "tenant_id", "status", "input", "output", "logs",
+		"files_url", "files_list", "duration_ms", "error", "partial", "created_at", "finished_at"}
+	logs := strings.Repeat("x", logChunkSize+10)
+	expectKey(mock, "sk-test", "tenant-1", false)
+	mock.ExpectQuery("FROM sandbox.executions").WithArgs("exec-1", "tenant-1").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("exec-1", "echo", "1.0.0", "tenant-1", "running",
+			[]byte("{}"), []byte("null"), "", "", "{}", 0, nil, false, time.Now(), nil))
+	mock.ExpectQuery("FROM sandbox.executions").WithArgs("exec-1", "tenant-1").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("exec-1", "echo", "1.0.0", "tenant-1", "success",
+			[]byte("{}"), []byte("null"), logs, "", "{}", 5, nil, false, time.Now(), time.Now()))
+
+	stream, err := client.StreamExecutionLogs(withToken("sk-test"), &skillboxv1.StreamExecutionLogsRequest{Id: "exec-1"})
+	if err != nil {
+		t.Fatalf("StreamExecutionLogs: %v", err)
+	}
+	var got strings.Builder
+	chunks := 0
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		got.Write(msg.GetData())
+		chunks++
+	}
+	if got.String() != logs || chunks != 2 {
+		t.Errorf("received %d bytes in %d chunks, want %d in 2", got.Len(), chunks, len(logs))
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSandboxExecute(t *testing.T) {
+	st, auth, mock := newStore(t)
+	ws := &fakeWorkspaces{}
+	client := skillboxv1.NewSandboxServiceClient(dial(t, Config{Store: st, Auth: auth, Runner: &fakeRunner{}, Workspaces: ws}))
+
+	expectKey(mock, "sk-test", "tenant-1", false)
+	resp, err := client.Execute(withToken("sk-test"), &skillboxv1.ExecuteRequest{SessionId: "s1", Command: "echo hi"})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if resp.GetStdout() != "hi\n" || resp.GetExitCode() != 3 {
+		t.Errorf("response = %+v", resp)
+	}
+	if len(ws.commands) != 1 || ws.commands[0] != "tenant-1:s1 echo hi /sandbox/session" {
+		t.Errorf("commands = %q", ws.commands)
+	}
+
+	expectKey(mock, "sk-test", "tenant-1", false)
+	if _, err := client.Execute(withToken("sk-test"), &skillboxv1.ExecuteRequest{SessionId: "s1", Command: "ls", Workdir: "/etc/../root"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("traversal: err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestOptionalServices(t *testing.T) {
+	st, auth, mock := newStore(t)
+	client := skillboxv1.NewFileServiceClient(dial(t, Config{Store: st, Auth: auth, Runner: &fakeRunner{}}))
+
+	expectKey(mock, "sk-test", "tenant-1", false)
+	if _, err := client.GetFile(withToken("sk-test"), &skillboxv1.GetFileRequest{Id: "f1"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("err = %v, want Unimplemented without an object store", err)
+	}
+}
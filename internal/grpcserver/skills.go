@@ -0,0 +1,172 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/devs-group/skillbox/internal/registry"
+	"github.com/devs-group/skillbox/internal/skill"
+	"github.com/devs-group/skillbox/internal/store"
+	skillboxv1 "github.com/devs-group/skillbox/proto/skillbox/v1"
+)
+
+type skillService struct {
+	skillboxv1.UnimplementedSkillServiceServer
+	store    *store.Store
+	registry Registry
+}
+
+// ListSkills mirrors handlers.ListSkills.
+func (s *skillService) ListSkills(ctx context.Context, req *skillboxv1.ListSkillsRequest) (*skillboxv1.ListSkillsResponse, error) {
+	tenant := tenantID(ctx)
+	var records []store.SkillRecord
+	var err error
+	switch req.GetStatus() {
+	case "all":
+		records, err = s.store.ListAllSkills(ctx, tenant)
+	case "":
+		records, err = s.store.ListSkills(ctx, tenant)
+	default:
+		records, err = s.store.ListSkills(ctx, tenant, req.GetStatus())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list skills: "+err.Error())
+	}
+
+	resp := &skillboxv1.ListSkillsResponse{Skills: make([]*skillboxv1.SkillSummary, len(records))}
+	for i, rec := range records {
+		resp.Skills[i] = &skillboxv1.SkillSummary{
+			Name:        rec.Name,
+			Version:     rec.Version,
+			Description: rec.Description,
+			Lang:        rec.Lang,
+			Status:      rec.Status,
+			Blocked:     rec.Blocked,
+		}
+		if rec.SourceURL != nil {
+			resp.Skills[i].SourceUrl = *rec.SourceURL
+		}
+	}
+	return resp, nil
+}
+
+// ListSkillVersions mirrors handlers.ListSkillVersions.
+func (s *skillService) ListSkillVersions(ctx context.Context, req *skillboxv1.ListSkillVersionsRequest) (*skillboxv1.ListSkillVersionsResponse, error) {
+	if err := skill.ValidateName(req.GetName()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	versions, err := s.store.ListSkillVersions(ctx, tenantID(ctx), req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list versions: "+err.Error())
+	}
+
+	resp := &skillboxv1.ListSkillVersionsResponse{Versions: make([]*skillboxv1.SkillVersion, len(versions))}
+	for i, v := range versions {
+		resp.Versions[i] = &skillboxv1.SkillVersion{
+			Version:    v.Version,
+			Status:     v.Status,
+			Active:     v.Active,
+			UploadedAt: timestamppb.New(v.UploadedAt),
+		}
+	}
+	return resp, nil
+}
+
+// GetSkill mirrors handlers.GetSkill.
+func (s *skillService) GetSkill(ctx context.Context, req *skillboxv1.GetSkillRequest) (*skillboxv1.GetSkillResponse, error) {
+	tenant := tenantID(ctx)
+	name, version, err := validateSkillRef(req.GetName(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve "latest" to the org's active version (a pending edit must not shadow it).
+	if version == "latest" {
+		if version, err = resolveVersion(ctx, s.store.ResolveActiveVersion, tenant, name); err != nil {
+			return nil, err
+		}
+	}
+
+	parsed, err := s.registry.LoadAnySkillMD(ctx, tenant, name, version)
+	if err != nil {
+		if errors.Is(err, registry.ErrSkillNotFound) {
+			return nil, status.Error(codes.NotFound, "skill not found: "+name+"@"+version)
+		}
+		return nil, status.Error(codes.Internal, "failed to retrieve skill: "+err.Error())
+	}
+
+	pb := &skillboxv1.Skill{
+		Name:         parsed.Name,
+		Version:      version,
+		Description:  parsed.Description,
+		Lang:         parsed.Lang,
+		Image:        parsed.Image,
+		Instructions: parsed.Instructions,
+		Mode:         parsed.Mode,
+	}
+	if parsed.Timeout > 0 {
+		pb.Timeout = parsed.Timeout.String()
+	}
+	if parsed.InputSchema != nil {
+		if pb.InputSchema, err = structpb.NewStruct(parsed.InputSchema); err != nil {
+			return nil, status.Error(codes.Internal, "invalid input schema: "+err.Error())
+		}
+	}
+	return &skillboxv1.GetSkillResponse{Skill: pb}, nil
+}
+
+// DeleteSkill mirrors handlers.DeleteSkill.
+func (s *skillService) DeleteSkill(ctx context.Context, req *skillboxv1.DeleteSkillRequest) (*skillboxv1.DeleteSkillResponse, error) {
+	tenant := tenantID(ctx)
+	name, version, err := validateSkillRef(req.GetName(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	if version == "latest" {
+		if version, err = resolveVersion(ctx, s.store.ResolveLatestVersion, tenant, name); err != nil {
+			return nil, err
+		}
+	}
+
+	// Best-effort removal from S3 — ignore "not found" (already gone).
+	if err := s.registry.Delete(ctx, tenant, name, version); err != nil && !errors.Is(err, registry.ErrSkillNotFound) {
+		return nil, status.Error(codes.Internal, "failed to delete skill: "+err.Error())
+	}
+	// Always clean up the DB record.
+	_ = s.store.DeleteSkill(ctx, tenant, name, version)
+
+	return &skillboxv1.DeleteSkillResponse{}, nil
+}
+
+// validateSkillRef checks a skill name and version to prevent S3 path
+// traversal.
+func validateSkillRef(name, version string) (string, string, error) {
+	if name == "" || version == "" {
+		return "", "", status.Error(codes.InvalidArgument, "skill name and version are required")
+	}
+	if err := skill.ValidateName(name); err != nil {
+		return "", "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := skill.ValidateVersion(version); err != nil {
+		return "", "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	return name, version, nil
+}
+
+// resolveVersion resolves "latest" with resolve.
+func resolveVersion(ctx context.Context, resolve func(ctx context.Context, tenantID, name string) (string, error), tenant, name string) (string, error) {
+	version, err := resolve(ctx, tenant, name)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return "", status.Error(codes.NotFound, "skill not found: "+name+"@latest")
+		}
+		return "", status.Error(codes.Internal, "failed to resolve latest version: "+err.Error())
+	}
+	return version, nil
+}
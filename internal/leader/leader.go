@@ -0,0 +1,196 @@
+// Package leader runs singleton background jobs on exactly one server
+// replica at a time.
+//
+// Each role is guarded by a Postgres session-level advisory lock held on a
+// dedicated connection. The replica holding the lock runs the role's job.
+// If that replica dies or loses its database connection, Postgres releases
+// the lock and another replica acquires it on its next attempt.
+package leader
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+// lockNamespace is the first key of every advisory lock taken by this
+// package, keeping them apart from locks taken for other purposes.
+const lockNamespace int32 = 0x5b0c
+
+// Job is the work a role performs while its replica leads. ctx is
+// cancelled when leadership is lost or the elector stops; a job that
+// returns early keeps the role held so it does not run again elsewhere.
+type Job func(ctx context.Context)
+
+type role struct {
+	name    string
+	job     Job
+	leading atomic.Bool
+}
+
+// Elector campaigns for registered roles and runs each role's job while
+// this replica holds it.
+type Elector struct {
+	store     *store.Store
+	replicaID string
+	interval  time.Duration
+	logger    *slog.Logger
+
+	mu    sync.Mutex
+	roles map[string]*role
+}
+
+// New creates an Elector identified by replicaID. interval is how often a
+// follower retries acquiring a role and how often a leader checks it still
+// holds one.
+func New(s *store.Store, replicaID string, interval time.Duration, logger *slog.Logger) *Elector {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Elector{
+		store:     s,
+		replicaID: replicaID,
+		interval:  interval,
+		logger:    logger,
+		roles:     make(map[string]*role),
+	}
+}
+
+// Register adds a role and its job. It must be called before Run.
+func (e *Elector) Register(name string, job Job) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.roles[name] = &role{name: name, job: job}
+}
+
+// Leading reports whether this replica currently holds the named role.
+func (e *Elector) Leading(name string) bool {
+	e.mu.Lock()
+	r, ok := e.roles[name]
+	e.mu.Unlock()
+	return ok && r.leading.Load()
+}
+
+// Run campaigns for every registered role until ctx is cancelled, then
+// stops all running jobs, releases the roles and returns.
+func (e *Elector) Run(ctx context.Context) {
+	e.mu.Lock()
+	roles := make([]*role, 0, len(e.roles))
+	for _, r := range e.roles {
+		roles = append(roles, r)
+	}
+	e.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, r := range roles {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.campaign(ctx, r)
+		}()
+	}
+	wg.Wait()
+}
+
+// campaign repeatedly tries to acquire r, leading it whenever it succeeds.
+func (e *Elector) campaign(ctx context.Context, r *role) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		e.lead(ctx, r)
+		timer.Reset(e.interval)
+	}
+}
+
+// lead acquires r's lock if it is free and runs r's job until the lock is
+// lost or ctx is cancelled. It returns immediately if another replica
+// holds the lock.
+func (e *Elector) lead(ctx context.Context, r *role) {
+	conn, err := e.store.DB().Conn(ctx)
+	if err != nil {
+		e.logger.Warn("leader: get connection failed", "role", r.name, "error", err)
+		return
+	}
+	var got bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1, $2)`,
+		lockNamespace, lockKey(r.name)).Scan(&got); err != nil {
+		e.logger.Warn("leader: lock attempt failed", "role", r.name, "error", err)
+		_ = conn.Close()
+		return
+	}
+	if !got {
+		_ = conn.Close()
+		return
+	}
+
+	if err := e.store.RecordLeader(ctx, r.name, e.replicaID); err != nil {
+		e.logger.Warn("leader: record holder failed", "role", r.name, "error", err)
+	}
+	r.leading.Store(true)
+	e.logger.Info("leader: acquired role", "role", r.name, "replica", e.replicaID)
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.job(jobCtx)
+	}()
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	lost := false
+	for !lost {
+		select {
+		case <-ctx.Done():
+			lost = true
+		case <-ticker.C:
+			if err := conn.PingContext(ctx); err != nil {
+				if ctx.Err() == nil {
+					e.logger.Warn("leader: lost role", "role", r.name, "error", err)
+				}
+				lost = true
+				continue
+			}
+			if err := e.store.RenewLeader(ctx, r.name, e.replicaID); err != nil && ctx.Err() == nil {
+				e.logger.Warn("leader: renew holder failed", "role", r.name, "error", err)
+			}
+		}
+	}
+
+	cancel()
+	<-done
+	r.leading.Store(false)
+	e.release(conn, r)
+}
+
+// release unlocks r and returns conn to the pool. If the unlock fails the
+// connection is discarded instead, which ends the session and with it the
+// lock.
+func (e *Elector) release(conn *sql.Conn, r *role) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1, $2)`, lockNamespace, lockKey(r.name)); err != nil {
+		_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	}
+	_ = conn.Close()
+	e.logger.Info("leader: released role", "role", r.name, "replica", e.replicaID)
+}
+
+// lockKey maps a role name to the second key of its advisory lock.
+func lockKey(name string) int32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	return int32(h.Sum32())
+}
@@ -0,0 +1,142 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+// waitFor polls cond until it holds or the test times out.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestElector_LeadsWhenLockIsFree(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	mock.ExpectQuery("SELECT pg_try_advisory_lock").
+		WithArgs(lockNamespace, lockKey("cleanup")).
+		WillReturnRows(sqlmock.NewRows([]string{"got"}).AddRow(true))
+	mock.ExpectExec("INSERT INTO sandbox.leader_roles").
+		WithArgs("cleanup", "replica-a").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("SELECT pg_advisory_unlock").
+		WithArgs(lockNamespace, lockKey("cleanup")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	e := New(store.NewWithDB(db), "replica-a", time.Hour, nil)
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	e.Register("cleanup", func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		close(stopped)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		e.Run(ctx)
+		close(done)
+	}()
+
+	<-started
+	if !e.Leading("cleanup") {
+		t.Error("Leading(cleanup) = false while the job runs")
+	}
+
+	cancel()
+	<-done
+	select {
+	case <-stopped:
+	default:
+		t.Error("Run returned before the job stopped")
+	}
+	if e.Leading("cleanup") {
+		t.Error("Leading(cleanup) = true after Run returned")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestElector_FollowerDoesNotRunJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	mock.ExpectQuery("SELECT pg_try_advisory_lock").
+		WillReturnRows(sqlmock.NewRows([]string{"got"}).AddRow(false))
+
+	e := New(store.NewWithDB(db), "replica-b", time.Hour, nil)
+	e.Register("scanner", func(ctx context.Context) {
+		t.Error("job ran without holding the lock")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		e.Run(ctx)
+		close(done)
+	}()
+
+	waitFor(t, "lock attempt", func() bool { return mock.ExpectationsWereMet() == nil })
+	if e.Leading("scanner") {
+		t.Error("Leading(scanner) = true without the lock")
+	}
+	cancel()
+	<-done
+}
+
+func TestElector_StopsJobWhenConnectionIsLost(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	mock.ExpectQuery("SELECT pg_try_advisory_lock").
+		WillReturnRows(sqlmock.NewRows([]string{"got"}).AddRow(true))
+	mock.ExpectExec("INSERT INTO sandbox.leader_roles").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPing().WillReturnError(errors.New("connection reset by peer"))
+	mock.ExpectExec("SELECT pg_advisory_unlock").
+		WillReturnError(errors.New("connection reset by peer"))
+
+	e := New(store.NewWithDB(db), "replica-a", 20*time.Millisecond, nil)
+	stopped := make(chan struct{})
+	e.Register("backfill", func(ctx context.Context) {
+		<-ctx.Done()
+		close(stopped)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go e.Run(ctx)
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job was not stopped after the lock connection failed")
+	}
+	waitFor(t, "role release", func() bool { return !e.Leading("backfill") })
+}
@@ -0,0 +1,270 @@
+// Package mcpserver serves a tenant's skills and session workspaces over
+// the Model Context Protocol, so MCP-speaking agents can use Skillbox
+// without the REST API or an SDK.
+//
+// Every available, unblocked executable skill becomes a tool whose input
+// schema comes from the input_schema field of its SKILL.md. Calling it runs
+// the skill through the runner, exactly like POST /v1/executions. When a
+// session manager is configured, the workspace tools bash, read_file,
+// write_file, and ls operate on a persistent session sandbox.
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/devs-group/skillbox/internal/runner"
+	"github.com/devs-group/skillbox/internal/sandbox"
+	"github.com/devs-group/skillbox/internal/tools"
+	"github.com/devs-group/skillbox/internal/version"
+)
+
+// skillRefreshInterval is how often a long-lived server, such as one
+// serving stdio or cached by the HTTP handler, re-reads the tenant's skills when tools are listed or
+// called.
+const skillRefreshInterval = 30 * time.Second
+
+// Limits for the per-tenant servers the HTTP handler reuses across
+// requests. A server is rebuilt after serverTTL; the cache is cleared when
+// it holds maxCachedServers, since servers are cheap to rebuild.
+const (
+	serverTTL        = 10 * time.Minute
+	maxCachedServers = 1024
+)
+
+// Runner executes skills.
+type Runner interface {
+	Run(ctx context.Context, req runner.RunRequest) (*runner.RunResult, error)
+}
+
+// Workspaces provides the session sandboxes behind the workspace tools.
+type Workspaces interface {
+	GetOrCreate(ctx context.Context, tenantID, externalID string, opts sandbox.SandboxSessionOpts) (*sandbox.ManagedSandbox, error)
+	Execute(ctx context.Context, key string, command, workdir string, timeout int) (*sandbox.CommandResult, error)
+	ReadFile(ctx context.Context, key string, filePath string) ([]byte, error)
+	WriteFile(ctx context.Context, key string, filePath, content string) error
+	ListDir(ctx context.Context, key string, dirPath string, maxDepth int) ([]sandbox.DirEntry, error)
+}
+
+// Server builds per-tenant MCP servers.
+type Server struct {
+	catalog    *tools.Catalog
+	runner     Runner
+	workspaces Workspaces // nil disables the workspace tools
+
+	mu      sync.Mutex
+	servers map[caller]cachedServer // HTTP servers by tenant and session
+}
+
+// cachedServer is a tenant's MCP server reused by the HTTP handler.
+type cachedServer struct {
+	mcp     *mcp.Server
+	created time.Time
+}
+
+// New creates a Server. ws may be nil, in which case only skill tools are
+// offered.
+func New(cat *tools.Catalog, r Runner, ws Workspaces) *Server {
+	return &Server{catalog: cat, runner: r, workspaces: ws, servers: make(map[caller]cachedServer)}
+}
+
+type contextKey struct{}
+
+// caller identifies who an HTTP request acts for.
+type caller struct {
+	tenantID  string
+	sessionID string
+}
+
+// WithTenant returns a context for an authenticated MCP request made on
+// behalf of tenantID. sessionID, if set, is the workspace session used
+// when a tool call does not name one.
+func WithTenant(ctx context.Context, tenantID, sessionID string) context.Context {
+	return context.WithValue(ctx, contextKey{}, caller{tenantID: tenantID, sessionID: sessionID})
+}
+
+// Handler returns a streamable HTTP handler. Requests must carry a tenant
+// set with WithTenant; authenticating them is the caller's job. The
+// handler is stateless, so any replica can serve any request. The server
+// of a tenant and session is built once and reused for serverTTL, so a
+// request only lists the tenant's skills when its tools are stale.
+func (s *Server) Handler() http.Handler {
+	return mcp.NewStreamableHTTPHandler(func(r *http.Request) *mcp.Server {
+		c, ok := r.Context().Value(contextKey{}).(caller)
+		if !ok || c.tenantID == "" {
+			return nil
+		}
+		return s.serverFor(r.Context(), c)
+	}, &mcp.StreamableHTTPOptions{Stateless: true, Logger: slog.Default()})
+}
+
+// serverFor returns the cached server of c, building it if it is missing
+// or older than serverTTL.
+func (s *Server) serverFor(ctx context.Context, c caller) *mcp.Server {
+	s.mu.Lock()
+	cached, ok := s.servers[c]
+	s.mu.Unlock()
+	if ok && time.Since(cached.created) < serverTTL {
+		return cached.mcp
+	}
+
+	// Built without holding the lock; concurrent misses may each build one.
+	srv := s.ForTenant(ctx, c.tenantID, c.sessionID)
+	s.mu.Lock()
+	if len(s.servers) >= maxCachedServers {
+		clear(s.servers)
+	}
+	s.servers[c] = cachedServer{mcp: srv, created: time.Now()}
+	s.mu.Unlock()
+	return srv
+}
+
+// ServeStdio serves tenantID's tools over stdin and stdout until ctx is
+// done or the client disconnects.
+func (s *Server) ServeStdio(ctx context.Context, tenantID, sessionID string) error {
+	return s.ForTenant(ctx, tenantID, sessionID).Run(ctx, &mcp.StdioTransport{})
+}
+
+// ForTenant builds an MCP server exposing tenantID's skills and, if
+// configured, workspace tools bound to sessionID by default.
+func (s *Server) ForTenant(ctx context.Context, tenantID, sessionID string) *mcp.Server {
+	t := &tenantServer{
+		Server:    s,
+		tenantID:  tenantID,
+		sessionID: sessionID,
+		tools:     make(map[string]string),
+	}
+	t.mcp = mcp.NewServer(&mcp.Implementation{Name: "skillbox", Version: version.Version}, &mcp.ServerOptions{
+		Logger:       slog.Default(),
+		Capabilities: &mcp.ServerCapabilities{Tools: &mcp.ToolCapabilities{ListChanged: true}},
+	})
+	if s.workspaces != nil {
+		t.addWorkspaceTools()
+	}
+	t.refresh(ctx)
+	t.mcp.AddReceivingMiddleware(t.refreshSkills)
+	return t.mcp
+}
+
+// tenantServer is the MCP server for one tenant.
+type tenantServer struct {
+	*Server
+	mcp       *mcp.Server
+	tenantID  string
+	sessionID string
+
+	mu        sync.Mutex
+	tools     map[string]string // skill tool name -> version
+	refreshed time.Time
+}
+
+// refreshSkills re-syncs skill tools before a listing or call once they
+// are older than skillRefreshInterval.
+func (t *tenantServer) refreshSkills(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method == "tools/list" || method == "tools/call" {
+			t.mu.Lock()
+			stale := time.Since(t.refreshed) > skillRefreshInterval
+			t.mu.Unlock()
+			if stale {
+				t.refresh(ctx)
+			}
+		}
+		return next(ctx, method, req)
+	}
+}
+
+// refresh adds a tool for every runnable skill of the tenant and removes
+// tools for skills that are gone. Errors are logged; the previous tools
+// stay in place.
+func (t *tenantServer) refresh(ctx context.Context) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.refreshed = time.Now()
+
+	defs, err := t.catalog.List(ctx, t.tenantID)
+	if err != nil {
+		slog.Warn("mcp: list skills failed", "tenant_id", t.tenantID, "error", err)
+		return
+	}
+
+	seen := make(map[string]bool, len(defs))
+	for _, d := range defs {
+		if isWorkspaceTool(d.Skill) {
+			continue
+		}
+		seen[d.Skill] = true
+		if t.tools[d.Skill] == d.Version {
+			continue
+		}
+		t.mcp.AddTool(&mcp.Tool{
+			Name:        d.Skill,
+			Description: d.Description,
+			InputSchema: d.InputSchema,
+		}, t.runSkill(d.Skill, d.Version))
+		t.tools[d.Skill] = d.Version
+	}
+
+	var stale []string
+	for name := range t.tools {
+		if !seen[name] {
+			stale = append(stale, name)
+			delete(t.tools, name)
+		}
+	}
+	if len(stale) > 0 {
+		t.mcp.RemoveTools(stale...)
+	}
+}
+
+// runSkill returns the handler for a skill tool. The tool arguments are
+// the skill input.
+func (t *tenantServer) runSkill(name, version string) mcp.ToolHandler {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		input := req.Params.Arguments
+		if len(input) == 0 {
+			input = json.RawMessage("{}")
+		}
+		result, err := t.runner.Run(ctx, runner.RunRequest{
+			Skill:    name,
+			Version:  version,
+			Input:    input,
+			TenantID: t.tenantID,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, runner.ErrSkillNotFound):
+				return toolError("skill not found: %s@%s", name, version), nil
+			case errors.Is(err, runner.ErrSkillNotAvailable), errors.Is(err, runner.ErrImageNotAllowed), errors.Is(err, runner.ErrTimeout):
+				return toolError("%v", err), nil
+			}
+			return nil, fmt.Errorf("run skill %s@%s: %w", name, version, err)
+		}
+
+		out, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("encode result: %w", err)
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(out)}},
+			IsError: result.Status != "success",
+		}, nil
+	}
+}
+
+// toolError reports a failure to the model rather than as a protocol
+// error, so the agent can see it and react.
+func toolError(format string, args ...any) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf(format, args...)}},
+		IsError: true,
+	}
+}
@@ -0,0 +1,221 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/devs-group/skillbox/internal/runner"
+	"github.com/devs-group/skillbox/internal/sandbox"
+	"github.com/devs-group/skillbox/internal/skill"
+	"github.com/devs-group/skillbox/internal/store"
+	"github.com/devs-group/skillbox/internal/tools"
+)
+
+type fakeLoader struct{}
+
+func (fakeLoader) LoadSkillMD(_ context.Context, _, name, version string) (*skill.Skill, error) {
+	sk := &skill.Skill{Name: name, Version: version, Description: "desc of " + name, Mode: "executable"}
+	switch name {
+	case "pdf-summary":
+		sk.InputSchema = map[string]any{"type": "object", "required": []any{"url"}}
+	case "notes":
+		sk.Mode = "cognitive"
+	}
+	return sk, nil
+}
+
+type fakeRunner struct {
+	got runner.RunRequest
+}
+
+func (f *fakeRunner) Run(_ context.Context, req runner.RunRequest) (*runner.RunResult, error) {
+	f.got = req
+	return &runner.RunResult{ExecutionID: "exec-1", Status: "success", Output: json.RawMessage(`{"ok":true}`)}, nil
+}
+
+type fakeWorkspaces struct {
+	sessions []string
+	commands []string
+}
+
+func (f *fakeWorkspaces) GetOrCreate(_ context.Context, tenantID, externalID string, _ sandbox.SandboxSessionOpts) (*sandbox.ManagedSandbox, error) {
+	f.sessions = append(f.sessions, tenantID+":"+externalID)
+	return &sandbox.ManagedSandbox{TenantID: tenantID, ExternalID: externalID}, nil
+}
+
+func (f *fakeWorkspaces) Execute(_ context.Context, key, command, _ string, _ int) (*sandbox.CommandResult, error) {
+	f.commands = append(f.commands, key+" "+command)
+	return &sandbox.CommandResult{Stdout: "hi\n", ExitCode: 3}, nil
+}
+
+func (f *fakeWorkspaces) ReadFile(context.Context, string, string) ([]byte, error) { return nil, nil }
+
+func (f *fakeWorkspaces) WriteFile(context.Context, string, string, string) error { return nil }
+
+func (f *fakeWorkspaces) ListDir(context.Context, string, string, int) ([]sandbox.DirEntry, error) {
+	return nil, nil
+}
+
+func skillRows() *sqlmock.Rows {
+	cols := []string{"tenant_id", "name", "version", "description", "lang", "status", "stars",
+		"scan_result", "scanned_at", "reviewed_by", "reviewed_at", "uploaded_at", "source_url", "blocked"}
+	now := time.Now()
+	return sqlmock.NewRows(cols).
+		AddRow("tenant-1", "pdf-summary", "1.2.0", "Summarize a PDF", "python", "available", 0, nil, nil, nil, nil, now, nil, false).
+		AddRow("tenant-1", "notes", "1.0.0", "Note-taking guide", "", "available", 0, nil, nil, nil, nil, now, nil, false).
+		AddRow("tenant-1", "blocked-one", "1.0.0", "Blocked", "bash", "available", 0, nil, nil, nil, nil, now, nil, true).
+		AddRow("tenant-1", "bash", "1.0.0", "Shadows a workspace tool", "bash", "available", 0, nil, nil, nil, nil, now, nil, false)
+}
+
+func connect(t *testing.T, srv *mcp.Server) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	serverT, clientT := mcp.NewInMemoryTransports()
+	ss, err := srv.Connect(ctx, serverT, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	t.Cleanup(func() { _ = ss.Close() })
+	cs, err := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil).Connect(ctx, clientT, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() { _ = cs.Close() })
+	return cs
+}
+
+func TestForTenant_SkillTools(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+	mock.ExpectQuery("FROM sandbox.skills").WithArgs("tenant-1", store.SkillStatusAvailable).WillReturnRows(skillRows())
+
+	r := &fakeRunner{}
+	ws := &fakeWorkspaces{}
+	s := New(tools.NewCatalog(store.NewWithDB(db), fakeLoader{}), r, ws)
+
+	cs := connect(t, s.ForTenant(context.Background(), "tenant-1", "default-sess"))
+	ctx := context.Background()
+
+	list, err := cs.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListTools: %v", err)
+	}
+	var names []string
+	for _, tool := range list.Tools {
+		names = append(names, tool.Name)
+		if tool.Name == "pdf-summary" {
+			schema, _ := json.Marshal(tool.InputSchema)
+			if !strings.Contains(string(schema), `"required":["url"]`) {
+				t.Errorf("pdf-summary schema = %s", schema)
+			}
+		}
+	}
+	sort.Strings(names)
+	if strings.Join(names, ",") != "bash,ls,pdf-summary,read_file,write_file" {
+		t.Errorf("tools = %v", names)
+	}
+
+	res, err := cs.CallTool(ctx, &mcp.CallToolParams{Name: "pdf-summary", Arguments: map[string]any{"url": "https://x"}})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if res.IsError || !strings.Contains(res.Content[0].(*mcp.TextContent).Text, `"execution_id":"exec-1"`) {
+		t.Errorf("result = %+v", res)
+	}
+	if r.got.Skill != "pdf-summary" || r.got.Version != "1.2.0" || r.got.TenantID != "tenant-1" || string(r.got.Input) != `{"url":"https://x"}` {
+		t.Errorf("run request = %+v", r.got)
+	}
+
+	res, err = cs.CallTool(ctx, &mcp.CallToolParams{Name: "bash", Arguments: map[string]any{"command": "echo hi"}})
+	if err != nil {
+		t.Fatalf("CallTool bash: %v", err)
+	}
+	if text := res.Content[0].(*mcp.TextContent).Text; text != "hi\n\n[exit code: 3]" {
+		t.Errorf("bash output = %q", text)
+	}
+	if _, err := cs.CallTool(ctx, &mcp.CallToolParams{Name: "bash", Arguments: map[string]any{"command": "pwd", "session_id": "other"}}); err != nil {
+		t.Fatalf("CallTool bash: %v", err)
+	}
+	if strings.Join(ws.commands, "|") != "tenant-1:default-sess echo hi|tenant-1:other pwd" {
+		t.Errorf("commands = %v", ws.commands)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWorkspaceTools_RequireSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+	mock.ExpectQuery("FROM sandbox.skills").WillReturnRows(sqlmock.NewRows(nil))
+
+	s := New(tools.NewCatalog(store.NewWithDB(db), fakeLoader{}), &fakeRunner{}, &fakeWorkspaces{})
+	cs := connect(t, s.ForTenant(context.Background(), "tenant-1", ""))
+
+	res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{Name: "read_file", Arguments: map[string]any{"path": "/sandbox/session/a"}})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if !res.IsError || res.Content[0].(*mcp.TextContent).Text != "session_id is required" {
+		t.Errorf("result = %+v", res)
+	}
+}
+
+func TestHandler_ReusesTenantServer(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+	// Only the first request lists the tenant's skills; a second query
+	// would fail and leave a freshly built server without skill tools.
+	mock.ExpectQuery("FROM sandbox.skills").WithArgs("tenant-1", store.SkillStatusAvailable).WillReturnRows(skillRows())
+
+	s := New(tools.NewCatalog(store.NewWithDB(db), fakeLoader{}), &fakeRunner{}, nil)
+	h := s.Handler()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(WithTenant(r.Context(), "tenant-1", "sess")))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	for i := range 2 {
+		cs, err := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil).Connect(ctx, &mcp.StreamableClientTransport{Endpoint: ts.URL}, nil)
+		if err != nil {
+			t.Fatalf("connect %d: %v", i, err)
+		}
+		list, err := cs.ListTools(ctx, nil)
+		if err != nil {
+			t.Fatalf("ListTools %d: %v", i, err)
+		}
+		var names []string
+		for _, tool := range list.Tools {
+			names = append(names, tool.Name)
+		}
+		sort.Strings(names)
+		if strings.Join(names, ",") != "pdf-summary" {
+			t.Errorf("request %d: tools = %v", i, names)
+		}
+		_ = cs.Close()
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,229 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/devs-group/skillbox/internal/sandbox"
+)
+
+// Defaults for the workspace tools, matching the sandbox REST API.
+const (
+	workspaceDir         = "/sandbox/session"
+	defaultBashTimeoutMs = 30000
+	maxBashTimeoutMs     = 600000
+	listDirDepth         = 2
+)
+
+// isWorkspaceTool reports whether name is reserved by a workspace tool.
+// Skills with these names are not exposed.
+func isWorkspaceTool(name string) bool {
+	switch name {
+	case "bash", "read_file", "write_file", "ls":
+		return true
+	}
+	return false
+}
+
+// sessionIDProperty is accepted by every workspace tool.
+var sessionIDProperty = map[string]any{
+	"type":        "string",
+	"description": "Workspace session to use. Calls with the same session share files and state. Defaults to the session of the MCP connection.",
+}
+
+func (t *tenantServer) addWorkspaceTools() {
+	t.mcp.AddTool(&mcp.Tool{
+		Name:        "bash",
+		Description: "Execute a bash command in the persistent workspace sandbox. The sandbox retains files and state across calls in the same session. Put files meant for the user in /sandbox/session/outputs/.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"command":    map[string]any{"type": "string", "description": "The bash command to execute."},
+				"workdir":    map[string]any{"type": "string", "description": "Working directory. Defaults to /sandbox/session."},
+				"timeout_ms": map[string]any{"type": "integer", "description": "Timeout in milliseconds. Defaults to 30000."},
+				"session_id": sessionIDProperty,
+			},
+			"required": []string{"command"},
+		},
+	}, t.handleBash)
+	t.mcp.AddTool(&mcp.Tool{
+		Name:        "read_file",
+		Description: "Read a file from the workspace.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"path":       map[string]any{"type": "string", "description": "Absolute path to the file (e.g. /sandbox/session/data.csv)."},
+				"session_id": sessionIDProperty,
+			},
+			"required": []string{"path"},
+		},
+	}, t.handleReadFile)
+	t.mcp.AddTool(&mcp.Tool{
+		Name:        "write_file",
+		Description: "Write content to a file in the workspace, replacing it if it exists.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"path":       map[string]any{"type": "string", "description": "Absolute path for the file (e.g. /sandbox/session/output.txt)."},
+				"content":    map[string]any{"type": "string", "description": "The content to write."},
+				"session_id": sessionIDProperty,
+			},
+			"required": []string{"path", "content"},
+		},
+	}, t.handleWriteFile)
+	t.mcp.AddTool(&mcp.Tool{
+		Name:        "ls",
+		Description: "List files and directories at a workspace path, two levels deep.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"path":       map[string]any{"type": "string", "description": "Directory to list. Defaults to /sandbox/session."},
+				"session_id": sessionIDProperty,
+			},
+		},
+	}, t.handleListDir)
+}
+
+// workspaceArgs decodes tool arguments into v and resolves the session
+// sandbox they address. On failure it returns a tool error result.
+func (t *tenantServer) workspaceArgs(ctx context.Context, req *mcp.CallToolRequest, v any) (string, *mcp.CallToolResult) {
+	var common struct {
+		SessionID string `json:"session_id"`
+	}
+	args := req.Params.Arguments
+	if len(args) == 0 {
+		args = json.RawMessage("{}")
+	}
+	if err := json.Unmarshal(args, &common); err != nil {
+		return "", toolError("invalid arguments: %v", err)
+	}
+	if err := json.Unmarshal(args, v); err != nil {
+		return "", toolError("invalid arguments: %v", err)
+	}
+
+	sessionID := common.SessionID
+	if sessionID == "" {
+		sessionID = t.sessionID
+	}
+	if sessionID == "" {
+		return "", toolError("session_id is required")
+	}
+	ms, err := t.workspaces.GetOrCreate(ctx, t.tenantID, sessionID, sandbox.SandboxSessionOpts{})
+	if err != nil {
+		return "", toolError("failed to get or create sandbox: %v", err)
+	}
+	return t.tenantID + ":" + ms.ExternalID, nil
+}
+
+func (t *tenantServer) handleBash(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var a struct {
+		Command   string `json:"command"`
+		WorkDir   string `json:"workdir"`
+		TimeoutMs int    `json:"timeout_ms"`
+	}
+	key, errResult := t.workspaceArgs(ctx, req, &a)
+	if errResult != nil {
+		return errResult, nil
+	}
+	if strings.TrimSpace(a.Command) == "" {
+		return toolError("command is required"), nil
+	}
+	if a.WorkDir == "" {
+		a.WorkDir = workspaceDir
+	}
+	if err := sandbox.ValidateSandboxPath(a.WorkDir); err != nil {
+		return toolError("invalid workdir: %v", err), nil
+	}
+	if a.TimeoutMs <= 0 {
+		a.TimeoutMs = defaultBashTimeoutMs
+	}
+	a.TimeoutMs = min(a.TimeoutMs, maxBashTimeoutMs)
+
+	res, err := t.workspaces.Execute(ctx, key, a.Command, a.WorkDir, a.TimeoutMs)
+	if err != nil {
+		return toolError("command execution failed: %v", err), nil
+	}
+
+	var out strings.Builder
+	out.WriteString(res.Stdout)
+	if res.Stderr != "" {
+		if out.Len() > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString("stderr: " + res.Stderr)
+	}
+	if res.ExitCode != 0 {
+		fmt.Fprintf(&out, "\n[exit code: %d]", res.ExitCode)
+	}
+	if out.Len() == 0 {
+		out.WriteString("(no output)")
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: out.String()}}}, nil
+}
+
+func (t *tenantServer) handleReadFile(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var a struct {
+		Path string `json:"path"`
+	}
+	key, errResult := t.workspaceArgs(ctx, req, &a)
+	if errResult != nil {
+		return errResult, nil
+	}
+	data, err := t.workspaces.ReadFile(ctx, key, a.Path)
+	if err != nil {
+		return toolError("failed to read file: %v", err), nil
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: string(data)}}}, nil
+}
+
+func (t *tenantServer) handleWriteFile(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var a struct {
+		Path    string `json:"path"`
+		Content string `json:"content"`
+	}
+	key, errResult := t.workspaceArgs(ctx, req, &a)
+	if errResult != nil {
+		return errResult, nil
+	}
+	if err := t.workspaces.WriteFile(ctx, key, a.Path, a.Content); err != nil {
+		return toolError("failed to write file: %v", err), nil
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "File written to " + a.Path}}}, nil
+}
+
+func (t *tenantServer) handleListDir(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var a struct {
+		Path string `json:"path"`
+	}
+	key, errResult := t.workspaceArgs(ctx, req, &a)
+	if errResult != nil {
+		return errResult, nil
+	}
+	if a.Path == "" {
+		a.Path = workspaceDir
+	}
+	entries, err := t.workspaces.ListDir(ctx, key, a.Path, listDirDepth)
+	if err != nil {
+		return toolError("failed to list directory: %v", err), nil
+	}
+
+	var out strings.Builder
+	out.WriteString(path.Clean(a.Path) + "/\n")
+	for _, e := range entries {
+		rel := strings.TrimPrefix(e.Path, path.Clean(a.Path)+"/")
+		if e.IsDir {
+			out.WriteString(rel + "/\n")
+		} else {
+			fmt.Fprintf(&out, "%s (%d bytes)\n", rel, e.Size)
+		}
+	}
+	if len(entries) == 0 {
+		out.WriteString("(empty directory)\n")
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: out.String()}}}, nil
+}
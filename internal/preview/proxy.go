@@ -0,0 +1,262 @@
+// Package preview exposes ports inside session sandboxes through an
+// authenticated reverse proxy, so agents can show users the web apps and
+// dashboards they build.
+//
+// Each registered port gets a random access token. The token is the only
+// credential a preview URL carries, which lets it be opened directly in a
+// browser. Previews are served either under /preview/<token>/ on the API
+// origin or, when a preview domain is configured, from <token>.<domain>.
+package preview
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devs-group/skillbox/internal/api/response"
+	"github.com/devs-group/skillbox/internal/config"
+	"github.com/devs-group/skillbox/internal/sandbox"
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+// PathPrefix is the route under which path-based previews are served.
+const PathPrefix = "/preview/"
+
+// targetCacheTTL is how long a resolved token is reused before the store
+// and OpenSandbox are consulted again. It bounds how long a preview keeps
+// working on other replicas after its port is unregistered or its session
+// is destroyed.
+const targetCacheTTL = 10 * time.Second
+
+// maxCachedTargets triggers a sweep of expired cache entries.
+const maxCachedTargets = 1024
+
+// pathModeCSP isolates path-based previews from the API origin they share.
+// Without allow-same-origin the page runs in an opaque origin, so its
+// scripts cannot read the origin's storage or call the API with ambient
+// credentials.
+const pathModeCSP = "sandbox allow-scripts allow-forms allow-popups allow-modals allow-downloads"
+
+// Endpoints resolves a port inside a sandbox to a reachable address.
+type Endpoints interface {
+	GetEndpoint(ctx context.Context, sandboxID string, port int) (*sandbox.Endpoint, error)
+}
+
+// target is a resolved preview destination.
+type target struct {
+	url     *url.URL
+	headers map[string]string
+	expires time.Time
+}
+
+// Proxy forwards HTTP and WebSocket traffic from preview URLs to sandbox
+// ports.
+type Proxy struct {
+	store     *store.Store
+	endpoints Endpoints
+	baseURL   string
+	domain    string
+	maxBody   int64
+
+	mu    sync.Mutex
+	cache map[string]target // keyed by token hash
+}
+
+// New creates a Proxy configured from the SKILLBOX_PREVIEW_* settings.
+func New(s *store.Store, endpoints Endpoints, cfg *config.Config) *Proxy {
+	return &Proxy{
+		store:     s,
+		endpoints: endpoints,
+		baseURL:   cfg.PreviewBaseURL,
+		domain:    cfg.PreviewDomain,
+		maxBody:   cfg.PreviewMaxBodySize,
+		cache:     make(map[string]target),
+	}
+}
+
+// NewToken returns a random preview access token and its hash. Tokens are
+// lowercase hex so they are valid DNS labels in host-based previews.
+func NewToken() (token, hash string, err error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("generate preview token: %w", err)
+	}
+	token = hex.EncodeToString(b)
+	return token, HashToken(token), nil
+}
+
+// HashToken returns the SHA-256 hash under which a token is stored.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// URL returns the preview URL for token.
+func (p *Proxy) URL(token string) string {
+	if p.domain != "" {
+		scheme := "https"
+		if u, err := url.Parse(p.baseURL); err == nil && u.Scheme != "" {
+			scheme = u.Scheme
+		}
+		return scheme + "://" + token + "." + p.domain + "/"
+	}
+	return p.baseURL + PathPrefix + token + "/"
+}
+
+// TokenFromHost extracts the token from a host-based preview request. It
+// returns false when no preview domain is configured or host is not a
+// subdomain of it.
+func (p *Proxy) TokenFromHost(host string) (string, bool) {
+	if p.domain == "" {
+		return "", false
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	token, ok := strings.CutSuffix(strings.ToLower(host), "."+p.domain)
+	if !ok || token == "" || strings.Contains(token, ".") {
+		return "", false
+	}
+	return token, true
+}
+
+// Forget drops a cached token so an unregistered port stops being served
+// by this replica immediately.
+func (p *Proxy) Forget(tokenHash string) {
+	p.mu.Lock()
+	delete(p.cache, tokenHash)
+	p.mu.Unlock()
+}
+
+// ServePath proxies a path-based preview request. path is the part of the
+// request path after /preview/<token>.
+func (p *Proxy) ServePath(w http.ResponseWriter, r *http.Request, token, path string) {
+	p.serve(w, r, token, path, PathPrefix+token)
+}
+
+// ServeHost proxies a host-based preview request for token.
+func (p *Proxy) ServeHost(w http.ResponseWriter, r *http.Request, token string) {
+	p.serve(w, r, token, r.URL.Path, "")
+}
+
+// serve forwards r to the port registered under token. prefix is the path
+// the preview is mounted under, empty for host-based previews.
+func (p *Proxy) serve(w http.ResponseWriter, r *http.Request, token, path, prefix string) {
+	if r.ContentLength > p.maxBody {
+		writeError(w, http.StatusRequestEntityTooLarge, "request_too_large",
+			fmt.Sprintf("request body exceeds %d bytes", p.maxBody))
+		return
+	}
+	t, err := p.resolve(r.Context(), HashToken(token))
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "not_found", "preview not found or expired")
+		return
+	}
+	if err != nil {
+		slog.Warn("preview: resolve target failed", "error", err)
+		writeError(w, http.StatusBadGateway, "preview_unavailable", "preview is not reachable")
+		return
+	}
+	if r.Body != nil {
+		r.Body = http.MaxBytesReader(w, r.Body, p.maxBody)
+	}
+	if path == "" {
+		path = "/"
+	}
+
+	rp := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.Out.URL.Path = path
+			pr.Out.URL.RawPath = ""
+			pr.SetURL(t.url)
+			pr.SetXForwarded()
+			if prefix != "" {
+				pr.Out.Header.Set("X-Forwarded-Prefix", prefix)
+				// Requests on the API origin carry the caller's Skillbox
+				// credentials, which the sandboxed code must not see.
+				pr.Out.Header.Del("Authorization")
+				pr.Out.Header.Del("Cookie")
+			}
+			for k, v := range t.headers {
+				pr.Out.Header.Set(k, v)
+			}
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			if prefix != "" {
+				// Cookies would be scoped to the API origin.
+				resp.Header.Del("Set-Cookie")
+				resp.Header.Set("Content-Security-Policy", pathModeCSP)
+			}
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeError(w, http.StatusRequestEntityTooLarge, "request_too_large",
+					fmt.Sprintf("request body exceeds %d bytes", p.maxBody))
+				return
+			}
+			if r.Context().Err() == nil {
+				slog.Warn("preview: upstream request failed", "error", err)
+			}
+			writeError(w, http.StatusBadGateway, "preview_unavailable", "nothing is listening on the preview port")
+		},
+	}
+	rp.ServeHTTP(w, r)
+}
+
+// resolve maps a token hash to its current upstream, caching the result
+// for targetCacheTTL.
+func (p *Proxy) resolve(ctx context.Context, tokenHash string) (target, error) {
+	p.mu.Lock()
+	t, ok := p.cache[tokenHash]
+	p.mu.Unlock()
+	if ok && time.Now().Before(t.expires) {
+		return t, nil
+	}
+
+	pt, err := p.store.GetPreviewTarget(ctx, tokenHash)
+	if err != nil {
+		return target{}, err
+	}
+	ep, err := p.endpoints.GetEndpoint(ctx, pt.SandboxID, pt.Port)
+	if err != nil {
+		return target{}, fmt.Errorf("resolve sandbox %s port %d: %w", pt.SandboxID, pt.Port, err)
+	}
+	u, err := url.Parse(ep.URL)
+	if err != nil || u.Host == "" {
+		return target{}, fmt.Errorf("resolve sandbox %s port %d: invalid endpoint %q", pt.SandboxID, pt.Port, ep.URL)
+	}
+
+	t = target{url: u, headers: ep.Headers, expires: time.Now().Add(targetCacheTTL)}
+	p.mu.Lock()
+	if len(p.cache) >= maxCachedTargets {
+		now := time.Now()
+		for k, c := range p.cache {
+			if now.After(c.expires) {
+				delete(p.cache, k)
+			}
+		}
+	}
+	p.cache[tokenHash] = t
+	p.mu.Unlock()
+	return t, nil
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response.APIError{Error: code, Message: message})
+}
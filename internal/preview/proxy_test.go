@@ -0,0 +1,228 @@
+package preview
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/websocket"
+
+	"github.com/devs-group/skillbox/internal/config"
+	"github.com/devs-group/skillbox/internal/sandbox"
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+const testToken = "0123456789abcdef0123456789abcdef0123456789abcdef"
+
+var previewTargetColumns = []string{"id", "tenant_id", "external_id", "port", "created_at", "sandbox_id"}
+
+// fakeEndpoints resolves every port to url.
+type fakeEndpoints struct {
+	url     string
+	headers map[string]string
+	calls   int
+}
+
+func (f *fakeEndpoints) GetEndpoint(_ context.Context, sandboxID string, port int) (*sandbox.Endpoint, error) {
+	f.calls++
+	return &sandbox.Endpoint{URL: f.url, Headers: f.headers}, nil
+}
+
+// newTestProxy returns a Proxy whose store expects one lookup of testToken
+// resolving to sandbox sb-1 port 3000, and whose endpoint is upstream.
+func newTestProxy(t *testing.T, upstream string, cfg *config.Config) (*Proxy, *fakeEndpoints, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	mock.ExpectQuery("SELECT p.id, p.tenant_id, p.external_id, p.port").
+		WithArgs(HashToken(testToken)).
+		WillReturnRows(sqlmock.NewRows(previewTargetColumns).
+			AddRow("pp-1", "tenant-1", "sess-1", 3000, time.Now(), "sb-1"))
+
+	if cfg.PreviewMaxBodySize == 0 {
+		cfg.PreviewMaxBodySize = 1 << 20
+	}
+	eps := &fakeEndpoints{url: upstream, headers: map[string]string{"OpenSandbox-Ingress-To": "sb-1-3000"}}
+	return New(store.NewWithDB(db), eps, cfg), eps, mock
+}
+
+func TestServePath_ForwardsToSandboxPort(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/assets/app.js" || r.URL.RawQuery != "v=2" {
+			t.Errorf("upstream got %s?%s, want /assets/app.js?v=2", r.URL.Path, r.URL.RawQuery)
+		}
+		if got := r.Header.Get("X-Forwarded-Prefix"); got != "/preview/"+testToken {
+			t.Errorf("X-Forwarded-Prefix = %q", got)
+		}
+		if got := r.Header.Get("OpenSandbox-Ingress-To"); got != "sb-1-3000" {
+			t.Errorf("endpoint header = %q, want sb-1-3000", got)
+		}
+		if r.Header.Get("Authorization") != "" || r.Header.Get("Cookie") != "" {
+			t.Errorf("caller credentials reached the sandbox: Authorization %q, Cookie %q",
+				r.Header.Get("Authorization"), r.Header.Get("Cookie"))
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "x"})
+		_, _ = io.WriteString(w, "console.log('hi')")
+	}))
+	defer upstream.Close()
+
+	p, eps, mock := newTestProxy(t, upstream.URL, &config.Config{})
+
+	for range 2 {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/preview/"+testToken+"/assets/app.js?v=2", nil)
+		r.Header.Set("Authorization", "Bearer sk-caller-key")
+		r.AddCookie(&http.Cookie{Name: "ory_kratos_session", Value: "secret"})
+		p.ServePath(w, r, testToken, "/assets/app.js")
+
+		if w.Code != http.StatusOK || w.Body.String() != "console.log('hi')" {
+			t.Fatalf("got %d %q", w.Code, w.Body.String())
+		}
+		if w.Header().Get("Set-Cookie") != "" {
+			t.Error("Set-Cookie was not stripped from a path-based preview")
+		}
+		if got := w.Header().Get("Content-Security-Policy"); got != pathModeCSP {
+			t.Errorf("Content-Security-Policy = %q, want %q", got, pathModeCSP)
+		}
+	}
+
+	// The second request is served from the target cache.
+	if eps.calls != 1 {
+		t.Errorf("endpoint resolved %d times, want 1", eps.calls)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestServePath_UnknownToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+	mock.ExpectQuery("SELECT p.id, p.tenant_id, p.external_id, p.port").
+		WillReturnRows(sqlmock.NewRows(previewTargetColumns))
+
+	p := New(store.NewWithDB(db), &fakeEndpoints{}, &config.Config{PreviewMaxBodySize: 1 << 20})
+	w := httptest.NewRecorder()
+	p.ServePath(w, httptest.NewRequest(http.MethodGet, "/preview/nope/", nil), "nope", "/")
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", w.Code)
+	}
+}
+
+func TestServePath_RejectsLargeBody(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+	}))
+	defer upstream.Close()
+
+	p, _, _ := newTestProxy(t, upstream.URL, &config.Config{PreviewMaxBodySize: 8})
+
+	// Declared too large: rejected before the target is resolved.
+	w := httptest.NewRecorder()
+	p.ServePath(w, httptest.NewRequest(http.MethodPost, "/preview/"+testToken+"/", strings.NewReader("0123456789")), testToken, "/")
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("declared length: status = %d, want 413", w.Code)
+	}
+
+	// Streamed without a length: cut off while forwarding.
+	r := httptest.NewRequest(http.MethodPost, "/preview/"+testToken+"/", io.MultiReader(strings.NewReader("0123456789")))
+	r.ContentLength = -1
+	w = httptest.NewRecorder()
+	p.ServePath(w, r, testToken, "/")
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("streamed body: status = %d, want 413", w.Code)
+	}
+}
+
+func TestServeHost_ProxiesWebSocket(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upstream upgrade: %v", err)
+			return
+		}
+		defer conn.Close() //nolint:errcheck
+		mt, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		_ = conn.WriteMessage(mt, append([]byte("echo: "), msg...))
+	}))
+	defer upstream.Close()
+
+	p, _, _ := newTestProxy(t, upstream.URL, &config.Config{PreviewDomain: "preview.example.com"})
+	front := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := p.TokenFromHost(r.Host)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		p.ServeHost(w, r, token)
+	}))
+	defer front.Close()
+
+	dialer := websocket.Dialer{}
+	header := http.Header{"Host": {testToken + ".preview.example.com"}}
+	conn, resp, err := dialer.Dial("ws"+strings.TrimPrefix(front.URL, "http")+"/hmr", header)
+	if err != nil {
+		t.Fatalf("dial through proxy: %v (response %v)", err, resp)
+	}
+	defer conn.Close() //nolint:errcheck
+
+	if err := conn.WriteMessage(websocket.TextMessage, []byte("ping")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	_, msg, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(msg) != "echo: ping" {
+		t.Errorf("got %q, want %q", msg, "echo: ping")
+	}
+}
+
+func TestURLAndTokenFromHost(t *testing.T) {
+	path := New(nil, nil, &config.Config{PreviewBaseURL: "https://api.example.com"})
+	if got := path.URL(testToken); got != "https://api.example.com/preview/"+testToken+"/" {
+		t.Errorf("path URL = %q", got)
+	}
+	if _, ok := path.TokenFromHost(testToken + ".preview.example.com"); ok {
+		t.Error("TokenFromHost matched without a preview domain")
+	}
+
+	host := New(nil, nil, &config.Config{PreviewBaseURL: "http://localhost:8080", PreviewDomain: "preview.localhost"})
+	if got := host.URL(testToken); got != "http://"+testToken+".preview.localhost/" {
+		t.Errorf("host URL = %q", got)
+	}
+	tests := []struct {
+		host  string
+		token string
+		ok    bool
+	}{
+		{testToken + ".preview.localhost", testToken, true},
+		{strings.ToUpper(testToken) + ".Preview.Localhost:8080", testToken, true},
+		{"preview.localhost", "", false},
+		{"a.b.preview.localhost", "", false},
+		{"api.example.com", "", false},
+	}
+	for _, tt := range tests {
+		token, ok := host.TokenFromHost(tt.host)
+		if token != tt.token || ok != tt.ok {
+			t.Errorf("TokenFromHost(%q) = %q, %v; want %q, %v", tt.host, token, ok, tt.token, tt.ok)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/devs-group/skillbox/internal/sandboxenv"
 	"github.com/devs-group/skillbox/internal/skill"
 )
 
@@ -38,14 +39,6 @@ type LoadedSkill struct {
 	HasPackageJSON bool
 }
 
-// knownEntrypoints lists the accepted entrypoint filenames in priority order.
-var knownEntrypoints = []string{
-	"main.py",
-	"run.py",
-	"main.js",
-	"main.sh",
-}
-
 // LoadSkill downloads a skill archive from the registry, extracts it to a
 // temporary directory, validates its contents, and returns a LoadedSkill.
 //
@@ -55,6 +48,8 @@ var knownEntrypoints = []string{
 //   - SKILL.md must exist and parse successfully
 //   - A recognized entrypoint script must exist
 //   - Zip entries are checked for path traversal (zip slip) attacks
+//   - With a verifier set, the archive's signature is checked against the
+//     tenant's trusted publisher keys and signing policy
 func LoadSkill(ctx context.Context, reg *Registry, tenantID, skillName, version string) (*LoadedSkill, error) {
 	// Download the zip from the registry.
 	rc, err := reg.Download(ctx, tenantID, skillName, version)
@@ -68,7 +63,17 @@ func LoadSkill(ctx context.Context, reg *Registry, tenantID, skillName, version
 	if err != nil {
 		return nil, fmt.Errorf("reading skill archive: %w", err)
 	}
+	if reg.verifier != nil {
+		if _, err := reg.verifier.VerifySkill(ctx, tenantID, zipBytes); err != nil {
+			return nil, fmt.Errorf("verifying skill %s@%s: %w", skillName, version, err)
+		}
+	}
+	return LoadSkillZip(zipBytes)
+}
 
+// LoadSkillZip is like LoadSkill for an archive that is already in
+// memory, such as a version still awaiting review.
+func LoadSkillZip(zipBytes []byte) (*LoadedSkill, error) {
 	zipReader, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
 	if err != nil {
 		return nil, fmt.Errorf("opening skill archive: %w", err)
@@ -112,7 +117,7 @@ func LoadSkill(ctx context.Context, reg *Registry, tenantID, skillName, version
 
 	// Find a recognized entrypoint script (optional — instruction-only or
 	// library-style skills may not have one).
-	entrypoint, _ := findEntrypoint(tmpDir)
+	entrypoint, _ := sandboxenv.FindEntrypoint(tmpDir)
 
 	// Infer language from entrypoint extension if not specified.
 	if parsedSkill.Lang == "" && entrypoint != "" {
@@ -133,6 +138,62 @@ func LoadSkill(ctx context.Context, reg *Registry, tenantID, skillName, version
 	}, nil
 }
 
+// LoadSkillMD downloads a skill archive and parses only its SKILL.md,
+// without extracting anything to disk. It is meant for callers that need
+// the skill's metadata, not its files.
+func (r *Registry) LoadSkillMD(ctx context.Context, tenantID, skillName, version string) (*skill.Skill, error) {
+	rc, err := r.Download(ctx, tenantID, skillName, version)
+	if err != nil {
+		return nil, fmt.Errorf("downloading skill %s/%s@%s: %w", tenantID, skillName, version, err)
+	}
+	defer rc.Close() //nolint:errcheck
+	return readSkillMD(rc)
+}
+
+// LoadAnySkillMD is like LoadSkillMD but also finds versions still
+// awaiting review, see DownloadAny.
+func (r *Registry) LoadAnySkillMD(ctx context.Context, tenantID, skillName, version string) (*skill.Skill, error) {
+	rc, err := r.DownloadAny(ctx, tenantID, skillName, version)
+	if err != nil {
+		return nil, fmt.Errorf("downloading skill %s/%s@%s: %w", tenantID, skillName, version, err)
+	}
+	defer rc.Close() //nolint:errcheck
+	return readSkillMD(rc)
+}
+
+// readSkillMD parses the SKILL.md at the root of the skill archive rc.
+func readSkillMD(rc io.Reader) (*skill.Skill, error) {
+	zipBytes, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("reading skill archive: %w", err)
+	}
+	zipReader, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+	if err != nil {
+		return nil, fmt.Errorf("opening skill archive: %w", err)
+	}
+
+	for _, f := range zipReader.File {
+		if strings.TrimPrefix(f.Name, "./") != "SKILL.md" {
+			continue
+		}
+		fr, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("opening SKILL.md: %w", err)
+		}
+		data, err := io.ReadAll(fr)
+		_ = fr.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading SKILL.md: %w", err)
+		}
+		parsed, err := skill.ParseSkillMD(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing SKILL.md: %w", err)
+		}
+		return parsed, nil
+	}
+	return nil, fmt.Errorf("SKILL.md not found in archive")
+}
+
 // extractZipEntry extracts a single zip entry to the target directory,
 // creating intermediate directories as needed. It rejects any entry whose
 // path contains ".." components or resolves outside the target directory
@@ -190,30 +251,6 @@ func extractZipEntry(targetDir string, f *zip.File) error {
 	return nil
 }
 
-// findEntrypoint searches the extracted skill directory for a recognized
-// entrypoint script. It checks both the root and a "scripts/" subdirectory.
-func findEntrypoint(dir string) (string, error) {
-	// Check root directory first.
-	for _, name := range knownEntrypoints {
-		if fileExists(filepath.Join(dir, name)) {
-			return name, nil
-		}
-	}
-
-	// Check scripts/ subdirectory.
-	for _, name := range knownEntrypoints {
-		candidate := filepath.Join("scripts", name)
-		if fileExists(filepath.Join(dir, candidate)) {
-			return candidate, nil
-		}
-	}
-
-	return "", fmt.Errorf(
-		"no recognized entrypoint found in archive (expected one of: %s)",
-		strings.Join(knownEntrypoints, ", "),
-	)
-}
-
 // fileExists returns true if the path exists and is a regular file.
 func fileExists(path string) bool {
 	info, err := os.Stat(path)
@@ -13,11 +13,16 @@ import (
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/devs-group/skillbox/internal/signing"
 )
 
 // ErrSkillNotFound is returned when a skill cannot be located in storage.
 var ErrSkillNotFound = errors.New("registry: skill not found")
 
+// ErrSBOMNotFound is returned when a skill version has no stored SBOM.
+var ErrSBOMNotFound = errors.New("registry: SBOM not found")
+
 // SkillMeta contains summary information about a skill version stored in
 // the registry. It is returned by List.
 type SkillMeta struct {
@@ -30,8 +35,21 @@ type SkillMeta struct {
 // object storage. Archives are stored as zip files keyed by tenant, skill
 // name, and version.
 type Registry struct {
-	client *minio.Client
-	bucket string
+	client   *minio.Client
+	bucket   string
+	verifier SkillVerifier
+}
+
+// SkillVerifier checks a skill archive against the signing policy of the
+// tenant that owns it. *signing.Verifier implements it.
+type SkillVerifier interface {
+	VerifySkill(ctx context.Context, tenantID string, zipData []byte) (*signing.Signature, error)
+}
+
+// SetVerifier makes LoadSkill check every archive with v before it is
+// extracted for execution.
+func (r *Registry) SetVerifier(v SkillVerifier) {
+	r.verifier = v
 }
 
 // New creates a Registry connected to the given S3/MinIO endpoint. It
@@ -74,6 +92,12 @@ func pendingPath(tenantID, skillName, version string) string {
 	return path.Join(tenantID, "skills", ".pending", skillName, version, "skill.zip")
 }
 
+// sbomPath returns the S3 key for a skill version's SBOM. It sits next to
+// the promoted archive whether or not the version was promoted.
+func sbomPath(tenantID, skillName, version string) string {
+	return path.Join(tenantID, "skills", skillName, version, "sbom.cdx.json")
+}
+
 // Upload stores a skill zip archive in the registry after validating that
 // the provided data is a valid zip file (by checking zip headers). The
 // archive is stored at {tenantID}/{skillName}/{version}/skill.zip.
@@ -288,9 +312,50 @@ func (r *Registry) Delete(ctx context.Context, tenantID, skillName, version stri
 	if !found {
 		return ErrSkillNotFound
 	}
+	// Best-effort: versions scanned before SBOMs existed have none.
+	_ = r.client.RemoveObject(ctx, r.bucket, sbomPath(tenantID, skillName, version), minio.RemoveObjectOptions{})
 	return nil
 }
 
+// PutSBOM stores the CycloneDX JSON SBOM of a skill version.
+func (r *Registry) PutSBOM(ctx context.Context, tenantID, skillName, version string, data []byte) error {
+	if tenantID == "" || skillName == "" || version == "" {
+		return fmt.Errorf("tenantID, skillName, and version are required")
+	}
+	key := sbomPath(tenantID, skillName, version)
+	_, err := r.client.PutObject(ctx, r.bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType: "application/vnd.cyclonedx+json",
+	})
+	if err != nil {
+		return fmt.Errorf("storing SBOM %q: %w", key, err)
+	}
+	return nil
+}
+
+// GetSBOM returns the SBOM of a skill version, or ErrSBOMNotFound if none
+// was generated.
+func (r *Registry) GetSBOM(ctx context.Context, tenantID, skillName, version string) ([]byte, error) {
+	if tenantID == "" || skillName == "" || version == "" {
+		return nil, fmt.Errorf("tenantID, skillName, and version are required")
+	}
+	key := sbomPath(tenantID, skillName, version)
+	obj, err := r.client.GetObject(ctx, r.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("downloading SBOM %q: %w", key, err)
+	}
+	defer obj.Close() //nolint:errcheck
+
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		errResp := minio.ErrorResponse{}
+		if errors.As(err, &errResp) && errResp.Code == "NoSuchKey" {
+			return nil, ErrSBOMNotFound
+		}
+		return nil, fmt.Errorf("reading SBOM %q: %w", key, err)
+	}
+	return data, nil
+}
+
 // ResolveLatest returns the version string of the most recently uploaded
 // version of a skill. If no versions are found, it returns ErrSkillNotFound.
 func (r *Registry) ResolveLatest(ctx context.Context, tenantID, skillName string) (string, error) {
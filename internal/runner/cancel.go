@@ -0,0 +1,34 @@
+package runner
+
+import "context"
+
+// Cancel stops a running execution of tenantID. The execution finishes with
+// status "cancelled" once the skill has been given its grace period to
+// flush output. Executions only run on the server that received them, so
+// Cancel returns ErrExecutionNotRunning for executions that have finished
+// or are running elsewhere.
+func (r *Runner) Cancel(tenantID, executionID string) error {
+	r.mu.Lock()
+	e, ok := r.running[executionID]
+	r.mu.Unlock()
+	if !ok || e.tenantID != tenantID {
+		return ErrExecutionNotRunning
+	}
+	e.cancel(ErrCancelled)
+	return nil
+}
+
+func (r *Runner) track(executionID, tenantID string, cancel context.CancelCauseFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running == nil {
+		r.running = make(map[string]runningExecution)
+	}
+	r.running[executionID] = runningExecution{tenantID: tenantID, cancel: cancel}
+}
+
+func (r *Runner) untrack(executionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.running, executionID)
+}
@@ -0,0 +1,33 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCancel(t *testing.T) {
+	r := &Runner{}
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+	r.track("exec-1", "tenant-a", cancel)
+
+	if err := r.Cancel("tenant-b", "exec-1"); !errors.Is(err, ErrExecutionNotRunning) {
+		t.Errorf("Cancel from another tenant = %v, want ErrExecutionNotRunning", err)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("execution cancelled by another tenant")
+	}
+
+	if err := r.Cancel("tenant-a", "exec-1"); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if !errors.Is(context.Cause(ctx), ErrCancelled) {
+		t.Errorf("cause = %v, want ErrCancelled", context.Cause(ctx))
+	}
+
+	r.untrack("exec-1")
+	if err := r.Cancel("tenant-a", "exec-1"); !errors.Is(err, ErrExecutionNotRunning) {
+		t.Errorf("Cancel after finish = %v, want ErrExecutionNotRunning", err)
+	}
+}
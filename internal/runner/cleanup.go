@@ -4,26 +4,57 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
+	"github.com/devs-group/skillbox/internal/config"
 	"github.com/devs-group/skillbox/internal/sandbox"
 )
 
+// sandboxDeleteTimeout bounds the deletion of a sandbox when a run ends.
+const sandboxDeleteTimeout = 30 * time.Second
+
+// orphanMargin is added to the longest possible sandbox lifetime before
+// CleanupOrphans may remove a sandbox. It absorbs clock skew between the
+// replicas and OpenSandbox and slow sandbox API calls.
+const orphanMargin = 10 * time.Minute
+
+// OrphanMinAge returns the minAge to pass to CleanupOrphans: the longest an
+// execution or skill test can hold a sandbox, plus orphanMargin. Sandbox
+// setup, dependency installation and the run share the execution timeout
+// (at most cfg.MaxTimeout); a timed-out run then gets the grace period and
+// output collection, and the sandbox is deleted within sandboxDeleteTimeout.
+func OrphanMinAge(cfg *config.Config) time.Duration {
+	return cfg.MaxTimeout + cfg.TimeoutGracePeriod + collectTimeout + sandboxDeleteTimeout + orphanMargin
+}
+
 // CleanupOrphans finds and removes any OpenSandbox sandboxes with the
-// metadata "managed-by=skillbox" that were left behind by previous server
-// instances (e.g. after a crash or ungraceful shutdown). It is designed
-// to be called once at server startup.
+// metadata "managed-by=skillbox" that were left behind by server instances
+// that crashed or shut down ungracefully. Sandboxes created less than
+// minAge ago are skipped: with several replicas they may belong to an
+// execution still running elsewhere, so minAge should exceed the longest
+// sandbox lifetime (see OrphanMinAge). Sandboxes without a creation time
+// are skipped too, since their age is unknown. It is run periodically by
+// whichever replica holds the cleanup role.
 //
 // Each orphaned sandbox is deleted. Errors removing individual sandboxes
 // are logged but do not stop the cleanup of remaining sandboxes. A non-nil
 // error is returned only if the sandbox listing itself fails.
-func CleanupOrphans(ctx context.Context, sb *sandbox.Client) error {
-	sandboxes, err := sb.ListSandboxes(ctx, map[string]string{
+func CleanupOrphans(ctx context.Context, sb *sandbox.Client, minAge time.Duration) error {
+	all, err := sb.ListSandboxes(ctx, map[string]string{
 		"managed-by": "skillbox",
 	})
 	if err != nil {
 		return fmt.Errorf("listing orphaned skillbox sandboxes: %w", err)
 	}
 
+	cutoff := time.Now().Add(-minAge)
+	var sandboxes []sandbox.SandboxResponse
+	for _, s := range all {
+		if !s.CreatedAt.IsZero() && s.CreatedAt.Before(cutoff) {
+			sandboxes = append(sandboxes, s)
+		}
+	}
+
 	if len(sandboxes) == 0 {
 		return nil
 	}
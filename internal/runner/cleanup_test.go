@@ -0,0 +1,64 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devs-group/skillbox/internal/config"
+	"github.com/devs-group/skillbox/internal/sandbox"
+)
+
+func TestCleanupOrphans_SkipsRecentSandboxes(t *testing.T) {
+	now := time.Now().UTC()
+	var (
+		mu      sync.Mutex
+		deleted []string
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /sandboxes", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("metadata"); got != "managed-by=skillbox" {
+			t.Errorf("metadata = %q, want managed-by=skillbox", got)
+		}
+		sb := func(id string, created time.Time) string {
+			return fmt.Sprintf(`{"id":%q,"status":{"state":"Running"},"expires_at":%q,"created_at":%q}`,
+				id, created.Add(time.Hour).Format(time.RFC3339), created.Format(time.RFC3339))
+		}
+		// sb-unknown has no creation time, so its age is unknown.
+		fmt.Fprintf(w, `[%s,%s,{"id":"sb-unknown","status":{"state":"Running"}}]`, //nolint:errcheck
+			sb("sb-old", now.Add(-time.Hour)), sb("sb-new", now.Add(-time.Minute)))
+	})
+	mux.HandleFunc("DELETE /sandboxes/{id}", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		deleted = append(deleted, r.PathValue("id"))
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	cl := sandbox.New(srv.URL, "", srv.Client())
+	if err := CleanupOrphans(context.Background(), cl, 15*time.Minute); err != nil {
+		t.Fatalf("CleanupOrphans: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(deleted) != 1 || deleted[0] != "sb-old" {
+		t.Errorf("deleted = %v, want [sb-old]", deleted)
+	}
+}
+
+func TestOrphanMinAge(t *testing.T) {
+	cfg := &config.Config{MaxTimeout: 10 * time.Minute, TimeoutGracePeriod: 30 * time.Second}
+	// The longest run: the full timeout, the grace period, output
+	// collection and the final delete.
+	longest := cfg.MaxTimeout + cfg.TimeoutGracePeriod + collectTimeout + sandboxDeleteTimeout
+	if got := OrphanMinAge(cfg); got <= longest {
+		t.Errorf("OrphanMinAge = %s, want more than the longest run (%s)", got, longest)
+	}
+}
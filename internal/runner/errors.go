@@ -16,3 +16,10 @@ var ErrTimeout = errors.New("runner: execution timed out")
 
 // ErrSkillNotAvailable is returned when a skill exists but is not in 'available' status.
 var ErrSkillNotAvailable = errors.New("runner: skill not available")
+
+// ErrExecutionNotRunning is returned by Cancel when the execution is not
+// running on this runner.
+var ErrExecutionNotRunning = errors.New("runner: execution not running")
+
+// ErrCancelled is the cancellation cause of an execution stopped by Cancel.
+var ErrCancelled = errors.New("runner: execution cancelled")
@@ -13,12 +13,14 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/devs-group/skillbox/internal/artifacts"
 	"github.com/devs-group/skillbox/internal/config"
 	"github.com/devs-group/skillbox/internal/registry"
 	"github.com/devs-group/skillbox/internal/sandbox"
+	"github.com/devs-group/skillbox/internal/sandboxenv"
 	"github.com/devs-group/skillbox/internal/skill"
 	"github.com/devs-group/skillbox/internal/store"
 )
@@ -38,13 +40,14 @@ type RunRequest struct {
 // RunResult holds the outcome of a skill execution.
 type RunResult struct {
 	ExecutionID string          `json:"execution_id"`
-	Status      string          `json:"status"` // success, failed, timeout
+	Status      string          `json:"status"` // success, failed, timeout, cancelled
 	Output      json.RawMessage `json:"output,omitempty"`
 	FilesURL    string          `json:"files_url,omitempty"`
 	FilesList   []string        `json:"files_list,omitempty"`
 	Logs        string          `json:"logs,omitempty"`
 	DurationMs  int64           `json:"duration_ms"`
 	Error       *string         `json:"error"`
+	Partial     bool            `json:"partial,omitempty"` // output was collected after a timeout
 }
 
 // setError is a helper that sets the Error field on a RunResult from a plain string.
@@ -64,6 +67,15 @@ type Runner struct {
 	store     *store.Store
 	artifacts *artifacts.Collector
 	sem       chan struct{} // concurrency limiter
+
+	mu      sync.Mutex
+	running map[string]runningExecution // by execution ID
+}
+
+// runningExecution is an in-flight Run that Cancel can stop.
+type runningExecution struct {
+	tenantID string
+	cancel   context.CancelCauseFunc
 }
 
 // New creates a Runner with all required dependencies.
@@ -83,8 +95,10 @@ func New(cfg *config.Config, sb *sandbox.Client, reg *registry.Registry, st *sto
 // command execution, output collection, artifact uploading, and cleanup.
 //
 // The context controls the overall execution timeout. If the context is
-// cancelled or times out, the sandbox is deleted and the execution is
-// marked as "timeout".
+// cancelled or times out, the skill is sent SIGTERM and given the configured
+// grace period to flush its work; whatever it left in /sandbox/out is then
+// collected and flagged as partial before the sandbox is deleted and the
+// execution is marked as "timeout".
 func (r *Runner) Run(ctx context.Context, req RunRequest) (result *RunResult, err error) {
 	// Acquire a concurrency slot (blocks if all slots are in use).
 	select {
@@ -135,9 +149,23 @@ func (r *Runner) Run(ctx context.Context, req RunRequest) (result *RunResult, er
 		Status:      "failed",
 	}
 
+	// Let Cancel stop the execution. Cancelling ctx takes the same path as
+	// a timeout: the skill gets SIGTERM and its partial output is kept.
+	runCtx, cancelRun := context.WithCancelCause(ctx)
+	ctx = runCtx
+	r.track(executionID, req.TenantID, cancelRun)
+	defer func() {
+		r.untrack(executionID)
+		cancelRun(nil)
+	}()
+
 	// Ensure we always update the execution record in the database,
 	// even if we return early due to an error.
 	defer func() {
+		if errors.Is(context.Cause(runCtx), ErrCancelled) {
+			result.Status = "cancelled"
+			result.setError("execution cancelled")
+		}
 		now := time.Now()
 		result.DurationMs = now.Sub(startTime).Milliseconds()
 
@@ -150,6 +178,7 @@ func (r *Runner) Run(ctx context.Context, req RunRequest) (result *RunResult, er
 			FilesList:  result.FilesList,
 			DurationMs: result.DurationMs,
 			Error:      result.Error,
+			Partial:    result.Partial,
 			FinishedAt: &now,
 		}
 		if updateErr := r.store.UpdateExecution(context.Background(), updateExec); updateErr != nil {
@@ -169,6 +198,11 @@ func (r *Runner) Run(ctx context.Context, req RunRequest) (result *RunResult, er
 		}
 	}()
 
+	if loadedSkill.Skill.Kind == skill.KindTemplate {
+		result.setError("skill is a template and cannot be executed")
+		return result, nil
+	}
+
 	// Step 3: Validate image against allowlist.
 	image := loadedSkill.Skill.DefaultImage()
 	if err := ValidateImage(image, r.config.ImageAllowlist); err != nil {
@@ -176,37 +210,8 @@ func (r *Runner) Run(ctx context.Context, req RunRequest) (result *RunResult, er
 		return result, nil
 	}
 
-	// Determine resource limits. Use skill-level overrides or server defaults,
-	// clamped to server-side maximums to prevent resource exhaustion.
-	memoryStr := r.config.DefaultMemoryStr()
-	if loadedSkill.Skill.Resources.Memory != "" {
-		memoryStr = loadedSkill.Skill.Resources.Memory
-		// Clamp to MaxMemory if the skill requests more than allowed.
-		if requested, parseErr := config.ParseMemory(memoryStr); parseErr == nil && requested > r.config.MaxMemory {
-			slog.Warn("clamping skill memory to server maximum",
-				"skill", req.Skill, "requested", memoryStr,
-				"max_bytes", r.config.MaxMemory)
-			memoryStr = r.config.DefaultMemoryStr()
-		}
-	}
-
-	cpuStr := r.config.DefaultCPUStr()
-	if loadedSkill.Skill.Resources.CPU != "" {
-		cpuStr = loadedSkill.Skill.Resources.CPU
-		// Clamp to MaxCPU if the skill requests more than allowed.
-		if requested, parseErr := strconv.ParseFloat(cpuStr, 64); parseErr == nil && requested > r.config.MaxCPU {
-			slog.Warn("clamping skill CPU to server maximum",
-				"skill", req.Skill, "requested", cpuStr,
-				"max_cpu", r.config.MaxCPU)
-			cpuStr = r.config.DefaultCPUStr()
-		}
-	}
-
-	// Determine execution timeout.
-	timeout := r.config.DefaultTimeout
-	if loadedSkill.Skill.Timeout > 0 {
-		timeout = min(loadedSkill.Skill.Timeout, r.config.MaxTimeout)
-	}
+	// Determine resource limits and the execution timeout.
+	cpuStr, memoryStr, timeout := r.limits(loadedSkill.Skill)
 	execCtx, execCancel := context.WithTimeout(ctx, timeout)
 	defer execCancel()
 
@@ -217,20 +222,10 @@ func (r *Runner) Run(ctx context.Context, req RunRequest) (result *RunResult, er
 	}
 
 	// Step 5: Build environment variables, filtering blocked ones.
-	envVars := map[string]string{
-		"SANDBOX_INPUT":      string(inputJSON),
-		"SANDBOX_OUTPUT":     "/sandbox/out/output.json",
-		"SANDBOX_FILES_DIR":  "/sandbox/out/files/",
-		"SANDBOX_INPUT_DIR":  "/sandbox/input/",
-		"SKILL_INSTRUCTIONS": loadedSkill.Skill.Instructions,
-		"HOME":               "/tmp",
-	}
-	for k, v := range req.Env {
-		if isBlockedEnvVar(k) {
-			result.setError(fmt.Sprintf("env var %q is not allowed", k))
-			return result, nil
-		}
-		envVars[k] = v
+	envVars, envErr := sandboxenv.Env(sandboxenv.Sandbox, inputJSON, loadedSkill.Skill.Instructions, req.Env)
+	if envErr != nil {
+		result.setError(envErr.Error())
+		return result, nil
 	}
 
 	// Step 6: Create OpenSandbox sandbox.
@@ -272,7 +267,7 @@ func (r *Runner) Run(ctx context.Context, req RunRequest) (result *RunResult, er
 
 	// Ensure sandbox is always deleted on exit.
 	defer func() {
-		deleteCtx, deleteCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		deleteCtx, deleteCancel := context.WithTimeout(context.Background(), sandboxDeleteTimeout)
 		defer deleteCancel()
 		if deleteErr := r.sandbox.DeleteSandbox(deleteCtx, sandboxID); deleteErr != nil {
 			log.Printf("runner: failed to delete sandbox %s: %v", shortID(sandboxID), deleteErr)
@@ -403,7 +398,7 @@ func (r *Runner) Run(ctx context.Context, req RunRequest) (result *RunResult, er
 	// the LLM writes code using the skill's utilities, and the runner
 	// executes it.
 	if loadedSkill.Entrypoint == "" {
-		generatedEntry := generateCodeRunnerEntrypoint()
+		generatedEntry := sandboxenv.CodeRunnerEntrypoint()
 		if reuploadErr := r.sandbox.UploadFiles(execCtx, execdURL, []sandbox.FileUpload{{
 			Path:    "/sandbox/scripts/main.py",
 			Content: generatedEntry,
@@ -418,17 +413,34 @@ func (r *Runner) Run(ctx context.Context, req RunRequest) (result *RunResult, er
 		}
 	}
 	cmd := buildShellCommand(loadedSkill)
-	timeoutMs := int(timeout.Milliseconds())
+	// ExecD's own deadline includes the grace period so it does not kill
+	// the skill before the runner has had a chance to send SIGTERM.
+	timeoutMs := int((timeout + r.config.TimeoutGracePeriod).Milliseconds())
 
+	timedOut := false
 	cmdResult, runErr := r.sandbox.RunCommand(execCtx, execdURL, cmd, "/sandbox", timeoutMs)
 	if runErr != nil {
-		if execCtx.Err() != nil {
-			result.Status = "timeout"
-			result.setError(fmt.Sprintf("execution timed out after %s", timeout))
+		if execCtx.Err() == nil {
+			result.setError(fmt.Sprintf("running command in sandbox: %v", runErr))
 			return result, nil
 		}
-		result.setError(fmt.Sprintf("running command in sandbox: %v", runErr))
-		return result, nil
+		result.Status = "timeout"
+		result.setError(fmt.Sprintf("execution timed out after %s", timeout))
+		if r.config.TimeoutGracePeriod <= 0 {
+			return result, nil
+		}
+
+		// Give the skill a chance to checkpoint, then collect whatever it
+		// produced. execCtx has expired (and ctx may have too), so collection
+		// runs on a fresh context bounded by the grace period.
+		timedOut = true
+		graceCtx, graceCancel := context.WithTimeout(context.Background(), r.config.TimeoutGracePeriod+collectTimeout)
+		defer graceCancel()
+		terminateSkill(graceCtx, r.sandbox, execdURL, r.config.TimeoutGracePeriod, 250*time.Millisecond)
+		ctx, execCtx = graceCtx, graceCtx
+		if cmdResult == nil {
+			cmdResult = &sandbox.CommandResult{}
+		}
 	}
 
 	// Collect logs from stdout/stderr.
@@ -588,8 +600,11 @@ func (r *Runner) Run(ctx context.Context, req RunRequest) (result *RunResult, er
 		}
 	}
 
-	// Determine final status based on exit code.
-	if cmdResult.ExitCode == 0 {
+	// Determine final status based on exit code. A timed-out run stays
+	// "timeout"; anything collected during the grace period is partial.
+	if timedOut {
+		result.Partial = result.Output != nil || len(result.FilesList) > 0
+	} else if cmdResult.ExitCode == 0 {
 		result.Status = "success"
 	} else {
 		result.Status = "failed"
@@ -605,6 +620,42 @@ func (r *Runner) Run(ctx context.Context, req RunRequest) (result *RunResult, er
 	return result, nil
 }
 
+// limits returns the CPU, memory and timeout for running sk: the skill's
+// own settings, or server defaults, clamped to server-side maximums to
+// prevent resource exhaustion.
+func (r *Runner) limits(sk *skill.Skill) (cpuStr, memoryStr string, timeout time.Duration) {
+	memoryStr = r.config.DefaultMemoryStr()
+	if sk.Resources.Memory != "" {
+		memoryStr = sk.Resources.Memory
+		// Clamp to MaxMemory if the skill requests more than allowed.
+		if requested, parseErr := config.ParseMemory(memoryStr); parseErr == nil && requested > r.config.MaxMemory {
+			slog.Warn("clamping skill memory to server maximum",
+				"skill", sk.Name, "requested", memoryStr,
+				"max_bytes", r.config.MaxMemory)
+			memoryStr = r.config.DefaultMemoryStr()
+		}
+	}
+
+	cpuStr = r.config.DefaultCPUStr()
+	if sk.Resources.CPU != "" {
+		cpuStr = sk.Resources.CPU
+		// Clamp to MaxCPU if the skill requests more than allowed.
+		if requested, parseErr := strconv.ParseFloat(cpuStr, 64); parseErr == nil && requested > r.config.MaxCPU {
+			slog.Warn("clamping skill CPU to server maximum",
+				"skill", sk.Name, "requested", cpuStr,
+				"max_cpu", r.config.MaxCPU)
+			cpuStr = r.config.DefaultCPUStr()
+		}
+	}
+
+	// Determine execution timeout.
+	timeout = r.config.DefaultTimeout
+	if sk.Timeout > 0 {
+		timeout = min(sk.Timeout, r.config.MaxTimeout)
+	}
+	return cpuStr, memoryStr, timeout
+}
+
 // pollExecD polls the ExecD health endpoint at the given interval until it
 // responds successfully or the overall timeout is reached.
 func pollExecD(ctx context.Context, client *sandbox.Client, execdURL string, interval, timeout time.Duration) error {
@@ -769,116 +820,7 @@ func downloadArtifacts(ctx context.Context, client *sandbox.Client, execdURL str
 // buildShellCommand constructs the shell command string to run inside the
 // sandbox based on the skill's language and whether dependency files are present.
 func buildShellCommand(loaded *registry.LoadedSkill) string {
-	entrypoint := "/sandbox/scripts/" + loaded.Entrypoint
-	lang := loaded.Skill.Lang
-
-	switch lang {
-	case "python":
-		if loaded.HasRequirements {
-			return fmt.Sprintf(
-				"pip install --no-cache-dir -r /sandbox/scripts/requirements.txt -t /tmp/deps && PYTHONPATH=/tmp/deps python %s",
-				entrypoint,
-			)
-		}
-		return fmt.Sprintf("python %s", entrypoint)
-
-	case "node", "nodejs", "javascript":
-		return fmt.Sprintf("node %s", entrypoint)
-
-	case "bash":
-		return fmt.Sprintf("bash %s", entrypoint)
-
-	case "shell", "sh":
-		return fmt.Sprintf("sh %s", entrypoint)
-
-	default:
-		return entrypoint
-	}
-}
-
-// generateCodeRunnerEntrypoint returns a Python script that reads the LLM's
-// input from SANDBOX_INPUT, extracts any Python code block, and executes it.
-// This enables library-style skills (SKILL.md + core/*.py utilities, no
-// main.py) to work the same way as in Claude's web UI.
-func generateCodeRunnerEntrypoint() []byte {
-	// Built as concatenated strings because the Python code uses backtick
-	// characters (via chr(96)) for matching markdown code fences, and Go
-	// raw string literals cannot contain backticks.
-	script := "#!/usr/bin/env python3\n" +
-		"\"\"\"Auto-generated entrypoint for library-style skill.\"\"\"\n" +
-		"import json, os, re, sys, traceback\n" +
-		"\n" +
-		"sys.path.insert(0, \"/sandbox/scripts\")\n" +
-		"\n" +
-		"OUTPUT_DIR = os.environ.get(\"SANDBOX_FILES_DIR\", \"/sandbox/out/files\")\n" +
-		"os.makedirs(OUTPUT_DIR, exist_ok=True)\n" +
-		"\n" +
-		"raw = os.environ.get(\"SANDBOX_INPUT\", \"{}\")\n" +
-		"try:\n" +
-		"    data = json.loads(raw)\n" +
-		"    text = data.get(\"input\", \"\") if isinstance(data, dict) else str(data)\n" +
-		"except Exception:\n" +
-		"    text = raw\n" +
-		"\n" +
-		"bt = chr(96)\n" +
-		"fence3 = bt * 3\n" +
-		"code = None\n" +
-		"for pat in [fence3 + r\"python\\s*\\n(.*?)\" + fence3, fence3 + r\"\\s*\\n(.*?)\" + fence3]:\n" +
-		"    m = re.findall(pat, text, re.DOTALL)\n" +
-		"    if m:\n" +
-		"        code = m[-1].strip()\n" +
-		"        break\n" +
-		"\n" +
-		"if code is None and any(kw in text for kw in [\"import \", \"from \", \"def \", \"class \", \"print(\"]):\n" +
-		"    code = text.strip()\n" +
-		"\n" +
-		"if not code:\n" +
-		"    print(json.dumps({\"status\": \"error\", \"error\": \"No Python code found in input. Send a code block using the skill utilities.\"}))\n" +
-		"    sys.exit(0)\n" +
-		"\n" +
-		"# Redirect bare filenames to the output directory.\n" +
-		"for ext in [\".gif\", \".png\", \".jpg\", \".csv\", \".xlsx\", \".pdf\"]:\n" +
-		"    code = re.sub(\n" +
-		"        r\"(['\"])([^'\\\"\" + r\"\\/]+\" + re.escape(ext) + r\")(['\"])\",\n" +
-		"        lambda m: m.group(1) + OUTPUT_DIR + \"/\" + m.group(2) + m.group(3),\n" +
-		"        code,\n" +
-		"    )\n" +
-		"\n" +
-		"try:\n" +
-		"    exec(code, {\"__name__\": \"__main__\"})\n" +
-		"except Exception:\n" +
-		"    traceback.print_exc()\n" +
-		"    print(json.dumps({\"status\": \"error\", \"error\": traceback.format_exc()}))\n" +
-		"    sys.exit(0)\n" +
-		"\n" +
-		"files = [f for f in os.listdir(OUTPUT_DIR) if not f.startswith(\".\")]\n" +
-		"if files:\n" +
-		"    print(json.dumps({\"status\": \"success\", \"files\": files}))\n" +
-		"else:\n" +
-		"    print(json.dumps({\"status\": \"success\", \"message\": \"Code executed (no output files produced).\"}))\n"
-	return []byte(script)
-}
-
-// blockedEnvVars lists environment variable names that callers may not
-// override. These are either security-sensitive (e.g. LD_PRELOAD) or
-// reserved by the sandbox runtime (SANDBOX_*, SKILL_*).
-var blockedEnvVars = map[string]bool{
-	"PATH":            true,
-	"HOME":            true,
-	"LD_PRELOAD":      true,
-	"LD_LIBRARY_PATH": true,
-	"PYTHONPATH":      true,
-	"NODE_PATH":       true,
-	"NODE_OPTIONS":    true,
-}
-
-// isBlockedEnvVar returns true if the given key must not be set by callers.
-func isBlockedEnvVar(key string) bool {
-	if blockedEnvVars[key] {
-		return true
-	}
-	upper := strings.ToUpper(key)
-	return strings.HasPrefix(upper, "SANDBOX_") || strings.HasPrefix(upper, "SKILL_")
+	return sandboxenv.ShellCommand(sandboxenv.Sandbox, loaded.Skill.Lang, loaded.Entrypoint, loaded.HasRequirements)
 }
 
 // shortID returns the first 12 characters of an ID for log output.
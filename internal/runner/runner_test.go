@@ -251,64 +251,6 @@ func TestBuildUploadFiles_NonexistentDir(t *testing.T) {
 	}
 }
 
-// ---------------------------------------------------------------------------
-// isBlockedEnvVar
-// ---------------------------------------------------------------------------
-
-func TestIsBlockedEnvVar_ExactMatches(t *testing.T) {
-	blocked := []string{
-		"PATH", "HOME", "LD_PRELOAD", "LD_LIBRARY_PATH",
-		"PYTHONPATH", "NODE_PATH", "NODE_OPTIONS",
-	}
-	for _, key := range blocked {
-		t.Run(key, func(t *testing.T) {
-			if !isBlockedEnvVar(key) {
-				t.Errorf("isBlockedEnvVar(%q) = false, want true", key)
-			}
-		})
-	}
-}
-
-func TestIsBlockedEnvVar_Prefixes(t *testing.T) {
-	tests := []struct {
-		key  string
-		want bool
-	}{
-		{"SANDBOX_INPUT", true},
-		{"SANDBOX_OUTPUT", true},
-		{"sandbox_lower", true},  // case-insensitive prefix check
-		{"Sandbox_Mixed", true},  // case-insensitive prefix check
-		{"SKILL_INSTRUCTIONS", true},
-		{"SKILL_CUSTOM", true},
-		{"skill_lower", true},
-	}
-	for _, tt := range tests {
-		t.Run(tt.key, func(t *testing.T) {
-			got := isBlockedEnvVar(tt.key)
-			if got != tt.want {
-				t.Errorf("isBlockedEnvVar(%q) = %v, want %v", tt.key, got, tt.want)
-			}
-		})
-	}
-}
-
-func TestIsBlockedEnvVar_AllowedVars(t *testing.T) {
-	allowed := []string{
-		"MY_API_KEY",
-		"DATABASE_URL",
-		"CUSTOM_VAR",
-		"FOO",
-		"BAR_BAZ",
-	}
-	for _, key := range allowed {
-		t.Run(key, func(t *testing.T) {
-			if isBlockedEnvVar(key) {
-				t.Errorf("isBlockedEnvVar(%q) = true, want false", key)
-			}
-		})
-	}
-}
-
 // ---------------------------------------------------------------------------
 // shortID
 // ---------------------------------------------------------------------------
@@ -0,0 +1,194 @@
+package runner
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/devs-group/skillbox/internal/registry"
+	"github.com/devs-group/skillbox/internal/sandbox"
+	"github.com/devs-group/skillbox/internal/sandboxenv"
+	"github.com/devs-group/skillbox/internal/skilltest"
+)
+
+// RunTests runs the test cases declared under tests/ in a skill archive,
+// each in its own sandbox, and returns their results. It returns a nil
+// report when the archive declares no cases. The archive need not be
+// promoted; the scan worker calls this for versions awaiting approval.
+//
+// An error means the cases could not be run at all (unreadable archive,
+// malformed case, disallowed image); a failing case is reported in the
+// Report instead.
+func (r *Runner) RunTests(ctx context.Context, tenantID string, zipBytes []byte) (*skilltest.Report, error) {
+	zr, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+	if err != nil {
+		return nil, fmt.Errorf("opening skill archive: %w", err)
+	}
+	cases, err := skilltest.Load(zr)
+	if err != nil {
+		return nil, err
+	}
+	if len(cases) == 0 {
+		return nil, nil
+	}
+
+	loadedSkill, err := registry.LoadSkillZip(zipBytes)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if removeErr := os.RemoveAll(loadedSkill.Dir); removeErr != nil {
+			log.Printf("runner: failed to remove skill dir %s: %v", loadedSkill.Dir, removeErr)
+		}
+	}()
+	if err := ValidateImage(loadedSkill.Skill.DefaultImage(), r.config.ImageAllowlist); err != nil {
+		return nil, fmt.Errorf("image validation: %w", err)
+	}
+
+	report := &skilltest.Report{}
+	for i := range cases {
+		report.Add(r.runTestCase(ctx, tenantID, loadedSkill, &cases[i]))
+	}
+	return report, nil
+}
+
+// runTestCase executes one test case in a fresh sandbox the same way Run
+// executes a request, and evaluates its output.
+func (r *Runner) runTestCase(ctx context.Context, tenantID string, loadedSkill *registry.LoadedSkill, c *skilltest.Case) skilltest.Result {
+	select {
+	case r.sem <- struct{}{}:
+		defer func() { <-r.sem }()
+	case <-ctx.Done():
+		return skilltest.Evaluate(c, "failed", ctx.Err().Error(), nil, 0)
+	}
+
+	startTime := time.Now()
+	status, output, errMsg := r.execTestCase(ctx, tenantID, loadedSkill, c)
+	return skilltest.Evaluate(c, status, errMsg, output, time.Since(startTime).Milliseconds())
+}
+
+func (r *Runner) execTestCase(ctx context.Context, tenantID string, loadedSkill *registry.LoadedSkill, c *skilltest.Case) (status string, output json.RawMessage, errMsg string) {
+	cpuStr, memoryStr, timeout := r.limits(loadedSkill.Skill)
+	execCtx, execCancel := context.WithTimeout(ctx, timeout)
+	defer execCancel()
+
+	envVars, err := sandboxenv.Env(sandboxenv.Sandbox, c.Input, loadedSkill.Skill.Instructions, nil)
+	if err != nil {
+		return "failed", nil, err.Error()
+	}
+
+	sbResp, err := r.sandbox.CreateSandbox(execCtx, sandbox.SandboxOpts{
+		Image:      loadedSkill.Skill.DefaultImage(),
+		Entrypoint: []string{"tail", "-f", "/dev/null"},
+		Env:        envVars,
+		Metadata: map[string]string{
+			"managed-by": "skillbox",
+			"tenant":     tenantID,
+			"skill":      loadedSkill.Skill.Name,
+			"test-case":  c.Name,
+		},
+		ResourceLimits: map[string]string{
+			"cpu":    cpuStr,
+			"memory": memoryStr,
+		},
+		NetworkPolicy: &sandbox.NetworkPolicy{
+			DefaultAction: "deny",
+		},
+		Timeout: max(60, int(timeout.Seconds())+60),
+	})
+	if err != nil {
+		return "failed", nil, fmt.Sprintf("creating sandbox: %v", err)
+	}
+	defer func() {
+		deleteCtx, deleteCancel := context.WithTimeout(context.Background(), sandboxDeleteTimeout)
+		defer deleteCancel()
+		if deleteErr := r.sandbox.DeleteSandbox(deleteCtx, sbResp.ID); deleteErr != nil {
+			log.Printf("runner: failed to delete sandbox %s: %v", shortID(sbResp.ID), deleteErr)
+		}
+	}()
+
+	if _, err := r.sandbox.WaitReady(execCtx, sbResp.ID); err != nil {
+		return "failed", nil, fmt.Sprintf("waiting for sandbox to become ready: %v", err)
+	}
+	execdURL, _, err := r.sandbox.DiscoverExecD(execCtx, sbResp.ID)
+	if err != nil {
+		return "failed", nil, fmt.Sprintf("discovering execd endpoint: %v", err)
+	}
+	if err := pollExecD(execCtx, r.sandbox, execdURL, 200*time.Millisecond, 30*time.Second); err != nil {
+		return "failed", nil, fmt.Sprintf("waiting for execd to become ready: %v", err)
+	}
+
+	uploads, err := buildTestUploads(loadedSkill, c)
+	if err != nil {
+		return "failed", nil, fmt.Sprintf("preparing files for upload: %v", err)
+	}
+	if err := r.sandbox.UploadFiles(execCtx, execdURL, uploads); err != nil {
+		return "failed", nil, fmt.Sprintf("uploading files to sandbox: %v", err)
+	}
+
+	cmdResult, err := r.sandbox.RunCommand(execCtx, execdURL, buildShellCommand(loadedSkill), sandboxenv.Sandbox.Root, int(timeout.Milliseconds()))
+	if err != nil {
+		if execCtx.Err() != nil {
+			return "timeout", nil, fmt.Sprintf("execution timed out after %s", timeout)
+		}
+		return "failed", nil, fmt.Sprintf("running command in sandbox: %v", err)
+	}
+
+	if rc, err := r.sandbox.DownloadFile(execCtx, execdURL, sandboxenv.Sandbox.OutputFile()); err == nil {
+		data, readErr := io.ReadAll(io.LimitReader(rc, r.config.MaxOutputSize))
+		_ = rc.Close()
+		if readErr == nil && json.Valid(data) {
+			output = data
+		}
+	}
+
+	if cmdResult.ExitCode != 0 {
+		msg := fmt.Sprintf("command exited with code %d", cmdResult.ExitCode)
+		if cmdResult.Error != "" {
+			msg = cmdResult.Error
+		}
+		if stderr := strings.TrimSpace(cmdResult.Stderr); stderr != "" {
+			msg += ": " + truncateString(stderr, 1024)
+		}
+		return "failed", output, msg
+	}
+	return "success", output, ""
+}
+
+// buildTestUploads returns the files a test case's sandbox starts with:
+// the skill, the case's input and input files, and the generated
+// code-runner entrypoint for skills without one. It resolves the skill's
+// entrypoint and language as Run does.
+func buildTestUploads(loadedSkill *registry.LoadedSkill, c *skilltest.Case) ([]sandbox.FileUpload, error) {
+	uploads, err := buildUploadFiles(loadedSkill.Dir, c.Input)
+	if err != nil {
+		return nil, err
+	}
+	for name, content := range c.Files {
+		uploads = append(uploads, sandbox.FileUpload{
+			Path:    path.Join(sandboxenv.Sandbox.InputDir(), name),
+			Content: content,
+			Mode:    0o644,
+		})
+	}
+	if loadedSkill.Entrypoint == "" {
+		uploads = append(uploads, sandbox.FileUpload{
+			Path:    path.Join(sandboxenv.Sandbox.ScriptsDir(), "main.py"),
+			Content: sandboxenv.CodeRunnerEntrypoint(),
+			Mode:    0o755,
+		})
+		loadedSkill.Entrypoint = "main.py"
+		if loadedSkill.Skill.Lang == "" {
+			loadedSkill.Skill.Lang = "python"
+		}
+	}
+	return uploads, nil
+}
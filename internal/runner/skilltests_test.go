@@ -0,0 +1,75 @@
+package runner
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devs-group/skillbox/internal/registry"
+	"github.com/devs-group/skillbox/internal/skill"
+	"github.com/devs-group/skillbox/internal/skilltest"
+)
+
+func TestBuildTestUploads_InputFilesAndCodeRunner(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "util.py"), []byte("X = 1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loaded := &registry.LoadedSkill{Skill: &skill.Skill{}, Dir: dir}
+	c := &skilltest.Case{
+		Name:  "csv",
+		Input: json.RawMessage(`{"input":"go"}`),
+		Files: map[string][]byte{"data.csv": []byte("a,b\n"), "nested/x.txt": []byte("x")},
+	}
+
+	uploads, err := buildTestUploads(loaded, c)
+	if err != nil {
+		t.Fatalf("buildTestUploads: %v", err)
+	}
+	byPath := make(map[string][]byte)
+	for _, u := range uploads {
+		byPath[u.Path] = u.Content
+	}
+
+	for p, want := range map[string]string{
+		"/sandbox/input.json":         `{"input":"go"}`,
+		"/sandbox/input/data.csv":     "a,b\n",
+		"/sandbox/input/nested/x.txt": "x",
+		"/sandbox/scripts/util.py":    "X = 1",
+	} {
+		if got, ok := byPath[p]; !ok || string(got) != want {
+			t.Errorf("upload %s = %q (present %v), want %q", p, got, ok, want)
+		}
+	}
+	if _, ok := byPath["/sandbox/scripts/main.py"]; !ok {
+		t.Error("missing generated /sandbox/scripts/main.py")
+	}
+	if loaded.Entrypoint != "main.py" || loaded.Skill.Lang != "python" {
+		t.Errorf("entrypoint/lang = %q/%q, want main.py/python", loaded.Entrypoint, loaded.Skill.Lang)
+	}
+}
+
+func TestRunTests_NoCases(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("SKILL.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("---\nname: s\nversion: 1.0.0\ndescription: d\n---\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without test cases no sandbox is needed, so a bare Runner suffices.
+	report, err := (&Runner{}).RunTests(context.Background(), "t1", buf.Bytes())
+	if err != nil || report != nil {
+		t.Fatalf("RunTests = %v, %v; want nil report", report, err)
+	}
+}
@@ -0,0 +1,64 @@
+package runner
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/devs-group/skillbox/internal/sandbox"
+)
+
+// collectTimeout bounds output collection after a timed-out run. It is
+// added on top of the grace period so a slow MinIO upload cannot hold the
+// sandbox (and a concurrency slot) indefinitely.
+const collectTimeout = 60 * time.Second
+
+// signalTimeoutMs is the ExecD-side timeout for the short helper commands
+// used to signal and probe skill processes.
+const signalTimeoutMs = 10_000
+
+// skillProcsScript returns a shell script that runs action once for every
+// process whose command line references /sandbox/scripts/, with the PID in
+// $pid. It walks /proc directly because slim images ship without procps,
+// and splits the path literal so the script never matches its own shell.
+func skillProcsScript(action string) string {
+	return `for p in /proc/[0-9]*; do pid=${p#/proc/}; ` +
+		`case "$(tr '\0' ' ' < "$p/cmdline" 2>/dev/null)" in *"/sandbox/scr""ipts/"*) ` +
+		action + `;; esac; done`
+}
+
+// terminateSkill sends SIGTERM to the skill's processes and waits up to
+// grace for them to exit, probing every interval. Processes still alive
+// after the grace period are sent SIGKILL so output collection sees a
+// quiescent /sandbox/out.
+func terminateSkill(ctx context.Context, client *sandbox.Client, execdURL string, grace, interval time.Duration) {
+	term := skillProcsScript(`kill -TERM "$pid" 2>/dev/null`)
+	if _, err := client.RunCommand(ctx, execdURL, term, "/sandbox", signalTimeoutMs); err != nil {
+		log.Printf("runner: failed to send SIGTERM to skill: %v", err)
+		return
+	}
+
+	// The probe exits 0 while any skill process is alive and 1 once all are gone.
+	probe := skillProcsScript("exit 0") + "; exit 1"
+	deadline := time.After(grace)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-deadline:
+			kill := skillProcsScript(`kill -KILL "$pid" 2>/dev/null`)
+			if _, err := client.RunCommand(ctx, execdURL, kill, "/sandbox", signalTimeoutMs); err != nil {
+				log.Printf("runner: failed to send SIGKILL to skill: %v", err)
+			}
+			return
+		case <-ticker.C:
+			res, err := client.RunCommand(ctx, execdURL, probe, "/sandbox", signalTimeoutMs)
+			if err == nil && res.ExitCode != 0 {
+				return
+			}
+		}
+	}
+}
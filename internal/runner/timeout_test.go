@@ -0,0 +1,97 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devs-group/skillbox/internal/sandbox"
+)
+
+// fakeExecD records /command requests and answers each with the exit code
+// returned by exitCode.
+type fakeExecD struct {
+	mu       sync.Mutex
+	commands []string
+	exitCode func(cmd string) int
+}
+
+func (f *fakeExecD) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Command string `json:"command"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	f.mu.Lock()
+	f.commands = append(f.commands, body.Command)
+	f.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"type":"execution_complete","exitCode":%d}`+"\n\n", f.exitCode(body.Command)) //nolint:errcheck
+}
+
+func (f *fakeExecD) count(substr string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, c := range f.commands {
+		if strings.Contains(c, substr) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestSkillProcsScript_DoesNotMatchItself(t *testing.T) {
+	script := skillProcsScript("exit 0")
+	if strings.Contains(script, "/sandbox/scripts/") {
+		t.Errorf("script contains the literal it matches on and would find its own shell: %s", script)
+	}
+	if !strings.Contains(script, "exit 0") {
+		t.Errorf("script does not contain the action: %s", script)
+	}
+}
+
+func TestTerminateSkill_ExitsWithinGrace(t *testing.T) {
+	execd := &fakeExecD{exitCode: func(string) int { return 1 }} // probe: nothing alive
+	srv := httptest.NewServer(execd)
+	defer srv.Close() //nolint:errcheck
+
+	client := sandbox.New("http://unused", "", srv.Client())
+
+	start := time.Now()
+	terminateSkill(context.Background(), client, srv.URL, 5*time.Second, 20*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("terminateSkill waited %s, want early return once the skill exited", elapsed)
+	}
+	if n := execd.count("kill -TERM"); n != 1 {
+		t.Errorf("SIGTERM commands = %d, want 1", n)
+	}
+	if n := execd.count("kill -KILL"); n != 0 {
+		t.Errorf("SIGKILL commands = %d, want 0", n)
+	}
+}
+
+func TestTerminateSkill_KillsAfterGrace(t *testing.T) {
+	execd := &fakeExecD{exitCode: func(string) int { return 0 }} // probe: still alive
+	srv := httptest.NewServer(execd)
+	defer srv.Close() //nolint:errcheck
+
+	client := sandbox.New("http://unused", "", srv.Client())
+
+	start := time.Now()
+	terminateSkill(context.Background(), client, srv.URL, 150*time.Millisecond, 20*time.Millisecond)
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("terminateSkill returned after %s, want it to wait out the grace period", elapsed)
+	}
+	if n := execd.count("kill -TERM"); n != 1 {
+		t.Errorf("SIGTERM commands = %d, want 1", n)
+	}
+	if n := execd.count("kill -KILL"); n != 1 {
+		t.Errorf("SIGKILL commands = %d, want 1", n)
+	}
+}
@@ -305,7 +305,18 @@ func (c *Client) UploadFiles(ctx context.Context, execdURL string, files []FileU
 
 // RunCommand executes a command inside the sandbox. The SSE response uses
 // non-standard framing: raw JSON + "\n\n", optionally "data:"-prefixed.
+// If the stream breaks mid-command (e.g. ctx expires), the output received
+// so far is returned alongside the error.
 func (c *Client) RunCommand(ctx context.Context, execdURL, cmd, cwd string, timeout int) (*CommandResult, error) {
+	return c.StreamCommand(ctx, execdURL, cmd, cwd, timeout, nil)
+}
+
+// StreamCommand executes a command like RunCommand but writes stdout and
+// stderr to out as events arrive instead of buffering them, so the result's
+// Stdout and Stderr stay empty. It is used for long-lived commands whose
+// output must be relayed live (e.g. PTY shells). A nil out behaves exactly
+// like RunCommand.
+func (c *Client) StreamCommand(ctx context.Context, execdURL, cmd, cwd string, timeout int, out io.Writer) (*CommandResult, error) {
 	payload, _ := json.Marshal(cmdReqWire{Command: cmd, Cwd: cwd, Background: false, Timeout: timeout})
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, trimURL(execdURL)+"/command", bytes.NewReader(payload))
 	if err != nil {
@@ -320,7 +331,7 @@ func (c *Client) RunCommand(ctx context.Context, execdURL, cmd, cwd string, time
 	if resp.StatusCode != http.StatusOK {
 		return nil, c.errStatus("run command", resp)
 	}
-	return parseSSEStream(resp.Body)
+	return parseSSEStreamTee(resp.Body, out)
 }
 
 // DownloadFile retrieves a file from the sandbox. Caller must close the reader.
@@ -422,6 +433,13 @@ func trimURL(u string) string { return strings.TrimRight(u, "/") }
 // parseSSEStream reads the non-standard SSE stream from ExecD's /command
 // endpoint. Events are bare JSON or "data: {json}", separated by "\n\n".
 func parseSSEStream(r io.Reader) (*CommandResult, error) {
+	return parseSSEStreamTee(r, nil)
+}
+
+// parseSSEStreamTee is parseSSEStream with stdout/stderr payloads written
+// to tee as each event is decoded. When tee is nil they are buffered into
+// the result instead.
+func parseSSEStreamTee(r io.Reader, tee io.Writer) (*CommandResult, error) {
 	result := &CommandResult{}
 	var stdoutBuf, stderrBuf strings.Builder
 	scanner := bufio.NewScanner(r)
@@ -431,7 +449,7 @@ func parseSSEStream(r io.Reader) (*CommandResult, error) {
 		line := scanner.Text()
 		if line == "" {
 			if lineBuf.Len() > 0 {
-				applySSE(lineBuf.String(), result, &stdoutBuf, &stderrBuf)
+				applySSE(lineBuf.String(), result, &stdoutBuf, &stderrBuf, tee)
 				lineBuf.Reset()
 			}
 			continue
@@ -442,17 +460,17 @@ func parseSSEStream(r io.Reader) (*CommandResult, error) {
 		lineBuf.WriteString(line)
 	}
 	if lineBuf.Len() > 0 {
-		applySSE(lineBuf.String(), result, &stdoutBuf, &stderrBuf)
+		applySSE(lineBuf.String(), result, &stdoutBuf, &stderrBuf, tee)
 	}
+	result.Stdout = stdoutBuf.String()
+	result.Stderr = stderrBuf.String()
 	if err := scanner.Err(); err != nil {
 		return result, fmt.Errorf("opensandbox: reading command stream: %w", err)
 	}
-	result.Stdout = stdoutBuf.String()
-	result.Stderr = stderrBuf.String()
 	return result, nil
 }
 
-func applySSE(raw string, result *CommandResult, stdout, stderr *strings.Builder) {
+func applySSE(raw string, result *CommandResult, stdout, stderr *strings.Builder, tee io.Writer) {
 	data := strings.TrimSpace(raw)
 	if strings.HasPrefix(data, "data:") {
 		data = strings.TrimSpace(data[5:])
@@ -471,8 +489,16 @@ func applySSE(raw string, result *CommandResult, stdout, stderr *strings.Builder
 	}
 	switch ev.Type {
 	case "stdout":
+		if tee != nil {
+			_, _ = io.WriteString(tee, payload)
+			return
+		}
 		stdout.WriteString(payload)
 	case "stderr":
+		if tee != nil {
+			_, _ = io.WriteString(tee, payload)
+			return
+		}
 		stderr.WriteString(payload)
 	case "error":
 		result.Error = payload
@@ -11,6 +11,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 )
 
@@ -1107,6 +1108,28 @@ func TestParseSSEStream_MixedPrefixed(t *testing.T) {
 	}
 }
 
+func TestParseSSEStream_StreamErrorKeepsPartialOutput(t *testing.T) {
+	// A broken stream (e.g. the command context expired) must still surface
+	// what the command printed before the break.
+	input := io.MultiReader(
+		strings.NewReader(`{"type":"stdout","data":"step 1 done\n"}`+"\n\n"+`{"type":"stderr","data":"warn\n"}`+"\n\n"),
+		iotest.ErrReader(context.DeadlineExceeded),
+	)
+	result, err := parseSSEStream(input)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if result == nil {
+		t.Fatal("expected partial result, got nil")
+	}
+	if result.Stdout != "step 1 done\n" {
+		t.Errorf("Stdout = %q, want %q", result.Stdout, "step 1 done\n")
+	}
+	if result.Stderr != "warn\n" {
+		t.Errorf("Stderr = %q, want %q", result.Stderr, "warn\n")
+	}
+}
+
 // ---------------------------------------------------------------------------
 // parseTime
 // ---------------------------------------------------------------------------
@@ -0,0 +1,412 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidPatch is returned by ApplyPatch when the diff cannot be
+// parsed or targets something it cannot change.
+var ErrInvalidPatch = errors.New("session manager: invalid patch")
+
+// EditReplacement replaces an exact string in a file. OldString must
+// occur exactly once unless ReplaceAll is set.
+type EditReplacement struct {
+	OldString  string `json:"old_string"`
+	NewString  string `json:"new_string"`
+	ReplaceAll bool   `json:"replace_all,omitempty"`
+}
+
+// EditConflict explains why part of an edit could not be applied. Edit is
+// the 1-based index of the failed replacement and Hunk the 1-based index
+// of the failed diff hunk within its file; Line is where the hunk was
+// expected to apply.
+type EditConflict struct {
+	Path   string `json:"path"`
+	Edit   int    `json:"edit,omitempty"`
+	Hunk   int    `json:"hunk,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// EditConflictError is returned by EditFile and ApplyPatch when some edits
+// do not apply. Edits are all-or-nothing: no file has been changed.
+type EditConflictError struct {
+	Conflicts []EditConflict
+}
+
+func (e *EditConflictError) Error() string {
+	if len(e.Conflicts) == 1 {
+		c := e.Conflicts[0]
+		return fmt.Sprintf("session manager: edit conflict in %s: %s", c.Path, c.Reason)
+	}
+	return fmt.Sprintf("session manager: %d edit conflicts", len(e.Conflicts))
+}
+
+// FileEdit reports the changes made to one file.
+type FileEdit struct {
+	Path    string `json:"path"`
+	Created bool   `json:"created,omitempty"`
+	// Replacements counts replaced occurrences for EditFile; Hunks counts
+	// applied hunks for ApplyPatch.
+	Replacements int   `json:"replacements,omitempty"`
+	Hunks        int   `json:"hunks,omitempty"`
+	Size         int64 `json:"size"`
+}
+
+// EditFile applies exact string replacements, in order, to a file in the
+// managed sandbox identified by key. Each replacement sees the result of
+// the previous ones.
+func (sm *SessionManager) EditFile(ctx context.Context, key, filePath string, edits []EditReplacement) (*FileEdit, error) {
+	if err := ValidateSandboxPath(filePath); err != nil {
+		return nil, err
+	}
+	data, err := sm.ReadFile(ctx, key, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	content, n, conflicts := applyReplacements(string(data), edits)
+	if len(conflicts) > 0 {
+		for i := range conflicts {
+			conflicts[i].Path = filePath
+		}
+		return nil, &EditConflictError{Conflicts: conflicts}
+	}
+	if err := sm.WriteFile(ctx, key, filePath, content); err != nil {
+		return nil, err
+	}
+	return &FileEdit{Path: filePath, Replacements: n, Size: int64(len(content))}, nil
+}
+
+// ApplyPatch applies a unified diff to files in the managed sandbox
+// identified by key. File paths come from the diff's ---/+++ headers;
+// relative paths, including git's a/ and b/ prefixes, are resolved
+// against /sandbox/session. If filePath is set the diff must cover a
+// single file and is applied to filePath instead. Hunks whose line
+// numbers are off are applied where their context matches.
+func (sm *SessionManager) ApplyPatch(ctx context.Context, key, diff, filePath string) ([]FileEdit, error) {
+	patches, err := parseUnifiedDiff(diff)
+	if err != nil {
+		return nil, err
+	}
+	if filePath != "" {
+		if len(patches) != 1 {
+			return nil, fmt.Errorf("%w: path is set but the diff covers %d files", ErrInvalidPatch, len(patches))
+		}
+		patches[0].path = filePath
+	}
+	for _, p := range patches {
+		if err := ValidateSandboxPath(p.path); err != nil {
+			return nil, err
+		}
+	}
+
+	contents := make(map[string]string)
+	created := make(map[string]bool)
+	hunks := make(map[string]int)
+	var order []string
+	var conflicts []EditConflict
+	for _, p := range patches {
+		content, seen := contents[p.path]
+		if !seen {
+			data, err := sm.ReadFile(ctx, key, p.path)
+			switch {
+			case p.create && err == nil && len(data) > 0:
+				conflicts = append(conflicts, EditConflict{Path: p.path, Reason: "file already exists"})
+				continue
+			case p.create:
+				// ExecD does not distinguish a missing file from other
+				// download failures, so any error means "new file".
+				created[p.path] = true
+			case err != nil:
+				return nil, err
+			default:
+				content = string(data)
+			}
+			order = append(order, p.path)
+		}
+
+		updated, fileConflicts := applyHunks(content, p.hunks)
+		for i := range fileConflicts {
+			fileConflicts[i].Path = p.path
+		}
+		conflicts = append(conflicts, fileConflicts...)
+		contents[p.path] = updated
+		hunks[p.path] += len(p.hunks)
+	}
+	if len(conflicts) > 0 {
+		return nil, &EditConflictError{Conflicts: conflicts}
+	}
+
+	// One upload so a multi-file patch lands together or not at all.
+	uploads := make([]FileUpload, 0, len(order))
+	results := make([]FileEdit, 0, len(order))
+	for _, p := range order {
+		uploads = append(uploads, FileUpload{Path: p, Content: []byte(contents[p]), Mode: 0o644})
+		results = append(results, FileEdit{Path: p, Created: created[p], Hunks: hunks[p], Size: int64(len(contents[p]))})
+	}
+	if err := sm.UploadFiles(ctx, key, uploads); err != nil {
+		return nil, fmt.Errorf("session manager: apply patch: %w", err)
+	}
+	return results, nil
+}
+
+// applyReplacements applies edits to content in order. A replacement that
+// does not apply is reported and skipped.
+func applyReplacements(content string, edits []EditReplacement) (string, int, []EditConflict) {
+	var conflicts []EditConflict
+	total := 0
+	for i, e := range edits {
+		reason := ""
+		count := strings.Count(content, e.OldString)
+		switch {
+		case e.OldString == "":
+			reason = "old_string is empty"
+		case e.OldString == e.NewString:
+			reason = "old_string and new_string are identical"
+		case count == 0:
+			reason = "old_string not found"
+		case count > 1 && !e.ReplaceAll:
+			reason = fmt.Sprintf("old_string matches %d times; include more surrounding context or set replace_all", count)
+		}
+		if reason != "" {
+			conflicts = append(conflicts, EditConflict{Edit: i + 1, Reason: reason})
+			continue
+		}
+		content = strings.ReplaceAll(content, e.OldString, e.NewString)
+		total += count
+	}
+	return content, total, conflicts
+}
+
+// filePatch is the part of a unified diff that applies to one file.
+type filePatch struct {
+	path   string
+	create bool
+	hunks  []hunk
+}
+
+// hunk is one @@ section of a unified diff.
+type hunk struct {
+	oldStart int
+	lines    []hunkLine
+	// oldNoEOL and newNoEOL record "\ No newline at end of file" markers
+	// on the old and new side.
+	oldNoEOL, newNoEOL bool
+}
+
+// hunkLine is a hunk body line: op is ' ', '-' or '+'.
+type hunkLine struct {
+	op   byte
+	text string
+}
+
+// parseUnifiedDiff splits a unified diff into per-file patches. Hunk line
+// counts are not trusted, since hand-written and model-written diffs often
+// get them wrong; a hunk ends at the next hunk or file header.
+func parseUnifiedDiff(diff string) ([]filePatch, error) {
+	lines := strings.Split(strings.ReplaceAll(diff, "\r\n", "\n"), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	var patches []filePatch
+	var cur *filePatch
+	var h *hunk
+	var last byte
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ "):
+			p, err := newFilePatch(line[4:], lines[i+1][4:])
+			if err != nil {
+				return nil, err
+			}
+			patches = append(patches, p)
+			cur, h = &patches[len(patches)-1], nil
+			i++
+		case strings.HasPrefix(line, "@@"):
+			if cur == nil {
+				return nil, fmt.Errorf("%w: hunk before file header on line %d", ErrInvalidPatch, i+1)
+			}
+			start, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidPatch, i+1, err)
+			}
+			cur.hunks = append(cur.hunks, hunk{oldStart: start})
+			h = &cur.hunks[len(cur.hunks)-1]
+		case h == nil:
+			// Preamble such as "diff --git" or "index" lines.
+		case strings.HasPrefix(line, `\`):
+			if last == ' ' || last == '-' {
+				h.oldNoEOL = true
+			}
+			if last == ' ' || last == '+' {
+				h.newNoEOL = true
+			}
+		case strings.HasPrefix(line, "diff "):
+			h = nil
+		case line == "":
+			// Editors and models often strip the space from blank
+			// context lines.
+			h.lines = append(h.lines, hunkLine{op: ' '})
+			last = ' '
+		case line[0] == ' ' || line[0] == '-' || line[0] == '+':
+			h.lines = append(h.lines, hunkLine{op: line[0], text: line[1:]})
+			last = line[0]
+		default:
+			return nil, fmt.Errorf("%w: unexpected line %d: %q", ErrInvalidPatch, i+1, line)
+		}
+	}
+
+	if len(patches) == 0 {
+		return nil, fmt.Errorf("%w: no file headers found", ErrInvalidPatch)
+	}
+	for _, p := range patches {
+		if len(p.hunks) == 0 {
+			return nil, fmt.Errorf("%w: no hunks for %s", ErrInvalidPatch, p.path)
+		}
+	}
+	return patches, nil
+}
+
+// newFilePatch resolves the target of a diff from its --- and +++ header
+// values.
+func newFilePatch(oldName, newName string) (filePatch, error) {
+	oldName, newName = headerPath(oldName), headerPath(newName)
+	if newName == "/dev/null" {
+		return filePatch{}, fmt.Errorf("%w: deleting %s is not supported", ErrInvalidPatch, oldName)
+	}
+	if (strings.HasPrefix(oldName, "a/") || oldName == "/dev/null") && strings.HasPrefix(newName, "b/") {
+		newName = newName[2:]
+	}
+	p := filePatch{path: newName, create: oldName == "/dev/null"}
+	if !path.IsAbs(p.path) {
+		p.path = sessionWorkspace + "/" + p.path
+	}
+	p.path = path.Clean(p.path)
+	return p, nil
+}
+
+// headerPath strips the optional timestamp from a ---/+++ header value.
+func headerPath(s string) string {
+	if i := strings.IndexByte(s, '\t'); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
+
+// parseHunkHeader returns the old-side start line of "@@ -l,s +l,s @@".
+func parseHunkHeader(line string) (int, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || !strings.HasPrefix(fields[1], "-") {
+		return 0, fmt.Errorf("malformed hunk header %q", line)
+	}
+	start, _, _ := strings.Cut(fields[1][1:], ",")
+	n, err := strconv.Atoi(start)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("malformed hunk header %q", line)
+	}
+	return n, nil
+}
+
+// applyHunks applies hunks in order to content. Each hunk is placed at its
+// stated line, adjusted by the growth of earlier hunks, or failing that at
+// the nearest later position where its old lines match, first exactly and
+// then ignoring trailing whitespace.
+func applyHunks(content string, hunks []hunk) (string, []EditConflict) {
+	eol := content == "" || strings.HasSuffix(content, "\n")
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	if content == "" {
+		lines = nil
+	}
+
+	var conflicts []EditConflict
+	offset, floor := 0, 0
+	for i, h := range hunks {
+		var oldLines, newLines []string
+		for _, l := range h.lines {
+			if l.op != '+' {
+				oldLines = append(oldLines, l.text)
+			}
+			if l.op != '-' {
+				newLines = append(newLines, l.text)
+			}
+		}
+
+		want := max(h.oldStart-1, 0) + offset
+		if len(oldLines) == 0 && h.oldStart > 0 {
+			// Pure insertions name the line they follow.
+			want = h.oldStart + offset
+		}
+		at := findHunk(lines, oldLines, want, floor)
+		if at < 0 {
+			conflicts = append(conflicts, EditConflict{Hunk: i + 1, Line: h.oldStart, Reason: hunkMismatch(lines, oldLines, want)})
+			continue
+		}
+
+		lines = append(lines[:at], append(newLines, lines[at+len(oldLines):]...)...)
+		offset += len(newLines) - len(oldLines)
+		floor = at + len(newLines)
+		switch {
+		case h.newNoEOL:
+			eol = false
+		case h.oldNoEOL:
+			eol = true
+		}
+	}
+
+	out := strings.Join(lines, "\n")
+	if eol && len(lines) > 0 {
+		out += "\n"
+	}
+	return out, conflicts
+}
+
+// findHunk returns the index at which old occurs in lines, searching
+// outward from want but never before floor, or -1.
+func findHunk(lines, old []string, want, floor int) int {
+	want = max(min(want, len(lines)), floor)
+	for _, eq := range []func(a, b string) bool{
+		func(a, b string) bool { return a == b },
+		func(a, b string) bool { return strings.TrimRight(a, " \t\r") == strings.TrimRight(b, " \t\r") },
+	} {
+		for d := 0; want-d >= floor || want+d <= len(lines); d++ {
+			for _, at := range []int{want - d, want + d} {
+				if at >= floor && at+len(old) <= len(lines) && linesMatch(lines[at:at+len(old)], old, eq) {
+					return at
+				}
+			}
+		}
+	}
+	return -1
+}
+
+func linesMatch(a, b []string, eq func(a, b string) bool) bool {
+	for i := range b {
+		if !eq(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// hunkMismatch describes the first line where old differs from lines at
+// want.
+func hunkMismatch(lines, old []string, want int) string {
+	for i, o := range old {
+		n := want + i
+		if n >= len(lines) {
+			return fmt.Sprintf("context does not match: expected %q at line %d, found end of file", o, n+1)
+		}
+		if lines[n] != o {
+			return fmt.Sprintf("context does not match: expected %q at line %d, found %q", o, n+1, lines[n])
+		}
+	}
+	return "context does not match"
+}
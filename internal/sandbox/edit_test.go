@@ -0,0 +1,151 @@
+package sandbox
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestApplyReplacements(t *testing.T) {
+	content := "a := 1\nb := 1\nc := 2\n"
+
+	got, n, conflicts := applyReplacements(content, []EditReplacement{
+		{OldString: "c := 2", NewString: "c := 3"},
+		{OldString: ":= 1", NewString: "= 1", ReplaceAll: true},
+	})
+	if len(conflicts) != 0 {
+		t.Fatalf("conflicts = %+v", conflicts)
+	}
+	if got != "a = 1\nb = 1\nc := 3\n" || n != 3 {
+		t.Errorf("got %q with %d replacements", got, n)
+	}
+
+	_, _, conflicts = applyReplacements(content, []EditReplacement{
+		{OldString: ":= 1", NewString: "= 1"},
+		{OldString: "missing", NewString: "x"},
+		{OldString: "c := 2", NewString: "c := 2"},
+		{OldString: "", NewString: "x"},
+	})
+	if len(conflicts) != 4 {
+		t.Fatalf("got %d conflicts, want 4: %+v", len(conflicts), conflicts)
+	}
+	if conflicts[0].Edit != 1 || !strings.Contains(conflicts[0].Reason, "matches 2 times") {
+		t.Errorf("conflict[0] = %+v", conflicts[0])
+	}
+	if conflicts[1].Reason != "old_string not found" {
+		t.Errorf("conflict[1] = %+v", conflicts[1])
+	}
+}
+
+func TestParseUnifiedDiff(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+index 3b18e51..a042389 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ package main
+
+-var x = 1
++var x = 2
+--- /dev/null
++++ /sandbox/session/notes.txt	2025-06-01 12:00:00
+@@ -0,0 +1 @@
++hello
+\ No newline at end of file
+`
+	patches, err := parseUnifiedDiff(diff)
+	if err != nil {
+		t.Fatalf("parseUnifiedDiff: %v", err)
+	}
+	if len(patches) != 2 {
+		t.Fatalf("got %d patches, want 2", len(patches))
+	}
+	if p := patches[0]; p.path != "/sandbox/session/main.go" || p.create || len(p.hunks) != 1 || len(p.hunks[0].lines) != 4 {
+		t.Errorf("patch[0] = %+v", p)
+	}
+	if p := patches[1]; p.path != "/sandbox/session/notes.txt" || !p.create || !p.hunks[0].newNoEOL {
+		t.Errorf("patch[1] = %+v", p)
+	}
+
+	for name, bad := range map[string]string{
+		"no header": "@@ -1 +1 @@\n-a\n+b\n",
+		"no hunks":  "--- a/x\n+++ b/x\n",
+		"delete":    "--- a/x\n+++ /dev/null\n@@ -1 +0,0 @@\n-a\n",
+		"garbage":   "--- a/x\n+++ b/x\n@@ -1 +1 @@\n*a\n",
+	} {
+		if _, err := parseUnifiedDiff(bad); !errors.Is(err, ErrInvalidPatch) {
+			t.Errorf("%s: err = %v, want ErrInvalidPatch", name, err)
+		}
+	}
+}
+
+func TestApplyHunks(t *testing.T) {
+	content := "one\ntwo\nthree\nfour\nfive\nsix\n"
+
+	tests := []struct {
+		name string
+		diff string
+		want string
+	}{
+		{
+			name: "exact position",
+			diff: "@@ -2,2 +2,2 @@\n two\n-three\n+THREE\n",
+			want: "one\ntwo\nTHREE\nfour\nfive\nsix\n",
+		},
+		{
+			name: "wrong line numbers",
+			diff: "@@ -1,2 +1,2 @@\n-five\n+FIVE\n six\n",
+			want: "one\ntwo\nthree\nfour\nFIVE\nsix\n",
+		},
+		{
+			name: "earlier hunks shift later ones",
+			diff: "@@ -1,1 +1,2 @@\n one\n+one-and-a-half\n@@ -4,1 +5,1 @@\n-four\n+FOUR\n",
+			want: "one\none-and-a-half\ntwo\nthree\nFOUR\nfive\nsix\n",
+		},
+		{
+			name: "pure insertion",
+			diff: "@@ -6,0 +7 @@\n+seven\n",
+			want: "one\ntwo\nthree\nfour\nfive\nsix\nseven\n",
+		},
+		{
+			name: "trailing whitespace ignored",
+			diff: "@@ -1 +1 @@\n-one  \n+ONE\n",
+			want: "ONE\ntwo\nthree\nfour\nfive\nsix\n",
+		},
+		{
+			name: "drop final newline",
+			diff: "@@ -6 +6 @@\n-six\n+six\n\\ No newline at end of file\n",
+			want: "one\ntwo\nthree\nfour\nfive\nsix",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patches, err := parseUnifiedDiff("--- a/f\n+++ b/f\n" + tt.diff)
+			if err != nil {
+				t.Fatalf("parseUnifiedDiff: %v", err)
+			}
+			got, conflicts := applyHunks(content, patches[0].hunks)
+			if len(conflicts) != 0 {
+				t.Fatalf("conflicts = %+v", conflicts)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyHunks_ReportsConflicts(t *testing.T) {
+	patches, err := parseUnifiedDiff("--- a/f\n+++ b/f\n@@ -1 +1 @@\n-one\n+ONE\n@@ -3 +3 @@\n-tree\n+THREE\n")
+	if err != nil {
+		t.Fatalf("parseUnifiedDiff: %v", err)
+	}
+	_, conflicts := applyHunks("one\ntwo\nthree\n", patches[0].hunks)
+	if len(conflicts) != 1 {
+		t.Fatalf("got %d conflicts, want 1: %+v", len(conflicts), conflicts)
+	}
+	c := conflicts[0]
+	if c.Hunk != 2 || c.Line != 3 || !strings.Contains(c.Reason, `expected "tree" at line 3, found "three"`) {
+		t.Errorf("conflict = %+v", c)
+	}
+}
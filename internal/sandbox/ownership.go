@@ -0,0 +1,282 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+// orphanCleanupBatch bounds how many abandoned sandboxes one CleanupOrphans
+// pass takes over and deletes.
+const orphanCleanupBatch = 50
+
+// Session sandboxes are shared between server replicas through the
+// sandbox.session_sandboxes table. Any replica can attach to a recorded
+// sandbox by its ExecD URL and serve requests for it; exactly one replica
+// at a time holds the session's lease and with it the right to sync and
+// destroy the sandbox. Leases are renewed by RenewLeases and lapse when
+// their holder dies, after which the next replica to heartbeat the
+// session takes over.
+
+// attachExisting looks up the sandbox recorded for a session and, if it is
+// reachable, caches it locally and returns it. When the recorded sandbox is
+// unreachable its ID is returned so the caller can replace it; when there is
+// no record both results are empty.
+func (sm *SessionManager) attachExisting(ctx context.Context, key, tenantID, externalID string) (*ManagedSandbox, string, error) {
+	rec, err := sm.store.GetSessionSandbox(ctx, tenantID, externalID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("session manager: get session sandbox: %w", err)
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	if err := sm.client.Ping(pingCtx, rec.ExecDURL); err != nil {
+		slog.Warn("session manager: recorded sandbox unreachable, replacing",
+			"key", key,
+			"sandbox_id", rec.SandboxID,
+			"owner", rec.Owner,
+			"error", err,
+		)
+		return nil, rec.SandboxID, nil
+	}
+
+	held, err := sm.store.HeartbeatSessionSandbox(ctx, tenantID, externalID, rec.SandboxID,
+		sm.config.ReplicaID, time.Now(), sm.config.SessionLeaseTTL)
+	if errors.Is(err, store.ErrNotFound) {
+		// Replaced or removed since we read it; let the caller create one.
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("session manager: heartbeat session sandbox: %w", err)
+	}
+
+	ms := &ManagedSandbox{
+		SandboxID:  rec.SandboxID,
+		ExecDURL:   rec.ExecDURL,
+		SessionID:  rec.SessionID,
+		TenantID:   tenantID,
+		ExternalID: externalID,
+		CreatedAt:  rec.CreatedAt,
+		LastUsedAt: time.Now(),
+		Image:      rec.Image,
+		leased:     held,
+	}
+	sm.mu.Lock()
+	sm.sessions[key] = ms
+	sm.mu.Unlock()
+
+	slog.Info("session manager: attached to existing sandbox",
+		"key", key,
+		"sandbox_id", rec.SandboxID,
+		"lease_holder", held,
+	)
+	return ms, "", nil
+}
+
+// liveSandbox returns the session's running sandbox, attaching to one that
+// another replica started if necessary, or nil if the session has none.
+func (sm *SessionManager) liveSandbox(ctx context.Context, tenantID, externalID string) (*ManagedSandbox, error) {
+	key := sessionKey(tenantID, externalID)
+	sm.mu.Lock()
+	ms, ok := sm.sessions[key]
+	sm.mu.Unlock()
+	if ok {
+		return ms, nil
+	}
+	ms, _, err := sm.attachExisting(ctx, key, tenantID, externalID)
+	return ms, err
+}
+
+// recordSandbox stores ms as the session's sandbox with this replica as
+// lease holder. With an empty staleSandboxID it only succeeds if the session
+// has no record yet; otherwise it only succeeds if the record still points
+// at the stale sandbox. A false result means another replica got there first.
+func (sm *SessionManager) recordSandbox(ctx context.Context, ms *ManagedSandbox, staleSandboxID string) (bool, error) {
+	rec := &store.SessionSandbox{
+		TenantID:   ms.TenantID,
+		ExternalID: ms.ExternalID,
+		SessionID:  ms.SessionID,
+		SandboxID:  ms.SandboxID,
+		ExecDURL:   ms.ExecDURL,
+		Image:      ms.Image,
+		Owner:      sm.config.ReplicaID,
+	}
+	var (
+		ok  bool
+		err error
+	)
+	if staleSandboxID == "" {
+		ok, err = sm.store.InsertSessionSandbox(ctx, rec, sm.config.SessionLeaseTTL)
+	} else {
+		ok, err = sm.store.ReplaceSessionSandbox(ctx, rec, staleSandboxID, sm.config.SessionLeaseTTL)
+	}
+	if err != nil {
+		return false, fmt.Errorf("session manager: record session sandbox: %w", err)
+	}
+	return ok, nil
+}
+
+// RenewLeases heartbeats every locally cached sandbox: it publishes local
+// usage, renews leases this replica holds and takes over leases whose
+// holder has lapsed. Sandboxes that another replica has replaced are
+// dropped from the local map. Call it well within SessionLeaseTTL.
+func (sm *SessionManager) RenewLeases(ctx context.Context) {
+	type entry struct {
+		key      string
+		ms       *ManagedSandbox
+		lastUsed time.Time
+		leased   bool
+	}
+	sm.mu.Lock()
+	entries := make([]entry, 0, len(sm.sessions))
+	for key, ms := range sm.sessions {
+		entries = append(entries, entry{key: key, ms: ms, lastUsed: ms.LastUsedAt, leased: ms.leased})
+	}
+	sm.mu.Unlock()
+
+	for _, e := range entries {
+		held, err := sm.store.HeartbeatSessionSandbox(ctx, e.ms.TenantID, e.ms.ExternalID, e.ms.SandboxID,
+			sm.config.ReplicaID, e.lastUsed, sm.config.SessionLeaseTTL)
+		if errors.Is(err, store.ErrNotFound) {
+			slog.Info("session manager: sandbox replaced or removed by another replica",
+				"key", e.key,
+				"sandbox_id", e.ms.SandboxID,
+			)
+			sm.forget(e.key, e.ms.SandboxID)
+			continue
+		}
+		if err != nil {
+			slog.Warn("session manager: lease heartbeat failed",
+				"key", e.key,
+				"error", err,
+			)
+			continue
+		}
+		if held != e.leased {
+			slog.Info("session manager: session lease changed",
+				"key", e.key,
+				"sandbox_id", e.ms.SandboxID,
+				"lease_holder", held,
+			)
+		}
+		sm.mu.Lock()
+		e.ms.leased = held
+		sm.mu.Unlock()
+	}
+}
+
+// releaseIdle handles one sandbox that has been idle locally for maxIdle
+// and reports whether it was deleted.
+func (sm *SessionManager) releaseIdle(ctx context.Context, key string, maxIdle time.Duration) bool {
+	sm.mu.Lock()
+	ms, ok := sm.sessions[key]
+	leased := ok && ms.leased
+	sm.mu.Unlock()
+	if !ok {
+		return false
+	}
+	if !leased {
+		sm.forget(key, ms.SandboxID)
+		return false
+	}
+
+	rec, err := sm.store.GetSessionSandbox(ctx, ms.TenantID, ms.ExternalID)
+	switch {
+	case errors.Is(err, store.ErrNotFound) || (err == nil && rec.SandboxID != ms.SandboxID):
+		sm.forget(key, ms.SandboxID)
+		return false
+	case err != nil:
+		slog.Warn("session manager: cleanup lookup failed", "key", key, "error", err)
+		return false
+	case time.Since(rec.LastUsedAt) <= maxIdle:
+		// Still in use through another replica: hand the lease over.
+		if err := sm.store.ReleaseSessionSandboxLease(ctx, ms.TenantID, ms.ExternalID, sm.config.ReplicaID); err != nil {
+			slog.Warn("session manager: cleanup lease release failed", "key", key, "error", err)
+			return false
+		}
+		sm.forget(key, ms.SandboxID)
+		return false
+	}
+
+	slog.Info("session manager: cleaning up idle sandbox", "key", key)
+	sm.syncAndRemove(ctx, key, "cleanup")
+	if err := sm.store.DeleteSessionSandbox(ctx, ms.TenantID, ms.ExternalID, ms.SandboxID); err != nil {
+		slog.Warn("session manager: cleanup delete sandbox record failed", "key", key, "error", err)
+	}
+	return true
+}
+
+// CleanupOrphans takes over idle sandboxes whose lease holder is gone,
+// syncs their files and deletes them. It returns how many were deleted.
+// Claiming goes through the lease, so concurrent calls on several replicas
+// are safe, but it only needs to run on the replica holding the cleanup
+// role.
+func (sm *SessionManager) CleanupOrphans(ctx context.Context, maxIdle time.Duration) int {
+	orphans, err := sm.store.ListOrphanedSessionSandboxes(ctx, time.Now().Add(-maxIdle), orphanCleanupBatch)
+	if err != nil {
+		slog.Warn("session manager: list orphaned sandboxes failed", "error", err)
+		return 0
+	}
+
+	cleaned := 0
+	for _, rec := range orphans {
+		key := sessionKey(rec.TenantID, rec.ExternalID)
+		sm.mu.Lock()
+		_, local := sm.sessions[key]
+		sm.mu.Unlock()
+		if local {
+			// Cached here, so RenewLeases will adopt it; local idle
+			// tracking decides its fate.
+			continue
+		}
+
+		// A zero lastUsed leaves last_used_at untouched while claiming.
+		held, err := sm.store.HeartbeatSessionSandbox(ctx, rec.TenantID, rec.ExternalID, rec.SandboxID,
+			sm.config.ReplicaID, time.Time{}, sm.config.SessionLeaseTTL)
+		if err != nil || !held {
+			continue
+		}
+
+		slog.Info("session manager: cleaning up orphaned sandbox",
+			"key", key,
+			"sandbox_id", rec.SandboxID,
+			"previous_owner", rec.Owner,
+		)
+		sm.mu.Lock()
+		sm.sessions[key] = &ManagedSandbox{
+			SandboxID:  rec.SandboxID,
+			ExecDURL:   rec.ExecDURL,
+			SessionID:  rec.SessionID,
+			TenantID:   rec.TenantID,
+			ExternalID: rec.ExternalID,
+			CreatedAt:  rec.CreatedAt,
+			LastUsedAt: rec.LastUsedAt,
+			Image:      rec.Image,
+			leased:     true,
+		}
+		sm.mu.Unlock()
+		sm.syncAndRemove(ctx, key, "orphan cleanup")
+		if err := sm.store.DeleteSessionSandbox(ctx, rec.TenantID, rec.ExternalID, rec.SandboxID); err != nil {
+			slog.Warn("session manager: orphan cleanup delete sandbox record failed", "key", key, "error", err)
+		}
+		cleaned++
+	}
+	return cleaned
+}
+
+// forget drops a sandbox from the local map without touching the sandbox
+// itself, unless the entry has since been replaced by a different sandbox.
+func (sm *SessionManager) forget(key, sandboxID string) {
+	sm.mu.Lock()
+	if ms, ok := sm.sessions[key]; ok && ms.SandboxID == sandboxID {
+		delete(sm.sessions, key)
+	}
+	sm.mu.Unlock()
+}
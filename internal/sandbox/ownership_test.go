@@ -0,0 +1,151 @@
+package sandbox
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/devs-group/skillbox/internal/config"
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+var sessionSandboxColumnNames = []string{
+	"tenant_id", "external_id", "session_id", "sandbox_id", "execd_url", "image",
+	"owner", "lease_expires_at", "created_at", "last_used_at",
+}
+
+// newOwnershipTestManager returns a SessionManager backed by sqlmock whose
+// OpenSandbox and ExecD calls go to handler.
+func newOwnershipTestManager(t *testing.T, handler http.Handler) (*SessionManager, sqlmock.Sqlmock, *httptest.Server) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	cfg := &config.Config{ReplicaID: "replica-b", SessionLeaseTTL: 30 * time.Second}
+	sm := NewSessionManager(New(srv.URL, "", srv.Client()), store.NewWithDB(db), nil, cfg)
+	return sm, mock, srv
+}
+
+func TestAttachExisting_UsesRecordedSandbox(t *testing.T) {
+	sm, mock, srv := newOwnershipTestManager(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ping" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT tenant_id, external_id, session_id, sandbox_id").
+		WithArgs("tenant-1", "ext-1").
+		WillReturnRows(sqlmock.NewRows(sessionSandboxColumnNames).
+			AddRow("tenant-1", "ext-1", "sess-1", "sb-1", srv.URL, "python:3.12",
+				"replica-a", now.Add(20*time.Second), now, now))
+	mock.ExpectQuery("UPDATE sandbox.session_sandboxes").
+		WithArgs("tenant-1", "ext-1", "sb-1", "replica-b", sqlmock.AnyArg(), int64(30000)).
+		WillReturnRows(sqlmock.NewRows([]string{"held"}).AddRow(false))
+
+	key := sessionKey("tenant-1", "ext-1")
+	ms, stale, err := sm.attachExisting(context.Background(), key, "tenant-1", "ext-1")
+	if err != nil {
+		t.Fatalf("attachExisting: %v", err)
+	}
+	if stale != "" {
+		t.Errorf("stale = %q, want empty", stale)
+	}
+	if ms == nil || ms.SandboxID != "sb-1" || ms.ExecDURL != srv.URL {
+		t.Fatalf("ms = %+v, want sandbox sb-1 at %s", ms, srv.URL)
+	}
+	if ms.leased {
+		t.Error("leased = true, want false while replica-a holds the lease")
+	}
+	if sm.sessions[key] != ms {
+		t.Error("attached sandbox was not cached")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAttachExisting_UnreachableReturnsStaleID(t *testing.T) {
+	sm, mock, srv := newOwnershipTestManager(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT tenant_id, external_id, session_id, sandbox_id").
+		WithArgs("tenant-1", "ext-1").
+		WillReturnRows(sqlmock.NewRows(sessionSandboxColumnNames).
+			AddRow("tenant-1", "ext-1", "sess-1", "sb-dead", srv.URL, "python:3.12",
+				"replica-a", now, now, now))
+
+	ms, stale, err := sm.attachExisting(context.Background(), sessionKey("tenant-1", "ext-1"), "tenant-1", "ext-1")
+	if err != nil {
+		t.Fatalf("attachExisting: %v", err)
+	}
+	if ms != nil {
+		t.Errorf("ms = %+v, want nil", ms)
+	}
+	if stale != "sb-dead" {
+		t.Errorf("stale = %q, want sb-dead", stale)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRenewLeases_ForgetsReplacedSandbox(t *testing.T) {
+	sm, mock, _ := newOwnershipTestManager(t, http.NotFoundHandler())
+
+	sm.sessions["tenant-1:ext-1"] = &ManagedSandbox{SandboxID: "sb-old", TenantID: "tenant-1", ExternalID: "ext-1", leased: true}
+	sm.sessions["tenant-1:ext-2"] = &ManagedSandbox{SandboxID: "sb-2", TenantID: "tenant-1", ExternalID: "ext-2"}
+
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery("UPDATE sandbox.session_sandboxes").
+		WithArgs("tenant-1", "ext-1", "sb-old", "replica-b", sqlmock.AnyArg(), int64(30000)).
+		WillReturnRows(sqlmock.NewRows([]string{"held"}))
+	mock.ExpectQuery("UPDATE sandbox.session_sandboxes").
+		WithArgs("tenant-1", "ext-2", "sb-2", "replica-b", sqlmock.AnyArg(), int64(30000)).
+		WillReturnRows(sqlmock.NewRows([]string{"held"}).AddRow(true))
+
+	sm.RenewLeases(context.Background())
+
+	if _, ok := sm.sessions["tenant-1:ext-1"]; ok {
+		t.Error("replaced sandbox still cached")
+	}
+	if ms, ok := sm.sessions["tenant-1:ext-2"]; !ok || !ms.leased {
+		t.Error("expired lease was not taken over")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestShutdown_LeavesUnleasedSandboxRunning(t *testing.T) {
+	sm, mock, _ := newOwnershipTestManager(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+
+	sm.sessions["tenant-1:ext-1"] = &ManagedSandbox{SandboxID: "sb-1", TenantID: "tenant-1", ExternalID: "ext-1"}
+
+	sm.Shutdown(context.Background())
+
+	if len(sm.sessions) != 0 {
+		t.Errorf("sessions = %d, want 0", len(sm.sessions))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,441 @@
+package sandbox
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// processRoot holds one directory per background process inside the
+// sandbox: the command, its combined output, its PID and, once it has
+// finished, its exit code. Keeping this state in the sandbox rather than
+// in memory lets any replica serving the session manage its processes.
+// Like ptyRoot it lives outside /sandbox/session so it is never synced.
+const processRoot = "/tmp/skillbox-procs"
+
+// processHelperTimeout bounds the short ExecD commands used to start,
+// inspect and signal background processes.
+const processHelperTimeout = 15 * time.Second
+
+// processPollInterval is how often WaitProcess checks whether a process
+// has exited.
+const processPollInterval = 500 * time.Millisecond
+
+// stopGracePeriod is how long StopProcesses waits after SIGTERM before
+// sending SIGKILL.
+const stopGracePeriod = 2 * time.Second
+
+const (
+	// DefaultProcessOutputLimit is the number of output bytes
+	// ProcessOutput returns when no limit is given.
+	DefaultProcessOutputLimit = 64 << 10
+	// MaxProcessOutputLimit caps the bytes returned by one ProcessOutput
+	// call.
+	MaxProcessOutputLimit = 1 << 20
+)
+
+var (
+	// ErrProcessNotFound is returned when a process ID does not exist in
+	// the session's sandbox.
+	ErrProcessNotFound = errors.New("session manager: process not found")
+	// ErrProcessExited is returned by SignalProcess when the process has
+	// already finished.
+	ErrProcessExited = errors.New("session manager: process already exited")
+	// ErrInvalidSignal is returned by SignalProcess for signals outside
+	// processSignals.
+	ErrInvalidSignal = errors.New("session manager: unsupported signal")
+)
+
+// processSignals lists the signals callers may send to a process.
+var processSignals = map[string]bool{
+	"HUP": true, "INT": true, "QUIT": true, "KILL": true, "TERM": true,
+	"USR1": true, "USR2": true, "STOP": true, "CONT": true,
+}
+
+// ProcessState describes the lifecycle of a background process.
+type ProcessState string
+
+const (
+	// ProcessRunning means the process has not exited yet.
+	ProcessRunning ProcessState = "running"
+	// ProcessExited means the process finished and its exit code is known.
+	ProcessExited ProcessState = "exited"
+	// ProcessKilled means the process is gone without a recorded exit
+	// code, e.g. after SIGKILL.
+	ProcessKilled ProcessState = "killed"
+)
+
+// Process describes a background process started with StartProcess.
+type Process struct {
+	ID         string       `json:"id"`
+	Command    string       `json:"command"`
+	WorkDir    string       `json:"workdir"`
+	PID        int          `json:"pid"`
+	State      ProcessState `json:"state"`
+	ExitCode   *int         `json:"exit_code,omitempty"`
+	OutputSize int64        `json:"output_size"`
+	StartedAt  time.Time    `json:"started_at"`
+}
+
+// ProcessOutput is a chunk of a process's combined stdout and stderr.
+type ProcessOutput struct {
+	Data string `json:"data"`
+	// Offset is the byte offset just past Data; pass it to the next
+	// ProcessOutput call to continue where this one stopped.
+	Offset int64        `json:"offset"`
+	State  ProcessState `json:"state"`
+	// ExitCode is set once the process has exited.
+	ExitCode *int `json:"exit_code,omitempty"`
+	// Done reports that the process has finished and all of its output
+	// has been read.
+	Done bool `json:"done"`
+}
+
+// processMeta is written next to each process when it starts. It is
+// stored base64-encoded so a listing can print it as a single token.
+type processMeta struct {
+	Command   string    `json:"command"`
+	WorkDir   string    `json:"workdir"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// StartProcess starts command detached in the managed sandbox identified
+// by key and returns without waiting for it to finish. stdout and stderr
+// are captured together and can be read with ProcessOutput.
+func (sm *SessionManager) StartProcess(ctx context.Context, key, command, workdir string) (*Process, error) {
+	if err := ValidateSandboxPath(workdir); err != nil {
+		return nil, err
+	}
+
+	id := uuid.New().String()
+	dir := processRoot + "/" + id
+	meta := processMeta{Command: command, WorkDir: workdir, StartedAt: time.Now().UTC()}
+	metaJSON, _ := json.Marshal(meta)
+
+	if err := sm.UploadFiles(ctx, key, []FileUpload{
+		{Path: dir + "/cmd.sh", Content: []byte(command), Mode: 0o644},
+		{Path: dir + "/run.sh", Content: processRunScript(dir, workdir), Mode: 0o755},
+		{Path: dir + "/meta", Content: []byte(base64.StdEncoding.EncodeToString(metaJSON)), Mode: 0o644},
+	}); err != nil {
+		return nil, fmt.Errorf("session manager: start process: %w", err)
+	}
+
+	res, err := sm.processHelper(ctx, key, processStartCommand(dir))
+	if err != nil {
+		return nil, fmt.Errorf("session manager: start process: %w", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(res.Stdout))
+	if err != nil {
+		return nil, fmt.Errorf("session manager: start process: no pid reported: %s", strings.TrimSpace(res.Stderr))
+	}
+
+	slog.Info("session manager: process started",
+		"key", key,
+		"process_id", id,
+		"pid", pid,
+	)
+	return &Process{
+		ID:        id,
+		Command:   command,
+		WorkDir:   workdir,
+		PID:       pid,
+		State:     ProcessRunning,
+		StartedAt: meta.StartedAt,
+	}, nil
+}
+
+// ListProcesses returns every background process started in the session,
+// oldest first, including those that have already exited.
+func (sm *SessionManager) ListProcesses(ctx context.Context, key string) ([]*Process, error) {
+	res, err := sm.processHelper(ctx, key, fmt.Sprintf(`for d in %s/*/; do %s; done; exit 0`, processRoot, processStatusSnippet(`"$d"`)))
+	if err != nil {
+		return nil, fmt.Errorf("session manager: list processes: %w", err)
+	}
+	procs, err := parseProcessRecords(res.Stdout)
+	if err != nil {
+		return nil, fmt.Errorf("session manager: list processes: %w", err)
+	}
+	sort.Slice(procs, func(i, j int) bool { return procs[i].StartedAt.Before(procs[j].StartedAt) })
+	return procs, nil
+}
+
+// GetProcess returns the current state of one background process.
+func (sm *SessionManager) GetProcess(ctx context.Context, key, id string) (*Process, error) {
+	dir, err := processDir(id)
+	if err != nil {
+		return nil, err
+	}
+	res, err := sm.processHelper(ctx, key, fmt.Sprintf(`d=%s; [ -f "$d/pid" ] || exit 3; %s`, dir, processStatusSnippet(`"$d"`)))
+	if err != nil {
+		return nil, fmt.Errorf("session manager: get process: %w", err)
+	}
+	if res.ExitCode == 3 {
+		return nil, ErrProcessNotFound
+	}
+	procs, err := parseProcessRecords(res.Stdout)
+	if err != nil {
+		return nil, fmt.Errorf("session manager: get process: %w", err)
+	}
+	if len(procs) != 1 {
+		return nil, ErrProcessNotFound
+	}
+	return procs[0], nil
+}
+
+// ProcessOutput returns up to limit bytes of the process's output starting
+// at byte offset. Callers tail a process by passing the returned Offset
+// back in until Done is set.
+func (sm *SessionManager) ProcessOutput(ctx context.Context, key, id string, offset int64, limit int) (*ProcessOutput, error) {
+	dir, err := processDir(id)
+	if err != nil {
+		return nil, err
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = DefaultProcessOutputLimit
+	}
+	// Room for at least one whole UTF-8 sequence; see trimPartialRune.
+	limit = max(min(limit, MaxProcessOutputLimit), utf8.UTFMax)
+
+	// The header ends in '|', which base64 never produces, so it can be
+	// split off reliably however ExecD frames the output.
+	cmd := fmt.Sprintf(`d=%s; [ -f "$d/pid" ] || exit 3; `+
+		`code=$(cat "$d/exit" 2>/dev/null); alive=0; kill -0 "$(cat "$d/pid")" 2>/dev/null && alive=1; `+
+		`printf '%%s %%s %%s|' "$(wc -c < "$d/out")" "${code:--}" "$alive"; `+
+		`tail -c +%d "$d/out" | head -c %d | base64`,
+		dir, offset+1, limit)
+	res, err := sm.processHelper(ctx, key, cmd)
+	if err != nil {
+		return nil, fmt.Errorf("session manager: process output: %w", err)
+	}
+	if res.ExitCode == 3 {
+		return nil, ErrProcessNotFound
+	}
+	return parseProcessOutput(res.Stdout, offset)
+}
+
+// SignalProcess sends signal (e.g. "TERM" or "SIGINT") to the process and
+// everything it started.
+func (sm *SessionManager) SignalProcess(ctx context.Context, key, id, signal string) error {
+	dir, err := processDir(id)
+	if err != nil {
+		return err
+	}
+	sig := strings.TrimPrefix(strings.ToUpper(signal), "SIG")
+	if !processSignals[sig] {
+		return fmt.Errorf("%w: %q", ErrInvalidSignal, signal)
+	}
+
+	res, err := sm.processHelper(ctx, key, fmt.Sprintf(`[ -f %[1]s/pid ] || exit 3; [ -f %[1]s/exit ] && exit 4; kill -s %[2]s -- -"$(cat %[1]s/pid)"`, dir, sig))
+	if err != nil {
+		return fmt.Errorf("session manager: signal process: %w", err)
+	}
+	switch res.ExitCode {
+	case 0:
+		return nil
+	case 3:
+		return ErrProcessNotFound
+	case 4:
+		return ErrProcessExited
+	default:
+		return fmt.Errorf("session manager: signal process: kill exited with code %d: %s", res.ExitCode, strings.TrimSpace(res.Stderr))
+	}
+}
+
+// WaitProcess blocks until the process has exited or timeout elapses and
+// returns its latest state. A process still running at the timeout is
+// returned with State ProcessRunning rather than an error.
+func (sm *SessionManager) WaitProcess(ctx context.Context, key, id string, timeout time.Duration) (*Process, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		p, err := sm.GetProcess(ctx, key, id)
+		if err != nil {
+			return nil, err
+		}
+		if p.State != ProcessRunning || !time.Now().Before(deadline) {
+			return p, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(min(processPollInterval, time.Until(deadline))):
+		}
+	}
+}
+
+// StopProcesses terminates every background process in the session,
+// escalating to SIGKILL for those still alive after stopGracePeriod.
+func (sm *SessionManager) StopProcesses(ctx context.Context, key string) error {
+	cmd := fmt.Sprintf(`pids=; for d in %[1]s/*/; do [ -f "$d/pid" ] && [ ! -f "$d/exit" ] || continue; `+
+		`p=$(cat "$d/pid"); kill -0 "$p" 2>/dev/null && pids="$pids $p"; done; [ -n "$pids" ] || exit 0; `+
+		`for p in $pids; do kill -s TERM -- -$p 2>/dev/null; done; sleep %[2]d; `+
+		`for p in $pids; do kill -s KILL -- -$p 2>/dev/null; done; exit 0`,
+		processRoot, int(stopGracePeriod.Seconds()))
+	if _, err := sm.processHelper(ctx, key, cmd); err != nil {
+		return fmt.Errorf("session manager: stop processes: %w", err)
+	}
+	return nil
+}
+
+// processHelper runs a short control command in the session's sandbox.
+// Evicts the sandbox on connection errors.
+func (sm *SessionManager) processHelper(ctx context.Context, key, cmd string) (*CommandResult, error) {
+	ms, err := sm.getSession(key)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, processHelperTimeout)
+	defer cancel()
+	res, err := sm.client.RunCommand(ctx, ms.ExecDURL, cmd, "/", int(processHelperTimeout.Milliseconds()))
+	if err != nil {
+		if isConnectionError(err) {
+			sm.evictStale(key, ms.SandboxID, err)
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+// processDir returns the control directory for id. Only IDs issued by
+// StartProcess are accepted, which also keeps id safe to use in shell
+// commands unquoted.
+func processDir(id string) (string, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return "", ErrProcessNotFound
+	}
+	return processRoot + "/" + id, nil
+}
+
+// processRunScript is the process's session leader. It records its PID,
+// runs the command with output captured and records the exit code. The
+// trap keeps it alive when a signal is sent to the process group so the
+// exit code can still be written; caught signals are reset for the
+// command, which receives them normally.
+func processRunScript(dir, workdir string) []byte {
+	return fmt.Appendf(nil, `: > %[1]s/out
+echo $$ > %[1]s/pid
+trap : HUP INT QUIT TERM USR1 USR2
+cd %[2]s 2>>%[1]s/out || { echo 1 > %[1]s/exit; exit 1; }
+if command -v bash >/dev/null 2>&1; then bash %[1]s/cmd.sh; else sh %[1]s/cmd.sh; fi >> %[1]s/out 2>&1 < /dev/null
+echo $? > %[1]s/exit.tmp && mv %[1]s/exit.tmp %[1]s/exit
+`, dir, shellQuote(workdir))
+}
+
+// processStartCommand detaches processRunScript into a new session with
+// util-linux setsid(1), falling back to Python on images without it, then
+// waits briefly for the script to report its PID. Unlike a shell "&",
+// neither leaves SIGINT ignored in the detached process.
+func processStartCommand(dir string) string {
+	return fmt.Sprintf(`if setsid -f true >/dev/null 2>&1; then setsid -f sh %[1]s/run.sh </dev/null >/dev/null 2>&1; `+
+		`else python3 -c 'import subprocess,sys; subprocess.Popen(["sh", sys.argv[1]], start_new_session=True, stdin=subprocess.DEVNULL, stdout=subprocess.DEVNULL, stderr=subprocess.DEVNULL)' %[1]s/run.sh; fi; `+
+		`i=0; while [ ! -s %[1]s/pid ] && [ $i -lt 50 ]; do sleep 0.1; i=$((i+1)); done; cat %[1]s/pid`,
+		dir)
+}
+
+// processStatusSnippet prints one ';'-terminated status record for the
+// process directory dir: id, pid, exit code or '-', alive flag, output
+// size and base64-encoded metadata.
+func processStatusSnippet(dir string) string {
+	return fmt.Sprintf(`[ -f %[1]s/pid ] && { code=$(cat %[1]s/exit 2>/dev/null); pid=$(cat %[1]s/pid); alive=0; kill -0 "$pid" 2>/dev/null && alive=1; `+
+		`printf '%%s %%s %%s %%s %%s %%s;' "$(basename %[1]s)" "$pid" "${code:--}" "$alive" "$(wc -c < %[1]s/out)" "$(cat %[1]s/meta)"; }`,
+		dir)
+}
+
+// parseProcessRecords decodes the records printed by processStatusSnippet.
+func parseProcessRecords(out string) ([]*Process, error) {
+	var procs []*Process
+	for rec := range strings.SplitSeq(out, ";") {
+		fields := strings.Fields(rec)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 6 {
+			return nil, fmt.Errorf("malformed process record %q", rec)
+		}
+		p := &Process{ID: fields[0]}
+		p.PID, _ = strconv.Atoi(fields[1])
+		p.State, p.ExitCode = processState(fields[2], fields[3])
+		p.OutputSize, _ = strconv.ParseInt(fields[4], 10, 64)
+
+		var meta processMeta
+		if raw, err := base64.StdEncoding.DecodeString(fields[5]); err == nil && json.Unmarshal(raw, &meta) == nil {
+			p.Command = meta.Command
+			p.WorkDir = meta.WorkDir
+			p.StartedAt = meta.StartedAt
+		}
+		procs = append(procs, p)
+	}
+	return procs, nil
+}
+
+// parseProcessOutput decodes the header and base64 body printed by the
+// ProcessOutput command for a read starting at offset.
+func parseProcessOutput(out string, offset int64) (*ProcessOutput, error) {
+	header, body, ok := strings.Cut(out, "|")
+	fields := strings.Fields(header)
+	if !ok || len(fields) != 3 {
+		return nil, fmt.Errorf("session manager: process output: malformed header %q", header)
+	}
+	size, _ := strconv.ParseInt(fields[0], 10, 64)
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(body), ""))
+	if err != nil {
+		return nil, fmt.Errorf("session manager: process output: decode: %w", err)
+	}
+
+	po := &ProcessOutput{}
+	po.State, po.ExitCode = processState(fields[1], fields[2])
+	// Unless this is the end of the output, hold back a multi-byte UTF-8
+	// sequence cut off by the limit; the next read starts with it.
+	if po.State == ProcessRunning || offset+int64(len(data)) < size {
+		data = trimPartialRune(data)
+	}
+	po.Data, po.Offset = string(data), offset+int64(len(data))
+	// The exit code is only written after the output file is closed, so
+	// once the process is gone size is final.
+	po.Done = po.State != ProcessRunning && po.Offset >= size
+	return po, nil
+}
+
+// trimPartialRune drops an incomplete UTF-8 sequence from the end of
+// data. It never trims data down to nothing, so a reader always makes
+// progress.
+func trimPartialRune(data []byte) []byte {
+	for i := 1; i < utf8.UTFMax && i < len(data); i++ {
+		if start := len(data) - i; utf8.RuneStart(data[start]) {
+			if !utf8.FullRune(data[start:]) {
+				return data[:start]
+			}
+			break
+		}
+	}
+	return data
+}
+
+// processState derives a process's state from its recorded exit code
+// ("-" when none) and whether its PID is still alive ("1" or "0").
+func processState(code, alive string) (ProcessState, *int) {
+	if n, err := strconv.Atoi(code); err == nil {
+		return ProcessExited, &n
+	}
+	if alive == "1" {
+		return ProcessRunning, nil
+	}
+	return ProcessKilled, nil
+}
+
+// shellQuote quotes s as a single POSIX shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
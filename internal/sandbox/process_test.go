@@ -0,0 +1,222 @@
+package sandbox
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devs-group/skillbox/internal/config"
+)
+
+const testProcessID = "0b8c4f0e-7d7a-4b0e-9f57-3f7f1f3c2a10"
+
+// fakeProcessExecD answers every ExecD command with the next queued
+// result and records the commands it received.
+type fakeProcessExecD struct {
+	mu       sync.Mutex
+	commands []string
+	results  []CommandResult
+}
+
+func (f *fakeProcessExecD) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/files/upload" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	var req cmdReqWire
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	f.mu.Lock()
+	f.commands = append(f.commands, req.Command)
+	var res CommandResult
+	if len(f.results) > 0 {
+		res, f.results = f.results[0], f.results[1:]
+	}
+	f.mu.Unlock()
+
+	w.WriteHeader(http.StatusOK)
+	if res.Stdout != "" {
+		data, _ := json.Marshal(res.Stdout)
+		fmt.Fprintf(w, `{"type":"stdout","text":%s}`+"\n\n", data) //nolint:errcheck
+	}
+	fmt.Fprintf(w, `{"type":"execution_complete","exitCode":%d}`+"\n\n", res.ExitCode) //nolint:errcheck
+}
+
+func newProcessTestManager(t *testing.T, results ...CommandResult) (*SessionManager, *fakeProcessExecD, string) {
+	t.Helper()
+	execd := &fakeProcessExecD{results: results}
+	srv := httptest.NewServer(execd)
+	t.Cleanup(srv.Close)
+
+	sm := NewSessionManager(New("http://unused", "", srv.Client()), nil, nil, &config.Config{})
+	key := sessionKey("tenant-1", "sess-1")
+	sm.sessions[key] = &ManagedSandbox{SandboxID: "sb-1", ExecDURL: srv.URL, TenantID: "tenant-1", ExternalID: "sess-1"}
+	return sm, execd, key
+}
+
+// statusRecord builds a record as printed by processStatusSnippet.
+func statusRecord(id, code, alive string, started time.Time) string {
+	meta, _ := json.Marshal(processMeta{Command: "npm run dev", WorkDir: "/sandbox/session", StartedAt: started})
+	return fmt.Sprintf("%s 4242 %s %s 17 %s;", id, code, alive, base64.StdEncoding.EncodeToString(meta))
+}
+
+func TestParseProcessRecords(t *testing.T) {
+	started := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	out := statusRecord("p-running", "-", "1", started) + "\n" +
+		statusRecord("p-exited", "3", "0", started) +
+		statusRecord("p-killed", "-", "0", started)
+
+	procs, err := parseProcessRecords(out)
+	if err != nil {
+		t.Fatalf("parseProcessRecords: %v", err)
+	}
+	if len(procs) != 3 {
+		t.Fatalf("got %d processes, want 3", len(procs))
+	}
+	if p := procs[0]; p.State != ProcessRunning || p.ExitCode != nil || p.PID != 4242 ||
+		p.OutputSize != 17 || p.Command != "npm run dev" || !p.StartedAt.Equal(started) {
+		t.Errorf("running process = %+v", p)
+	}
+	if p := procs[1]; p.State != ProcessExited || p.ExitCode == nil || *p.ExitCode != 3 {
+		t.Errorf("exited process = %+v", p)
+	}
+	if p := procs[2]; p.State != ProcessKilled || p.ExitCode != nil {
+		t.Errorf("killed process = %+v", p)
+	}
+
+	if _, err := parseProcessRecords("p-1 4242 -;"); err == nil {
+		t.Error("expected error for a truncated record")
+	}
+}
+
+func TestProcessOutput_TailsFromOffset(t *testing.T) {
+	chunk := "world\n"
+	sm, execd, key := newProcessTestManager(t, CommandResult{
+		Stdout: "16 0 0|" + base64.StdEncoding.EncodeToString([]byte(chunk)) + "\n",
+	})
+
+	out, err := sm.ProcessOutput(t.Context(), key, testProcessID, 10, 0)
+	if err != nil {
+		t.Fatalf("ProcessOutput: %v", err)
+	}
+	if out.Data != chunk || out.Offset != 16 {
+		t.Errorf("got data %q offset %d, want %q offset 16", out.Data, out.Offset, chunk)
+	}
+	if out.State != ProcessExited || out.ExitCode == nil || *out.ExitCode != 0 || !out.Done {
+		t.Errorf("got state %s exit %v done %v, want exited 0 done", out.State, out.ExitCode, out.Done)
+	}
+	if cmd := execd.commands[0]; !strings.Contains(cmd, "tail -c +11 ") || !strings.Contains(cmd, fmt.Sprintf("head -c %d ", DefaultProcessOutputLimit)) {
+		t.Errorf("command does not read from offset 10 with the default limit: %s", cmd)
+	}
+}
+
+func TestProcessOutput_NotDoneWhileRunning(t *testing.T) {
+	sm, _, key := newProcessTestManager(t, CommandResult{Stdout: "0 - 1|"})
+
+	out, err := sm.ProcessOutput(t.Context(), key, testProcessID, 0, 0)
+	if err != nil {
+		t.Fatalf("ProcessOutput: %v", err)
+	}
+	if out.Data != "" || out.Offset != 0 || out.State != ProcessRunning || out.Done {
+		t.Errorf("got %+v, want empty running chunk", out)
+	}
+}
+
+func TestProcessOutput_KeepsRunesWhole(t *testing.T) {
+	// "héllo" with the limit falling inside "é" (0xC3 0xA9).
+	tests := []struct {
+		name     string
+		header   string
+		chunk    string
+		wantData string
+	}{
+		{"running", "10 - 1", "h\xc3", "h"},
+		{"more output", "10 0 0", "h\xc3", "h"},
+		{"end of output", "2 0 0", "h\xc3", "h\xc3"},
+		{"lone partial rune", "10 - 1", "\xc3", "\xc3"},
+		{"whole runes", "10 - 1", "h\xc3\xa9", "h\xc3\xa9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := parseProcessOutput(tt.header+"|"+base64.StdEncoding.EncodeToString([]byte(tt.chunk)), 0)
+			if err != nil {
+				t.Fatalf("parseProcessOutput: %v", err)
+			}
+			if out.Data != tt.wantData || out.Offset != int64(len(tt.wantData)) {
+				t.Errorf("got data %q offset %d, want %q offset %d", out.Data, out.Offset, tt.wantData, len(tt.wantData))
+			}
+		})
+	}
+}
+
+func TestSignalProcess(t *testing.T) {
+	sm, execd, key := newProcessTestManager(t,
+		CommandResult{},
+		CommandResult{ExitCode: 3},
+		CommandResult{ExitCode: 4},
+	)
+
+	if err := sm.SignalProcess(t.Context(), key, testProcessID, "sigint"); err != nil {
+		t.Fatalf("SignalProcess: %v", err)
+	}
+	if cmd := execd.commands[0]; !strings.Contains(cmd, "kill -s INT -- -") {
+		t.Errorf("signal command = %s", cmd)
+	}
+	if err := sm.SignalProcess(t.Context(), key, testProcessID, "TERM"); !errors.Is(err, ErrProcessNotFound) {
+		t.Errorf("missing process: err = %v, want ErrProcessNotFound", err)
+	}
+	if err := sm.SignalProcess(t.Context(), key, testProcessID, "TERM"); !errors.Is(err, ErrProcessExited) {
+		t.Errorf("exited process: err = %v, want ErrProcessExited", err)
+	}
+
+	// Rejected before reaching the sandbox.
+	if err := sm.SignalProcess(t.Context(), key, testProcessID, "SEGV"); !errors.Is(err, ErrInvalidSignal) {
+		t.Errorf("SEGV: err = %v, want ErrInvalidSignal", err)
+	}
+	if err := sm.SignalProcess(t.Context(), key, "../../etc", "TERM"); !errors.Is(err, ErrProcessNotFound) {
+		t.Errorf("bad id: err = %v, want ErrProcessNotFound", err)
+	}
+	if len(execd.commands) != 3 {
+		t.Errorf("sandbox received %d commands, want 3", len(execd.commands))
+	}
+}
+
+func TestWaitProcess_ReturnsOnExit(t *testing.T) {
+	started := time.Now().UTC()
+	sm, execd, key := newProcessTestManager(t,
+		CommandResult{Stdout: statusRecord(testProcessID, "-", "1", started)},
+		CommandResult{Stdout: statusRecord(testProcessID, "0", "0", started)},
+	)
+
+	p, err := sm.WaitProcess(t.Context(), key, testProcessID, time.Minute)
+	if err != nil {
+		t.Fatalf("WaitProcess: %v", err)
+	}
+	if p.State != ProcessExited || p.ExitCode == nil || *p.ExitCode != 0 {
+		t.Errorf("got %+v, want exited with code 0", p)
+	}
+	if len(execd.commands) != 2 {
+		t.Errorf("polled %d times, want 2", len(execd.commands))
+	}
+}
+
+func TestWaitProcess_TimeoutReturnsRunning(t *testing.T) {
+	sm, _, key := newProcessTestManager(t,
+		CommandResult{Stdout: statusRecord(testProcessID, "-", "1", time.Now())},
+	)
+
+	p, err := sm.WaitProcess(t.Context(), key, testProcessID, 0)
+	if err != nil {
+		t.Fatalf("WaitProcess: %v", err)
+	}
+	if p.State != ProcessRunning {
+		t.Errorf("state = %s, want running", p.State)
+	}
+}
@@ -0,0 +1,413 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+// ptyRoot holds per-PTY control files (input FIFO, tty name, host PID)
+// inside the sandbox. It lives outside /sandbox/session so
+// SyncSessionFiles never persists them.
+const ptyRoot = "/tmp/skillbox-pty"
+
+// ptyCommandTimeoutMs is the ExecD timeout for the command hosting the
+// shell. Idle shells are closed by the PTY watchdog long before this; it
+// only caps a shell at the sandbox's maximum lifetime.
+const ptyCommandTimeoutMs = 24 * 60 * 60 * 1000
+
+// ptyHelperTimeout bounds the short ExecD commands used to feed stdin,
+// resize and tear down a PTY.
+const ptyHelperTimeout = 10 * time.Second
+
+// maxPTYInputChunk caps the stdin relayed per ExecD command so the
+// base64-encoded payload stays well below ARG_MAX.
+const maxPTYInputChunk = 16 << 10
+
+// maxTranscriptSize caps the recorded transcript. Output beyond the cap is
+// still relayed to the client but no longer recorded.
+const maxTranscriptSize = 10 << 20 // 10 MiB
+
+// ErrPTYClosed is returned when writing to or resizing a PTY whose shell
+// has exited or which has been closed.
+var ErrPTYClosed = errors.New("session manager: pty closed")
+
+// PTYOpts configures a new interactive shell.
+type PTYOpts struct {
+	Cols int // terminal width; default 80
+	Rows int // terminal height; default 24
+}
+
+// PTY is an interactive shell running under a pseudo-terminal inside a
+// session sandbox. ExecD has no stdin channel, so the shell reads from a
+// FIFO that Write feeds through short helper commands, while its output is
+// relayed live from the long-lived command hosting it.
+type PTY struct {
+	ID         string
+	TenantID   string
+	ExternalID string
+	Cols, Rows int // initial terminal size
+
+	sm       *SessionManager
+	key      string
+	execdURL string
+	dir      string
+
+	out    *io.PipeReader
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	exitCode  int
+	err       error
+	idleTimed atomic.Bool
+	lastIO    atomic.Int64 // unix nanos of the last stdin/stdout activity
+
+	writeMu   sync.Mutex
+	closeOnce sync.Once
+}
+
+// OpenPTY starts an interactive shell in the managed sandbox identified by
+// key. The shell runs in /sandbox/session and is closed automatically after
+// SandboxPTYIdleTimeout without input or output.
+func (sm *SessionManager) OpenPTY(ctx context.Context, key string, opts PTYOpts) (*PTY, error) {
+	ms, err := sm.getSession(key)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Cols <= 0 {
+		opts.Cols = 80
+	}
+	if opts.Rows <= 0 {
+		opts.Rows = 24
+	}
+
+	id := uuid.New().String()
+	dir := ptyRoot + "/" + id
+
+	if err := sm.client.UploadFiles(ctx, ms.ExecDURL, []FileUpload{
+		{Path: dir + "/init.sh", Content: ptyInitScript(dir, opts), Mode: 0o755},
+	}); err != nil {
+		if isConnectionError(err) {
+			sm.evictStale(key, ms.SandboxID, err)
+		}
+		return nil, fmt.Errorf("session manager: pty setup: %w", err)
+	}
+	res, err := sm.client.RunCommand(ctx, ms.ExecDURL, "mkfifo "+dir+"/in", "/", int(ptyHelperTimeout.Milliseconds()))
+	if err != nil {
+		return nil, fmt.Errorf("session manager: pty setup: %w", err)
+	}
+	if res.ExitCode != 0 {
+		return nil, fmt.Errorf("session manager: pty setup: mkfifo exited with code %d: %s", res.ExitCode, res.Stderr)
+	}
+
+	pr, pw := io.Pipe()
+	streamCtx, cancel := context.WithCancel(context.Background())
+	p := &PTY{
+		ID:         id,
+		TenantID:   ms.TenantID,
+		ExternalID: ms.ExternalID,
+		Cols:       opts.Cols,
+		Rows:       opts.Rows,
+		sm:         sm,
+		key:        key,
+		execdURL:   ms.ExecDURL,
+		dir:        dir,
+		out:        pr,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+	p.touch()
+
+	go func() {
+		result, streamErr := sm.client.StreamCommand(streamCtx, ms.ExecDURL, ptyHostCommand(dir), "/sandbox/session", ptyCommandTimeoutMs, activityWriter{p, pw})
+		if result != nil {
+			p.exitCode = result.ExitCode
+		}
+		if streamErr != nil && streamCtx.Err() == nil {
+			p.err = streamErr
+		}
+		// Mark the PTY done before readers see EOF so they can tell a
+		// shell exit from a broken relay.
+		close(p.done)
+		_ = pw.Close()
+	}()
+	go p.watchIdle(sm.config.SandboxPTYIdleTimeout)
+
+	slog.Info("session manager: pty opened",
+		"key", key,
+		"sandbox_id", ms.SandboxID,
+		"pty_id", id,
+	)
+	return p, nil
+}
+
+// Read reads shell output. It returns io.EOF once the shell has exited and
+// an error once the PTY has been closed.
+func (p *PTY) Read(b []byte) (int, error) {
+	return p.out.Read(b)
+}
+
+// Write relays b to the shell's stdin.
+func (p *PTY) Write(b []byte) (int, error) {
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	written := 0
+	for len(b) > 0 {
+		select {
+		case <-p.done:
+			return written, ErrPTYClosed
+		default:
+		}
+		n := min(len(b), maxPTYInputChunk)
+		cmd := fmt.Sprintf("printf '%%s' '%s' | base64 -d > %s/in", base64.StdEncoding.EncodeToString(b[:n]), p.dir)
+		if err := p.helper(cmd); err != nil {
+			return written, fmt.Errorf("session manager: pty write: %w", err)
+		}
+		written += n
+		b = b[n:]
+	}
+	p.touch()
+	return written, nil
+}
+
+// Resize changes the terminal size. The kernel delivers SIGWINCH to the
+// shell's foreground process group.
+func (p *PTY) Resize(cols, rows int) error {
+	if cols <= 0 || rows <= 0 {
+		return fmt.Errorf("session manager: pty resize: invalid size %dx%d", cols, rows)
+	}
+	select {
+	case <-p.done:
+		return ErrPTYClosed
+	default:
+	}
+	cmd := fmt.Sprintf(`stty -F "$(cat %s/tty)" cols %d rows %d`, p.dir, cols, rows)
+	if err := p.helper(cmd); err != nil {
+		return fmt.Errorf("session manager: pty resize: %w", err)
+	}
+	p.touch()
+	return nil
+}
+
+// Done is closed once the shell has exited or the PTY has been closed.
+func (p *PTY) Done() <-chan struct{} {
+	return p.done
+}
+
+// Wait blocks until the shell is gone and returns its exit code. The error
+// is non-nil only if the output stream broke for a reason other than Close.
+func (p *PTY) Wait() (int, error) {
+	<-p.done
+	return p.exitCode, p.err
+}
+
+// IdleTimedOut reports whether the PTY was closed by the idle watchdog.
+func (p *PTY) IdleTimedOut() bool {
+	return p.idleTimed.Load()
+}
+
+// Close hangs up the shell, removes its control files and stops relaying
+// output. It is safe to call more than once.
+func (p *PTY) Close() error {
+	p.closeOnce.Do(func() {
+		cmd := fmt.Sprintf(`kill -HUP "$(cat %s/pid)" 2>/dev/null; rm -rf %s`, p.dir, p.dir)
+		if err := p.helper(cmd); err != nil {
+			slog.Warn("session manager: pty teardown failed",
+				"pty_id", p.ID,
+				"error", err,
+			)
+		}
+		p.cancel()
+		// Unblock the relay if nobody is reading output any more.
+		_ = p.out.CloseWithError(ErrPTYClosed)
+		<-p.done
+		slog.Info("session manager: pty closed", "key", p.key, "pty_id", p.ID)
+	})
+	return nil
+}
+
+// helper runs a short control command next to the shell and treats a
+// non-zero exit code as an error.
+func (p *PTY) helper(cmd string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), ptyHelperTimeout)
+	defer cancel()
+	res, err := p.sm.client.RunCommand(ctx, p.execdURL, cmd, "/", int(ptyHelperTimeout.Milliseconds()))
+	if err != nil {
+		return err
+	}
+	if res.ExitCode != 0 {
+		return fmt.Errorf("exited with code %d: %s", res.ExitCode, res.Stderr)
+	}
+	return nil
+}
+
+// touch records I/O activity and keeps the session's idle cleanup from
+// reaping the sandbox while a shell is in use.
+func (p *PTY) touch() {
+	p.lastIO.Store(time.Now().UnixNano())
+	_, _ = p.sm.getSession(p.key)
+}
+
+// watchIdle closes the PTY once no input or output has been seen for idle.
+func (p *PTY) watchIdle(idle time.Duration) {
+	timer := time.NewTimer(idle)
+	defer timer.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-timer.C:
+			since := time.Since(time.Unix(0, p.lastIO.Load()))
+			if since < idle {
+				timer.Reset(idle - since)
+				continue
+			}
+			p.idleTimed.Store(true)
+			_ = p.Close()
+			return
+		}
+	}
+}
+
+// activityWriter forwards shell output to the PTY's pipe and counts it as
+// activity for the idle watchdog.
+type activityWriter struct {
+	p *PTY
+	w io.Writer
+}
+
+func (a activityWriter) Write(b []byte) (int, error) {
+	a.p.touch()
+	return a.w.Write(b)
+}
+
+// ptyInitScript runs as the first process on the pseudo-terminal: it sizes
+// the terminal, records the tty device for Resize and execs the shell.
+func ptyInitScript(dir string, opts PTYOpts) []byte {
+	return fmt.Appendf(nil, `stty cols %d rows %d 2>/dev/null
+tty > %s/tty
+export TERM=xterm-256color
+cd /sandbox/session 2>/dev/null
+if command -v bash >/dev/null 2>&1; then exec bash -i; fi
+exec sh -i
+`, opts.Cols, opts.Rows, dir)
+}
+
+// ptyHostCommand allocates the pseudo-terminal around ptyInitScript with
+// util-linux script(1), falling back to Python's pty module on images
+// without it. The FIFO is opened read-write so the shell never sees EOF
+// between stdin writes.
+func ptyHostCommand(dir string) string {
+	return fmt.Sprintf(`echo $$ > %[1]s/pid; `+
+		`if command -v script >/dev/null 2>&1; then exec script -qfc "sh %[1]s/init.sh" /dev/null <> %[1]s/in; fi; `+
+		`exec python3 -c 'import os,pty,sys; sys.exit(os.waitstatus_to_exitcode(pty.spawn(["sh", sys.argv[1]])))' %[1]s/init.sh <> %[1]s/in`,
+		dir)
+}
+
+// Transcript records a PTY session in asciicast v2 format
+// (https://docs.asciinema.org/manual/asciicast/v2/). Only output and
+// resizes are recorded: echoed keystrokes already appear in the output,
+// and recording raw input would capture passwords typed with echo off.
+type Transcript struct {
+	mu        sync.Mutex
+	start     time.Time
+	buf       bytes.Buffer
+	truncated bool
+}
+
+// NewTranscript starts a transcript for a terminal of the given size.
+func NewTranscript(cols, rows int) *Transcript {
+	t := &Transcript{start: time.Now()}
+	header, _ := json.Marshal(map[string]any{
+		"version":   2,
+		"width":     cols,
+		"height":    rows,
+		"timestamp": t.start.Unix(),
+		"env":       map[string]string{"TERM": "xterm-256color"},
+	})
+	t.buf.Write(header)
+	t.buf.WriteByte('\n')
+	return t
+}
+
+// Output records terminal output.
+func (t *Transcript) Output(data []byte) {
+	t.event("o", string(data))
+}
+
+// Resize records a terminal resize.
+func (t *Transcript) Resize(cols, rows int) {
+	t.event("r", fmt.Sprintf("%dx%d", cols, rows))
+}
+
+// Bytes returns the recorded transcript.
+func (t *Transcript) Bytes() []byte {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return bytes.Clone(t.buf.Bytes())
+}
+
+// Truncated reports whether recording stopped at maxTranscriptSize.
+func (t *Transcript) Truncated() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.truncated
+}
+
+func (t *Transcript) event(kind, data string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.truncated {
+		return
+	}
+	line, _ := json.Marshal([]any{time.Since(t.start).Seconds(), kind, data})
+	if t.buf.Len()+len(line)+1 > maxTranscriptSize {
+		t.truncated = true
+		return
+	}
+	t.buf.Write(line)
+	t.buf.WriteByte('\n')
+}
+
+// SaveTranscript uploads a PTY transcript to MinIO and registers it with
+// the files API so it can be listed and downloaded via /v1/files. It is
+// stored as a tenant file rather than a session file so it is not mounted
+// back into the sandbox.
+func (sm *SessionManager) SaveTranscript(ctx context.Context, p *PTY, t *Transcript) (*store.File, error) {
+	if sm.artifacts == nil {
+		return nil, fmt.Errorf("session manager: save transcript: no artifact storage configured")
+	}
+	data := t.Bytes()
+	name := fmt.Sprintf("pty-%s-%s.cast", p.ExternalID, time.Now().UTC().Format("20060102T150405Z"))
+	s3Key := fmt.Sprintf("%s/files/%s/%s", p.TenantID, uuid.New().String(), name)
+
+	if _, err := sm.artifacts.UploadObject(ctx, s3Key, bytes.NewReader(data), int64(len(data)), "application/x-asciicast"); err != nil {
+		return nil, fmt.Errorf("session manager: upload transcript: %w", err)
+	}
+	f, err := sm.store.CreateFile(ctx, &store.File{
+		TenantID:    p.TenantID,
+		Name:        name,
+		ContentType: "application/x-asciicast",
+		SizeBytes:   int64(len(data)),
+		S3Key:       s3Key,
+		Version:     1,
+	})
+	if err != nil {
+		_ = sm.artifacts.DeleteObject(ctx, s3Key)
+		return nil, fmt.Errorf("session manager: create transcript record: %w", err)
+	}
+	return f, nil
+}
@@ -0,0 +1,257 @@
+package sandbox
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devs-group/skillbox/internal/config"
+)
+
+// fakePTYExecD emulates the ExecD commands OpenPTY issues: the streaming
+// host command echoes whatever arrives through the stdin helper, and the
+// hang-up helper ends it.
+type fakePTYExecD struct {
+	t       *testing.T
+	stdin   chan string
+	hangup  chan struct{}
+	hungUp  sync.Once
+	mu      sync.Mutex
+	resizes []string
+}
+
+var base64Arg = regexp.MustCompile(`printf '%s' '([^']*)'`)
+
+func newFakePTYExecD(t *testing.T) *fakePTYExecD {
+	return &fakePTYExecD{t: t, stdin: make(chan string, 16), hangup: make(chan struct{})}
+}
+
+func (f *fakePTYExecD) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/files/upload" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	var req cmdReqWire
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	w.WriteHeader(http.StatusOK)
+
+	switch {
+	case strings.Contains(req.Command, "exec script"):
+		flusher := w.(http.Flusher)
+		writeEvent := func(ev string) {
+			fmt.Fprint(w, ev+"\n\n") //nolint:errcheck
+			flusher.Flush()
+		}
+		writeEvent(`{"type":"stdout","text":"$ "}`)
+		for {
+			select {
+			case in := <-f.stdin:
+				data, _ := json.Marshal(in)
+				writeEvent(`{"type":"stdout","text":` + string(data) + `}`)
+			case <-f.hangup:
+				writeEvent(`{"type":"execution_complete","exitCode":0}`)
+				return
+			case <-r.Context().Done():
+				return
+			}
+		}
+	case strings.Contains(req.Command, "base64 -d"):
+		m := base64Arg.FindStringSubmatch(req.Command)
+		if m == nil {
+			f.t.Errorf("stdin helper without payload: %s", req.Command)
+			break
+		}
+		decoded, _ := base64.StdEncoding.DecodeString(m[1])
+		f.stdin <- string(decoded)
+	case strings.Contains(req.Command, "stty -F"):
+		f.mu.Lock()
+		f.resizes = append(f.resizes, req.Command)
+		f.mu.Unlock()
+	case strings.Contains(req.Command, "kill -HUP"):
+		f.hungUp.Do(func() { close(f.hangup) })
+	}
+	fmt.Fprint(w, `{"type":"execution_complete","exitCode":0}`+"\n\n") //nolint:errcheck
+}
+
+func newPTYTestManager(t *testing.T, idle time.Duration) (*SessionManager, *fakePTYExecD, string) {
+	t.Helper()
+	execd := newFakePTYExecD(t)
+	srv := httptest.NewServer(execd)
+	t.Cleanup(srv.Close)
+
+	sm := NewSessionManager(New("http://unused", "", srv.Client()), nil, nil, &config.Config{SandboxPTYIdleTimeout: idle})
+	key := sessionKey("tenant-1", "sess-1")
+	sm.sessions[key] = &ManagedSandbox{
+		SandboxID:  "sb-1",
+		ExecDURL:   srv.URL,
+		TenantID:   "tenant-1",
+		ExternalID: "sess-1",
+		LastUsedAt: time.Now(),
+	}
+	return sm, execd, key
+}
+
+// readUntil reads from r until the accumulated output contains want.
+func readUntil(t *testing.T, r io.Reader, want string) string {
+	t.Helper()
+	var got strings.Builder
+	buf := make([]byte, 256)
+	deadline := time.Now().Add(3 * time.Second)
+	for !strings.Contains(got.String(), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %q, got %q", want, got.String())
+		}
+		n, err := r.Read(buf)
+		got.Write(buf[:n])
+		if err != nil {
+			t.Fatalf("read: %v (got %q, want %q)", err, got.String(), want)
+		}
+	}
+	return got.String()
+}
+
+func TestPTY_RelaysStdinOutputAndResize(t *testing.T) {
+	sm, execd, key := newPTYTestManager(t, time.Minute)
+
+	pty, err := sm.OpenPTY(t.Context(), key, PTYOpts{})
+	if err != nil {
+		t.Fatalf("OpenPTY: %v", err)
+	}
+	defer pty.Close() //nolint:errcheck
+
+	if pty.Cols != 80 || pty.Rows != 24 {
+		t.Errorf("default size = %dx%d, want 80x24", pty.Cols, pty.Rows)
+	}
+	readUntil(t, pty, "$ ")
+
+	if _, err := pty.Write([]byte("echo 'hi'\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	readUntil(t, pty, "echo 'hi'\n")
+
+	if err := pty.Resize(120, 40); err != nil {
+		t.Fatalf("Resize: %v", err)
+	}
+	execd.mu.Lock()
+	resizes := execd.resizes
+	execd.mu.Unlock()
+	if len(resizes) != 1 || !strings.Contains(resizes[0], "cols 120 rows 40") {
+		t.Errorf("resize commands = %v, want one with cols 120 rows 40", resizes)
+	}
+}
+
+func TestPTY_CloseEndsShell(t *testing.T) {
+	sm, _, key := newPTYTestManager(t, time.Minute)
+
+	pty, err := sm.OpenPTY(t.Context(), key, PTYOpts{Cols: 100, Rows: 30})
+	if err != nil {
+		t.Fatalf("OpenPTY: %v", err)
+	}
+	readUntil(t, pty, "$ ")
+
+	if err := pty.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	select {
+	case <-pty.Done():
+	case <-time.After(3 * time.Second):
+		t.Fatal("PTY not done after Close")
+	}
+	if _, err := pty.Write([]byte("x")); err != ErrPTYClosed {
+		t.Errorf("Write after Close = %v, want ErrPTYClosed", err)
+	}
+	if pty.IdleTimedOut() {
+		t.Error("IdleTimedOut = true after explicit Close")
+	}
+}
+
+func TestPTY_IdleTimeout(t *testing.T) {
+	sm, _, key := newPTYTestManager(t, 150*time.Millisecond)
+
+	pty, err := sm.OpenPTY(t.Context(), key, PTYOpts{})
+	if err != nil {
+		t.Fatalf("OpenPTY: %v", err)
+	}
+	defer pty.Close() //nolint:errcheck
+
+	select {
+	case <-pty.Done():
+	case <-time.After(3 * time.Second):
+		t.Fatal("PTY not closed by idle watchdog")
+	}
+	if !pty.IdleTimedOut() {
+		t.Error("IdleTimedOut = false, want true")
+	}
+}
+
+func TestPTY_UnknownSession(t *testing.T) {
+	sm, _, _ := newPTYTestManager(t, time.Minute)
+	if _, err := sm.OpenPTY(t.Context(), "tenant-1:missing", PTYOpts{}); err == nil {
+		t.Fatal("expected error for unknown session, got nil")
+	}
+}
+
+func TestPTYHostCommand_ReadsFromFIFO(t *testing.T) {
+	cmd := ptyHostCommand("/tmp/skillbox-pty/abc")
+	for _, want := range []string{"echo $$ > /tmp/skillbox-pty/abc/pid", "<> /tmp/skillbox-pty/abc/in", "script -qfc", "pty.spawn"} {
+		if !strings.Contains(cmd, want) {
+			t.Errorf("host command missing %q: %s", want, cmd)
+		}
+	}
+}
+
+func TestTranscript_Asciicast(t *testing.T) {
+	tr := NewTranscript(80, 24)
+	tr.Output([]byte("hello\r\n"))
+	tr.Resize(100, 30)
+
+	lines := strings.Split(strings.TrimSpace(string(tr.Bytes())), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3: %q", len(lines), lines)
+	}
+
+	var header map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatalf("header: %v", err)
+	}
+	if header["version"] != float64(2) || header["width"] != float64(80) || header["height"] != float64(24) {
+		t.Errorf("header = %v, want version 2, 80x24", header)
+	}
+
+	var out []any
+	if err := json.Unmarshal([]byte(lines[1]), &out); err != nil {
+		t.Fatalf("output event: %v", err)
+	}
+	if out[1] != "o" || out[2] != "hello\r\n" {
+		t.Errorf("output event = %v", out)
+	}
+	var resize []any
+	if err := json.Unmarshal([]byte(lines[2]), &resize); err != nil {
+		t.Fatalf("resize event: %v", err)
+	}
+	if resize[1] != "r" || resize[2] != "100x30" {
+		t.Errorf("resize event = %v", resize)
+	}
+}
+
+func TestTranscript_Truncates(t *testing.T) {
+	tr := NewTranscript(80, 24)
+	chunk := []byte(strings.Repeat("x", 1<<20))
+	for range 12 {
+		tr.Output(chunk)
+	}
+	if !tr.Truncated() {
+		t.Error("Truncated = false, want true")
+	}
+	if n := len(tr.Bytes()); n > maxTranscriptSize {
+		t.Errorf("transcript size = %d, want <= %d", n, maxTranscriptSize)
+	}
+}
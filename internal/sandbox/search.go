@@ -0,0 +1,332 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultSearchResults is the number of matches Search returns when no
+	// limit is given.
+	DefaultSearchResults = 100
+	// MaxSearchResults caps the matches returned by one Search call.
+	MaxSearchResults = 1000
+	// MaxSearchContextLines caps the lines of context around each match.
+	MaxSearchContextLines = 5
+)
+
+// Content searches download every candidate file, so they are bounded in
+// the number of files, the size of each file and the bytes read overall.
+// Files beyond these limits are skipped and the result is marked
+// truncated.
+const (
+	maxSearchFiles    = 2000
+	maxSearchFileSize = 1 << 20
+	maxSearchBytes    = 64 << 20
+	searchConcurrency = 8
+	maxSearchSnippet  = 300
+	binarySniffLength = 8000
+)
+
+// searchSkipDirs are directories below the search root whose contents are
+// never searched: they are large, generated, and rarely what an agent is
+// looking for. Searching from inside one still works.
+var searchSkipDirs = map[string]bool{".git": true, "node_modules": true, "__pycache__": true}
+
+// ErrInvalidPattern is returned by Search when the query is not a valid
+// regular expression or the glob is malformed.
+var ErrInvalidPattern = errors.New("session manager: invalid search pattern")
+
+// SearchOptions configures Search.
+type SearchOptions struct {
+	// Path is the directory to search. It must be under /sandbox/session.
+	Path string
+	// Glob limits the files searched. A pattern without a slash, such as
+	// "*.go", matches file names; one with a slash matches paths relative
+	// to Path. Empty matches every file.
+	Glob string
+	// Query is matched against each line of the candidate files. When it
+	// is empty, Search lists the files matching Glob instead.
+	Query string
+	// Regex treats Query as an RE2 regular expression instead of a
+	// literal string.
+	Regex bool
+	// IgnoreCase makes Query match case-insensitively.
+	IgnoreCase bool
+	// ContextLines adds up to this many lines before and after each match
+	// to its snippet.
+	ContextLines int
+	// MaxResults caps the returned matches. Zero means
+	// DefaultSearchResults.
+	MaxResults int
+}
+
+// SearchMatch is a single search hit. Line and Snippet are zero for file
+// name searches.
+type SearchMatch struct {
+	Path    string `json:"path"`
+	Line    int    `json:"line,omitempty"`
+	Snippet string `json:"snippet,omitempty"`
+}
+
+// SearchResult is the outcome of Search.
+type SearchResult struct {
+	Matches []SearchMatch `json:"matches"`
+	// Truncated reports that more matches exist than were returned, or
+	// that some files were skipped because of the search limits.
+	Truncated bool `json:"truncated"`
+}
+
+// Search finds files under opts.Path in the managed sandbox identified by
+// key. With a query it returns matching lines, otherwise matching file
+// names. Binary files are skipped. Evicts the sandbox on connection
+// errors.
+func (sm *SessionManager) Search(ctx context.Context, key string, opts SearchOptions) (*SearchResult, error) {
+	if err := ValidateSandboxPath(opts.Path); err != nil {
+		return nil, err
+	}
+	if opts.MaxResults <= 0 {
+		opts.MaxResults = DefaultSearchResults
+	}
+	opts.MaxResults = min(opts.MaxResults, MaxSearchResults)
+	opts.ContextLines = max(0, min(opts.ContextLines, MaxSearchContextLines))
+
+	var re *regexp.Regexp
+	if opts.Query != "" {
+		var err error
+		if re, err = compileSearchQuery(opts.Query, opts.Regex, opts.IgnoreCase); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := path.Match(opts.Glob, ""); err != nil {
+		return nil, fmt.Errorf("%w: glob: %v", ErrInvalidPattern, err)
+	}
+
+	ms, err := sm.getSession(key)
+	if err != nil {
+		return nil, err
+	}
+	files, err := sm.client.SearchFiles(ctx, ms.ExecDURL, opts.Path, "**")
+	if err != nil {
+		if isConnectionError(err) {
+			sm.evictStale(key, ms.SandboxID, err)
+		}
+		return nil, fmt.Errorf("session manager: search: %w", err)
+	}
+	files = filterSearchFiles(files, path.Clean(opts.Path), opts.Glob)
+
+	result := &SearchResult{Matches: []SearchMatch{}}
+	if re == nil {
+		for _, f := range files {
+			if len(result.Matches) == opts.MaxResults {
+				result.Truncated = true
+				break
+			}
+			result.Matches = append(result.Matches, SearchMatch{Path: f.Path})
+		}
+		return result, nil
+	}
+
+	if len(files) > maxSearchFiles {
+		files = files[:maxSearchFiles]
+		result.Truncated = true
+	}
+	var budget int64 = maxSearchBytes
+	candidates := files[:0:0]
+	for _, f := range files {
+		if f.Size > maxSearchFileSize || f.Size > budget {
+			result.Truncated = true
+			continue
+		}
+		budget -= f.Size
+		candidates = append(candidates, f)
+	}
+
+	matches, truncated, err := sm.searchContents(ctx, ms.ExecDURL, candidates, re, opts)
+	if err != nil {
+		if isConnectionError(err) {
+			sm.evictStale(key, ms.SandboxID, err)
+		}
+		return nil, fmt.Errorf("session manager: search: %w", err)
+	}
+	result.Matches = matches
+	result.Truncated = result.Truncated || truncated
+	return result, nil
+}
+
+// searchContents downloads files concurrently and returns their matching
+// lines in file order. Files are searched in order until opts.MaxResults
+// matches are found; later files are not downloaded. A file that cannot be
+// read is logged and skipped, and the result is marked truncated; only
+// cancellation and losing the sandbox abort the search.
+func (sm *SessionManager) searchContents(ctx context.Context, execdURL string, files []FileInfo, re *regexp.Regexp, opts SearchOptions) ([]SearchMatch, bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	perFile := make([][]SearchMatch, len(files))
+	errs := make([]error, len(files))
+	done := make([]chan struct{}, len(files))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	sem := make(chan struct{}, searchConcurrency)
+	go func() {
+		for i, f := range files {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				for j := i; j < len(files); j++ {
+					close(done[j])
+				}
+				return
+			}
+			go func() {
+				defer func() { <-sem; close(done[i]) }()
+				rc, err := sm.client.DownloadFile(ctx, execdURL, f.Path)
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				data, err := io.ReadAll(io.LimitReader(rc, maxSearchFileSize))
+				_ = rc.Close()
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				perFile[i] = matchLines(f.Path, data, re, opts.ContextLines)
+			}()
+		}
+	}()
+
+	var out []SearchMatch
+	truncated, skipped := false, false
+	for i := range files {
+		<-done[i]
+		if err := errs[i]; err != nil {
+			if ctx.Err() != nil || isConnectionError(err) {
+				cancel()
+				for j := i + 1; j < len(files); j++ {
+					<-done[j]
+				}
+				return nil, false, err
+			}
+			slog.Warn("session manager: search: skipping unreadable file", "path", files[i].Path, "error", err)
+			skipped = true
+			continue
+		}
+		for _, m := range perFile[i] {
+			if len(out) == opts.MaxResults {
+				truncated = true
+				break
+			}
+			out = append(out, m)
+		}
+		if truncated {
+			cancel()
+			break
+		}
+	}
+	// Wait for in-flight downloads so none outlive the call.
+	for i := range files {
+		<-done[i]
+	}
+	if out == nil {
+		out = []SearchMatch{}
+	}
+	return out, truncated || skipped, nil
+}
+
+// compileSearchQuery turns a search query into a regular expression.
+func compileSearchQuery(query string, isRegex, ignoreCase bool) (*regexp.Regexp, error) {
+	expr := query
+	if !isRegex {
+		expr = regexp.QuoteMeta(query)
+	}
+	if ignoreCase {
+		expr = "(?i)" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPattern, err)
+	}
+	return re, nil
+}
+
+// filterSearchFiles keeps the files under root that match glob, skipping
+// searchSkipDirs, sorted by path.
+func filterSearchFiles(files []FileInfo, root, glob string) []FileInfo {
+	var out []FileInfo
+	for _, f := range files {
+		p := path.Clean(f.Path)
+		rel, ok := strings.CutPrefix(p, root+"/")
+		if !ok {
+			continue
+		}
+		dirs := strings.Split(rel, "/")
+		skip := false
+		for _, d := range dirs[:len(dirs)-1] {
+			skip = skip || searchSkipDirs[d]
+		}
+		if skip {
+			continue
+		}
+		if glob != "" {
+			name := rel
+			if !strings.Contains(glob, "/") {
+				name = path.Base(rel)
+			}
+			if ok, _ := path.Match(glob, name); !ok {
+				continue
+			}
+		}
+		f.Path = p
+		out = append(out, f)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out
+}
+
+// matchLines returns the lines of data matching re. Each snippet is the
+// matching line, or the line with its surrounding context, trimmed to
+// maxSearchSnippet bytes per line. Binary content yields no matches.
+func matchLines(filePath string, data []byte, re *regexp.Regexp, contextLines int) []SearchMatch {
+	if bytes.IndexByte(data[:min(len(data), binarySniffLength)], 0) >= 0 {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	var out []SearchMatch
+	for i, line := range lines {
+		if !re.MatchString(line) {
+			continue
+		}
+		from, to := max(0, i-contextLines), min(len(lines), i+contextLines+1)
+		snippet := make([]string, 0, to-from)
+		for _, l := range lines[from:to] {
+			snippet = append(snippet, truncateSnippet(strings.TrimSuffix(l, "\r")))
+		}
+		out = append(out, SearchMatch{Path: filePath, Line: i + 1, Snippet: strings.Join(snippet, "\n")})
+	}
+	return out
+}
+
+// truncateSnippet shortens s to at most maxSearchSnippet bytes without
+// splitting a UTF-8 sequence.
+func truncateSnippet(s string) string {
+	if len(s) <= maxSearchSnippet {
+		return s
+	}
+	cut := maxSearchSnippet
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "…"
+}
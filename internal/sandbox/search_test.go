@@ -0,0 +1,222 @@
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/devs-group/skillbox/internal/config"
+)
+
+// fakeFilesExecD serves ExecD's file endpoints from an in-memory file
+// tree and records uploads.
+type fakeFilesExecD struct {
+	mu         sync.Mutex
+	files      map[string]string
+	unreadable map[string]bool // downloads of these paths fail with 500
+	uploads    int
+}
+
+func (f *fakeFilesExecD) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/files/search":
+		dir := r.URL.Query().Get("path")
+		var out []fileInfoWire
+		for p, c := range f.files {
+			if strings.HasPrefix(p, dir+"/") {
+				out = append(out, fileInfoWire{Path: p, Size: int64(len(c))})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(out)
+	case "/files/download":
+		if f.unreadable[r.URL.Query().Get("path")] {
+			http.Error(w, "permission denied", http.StatusInternalServerError)
+			return
+		}
+		c, ok := f.files[r.URL.Query().Get("path")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, c)
+	case "/files/upload":
+		f.uploads++
+		_, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		var meta fileMetaWire
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				break
+			}
+			data, _ := io.ReadAll(part)
+			if part.FormName() == "metadata" {
+				_ = json.Unmarshal(data, &meta)
+			} else {
+				f.files[meta.Path] = string(data)
+			}
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newFilesTestManager(t *testing.T, files map[string]string) (*SessionManager, *fakeFilesExecD, string) {
+	t.Helper()
+	execd := &fakeFilesExecD{files: files}
+	srv := httptest.NewServer(execd)
+	t.Cleanup(srv.Close)
+
+	sm := NewSessionManager(New("http://unused", "", srv.Client()), nil, nil, &config.Config{})
+	key := sessionKey("tenant-1", "sess-1")
+	sm.sessions[key] = &ManagedSandbox{SandboxID: "sb-1", ExecDURL: srv.URL, TenantID: "tenant-1", ExternalID: "sess-1"}
+	return sm, execd, key
+}
+
+func TestSearch_Content(t *testing.T) {
+	sm, _, key := newFilesTestManager(t, map[string]string{
+		"/sandbox/session/app/main.go":            "package main\n\nfunc main() {\n\tTODO()\n}\n",
+		"/sandbox/session/app/util.go":            "package main\n\n// todo: remove\n",
+		"/sandbox/session/README.md":              "TODO: docs\n",
+		"/sandbox/session/node_modules/x/todo.go": "TODO\n",
+		"/sandbox/session/app/blob.go":            "TODO\x00binary",
+	})
+
+	res, err := sm.Search(context.Background(), key, SearchOptions{
+		Path: "/sandbox/session", Query: "todo", IgnoreCase: true, Glob: "*.go", ContextLines: 1,
+	})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(res.Matches) != 2 || res.Truncated {
+		t.Fatalf("matches = %+v, truncated = %v", res.Matches, res.Truncated)
+	}
+	if m := res.Matches[0]; m.Path != "/sandbox/session/app/main.go" || m.Line != 4 || m.Snippet != "func main() {\n\tTODO()\n}" {
+		t.Errorf("match[0] = %+v", m)
+	}
+	if m := res.Matches[1]; m.Path != "/sandbox/session/app/util.go" || m.Line != 3 {
+		t.Errorf("match[1] = %+v", m)
+	}
+
+	res, err = sm.Search(context.Background(), key, SearchOptions{Path: "/sandbox/session", Query: "TODO", MaxResults: 1})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(res.Matches) != 1 || !res.Truncated {
+		t.Errorf("with MaxResults 1: matches = %+v, truncated = %v", res.Matches, res.Truncated)
+	}
+}
+
+func TestSearch_SkipsUnreadableFiles(t *testing.T) {
+	sm, execd, key := newFilesTestManager(t, map[string]string{
+		"/sandbox/session/a.txt": "TODO a\n",
+		"/sandbox/session/b.txt": "TODO b\n",
+		"/sandbox/session/c.txt": "TODO c\n",
+	})
+	execd.unreadable = map[string]bool{"/sandbox/session/b.txt": true}
+
+	res, err := sm.Search(context.Background(), key, SearchOptions{Path: "/sandbox/session", Query: "TODO"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(res.Matches) != 2 || res.Matches[0].Path != "/sandbox/session/a.txt" || res.Matches[1].Path != "/sandbox/session/c.txt" {
+		t.Errorf("matches = %+v, want a.txt and c.txt", res.Matches)
+	}
+	if !res.Truncated {
+		t.Error("result with a skipped file is not marked truncated")
+	}
+}
+
+func TestSearch_FileNames(t *testing.T) {
+	sm, _, key := newFilesTestManager(t, map[string]string{
+		"/sandbox/session/a/one.py": "",
+		"/sandbox/session/b/two.py": "",
+		"/sandbox/session/b/two.js": "",
+	})
+
+	res, err := sm.Search(context.Background(), key, SearchOptions{Path: "/sandbox/session/b", Glob: "*.py"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(res.Matches) != 1 || res.Matches[0].Path != "/sandbox/session/b/two.py" || res.Matches[0].Line != 0 {
+		t.Errorf("matches = %+v", res.Matches)
+	}
+
+	if _, err := sm.Search(context.Background(), key, SearchOptions{Path: "/sandbox/session", Query: "(", Regex: true}); !errors.Is(err, ErrInvalidPattern) {
+		t.Errorf("invalid regex: err = %v, want ErrInvalidPattern", err)
+	}
+	if _, err := sm.Search(context.Background(), key, SearchOptions{Path: "/etc"}); err == nil {
+		t.Error("search outside /sandbox/session succeeded")
+	}
+}
+
+func TestMatchLines_TruncatesLongLines(t *testing.T) {
+	long := strings.Repeat("é", maxSearchSnippet)
+	got := matchLines("/f", []byte("x"+long+"\n"), regexp.MustCompile("x"), 0)
+	if len(got) != 1 || len(got[0].Snippet) > maxSearchSnippet+len("…") || !strings.HasSuffix(got[0].Snippet, "…") {
+		t.Errorf("snippet = %q", got[0].Snippet)
+	}
+}
+
+func TestApplyPatch_AllOrNothing(t *testing.T) {
+	files := map[string]string{
+		"/sandbox/session/a.txt": "alpha\n",
+		"/sandbox/session/b.txt": "beta\n",
+	}
+	sm, execd, key := newFilesTestManager(t, files)
+
+	conflicting := "--- a/a.txt\n+++ b/a.txt\n@@ -1 +1 @@\n-alpha\n+ALPHA\n" +
+		"--- a/b.txt\n+++ b/b.txt\n@@ -1 +1 @@\n-gamma\n+GAMMA\n"
+	_, err := sm.ApplyPatch(context.Background(), key, conflicting, "")
+	var conflict *EditConflictError
+	if !errors.As(err, &conflict) || len(conflict.Conflicts) != 1 || conflict.Conflicts[0].Path != "/sandbox/session/b.txt" {
+		t.Fatalf("err = %v, want one conflict in b.txt", err)
+	}
+	if execd.uploads != 0 || files["/sandbox/session/a.txt"] != "alpha\n" {
+		t.Fatal("a conflicting patch changed files")
+	}
+
+	ok := "--- a/a.txt\n+++ b/a.txt\n@@ -1 +1 @@\n-alpha\n+ALPHA\n" +
+		"--- /dev/null\n+++ b/c.txt\n@@ -0,0 +1 @@\n+gamma\n"
+	edits, err := sm.ApplyPatch(context.Background(), key, ok, "")
+	if err != nil {
+		t.Fatalf("ApplyPatch: %v", err)
+	}
+	if len(edits) != 2 || !edits[1].Created || execd.uploads != 1 {
+		t.Errorf("edits = %+v, uploads = %d", edits, execd.uploads)
+	}
+	if files["/sandbox/session/a.txt"] != "ALPHA\n" || files["/sandbox/session/c.txt"] != "gamma\n" {
+		t.Errorf("files = %q", files)
+	}
+}
+
+func TestEditFile(t *testing.T) {
+	files := map[string]string{"/sandbox/session/app.py": "DEBUG = True\n"}
+	sm, _, key := newFilesTestManager(t, files)
+
+	fe, err := sm.EditFile(context.Background(), key, "/sandbox/session/app.py", []EditReplacement{{OldString: "True", NewString: "False"}})
+	if err != nil {
+		t.Fatalf("EditFile: %v", err)
+	}
+	if fe.Replacements != 1 || files["/sandbox/session/app.py"] != "DEBUG = False\n" {
+		t.Errorf("edit = %+v, file = %q", fe, files["/sandbox/session/app.py"])
+	}
+
+	_, err = sm.EditFile(context.Background(), key, "/sandbox/session/app.py", []EditReplacement{{OldString: "True", NewString: "False"}})
+	var conflict *EditConflictError
+	if !errors.As(err, &conflict) || conflict.Conflicts[0].Path != "/sandbox/session/app.py" {
+		t.Errorf("err = %v, want conflict", err)
+	}
+}
@@ -39,6 +39,10 @@ type ManagedSandbox struct {
 	CreatedAt  time.Time
 	LastUsedAt time.Time
 	Image      string
+
+	// leased reports whether this replica holds the session's ownership
+	// lease. Guarded by SessionManager.mu.
+	leased bool
 }
 
 // SandboxSessionOpts configures sandbox creation for a session.
@@ -150,6 +154,15 @@ func (sm *SessionManager) createSandbox(ctx context.Context, tenantID, externalI
 		return nil, fmt.Errorf("session manager: get or create session: %w", err)
 	}
 
+	// Another replica may already run a sandbox for this session.
+	existing, staleSandboxID, err := sm.attachExisting(ctx, key, tenantID, externalID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
 	// Resolve defaults.
 	image := opts.Image
 	if image == "" {
@@ -251,6 +264,34 @@ func (sm *SessionManager) createSandbox(ctx context.Context, tenantID, externalI
 		CreatedAt:  time.Now(),
 		LastUsedAt: time.Now(),
 		Image:      image,
+		leased:     true,
+	}
+
+	// Record ownership. If another replica recorded a sandbox first, use
+	// that one and discard ours.
+	won, err := sm.recordSandbox(ctx, ms, staleSandboxID)
+	if err != nil {
+		_ = sm.client.DeleteSandbox(context.Background(), sbResp.ID)
+		return nil, err
+	}
+	if !won {
+		slog.Info("session manager: lost sandbox creation race, attaching to winner",
+			"key", key,
+			"discarded_sandbox_id", sbResp.ID,
+		)
+		_ = sm.client.DeleteSandbox(context.Background(), sbResp.ID)
+		winner, _, err := sm.attachExisting(ctx, key, tenantID, externalID)
+		if err != nil {
+			return nil, err
+		}
+		if winner == nil {
+			return nil, fmt.Errorf("session manager: sandbox for %q was created concurrently but is unreachable", key)
+		}
+		return winner, nil
+	}
+	if staleSandboxID != "" {
+		// The replaced sandbox was unreachable; make sure it is gone.
+		_ = sm.client.DeleteSandbox(context.Background(), staleSandboxID)
 	}
 
 	sm.mu.Lock()
@@ -638,9 +679,12 @@ func (sm *SessionManager) SyncSessionFiles(ctx context.Context, key string) erro
 	return nil
 }
 
-// Cleanup finds sandboxes that have been idle longer than maxIdle, syncs
-// their files, then deletes them from OpenSandbox and removes them from
-// the managed map. Called by a background goroutine.
+// Cleanup handles sandboxes that have been idle on this replica longer than
+// maxIdle. A sandbox whose lease this replica holds is synced and deleted
+// once no replica has used it for maxIdle; if another replica used it more
+// recently, the lease is released so that replica takes it over. Sandboxes
+// held by others are only dropped from the local map. Called by a
+// background goroutine on every replica.
 func (sm *SessionManager) Cleanup(ctx context.Context, maxIdle time.Duration) {
 	sm.mu.Lock()
 	var expired []string
@@ -651,17 +695,22 @@ func (sm *SessionManager) Cleanup(ctx context.Context, maxIdle time.Duration) {
 	}
 	sm.mu.Unlock()
 
+	cleaned := 0
 	for _, key := range expired {
-		slog.Info("session manager: cleaning up idle sandbox", "key", key)
-		sm.syncAndRemove(ctx, key, "cleanup")
+		if sm.releaseIdle(ctx, key, maxIdle) {
+			cleaned++
+		}
 	}
 
-	if len(expired) > 0 {
-		slog.Info("session manager: cleanup complete", "cleaned", len(expired))
+	if cleaned > 0 {
+		slog.Info("session manager: cleanup complete", "cleaned", cleaned)
 	}
 }
 
-// Shutdown syncs all managed session files and deletes all managed sandboxes.
+// Shutdown syncs the files of every sandbox this replica holds the lease
+// for and releases the leases, then forgets all local sandboxes. Sandboxes
+// are left running so other replicas (or this one after a restart) can
+// attach to them; idle ones are removed by a later Cleanup on any replica.
 // Called during graceful server shutdown.
 func (sm *SessionManager) Shutdown(ctx context.Context) {
 	sm.mu.Lock()
@@ -674,7 +723,28 @@ func (sm *SessionManager) Shutdown(ctx context.Context) {
 	slog.Info("session manager: shutting down", "active_sandboxes", len(keys))
 
 	for _, key := range keys {
-		sm.syncAndRemove(ctx, key, "shutdown")
+		sm.mu.Lock()
+		ms, ok := sm.sessions[key]
+		leased := ok && ms.leased
+		sm.mu.Unlock()
+		if !ok {
+			continue
+		}
+		if leased {
+			if err := sm.SyncSessionFiles(ctx, key); err != nil {
+				slog.Warn("session manager: shutdown sync failed",
+					"key", key,
+					"error", err,
+				)
+			}
+			if err := sm.store.ReleaseSessionSandboxLease(ctx, ms.TenantID, ms.ExternalID, sm.config.ReplicaID); err != nil {
+				slog.Warn("session manager: shutdown lease release failed",
+					"key", key,
+					"error", err,
+				)
+			}
+		}
+		sm.forget(key, ms.SandboxID)
 	}
 
 	slog.Info("session manager: shutdown complete")
@@ -727,8 +797,28 @@ func isSandboxGone(err error) bool {
 }
 
 // Destroy tears down a specific session sandbox. It syncs files first,
-// then deletes the sandbox and removes it from the managed map.
+// then deletes the sandbox and its ownership record and removes it from
+// the managed map. A sandbox created by another replica is attached first,
+// so Destroy works on any replica.
 func (sm *SessionManager) Destroy(ctx context.Context, key string) error {
+	// The sandbox may be running on behalf of another replica.
+	sm.mu.Lock()
+	_, local := sm.sessions[key]
+	sm.mu.Unlock()
+	if tenantID, externalID, ok := strings.Cut(key, ":"); ok && !local {
+		if _, _, err := sm.attachExisting(ctx, key, tenantID, externalID); err != nil {
+			return err
+		}
+	}
+
+	// Stop background processes first so their final writes are synced.
+	if err := sm.StopProcesses(ctx, key); err != nil {
+		slog.Warn("session manager: destroy stop processes failed",
+			"key", key,
+			"error", err,
+		)
+	}
+
 	// Sync files before destroying.
 	if err := sm.SyncSessionFiles(ctx, key); err != nil {
 		slog.Warn("session manager: destroy sync failed",
@@ -751,6 +841,12 @@ func (sm *SessionManager) Destroy(ctx context.Context, key string) error {
 	if err := sm.client.DeleteSandbox(ctx, ms.SandboxID); err != nil {
 		return fmt.Errorf("session manager: destroy sandbox: %w", err)
 	}
+	if err := sm.store.DeleteSessionSandbox(ctx, ms.TenantID, ms.ExternalID, ms.SandboxID); err != nil {
+		slog.Warn("session manager: destroy delete sandbox record failed",
+			"key", key,
+			"error", err,
+		)
+	}
 
 	slog.Info("session manager: sandbox destroyed",
 		"sandbox_id", ms.SandboxID,
@@ -759,3 +855,9 @@ func (sm *SessionManager) Destroy(ctx context.Context, key string) error {
 
 	return nil
 }
+
+// GetEndpoint resolves the externally reachable address of port inside
+// sandboxID.
+func (sm *SessionManager) GetEndpoint(ctx context.Context, sandboxID string, port int) (*Endpoint, error) {
+	return sm.client.GetEndpoint(ctx, sandboxID, port)
+}
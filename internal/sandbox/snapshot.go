@@ -0,0 +1,525 @@
+package sandbox
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+const (
+	// maxSnapshotSize caps the uncompressed size of a workspace snapshot.
+	maxSnapshotSize = 1 << 30 // 1 GiB
+	// snapshotPruneBatch bounds how many expired snapshots one PruneSnapshots
+	// pass deletes.
+	snapshotPruneBatch = 100
+	// sessionWorkspace is the sandbox directory snapshots capture.
+	sessionWorkspace = "/sandbox/session"
+)
+
+var (
+	// ErrSessionExists is returned by ForkSnapshot when the target session
+	// ID is already in use.
+	ErrSessionExists = errors.New("session already exists")
+	// ErrSnapshotTooLarge is returned when a workspace exceeds maxSnapshotSize.
+	ErrSnapshotTooLarge = errors.New("workspace exceeds snapshot size limit")
+)
+
+// snapshotEntry is one file of a workspace snapshot. Name is relative to
+// /sandbox/session.
+type snapshotEntry struct {
+	Name string
+	Data []byte
+}
+
+// CreateSnapshot bundles the session's workspace into a new snapshot. The
+// workspace is read from the live sandbox when there is one, otherwise from
+// the session files last synced to object storage. Taking a snapshot with
+// an existing name adds a new version of it. Afterwards the tenant's oldest
+// snapshots are pruned down to the configured limit.
+func (sm *SessionManager) CreateSnapshot(ctx context.Context, tenantID, externalID, name string) (*store.Snapshot, error) {
+	sess, err := sm.store.GetSessionByExternalID(ctx, tenantID, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("session manager: snapshot: %w", err)
+	}
+
+	ms, err := sm.liveSandbox(ctx, tenantID, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("session manager: snapshot: %w", err)
+	}
+	live := ms != nil
+
+	var entries []snapshotEntry
+	if live {
+		entries, err = sm.readSandboxWorkspace(ctx, ms)
+	} else {
+		entries, err = sm.readStoredWorkspace(ctx, tenantID, sess.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("session manager: snapshot: %w", err)
+	}
+
+	bundle, err := buildSnapshotBundle(entries)
+	if err != nil {
+		return nil, fmt.Errorf("session manager: snapshot: %w", err)
+	}
+
+	id := uuid.NewString()
+	s3Key := fmt.Sprintf("%s/snapshots/%s/%s.tar.gz", tenantID, sess.ID, id)
+	if _, err := sm.artifacts.UploadObject(ctx, s3Key, bytes.NewReader(bundle), int64(len(bundle)), "application/gzip"); err != nil {
+		return nil, fmt.Errorf("session manager: snapshot upload: %w", err)
+	}
+
+	snap, err := sm.store.CreateSnapshot(ctx, &store.Snapshot{
+		ID:        id,
+		TenantID:  tenantID,
+		SessionID: sess.ID,
+		Name:      name,
+		S3Key:     s3Key,
+		SizeBytes: int64(len(bundle)),
+		FileCount: len(entries),
+	})
+	if err != nil {
+		_ = sm.artifacts.DeleteObject(context.Background(), s3Key)
+		return nil, fmt.Errorf("session manager: snapshot: %w", err)
+	}
+
+	slog.Info("session manager: snapshot created",
+		"tenant_id", tenantID,
+		"external_id", externalID,
+		"snapshot_id", snap.ID,
+		"name", snap.Name,
+		"version", snap.Version,
+		"files", snap.FileCount,
+		"live", live,
+	)
+
+	sm.enforceSnapshotLimit(ctx, tenantID)
+	return snap, nil
+}
+
+// ListSnapshots returns the snapshots of a session, newest first.
+func (sm *SessionManager) ListSnapshots(ctx context.Context, tenantID, externalID string) ([]*store.Snapshot, error) {
+	sess, err := sm.store.GetSessionByExternalID(ctx, tenantID, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("session manager: list snapshots: %w", err)
+	}
+	return sm.store.ListSnapshots(ctx, tenantID, sess.ID)
+}
+
+// DeleteSnapshot removes a session's snapshot and its bundle.
+func (sm *SessionManager) DeleteSnapshot(ctx context.Context, tenantID, externalID, snapshotID string) error {
+	_, snap, err := sm.sessionSnapshot(ctx, tenantID, externalID, snapshotID)
+	if err != nil {
+		return fmt.Errorf("session manager: delete snapshot: %w", err)
+	}
+	return sm.removeSnapshot(ctx, snap)
+}
+
+// RestoreSnapshot replaces the session's workspace with the snapshot's
+// contents. The persisted session files are replaced so the next sandbox
+// mounts the restored state, and a live sandbox is rewritten in place.
+func (sm *SessionManager) RestoreSnapshot(ctx context.Context, tenantID, externalID, snapshotID string) (*store.Snapshot, error) {
+	sess, snap, err := sm.sessionSnapshot(ctx, tenantID, externalID, snapshotID)
+	if err != nil {
+		return nil, fmt.Errorf("session manager: restore: %w", err)
+	}
+	entries, err := sm.loadSnapshot(ctx, snap)
+	if err != nil {
+		return nil, fmt.Errorf("session manager: restore: %w", err)
+	}
+
+	if err := sm.replaceStoredWorkspace(ctx, tenantID, externalID, sess.ID, entries); err != nil {
+		return nil, fmt.Errorf("session manager: restore: %w", err)
+	}
+
+	ms, err := sm.liveSandbox(ctx, tenantID, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("session manager: restore: %w", err)
+	}
+	live := ms != nil
+	if live {
+		if err := sm.replaceSandboxWorkspace(ctx, ms, entries); err != nil {
+			if isConnectionError(err) {
+				sm.evictStale(sessionKey(tenantID, externalID), ms.SandboxID, err)
+			}
+			return nil, fmt.Errorf("session manager: restore sandbox: %w", err)
+		}
+	}
+
+	slog.Info("session manager: snapshot restored",
+		"tenant_id", tenantID,
+		"external_id", externalID,
+		"snapshot_id", snap.ID,
+		"files", len(entries),
+		"live", live,
+	)
+	return snap, nil
+}
+
+// ForkSnapshot creates a new session whose workspace starts as a copy of
+// the snapshot. The new session's sandbox is created lazily on first use,
+// like any other session.
+func (sm *SessionManager) ForkSnapshot(ctx context.Context, tenantID, externalID, snapshotID, newExternalID string) (*store.Session, error) {
+	_, snap, err := sm.sessionSnapshot(ctx, tenantID, externalID, snapshotID)
+	if err != nil {
+		return nil, fmt.Errorf("session manager: fork: %w", err)
+	}
+
+	if _, err := sm.store.GetSessionByExternalID(ctx, tenantID, newExternalID); err == nil {
+		return nil, fmt.Errorf("session manager: fork: %w", ErrSessionExists)
+	} else if !errors.Is(err, store.ErrNotFound) {
+		return nil, fmt.Errorf("session manager: fork: %w", err)
+	}
+
+	entries, err := sm.loadSnapshot(ctx, snap)
+	if err != nil {
+		return nil, fmt.Errorf("session manager: fork: %w", err)
+	}
+
+	sess, err := sm.store.GetOrCreateSession(ctx, tenantID, newExternalID)
+	if err != nil {
+		return nil, fmt.Errorf("session manager: fork: %w", err)
+	}
+	if err := sm.replaceStoredWorkspace(ctx, tenantID, newExternalID, sess.ID, entries); err != nil {
+		return nil, fmt.Errorf("session manager: fork: %w", err)
+	}
+
+	slog.Info("session manager: session forked",
+		"tenant_id", tenantID,
+		"source_external_id", externalID,
+		"snapshot_id", snap.ID,
+		"external_id", newExternalID,
+	)
+	return sess, nil
+}
+
+// PruneSnapshots deletes snapshots older than the configured maximum age.
+// It is a no-op when no maximum age is set. Called by a background goroutine.
+func (sm *SessionManager) PruneSnapshots(ctx context.Context) {
+	if sm.config.SnapshotMaxAge <= 0 {
+		return
+	}
+	expired, err := sm.store.ListSnapshotsCreatedBefore(ctx, time.Now().Add(-sm.config.SnapshotMaxAge), snapshotPruneBatch)
+	if err != nil {
+		slog.Warn("session manager: list expired snapshots failed", "error", err)
+		return
+	}
+	for _, snap := range expired {
+		if err := sm.removeSnapshot(ctx, snap); err != nil {
+			slog.Warn("session manager: prune snapshot failed",
+				"snapshot_id", snap.ID,
+				"error", err,
+			)
+		}
+	}
+	if len(expired) > 0 {
+		slog.Info("session manager: expired snapshots pruned", "pruned", len(expired))
+	}
+}
+
+// enforceSnapshotLimit deletes the tenant's oldest snapshots beyond the
+// configured per-tenant limit. Failures are logged, not returned: the new
+// snapshot has already been created.
+func (sm *SessionManager) enforceSnapshotLimit(ctx context.Context, tenantID string) {
+	over, err := sm.store.ListSnapshotsOverLimit(ctx, tenantID, sm.config.SnapshotMaxPerTenant)
+	if err != nil {
+		slog.Warn("session manager: list snapshots over limit failed",
+			"tenant_id", tenantID,
+			"error", err,
+		)
+		return
+	}
+	for _, snap := range over {
+		if err := sm.removeSnapshot(ctx, snap); err != nil {
+			slog.Warn("session manager: prune snapshot failed",
+				"snapshot_id", snap.ID,
+				"error", err,
+			)
+		}
+	}
+}
+
+// removeSnapshot deletes a snapshot's bundle and then its record.
+func (sm *SessionManager) removeSnapshot(ctx context.Context, snap *store.Snapshot) error {
+	if err := sm.artifacts.DeleteObject(ctx, snap.S3Key); err != nil {
+		return err
+	}
+	return sm.store.DeleteSnapshot(ctx, snap.TenantID, snap.ID)
+}
+
+// sessionSnapshot resolves a session and one of its snapshots. A snapshot
+// of a different session is reported as not found.
+func (sm *SessionManager) sessionSnapshot(ctx context.Context, tenantID, externalID, snapshotID string) (*store.Session, *store.Snapshot, error) {
+	sess, err := sm.store.GetSessionByExternalID(ctx, tenantID, externalID)
+	if err != nil {
+		return nil, nil, err
+	}
+	snap, err := sm.store.GetSnapshot(ctx, tenantID, snapshotID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if snap.SessionID != sess.ID {
+		return nil, nil, store.ErrNotFound
+	}
+	return sess, snap, nil
+}
+
+// loadSnapshot downloads and unpacks a snapshot bundle.
+func (sm *SessionManager) loadSnapshot(ctx context.Context, snap *store.Snapshot) ([]snapshotEntry, error) {
+	rc, _, _, err := sm.artifacts.DownloadObject(ctx, snap.S3Key)
+	if err != nil {
+		return nil, fmt.Errorf("download snapshot: %w", err)
+	}
+	defer rc.Close() //nolint:errcheck
+	return readSnapshotBundle(rc)
+}
+
+// readSandboxWorkspace reads every file under /sandbox/session from a live
+// sandbox. Unlike SyncSessionFiles it fails on the first unreadable file,
+// since a snapshot with silently missing files would be worse than none.
+func (sm *SessionManager) readSandboxWorkspace(ctx context.Context, ms *ManagedSandbox) ([]snapshotEntry, error) {
+	files, err := sm.client.SearchFiles(ctx, ms.ExecDURL, sessionWorkspace, "**")
+	if err != nil {
+		return nil, fmt.Errorf("list workspace: %w", err)
+	}
+
+	var entries []snapshotEntry
+	var total int64
+	for _, f := range files {
+		name := strings.TrimPrefix(path.Clean(f.Path), sessionWorkspace+"/")
+		if !snapshotIncludes(name) || name == path.Clean(f.Path) {
+			continue
+		}
+		total += f.Size
+		if total > maxSnapshotSize {
+			return nil, ErrSnapshotTooLarge
+		}
+
+		rc, err := sm.client.DownloadFile(ctx, ms.ExecDURL, f.Path)
+		if err != nil {
+			return nil, fmt.Errorf("download %s: %w", f.Path, err)
+		}
+		data, err := readSessionFile(rc, maxSessionFileSize)
+		_ = rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", f.Path, err)
+		}
+		entries = append(entries, snapshotEntry{Name: name, Data: data})
+	}
+	return entries, nil
+}
+
+// readSessionFile reads a workspace file of at most limit bytes. A larger
+// file is an error rather than being cut short, which would corrupt the
+// workspace.
+func readSessionFile(r io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("file exceeds the %d byte limit", limit)
+	}
+	return data, nil
+}
+
+// readStoredWorkspace rebuilds a workspace from the session files synced
+// to object storage. Sync records each pass as a new row, so only the
+// newest record per name is used.
+func (sm *SessionManager) readStoredWorkspace(ctx context.Context, tenantID, sessionID string) ([]snapshotEntry, error) {
+	files, err := sm.store.ListAllSessionFiles(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("list session files: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var entries []snapshotEntry
+	var total int64
+	for _, f := range files {
+		if seen[f.Name] || !snapshotIncludes(f.Name) {
+			continue
+		}
+		seen[f.Name] = true
+		total += f.SizeBytes
+		if total > maxSnapshotSize {
+			return nil, ErrSnapshotTooLarge
+		}
+
+		rc, _, _, err := sm.artifacts.DownloadObject(ctx, f.S3Key)
+		if err != nil {
+			return nil, fmt.Errorf("download %s: %w", f.Name, err)
+		}
+		data, err := readSessionFile(rc, maxSessionFileSize)
+		_ = rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", f.Name, err)
+		}
+		entries = append(entries, snapshotEntry{Name: f.Name, Data: data})
+	}
+	return entries, nil
+}
+
+// replaceStoredWorkspace makes entries the session's persisted files:
+// new objects and records are written first, then the previous records
+// (and any objects no longer referenced) are removed.
+func (sm *SessionManager) replaceStoredWorkspace(ctx context.Context, tenantID, externalID, sessionID string, entries []snapshotEntry) error {
+	previous, err := sm.store.ListAllSessionFiles(ctx, tenantID, sessionID)
+	if err != nil {
+		return fmt.Errorf("list session files: %w", err)
+	}
+
+	written := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		s3Key := fmt.Sprintf("%s/sessions/%s/%s", tenantID, externalID, e.Name)
+		if _, err := sm.artifacts.UploadObject(ctx, s3Key, bytes.NewReader(e.Data), int64(len(e.Data)), "application/octet-stream"); err != nil {
+			return fmt.Errorf("upload %s: %w", e.Name, err)
+		}
+		if _, err := sm.store.CreateFile(ctx, &store.File{
+			TenantID:    tenantID,
+			SessionID:   sessionID,
+			Name:        e.Name,
+			ContentType: "application/octet-stream",
+			SizeBytes:   int64(len(e.Data)),
+			S3Key:       s3Key,
+			Version:     1,
+		}); err != nil {
+			return fmt.Errorf("create file record %s: %w", e.Name, err)
+		}
+		written[s3Key] = true
+	}
+
+	for _, f := range previous {
+		if !written[f.S3Key] {
+			if err := sm.artifacts.DeleteObject(ctx, f.S3Key); err != nil {
+				slog.Warn("session manager: failed to delete replaced session file",
+					"s3_key", f.S3Key,
+					"error", err,
+				)
+			}
+		}
+		if err := sm.store.DeleteFile(ctx, f.ID, tenantID); err != nil {
+			slog.Warn("session manager: failed to delete replaced file record",
+				"file_id", f.ID,
+				"error", err,
+			)
+		}
+	}
+	return nil
+}
+
+// replaceSandboxWorkspace empties /sandbox/session in a live sandbox and
+// writes entries into it.
+func (sm *SessionManager) replaceSandboxWorkspace(ctx context.Context, ms *ManagedSandbox, entries []snapshotEntry) error {
+	res, err := sm.client.RunCommand(ctx, ms.ExecDURL, "find "+sessionWorkspace+" -mindepth 1 -delete", "/", 60_000)
+	if err != nil {
+		return fmt.Errorf("clear workspace: %w", err)
+	}
+	if res.ExitCode != 0 {
+		return fmt.Errorf("clear workspace: exit %d: %s", res.ExitCode, strings.TrimSpace(res.Stderr))
+	}
+
+	uploads := []FileUpload{
+		{Path: sessionWorkspace + "/.keep", Content: []byte(""), Mode: 0o644},
+		{Path: sessionWorkspace + "/skills/.keep", Content: []byte(""), Mode: 0o644},
+		{Path: sessionWorkspace + "/outputs/.keep", Content: []byte(""), Mode: 0o644},
+	}
+	for _, e := range entries {
+		uploads = append(uploads, FileUpload{Path: sessionWorkspace + "/" + e.Name, Content: e.Data, Mode: 0o644})
+	}
+	return sm.client.UploadFiles(ctx, ms.ExecDURL, uploads)
+}
+
+// snapshotIncludes reports whether a workspace-relative file belongs in a
+// snapshot. Placeholders are recreated on restore and drive files are
+// persisted elsewhere.
+func snapshotIncludes(name string) bool {
+	if name == "" || name == ".keep" || strings.HasSuffix(name, "/.keep") {
+		return false
+	}
+	return !strings.HasPrefix(name, "drive/") && !strings.Contains(name, "/drive/")
+}
+
+// buildSnapshotBundle packs entries into a tar.gz archive, sorted by name
+// so identical workspaces produce identical bundles.
+func buildSnapshotBundle(entries []snapshotEntry) ([]byte, error) {
+	sorted := make([]snapshotEntry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for _, e := range sorted {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:     e.Name,
+			Mode:     0o644,
+			Size:     int64(len(e.Data)),
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			return nil, fmt.Errorf("write tar header %s: %w", e.Name, err)
+		}
+		if _, err := tw.Write(e.Data); err != nil {
+			return nil, fmt.Errorf("write tar entry %s: %w", e.Name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("close tar: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return nil, fmt.Errorf("close gzip: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// readSnapshotBundle unpacks a tar.gz bundle, rejecting entries that would
+// escape the workspace and bundles over maxSnapshotSize.
+func readSnapshotBundle(r io.Reader) ([]snapshotEntry, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("open snapshot bundle: %w", err)
+	}
+	defer gr.Close() //nolint:errcheck
+
+	tr := tar.NewReader(gr)
+	var entries []snapshotEntry
+	var total int64
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read snapshot bundle: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		if name == "." || path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("snapshot bundle: unsafe entry name %q", hdr.Name)
+		}
+		total += hdr.Size
+		if total > maxSnapshotSize {
+			return nil, ErrSnapshotTooLarge
+		}
+		data, err := readSessionFile(tr, maxSessionFileSize)
+		if err != nil {
+			return nil, fmt.Errorf("read snapshot entry %s: %w", name, err)
+		}
+		entries = append(entries, snapshotEntry{Name: name, Data: data})
+	}
+	return entries, nil
+}
@@ -0,0 +1,184 @@
+package sandbox
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSnapshotBundle_RoundTrip(t *testing.T) {
+	entries := []snapshotEntry{
+		{Name: "outputs/report.md", Data: []byte("# Report\n")},
+		{Name: "data.csv", Data: []byte("a,b\n1,2\n")},
+		{Name: "empty.txt", Data: []byte{}},
+	}
+
+	bundle, err := buildSnapshotBundle(entries)
+	if err != nil {
+		t.Fatalf("buildSnapshotBundle: %v", err)
+	}
+	got, err := readSnapshotBundle(bytes.NewReader(bundle))
+	if err != nil {
+		t.Fatalf("readSnapshotBundle: %v", err)
+	}
+
+	want := map[string]string{
+		"data.csv":          "a,b\n1,2\n",
+		"empty.txt":         "",
+		"outputs/report.md": "# Report\n",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(got), len(want))
+	}
+	for i, e := range got {
+		if w, ok := want[e.Name]; !ok || string(e.Data) != w {
+			t.Errorf("entry %q = %q, want %q", e.Name, e.Data, w)
+		}
+		if i > 0 && got[i-1].Name > e.Name {
+			t.Errorf("entries not sorted: %q before %q", got[i-1].Name, e.Name)
+		}
+	}
+
+	again, err := buildSnapshotBundle([]snapshotEntry{entries[2], entries[0], entries[1]})
+	if err != nil {
+		t.Fatalf("buildSnapshotBundle: %v", err)
+	}
+	if !bytes.Equal(bundle, again) {
+		t.Error("bundle depends on entry order, want deterministic output")
+	}
+}
+
+func TestReadSnapshotBundle_RejectsUnsafeNames(t *testing.T) {
+	for _, name := range []string{"../escape", "/etc/passwd", "a/../../b"} {
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gw)
+		_ = tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: 1, Typeflag: tar.TypeReg})
+		_, _ = tw.Write([]byte("x"))
+		_ = tw.Close()
+		_ = gw.Close()
+
+		if _, err := readSnapshotBundle(&buf); err == nil {
+			t.Errorf("readSnapshotBundle(%q) = nil error, want rejection", name)
+		}
+	}
+}
+
+func TestSnapshotIncludes(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"report.md", true},
+		{"outputs/chart.png", true},
+		{"skills/my-skill/SKILL.md", true},
+		{".keep", false},
+		{"outputs/.keep", false},
+		{"drive/shared.txt", false},
+		{"outputs/drive/x", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := snapshotIncludes(tt.name); got != tt.want {
+			t.Errorf("snapshotIncludes(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReadSandboxWorkspace(t *testing.T) {
+	files := map[string]string{
+		"/sandbox/session/notes.txt":         "hello",
+		"/sandbox/session/outputs/.keep":     "",
+		"/sandbox/session/drive/shared.txt":  "skip me",
+		"/sandbox/session/outputs/result.md": "done",
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files/search":
+			var list []map[string]any
+			for p, data := range files {
+				list = append(list, map[string]any{"path": p, "size": len(data), "modified_at": time.Now()})
+			}
+			_ = json.NewEncoder(w).Encode(list)
+		case "/files/download":
+			data, ok := files[r.URL.Query().Get("path")]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write([]byte(data))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	sm := &SessionManager{client: New("http://unused", "", srv.Client())}
+	entries, err := sm.readSandboxWorkspace(context.Background(), &ManagedSandbox{ExecDURL: srv.URL})
+	if err != nil {
+		t.Fatalf("readSandboxWorkspace: %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, e := range entries {
+		got[e.Name] = string(e.Data)
+	}
+	want := map[string]string{"notes.txt": "hello", "outputs/result.md": "done"}
+	if len(got) != len(want) {
+		t.Fatalf("entries = %v, want %v", got, want)
+	}
+	for name, data := range want {
+		if got[name] != data {
+			t.Errorf("entry %q = %q, want %q", name, got[name], data)
+		}
+	}
+}
+
+func TestReadSandboxWorkspace_FailsOnUnreadableFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/files/search" {
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"path": "/sandbox/session/gone.txt", "size": 3}})
+			return
+		}
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	sm := &SessionManager{client: New("http://unused", "", srv.Client())}
+	if _, err := sm.readSandboxWorkspace(context.Background(), &ManagedSandbox{ExecDURL: srv.URL}); err == nil {
+		t.Fatal("expected error for unreadable file, got nil")
+	}
+}
+
+func TestReadSandboxWorkspace_TooLarge(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]map[string]any{
+			{"path": "/sandbox/session/a.bin", "size": maxSnapshotSize},
+			{"path": "/sandbox/session/b.bin", "size": 1},
+		})
+	}))
+	defer srv.Close()
+
+	sm := &SessionManager{client: New("http://unused", "", srv.Client())}
+	_, err := sm.readSandboxWorkspace(context.Background(), &ManagedSandbox{ExecDURL: srv.URL})
+	if !errors.Is(err, ErrSnapshotTooLarge) {
+		t.Fatalf("err = %v, want ErrSnapshotTooLarge", err)
+	}
+}
+
+func TestReadSessionFile_RejectsOversizedFile(t *testing.T) {
+	data, err := readSessionFile(bytes.NewReader([]byte("12345")), 5)
+	if err != nil || string(data) != "12345" {
+		t.Fatalf("readSessionFile at the limit = %q, %v", data, err)
+	}
+	if _, err := readSessionFile(bytes.NewReader([]byte("123456")), 5); err == nil {
+		t.Fatal("expected error for a file over the limit, got nil")
+	}
+}
@@ -0,0 +1,264 @@
+// Package sandboxenv describes the contract between Skillbox and the skill
+// code it executes: where the skill's files, input and output live, which
+// environment variables the skill sees, and how its entrypoint is started.
+//
+// The runner applies the contract inside an OpenSandbox sandbox rooted at
+// /sandbox. `skillbox dev` applies the same contract to a directory on the
+// developer's machine, so a skill that honours the SANDBOX_* variables
+// behaves identically in both places.
+package sandboxenv
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Layout locates the directories of the sandbox contract.
+type Layout struct {
+	// Root holds scripts/, input/, input.json and out/. Commands run with
+	// Root as their working directory.
+	Root string
+
+	// Home is the skill's HOME and scratch space for installed
+	// dependencies.
+	Home string
+}
+
+// Sandbox is the layout used inside execution sandboxes.
+var Sandbox = Layout{Root: "/sandbox", Home: "/tmp"}
+
+// ScriptsDir is where the skill's files are placed.
+func (l Layout) ScriptsDir() string { return l.join("scripts") }
+
+// InputFile is the path of the input JSON document.
+func (l Layout) InputFile() string { return l.join("input.json") }
+
+// InputDir is where caller-supplied input files are placed.
+func (l Layout) InputDir() string { return l.join("input") }
+
+// OutputFile is where the skill writes its JSON result.
+func (l Layout) OutputFile() string { return l.join("out", "output.json") }
+
+// FilesDir is where the skill writes output files.
+func (l Layout) FilesDir() string { return l.join("out", "files") }
+
+// SessionDir is where a session's persistent workspace is mounted.
+func (l Layout) SessionDir() string { return l.join("session") }
+
+func (l Layout) join(elem ...string) string {
+	return filepath.Join(append([]string{l.Root}, elem...)...)
+}
+
+// dir returns p with a trailing separator, the form the SANDBOX_*_DIR
+// variables use.
+func dir(p string) string {
+	return p + string(filepath.Separator)
+}
+
+// Env returns the environment for a skill run: the contract variables for
+// input, instructions and the l layout, plus the caller's extra variables.
+// It returns an error naming the first extra variable that is blocked, see
+// IsBlockedEnvVar.
+func Env(l Layout, input []byte, instructions string, extra map[string]string) (map[string]string, error) {
+	if input == nil {
+		input = []byte("{}")
+	}
+	env := map[string]string{
+		"SANDBOX_INPUT":      string(input),
+		"SANDBOX_OUTPUT":     l.OutputFile(),
+		"SANDBOX_FILES_DIR":  dir(l.FilesDir()),
+		"SANDBOX_INPUT_DIR":  dir(l.InputDir()),
+		"SKILL_INSTRUCTIONS": instructions,
+		"HOME":               l.Home,
+	}
+	for k, v := range extra {
+		if IsBlockedEnvVar(k) {
+			return nil, fmt.Errorf("env var %q is not allowed", k)
+		}
+		env[k] = v
+	}
+	return env, nil
+}
+
+// blockedEnvVars lists environment variable names that callers may not
+// override. These are either security-sensitive (e.g. LD_PRELOAD) or
+// reserved by the sandbox runtime (SANDBOX_*, SKILL_*).
+var blockedEnvVars = map[string]bool{
+	"PATH":            true,
+	"HOME":            true,
+	"LD_PRELOAD":      true,
+	"LD_LIBRARY_PATH": true,
+	"PYTHONPATH":      true,
+	"NODE_PATH":       true,
+	"NODE_OPTIONS":    true,
+}
+
+// IsBlockedEnvVar returns true if the given key must not be set by callers.
+func IsBlockedEnvVar(key string) bool {
+	if blockedEnvVars[key] {
+		return true
+	}
+	upper := strings.ToUpper(key)
+	return strings.HasPrefix(upper, "SANDBOX_") || strings.HasPrefix(upper, "SKILL_")
+}
+
+// ShellCommand constructs the shell command that starts entrypoint, a path
+// relative to the scripts directory, based on the skill's language and
+// whether a requirements.txt is present. Paths are quoted, so layouts and
+// entrypoints may contain spaces or shell metacharacters.
+func ShellCommand(l Layout, lang, entrypoint string, hasRequirements bool) string {
+	ep := ShellQuote(filepath.Join(l.ScriptsDir(), entrypoint))
+
+	switch lang {
+	case "python":
+		if hasRequirements {
+			deps := ShellQuote(filepath.Join(l.Home, "deps"))
+			return fmt.Sprintf(
+				"pip install --no-cache-dir -r %s -t %s && PYTHONPATH=%s python %s",
+				ShellQuote(filepath.Join(l.ScriptsDir(), "requirements.txt")), deps, deps, ep,
+			)
+		}
+		return fmt.Sprintf("python %s", ep)
+
+	case "node", "nodejs", "javascript":
+		return fmt.Sprintf("node %s", ep)
+
+	case "bash":
+		return fmt.Sprintf("bash %s", ep)
+
+	case "shell", "sh":
+		return fmt.Sprintf("sh %s", ep)
+
+	default:
+		return ep
+	}
+}
+
+// ShellQuote returns s as a single POSIX shell word. Strings made only of
+// characters the shell treats literally are returned unchanged; anything
+// else is wrapped in single quotes.
+func ShellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, needsQuoting) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// needsQuoting reports whether r is outside the set of characters that are
+// literal in every position of an unquoted shell word.
+func needsQuoting(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return false
+	}
+	return !strings.ContainsRune("_@%+=:,./-", r)
+}
+
+// knownEntrypoints lists the accepted entrypoint filenames in priority order.
+var knownEntrypoints = []string{
+	"main.py",
+	"run.py",
+	"main.js",
+	"main.sh",
+}
+
+// FindEntrypoint searches a skill directory for a recognized entrypoint
+// script. It checks both the root and a "scripts/" subdirectory and
+// returns the path relative to dir.
+func FindEntrypoint(dir string) (string, error) {
+	// Check root directory first.
+	for _, name := range knownEntrypoints {
+		if fileExists(filepath.Join(dir, name)) {
+			return name, nil
+		}
+	}
+
+	// Check scripts/ subdirectory.
+	for _, name := range knownEntrypoints {
+		candidate := filepath.Join("scripts", name)
+		if fileExists(filepath.Join(dir, candidate)) {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf(
+		"no recognized entrypoint found (expected one of: %s)",
+		strings.Join(knownEntrypoints, ", "),
+	)
+}
+
+// fileExists returns true if the path exists and is a regular file.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return !info.IsDir()
+}
+
+// CodeRunnerEntrypoint returns a Python script that reads the LLM's input
+// from SANDBOX_INPUT, extracts any Python code block, and executes it.
+// This enables library-style skills (SKILL.md + core/*.py utilities, no
+// main.py) to work the same way as in Claude's web UI. The script must be
+// written to main.py in the scripts directory so the skill's modules are
+// importable.
+func CodeRunnerEntrypoint() []byte {
+	// Built as concatenated strings because the Python code uses backtick
+	// characters (via chr(96)) for matching markdown code fences, and Go
+	// raw string literals cannot contain backticks.
+	script := "#!/usr/bin/env python3\n" +
+		"\"\"\"Auto-generated entrypoint for library-style skill.\"\"\"\n" +
+		"import json, os, re, sys, traceback\n" +
+		"\n" +
+		"sys.path.insert(0, os.path.dirname(os.path.abspath(__file__)))\n" +
+		"\n" +
+		"OUTPUT_DIR = os.environ.get(\"SANDBOX_FILES_DIR\", \"/sandbox/out/files\")\n" +
+		"os.makedirs(OUTPUT_DIR, exist_ok=True)\n" +
+		"\n" +
+		"raw = os.environ.get(\"SANDBOX_INPUT\", \"{}\")\n" +
+		"try:\n" +
+		"    data = json.loads(raw)\n" +
+		"    text = data.get(\"input\", \"\") if isinstance(data, dict) else str(data)\n" +
+		"except Exception:\n" +
+		"    text = raw\n" +
+		"\n" +
+		"bt = chr(96)\n" +
+		"fence3 = bt * 3\n" +
+		"code = None\n" +
+		"for pat in [fence3 + r\"python\\s*\\n(.*?)\" + fence3, fence3 + r\"\\s*\\n(.*?)\" + fence3]:\n" +
+		"    m = re.findall(pat, text, re.DOTALL)\n" +
+		"    if m:\n" +
+		"        code = m[-1].strip()\n" +
+		"        break\n" +
+		"\n" +
+		"if code is None and any(kw in text for kw in [\"import \", \"from \", \"def \", \"class \", \"print(\"]):\n" +
+		"    code = text.strip()\n" +
+		"\n" +
+		"if not code:\n" +
+		"    print(json.dumps({\"status\": \"error\", \"error\": \"No Python code found in input. Send a code block using the skill utilities.\"}))\n" +
+		"    sys.exit(0)\n" +
+		"\n" +
+		"# Redirect bare filenames to the output directory.\n" +
+		"for ext in [\".gif\", \".png\", \".jpg\", \".csv\", \".xlsx\", \".pdf\"]:\n" +
+		"    code = re.sub(\n" +
+		"        \"([\\x27\\x22])([^\\x27\\x22/\\\\\\\\]+\" + re.escape(ext) + \")([\\x27\\x22])\",\n" +
+		"        lambda m: m.group(1) + OUTPUT_DIR + \"/\" + m.group(2) + m.group(3),\n" +
+		"        code,\n" +
+		"    )\n" +
+		"\n" +
+		"try:\n" +
+		"    exec(code, {\"__name__\": \"__main__\"})\n" +
+		"except Exception:\n" +
+		"    traceback.print_exc()\n" +
+		"    print(json.dumps({\"status\": \"error\", \"error\": traceback.format_exc()}))\n" +
+		"    sys.exit(0)\n" +
+		"\n" +
+		"files = [f for f in os.listdir(OUTPUT_DIR) if not f.startswith(\".\")]\n" +
+		"if files:\n" +
+		"    print(json.dumps({\"status\": \"success\", \"files\": files}))\n" +
+		"else:\n" +
+		"    print(json.dumps({\"status\": \"success\", \"message\": \"Code executed (no output files produced).\"}))\n"
+	return []byte(script)
+}
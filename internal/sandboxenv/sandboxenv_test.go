@@ -0,0 +1,235 @@
+package sandboxenv
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// Env
+// ---------------------------------------------------------------------------
+
+func TestEnv_Sandbox(t *testing.T) {
+	env, err := Env(Sandbox, []byte(`{"x":1}`), "do things", map[string]string{"API_KEY": "k"})
+	if err != nil {
+		t.Fatalf("Env: %v", err)
+	}
+	want := map[string]string{
+		"SANDBOX_INPUT":      `{"x":1}`,
+		"SANDBOX_OUTPUT":     "/sandbox/out/output.json",
+		"SANDBOX_FILES_DIR":  "/sandbox/out/files/",
+		"SANDBOX_INPUT_DIR":  "/sandbox/input/",
+		"SKILL_INSTRUCTIONS": "do things",
+		"HOME":               "/tmp",
+		"API_KEY":            "k",
+	}
+	if len(env) != len(want) {
+		t.Errorf("Env returned %d variables, want %d: %v", len(env), len(want), env)
+	}
+	for k, v := range want {
+		if env[k] != v {
+			t.Errorf("env[%s] = %q, want %q", k, env[k], v)
+		}
+	}
+}
+
+func TestEnv_DefaultInput(t *testing.T) {
+	env, err := Env(Sandbox, nil, "", nil)
+	if err != nil {
+		t.Fatalf("Env: %v", err)
+	}
+	if env["SANDBOX_INPUT"] != "{}" {
+		t.Errorf("SANDBOX_INPUT = %q, want {}", env["SANDBOX_INPUT"])
+	}
+}
+
+func TestEnv_LocalLayout(t *testing.T) {
+	l := Layout{Root: "/work/sandbox", Home: "/work/home"}
+	env, err := Env(l, nil, "", nil)
+	if err != nil {
+		t.Fatalf("Env: %v", err)
+	}
+	if got, want := env["SANDBOX_FILES_DIR"], "/work/sandbox/out/files/"; got != want {
+		t.Errorf("SANDBOX_FILES_DIR = %q, want %q", got, want)
+	}
+	if got, want := env["HOME"], "/work/home"; got != want {
+		t.Errorf("HOME = %q, want %q", got, want)
+	}
+}
+
+func TestEnv_BlockedExtra(t *testing.T) {
+	_, err := Env(Sandbox, nil, "", map[string]string{"LD_PRELOAD": "/evil.so"})
+	if err == nil || err.Error() != `env var "LD_PRELOAD" is not allowed` {
+		t.Fatalf("Env error = %v, want LD_PRELOAD rejection", err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// IsBlockedEnvVar
+// ---------------------------------------------------------------------------
+
+func TestIsBlockedEnvVar_ExactMatches(t *testing.T) {
+	blocked := []string{
+		"PATH", "HOME", "LD_PRELOAD", "LD_LIBRARY_PATH",
+		"PYTHONPATH", "NODE_PATH", "NODE_OPTIONS",
+	}
+	for _, key := range blocked {
+		t.Run(key, func(t *testing.T) {
+			if !IsBlockedEnvVar(key) {
+				t.Errorf("IsBlockedEnvVar(%q) = false, want true", key)
+			}
+		})
+	}
+}
+
+func TestIsBlockedEnvVar_Prefixes(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"SANDBOX_INPUT", true},
+		{"SANDBOX_OUTPUT", true},
+		{"sandbox_lower", true}, // case-insensitive prefix check
+		{"Sandbox_Mixed", true}, // case-insensitive prefix check
+		{"SKILL_INSTRUCTIONS", true},
+		{"SKILL_CUSTOM", true},
+		{"skill_lower", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got := IsBlockedEnvVar(tt.key)
+			if got != tt.want {
+				t.Errorf("IsBlockedEnvVar(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsBlockedEnvVar_AllowedVars(t *testing.T) {
+	allowed := []string{
+		"MY_API_KEY",
+		"DATABASE_URL",
+		"CUSTOM_VAR",
+		"FOO",
+		"BAR_BAZ",
+	}
+	for _, key := range allowed {
+		t.Run(key, func(t *testing.T) {
+			if IsBlockedEnvVar(key) {
+				t.Errorf("IsBlockedEnvVar(%q) = true, want false", key)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// ShellCommand
+// ---------------------------------------------------------------------------
+
+func TestShellCommand_LocalLayout(t *testing.T) {
+	l := Layout{Root: "/work/sandbox", Home: "/work/home"}
+	got := ShellCommand(l, "python", "main.py", true)
+	want := "pip install --no-cache-dir -r /work/sandbox/scripts/requirements.txt -t /work/home/deps && PYTHONPATH=/work/home/deps python /work/sandbox/scripts/main.py"
+	if got != want {
+		t.Errorf("ShellCommand = %q, want %q", got, want)
+	}
+}
+
+func TestShellCommand_QuotesPaths(t *testing.T) {
+	l := Layout{Root: "/Users/dev/My Skills/sandbox", Home: "/Users/dev/it's home"}
+	got := ShellCommand(l, "python", "scripts/main.py", true)
+	want := `pip install --no-cache-dir -r '/Users/dev/My Skills/sandbox/scripts/requirements.txt' -t '/Users/dev/it'\''s home/deps' && PYTHONPATH='/Users/dev/it'\''s home/deps' python '/Users/dev/My Skills/sandbox/scripts/scripts/main.py'`
+	if got != want {
+		t.Errorf("ShellCommand = %q, want %q", got, want)
+	}
+	if got := ShellCommand(l, "custom", "run;rm -rf x", false); got != "'/Users/dev/My Skills/sandbox/scripts/run;rm -rf x'" {
+		t.Errorf("ShellCommand(default) = %q", got)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"/sandbox/scripts/main.py", "/sandbox/scripts/main.py"},
+		{"", "''"},
+		{"a b", "'a b'"},
+		{"$(id)", "'$(id)'"},
+		{"it's", `'it'\''s'`},
+		{"a*b", "'a*b'"},
+	}
+	for _, tt := range tests {
+		if got := ShellQuote(tt.in); got != tt.want {
+			t.Errorf("ShellQuote(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// FindEntrypoint
+// ---------------------------------------------------------------------------
+
+func TestFindEntrypoint(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		want  string
+	}{
+		{"root", []string{"main.py"}, "main.py"},
+		{"priority", []string{"main.sh", "run.py"}, "run.py"},
+		{"root before scripts", []string{"scripts/main.py", "main.js"}, "main.js"},
+		{"scripts", []string{"scripts/main.sh"}, filepath.Join("scripts", "main.sh")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, f := range tt.files {
+				p := filepath.Join(dir, f)
+				if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(p, nil, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := FindEntrypoint(dir)
+			if err != nil {
+				t.Fatalf("FindEntrypoint: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FindEntrypoint = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindEntrypoint_None(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "main.py"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FindEntrypoint(dir); err == nil {
+		t.Fatal("expected error when only a directory named main.py exists")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// CodeRunnerEntrypoint
+// ---------------------------------------------------------------------------
+
+func TestCodeRunnerEntrypoint_IsValidPython(t *testing.T) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 not installed")
+	}
+	path := filepath.Join(t.TempDir(), "main.py")
+	if err := os.WriteFile(path, CodeRunnerEntrypoint(), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	out, err := exec.Command(python, "-m", "py_compile", path).CombinedOutput()
+	if err != nil {
+		t.Fatalf("generated entrypoint does not compile: %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,268 @@
+// Package scaffold generates new skill directories for `skillbox init`,
+// either from the built-in templates embedded in this package or from a
+// template package stored in the registry (a skill with kind: template).
+//
+// Built-in templates live under templates/<mode>/<template>/:
+//
+//	instructions.md  SKILL.md body
+//	tests/...        sample test cases, shared by every language
+//	<lang>/...       entrypoint and dependency manifest for one language
+//
+// Files ending in .tmpl, instructions.md and README.md.tmpl are rendered
+// with text/template; the .tmpl suffix is dropped.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/devs-group/skillbox/internal/skill"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// Skill modes.
+const (
+	ModeExecutable = "executable"
+	ModeCognitive  = "cognitive"
+)
+
+// DefaultTemplate is the built-in template used when none is given.
+const DefaultTemplate = "basic"
+
+// Options describes the skill to generate.
+type Options struct {
+	Name        string
+	Description string // defaults to a placeholder
+	Lang        string // skill.LangPython (default), skill.LangNode or skill.LangBash
+	Mode        string // ModeExecutable (default) or ModeCognitive
+	Template    string // built-in template name, DefaultTemplate if empty
+}
+
+// entrypoints maps each language to the entrypoint the built-in
+// executable templates ship.
+var entrypoints = map[string]string{
+	skill.LangPython: "scripts/main.py",
+	skill.LangNode:   "scripts/main.js",
+	skill.LangBash:   "scripts/main.sh",
+}
+
+// templateData is passed to every rendered file.
+type templateData struct {
+	Name        string
+	Description string
+	Lang        string
+	Mode        string
+	Entrypoint  string // path of the entrypoint, empty for cognitive skills
+	Manifest    string // path of the dependency manifest, if any
+}
+
+// Templates returns the names of the built-in templates for mode.
+func Templates(mode string) []string {
+	entries, err := fs.ReadDir(templatesFS, path.Join("templates", mode))
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+// IsBuiltin reports whether name is a built-in template for any mode.
+func IsBuiltin(name string) bool {
+	return slices.Contains(Templates(ModeExecutable), name) || slices.Contains(Templates(ModeCognitive), name)
+}
+
+// Generate returns the files of a new skill built from a built-in
+// template, keyed by slash-separated path.
+func Generate(opts Options) (map[string][]byte, error) {
+	if err := skill.ValidateName(opts.Name); err != nil {
+		return nil, err
+	}
+	if opts.Lang == "" {
+		opts.Lang = skill.LangPython
+	}
+	if opts.Mode == "" {
+		opts.Mode = ModeExecutable
+	}
+	if opts.Template == "" {
+		opts.Template = DefaultTemplate
+	}
+	if opts.Description == "" {
+		opts.Description = fmt.Sprintf("TODO: describe what %s does", opts.Name)
+	}
+	if opts.Mode != ModeExecutable && opts.Mode != ModeCognitive {
+		return nil, fmt.Errorf("mode %q is not supported (use executable or cognitive)", opts.Mode)
+	}
+	if opts.Mode == ModeCognitive && opts.Lang != skill.LangPython {
+		return nil, fmt.Errorf("cognitive skills ship Python utilities; use lang python")
+	}
+
+	root := path.Join("templates", opts.Mode, opts.Template)
+	if _, err := fs.Stat(templatesFS, root); err != nil {
+		return nil, fmt.Errorf("unknown %s template %q (available: %s)", opts.Mode, opts.Template, strings.Join(Templates(opts.Mode), ", "))
+	}
+	langRoot := path.Join(root, opts.Lang)
+	if _, err := fs.Stat(templatesFS, langRoot); err != nil {
+		return nil, fmt.Errorf("template %q has no %s variant", opts.Template, opts.Lang)
+	}
+
+	data := templateData{
+		Name:        opts.Name,
+		Description: opts.Description,
+		Lang:        opts.Lang,
+		Mode:        opts.Mode,
+	}
+	if opts.Mode == ModeExecutable {
+		data.Entrypoint = entrypoints[opts.Lang]
+	}
+	if opts.Lang == skill.LangPython {
+		data.Manifest = "requirements.txt"
+	} else if opts.Lang == skill.LangNode {
+		data.Manifest = "package.json"
+	}
+
+	files := make(map[string][]byte)
+	if err := addTree(files, langRoot, data); err != nil {
+		return nil, err
+	}
+	if err := addTree(files, path.Join(root, "tests"), data, "tests"); err != nil {
+		return nil, err
+	}
+	readme, err := render(path.Join("templates", "README.md.tmpl"), data)
+	if err != nil {
+		return nil, err
+	}
+	files["README.md"] = readme
+
+	instructions, err := render(path.Join(root, "instructions.md"), data)
+	if err != nil {
+		return nil, err
+	}
+	md := skill.BuildSkillMD(opts.Name, opts.Description, opts.Lang, "", strings.TrimSpace(string(instructions)))
+	if opts.Mode == ModeCognitive {
+		md = skill.SetFrontmatterField(md, "mode", strconv.Quote(ModeCognitive))
+	}
+	if _, err := skill.ParseSkillMD([]byte(md)); err != nil {
+		return nil, err
+	}
+	files["SKILL.md"] = []byte(md)
+	return files, nil
+}
+
+// addTree renders every file under dir into files, at its path relative
+// to dir joined to prefix.
+func addTree(files map[string][]byte, dir string, data templateData, prefix ...string) error {
+	err := fs.WalkDir(templatesFS, dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel := strings.TrimPrefix(p, dir+"/")
+		content, err := fs.ReadFile(templatesFS, p)
+		if err != nil {
+			return err
+		}
+		if name, ok := strings.CutSuffix(rel, ".tmpl"); ok {
+			if content, err = render(p, data); err != nil {
+				return err
+			}
+			rel = name
+		}
+		files[path.Join(append(prefix, rel)...)] = content
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func render(name string, data templateData) ([]byte, error) {
+	src, err := fs.ReadFile(templatesFS, name)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New(path.Base(name)).Parse(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("render %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// FromTemplate turns the files of a registry template package into a new
+// skill called name: SKILL.md gets the new name and version 1.0.0, and its
+// kind: template line is removed. Every other file is copied unchanged.
+func FromTemplate(files map[string][]byte, name string) (map[string][]byte, error) {
+	if err := skill.ValidateName(name); err != nil {
+		return nil, err
+	}
+	md, ok := files["SKILL.md"]
+	if !ok {
+		return nil, fmt.Errorf("template has no SKILL.md")
+	}
+	tpl, err := skill.ParseSkillMD(md)
+	if err != nil {
+		return nil, fmt.Errorf("template SKILL.md: %w", err)
+	}
+	if tpl.Kind != skill.KindTemplate {
+		return nil, fmt.Errorf("%s is not a template (kind: %s)", tpl.Name, tpl.Kind)
+	}
+
+	content := skill.SetFrontmatterField(string(md), "name", strconv.Quote(name))
+	content = skill.SetFrontmatterField(content, "kind", "")
+	content = skill.SetFrontmatterVersion(content, strconv.Quote(skill.DefaultVersion))
+	if _, err := skill.ParseSkillMD([]byte(content)); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string][]byte, len(files))
+	for p, data := range files {
+		if !filepath.IsLocal(filepath.FromSlash(p)) {
+			return nil, fmt.Errorf("template file %q escapes the skill directory", p)
+		}
+		out[p] = data
+	}
+	out["SKILL.md"] = []byte(content)
+	return out, nil
+}
+
+// Write creates dir and writes files into it. dir must not exist or be
+// empty. Scripts under scripts/ are made executable.
+func Write(dir string, files map[string][]byte) error {
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return fmt.Errorf("%s already exists and is not empty", dir)
+	}
+	for p, data := range files {
+		target := filepath.Join(dir, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		mode := os.FileMode(0o644)
+		if strings.HasPrefix(p, "scripts/") {
+			mode = 0o755
+		}
+		if err := os.WriteFile(target, data, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/devs-group/skillbox/internal/skill"
+	"github.com/devs-group/skillbox/internal/skilltest"
+)
+
+func TestGenerate_BuiltinTemplates(t *testing.T) {
+	type variant struct{ mode, template, lang string }
+	var variants []variant
+	for _, tpl := range Templates(ModeExecutable) {
+		for _, lang := range []string{skill.LangPython, skill.LangNode, skill.LangBash} {
+			variants = append(variants, variant{ModeExecutable, tpl, lang})
+		}
+	}
+	for _, tpl := range Templates(ModeCognitive) {
+		variants = append(variants, variant{ModeCognitive, tpl, skill.LangPython})
+	}
+	if len(variants) < 7 {
+		t.Fatalf("expected at least 7 built-in variants, got %d", len(variants))
+	}
+
+	for _, v := range variants {
+		t.Run(v.mode+"/"+v.template+"/"+v.lang, func(t *testing.T) {
+			files, err := Generate(Options{Name: "my-skill", Lang: v.lang, Mode: v.mode, Template: v.template})
+			if err != nil {
+				t.Fatalf("Generate: %v", err)
+			}
+
+			sk, err := skill.ParseSkillMD(files["SKILL.md"])
+			if err != nil {
+				t.Fatalf("generated SKILL.md: %v", err)
+			}
+			if sk.Name != "my-skill" || sk.Lang != v.lang || sk.Mode != v.mode || sk.Kind != skill.KindSkill {
+				t.Errorf("SKILL.md = %s/%s/%s/%s", sk.Name, sk.Lang, sk.Mode, sk.Kind)
+			}
+			if !strings.Contains(sk.Instructions, "# my-skill") {
+				t.Errorf("instructions not rendered:\n%s", sk.Instructions)
+			}
+			if _, ok := files["README.md"]; !ok {
+				t.Error("missing README.md")
+			}
+			if v.mode == ModeExecutable {
+				if _, ok := files[entrypoints[v.lang]]; !ok {
+					t.Errorf("missing entrypoint %s", entrypoints[v.lang])
+				}
+			}
+			for p, content := range files {
+				if strings.HasSuffix(p, ".tmpl") {
+					t.Errorf("unrendered template file %s", p)
+				}
+				if strings.Contains(string(content), "{{") {
+					t.Errorf("%s contains template markup", p)
+				}
+			}
+
+			fsys := fstest.MapFS{}
+			for p, content := range files {
+				fsys[p] = &fstest.MapFile{Data: content}
+			}
+			cases, err := skilltest.Load(fsys)
+			if err != nil || len(cases) == 0 {
+				t.Errorf("sample tests: %d cases, err %v", len(cases), err)
+			}
+		})
+	}
+}
+
+func TestGenerate_Manifest(t *testing.T) {
+	for lang, manifest := range map[string]string{skill.LangPython: "requirements.txt", skill.LangNode: "package.json"} {
+		files, err := Generate(Options{Name: "dep-skill", Lang: lang, Description: `Says "hi"`})
+		if err != nil {
+			t.Fatalf("Generate(%s): %v", lang, err)
+		}
+		if _, ok := files[manifest]; !ok {
+			t.Errorf("%s: missing %s", lang, manifest)
+		}
+	}
+
+	files, err := Generate(Options{Name: "dep-skill", Lang: skill.LangNode, Description: `Says "hi"`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(files["package.json"]), `"description": "Says \"hi\""`) {
+		t.Errorf("package.json description not escaped:\n%s", files["package.json"])
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{"invalid name", Options{Name: "../x"}, "invalid characters"},
+		{"unknown template", Options{Name: "s", Template: "nope"}, "unknown executable template"},
+		{"unknown mode", Options{Name: "s", Mode: "magic"}, "not supported"},
+		{"cognitive node", Options{Name: "s", Mode: ModeCognitive, Lang: skill.LangNode}, "use lang python"},
+		{"unknown lang", Options{Name: "s", Lang: "ruby"}, "no ruby variant"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Generate(tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Generate() error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromTemplate(t *testing.T) {
+	tpl := map[string][]byte{
+		"SKILL.md":        []byte("---\nname: team-base\nversion: 2.3.0\ndescription: Team skill template\nlang: python\nkind: template\ntimeout: 30s\n---\n\nBody\n"),
+		"scripts/main.py": []byte("print('hi')\n"),
+	}
+
+	files, err := FromTemplate(tpl, "invoice-parser")
+	if err != nil {
+		t.Fatalf("FromTemplate: %v", err)
+	}
+	sk, err := skill.ParseSkillMD(files["SKILL.md"])
+	if err != nil {
+		t.Fatalf("SKILL.md: %v", err)
+	}
+	if sk.Name != "invoice-parser" || sk.Version != "1.0.0" || sk.Kind != skill.KindSkill || sk.Timeout.String() != "30s" {
+		t.Errorf("SKILL.md = name %q version %q kind %q timeout %s", sk.Name, sk.Version, sk.Kind, sk.Timeout)
+	}
+	if string(files["scripts/main.py"]) != "print('hi')\n" {
+		t.Errorf("scripts/main.py = %q", files["scripts/main.py"])
+	}
+
+	notTemplate := map[string][]byte{"SKILL.md": []byte("---\nname: s\ndescription: d\n---\n")}
+	if _, err := FromTemplate(notTemplate, "x"); err == nil || !strings.Contains(err.Error(), "not a template") {
+		t.Errorf("FromTemplate(skill) error = %v, want not a template", err)
+	}
+
+	escaping := map[string][]byte{"SKILL.md": tpl["SKILL.md"], "../evil.sh": []byte("x")}
+	if _, err := FromTemplate(escaping, "x"); err == nil {
+		t.Error("expected error for a path outside the skill directory")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "new-skill")
+	files := map[string][]byte{
+		"SKILL.md":        []byte("md"),
+		"scripts/main.sh": []byte("echo"),
+	}
+	if err := Write(dir, files); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	info, err := os.Stat(filepath.Join(dir, "scripts", "main.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("scripts/main.sh mode = %v, want executable", info.Mode())
+	}
+
+	if err := Write(dir, files); err == nil || !strings.Contains(err.Error(), "not empty") {
+		t.Errorf("Write into non-empty dir error = %v", err)
+	}
+}
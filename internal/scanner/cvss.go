@@ -0,0 +1,101 @@
+package scanner
+
+import (
+	"math"
+	"strings"
+)
+
+// cvss3Weights are the metric weights of the CVSS v3.x base score.
+// Privileges Required is weighted by scope, so its entries are keyed
+// "PR:<value>" for unchanged scope and "PRC:<value>" for changed scope.
+var cvss3Weights = map[string]float64{
+	"AV:N": 0.85, "AV:A": 0.62, "AV:L": 0.55, "AV:P": 0.2,
+	"AC:L": 0.77, "AC:H": 0.44,
+	"PR:N": 0.85, "PR:L": 0.62, "PR:H": 0.27,
+	"PRC:N": 0.85, "PRC:L": 0.68, "PRC:H": 0.5,
+	"UI:N": 0.85, "UI:R": 0.62,
+	"C:H": 0.56, "C:L": 0.22, "C:N": 0,
+	"I:H": 0.56, "I:L": 0.22, "I:N": 0,
+	"A:H": 0.56, "A:L": 0.22, "A:N": 0,
+}
+
+// cvss3BaseScore computes the base score of a CVSS v3.0 or v3.1 vector
+// such as "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H". ok is false for
+// other vectors or when a base metric is missing.
+func cvss3BaseScore(vector string) (score float64, ok bool) {
+	parts := strings.Split(strings.TrimSpace(vector), "/")
+	if len(parts) < 9 || (parts[0] != "CVSS:3.0" && parts[0] != "CVSS:3.1") {
+		return 0, false
+	}
+	metrics := make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		k, v, found := strings.Cut(p, ":")
+		if !found {
+			return 0, false
+		}
+		metrics[k] = v
+	}
+
+	changed := metrics["S"] == "C"
+	if !changed && metrics["S"] != "U" {
+		return 0, false
+	}
+	pr := "PR"
+	if changed {
+		pr = "PRC"
+	}
+	weight := func(key, metric string) (float64, bool) {
+		w, ok := cvss3Weights[key+":"+metrics[metric]]
+		return w, ok
+	}
+	av, ok1 := weight("AV", "AV")
+	ac, ok2 := weight("AC", "AC")
+	prw, ok3 := weight(pr, "PR")
+	ui, ok4 := weight("UI", "UI")
+	c, ok5 := weight("C", "C")
+	i, ok6 := weight("I", "I")
+	a, ok7 := weight("A", "A")
+	if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 || !ok6 || !ok7 {
+		return 0, false
+	}
+
+	iss := 1 - (1-c)*(1-i)*(1-a)
+	var impact float64
+	if changed {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	} else {
+		impact = 6.42 * iss
+	}
+	if impact <= 0 {
+		return 0, true
+	}
+	exploitability := 8.22 * av * ac * prw * ui
+	if changed {
+		return cvssRoundUp(math.Min(1.08*(impact+exploitability), 10)), true
+	}
+	return cvssRoundUp(math.Min(impact+exploitability, 10)), true
+}
+
+// cvssRoundUp rounds up to one decimal place as specified in CVSS v3.1,
+// Appendix A, avoiding floating point artifacts such as 4.000001 -> 4.1.
+func cvssRoundUp(x float64) float64 {
+	n := int64(math.Round(x * 100000))
+	if n%10000 == 0 {
+		return float64(n) / 100000
+	}
+	return float64(n/10000+1) / 10
+}
+
+// cvssSeverity maps a CVSS base score to its qualitative severity rating.
+// A score of 0 ("None") is rated low.
+func cvssSeverity(score float64) string {
+	switch {
+	case score >= 9:
+		return VulnSeverityCritical
+	case score >= 7:
+		return VulnSeverityHigh
+	case score >= 4:
+		return VulnSeverityMedium
+	}
+	return VulnSeverityLow
+}
@@ -0,0 +1,517 @@
+package scanner
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Package ecosystems, named as in OSV and package URLs.
+const (
+	EcosystemPyPI = "PyPI"
+	EcosystemNPM  = "npm"
+)
+
+// maxManifestSize caps how much of a manifest or lockfile is read.
+// Lockfiles routinely exceed the 1MB pattern-scan cap.
+const maxManifestSize int64 = 16 << 20
+
+// Dependency is a third-party package a skill declares, locks or vendors.
+// One package appears once per file that mentions it.
+type Dependency struct {
+	Ecosystem string `json:"ecosystem"`           // EcosystemPyPI or EcosystemNPM
+	Name      string `json:"name"`                // normalized package name
+	Version   string `json:"version,omitempty"`   // exact version, if pinned, locked or vendored
+	Specifier string `json:"specifier,omitempty"` // declared range, e.g. ">=2.0" or "^4.17"
+	FilePath  string `json:"file_path"`
+}
+
+// ExtractDependencies lists the packages in a skill archive's
+// requirements*.txt, pyproject.toml, package.json and lockfiles
+// (package-lock.json, yarn.lock, poetry.lock, uv.lock, Pipfile.lock), and
+// the packages vendored into node_modules or as *.dist-info directories.
+// Malformed files are skipped rather than failing the extraction.
+func ExtractDependencies(zr *zip.Reader) ([]Dependency, error) {
+	var deps []Dependency
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		name := strings.TrimPrefix(f.Name, "./")
+		parse := manifestParser(name)
+		if parse == nil {
+			continue
+		}
+		content, err := readManifest(f)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", f.Name, err)
+		}
+		deps = append(deps, parse(content, name)...)
+	}
+
+	sort.Slice(deps, func(i, j int) bool {
+		a, b := deps[i], deps[j]
+		if a.Ecosystem != b.Ecosystem {
+			return a.Ecosystem < b.Ecosystem
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Version != b.Version {
+			return a.Version < b.Version
+		}
+		return a.FilePath < b.FilePath
+	})
+	return deps, nil
+}
+
+// manifestParser returns the parser for a dependency file, or nil if name
+// is not one.
+func manifestParser(name string) func(content []byte, filePath string) []Dependency {
+	base := path.Base(name)
+
+	// Vendored packages: node_modules/<pkg>/package.json and
+	// node_modules/@scope/<pkg>/package.json. Nothing else under
+	// node_modules describes the skill's own dependencies.
+	if i := strings.LastIndex(name, "node_modules/"); i >= 0 {
+		rest := strings.Split(name[i+len("node_modules/"):], "/")
+		if base == "package.json" && (len(rest) == 2 || (len(rest) == 3 && strings.HasPrefix(rest[0], "@"))) {
+			return parseVendoredPackageJSON
+		}
+		return nil
+	}
+	dir := path.Base(path.Dir(name))
+	switch {
+	case base == "METADATA" && strings.HasSuffix(dir, ".dist-info"),
+		base == "PKG-INFO" && strings.HasSuffix(dir, ".egg-info"):
+		return parsePythonMetadata
+	case strings.Contains(name, ".dist-info/"), strings.Contains(name, ".egg-info/"):
+		return nil
+	}
+
+	switch base {
+	case "pyproject.toml":
+		return parsePyproject
+	case "poetry.lock", "uv.lock":
+		return parseTOMLLock
+	case "Pipfile.lock":
+		return parsePipfileLock
+	case "package.json":
+		return parsePackageJSON
+	case "package-lock.json", "npm-shrinkwrap.json":
+		return parsePackageLock
+	case "yarn.lock":
+		return parseYarnLock
+	}
+	if strings.HasPrefix(base, "requirements") && strings.HasSuffix(base, ".txt") {
+		return parseRequirements
+	}
+	return nil
+}
+
+// isDeclaredManifest reports whether filePath is a manifest a skill author
+// writes (requirements*.txt, pyproject.toml or package.json) rather than a
+// lockfile or the metadata of a vendored package.
+func isDeclaredManifest(filePath string) bool {
+	if strings.Contains(filePath, "node_modules/") {
+		return false
+	}
+	base := path.Base(filePath)
+	return base == "pyproject.toml" || base == "package.json" ||
+		(strings.HasPrefix(base, "requirements") && strings.HasSuffix(base, ".txt"))
+}
+
+// NormalizePackageName returns name as ExtractDependencies records it:
+// PEP 503 normalized for PyPI and lower-cased otherwise.
+func NormalizePackageName(ecosystem, name string) string {
+	if ecosystem == EcosystemPyPI {
+		return normalizePyPIName(name)
+	}
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// readManifest reads a dependency file, up to maxManifestSize bytes.
+func readManifest(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close() //nolint:errcheck
+	return io.ReadAll(io.LimitReader(rc, maxManifestSize))
+}
+
+// --------------------------------------------------------------------
+// Python
+// --------------------------------------------------------------------
+
+// pep503Separators matches the runs of separators PEP 503 folds into "-".
+var pep503Separators = regexp.MustCompile(`[-_.]+`)
+
+// normalizePyPIName returns the PEP 503 normalized form of a package name.
+func normalizePyPIName(name string) string {
+	return pep503Separators.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+}
+
+// extractPkgName extracts the package name from a requirements.txt line,
+// stripping version specifiers and extras.
+func extractPkgName(line string) string {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return ""
+	}
+	for _, sep := range []string{"==", ">=", "<=", "!=", "~=", ">", "<", "[", ";", "@"} {
+		if idx := strings.Index(line, sep); idx >= 0 {
+			line = line[:idx]
+		}
+	}
+	return strings.TrimSpace(strings.ToLower(line))
+}
+
+// pypiRequirement builds a Dependency from a PEP 508 requirement such as
+// "requests[socks]>=2.0; python_version>'3.8'". It returns false for lines
+// that name no package.
+func pypiRequirement(req, filePath string) (Dependency, bool) {
+	req = strings.TrimSpace(req)
+	if i := strings.Index(req, ";"); i >= 0 {
+		req = req[:i] // environment marker
+	}
+	if i := strings.Index(req, "["); i >= 0 {
+		if j := strings.Index(req[i:], "]"); j >= 0 {
+			req = req[:i] + req[i+j+1:] // extras
+		}
+	}
+	name := extractPkgName(req)
+	if name == "" || strings.ContainsAny(name, " /:(") {
+		return Dependency{}, false
+	}
+	spec := ""
+	if i := strings.IndexAny(req, "=<>!~@"); i >= 0 {
+		spec = strings.TrimSpace(req[i:])
+	}
+
+	d := Dependency{Ecosystem: EcosystemPyPI, Name: normalizePyPIName(name), Specifier: spec, FilePath: filePath}
+	if v, ok := strings.CutPrefix(spec, "==="); ok {
+		d.Version = strings.TrimSpace(v)
+	} else if v, ok := strings.CutPrefix(spec, "=="); ok && !strings.ContainsAny(v, "*,") {
+		d.Version = strings.TrimSpace(v)
+	}
+	if d.Version != "" {
+		d.Specifier = ""
+	}
+	return d, true
+}
+
+// parseRequirements parses a pip requirements file. Options (-r, -e,
+// --hash, ...) and URL requirements without a name are skipped.
+func parseRequirements(content []byte, filePath string) []Dependency {
+	var deps []Dependency
+	for _, line := range strings.Split(string(content), "\n") {
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		if i := strings.Index(line, " --"); i >= 0 {
+			line = line[:i] // per-requirement options such as --hash
+		}
+		line = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), `\`))
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		if d, ok := pypiRequirement(line, filePath); ok {
+			deps = append(deps, d)
+		}
+	}
+	return deps
+}
+
+// parsePyproject reads PEP 621 [project] dependencies and optional
+// dependencies, and Poetry's dependency tables.
+func parsePyproject(content []byte, filePath string) []Dependency {
+	var doc struct {
+		Project struct {
+			Dependencies         []string            `toml:"dependencies"`
+			OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+		} `toml:"project"`
+		Tool struct {
+			Poetry struct {
+				Dependencies    map[string]any `toml:"dependencies"`
+				DevDependencies map[string]any `toml:"dev-dependencies"`
+				Group           map[string]struct {
+					Dependencies map[string]any `toml:"dependencies"`
+				} `toml:"group"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if err := toml.Unmarshal(content, &doc); err != nil {
+		return nil
+	}
+
+	var deps []Dependency
+	reqs := doc.Project.Dependencies
+	for _, extra := range doc.Project.OptionalDependencies {
+		reqs = append(reqs, extra...)
+	}
+	for _, r := range reqs {
+		if d, ok := pypiRequirement(r, filePath); ok {
+			deps = append(deps, d)
+		}
+	}
+
+	tables := []map[string]any{doc.Tool.Poetry.Dependencies, doc.Tool.Poetry.DevDependencies}
+	for _, g := range doc.Tool.Poetry.Group {
+		tables = append(tables, g.Dependencies)
+	}
+	for _, table := range tables {
+		for name, v := range table {
+			if strings.EqualFold(name, "python") {
+				continue
+			}
+			spec := ""
+			switch v := v.(type) {
+			case string:
+				spec = v
+			case map[string]any:
+				spec, _ = v["version"].(string)
+			}
+			d := Dependency{Ecosystem: EcosystemPyPI, Name: normalizePyPIName(name), Specifier: spec, FilePath: filePath}
+			// Poetry reads a bare version as an exact pin.
+			if v := strings.TrimPrefix(spec, "=="); exactVersion(v) {
+				d.Version, d.Specifier = v, ""
+			}
+			deps = append(deps, d)
+		}
+	}
+	return deps
+}
+
+// parseTOMLLock reads the [[package]] entries of poetry.lock and uv.lock.
+func parseTOMLLock(content []byte, filePath string) []Dependency {
+	var doc struct {
+		Package []struct {
+			Name    string `toml:"name"`
+			Version string `toml:"version"`
+		} `toml:"package"`
+	}
+	if err := toml.Unmarshal(content, &doc); err != nil {
+		return nil
+	}
+	var deps []Dependency
+	for _, p := range doc.Package {
+		if p.Name == "" || p.Version == "" {
+			continue
+		}
+		deps = append(deps, Dependency{Ecosystem: EcosystemPyPI, Name: normalizePyPIName(p.Name), Version: p.Version, FilePath: filePath})
+	}
+	return deps
+}
+
+// parsePipfileLock reads the default and develop sections of Pipfile.lock.
+func parsePipfileLock(content []byte, filePath string) []Dependency {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil
+	}
+	var deps []Dependency
+	for _, section := range []string{"default", "develop"} {
+		var pkgs map[string]struct {
+			Version string `json:"version"`
+		}
+		if err := json.Unmarshal(doc[section], &pkgs); err != nil {
+			continue
+		}
+		for name, p := range pkgs {
+			d := Dependency{Ecosystem: EcosystemPyPI, Name: normalizePyPIName(name), FilePath: filePath}
+			if v, ok := strings.CutPrefix(p.Version, "=="); ok {
+				d.Version = v
+			} else {
+				d.Specifier = p.Version
+			}
+			deps = append(deps, d)
+		}
+	}
+	return deps
+}
+
+// parsePythonMetadata reads the Name and Version headers of a vendored
+// package's METADATA or PKG-INFO.
+func parsePythonMetadata(content []byte, filePath string) []Dependency {
+	var name, version string
+	sc := bufio.NewScanner(strings.NewReader(string(content)))
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" {
+			break // end of headers
+		}
+		if v, ok := strings.CutPrefix(line, "Name:"); ok {
+			name = strings.TrimSpace(v)
+		} else if v, ok := strings.CutPrefix(line, "Version:"); ok {
+			version = strings.TrimSpace(v)
+		}
+	}
+	if name == "" {
+		return nil
+	}
+	return []Dependency{{Ecosystem: EcosystemPyPI, Name: normalizePyPIName(name), Version: version, FilePath: filePath}}
+}
+
+// --------------------------------------------------------------------
+// npm
+// --------------------------------------------------------------------
+
+// exactSemver matches an exact version, optionally prefixed with "=" or "v".
+var exactSemver = regexp.MustCompile(`^[=v]?\d+(\.\d+)*(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+// exactVersion reports whether spec pins a single version.
+func exactVersion(spec string) bool {
+	return exactSemver.MatchString(strings.TrimSpace(spec))
+}
+
+// parsePackageJSON reads dependencies, devDependencies, peerDependencies
+// and optionalDependencies from package.json.
+func parsePackageJSON(content []byte, filePath string) []Dependency {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil
+	}
+	var deps []Dependency
+	for _, section := range []string{"dependencies", "devDependencies", "peerDependencies", "optionalDependencies"} {
+		var table map[string]string
+		if err := json.Unmarshal(doc[section], &table); err != nil {
+			continue
+		}
+		for name, spec := range table {
+			d := Dependency{Ecosystem: EcosystemNPM, Name: strings.ToLower(name), Specifier: spec, FilePath: filePath}
+			if exactVersion(spec) {
+				d.Version, d.Specifier = strings.TrimLeft(spec, "=v"), ""
+			}
+			deps = append(deps, d)
+		}
+	}
+	return deps
+}
+
+// parseVendoredPackageJSON reads the name and version of a package
+// vendored into node_modules.
+func parseVendoredPackageJSON(content []byte, filePath string) []Dependency {
+	var doc struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(content, &doc); err != nil || doc.Name == "" {
+		return nil
+	}
+	return []Dependency{{Ecosystem: EcosystemNPM, Name: strings.ToLower(doc.Name), Version: doc.Version, FilePath: filePath}}
+}
+
+// parsePackageLock reads package-lock.json and npm-shrinkwrap.json: the
+// "packages" map of lockfile v2 and v3, or the nested "dependencies" of v1.
+func parsePackageLock(content []byte, filePath string) []Dependency {
+	type v1Dep struct {
+		Version      string                     `json:"version"`
+		Dependencies map[string]json.RawMessage `json:"dependencies"`
+	}
+	var doc struct {
+		Packages map[string]struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+			Link    bool   `json:"link"`
+		} `json:"packages"`
+		Dependencies map[string]json.RawMessage `json:"dependencies"`
+	}
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil
+	}
+
+	seen := make(map[[2]string]bool)
+	var deps []Dependency
+	add := func(name, version string) {
+		key := [2]string{strings.ToLower(name), version}
+		if name == "" || version == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		deps = append(deps, Dependency{Ecosystem: EcosystemNPM, Name: key[0], Version: version, FilePath: filePath})
+	}
+
+	if len(doc.Packages) > 0 {
+		for key, p := range doc.Packages {
+			i := strings.LastIndex(key, "node_modules/")
+			if i < 0 || p.Link {
+				continue // the root project or a workspace link
+			}
+			name := p.Name
+			if name == "" {
+				name = key[i+len("node_modules/"):]
+			}
+			add(name, p.Version)
+		}
+		return deps
+	}
+
+	var walk func(map[string]json.RawMessage)
+	walk = func(m map[string]json.RawMessage) {
+		for name, raw := range m {
+			var d v1Dep
+			if json.Unmarshal(raw, &d) != nil {
+				continue
+			}
+			add(name, d.Version)
+			walk(d.Dependencies)
+		}
+	}
+	walk(doc.Dependencies)
+	return deps
+}
+
+// parseYarnLock reads yarn.lock in both the classic (v1) and the Berry
+// format: a header line of comma-separated "name@range" keys followed by
+// an indented version field.
+func parseYarnLock(content []byte, filePath string) []Dependency {
+	seen := make(map[[2]string]bool)
+	var deps []Dependency
+	var names []string
+	for _, line := range strings.Split(string(content), "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") {
+			names = names[:0]
+			for _, key := range strings.Split(strings.TrimSuffix(line, ":"), ",") {
+				key = strings.Trim(strings.TrimSpace(key), `"`)
+				if strings.Contains(key, "@workspace:") || strings.Contains(key, "@link:") || strings.Contains(key, "@portal:") {
+					continue // the project itself or a local package
+				}
+				if i := strings.LastIndex(key, "@"); i > 0 {
+					names = append(names, key[:i])
+				}
+			}
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		v, ok := strings.CutPrefix(trimmed, "version ")
+		if !ok {
+			v, ok = strings.CutPrefix(trimmed, "version: ")
+		}
+		if !ok {
+			continue
+		}
+		version := strings.Trim(strings.TrimSpace(v), `"`)
+		for _, name := range names {
+			key := [2]string{strings.ToLower(name), version}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			deps = append(deps, Dependency{Ecosystem: EcosystemNPM, Name: key[0], Version: version, FilePath: filePath})
+		}
+		names = names[:0]
+	}
+	return deps
+}
@@ -0,0 +1,176 @@
+package scanner
+
+import (
+	"testing"
+)
+
+func TestExtractDependencies(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []Dependency
+	}{
+		{
+			name: "requirements.txt",
+			files: map[string]string{
+				"requirements.txt": "# deps\nrequests==2.31.0 --hash=sha256:abc\nFlask_Login>=0.6 ; python_version > '3.8'\n" +
+					"uvicorn[standard]==0.23.*\n-r other.txt\nhttps://example.com/pkg.whl\n",
+			},
+			want: []Dependency{
+				{Ecosystem: EcosystemPyPI, Name: "flask-login", Specifier: ">=0.6", FilePath: "requirements.txt"},
+				{Ecosystem: EcosystemPyPI, Name: "requests", Version: "2.31.0", FilePath: "requirements.txt"},
+				{Ecosystem: EcosystemPyPI, Name: "uvicorn", Specifier: "==0.23.*", FilePath: "requirements.txt"},
+			},
+		},
+		{
+			name: "pyproject.toml",
+			files: map[string]string{
+				"pyproject.toml": `[project]
+name = "skill"
+dependencies = ["httpx>=0.27", "pydantic==2.7.1"]
+
+[project.optional-dependencies]
+dev = ["pytest"]
+
+[tool.poetry.dependencies]
+python = "^3.11"
+rich = "13.7.1"
+click = { version = "^8.1", optional = true }
+`,
+			},
+			want: []Dependency{
+				{Ecosystem: EcosystemPyPI, Name: "click", Specifier: "^8.1", FilePath: "pyproject.toml"},
+				{Ecosystem: EcosystemPyPI, Name: "httpx", Specifier: ">=0.27", FilePath: "pyproject.toml"},
+				{Ecosystem: EcosystemPyPI, Name: "pydantic", Version: "2.7.1", FilePath: "pyproject.toml"},
+				{Ecosystem: EcosystemPyPI, Name: "pytest", FilePath: "pyproject.toml"},
+				{Ecosystem: EcosystemPyPI, Name: "rich", Version: "13.7.1", FilePath: "pyproject.toml"},
+			},
+		},
+		{
+			name: "python lockfiles",
+			files: map[string]string{
+				"poetry.lock":  "[[package]]\nname = \"Jinja2\"\nversion = \"3.1.4\"\n",
+				"Pipfile.lock": `{"_meta": {}, "default": {"urllib3": {"version": "==2.2.2"}}, "develop": {}}`,
+			},
+			want: []Dependency{
+				{Ecosystem: EcosystemPyPI, Name: "jinja2", Version: "3.1.4", FilePath: "poetry.lock"},
+				{Ecosystem: EcosystemPyPI, Name: "urllib3", Version: "2.2.2", FilePath: "Pipfile.lock"},
+			},
+		},
+		{
+			name: "vendored python package",
+			files: map[string]string{
+				"lib/six-1.16.0.dist-info/METADATA": "Metadata-Version: 2.1\nName: six\nVersion: 1.16.0\n\nVersion: 9.9.9\n",
+				"lib/six-1.16.0.dist-info/RECORD":   "six.py,,\n",
+			},
+			want: []Dependency{
+				{Ecosystem: EcosystemPyPI, Name: "six", Version: "1.16.0", FilePath: "lib/six-1.16.0.dist-info/METADATA"},
+			},
+		},
+		{
+			name: "package.json",
+			files: map[string]string{
+				"package.json": `{"name": "skill", "dependencies": {"lodash": "^4.17.0", "left-pad": "1.3.0"}, "devDependencies": {"@types/node": "=20.1.0"}}`,
+			},
+			want: []Dependency{
+				{Ecosystem: EcosystemNPM, Name: "@types/node", Version: "20.1.0", FilePath: "package.json"},
+				{Ecosystem: EcosystemNPM, Name: "left-pad", Version: "1.3.0", FilePath: "package.json"},
+				{Ecosystem: EcosystemNPM, Name: "lodash", Specifier: "^4.17.0", FilePath: "package.json"},
+			},
+		},
+		{
+			name: "package-lock.json v3",
+			files: map[string]string{
+				"package-lock.json": `{"lockfileVersion": 3, "packages": {
+					"": {"name": "skill", "version": "1.0.0"},
+					"node_modules/lodash": {"version": "4.17.21"},
+					"node_modules/a/node_modules/@scope/b": {"version": "2.0.0"},
+					"packages/local": {"version": "0.0.1"}}}`,
+			},
+			want: []Dependency{
+				{Ecosystem: EcosystemNPM, Name: "@scope/b", Version: "2.0.0", FilePath: "package-lock.json"},
+				{Ecosystem: EcosystemNPM, Name: "lodash", Version: "4.17.21", FilePath: "package-lock.json"},
+			},
+		},
+		{
+			name: "package-lock.json v1",
+			files: map[string]string{
+				"package-lock.json": `{"lockfileVersion": 1, "dependencies": {"a": {"version": "1.0.0", "dependencies": {"b": {"version": "2.0.0"}}}}}`,
+			},
+			want: []Dependency{
+				{Ecosystem: EcosystemNPM, Name: "a", Version: "1.0.0", FilePath: "package-lock.json"},
+				{Ecosystem: EcosystemNPM, Name: "b", Version: "2.0.0", FilePath: "package-lock.json"},
+			},
+		},
+		{
+			name: "yarn.lock",
+			files: map[string]string{
+				"yarn.lock": `# yarn lockfile v1
+
+"@babel/code-frame@^7.0.0", "@babel/code-frame@^7.22.0":
+  version "7.22.13"
+  resolved "https://registry.yarnpkg.com/..."
+
+lodash@^4.17.21:
+  version "4.17.21"
+`,
+			},
+			want: []Dependency{
+				{Ecosystem: EcosystemNPM, Name: "@babel/code-frame", Version: "7.22.13", FilePath: "yarn.lock"},
+				{Ecosystem: EcosystemNPM, Name: "lodash", Version: "4.17.21", FilePath: "yarn.lock"},
+			},
+		},
+		{
+			name: "vendored node_modules",
+			files: map[string]string{
+				"node_modules/chalk/package.json":               `{"name": "chalk", "version": "5.3.0", "dependencies": {"ignored": "1.0.0"}}`,
+				"node_modules/@scope/pkg/package.json":          `{"name": "@scope/pkg", "version": "1.2.3"}`,
+				"node_modules/chalk/source/vendor/package.json": `{"name": "not-a-package", "version": "0.0.0"}`,
+				"node_modules/chalk/requirements.txt":           "ignored==1.0\n",
+			},
+			want: []Dependency{
+				{Ecosystem: EcosystemNPM, Name: "@scope/pkg", Version: "1.2.3", FilePath: "node_modules/@scope/pkg/package.json"},
+				{Ecosystem: EcosystemNPM, Name: "chalk", Version: "5.3.0", FilePath: "node_modules/chalk/package.json"},
+			},
+		},
+		{
+			name: "malformed manifests are skipped",
+			files: map[string]string{
+				"package.json":   "{not json",
+				"pyproject.toml": "[project\n",
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExtractDependencies(createTestZip(t, tt.files))
+			if err != nil {
+				t.Fatalf("ExtractDependencies: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d dependencies %+v, want %d %+v", len(got), got, len(tt.want), tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("dependency %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizePackageName(t *testing.T) {
+	tests := []struct{ ecosystem, name, want string }{
+		{EcosystemPyPI, "Typing_Extensions", "typing-extensions"},
+		{EcosystemPyPI, "zope.interface", "zope-interface"},
+		{EcosystemNPM, "@Types/Node", "@types/node"},
+		{EcosystemNPM, "lodash.merge", "lodash.merge"},
+	}
+	for _, tt := range tests {
+		if got := NormalizePackageName(tt.ecosystem, tt.name); got != tt.want {
+			t.Errorf("NormalizePackageName(%q, %q) = %q, want %q", tt.ecosystem, tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,427 @@
+package scanner
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Severities of known vulnerabilities, in increasing order. Advisories
+// rate themselves (GitHub's "MODERATE" is MEDIUM) or carry a CVSS v3
+// vector the rating is derived from; advisories with neither are UNKNOWN.
+const (
+	VulnSeverityUnknown  = "UNKNOWN"
+	VulnSeverityLow      = "LOW"
+	VulnSeverityMedium   = "MEDIUM"
+	VulnSeverityHigh     = "HIGH"
+	VulnSeverityCritical = "CRITICAL"
+)
+
+// Block thresholds for ApplyVulnThreshold. Under VulnThresholdNone no
+// known vulnerability blocks a skill.
+const (
+	DefaultVulnThreshold = "critical"
+	VulnThresholdNone    = "none"
+)
+
+// vulnSeverityRank orders severities; unknown ratings rank lowest.
+func vulnSeverityRank(severity string) int {
+	switch strings.ToUpper(severity) {
+	case VulnSeverityLow:
+		return 1
+	case VulnSeverityMedium, "MODERATE":
+		return 2
+	case VulnSeverityHigh:
+		return 3
+	case VulnSeverityCritical:
+		return 4
+	}
+	return 0
+}
+
+// ValidVulnThreshold reports whether threshold is a block threshold
+// ApplyVulnThreshold accepts: "low", "medium", "high", "critical" or "none".
+func ValidVulnThreshold(threshold string) bool {
+	return strings.EqualFold(threshold, VulnThresholdNone) || vulnSeverityRank(threshold) > 0
+}
+
+// VulnDB is an in-memory index of OSV advisories for PyPI and npm
+// packages, loaded from an offline dump by LoadVulnDB.
+type VulnDB struct {
+	byPackage  map[string][]*osvEntry // keyed by ecosystem + ":" + normalized name
+	advisories int
+}
+
+// osvEntry is what the index keeps of one advisory for one package.
+type osvEntry struct {
+	id       string
+	aliases  []string
+	summary  string
+	severity string
+	ranges   [][]osvEvent
+	versions []string
+}
+
+// osvEvent is one event of an OSV range; exactly one field is set.
+type osvEvent struct {
+	Introduced   string `json:"introduced"`
+	Fixed        string `json:"fixed"`
+	LastAffected string `json:"last_affected"`
+}
+
+// version returns the version the event refers to.
+func (e osvEvent) version() string {
+	switch {
+	case e.Introduced != "":
+		return e.Introduced
+	case e.Fixed != "":
+		return e.Fixed
+	}
+	return e.LastAffected
+}
+
+// osvSeverity is an entry of an OSV severity array.
+type osvSeverity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// osvAdvisory is the part of an OSV record the vulnerability index uses.
+type osvAdvisory struct {
+	ID        string        `json:"id"`
+	Aliases   []string      `json:"aliases"`
+	Summary   string        `json:"summary"`
+	Withdrawn string        `json:"withdrawn"`
+	Severity  []osvSeverity `json:"severity"`
+	Affected  []struct {
+		Package struct {
+			Name      string `json:"name"`
+			Ecosystem string `json:"ecosystem"`
+		} `json:"package"`
+		Ranges []struct {
+			Type   string     `json:"type"`
+			Events []osvEvent `json:"events"`
+		} `json:"ranges"`
+		Versions          []string       `json:"versions"`
+		Severity          []osvSeverity  `json:"severity"`
+		DatabaseSpecific  map[string]any `json:"database_specific"`
+		EcosystemSpecific map[string]any `json:"ecosystem_specific"`
+	} `json:"affected"`
+	DatabaseSpecific map[string]any `json:"database_specific"`
+}
+
+// LoadVulnDB reads the OSV advisories in dir: JSON files, one advisory
+// each, and the all.zip archives OSV publishes per ecosystem, in any
+// subdirectory. Only PyPI and npm packages are indexed and withdrawn
+// advisories are ignored. Unreadable or malformed advisories are skipped
+// with a warning.
+func LoadVulnDB(dir string, logger *slog.Logger) (*VulnDB, error) {
+	db := &VulnDB{byPackage: make(map[string][]*osvEntry)}
+
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json":
+			data, err := os.ReadFile(path)
+			if err != nil {
+				logger.Warn("skipping unreadable OSV file", "path", path, "error", err)
+				return nil
+			}
+			db.add(data, path, logger)
+		case ".zip":
+			if err := db.addArchive(path, logger); err != nil {
+				logger.Warn("skipping unreadable OSV archive", "path", path, "error", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk OSV dir: %w", err)
+	}
+
+	// lookup reports the first of several IDs of one advisory, so keep the
+	// order stable: GHSA before PYSEC.
+	for _, entries := range db.byPackage {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].id < entries[j].id })
+	}
+	return db, nil
+}
+
+// addArchive indexes the advisories in an OSV all.zip archive.
+func (db *VulnDB) addArchive(path string, logger *slog.Logger) error {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer zr.Close() //nolint:errcheck
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !strings.HasSuffix(strings.ToLower(f.Name), ".json") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(io.LimitReader(rc, maxManifestSize))
+		rc.Close() //nolint:errcheck
+		if err != nil {
+			return err
+		}
+		db.add(data, path+"/"+f.Name, logger)
+	}
+	return nil
+}
+
+// add indexes one OSV record.
+func (db *VulnDB) add(data []byte, path string, logger *slog.Logger) {
+	var adv osvAdvisory
+	if err := json.Unmarshal(data, &adv); err != nil {
+		logger.Warn("skipping malformed OSV file", "path", path, "error", err)
+		return
+	}
+	if adv.ID == "" || adv.Withdrawn != "" {
+		return
+	}
+
+	indexed := false
+	for _, a := range adv.Affected {
+		eco := a.Package.Ecosystem
+		if eco != EcosystemPyPI && eco != EcosystemNPM {
+			continue
+		}
+		name := NormalizePackageName(eco, a.Package.Name)
+		if name == "" {
+			continue
+		}
+
+		e := &osvEntry{
+			id:       adv.ID,
+			aliases:  adv.Aliases,
+			summary:  adv.Summary,
+			severity: VulnSeverityUnknown,
+			versions: a.Versions,
+		}
+		// The package's own rating wins over the advisory's.
+		for _, rating := range []string{
+			canonicalSeverity(specificSeverity(a.DatabaseSpecific)),
+			canonicalSeverity(specificSeverity(a.EcosystemSpecific)),
+			cvssRating(a.Severity),
+			canonicalSeverity(specificSeverity(adv.DatabaseSpecific)),
+			cvssRating(adv.Severity),
+		} {
+			if rating != "" {
+				e.severity = rating
+				break
+			}
+		}
+		for _, r := range a.Ranges {
+			if r.Type != "GIT" && len(r.Events) > 0 {
+				e.ranges = append(e.ranges, r.Events)
+			}
+		}
+		if len(e.ranges) == 0 && len(e.versions) == 0 {
+			continue
+		}
+		key := eco + ":" + name
+		db.byPackage[key] = append(db.byPackage[key], e)
+		indexed = true
+	}
+	if indexed {
+		db.advisories++
+	}
+}
+
+// specificSeverity returns the "severity" of a database_specific or
+// ecosystem_specific object, as GitHub advisories carry it.
+func specificSeverity(m map[string]any) string {
+	s, _ := m["severity"].(string)
+	return s
+}
+
+// canonicalSeverity returns the VulnSeverity constant for a rating, or ""
+// if it is not one.
+func canonicalSeverity(rating string) string {
+	switch vulnSeverityRank(rating) {
+	case 1:
+		return VulnSeverityLow
+	case 2:
+		return VulnSeverityMedium
+	case 3:
+		return VulnSeverityHigh
+	case 4:
+		return VulnSeverityCritical
+	}
+	return ""
+}
+
+// cvssRating rates the first CVSS v3 vector in an OSV severity array, or
+// returns "" if there is none.
+func cvssRating(scores []osvSeverity) string {
+	for _, s := range scores {
+		if s.Type != "CVSS_V3" {
+			continue
+		}
+		if score, ok := cvss3BaseScore(s.Score); ok {
+			return cvssSeverity(score)
+		}
+	}
+	return ""
+}
+
+// Len returns the number of advisories indexed.
+func (db *VulnDB) Len() int {
+	if db == nil {
+		return 0
+	}
+	return db.advisories
+}
+
+// vulnerability is an advisory that affects a dependency.
+type vulnerability struct {
+	ID           string
+	Aliases      []string
+	Summary      string
+	Severity     string
+	FixedVersion string // lowest fixed version above the dependency's, if any
+}
+
+// lookup returns the advisories affecting the pinned version of dep.
+// An advisory published under several IDs (a GHSA and a PYSEC record for
+// one CVE, say) is reported once.
+func (db *VulnDB) lookup(dep Dependency) []vulnerability {
+	if db == nil || dep.Version == "" {
+		return nil
+	}
+	var vulns []vulnerability
+	seen := make(map[string]bool)
+	for _, e := range db.byPackage[dep.Ecosystem+":"+NormalizePackageName(dep.Ecosystem, dep.Name)] {
+		affected, fixed := e.affects(dep.Ecosystem, dep.Version)
+		if !affected || seen[e.id] {
+			continue
+		}
+		seen[e.id] = true
+		dup := false
+		for _, a := range e.aliases {
+			dup = dup || seen[a]
+			seen[a] = true
+		}
+		if dup {
+			continue
+		}
+		vulns = append(vulns, vulnerability{
+			ID:           e.id,
+			Aliases:      e.aliases,
+			Summary:      e.summary,
+			Severity:     e.severity,
+			FixedVersion: fixed,
+		})
+	}
+	return vulns
+}
+
+// affects reports whether version lies in one of the entry's ranges or
+// is one of its listed versions, and returns the lowest fixed version
+// above it.
+func (e *osvEntry) affects(ecosystem, version string) (bool, string) {
+	affected := false
+	for _, v := range e.versions {
+		if v == version {
+			affected = true
+			break
+		}
+		if c, ok := compareVersions(ecosystem, v, version); ok && c == 0 {
+			affected = true
+			break
+		}
+	}
+	for _, events := range e.ranges {
+		if !affected && inRange(ecosystem, version, events) {
+			affected = true
+		}
+	}
+	if !affected {
+		return false, ""
+	}
+
+	fixed := ""
+	for _, events := range e.ranges {
+		for _, ev := range events {
+			if ev.Fixed == "" {
+				continue
+			}
+			if c, ok := compareVersions(ecosystem, ev.Fixed, version); !ok || c <= 0 {
+				continue
+			}
+			if fixed == "" {
+				fixed = ev.Fixed
+			} else if c, _ := compareVersions(ecosystem, ev.Fixed, fixed); c < 0 {
+				fixed = ev.Fixed
+			}
+		}
+	}
+	return true, fixed
+}
+
+// inRange evaluates an OSV range against version as the OSV schema
+// specifies: events are sorted by version, an "introduced" at or below
+// version opens the range, and a "fixed" at or below or a
+// "last_affected" below version closes it. A range with a version that
+// cannot be parsed matches nothing.
+func inRange(ecosystem, version string, events []osvEvent) bool {
+	sorted := make([]osvEvent, 0, len(events))
+	for _, ev := range events {
+		v := ev.version()
+		if v == "" {
+			continue // "limit" events and unknown types
+		}
+		if ev.Introduced != "0" {
+			if _, ok := compareVersions(ecosystem, v, v); !ok {
+				return false
+			}
+		}
+		sorted = append(sorted, ev)
+	}
+	if _, ok := compareVersions(ecosystem, version, version); !ok {
+		return false
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Introduced == "0" || sorted[j].Introduced == "0" {
+			return sorted[i].Introduced == "0" && sorted[j].Introduced != "0"
+		}
+		c, _ := compareVersions(ecosystem, sorted[i].version(), sorted[j].version())
+		return c < 0
+	})
+
+	affected := false
+	for _, ev := range sorted {
+		switch {
+		case ev.Introduced != "":
+			if ev.Introduced == "0" {
+				affected = true
+			} else if c, _ := compareVersions(ecosystem, version, ev.Introduced); c >= 0 {
+				affected = true
+			}
+		case ev.Fixed != "":
+			if c, _ := compareVersions(ecosystem, version, ev.Fixed); c >= 0 {
+				affected = false
+			}
+		case ev.LastAffected != "":
+			if c, _ := compareVersions(ecosystem, version, ev.LastAffected); c > 0 {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
@@ -0,0 +1,167 @@
+package scanner
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testOSVRecords is a small OSV dump: two IDs of one Jinja2 advisory, a
+// CVSS-rated lodash advisory, a last_affected range, a versions list, and
+// records the index must ignore.
+var testOSVRecords = map[string]string{
+	"PyPI/GHSA-g3rq-g295-4j3m.json": `{
+		"id": "GHSA-g3rq-g295-4j3m", "aliases": ["CVE-2020-28493", "PYSEC-2021-66"],
+		"summary": "Regular Expression Denial of Service in Jinja2",
+		"affected": [{"package": {"ecosystem": "PyPI", "name": "Jinja2"},
+			"ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "2.11.3"}]}]}],
+		"database_specific": {"severity": "MODERATE"}}`,
+	"PyPI/PYSEC-2021-66.json": `{
+		"id": "PYSEC-2021-66", "aliases": ["CVE-2020-28493", "GHSA-g3rq-g295-4j3m"],
+		"affected": [{"package": {"ecosystem": "PyPI", "name": "jinja2"},
+			"ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "2.11.3"}]}]}]}`,
+	"PyPI/PYSEC-2018-28.json": `{
+		"id": "PYSEC-2018-28",
+		"affected": [{"package": {"ecosystem": "PyPI", "name": "requests"}, "versions": ["2.19", "2.19.1"]}]}`,
+	"npm/GHSA-p6mc-m468-83gw.json": `{
+		"id": "GHSA-p6mc-m468-83gw", "summary": "Prototype Pollution in lodash",
+		"severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}],
+		"affected": [{"package": {"ecosystem": "npm", "name": "lodash"},
+			"ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "4.17.19"}]}]}]}`,
+	"npm/GHSA-xvch-5gv4-984h.json": `{
+		"id": "GHSA-xvch-5gv4-984h",
+		"affected": [{"package": {"ecosystem": "npm", "name": "minimist"},
+			"ranges": [{"type": "SEMVER", "events": [{"introduced": "1.0.0"}, {"last_affected": "1.2.5"}]}],
+			"database_specific": {"severity": "LOW"}}]}`,
+	"npm/withdrawn.json": `{
+		"id": "GHSA-wwww-wwww-wwww", "withdrawn": "2024-01-01T00:00:00Z",
+		"affected": [{"package": {"ecosystem": "npm", "name": "left-pad"},
+			"ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}]}]}]}`,
+	"other/git-and-maven.json": `{
+		"id": "OSV-2024-1",
+		"affected": [
+			{"package": {"ecosystem": "npm", "name": "gitonly"},
+				"ranges": [{"type": "GIT", "repo": "https://example.com/x.git", "events": [{"introduced": "0"}]}]},
+			{"package": {"ecosystem": "Maven", "name": "org.example:lib"},
+				"ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}]}]}]}`,
+	"other/malformed.json": `{"id": `,
+	"other/README.md":      "not an advisory",
+}
+
+// writeTestOSV writes testOSVRecords to a directory, plus an all.zip
+// archive with one more advisory, and returns the directory.
+func writeTestOSV(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range testOSVRecords {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := os.Create(filepath.Join(dir, "npm", "all.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("GHSA-aaaa-bbbb-cccc.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.Write([]byte(`{
+		"id": "GHSA-aaaa-bbbb-cccc",
+		"affected": [{"package": {"ecosystem": "npm", "name": "@scope/pkg"},
+			"ranges": [
+				{"type": "SEMVER", "events": [{"introduced": "3.0.0"}, {"fixed": "3.0.1"}]},
+				{"type": "SEMVER", "events": [{"introduced": "2.0.0"}, {"fixed": "2.1.0"}]}],
+			"database_specific": {"severity": "HIGH"}}]}`))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLoadVulnDB(t *testing.T) {
+	db, err := LoadVulnDB(writeTestOSV(t), testLogger)
+	if err != nil {
+		t.Fatalf("LoadVulnDB: %v", err)
+	}
+	if db.Len() != 6 {
+		t.Errorf("Len() = %d, want 6", db.Len())
+	}
+
+	tests := []struct {
+		name      string
+		dep       Dependency
+		wantID    string // "" = not affected
+		severity  string
+		fixed     string
+		wantCount int
+	}{
+		{"affected, aliases reported once", Dependency{Ecosystem: EcosystemPyPI, Name: "jinja2", Version: "2.10"}, "GHSA-g3rq-g295-4j3m", VulnSeverityMedium, "2.11.3", 1},
+		{"fixed version", Dependency{Ecosystem: EcosystemPyPI, Name: "jinja2", Version: "2.11.3"}, "", "", "", 0},
+		{"prerelease of fix is affected", Dependency{Ecosystem: EcosystemPyPI, Name: "jinja2", Version: "2.11.3rc1"}, "GHSA-g3rq-g295-4j3m", VulnSeverityMedium, "2.11.3", 1},
+		{"versions list", Dependency{Ecosystem: EcosystemPyPI, Name: "requests", Version: "2.19.0"}, "PYSEC-2018-28", VulnSeverityUnknown, "", 1},
+		{"not in versions list", Dependency{Ecosystem: EcosystemPyPI, Name: "requests", Version: "2.20.0"}, "", "", "", 0},
+		{"CVSS rated", Dependency{Ecosystem: EcosystemNPM, Name: "lodash", Version: "4.17.15"}, "GHSA-p6mc-m468-83gw", VulnSeverityCritical, "4.17.19", 1},
+		{"last_affected included", Dependency{Ecosystem: EcosystemNPM, Name: "minimist", Version: "1.2.5"}, "GHSA-xvch-5gv4-984h", VulnSeverityLow, "", 1},
+		{"after last_affected", Dependency{Ecosystem: EcosystemNPM, Name: "minimist", Version: "1.2.6"}, "", "", "", 0},
+		{"before introduced", Dependency{Ecosystem: EcosystemNPM, Name: "minimist", Version: "0.2.1"}, "", "", "", 0},
+		{"second range, from zip", Dependency{Ecosystem: EcosystemNPM, Name: "@scope/pkg", Version: "2.0.5"}, "GHSA-aaaa-bbbb-cccc", VulnSeverityHigh, "2.1.0", 1},
+		{"between ranges", Dependency{Ecosystem: EcosystemNPM, Name: "@scope/pkg", Version: "2.5.0"}, "", "", "", 0},
+		{"withdrawn", Dependency{Ecosystem: EcosystemNPM, Name: "left-pad", Version: "1.0.0"}, "", "", "", 0},
+		{"git ranges ignored", Dependency{Ecosystem: EcosystemNPM, Name: "gitonly", Version: "1.0.0"}, "", "", "", 0},
+		{"unpinned", Dependency{Ecosystem: EcosystemNPM, Name: "lodash", Specifier: "^4.17.0"}, "", "", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := db.lookup(tt.dep)
+			if len(got) != tt.wantCount {
+				t.Fatalf("lookup = %+v, want %d vulnerabilities", got, tt.wantCount)
+			}
+			if tt.wantCount == 0 {
+				return
+			}
+			if got[0].ID != tt.wantID || got[0].Severity != tt.severity || got[0].FixedVersion != tt.fixed {
+				t.Errorf("lookup = %+v, want %s %s fixed %q", got[0], tt.wantID, tt.severity, tt.fixed)
+			}
+		})
+	}
+}
+
+func TestLoadVulnDB_MissingDir(t *testing.T) {
+	if _, err := LoadVulnDB(filepath.Join(t.TempDir(), "missing"), testLogger); err == nil {
+		t.Error("expected error for a missing directory")
+	}
+}
+
+func TestCVSS3BaseScore(t *testing.T) {
+	tests := []struct {
+		vector string
+		score  float64
+		ok     bool
+	}{
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", 9.8, true},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N", 6.1, true},
+		{"CVSS:3.0/AV:N/AC:L/PR:L/UI:N/S:C/C:H/I:H/A:H", 9.9, true},
+		{"CVSS:3.1/AV:L/AC:H/PR:H/UI:R/S:U/C:N/I:N/A:N", 0, true},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/E:P", 9.8, true},
+		{"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N", 0, false},
+		{"CVSS:3.1/AV:N/AC:L", 0, false},
+		{"CVSS:3.1/AV:X/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", 0, false},
+	}
+	for _, tt := range tests {
+		score, ok := cvss3BaseScore(tt.vector)
+		if ok != tt.ok || score != tt.score {
+			t.Errorf("cvss3BaseScore(%q) = %v, %v; want %v, %v", tt.vector, score, ok, tt.score, tt.ok)
+		}
+	}
+}
@@ -28,6 +28,11 @@ type Finding struct {
 	MatchText   string   `json:"match_text,omitempty"`    // the matched text snippet (trimmed, max 120 chars)
 	Remediation string   `json:"remediation,omitempty"`   // actionable guidance for the skill author
 	IssueCode   string   `json:"issue_code,omitempty"`    // unique issue code (e.g. "E006", "W008")
+
+	// Known-vulnerability findings name the advisory that matched.
+	AdvisoryID       string `json:"advisory_id,omitempty"`       // OSV ID, e.g. "GHSA-xxxx-xxxx-xxxx"
+	AdvisorySeverity string `json:"advisory_severity,omitempty"` // LOW, MEDIUM, HIGH, CRITICAL or UNKNOWN
+	FixedVersion     string `json:"fixed_version,omitempty"`     // lowest version the advisory is fixed in
 }
 
 // ScanResult is the outcome of a full scanner pipeline run.
@@ -0,0 +1,176 @@
+package scanner
+
+import (
+	"path"
+	"sort"
+)
+
+// SARIF 2.1.0 schema and version written by ToSARIF.
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+// issueCodeTitles names the issue codes for SARIF rule descriptions. See
+// docs/SECURITY-SCANNER.md.
+var issueCodeTitles = map[string]string{
+	"E004": "Prompt injection",
+	"E005": "Suspicious URLs",
+	"E006": "Malicious code patterns",
+	"W007": "Credential exposure risk",
+	"W008": "Hardcoded secrets",
+	"W009": "Financial execution",
+	"W012": "Runtime external dependencies",
+	"W013": "System/service modification",
+	"W014": "Known vulnerable dependency",
+}
+
+// SARIFLog is a SARIF 2.1.0 log with a single run. Only the properties
+// code scanning tools and editors read are included.
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun is the run of one tool over one skill.
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+// SARIFTool describes the scanner and the rules its results refer to.
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver is the tool component that produced the results.
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+// SARIFRule is an issue code, or a finding category without one.
+type SARIFRule struct {
+	ID               string       `json:"id"`
+	ShortDescription SARIFMessage `json:"shortDescription"`
+}
+
+// SARIFMessage is a plain-text message.
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFResult is one finding.
+type SARIFResult struct {
+	RuleID     string          `json:"ruleId"`
+	Level      string          `json:"level"` // "error" for BLOCK, "warning" for FLAG
+	Message    SARIFMessage    `json:"message"`
+	Locations  []SARIFLocation `json:"locations,omitempty"`
+	Properties map[string]any  `json:"properties,omitempty"`
+}
+
+// SARIFLocation is the file and line a finding was detected at.
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation locates a finding in an artifact.
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+// SARIFArtifactLocation is the path of a file, relative to the directory
+// the tool ran in.
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIFRegion is the line of a finding.
+type SARIFRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// ToSARIF converts a scan result into a SARIF log. Findings are identified
+// by their issue code, or by their category when they have none. root is
+// prepended to the file paths of the findings, which are relative to the
+// skill directory, so that they resolve from where the log is consumed.
+func ToSARIF(result *ScanResult, toolVersion, root string) *SARIFLog {
+	rules := map[string]string{}
+	results := make([]SARIFResult, 0, len(result.Findings))
+	for _, f := range result.Findings {
+		id := f.IssueCode
+		title := issueCodeTitles[id]
+		if id == "" {
+			id = f.Category
+		}
+		if title == "" {
+			title = f.Category
+		}
+		rules[id] = title
+
+		level := "warning"
+		if f.Severity == SeverityBlock {
+			level = "error"
+		}
+		msg := f.Description
+		if f.Remediation != "" {
+			msg += " " + f.Remediation
+		}
+
+		r := SARIFResult{
+			RuleID:  id,
+			Level:   level,
+			Message: SARIFMessage{Text: msg},
+			Properties: map[string]any{
+				"stage":    f.Stage,
+				"severity": string(f.Severity),
+				"category": f.Category,
+			},
+		}
+		if f.AdvisoryID != "" {
+			r.Properties["advisory_id"] = f.AdvisoryID
+			r.Properties["advisory_severity"] = f.AdvisorySeverity
+			if f.FixedVersion != "" {
+				r.Properties["fixed_version"] = f.FixedVersion
+			}
+		}
+		if f.FilePath != "" {
+			loc := SARIFLocation{PhysicalLocation: SARIFPhysicalLocation{
+				ArtifactLocation: SARIFArtifactLocation{URI: path.Join(root, f.FilePath)},
+			}}
+			if f.Line > 0 {
+				loc.PhysicalLocation.Region = &SARIFRegion{StartLine: f.Line}
+			}
+			r.Locations = []SARIFLocation{loc}
+		}
+		results = append(results, r)
+	}
+
+	ids := make([]string, 0, len(rules))
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	driverRules := make([]SARIFRule, 0, len(ids))
+	for _, id := range ids {
+		driverRules = append(driverRules, SARIFRule{ID: id, ShortDescription: SARIFMessage{Text: rules[id]}})
+	}
+
+	return &SARIFLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []SARIFRun{{
+			Tool: SARIFTool{Driver: SARIFDriver{
+				Name:           "skillbox-scanner",
+				Version:        toolVersion,
+				InformationURI: "https://github.com/devs-group/skillbox",
+				Rules:          driverRules,
+			}},
+			Results: results,
+		}},
+	}
+}
@@ -0,0 +1,59 @@
+package scanner
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestToSARIF(t *testing.T) {
+	result := &ScanResult{Findings: []Finding{
+		{Stage: stageNamePatterns, Severity: SeverityBlock, Category: "reverse_shell", FilePath: "scripts/run.sh", Line: 3, Description: "Reverse shell", Remediation: "Remove it.", IssueCode: "E006"},
+		{Stage: stageNamePatterns, Severity: SeverityBlock, Category: "piped_execution", FilePath: "install.sh", Description: "Piped execution", IssueCode: "E006"},
+		{Stage: stageNameDeps, Severity: SeverityFlag, Category: "typosquat", FilePath: "requirements.txt", Line: 1, Description: "Possible typosquat"},
+	}}
+
+	log := ToSARIF(result, "1.2.3", "skills/demo")
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected log header: %+v", log)
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Version != "1.2.3" {
+		t.Errorf("driver version = %q", run.Tool.Driver.Version)
+	}
+	if len(run.Tool.Driver.Rules) != 2 {
+		t.Fatalf("expected 2 rules (E006, typosquat), got %+v", run.Tool.Driver.Rules)
+	}
+	if r := run.Tool.Driver.Rules[0]; r.ID != "E006" || r.ShortDescription.Text != "Malicious code patterns" {
+		t.Errorf("unexpected rule %+v", r)
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(run.Results))
+	}
+
+	first := run.Results[0]
+	if first.Level != "error" || first.RuleID != "E006" || first.Message.Text != "Reverse shell Remove it." {
+		t.Errorf("unexpected result %+v", first)
+	}
+	loc := first.Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "skills/demo/scripts/run.sh" || loc.Region == nil || loc.Region.StartLine != 3 {
+		t.Errorf("unexpected location %+v", loc)
+	}
+	if run.Results[1].Locations[0].PhysicalLocation.Region != nil {
+		t.Error("expected no region without a line")
+	}
+	if last := run.Results[2]; last.Level != "warning" || last.RuleID != "typosquat" {
+		t.Errorf("unexpected result %+v", last)
+	}
+
+	data, err := json.Marshal(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw["$schema"] == nil {
+		t.Error("expected $schema in the encoded log")
+	}
+}
@@ -0,0 +1,186 @@
+package scanner
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CycloneDX spec version written by BuildSBOM.
+const cycloneDXVersion = "1.5"
+
+// SBOM is a CycloneDX JSON software bill of materials for one skill
+// version. Only the properties SBOM tooling commonly reads are included.
+type SBOM struct {
+	BOMFormat    string           `json:"bomFormat"` // always "CycloneDX"
+	SpecVersion  string           `json:"specVersion"`
+	SerialNumber string           `json:"serialNumber"`
+	Version      int              `json:"version"`
+	Metadata     SBOMMetadata     `json:"metadata"`
+	Components   []SBOMComponent  `json:"components"`
+	Dependencies []SBOMDependency `json:"dependencies"`
+}
+
+// SBOMMetadata records when and by what the SBOM was generated, and the
+// skill it describes.
+type SBOMMetadata struct {
+	Timestamp string        `json:"timestamp"`
+	Tools     SBOMTools     `json:"tools"`
+	Component SBOMComponent `json:"component"`
+}
+
+// SBOMTools lists the tools that generated the SBOM.
+type SBOMTools struct {
+	Components []SBOMComponent `json:"components"`
+}
+
+// SBOMComponent is the skill itself, the generating tool, or a package
+// the skill depends on.
+type SBOMComponent struct {
+	Type       string         `json:"type"` // "application" or "library"
+	BOMRef     string         `json:"bom-ref,omitempty"`
+	Name       string         `json:"name"`
+	Version    string         `json:"version,omitempty"`
+	PURL       string         `json:"purl,omitempty"`
+	Properties []SBOMProperty `json:"properties,omitempty"`
+}
+
+// SBOMProperty is a name/value annotation. BuildSBOM uses
+// "skillbox:file" for the files a package was found in and
+// "skillbox:specifier" for declared version ranges.
+type SBOMProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// SBOMDependency lists the components a component depends on.
+type SBOMDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// BuildSBOM builds the SBOM of a skill version from its dependencies (see
+// ExtractDependencies). A package gets one component per exact version
+// found; a package that is only declared with a range gets a single
+// component without a version.
+func BuildSBOM(skillName, skillVersion string, deps []Dependency, toolVersion string) *SBOM {
+	skillRef := "skill:" + skillName + "@" + skillVersion
+	bom := &SBOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  cycloneDXVersion,
+		SerialNumber: "urn:uuid:" + uuid.NewString(),
+		Version:      1,
+		Metadata: SBOMMetadata{
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Tools: SBOMTools{Components: []SBOMComponent{
+				{Type: "application", Name: "skillbox", Version: toolVersion},
+			}},
+			Component: SBOMComponent{Type: "application", BOMRef: skillRef, Name: skillName, Version: skillVersion},
+		},
+		Components: []SBOMComponent{},
+	}
+
+	// Group the occurrences by package, then by exact version.
+	type pkgKey struct{ ecosystem, name string }
+	byPkg := make(map[pkgKey][]Dependency)
+	var keys []pkgKey
+	for _, d := range deps {
+		k := pkgKey{d.Ecosystem, d.Name}
+		if _, ok := byPkg[k]; !ok {
+			keys = append(keys, k)
+		}
+		byPkg[k] = append(byPkg[k], d)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].ecosystem != keys[j].ecosystem {
+			return keys[i].ecosystem < keys[j].ecosystem
+		}
+		return keys[i].name < keys[j].name
+	})
+
+	var refs []string
+	for _, k := range keys {
+		occurrences := byPkg[k]
+		var versions []string
+		var declared []Dependency
+		seen := make(map[string]bool)
+		for _, d := range occurrences {
+			switch {
+			case d.Version == "":
+				declared = append(declared, d)
+			case !seen[d.Version]:
+				seen[d.Version] = true
+				versions = append(versions, d.Version)
+			}
+		}
+		if len(versions) == 0 {
+			versions = []string{""}
+		}
+		sort.Strings(versions)
+
+		for _, v := range versions {
+			c := SBOMComponent{
+				Type:    "library",
+				Name:    k.name,
+				Version: v,
+				PURL:    PackageURL(k.ecosystem, k.name, v),
+			}
+			c.BOMRef = c.PURL
+			files := make(map[string]bool)
+			for _, d := range occurrences {
+				if (d.Version == v || d.Version == "") && !files[d.FilePath] {
+					files[d.FilePath] = true
+					c.Properties = append(c.Properties, SBOMProperty{Name: "skillbox:file", Value: d.FilePath})
+				}
+			}
+			for _, d := range declared {
+				if d.Specifier != "" {
+					c.Properties = append(c.Properties, SBOMProperty{Name: "skillbox:specifier", Value: d.Specifier})
+				}
+			}
+			bom.Components = append(bom.Components, c)
+			refs = append(refs, c.BOMRef)
+		}
+	}
+
+	bom.Dependencies = []SBOMDependency{{Ref: skillRef, DependsOn: refs}}
+	if refs == nil {
+		bom.Dependencies[0].DependsOn = []string{}
+	}
+	return bom
+}
+
+// PackageURL returns the package URL (purl) of a package, without a
+// version if version is empty.
+func PackageURL(ecosystem, name, version string) string {
+	var b strings.Builder
+	b.WriteString("pkg:")
+	switch ecosystem {
+	case EcosystemPyPI:
+		b.WriteString("pypi/")
+		b.WriteString(purlEscape(name))
+	case EcosystemNPM:
+		b.WriteString("npm/")
+		if scope, pkg, ok := strings.Cut(name, "/"); ok {
+			b.WriteString(purlEscape(scope))
+			b.WriteString("/")
+			name = pkg
+		}
+		b.WriteString(purlEscape(name))
+	default:
+		b.WriteString(strings.ToLower(ecosystem) + "/" + purlEscape(name))
+	}
+	if version != "" {
+		b.WriteString("@")
+		b.WriteString(purlEscape(version))
+	}
+	return b.String()
+}
+
+// purlEscape percent-encodes a purl segment, including "@" and "+".
+func purlEscape(s string) string {
+	return strings.NewReplacer("@", "%40", "+", "%2B").Replace(url.PathEscape(s))
+}
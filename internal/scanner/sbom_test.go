@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestBuildSBOM(t *testing.T) {
+	deps := []Dependency{
+		{Ecosystem: EcosystemNPM, Name: "@scope/pkg", Version: "1.0.0+build", FilePath: "package-lock.json"},
+		{Ecosystem: EcosystemNPM, Name: "lodash", Specifier: "^4.17.0", FilePath: "package.json"},
+		{Ecosystem: EcosystemNPM, Name: "lodash", Version: "4.17.21", FilePath: "package-lock.json"},
+		{Ecosystem: EcosystemNPM, Name: "lodash", Version: "4.17.20", FilePath: "node_modules/x/node_modules/lodash/package.json"},
+		{Ecosystem: EcosystemPyPI, Name: "httpx", Specifier: ">=0.27", FilePath: "requirements.txt"},
+	}
+
+	bom := BuildSBOM("my-skill", "1.2.0", deps, "v0.9.0")
+
+	if bom.BOMFormat != "CycloneDX" || bom.SpecVersion != "1.5" || !strings.HasPrefix(bom.SerialNumber, "urn:uuid:") {
+		t.Errorf("header = %s %s %s", bom.BOMFormat, bom.SpecVersion, bom.SerialNumber)
+	}
+	if c := bom.Metadata.Component; c.Name != "my-skill" || c.Version != "1.2.0" || c.BOMRef != "skill:my-skill@1.2.0" {
+		t.Errorf("metadata component = %+v", c)
+	}
+	if tools := bom.Metadata.Tools.Components; len(tools) != 1 || tools[0].Version != "v0.9.0" {
+		t.Errorf("tools = %+v", tools)
+	}
+
+	var purls []string
+	for _, c := range bom.Components {
+		purls = append(purls, c.PURL)
+	}
+	want := []string{
+		"pkg:pypi/httpx",
+		"pkg:npm/%40scope/pkg@1.0.0%2Bbuild",
+		"pkg:npm/lodash@4.17.20",
+		"pkg:npm/lodash@4.17.21",
+	}
+	if strings.Join(purls, " ") != strings.Join(want, " ") {
+		t.Fatalf("components = %v, want %v", purls, want)
+	}
+
+	// The declared range is recorded on every locked version of lodash.
+	lodash := bom.Components[3]
+	var props []string
+	for _, p := range lodash.Properties {
+		props = append(props, p.Name+"="+p.Value)
+	}
+	if got := strings.Join(props, ","); got != "skillbox:file=package.json,skillbox:file=package-lock.json,skillbox:specifier=^4.17.0" {
+		t.Errorf("lodash properties = %s", got)
+	}
+
+	if len(bom.Dependencies) != 1 || len(bom.Dependencies[0].DependsOn) != 4 {
+		t.Errorf("dependencies = %+v", bom.Dependencies)
+	}
+}
+
+func TestBuildSBOM_NoDependencies(t *testing.T) {
+	data, err := json.Marshal(BuildSBOM("s", "1.0.0", nil, "dev"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"components":[]`, `"dependsOn":[]`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("SBOM %s does not contain %s", data, want)
+		}
+	}
+}
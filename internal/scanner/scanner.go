@@ -42,6 +42,7 @@ type Pipeline struct {
 	// (user-uploaded, separate from embedded defaults). Protected by mu.
 	customPatterns *PatternFile
 	ossfFeedDir    string
+	vulnDB         *VulnDB // known-vulnerability database, nil when not configured
 }
 
 // New creates a new scanner Pipeline configured for Tier 1 and Tier 2 scanning.
@@ -136,6 +137,9 @@ func (p *Pipeline) SetCustomPatterns(pf *PatternFile) error {
 		newPromptStage(p.logger),
 		newSecurityStage(p.logger),
 	}
+	if p.vulnDB != nil {
+		p.tier2 = append(p.tier2, newVulnStage(p.logger, p.vulnDB))
+	}
 
 	p.logger.Info("custom patterns reloaded",
 		"block_patterns", len(lp.blockPatterns),
@@ -146,6 +150,26 @@ func (p *Pipeline) SetCustomPatterns(pf *PatternFile) error {
 	return nil
 }
 
+// SetVulnDB enables matching dependencies against the known
+// vulnerabilities in db as part of Tier 2, replacing any database set
+// before. Pass nil to disable it.
+func (p *Pipeline) SetVulnDB(db *VulnDB) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.vulnDB = db
+	tier2 := make([]stage, 0, len(p.tier2)+1)
+	for _, s := range p.tier2 {
+		if _, ok := s.(*vulnStage); !ok {
+			tier2 = append(tier2, s)
+		}
+	}
+	if db != nil {
+		tier2 = append(tier2, newVulnStage(p.logger, db))
+	}
+	p.tier2 = tier2
+}
+
 // Scan runs the tiered scanning pipeline.
 //
 // Tier 1 (Quick Scan): Runs static patterns + dep blocklist.
@@ -153,7 +177,8 @@ func (p *Pipeline) SetCustomPatterns(pf *PatternFile) error {
 //   - BLOCK findings → reject immediately.
 //   - FLAG findings → escalate to Tier 2.
 //
-// Tier 2 (Deep Scan): Typosquatting, prompt injection, Unicode analysis.
+// Tier 2 (Deep Scan): Typosquatting, prompt injection, Unicode analysis,
+// and known vulnerabilities when a vulnerability database is set.
 //   - BLOCK findings → reject.
 //   - Unresolved flags → escalate to Tier 3 (if enabled).
 //   - All resolved → accept.
@@ -222,8 +247,15 @@ func (p *Pipeline) Scan(ctx context.Context, zr *zip.Reader, s *skill.Skill) (*S
 		return result, nil
 	}
 
-	// Collect all unresolved flags from Tier 1 + Tier 2.
-	allFlags := collectFlags(result.Findings)
+	// Collect all unresolved flags from Tier 1 + Tier 2. Known
+	// vulnerabilities are matched against advisories, not judged, so they
+	// are left to ApplyVulnThreshold rather than escalated.
+	var allFlags []Finding
+	for _, f := range collectFlags(result.Findings) {
+		if f.Stage != stageNameVulns {
+			allFlags = append(allFlags, f)
+		}
+	}
 
 	// --- Tier 3: LLM Analysis (optional) ---
 	if len(allFlags) > 0 && len(p.tier3) > 0 {
@@ -251,6 +283,57 @@ func (p *Pipeline) Scan(ctx context.Context, zr *zip.Reader, s *skill.Skill) (*S
 	return result, nil
 }
 
+// stageNameZipCheck is the Stage of findings from CheckZIPSafety in Lint.
+const stageNameZipCheck = "zipcheck"
+
+// Lint runs the ZIP safety checks and the Tier 1 and Tier 2 stages over an
+// archive, for skill authors checking a skill before upload. Unlike Scan
+// it reports every finding instead of stopping at the first tier with a
+// BLOCK, never runs Tier 3 and records no metrics. An archive that fails
+// the ZIP safety checks gets a single BLOCK finding and is not scanned
+// further, as an upload would be rejected before scanning.
+func (p *Pipeline) Lint(ctx context.Context, zr *zip.Reader) (*ScanResult, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	start := time.Now()
+	result := &ScanResult{Pass: true}
+
+	if err := CheckZIPSafety(zr); err != nil {
+		result.Pass = false
+		result.Findings = []Finding{{
+			Stage:       stageNameZipCheck,
+			Severity:    SeverityBlock,
+			Category:    "unsafe_archive",
+			Description: err.Error(),
+			Remediation: "Remove nested archives, symlinks and oversized files, and keep the skill under 500 files.",
+		}}
+		result.Duration = time.Since(start)
+		result.GenerateSummary()
+		return result, nil
+	}
+
+	result.Tier = 1
+	t1Findings, err := p.runStages(ctx, p.tier1, zr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("tier 1: %w", err)
+	}
+	result.Tier = 2
+	t2Findings, err := p.runStages(ctx, p.tier2, zr, collectFlags(t1Findings))
+	if err != nil {
+		return nil, fmt.Errorf("tier 2: %w", err)
+	}
+	result.Findings = append(t1Findings, t2Findings...)
+
+	result.Pass = !hasBlock(result.Findings)
+	result.Duration = time.Since(start)
+	result.GenerateSummary()
+	return result, nil
+}
+
 // runStages executes a slice of stages sequentially, collecting findings.
 func (p *Pipeline) runStages(ctx context.Context, stages []stage, zr *zip.Reader, priorFlags []Finding) ([]Finding, error) {
 	var all []Finding
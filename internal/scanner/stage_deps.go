@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"path"
 	"strings"
 	"unicode"
 )
@@ -16,7 +17,12 @@ const stageNameDeps = "dependencies"
 //   - Typosquatting via Levenshtein distance against popular packages
 //   - Homoglyph/mixed-script package names
 //   - preinstall/postinstall npm hooks
-//   - pyproject.toml dependency parsing
+//
+// Package names come from ExtractDependencies, the parsers the SBOM is
+// built from, limited to the manifests a skill author writes
+// (requirements*.txt, pyproject.toml, package.json). Lockfiles and
+// vendored packages list transitive dependencies, whose short names would
+// make the distance check noisy.
 type depsStage struct {
 	logger            *slog.Logger
 	popularPackages   map[string]bool
@@ -42,144 +48,33 @@ func (ds *depsStage) run(ctx context.Context, zr *zip.Reader, _ []Finding) ([]Fi
 		if ctx.Err() != nil {
 			return nil, fmt.Errorf("%s: %w", stageNameDeps, ctx.Err())
 		}
-
-		if f.FileInfo().IsDir() {
+		if f.FileInfo().IsDir() || path.Base(f.Name) != "package.json" {
 			continue
 		}
-
-		name := strings.TrimPrefix(f.Name, "./")
-		switch {
-		case name == "requirements.txt" || strings.HasSuffix(name, "/requirements.txt"):
-			content, err := readZipFileContent(f)
-			if err != nil {
-				return nil, fmt.Errorf("%s: read %s: %w", stageNameDeps, f.Name, err)
-			}
-			findings = append(findings, ds.checkRequirementsTyposquat(string(content), f.Name)...)
-
-		case name == "pyproject.toml" || strings.HasSuffix(name, "/pyproject.toml"):
-			content, err := readZipFileContent(f)
-			if err != nil {
-				return nil, fmt.Errorf("%s: read %s: %w", stageNameDeps, f.Name, err)
-			}
-			findings = append(findings, ds.checkPyprojectToml(string(content), f.Name)...)
-
-		case name == "package.json" || strings.HasSuffix(name, "/package.json"):
-			content, err := readZipFileContent(f)
-			if err != nil {
-				return nil, fmt.Errorf("%s: read %s: %w", stageNameDeps, f.Name, err)
-			}
-			findings = append(findings, ds.checkPackageJSONHooks(string(content), f.Name)...)
-			findings = append(findings, ds.checkPackageJSONTyposquat(string(content), f.Name)...)
+		content, err := readZipFileContent(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: read %s: %w", stageNameDeps, f.Name, err)
 		}
+		findings = append(findings, ds.checkPackageJSONHooks(string(content), f.Name)...)
 	}
 
-	return findings, nil
-}
-
-// checkRequirementsTyposquat parses requirements.txt and checks each package
-// for typosquatting and homoglyph attacks.
-func (ds *depsStage) checkRequirementsTyposquat(content, filePath string) []Finding {
-	var findings []Finding
-	for _, line := range strings.Split(content, "\n") {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
-			continue
-		}
-		pkg := extractPkgName(line)
-		if pkg == "" {
-			continue
-		}
-
-		// Already in blocklist → handled by Tier 1. Skip.
-		if ds.blocklistPackages[pkg] {
-			continue
-		}
-
-		// Already a known popular package → not a typosquat.
-		if ds.popularPackages[pkg] {
-			continue
-		}
-
-		findings = append(findings, ds.checkPackageName(pkg, filePath)...)
+	deps, err := ExtractDependencies(zr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", stageNameDeps, err)
 	}
-	return findings
-}
-
-// pyprojectMetadataKeys are keys in [project] and [tool.poetry] sections that
-// are NOT dependency names and should be skipped.
-var pyprojectMetadataKeys = map[string]bool{
-	"name": true, "version": true, "description": true, "readme": true,
-	"license": true, "authors": true, "maintainers": true, "keywords": true,
-	"classifiers": true, "urls": true, "scripts": true, "gui-scripts": true,
-	"entry-points": true, "requires-python": true, "python": true,
-	"dependencies": true, "optional-dependencies": true,
-}
-
-// checkPyprojectToml does a simple line-by-line extraction of dependency names
-// from pyproject.toml. Parses [project.dependencies] and
-// [tool.poetry.dependencies] sections without a full TOML parser.
-func (ds *depsStage) checkPyprojectToml(content, filePath string) []Finding {
-	var findings []Finding
-	inDeps := false
-	inDepArray := false
-
-	for _, line := range strings.Split(content, "\n") {
-		trimmed := strings.TrimSpace(line)
-
-		// Detect section headers.
-		if strings.HasPrefix(trimmed, "[") {
-			lower := strings.ToLower(trimmed)
-			inDeps = lower == "[project.dependencies]" ||
-				lower == "[tool.poetry.dependencies]"
-			inDepArray = false
+	for _, d := range deps {
+		if !isDeclaredManifest(d.FilePath) {
 			continue
 		}
-
-		// Inside [project] (not [project.dependencies]), look for the
-		// dependencies = [...] inline array.
-		// For simplicity, we only handle [project.dependencies] and
-		// [tool.poetry.dependencies] sections.
-
-		if !inDeps {
-			continue
-		}
-
-		// Handle start/end of array.
-		if strings.HasPrefix(trimmed, "dependencies") && strings.Contains(trimmed, "[") {
-			inDepArray = true
-			continue
-		}
-		if trimmed == "]" {
-			inDepArray = false
-			continue
-		}
-
-		if inDepArray {
-			// Array items: "requests>=2.0",
-			item := strings.Trim(trimmed, `"' ,`)
-			pkg := extractPkgName(item)
-			if pkg != "" && !ds.blocklistPackages[pkg] && !ds.popularPackages[pkg] {
-				findings = append(findings, ds.checkPackageName(pkg, filePath)...)
-			}
-			continue
-		}
-
-		// For poetry style: requests = "^2.28"
-		if idx := strings.Index(trimmed, "="); idx > 0 {
-			pkg := strings.TrimSpace(trimmed[:idx])
-			pkg = strings.Trim(pkg, `"'`)
-			pkg = strings.ToLower(pkg)
-			if pyprojectMetadataKeys[pkg] || pkg == "" {
-				continue
-			}
-			if !ds.blocklistPackages[pkg] && !ds.popularPackages[pkg] {
-				findings = append(findings, ds.checkPackageName(pkg, filePath)...)
-			}
+		// Blocklisted packages are handled by Tier 1, and popular packages
+		// are not typosquats.
+		if ds.blocklistPackages[d.Name] || ds.popularPackages[d.Name] {
 			continue
 		}
+		findings = append(findings, ds.checkPackageName(d.Name, d.FilePath)...)
 	}
 
-	return findings
+	return findings, nil
 }
 
 // checkPackageJSONHooks checks for preinstall/postinstall scripts in package.json.
@@ -213,71 +108,6 @@ func (ds *depsStage) checkPackageJSONHooks(content, filePath string) []Finding {
 	return findings
 }
 
-// checkPackageJSONTyposquat extracts dependency names from package.json and
-// checks for typosquatting. Only parses dependencies/devDependencies/peerDependencies.
-func (ds *depsStage) checkPackageJSONTyposquat(content, filePath string) []Finding {
-	var findings []Finding
-
-	// Track brace depth to correctly handle nested objects.
-	inDeps := false
-	braceDepth := 0
-
-	for _, line := range strings.Split(content, "\n") {
-		trimmed := strings.TrimSpace(line)
-
-		// Detect dependency section start.
-		if !inDeps {
-			if (strings.Contains(trimmed, `"dependencies"`) ||
-				strings.Contains(trimmed, `"devDependencies"`) ||
-				strings.Contains(trimmed, `"peerDependencies"`)) &&
-				strings.Contains(trimmed, "{") {
-				inDeps = true
-				braceDepth = 1
-				continue
-			}
-			// Handle case where { is on the next line.
-			if strings.Contains(trimmed, `"dependencies"`) ||
-				strings.Contains(trimmed, `"devDependencies"`) ||
-				strings.Contains(trimmed, `"peerDependencies"`) {
-				inDeps = true
-				braceDepth = 0
-				continue
-			}
-			continue
-		}
-
-		// Track brace depth.
-		for _, ch := range trimmed {
-			if ch == '{' {
-				braceDepth++
-			}
-			if ch == '}' {
-				braceDepth--
-			}
-		}
-		if braceDepth <= 0 {
-			inDeps = false
-			continue
-		}
-
-		// Extract package name from "pkg-name": "version"
-		parts := strings.SplitN(trimmed, ":", 2)
-		if len(parts) != 2 {
-			continue
-		}
-		pkg := strings.Trim(strings.TrimSpace(parts[0]), `",`)
-		pkg = strings.ToLower(pkg)
-
-		if pkg == "" || ds.blocklistPackages[pkg] || ds.popularPackages[pkg] {
-			continue
-		}
-
-		findings = append(findings, ds.checkPackageName(pkg, filePath)...)
-	}
-
-	return findings
-}
-
 // checkPackageName checks a single package name for typosquatting and homoglyphs.
 func (ds *depsStage) checkPackageName(pkg, filePath string) []Finding {
 	var findings []Finding
@@ -338,21 +168,6 @@ func (ds *depsStage) checkPackageName(pkg, filePath string) []Finding {
 	return findings
 }
 
-// extractPkgName extracts the package name from a requirements.txt line,
-// stripping version specifiers and extras.
-func extractPkgName(line string) string {
-	line = strings.TrimSpace(line)
-	if line == "" {
-		return ""
-	}
-	for _, sep := range []string{"==", ">=", "<=", "!=", "~=", ">", "<", "[", ";", "@"} {
-		if idx := strings.Index(line, sep); idx >= 0 {
-			line = line[:idx]
-		}
-	}
-	return strings.TrimSpace(strings.ToLower(line))
-}
-
 // hasMixedScript returns true if a string contains characters from multiple
 // Unicode scripts (e.g., Latin + Cyrillic), which indicates a homoglyph attack.
 func hasMixedScript(s string) bool {
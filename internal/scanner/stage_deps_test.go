@@ -116,9 +116,9 @@ func TestDepsStage(t *testing.T) {
 		{
 			name: "pyproject.toml with clean deps",
 			files: map[string]string{
-				"pyproject.toml": `[project.dependencies]
-requests = ">=2.28"
-flask = ">=2.0"
+				"pyproject.toml": `[project]
+name = "my-skill"
+dependencies = ["requests>=2.28", "flask>=2.0"]
 `,
 			},
 			wantNoFindings: true,
@@ -134,6 +134,43 @@ requets = "^2.28"
 			wantBlock:    true,
 			wantCategory: "typosquat_package",
 		},
+		{
+			name: "pyproject.toml PEP 621 typosquat",
+			files: map[string]string{
+				"pyproject.toml": `[project]
+name = "my-skill"
+dependencies = [
+    "flask>=2.0",
+    "requets[socks]>=2.28; python_version > '3.8'",
+]
+`,
+			},
+			wantBlock:    true,
+			wantCategory: "typosquat_package",
+		},
+		{
+			name: "typosquat in requirements-dev.txt",
+			files: map[string]string{
+				"requirements-dev.txt": "requets==2.28.0\n",
+			},
+			wantBlock:    true,
+			wantCategory: "typosquat_package",
+		},
+		{
+			name: "lockfile entries are not checked",
+			files: map[string]string{
+				"package-lock.json": `{"lockfileVersion": 3, "packages": {"node_modules/expresss": {"version": "4.18.0"}}}`,
+			},
+			wantNoFindings: true,
+		},
+		{
+			name: "install hook in a vendored package",
+			files: map[string]string{
+				"node_modules/evil/package.json": `{"name": "evil", "version": "1.0.0", "scripts": {"postinstall": "node x.js"}}`,
+			},
+			wantBlock:    true,
+			wantCategory: "install_hook",
+		},
 		{
 			name: "already blocklisted package — skipped by deps stage",
 			files: map[string]string{
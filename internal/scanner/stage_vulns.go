@@ -0,0 +1,106 @@
+package scanner
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+const (
+	stageNameVulns = "vulnerabilities"
+
+	categoryVulnerableDep = "vulnerable_dependency"
+)
+
+// vulnStage implements the stage interface for Tier 2 known-vulnerability
+// matching. It reports every pinned, locked or vendored dependency version
+// that an advisory in the OSV database affects. Declared ranges are not
+// resolved, so a dependency without an exact version is never reported.
+//
+// Findings are always FLAG; ApplyVulnThreshold raises them to BLOCK by the
+// tenant's severity threshold once the scan is done.
+type vulnStage struct {
+	logger *slog.Logger
+	db     *VulnDB
+}
+
+func newVulnStage(logger *slog.Logger, db *VulnDB) *vulnStage {
+	return &vulnStage{logger: logger, db: db}
+}
+
+func (vs *vulnStage) name() string {
+	return stageNameVulns
+}
+
+func (vs *vulnStage) run(ctx context.Context, zr *zip.Reader, _ []Finding) ([]Finding, error) {
+	deps, err := ExtractDependencies(zr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", stageNameVulns, err)
+	}
+
+	var findings []Finding
+	for _, d := range deps {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%s: %w", stageNameVulns, ctx.Err())
+		}
+		for _, v := range vs.db.lookup(d) {
+			findings = append(findings, vulnFinding(d, v))
+		}
+	}
+	return findings, nil
+}
+
+// vulnFinding describes an advisory affecting a dependency.
+func vulnFinding(d Dependency, v vulnerability) Finding {
+	id := v.ID
+	if len(v.Aliases) > 0 {
+		id += " (" + strings.Join(v.Aliases, ", ") + ")"
+	}
+	desc := fmt.Sprintf("%s %s has a known %s severity vulnerability: %s", d.Name, d.Version, strings.ToLower(v.Severity), id)
+	if v.Summary != "" {
+		desc += " — " + v.Summary
+	}
+
+	remediation := fmt.Sprintf("Upgrade %s to %s or later.", d.Name, v.FixedVersion)
+	if v.FixedVersion == "" {
+		remediation = fmt.Sprintf("No fixed version of %s is known; replace the package or remove it.", d.Name)
+	}
+
+	return Finding{
+		Stage:            stageNameVulns,
+		Severity:         SeverityFlag,
+		Category:         categoryVulnerableDep,
+		FilePath:         d.FilePath,
+		Description:      desc,
+		MatchText:        d.Name + "@" + d.Version,
+		Remediation:      remediation,
+		IssueCode:        "W014",
+		AdvisoryID:       v.ID,
+		AdvisorySeverity: v.Severity,
+		FixedVersion:     v.FixedVersion,
+	}
+}
+
+// ApplyVulnThreshold raises the known-vulnerability findings of result
+// rated at or above threshold ("low", "medium", "high" or "critical")
+// from FLAG to BLOCK and fails the result if any were raised. Advisories
+// of unknown severity stay FLAG. A threshold of "none" blocks nothing.
+func ApplyVulnThreshold(result *ScanResult, threshold string) {
+	minRank := vulnSeverityRank(threshold)
+	if result == nil || minRank == 0 {
+		return
+	}
+	blocked := false
+	for i, f := range result.Findings {
+		if f.Stage == stageNameVulns && f.Severity == SeverityFlag && vulnSeverityRank(f.AdvisorySeverity) >= minRank {
+			result.Findings[i].Severity = SeverityBlock
+			blocked = true
+		}
+	}
+	if blocked {
+		result.Pass = false
+		result.GenerateSummary()
+	}
+}
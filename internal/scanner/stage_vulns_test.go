@@ -0,0 +1,122 @@
+package scanner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// vulnerableSkill pins a moderate Jinja2 and a critical lodash advisory
+// from testOSVRecords.
+var vulnerableSkill = map[string]string{
+	"SKILL.md":          "---\nname: test-skill\nversion: 1.0.0\ndescription: test\n---\n",
+	"requirements.txt":  "jinja2==2.10\nrequests>=2.0\n",
+	"package-lock.json": `{"lockfileVersion": 3, "packages": {"node_modules/lodash": {"version": "4.17.15"}}}`,
+}
+
+func TestVulnStage_Findings(t *testing.T) {
+	db, err := LoadVulnDB(writeTestOSV(t), testLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	findings, err := newVulnStage(testLogger, db).run(context.Background(), buildZip(t, vulnerableSkill), nil)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("got %d findings %+v, want 2", len(findings), findings)
+	}
+
+	jinja := findings[0]
+	if jinja.Severity != SeverityFlag || jinja.IssueCode != "W014" || jinja.Category != categoryVulnerableDep {
+		t.Errorf("finding = %+v", jinja)
+	}
+	if jinja.AdvisoryID != "GHSA-g3rq-g295-4j3m" || jinja.AdvisorySeverity != VulnSeverityMedium || jinja.FixedVersion != "2.11.3" {
+		t.Errorf("advisory = %s %s %s", jinja.AdvisoryID, jinja.AdvisorySeverity, jinja.FixedVersion)
+	}
+	if jinja.FilePath != "requirements.txt" || !strings.Contains(jinja.Description, "CVE-2020-28493") ||
+		jinja.Remediation != "Upgrade jinja2 to 2.11.3 or later." {
+		t.Errorf("finding = %+v", jinja)
+	}
+	if lodash := findings[1]; lodash.AdvisorySeverity != VulnSeverityCritical || lodash.FilePath != "package-lock.json" {
+		t.Errorf("finding = %+v", lodash)
+	}
+}
+
+func TestApplyVulnThreshold(t *testing.T) {
+	findings := func() []Finding {
+		return []Finding{
+			{Stage: stageNameVulns, Severity: SeverityFlag, AdvisorySeverity: VulnSeverityMedium},
+			{Stage: stageNameVulns, Severity: SeverityFlag, AdvisorySeverity: VulnSeverityCritical},
+			{Stage: stageNameVulns, Severity: SeverityFlag, AdvisorySeverity: VulnSeverityUnknown},
+			{Stage: stageNameDeps, Severity: SeverityFlag, Category: "typosquat"},
+		}
+	}
+	tests := []struct {
+		threshold string
+		blocked   []bool
+	}{
+		{"critical", []bool{false, true, false, false}},
+		{"MEDIUM", []bool{true, true, false, false}},
+		{"low", []bool{true, true, false, false}},
+		{"none", []bool{false, false, false, false}},
+		{"", []bool{false, false, false, false}},
+	}
+	for _, tt := range tests {
+		result := &ScanResult{Pass: true, Findings: findings()}
+		ApplyVulnThreshold(result, tt.threshold)
+
+		anyBlocked := false
+		for i, f := range result.Findings {
+			blocked := f.Severity == SeverityBlock
+			anyBlocked = anyBlocked || blocked
+			if blocked != tt.blocked[i] {
+				t.Errorf("threshold %q: finding %d blocked = %v, want %v", tt.threshold, i, blocked, tt.blocked[i])
+			}
+		}
+		if result.Pass == anyBlocked {
+			t.Errorf("threshold %q: Pass = %v with blocked findings = %v", tt.threshold, result.Pass, anyBlocked)
+		}
+	}
+}
+
+func TestScan_VulnerabilitiesNotEscalated(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("known vulnerabilities were escalated to LLM analysis")
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	p := mustNew(t, 30*time.Second, testLogger, &LLMConfig{
+		APIKey: "test-key", Model: "test", Timeout: 5 * time.Second, MaxConcurrent: 1, BaseURL: srv.URL,
+	}, "", "")
+	db, err := LoadVulnDB(writeTestOSV(t), testLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.SetVulnDB(db)
+
+	result, err := p.Scan(context.Background(), buildZip(t, vulnerableSkill), testSkill())
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if !result.Pass || result.Tier != 2 {
+		t.Errorf("Pass = %v, Tier = %d; want a pass at tier 2", result.Pass, result.Tier)
+	}
+	if n := len(collectFlags(result.Findings)); n != 2 {
+		t.Errorf("got %d flags, want 2: %+v", n, result.Findings)
+	}
+
+	// Clearing the database removes the stage.
+	p.SetVulnDB(nil)
+	result, err = p.Scan(context.Background(), buildZip(t, vulnerableSkill), testSkill())
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(result.Findings) != 0 {
+		t.Errorf("findings without a database: %+v", result.Findings)
+	}
+}
@@ -0,0 +1,295 @@
+package scanner
+
+import (
+	"regexp"
+	"strings"
+)
+
+// compareVersions compares two versions of a package in ecosystem: PEP 440
+// for PyPI and semver for npm. ok is false when either version cannot be
+// parsed, in which case the result is meaningless.
+func compareVersions(ecosystem, a, b string) (cmp int, ok bool) {
+	if ecosystem == EcosystemPyPI {
+		va, okA := parsePEP440(a)
+		vb, okB := parsePEP440(b)
+		if !okA || !okB {
+			return 0, false
+		}
+		return va.compare(vb), true
+	}
+	va, okA := parseSemver(a)
+	vb, okB := parseSemver(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	return va.compare(vb), true
+}
+
+// compareNumeric compares two strings of decimal digits by value, without
+// the overflow limits of strconv.
+func compareNumeric(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// --------------------------------------------------------------------
+// semver (npm)
+// --------------------------------------------------------------------
+
+// semver is a parsed semantic version. Build metadata is dropped since it
+// does not take part in precedence.
+type semver struct {
+	core [3]string
+	pre  []string
+}
+
+// parseSemver parses a semantic version, tolerating a leading "v" or "="
+// and missing minor or patch numbers ("1.2" is 1.2.0).
+func parseSemver(s string) (semver, bool) {
+	s = strings.TrimLeft(strings.TrimSpace(s), "=v")
+	if i := strings.Index(s, "+"); i >= 0 {
+		s = s[:i]
+	}
+	var v semver
+	core, pre, hasPre := strings.Cut(s, "-")
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return semver{}, false
+	}
+	for i := range v.core {
+		v.core[i] = "0"
+		if i < len(parts) {
+			if !isNumeric(parts[i]) {
+				return semver{}, false
+			}
+			v.core[i] = parts[i]
+		}
+	}
+	if hasPre {
+		if pre == "" {
+			return semver{}, false
+		}
+		v.pre = strings.Split(pre, ".")
+	}
+	return v, true
+}
+
+// compare orders versions by semver precedence: a prerelease sorts before
+// its release, and prerelease identifiers compare numerically when both
+// are numbers and lexically otherwise.
+func (v semver) compare(o semver) int {
+	for i := range v.core {
+		if c := compareNumeric(v.core[i], o.core[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(v.pre) == 0 && len(o.pre) == 0:
+		return 0
+	case len(v.pre) == 0:
+		return 1
+	case len(o.pre) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.pre) && i < len(o.pre); i++ {
+		a, b := v.pre[i], o.pre[i]
+		numA, numB := isNumeric(a), isNumeric(b)
+		var c int
+		switch {
+		case numA && numB:
+			c = compareNumeric(a, b)
+		case numA:
+			c = -1
+		case numB:
+			c = 1
+		default:
+			c = strings.Compare(a, b)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(v.pre), len(o.pre))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// --------------------------------------------------------------------
+// PEP 440 (PyPI)
+// --------------------------------------------------------------------
+
+// pep440Pattern is the version pattern from PEP 440, Appendix B.
+var pep440Pattern = regexp.MustCompile(`(?i)^\s*v?` +
+	`(?:(?P<epoch>[0-9]+)!)?` +
+	`(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+	`(?:[-_.]?(?P<pre_l>alpha|a|beta|b|preview|pre|c|rc)[-_.]?(?P<pre_n>[0-9]+)?)?` +
+	`(?:-(?P<post_n1>[0-9]+)|[-_.]?(?P<post_l>post|rev|r)[-_.]?(?P<post_n2>[0-9]+)?)?` +
+	`(?:[-_.]?(?P<dev_l>dev)[-_.]?(?P<dev_n>[0-9]+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?\s*$`)
+
+// Release phases of a PEP 440 version, in sort order. A version with only
+// a dev segment ("1.0.dev1") sorts before all of its pre-releases.
+const (
+	pep440DevOnly = iota
+	pep440Alpha
+	pep440Beta
+	pep440RC
+	pep440Final
+)
+
+// pep440Version is a parsed PEP 440 version.
+type pep440Version struct {
+	epoch   string
+	release []string
+	phase   int
+	pre     string
+	post    string
+	hasPost bool
+	dev     string
+	hasDev  bool
+	local   []string
+}
+
+// parsePEP440 parses a PEP 440 version, accepting the alternative
+// spellings the specification normalizes ("1.0-alpha.1", "1.0-1").
+func parsePEP440(s string) (pep440Version, bool) {
+	m := pep440Pattern.FindStringSubmatch(s)
+	if m == nil {
+		return pep440Version{}, false
+	}
+	group := func(name string) string { return m[pep440Pattern.SubexpIndex(name)] }
+
+	v := pep440Version{epoch: group("epoch"), phase: pep440Final}
+	if v.epoch == "" {
+		v.epoch = "0"
+	}
+	v.release = strings.Split(group("release"), ".")
+
+	if l := strings.ToLower(group("pre_l")); l != "" {
+		switch l {
+		case "a", "alpha":
+			v.phase = pep440Alpha
+		case "b", "beta":
+			v.phase = pep440Beta
+		default:
+			v.phase = pep440RC
+		}
+		v.pre = group("pre_n")
+	}
+	if n := group("post_n1"); n != "" {
+		v.post, v.hasPost = n, true
+	} else if group("post_l") != "" {
+		v.post, v.hasPost = group("post_n2"), true
+	}
+	if group("dev_l") != "" {
+		v.dev, v.hasDev = group("dev_n"), true
+		if v.phase == pep440Final && !v.hasPost {
+			v.phase = pep440DevOnly
+		}
+	}
+	if local := group("local"); local != "" {
+		v.local = strings.FieldsFunc(strings.ToLower(local), func(r rune) bool {
+			return r == '-' || r == '_' || r == '.'
+		})
+	}
+	return v, true
+}
+
+// compare orders versions as PEP 440 does: epoch, release, pre-release,
+// post-release, dev-release, then local version label.
+func (v pep440Version) compare(o pep440Version) int {
+	if c := compareNumeric(v.epoch, o.epoch); c != 0 {
+		return c
+	}
+	// Missing release segments count as zero: 1.0 == 1.
+	for i := 0; i < len(v.release) || i < len(o.release); i++ {
+		a, b := "0", "0"
+		if i < len(v.release) {
+			a = v.release[i]
+		}
+		if i < len(o.release) {
+			b = o.release[i]
+		}
+		if c := compareNumeric(a, b); c != 0 {
+			return c
+		}
+	}
+	if c := compareInts(v.phase, o.phase); c != 0 {
+		return c
+	}
+	if c := compareNumeric(v.pre, o.pre); c != 0 {
+		return c
+	}
+	// No post-release sorts before any post-release.
+	if v.hasPost != o.hasPost {
+		if v.hasPost {
+			return 1
+		}
+		return -1
+	}
+	if c := compareNumeric(v.post, o.post); c != 0 {
+		return c
+	}
+	// A dev release sorts before the same version without one.
+	if v.hasDev != o.hasDev {
+		if v.hasDev {
+			return -1
+		}
+		return 1
+	}
+	if c := compareNumeric(v.dev, o.dev); c != 0 {
+		return c
+	}
+	return compareLocal(v.local, o.local)
+}
+
+// compareLocal compares local version labels segment by segment: numeric
+// segments by value and above alphanumeric ones, which compare lexically.
+// A label that is a prefix of the other sorts first.
+func compareLocal(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		numA, numB := isNumeric(a[i]), isNumeric(b[i])
+		var c int
+		switch {
+		case numA && numB:
+			c = compareNumeric(a[i], b[i])
+		case numA:
+			c = 1
+		case numB:
+			c = -1
+		default:
+			c = strings.Compare(a[i], b[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(a), len(b))
+}
@@ -0,0 +1,61 @@
+package scanner
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		ecosystem string
+		a, b      string
+		want      int
+	}{
+		// semver
+		{EcosystemNPM, "1.2.3", "1.2.3", 0},
+		{EcosystemNPM, "1.2.3", "1.10.0", -1},
+		{EcosystemNPM, "v2.0.0", "1.99.99", 1},
+		{EcosystemNPM, "1.0.0-alpha", "1.0.0", -1},
+		{EcosystemNPM, "1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{EcosystemNPM, "1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{EcosystemNPM, "1.0.0-rc.1", "1.0.0-rc.1.1", -1},
+		{EcosystemNPM, "1.0.0+build.1", "1.0.0", 0},
+		{EcosystemNPM, "1.2", "1.2.0", 0},
+
+		// PEP 440
+		{EcosystemPyPI, "1.0", "1.0.0", 0},
+		{EcosystemPyPI, "2.10", "2.9.3", 1},
+		{EcosystemPyPI, "1.0.dev1", "1.0a1", -1},
+		{EcosystemPyPI, "1.0a1", "1.0b1", -1},
+		{EcosystemPyPI, "1.0b2", "1.0rc1", -1},
+		{EcosystemPyPI, "1.0rc1", "1.0", -1},
+		{EcosystemPyPI, "1.0", "1.0.post1", -1},
+		{EcosystemPyPI, "1.0.post1.dev1", "1.0.post1", -1},
+		{EcosystemPyPI, "1.0-1", "1.0.post1", 0},
+		{EcosystemPyPI, "1.0-alpha.2", "1.0a2", 0},
+		{EcosystemPyPI, "1!0.1", "2.0", 1},
+		{EcosystemPyPI, "1.0+local.1", "1.0", 1},
+		{EcosystemPyPI, "1.0+abc", "1.0+1", -1},
+	}
+	for _, tt := range tests {
+		got, ok := compareVersions(tt.ecosystem, tt.a, tt.b)
+		if !ok {
+			t.Errorf("compareVersions(%s, %q, %q): not comparable", tt.ecosystem, tt.a, tt.b)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("compareVersions(%s, %q, %q) = %d, want %d", tt.ecosystem, tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCompareVersions_Invalid(t *testing.T) {
+	for _, tt := range []struct{ ecosystem, v string }{
+		{EcosystemNPM, "1.2.3.4"},
+		{EcosystemNPM, "latest"},
+		{EcosystemNPM, "1.0.0-"},
+		{EcosystemPyPI, "not-a-version"},
+		{EcosystemPyPI, "1.0-foo"},
+	} {
+		if _, ok := compareVersions(tt.ecosystem, tt.v, "1.0.0"); ok {
+			t.Errorf("compareVersions(%s, %q) accepted an invalid version", tt.ecosystem, tt.v)
+		}
+	}
+}
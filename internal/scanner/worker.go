@@ -8,8 +8,13 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/devs-group/skillbox/internal/skill"
+	"github.com/devs-group/skillbox/internal/skilltest"
+	"github.com/devs-group/skillbox/internal/version"
 )
 
 // ScanJob represents a skill queued for async scanning.
@@ -43,17 +48,28 @@ type ApprovalConfig struct {
 }
 
 // Worker processes pending skills asynchronously via a background goroutine.
+// With several server replicas only one runs Start at a time; Submit on the
+// others is a no-op and the running worker finds their jobs by polling.
 type Worker struct {
-	registry RegistryForWorker
-	scanner  Scanner
-	scanCh   chan ScanJob
-	logger   *slog.Logger
+	registry     RegistryForWorker
+	scanner      Scanner
+	scanCh       chan ScanJob
+	logger       *slog.Logger
+	pollInterval time.Duration
+	running      atomic.Bool
+
+	mu     sync.Mutex
+	queued map[ScanJob]struct{} // jobs in scanCh or being processed
 
 	// store operations are done via callbacks to avoid circular imports.
 	updateStatus    func(ctx context.Context, tenantID, name, version, status string, result json.RawMessage) error
 	listPending     func(ctx context.Context) ([]ScanJob, error)
 	getApprovalPolicy func(ctx context.Context, tenantID string) (string, error)
 	onAvailable     func(ctx context.Context, tenantID, name, version string) error
+	runTests        func(ctx context.Context, tenantID string, zipData []byte) (*skilltest.Report, error)
+	saveTestResults func(ctx context.Context, tenantID, name, version string, results json.RawMessage) error
+	saveSBOM        func(ctx context.Context, tenantID, name, version string, sbom *SBOM, deps []Dependency) error
+	getVulnThreshold func(ctx context.Context, tenantID string) (string, error)
 }
 
 // WorkerConfig holds the dependencies for creating a Worker.
@@ -62,10 +78,15 @@ type WorkerConfig struct {
 	Scanner         Scanner
 	Logger          *slog.Logger
 	BufferSize      int // channel buffer size (default 100)
+	PollInterval    time.Duration // how often Start re-lists pending jobs (0 = only at startup)
 	UpdateStatus    func(ctx context.Context, tenantID, name, version, status string, result json.RawMessage) error
 	ListPending     func(ctx context.Context) ([]ScanJob, error)
 	GetApprovalPolicy func(ctx context.Context, tenantID string) (string, error)
 	OnAvailable     func(ctx context.Context, tenantID, name, version string) error
+	RunTests        func(ctx context.Context, tenantID string, zipData []byte) (*skilltest.Report, error) // nil = skip skill tests
+	SaveTestResults func(ctx context.Context, tenantID, name, version string, results json.RawMessage) error
+	SaveSBOM        func(ctx context.Context, tenantID, name, version string, sbom *SBOM, deps []Dependency) error // nil = no SBOM
+	GetVulnThreshold func(ctx context.Context, tenantID string) (string, error) // nil = known vulnerabilities only FLAG
 }
 
 // NewWorker creates a scan worker with the given dependencies.
@@ -79,39 +100,79 @@ func NewWorker(cfg WorkerConfig) *Worker {
 		scanner:           cfg.Scanner,
 		scanCh:            make(chan ScanJob, bufSize),
 		logger:            cfg.Logger,
+		pollInterval:      cfg.PollInterval,
+		queued:            make(map[ScanJob]struct{}),
 		updateStatus:      cfg.UpdateStatus,
 		listPending:       cfg.ListPending,
 		getApprovalPolicy: cfg.GetApprovalPolicy,
 		onAvailable:       cfg.OnAvailable,
+		runTests:          cfg.RunTests,
+		saveTestResults:   cfg.SaveTestResults,
+		saveSBOM:          cfg.SaveSBOM,
+		getVulnThreshold:  cfg.GetVulnThreshold,
 	}
 }
 
 // Submit queues a scan job. Non-blocking — drops the job if the channel
-// is full (the startup recovery loop will pick it up).
+// is full (the startup recovery loop will pick it up). Jobs already queued
+// are ignored, and so is everything while the worker is not running on
+// this replica.
 func (w *Worker) Submit(job ScanJob) {
+	if !w.running.Load() {
+		w.logger.Debug("scan worker not running on this replica, job left for the leader",
+			"skill", job.Skill, "version", job.Version, "tenant", job.TenantID)
+		return
+	}
+
+	w.mu.Lock()
+	if _, ok := w.queued[job]; ok {
+		w.mu.Unlock()
+		return
+	}
+	w.queued[job] = struct{}{}
+	w.mu.Unlock()
+
 	select {
 	case w.scanCh <- job:
 		w.logger.Debug("scan job queued", "skill", job.Skill, "version", job.Version, "tenant", job.TenantID)
 	default:
+		w.mu.Lock()
+		delete(w.queued, job)
+		w.mu.Unlock()
 		w.logger.Warn("scan job channel full, job will be recovered on next poll",
 			"skill", job.Skill, "version", job.Version, "tenant", job.TenantID)
 	}
 }
 
 // Start launches the background worker goroutine. It first recovers any
-// pending jobs from the database, then processes jobs from the channel.
-// Blocks until ctx is cancelled.
+// pending jobs from the database, then processes jobs from the channel,
+// re-listing pending jobs every PollInterval. Blocks until ctx is cancelled.
 func (w *Worker) Start(ctx context.Context) {
+	w.running.Store(true)
+	defer w.running.Store(false)
+
 	// Startup recovery: re-queue skills stuck in pending/scanning.
 	w.recoverPendingJobs(ctx)
 
+	var poll <-chan time.Time
+	if w.pollInterval > 0 {
+		ticker := time.NewTicker(w.pollInterval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			w.logger.Info("scan worker shutting down")
 			return
+		case <-poll:
+			w.recoverPendingJobs(ctx)
 		case job := <-w.scanCh:
 			w.processJob(ctx, job)
+			w.mu.Lock()
+			delete(w.queued, job)
+			w.mu.Unlock()
 		}
 	}
 }
@@ -197,6 +258,9 @@ func (w *Worker) processJob(ctx context.Context, job ScanJob) {
 		parsedSkill = &skill.Skill{Name: job.Skill, Version: job.Version}
 	}
 
+	// Record what the version pulls in, whatever the scan decides.
+	w.generateSBOM(ctx, job, zr, logger)
+
 	// Run the scanner pipeline.
 	scanResult, err := w.scanner.Scan(ctx, zr, parsedSkill)
 	if err != nil {
@@ -207,8 +271,24 @@ func (w *Worker) processJob(ctx context.Context, job ScanJob) {
 		return
 	}
 
+	// Block known vulnerabilities at or above the tenant's threshold.
+	if w.getVulnThreshold != nil {
+		threshold, err := w.getVulnThreshold(ctx, job.TenantID)
+		if err != nil {
+			logger.Warn("failed to get vulnerability threshold, using default", "error", err)
+			threshold = DefaultVulnThreshold
+		}
+		ApplyVulnThreshold(scanResult, threshold)
+	}
+
 	resultJSON, _ := json.Marshal(scanResult)
 
+	// Run the skill's own test cases. A version that fails them is parked
+	// in test_failed whatever the approval policy.
+	if scanResult.Pass && !w.runSkillTests(ctx, job, zipBytes, resultJSON, logger) {
+		return
+	}
+
 	// Get the tenant's approval policy.
 	policy := "auto"
 	if w.getApprovalPolicy != nil {
@@ -260,6 +340,58 @@ func (w *Worker) evaluateAndTransition(ctx context.Context, job ScanJob, result
 	}
 }
 
+// generateSBOM extracts the version's dependencies and hands them to
+// SaveSBOM with the CycloneDX SBOM built from them. Failures are logged:
+// a missing SBOM does not hold up the scan.
+func (w *Worker) generateSBOM(ctx context.Context, job ScanJob, zr *zip.Reader, logger *slog.Logger) {
+	if w.saveSBOM == nil {
+		return
+	}
+	deps, err := ExtractDependencies(zr)
+	if err != nil {
+		logger.Error("failed to extract skill dependencies", "error", err)
+		return
+	}
+	sbom := BuildSBOM(job.Skill, job.Version, deps, version.Version)
+	if err := w.saveSBOM(ctx, job.TenantID, job.Skill, job.Version, sbom, deps); err != nil {
+		logger.Error("failed to store skill SBOM", "error", err)
+	}
+}
+
+// runSkillTests runs the test cases declared in the skill archive and
+// stores their results on the version. It returns false after moving the
+// version to test_failed, and true when the cases passed or there are none.
+// Cases that cannot be run at all count as failed.
+func (w *Worker) runSkillTests(ctx context.Context, job ScanJob, zipBytes []byte, scanJSON json.RawMessage, logger *slog.Logger) bool {
+	if w.runTests == nil {
+		return true
+	}
+	report, err := w.runTests(ctx, job.TenantID, zipBytes)
+	if err != nil {
+		report = &skilltest.Report{Error: err.Error()}
+	}
+	if report == nil {
+		return true
+	}
+
+	if w.saveTestResults != nil {
+		reportJSON, _ := json.Marshal(report)
+		if err := w.saveTestResults(ctx, job.TenantID, job.Skill, job.Version, reportJSON); err != nil {
+			logger.Error("failed to store skill test results", "error", err)
+		}
+	}
+	if report.OK() {
+		logger.Info("skill tests passed", "cases", report.Passed)
+		return true
+	}
+	reason := fmt.Sprintf("%d of %d test cases failed", report.Failed, report.Passed+report.Failed)
+	if report.Error != "" {
+		reason = "tests could not run: " + report.Error
+	}
+	w.failJob(ctx, job, "test_failed", scanJSON, reason)
+	return false
+}
+
 // failJob transitions a skill to a failure status and logs the reason.
 func (w *Worker) failJob(ctx context.Context, job ScanJob, status string, resultJSON json.RawMessage, reason string) {
 	w.logger.Warn("scan job failed",
@@ -0,0 +1,241 @@
+package scanner
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/devs-group/skillbox/internal/skill"
+	"github.com/devs-group/skillbox/internal/skilltest"
+)
+
+func TestWorkerSubmit_IgnoredWhenNotRunning(t *testing.T) {
+	w := NewWorker(WorkerConfig{Logger: slog.Default()})
+
+	w.Submit(ScanJob{TenantID: "t1", Skill: "s", Version: "1.0.0"})
+
+	if n := len(w.scanCh); n != 0 {
+		t.Errorf("queued %d jobs on a worker that is not running, want 0", n)
+	}
+}
+
+func TestWorkerSubmit_DeduplicatesQueuedJobs(t *testing.T) {
+	w := NewWorker(WorkerConfig{Logger: slog.Default()})
+	w.running.Store(true)
+
+	job := ScanJob{TenantID: "t1", Skill: "s", Version: "1.0.0"}
+	w.Submit(job)
+	w.Submit(job)
+	w.Submit(ScanJob{TenantID: "t1", Skill: "s", Version: "1.0.1"})
+
+	if n := len(w.scanCh); n != 2 {
+		t.Errorf("queued %d jobs, want 2", n)
+	}
+}
+
+// pendingRegistry serves one archive from the pending prefix and records
+// promotions.
+type pendingRegistry struct {
+	zip      []byte
+	promoted bool
+}
+
+func (r *pendingRegistry) DownloadPending(context.Context, string, string, string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(r.zip)), nil
+}
+
+func (r *pendingRegistry) Promote(context.Context, string, string, string) error {
+	r.promoted = true
+	return nil
+}
+
+func (r *pendingRegistry) Quarantine(context.Context, string, string, string) error { return nil }
+
+func skillZip(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("SKILL.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("---\nname: s\nversion: 1.0.0\ndescription: d\n---\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// runJobWithTests processes one job whose skill tests produce report and
+// err, returning the final status, whether the version was promoted and the
+// stored test results.
+func runJobWithTests(t *testing.T, report *skilltest.Report, err error) (string, bool, json.RawMessage) {
+	t.Helper()
+	reg := &pendingRegistry{zip: skillZip(t)}
+	var status string
+	var saved json.RawMessage
+	w := NewWorker(WorkerConfig{
+		Registry: reg,
+		Scanner:  &NoopScanner{},
+		Logger:   slog.Default(),
+		UpdateStatus: func(_ context.Context, _, _, _, s string, _ json.RawMessage) error {
+			status = s
+			return nil
+		},
+		GetApprovalPolicy: func(context.Context, string) (string, error) { return "none", nil },
+		RunTests: func(context.Context, string, []byte) (*skilltest.Report, error) {
+			return report, err
+		},
+		SaveTestResults: func(_ context.Context, _, _, _ string, results json.RawMessage) error {
+			saved = results
+			return nil
+		},
+	})
+	w.processJob(context.Background(), ScanJob{TenantID: "t1", Skill: "s", Version: "1.0.0"})
+	return status, reg.promoted, saved
+}
+
+func TestProcessJob_SkillTests(t *testing.T) {
+	passing := &skilltest.Report{Passed: 1, Cases: []skilltest.Result{{Name: "a", Passed: true, Status: "success"}}}
+	failing := &skilltest.Report{Passed: 1, Failed: 1, Cases: []skilltest.Result{
+		{Name: "a", Passed: true, Status: "success"},
+		{Name: "b", Status: "success", Error: "$.n: expected 1, got 2"},
+	}}
+
+	tests := []struct {
+		name         string
+		report       *skilltest.Report
+		err          error
+		wantStatus   string
+		wantPromoted bool
+		wantSaved    string // substring of the stored results; "" = nothing stored
+	}{
+		{"no cases", nil, nil, "available", true, ""},
+		{"passing", passing, nil, "available", true, `"passed":1`},
+		{"failing", failing, nil, "test_failed", false, `expected 1, got 2`},
+		{"cannot run", nil, errors.New("image validation: not allowed"), "test_failed", false, `"error":"image validation: not allowed"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, promoted, saved := runJobWithTests(t, tt.report, tt.err)
+			if status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", status, tt.wantStatus)
+			}
+			if promoted != tt.wantPromoted {
+				t.Errorf("promoted = %v, want %v", promoted, tt.wantPromoted)
+			}
+			if tt.wantSaved == "" {
+				if saved != nil {
+					t.Errorf("stored test results %s, want none", saved)
+				}
+			} else if !strings.Contains(string(saved), tt.wantSaved) {
+				t.Errorf("stored test results %s, want them to contain %s", saved, tt.wantSaved)
+			}
+		})
+	}
+}
+
+func TestProcessJob_SavesSBOM(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"SKILL.md":         "---\nname: s\nversion: 1.0.0\ndescription: d\n---\n",
+		"requirements.txt": "requests==2.31.0\n",
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var sbom *SBOM
+	var deps []Dependency
+	w := NewWorker(WorkerConfig{
+		Registry:     &pendingRegistry{zip: buf.Bytes()},
+		Scanner:      &NoopScanner{},
+		Logger:       slog.Default(),
+		UpdateStatus: func(context.Context, string, string, string, string, json.RawMessage) error { return nil },
+		SaveSBOM: func(_ context.Context, _, _, _ string, b *SBOM, d []Dependency) error {
+			sbom, deps = b, d
+			return nil
+		},
+	})
+	w.processJob(context.Background(), ScanJob{TenantID: "t1", Skill: "s", Version: "1.0.0"})
+
+	if sbom == nil {
+		t.Fatal("SBOM was not saved")
+	}
+	if len(sbom.Components) != 1 || sbom.Components[0].PURL != "pkg:pypi/requests@2.31.0" {
+		t.Errorf("components = %+v", sbom.Components)
+	}
+	if len(deps) != 1 || deps[0].FilePath != "requirements.txt" {
+		t.Errorf("dependencies = %+v", deps)
+	}
+}
+
+// resultScanner returns a copy of a fixed scan result.
+type resultScanner struct{ findings []Finding }
+
+func (s *resultScanner) Scan(context.Context, *zip.Reader, *skill.Skill) (*ScanResult, error) {
+	return &ScanResult{Pass: true, Tier: 2, Findings: append([]Finding(nil), s.findings...)}, nil
+}
+
+func TestProcessJob_VulnThreshold(t *testing.T) {
+	sc := &resultScanner{findings: []Finding{{
+		Stage: stageNameVulns, Severity: SeverityFlag, Category: categoryVulnerableDep,
+		AdvisoryID: "GHSA-p6mc-m468-83gw", AdvisorySeverity: VulnSeverityHigh,
+	}}}
+	tests := []struct {
+		threshold string
+		err       error
+		want      string
+	}{
+		{"high", nil, "quarantined"},
+		{"critical", nil, "review"},
+		{"none", nil, "review"},
+		{"", errors.New("db down"), "review"}, // default: critical
+	}
+	for _, tt := range tests {
+		reg := &pendingRegistry{zip: skillZip(t)}
+		var status string
+		var result ScanResult
+		w := NewWorker(WorkerConfig{
+			Registry: reg,
+			Scanner:  sc,
+			Logger:   slog.Default(),
+			UpdateStatus: func(_ context.Context, _, _, _, s string, r json.RawMessage) error {
+				status = s
+				if r != nil {
+					_ = json.Unmarshal(r, &result)
+				}
+				return nil
+			},
+			GetApprovalPolicy: func(context.Context, string) (string, error) { return "auto", nil },
+			GetVulnThreshold: func(context.Context, string) (string, error) {
+				return tt.threshold, tt.err
+			},
+		})
+		w.processJob(context.Background(), ScanJob{TenantID: "t1", Skill: "s", Version: "1.0.0"})
+
+		if status != tt.want {
+			t.Errorf("threshold %q: status = %q, want %q", tt.threshold, status, tt.want)
+		}
+		if blocked := result.Findings[0].Severity == SeverityBlock; blocked != (tt.want == "quarantined") || result.Pass == blocked {
+			t.Errorf("threshold %q: stored result = %+v", tt.threshold, result)
+		}
+	}
+}
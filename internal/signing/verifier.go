@@ -0,0 +1,73 @@
+package signing
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+// KeyStore is the tenant state a Verifier reads. *store.Store implements
+// it.
+type KeyStore interface {
+	// GetPublisherKey returns store.ErrNotFound for keys the tenant does
+	// not trust.
+	GetPublisherKey(ctx context.Context, tenantID, keyID string) (*store.PublisherKey, error)
+	RequireSignedSkills(ctx context.Context, tenantID string) (bool, error)
+}
+
+// Verifier applies a tenant's signing policy to skill archives.
+type Verifier struct {
+	keys KeyStore
+}
+
+// NewVerifier returns a Verifier reading trusted keys and the signing
+// policy from keys.
+func NewVerifier(keys KeyStore) *Verifier {
+	return &Verifier{keys: keys}
+}
+
+// VerifySkill checks the signature of a skill archive owned by tenantID.
+// It returns the signature, or nil for an unsigned archive the tenant
+// accepts. The error wraps ErrUnsigned, ErrUntrustedKey or
+// ErrInvalidSignature when the archive is rejected.
+func (v *Verifier) VerifySkill(ctx context.Context, tenantID string, zipData []byte) (*Signature, error) {
+	zr, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	if err != nil {
+		return nil, fmt.Errorf("open skill archive: %w", err)
+	}
+	sig, err := Extract(zr)
+	if err != nil {
+		return nil, err
+	}
+
+	if sig == nil {
+		required, err := v.keys.RequireSignedSkills(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if required {
+			return nil, ErrUnsigned
+		}
+		return nil, nil
+	}
+
+	key, err := v.keys.GetPublisherKey(ctx, tenantID, sig.KeyID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, fmt.Errorf("%w %s", ErrUntrustedKey, sig.KeyID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	pub, err := ParsePublicKey([]byte(key.PublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("publisher key %s: %w", key.KeyID, err)
+	}
+	if err := Verify(zr, sig, pub); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
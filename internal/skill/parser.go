@@ -14,39 +14,74 @@ var frontmatterVersionKeyRe = regexp.MustCompile(`^\s*version\s*:`)
 // no parseable frontmatter is returned unchanged.
 func SetFrontmatterVersion(content, v string) string {
 	lines := strings.Split(content, "\n")
-	open := -1
+	open, closeI := frontmatterBounds(lines)
+	if closeI < 0 {
+		return content
+	}
+	for i := open + 1; i < closeI; i++ {
+		if frontmatterVersionKeyRe.MatchString(lines[i]) {
+			lines[i] = "version: " + v
+			return strings.Join(lines, "\n")
+		}
+	}
+	out := append([]string{}, lines[:open+1]...)
+	out = append(out, "version: "+v)
+	out = append(out, lines[open+1:]...)
+	return strings.Join(out, "\n")
+}
+
+// SetFrontmatterField sets the top-level key in a SKILL.md frontmatter to
+// the YAML scalar value, appending it before the closing delimiter if
+// absent. An empty value removes the key. Content with no parseable
+// frontmatter is returned unchanged.
+func SetFrontmatterField(content, key, value string) string {
+	lines := strings.Split(content, "\n")
+	open, closeI := frontmatterBounds(lines)
+	if closeI < 0 {
+		return content
+	}
+	keyRe := regexp.MustCompile(`^` + regexp.QuoteMeta(key) + `\s*:`)
+	for i := open + 1; i < closeI; i++ {
+		if !keyRe.MatchString(lines[i]) {
+			continue
+		}
+		if value == "" {
+			return strings.Join(append(lines[:i:i], lines[i+1:]...), "\n")
+		}
+		lines[i] = key + ": " + value
+		return strings.Join(lines, "\n")
+	}
+	if value == "" {
+		return content
+	}
+	out := append([]string{}, lines[:closeI]...)
+	out = append(out, key+": "+value)
+	out = append(out, lines[closeI:]...)
+	return strings.Join(out, "\n")
+}
+
+// frontmatterBounds returns the line indexes of the opening and closing
+// "---" delimiters, or -1 for closeI if there is no frontmatter.
+func frontmatterBounds(lines []string) (open, closeI int) {
+	open = -1
 	for i, ln := range lines {
 		if strings.TrimSpace(ln) == "---" {
 			open = i
 			break
 		}
 		if strings.TrimSpace(ln) != "" {
-			return content
+			return -1, -1
 		}
 	}
 	if open < 0 {
-		return content
+		return -1, -1
 	}
-	closeI := -1
 	for i := open + 1; i < len(lines); i++ {
 		if strings.TrimSpace(lines[i]) == "---" {
-			closeI = i
-			break
+			return open, i
 		}
 	}
-	if closeI < 0 {
-		return content
-	}
-	for i := open + 1; i < closeI; i++ {
-		if frontmatterVersionKeyRe.MatchString(lines[i]) {
-			lines[i] = "version: " + v
-			return strings.Join(lines, "\n")
-		}
-	}
-	out := append([]string{}, lines[:open+1]...)
-	out = append(out, "version: "+v)
-	out = append(out, lines[open+1:]...)
-	return strings.Join(out, "\n")
+	return open, -1
 }
 
 // splitFrontmatter splits a SKILL.md file into the raw YAML frontmatter
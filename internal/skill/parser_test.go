@@ -179,6 +179,42 @@ Do something.
 	}
 }
 
+func TestParseInputSchema(t *testing.T) {
+	input := []byte(`---
+name: pdf-summary
+description: Summarize a PDF
+input_schema:
+  type: object
+  properties:
+    url:
+      type: string
+  required: [url]
+---
+`)
+
+	s, err := ParseSkillMD(input)
+	if err != nil {
+		t.Fatalf("ParseSkillMD: %v", err)
+	}
+	props, ok := s.InputSchema["properties"].(map[string]any)
+	if !ok || props["url"] == nil {
+		t.Errorf("InputSchema = %#v", s.InputSchema)
+	}
+	if got := s.ToolInputSchema(); got["type"] != "object" {
+		t.Errorf("ToolInputSchema() = %#v", got)
+	}
+
+	s.InputSchema = nil
+	if got := s.ToolInputSchema(); len(got) != 1 || got["type"] != "object" {
+		t.Errorf("default ToolInputSchema() = %#v", got)
+	}
+
+	_, err = ParseSkillMD([]byte("---\nname: x\ndescription: y\ninput_schema:\n  type: string\n---\n"))
+	if err == nil || !strings.Contains(err.Error(), "input_schema") {
+		t.Errorf("non-object input_schema: err = %v", err)
+	}
+}
+
 func TestDefaultImage(t *testing.T) {
 	tests := []struct {
 		lang      string
@@ -450,6 +486,49 @@ func TestSetFrontmatterVersion(t *testing.T) {
 	}
 }
 
+func TestSetFrontmatterField(t *testing.T) {
+	const content = "---\nname: tpl\nkind: template\n---\n\nbody\n"
+	tests := []struct {
+		name       string
+		key, value string
+		want       string
+	}{
+		{"rewrites existing key", "name", `"word-counter"`, "---\nname: \"word-counter\"\nkind: template\n---\n\nbody\n"},
+		{"appends missing key", "mode", `"cognitive"`, "---\nname: tpl\nkind: template\nmode: \"cognitive\"\n---\n\nbody\n"},
+		{"empty value removes key", "kind", "", "---\nname: tpl\n---\n\nbody\n"},
+		{"removing missing key is a no-op", "mode", "", content},
+		{"nested keys are not matched", "cpu", "1", "---\nname: tpl\nkind: template\ncpu: 1\n---\n\nbody\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SetFrontmatterField(content, tt.key, tt.value); got != tt.want {
+				t.Errorf("SetFrontmatterField()\n got: %q\nwant: %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := SetFrontmatterField("no frontmatter\n", "kind", "skill"); got != "no frontmatter\n" {
+		t.Errorf("content without frontmatter changed: %q", got)
+	}
+}
+
+func TestParseSkillMD_Kind(t *testing.T) {
+	sk, err := ParseSkillMD([]byte("---\nname: s\ndescription: d\n---\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sk.Kind != KindSkill {
+		t.Errorf("default kind = %q, want %q", sk.Kind, KindSkill)
+	}
+	sk, err = ParseSkillMD([]byte("---\nname: s\ndescription: d\nkind: template\n---\n"))
+	if err != nil || sk.Kind != KindTemplate {
+		t.Errorf("kind = %v, %v; want template", sk, err)
+	}
+	if _, err := ParseSkillMD([]byte("---\nname: s\ndescription: d\nkind: library\n---\n")); err == nil {
+		t.Error("expected error for unknown kind")
+	}
+}
+
 func TestCompareVersions(t *testing.T) {
 	tests := []struct {
 		a, b string
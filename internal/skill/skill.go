@@ -25,6 +25,13 @@ var validLangs = map[string]bool{
 	LangBash:   true,
 }
 
+// Package kinds. A template is a skill package that `skillbox init`
+// copies to start a new skill; it is stored like a skill but never runs.
+const (
+	KindSkill    = "skill"
+	KindTemplate = "template"
+)
+
 // nameRe validates skill names: alphanumeric, hyphens, underscores, and dots.
 // Must start with an alphanumeric character. No path separators or traversal.
 var nameRe = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]{0,127}$`)
@@ -41,14 +48,16 @@ type Resources struct {
 
 // frontmatter mirrors the YAML structure inside the SKILL.md header.
 type frontmatter struct {
-	Name        string    `yaml:"name"`
-	Version     string    `yaml:"version"`
-	Description string    `yaml:"description"`
-	Lang        string    `yaml:"lang"`
-	Image       string    `yaml:"image,omitempty"`
-	Timeout     string    `yaml:"timeout,omitempty"`
-	Resources   Resources `yaml:"resources,omitempty"`
-	Mode        string    `yaml:"mode,omitempty"`
+	Name        string         `yaml:"name"`
+	Version     string         `yaml:"version"`
+	Description string         `yaml:"description"`
+	Lang        string         `yaml:"lang"`
+	Image       string         `yaml:"image,omitempty"`
+	Timeout     string         `yaml:"timeout,omitempty"`
+	Resources   Resources      `yaml:"resources,omitempty"`
+	Mode        string         `yaml:"mode,omitempty"`
+	Kind        string         `yaml:"kind,omitempty"`
+	InputSchema map[string]any `yaml:"input_schema,omitempty"`
 }
 
 // Skill is the fully parsed and validated representation of a SKILL.md file.
@@ -60,8 +69,10 @@ type Skill struct {
 	Image        string // Docker image; empty means use DefaultImage()
 	Timeout      time.Duration
 	Resources    Resources
-	Instructions string // body text after the frontmatter
-	Mode         string // "executable" (default) or "cognitive"
+	Instructions string         // body text after the frontmatter
+	Mode         string         // "executable" (default) or "cognitive"
+	Kind         string         // KindSkill (default) or KindTemplate
+	InputSchema  map[string]any // JSON Schema for the input; nil if undeclared
 }
 
 // ParseSkillMD extracts the YAML frontmatter (between two "---" lines)
@@ -88,6 +99,11 @@ func ParseSkillMD(data []byte) (*Skill, error) {
 		mode = "executable"
 	}
 
+	kind := f.Kind
+	if kind == "" {
+		kind = KindSkill
+	}
+
 	s := &Skill{
 		Name:         f.Name,
 		Version:      version,
@@ -97,6 +113,8 @@ func ParseSkillMD(data []byte) (*Skill, error) {
 		Resources:    f.Resources,
 		Instructions: strings.TrimSpace(body),
 		Mode:         mode,
+		Kind:         kind,
+		InputSchema:  f.InputSchema,
 	}
 
 	// Parse timeout if provided.
@@ -137,6 +155,14 @@ func (s *Skill) Validate() error {
 	if s.Mode != "" && s.Mode != "executable" && s.Mode != "cognitive" {
 		errs = append(errs, fmt.Sprintf("mode %q is not supported (use executable or cognitive)", s.Mode))
 	}
+	if s.Kind != "" && s.Kind != KindSkill && s.Kind != KindTemplate {
+		errs = append(errs, fmt.Sprintf("kind %q is not supported (use skill or template)", s.Kind))
+	}
+	if s.InputSchema != nil {
+		if t, ok := s.InputSchema["type"]; ok && t != "object" {
+			errs = append(errs, fmt.Sprintf("input_schema type %v is not supported (skill input must be an object)", t))
+		}
+	}
 
 	if len(errs) > 0 {
 		return fmt.Errorf("invalid skill: %s", strings.Join(errs, "; "))
@@ -146,15 +172,35 @@ func (s *Skill) Validate() error {
 
 // SkillMetadata is the subset of Skill returned in list/get API responses.
 type SkillMetadata struct {
-	Name         string    `json:"name"`
-	Version      string    `json:"version"`
-	Description  string    `json:"description"`
-	Lang         string    `json:"lang"`
-	Image        string    `json:"image,omitempty"`
-	Instructions string    `json:"instructions,omitempty"`
-	Timeout      string    `json:"timeout,omitempty"`
-	Resources    Resources `json:"resources,omitempty"`
-	Mode         string    `json:"mode"`
+	Name         string         `json:"name"`
+	Version      string         `json:"version"`
+	Description  string         `json:"description"`
+	Lang         string         `json:"lang"`
+	Image        string         `json:"image,omitempty"`
+	Instructions string         `json:"instructions,omitempty"`
+	Timeout      string         `json:"timeout,omitempty"`
+	Resources    Resources      `json:"resources,omitempty"`
+	Mode         string         `json:"mode"`
+	Kind         string         `json:"kind,omitempty"`
+	InputSchema  map[string]any `json:"input_schema,omitempty"`
+}
+
+// ToolInputSchema returns the JSON Schema agents should use for the
+// skill's input: the declared input_schema, or any object if the skill
+// does not declare one.
+func (s *Skill) ToolInputSchema() map[string]any {
+	if s.InputSchema == nil {
+		return map[string]any{"type": "object"}
+	}
+	if _, ok := s.InputSchema["type"]; ok {
+		return s.InputSchema
+	}
+	schema := make(map[string]any, len(s.InputSchema)+1)
+	for k, v := range s.InputSchema {
+		schema[k] = v
+	}
+	schema["type"] = "object"
+	return schema
 }
 
 // SkillSummary is the compact representation returned by list endpoints.
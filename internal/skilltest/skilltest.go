@@ -0,0 +1,308 @@
+// Package skilltest loads the test cases a skill package declares under
+// tests/ and checks execution results against them.
+//
+// Each case is a directory below tests/:
+//
+//	tests/<case>/input.json            input payload (optional, default {})
+//	tests/<case>/files/...             input files, placed in SANDBOX_INPUT_DIR
+//	tests/<case>/expected.json         output must equal this JSON
+//	tests/<case>/expected.subset.json  output must contain this JSON
+//	tests/<case>/expected.schema.json  output must validate against this schema
+//
+// A case declares exactly one of the expected files. The same cases run
+// locally through `skillbox skill test` and in a sandbox when a new
+// version is published.
+package skilltest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// Dir is the directory of a skill package that holds its test cases.
+const Dir = "tests"
+
+// Expectation kinds.
+const (
+	ExpectExact  = "exact"
+	ExpectSubset = "subset"
+	ExpectSchema = "schema"
+)
+
+// expectFiles maps each expected-output file name to its kind.
+var expectFiles = map[string]string{
+	"expected.json":        ExpectExact,
+	"expected.subset.json": ExpectSubset,
+	"expected.schema.json": ExpectSchema,
+}
+
+// Case is one test case of a skill.
+type Case struct {
+	Name     string
+	Input    json.RawMessage
+	Files    map[string][]byte // path relative to the input directory -> content
+	Expect   string            // ExpectExact, ExpectSubset or ExpectSchema
+	Expected json.RawMessage
+}
+
+// Load reads the test cases under tests/ in fsys, sorted by name. fsys is
+// rooted at the skill package, e.g. os.DirFS(dir) or a *zip.Reader. A
+// package without a tests/ directory has no cases.
+func Load(fsys fs.FS) ([]Case, error) {
+	entries, err := fs.ReadDir(fsys, Dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", Dir, err)
+	}
+
+	var cases []Case
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		c, err := loadCase(fsys, e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("test case %q: %w", e.Name(), err)
+		}
+		cases = append(cases, c)
+	}
+	return cases, nil
+}
+
+func loadCase(fsys fs.FS, name string) (Case, error) {
+	dir := path.Join(Dir, name)
+	c := Case{Name: name, Input: json.RawMessage("{}")}
+
+	if data, err := fs.ReadFile(fsys, path.Join(dir, "input.json")); err == nil {
+		if !json.Valid(data) {
+			return c, fmt.Errorf("input.json is not valid JSON")
+		}
+		c.Input = data
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return c, err
+	}
+
+	for file, kind := range expectFiles {
+		data, err := fs.ReadFile(fsys, path.Join(dir, file))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return c, err
+		}
+		if c.Expect != "" {
+			return c, fmt.Errorf("declares more than one expected output file")
+		}
+		if !json.Valid(data) {
+			return c, fmt.Errorf("%s is not valid JSON", file)
+		}
+		if kind == ExpectSchema {
+			if _, err := resolveSchema(data); err != nil {
+				return c, fmt.Errorf("%s: %w", file, err)
+			}
+		}
+		c.Expect, c.Expected = kind, data
+	}
+	if c.Expect == "" {
+		return c, fmt.Errorf("no expected output; add one of expected.json, expected.subset.json or expected.schema.json")
+	}
+
+	filesDir := path.Join(dir, "files")
+	err := fs.WalkDir(fsys, filesDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		if c.Files == nil {
+			c.Files = make(map[string][]byte)
+		}
+		c.Files[strings.TrimPrefix(p, filesDir+"/")] = data
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return c, fmt.Errorf("read input files: %w", err)
+	}
+	return c, nil
+}
+
+func resolveSchema(data []byte) (*jsonschema.Resolved, error) {
+	var s jsonschema.Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid JSON Schema: %w", err)
+	}
+	return s.Resolve(nil)
+}
+
+// Check compares a skill's output against the case's expectation. It
+// returns nil when the output matches and otherwise an error describing
+// the first mismatch.
+func (c *Case) Check(output json.RawMessage) error {
+	if len(output) == 0 {
+		return fmt.Errorf("no output.json produced")
+	}
+	var got any
+	if err := json.Unmarshal(output, &got); err != nil {
+		return fmt.Errorf("output is not valid JSON: %w", err)
+	}
+
+	switch c.Expect {
+	case ExpectExact:
+		var want any
+		if err := json.Unmarshal(c.Expected, &want); err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(got, want) {
+			return fmt.Errorf("output %s does not equal expected %s", compact(output), compact(c.Expected))
+		}
+		return nil
+
+	case ExpectSubset:
+		var want any
+		if err := json.Unmarshal(c.Expected, &want); err != nil {
+			return err
+		}
+		return containsJSON("$", got, want)
+
+	case ExpectSchema:
+		rs, err := resolveSchema(c.Expected)
+		if err != nil {
+			return err
+		}
+		if err := rs.Validate(got); err != nil {
+			return fmt.Errorf("output does not match schema: %w", err)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown expectation %q", c.Expect)
+	}
+}
+
+// containsJSON reports whether got contains want: objects may have extra
+// keys, arrays must have the same length with each element containing the
+// expected one, and scalars must be equal. at is the JSON path of got, used
+// in the error.
+func containsJSON(at string, got, want any) error {
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected an object, got %s", at, describe(got))
+		}
+		keys := make([]string, 0, len(w))
+		for k := range w {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			v, ok := g[k]
+			if !ok {
+				return fmt.Errorf("%s: missing key %q", at, k)
+			}
+			if err := containsJSON(at+"."+k, v, w[k]); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case []any:
+		g, ok := got.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected an array, got %s", at, describe(got))
+		}
+		if len(g) != len(w) {
+			return fmt.Errorf("%s: expected %d elements, got %d", at, len(w), len(g))
+		}
+		for i := range w {
+			if err := containsJSON(fmt.Sprintf("%s[%d]", at, i), g[i], w[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		if !reflect.DeepEqual(got, want) {
+			return fmt.Errorf("%s: expected %s, got %s", at, describe(want), describe(got))
+		}
+		return nil
+	}
+}
+
+func describe(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func compact(data []byte) string {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return string(data)
+	}
+	return describe(v)
+}
+
+// Result is the outcome of one test case.
+type Result struct {
+	Name       string `json:"name"`
+	Passed     bool   `json:"passed"`
+	Status     string `json:"status"` // execution status: success, failed, timeout
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// Evaluate builds the Result of running c: a case passes when the execution
+// succeeded and its output meets the expectation. execErr is the
+// execution's error message, if any.
+func Evaluate(c *Case, status, execErr string, output json.RawMessage, durationMs int64) Result {
+	res := Result{Name: c.Name, Status: status, DurationMs: durationMs}
+	if status != "success" {
+		res.Error = "execution " + status
+		if execErr != "" {
+			res.Error += ": " + execErr
+		}
+		return res
+	}
+	if err := c.Check(output); err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Passed = true
+	return res
+}
+
+// Report collects the results of a skill's test cases.
+type Report struct {
+	Passed int      `json:"passed"`
+	Failed int      `json:"failed"`
+	Cases  []Result `json:"cases"`
+	Error  string   `json:"error,omitempty"` // set when the cases could not be run
+}
+
+// Add records a result.
+func (r *Report) Add(res Result) {
+	if res.Passed {
+		r.Passed++
+	} else {
+		r.Failed++
+	}
+	r.Cases = append(r.Cases, res)
+}
+
+// OK reports whether every case ran and passed.
+func (r *Report) OK() bool { return r.Failed == 0 && r.Error == "" }
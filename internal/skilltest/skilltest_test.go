@@ -0,0 +1,134 @@
+package skilltest
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"SKILL.md":                             {Data: []byte("---\nname: x\n---\n")},
+		"tests/b-subset/input.json":            {Data: []byte(`{"n": 2}`)},
+		"tests/b-subset/expected.subset.json":  {Data: []byte(`{"ok": true}`)},
+		"tests/b-subset/files/data.csv":        {Data: []byte("a,b\n")},
+		"tests/b-subset/files/nested/more.txt": {Data: []byte("more")},
+		"tests/a-exact/expected.json":          {Data: []byte(`{"n": 1}`)},
+		"tests/c-schema/expected.schema.json":  {Data: []byte(`{"type": "object", "required": ["n"]}`)},
+		"tests/README.md":                      {Data: []byte("ignored")},
+	}
+
+	cases, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(cases) != 3 {
+		t.Fatalf("got %d cases, want 3", len(cases))
+	}
+
+	if c := cases[0]; c.Name != "a-exact" || c.Expect != ExpectExact || string(c.Input) != "{}" {
+		t.Errorf("cases[0] = %+v, want a-exact with default input", c)
+	}
+	c := cases[1]
+	if c.Name != "b-subset" || c.Expect != ExpectSubset || string(c.Input) != `{"n": 2}` {
+		t.Errorf("cases[1] = %+v", c)
+	}
+	if string(c.Files["data.csv"]) != "a,b\n" || string(c.Files["nested/more.txt"]) != "more" {
+		t.Errorf("cases[1].Files = %v", c.Files)
+	}
+	if cases[2].Expect != ExpectSchema {
+		t.Errorf("cases[2].Expect = %q, want schema", cases[2].Expect)
+	}
+}
+
+func TestLoad_NoTests(t *testing.T) {
+	cases, err := Load(fstest.MapFS{"SKILL.md": {Data: []byte("x")}})
+	if err != nil || cases != nil {
+		t.Fatalf("Load = %v, %v; want no cases", cases, err)
+	}
+}
+
+func TestLoad_InvalidCases(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{"no expectation", fstest.MapFS{"tests/x/input.json": {Data: []byte(`{}`)}}, "no expected output"},
+		{"two expectations", fstest.MapFS{
+			"tests/x/expected.json":        {Data: []byte(`{}`)},
+			"tests/x/expected.subset.json": {Data: []byte(`{}`)},
+		}, "more than one"},
+		{"bad input", fstest.MapFS{
+			"tests/x/input.json":    {Data: []byte(`{`)},
+			"tests/x/expected.json": {Data: []byte(`{}`)},
+		}, "input.json is not valid JSON"},
+		{"bad schema", fstest.MapFS{
+			"tests/x/expected.schema.json": {Data: []byte(`{"type": 5}`)},
+		}, "expected.schema.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.fsys)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Load error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		expect   string
+		expected string
+		output   string
+		wantErr  string
+	}{
+		{"exact match ignores formatting", ExpectExact, `{"a": 1, "b": [1, 2]}`, `{"b":[1,2],"a":1}`, ""},
+		{"exact extra key", ExpectExact, `{"a": 1}`, `{"a": 1, "b": 2}`, "does not equal"},
+		{"subset extra keys", ExpectSubset, `{"a": {"b": 1}}`, `{"a": {"b": 1, "c": 2}, "d": 3}`, ""},
+		{"subset missing key", ExpectSubset, `{"a": {"x": 1}}`, `{"a": {"b": 1}}`, `$.a: missing key "x"`},
+		{"subset wrong value", ExpectSubset, `{"a": [1, 2]}`, `{"a": [1, 3]}`, "$.a[1]: expected 2, got 3"},
+		{"subset array length", ExpectSubset, `{"a": [1]}`, `{"a": [1, 2]}`, "expected 1 elements, got 2"},
+		{"subset type mismatch", ExpectSubset, `{"a": {}}`, `{"a": "s"}`, "expected an object"},
+		{"schema valid", ExpectSchema, `{"type": "object", "required": ["n"], "properties": {"n": {"type": "integer"}}}`, `{"n": 3}`, ""},
+		{"schema invalid", ExpectSchema, `{"type": "object", "required": ["n"]}`, `{"m": 3}`, "does not match schema"},
+		{"no output", ExpectExact, `{}`, ``, "no output.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Case{Name: "x", Expect: tt.expect, Expected: json.RawMessage(tt.expected)}
+			err := c.Check(json.RawMessage(tt.output))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Check: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Check error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEvaluateAndReport(t *testing.T) {
+	c := &Case{Name: "x", Expect: ExpectExact, Expected: json.RawMessage(`{"ok": true}`)}
+
+	var r Report
+	r.Add(Evaluate(c, "success", "", json.RawMessage(`{"ok": true}`), 5))
+	r.Add(Evaluate(c, "failed", "command exited with code 1", nil, 7))
+	r.Add(Evaluate(c, "success", "", json.RawMessage(`{"ok": false}`), 3))
+
+	if r.Passed != 1 || r.Failed != 2 || r.OK() {
+		t.Fatalf("report = %+v, want 1 passed and 2 failed", r)
+	}
+	if got := r.Cases[1].Error; got != "execution failed: command exited with code 1" {
+		t.Errorf("Cases[1].Error = %q", got)
+	}
+	if !strings.Contains(r.Cases[2].Error, "does not equal") {
+		t.Errorf("Cases[2].Error = %q", r.Cases[2].Error)
+	}
+}
@@ -103,28 +103,26 @@ func (s *Store) ListKeys(ctx context.Context, tenantID string) ([]APIKey, error)
 	return keys, nil
 }
 
-// RevokeKey soft-deletes an API key by setting its revoked_at timestamp.
-// It returns an error if the key does not exist or is already revoked.
-func (s *Store) RevokeKey(ctx context.Context, keyID string) error {
-	res, err := s.conn().ExecContext(ctx,
+// RevokeKey soft-deletes one of a tenant's API keys by setting its
+// revoked_at timestamp and returns the key's hash so callers can drop it
+// from caches. It returns ErrNotFound if the tenant has no such key or it
+// is already revoked.
+func (s *Store) RevokeKey(ctx context.Context, tenantID, keyID string) (string, error) {
+	var hash string
+	err := s.conn().QueryRowContext(ctx,
 		`UPDATE sandbox.api_keys
 		    SET revoked_at = now()
-		  WHERE id = $1 AND revoked_at IS NULL`,
-		keyID,
-	)
-	if err != nil {
-		return fmt.Errorf("revoke key: %w", err)
+		  WHERE id = $1 AND tenant_id = $2 AND revoked_at IS NULL
+		  RETURNING key_hash`,
+		keyID, tenantID,
+	).Scan(&hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrNotFound
 	}
-
-	n, err := res.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("revoke key rows affected: %w", err)
-	}
-	if n == 0 {
-		return fmt.Errorf("key %s not found or already revoked", keyID)
+		return "", fmt.Errorf("revoke key: %w", err)
 	}
-
-	return nil
+	return hash, nil
 }
 
 // GetAPIKeyByHash looks up an API key by its SHA-256 hex hash.
@@ -144,15 +144,17 @@ func (s *Store) UpdateApprovalStatus(ctx context.Context, id, status, reviewerID
 	return nil
 }
 
-// IsSkillApprovedForTenant checks if a skill has been approved for a tenant.
-func (s *Store) IsSkillApprovedForTenant(ctx context.Context, tenantID, skillName string) (bool, error) {
+// IsSkillApprovedForTenant checks if one version of a skill has been
+// approved for a tenant. Approvals are per version, so approving 1.0.0 does
+// not approve 1.1.0.
+func (s *Store) IsSkillApprovedForTenant(ctx context.Context, tenantID, skillName, version string) (bool, error) {
 	var exists bool
 	err := s.conn().QueryRowContext(ctx, `
 		SELECT EXISTS(
 			SELECT 1 FROM sandbox.tenant_approved_skills
-			WHERE tenant_id = $1 AND skill_name = $2
+			WHERE tenant_id = $1 AND skill_name = $2 AND skill_version = $3
 		)
-	`, tenantID, skillName).Scan(&exists)
+	`, tenantID, skillName, version).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("check skill approval: %w", err)
 	}
@@ -25,6 +25,7 @@ type Execution struct {
 	FilesList    []string        `json:"files_list,omitempty"`
 	DurationMs   int64           `json:"duration_ms"`
 	Error        *string         `json:"error"`
+	Partial      bool            `json:"partial,omitempty"` // output collected after a timeout
 	CreatedAt    time.Time       `json:"created_at"`
 	FinishedAt   *time.Time      `json:"finished_at,omitempty"`
 }
@@ -68,10 +69,11 @@ func (s *Store) UpdateExecution(ctx context.Context, e *Execution) error {
 		    files_list = $6,
 		    duration_ms = $7,
 		    error = $8,
-		    finished_at = $9
+		    finished_at = $9,
+		    partial = $10
 		WHERE id = $1 AND status = 'running'
 	`, e.ID, e.Status, nullableJSON(e.Output), e.Logs, e.FilesURL,
-		pq.Array(e.FilesList), e.DurationMs, e.Error, e.FinishedAt,
+		pq.Array(e.FilesList), e.DurationMs, e.Error, e.FinishedAt, e.Partial,
 	)
 	if err != nil {
 		return fmt.Errorf("update execution: %w", err)
@@ -96,13 +98,13 @@ func (s *Store) GetExecution(ctx context.Context, id, tenantID string) (*Executi
 	err := s.conn().QueryRowContext(ctx, `
 		SELECT id, skill_name, skill_version, tenant_id, status,
 		       input, output, logs, files_url, files_list,
-		       duration_ms, error, created_at, finished_at
+		       duration_ms, error, partial, created_at, finished_at
 		FROM sandbox.executions
 		WHERE id = $1 AND tenant_id = $2
 	`, id, tenantID).Scan(
 		&e.ID, &e.SkillName, &e.SkillVersion, &e.TenantID, &e.Status,
 		&e.Input, &e.Output, &e.Logs, &e.FilesURL, pq.Array(&filesList),
-		&e.DurationMs, &e.Error, &e.CreatedAt, &e.FinishedAt,
+		&e.DurationMs, &e.Error, &e.Partial, &e.CreatedAt, &e.FinishedAt,
 	)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, ErrNotFound
@@ -119,28 +121,40 @@ func (s *Store) GetExecution(ctx context.Context, id, tenantID string) (*Executi
 	return e, nil
 }
 
-// ListExecutions returns executions for a tenant ordered by creation time
-// (newest first), with pagination via limit and offset.
-func (s *Store) ListExecutions(ctx context.Context, tenantID string, limit, offset int) ([]Execution, error) {
-	if limit <= 0 {
-		limit = 20
+// ExecutionFilter selects executions for ListExecutions. Empty fields
+// match everything.
+type ExecutionFilter struct {
+	TenantID string
+	Skill    string
+	Status   string
+	Limit    int
+	Offset   int
+}
+
+// ListExecutions returns a tenant's executions matching filter, ordered by
+// creation time (newest first), with pagination via limit and offset.
+func (s *Store) ListExecutions(ctx context.Context, filter ExecutionFilter) ([]Execution, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 20
 	}
-	if limit > 100 {
-		limit = 100
+	if filter.Limit > 100 {
+		filter.Limit = 100
 	}
-	if offset < 0 {
-		offset = 0
+	if filter.Offset < 0 {
+		filter.Offset = 0
 	}
 
 	rows, err := s.conn().QueryContext(ctx, `
 		SELECT id, skill_name, skill_version, tenant_id, status,
 		       input, output, logs, files_url, files_list,
-		       duration_ms, error, created_at, finished_at
+		       duration_ms, error, partial, created_at, finished_at
 		FROM sandbox.executions
 		WHERE tenant_id = $1
+		  AND ($2 = '' OR skill_name = $2)
+		  AND ($3 = '' OR status = $3)
 		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
-	`, tenantID, limit, offset)
+		LIMIT $4 OFFSET $5
+	`, filter.TenantID, filter.Skill, filter.Status, filter.Limit, filter.Offset)
 	if err != nil {
 		return nil, fmt.Errorf("list executions: %w", err)
 	}
@@ -153,7 +167,7 @@ func (s *Store) ListExecutions(ctx context.Context, tenantID string, limit, offs
 		if err := rows.Scan(
 			&e.ID, &e.SkillName, &e.SkillVersion, &e.TenantID, &e.Status,
 			&e.Input, &e.Output, &e.Logs, &e.FilesURL, pq.Array(&filesList),
-			&e.DurationMs, &e.Error, &e.CreatedAt, &e.FinishedAt,
+			&e.DurationMs, &e.Error, &e.Partial, &e.CreatedAt, &e.FinishedAt,
 		); err != nil {
 			return nil, fmt.Errorf("scan execution row: %w", err)
 		}
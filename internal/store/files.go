@@ -111,6 +111,12 @@ func (s *Store) ListFiles(ctx context.Context, filter FileFilter) ([]*File, erro
 	}
 	defer rows.Close() //nolint:errcheck
 
+	return scanFiles(rows)
+}
+
+// scanFiles reads the file records of a query selecting the columns of
+// ListFiles.
+func scanFiles(rows *sql.Rows) ([]*File, error) {
 	var files []*File
 	for rows.Next() {
 		f := &File{}
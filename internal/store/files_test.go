@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -581,3 +582,38 @@ func TestListFileVersions_DBError(t *testing.T) {
 		t.Errorf("unfulfilled expectations: %v", err)
 	}
 }
+
+// --- ListAllSessionFiles ---
+
+func TestListAllSessionFiles_ReturnsEveryFile(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	s := &Store{db: db}
+
+	// More rows than the ListFiles page limit, with no LIMIT argument.
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows(fileColumns)
+	for i := 0; i < 250; i++ {
+		rows.AddRow(fmt.Sprintf("file-%d", i), "tenant-1", "sess-1", nil, fmt.Sprintf("f%d.txt", i),
+			"text/plain", int64(1), "key", 1, nil, now, now)
+	}
+	mock.ExpectQuery("SELECT id, tenant_id, session_id, execution_id, name, content_type").
+		WithArgs("tenant-1", "sess-1").
+		WillReturnRows(rows)
+
+	files, err := s.ListAllSessionFiles(context.Background(), "tenant-1", "sess-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 250 {
+		t.Errorf("got %d files, want 250", len(files))
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
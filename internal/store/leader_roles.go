@@ -0,0 +1,74 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// LeaderRole represents a row in the sandbox.leader_roles table: the
+// replica holding a singleton background role.
+type LeaderRole struct {
+	Role       string    `json:"role"`
+	Holder     string    `json:"holder"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	RenewedAt  time.Time `json:"renewed_at"`
+}
+
+// RecordLeader marks holder as the current holder of role. acquired_at is
+// only reset when the holder changes.
+func (s *Store) RecordLeader(ctx context.Context, role, holder string) error {
+	_, err := s.conn().ExecContext(ctx, `
+		INSERT INTO sandbox.leader_roles (role, holder)
+		VALUES ($1, $2)
+		ON CONFLICT (role) DO UPDATE
+		SET acquired_at = CASE WHEN sandbox.leader_roles.holder = EXCLUDED.holder
+		                       THEN sandbox.leader_roles.acquired_at ELSE NOW() END,
+		    holder = EXCLUDED.holder,
+		    renewed_at = NOW()
+	`, role, holder)
+	if err != nil {
+		return fmt.Errorf("record leader: %w", err)
+	}
+	return nil
+}
+
+// RenewLeader bumps renewed_at for role if holder still holds it.
+func (s *Store) RenewLeader(ctx context.Context, role, holder string) error {
+	_, err := s.conn().ExecContext(ctx, `
+		UPDATE sandbox.leader_roles
+		SET renewed_at = NOW()
+		WHERE role = $1 AND holder = $2
+	`, role, holder)
+	if err != nil {
+		return fmt.Errorf("renew leader: %w", err)
+	}
+	return nil
+}
+
+// ListLeaderRoles returns every recorded role and its holder, ordered by
+// role name.
+func (s *Store) ListLeaderRoles(ctx context.Context) ([]LeaderRole, error) {
+	rows, err := s.conn().QueryContext(ctx, `
+		SELECT role, holder, acquired_at, renewed_at
+		FROM sandbox.leader_roles
+		ORDER BY role
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list leader roles: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var out []LeaderRole
+	for rows.Next() {
+		var r LeaderRole
+		if err := rows.Scan(&r.Role, &r.Holder, &r.AcquiredAt, &r.RenewedAt); err != nil {
+			return nil, fmt.Errorf("list leader roles scan: %w", err)
+		}
+		out = append(out, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list leader roles rows: %w", err)
+	}
+	return out, nil
+}
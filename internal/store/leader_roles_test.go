@@ -0,0 +1,41 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestListLeaderRoles(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	s := &Store{db: db}
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT role, holder, acquired_at, renewed_at").
+		WillReturnRows(sqlmock.NewRows([]string{"role", "holder", "acquired_at", "renewed_at"}).
+			AddRow("sandbox-cleanup", "api-0", now, now.Add(time.Minute)).
+			AddRow("scan-worker", "api-1", now, now))
+
+	roles, err := s.ListLeaderRoles(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(roles) != 2 || roles[0].Holder != "api-0" || roles[1].Role != "scan-worker" {
+		t.Errorf("roles = %+v", roles)
+	}
+	if !roles[0].RenewedAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("RenewedAt = %v, want %v", roles[0].RenewedAt, now.Add(time.Minute))
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
@@ -2,6 +2,8 @@ package store
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 )
 
@@ -92,16 +94,18 @@ func (s *Store) ListMarketplaceSkills(ctx context.Context, query string, limit,
 	return records, totalCount, nil
 }
 
-// GetMarketplaceSkill retrieves a single public skill by name (latest version).
+// GetMarketplaceSkill retrieves a single public skill by name (latest
+// available version). Versions under review, quarantined, declined or that
+// failed their tests are never served from the marketplace.
 func (s *Store) GetMarketplaceSkill(ctx context.Context, name string) (*SkillRecord, error) {
 	rec := &SkillRecord{}
 	err := s.conn().QueryRowContext(ctx, `
 		SELECT tenant_id, name, version, description, lang, stars, uploaded_at
 		FROM sandbox.skills
-		WHERE tenant_id = 'public' AND name = $1
+		WHERE tenant_id = 'public' AND name = $1 AND status = $2
 		ORDER BY uploaded_at DESC
 		LIMIT 1
-	`, name).Scan(&rec.TenantID, &rec.Name, &rec.Version, &rec.Description, &rec.Lang, &rec.Stars, &rec.UploadedAt)
+	`, name, SkillStatusAvailable).Scan(&rec.TenantID, &rec.Name, &rec.Version, &rec.Description, &rec.Lang, &rec.Stars, &rec.UploadedAt)
 	if err != nil {
 		if err.Error() == "sql: no rows in result set" {
 			return nil, ErrNotFound
@@ -110,3 +114,21 @@ func (s *Store) GetMarketplaceSkill(ctx context.Context, name string) (*SkillRec
 	}
 	return rec, nil
 }
+
+// GetMarketplaceSkillVersion retrieves one available version of a public
+// skill.
+func (s *Store) GetMarketplaceSkillVersion(ctx context.Context, name, version string) (*SkillRecord, error) {
+	rec := &SkillRecord{}
+	err := s.conn().QueryRowContext(ctx, `
+		SELECT tenant_id, name, version, description, lang, stars, uploaded_at
+		FROM sandbox.skills
+		WHERE tenant_id = 'public' AND name = $1 AND version = $2 AND status = $3
+	`, name, version, SkillStatusAvailable).Scan(&rec.TenantID, &rec.Name, &rec.Version, &rec.Description, &rec.Lang, &rec.Stars, &rec.UploadedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get marketplace skill version: %w", err)
+	}
+	return rec, nil
+}
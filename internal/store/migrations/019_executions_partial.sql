@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE sandbox.executions ADD COLUMN partial BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE sandbox.executions DROP COLUMN partial;
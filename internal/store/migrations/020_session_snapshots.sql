@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS sandbox.session_snapshots (
+    id         UUID PRIMARY KEY,
+    tenant_id  TEXT NOT NULL,
+    session_id UUID NOT NULL REFERENCES sandbox.sessions(id) ON DELETE CASCADE,
+    name       TEXT NOT NULL,
+    version    INTEGER NOT NULL,
+    s3_key     TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    file_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(session_id, name, version)
+);
+
+CREATE INDEX idx_session_snapshots_tenant ON sandbox.session_snapshots(tenant_id, created_at DESC);
+CREATE INDEX idx_session_snapshots_session ON sandbox.session_snapshots(session_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS sandbox.session_snapshots;
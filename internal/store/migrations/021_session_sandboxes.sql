@@ -0,0 +1,22 @@
+-- +goose Up
+-- Which sandbox backs each session and which replica holds its lease.
+-- Any replica may attach to the sandbox; only the lease holder syncs and
+-- destroys it.
+CREATE TABLE IF NOT EXISTS sandbox.session_sandboxes (
+    tenant_id        TEXT NOT NULL,
+    external_id      TEXT NOT NULL,
+    session_id       UUID NOT NULL REFERENCES sandbox.sessions(id) ON DELETE CASCADE,
+    sandbox_id       TEXT NOT NULL,
+    execd_url        TEXT NOT NULL,
+    image            TEXT NOT NULL DEFAULT '',
+    owner            TEXT NOT NULL,
+    lease_expires_at TIMESTAMPTZ NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, external_id)
+);
+
+CREATE INDEX idx_session_sandboxes_lease ON sandbox.session_sandboxes(lease_expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS sandbox.session_sandboxes;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- Which replica currently holds each singleton background role. The role
+-- itself is guarded by a Postgres advisory lock; this table only records
+-- the holder so operators can see it.
+CREATE TABLE IF NOT EXISTS sandbox.leader_roles (
+    role        TEXT PRIMARY KEY,
+    holder      TEXT NOT NULL,
+    acquired_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    renewed_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS sandbox.leader_roles;
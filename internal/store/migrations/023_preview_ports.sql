@@ -0,0 +1,19 @@
+-- +goose Up
+-- Sandbox ports exposed through the preview proxy. Each row carries the
+-- hash of the access token embedded in its preview URL. Rows are removed
+-- together with the session's sandbox record, so previews stop working as
+-- soon as the sandbox is destroyed or reaped.
+CREATE TABLE IF NOT EXISTS sandbox.preview_ports (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id   TEXT NOT NULL,
+    external_id TEXT NOT NULL,
+    port        INTEGER NOT NULL CHECK (port BETWEEN 1 AND 65535),
+    token_hash  TEXT NOT NULL UNIQUE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant_id, external_id, port),
+    FOREIGN KEY (tenant_id, external_id)
+        REFERENCES sandbox.session_sandboxes(tenant_id, external_id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS sandbox.preview_ports;
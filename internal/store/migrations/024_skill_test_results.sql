@@ -0,0 +1,18 @@
+-- +goose Up
+-- Skill versions that declare test cases run them after the security scan.
+-- A version whose tests fail is parked in 'test_failed'; the results of the
+-- last run are kept on the version either way.
+ALTER TABLE sandbox.skills ADD COLUMN test_results JSONB;
+
+ALTER TABLE sandbox.skills DROP CONSTRAINT skills_status_check;
+ALTER TABLE sandbox.skills
+    ADD CONSTRAINT skills_status_check
+    CHECK (status IN ('pending', 'scanning', 'review', 'available', 'declined', 'quarantined', 'test_failed'));
+
+-- +goose Down
+UPDATE sandbox.skills SET status = 'declined' WHERE status = 'test_failed';
+ALTER TABLE sandbox.skills DROP CONSTRAINT skills_status_check;
+ALTER TABLE sandbox.skills
+    ADD CONSTRAINT skills_status_check
+    CHECK (status IN ('pending', 'scanning', 'review', 'available', 'declined', 'quarantined'));
+ALTER TABLE sandbox.skills DROP COLUMN test_results;
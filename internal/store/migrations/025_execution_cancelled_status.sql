@@ -0,0 +1,15 @@
+-- +goose Up
+-- Add 'cancelled' to allowed execution status values (POST /v1/executions/:id/cancel).
+ALTER TABLE sandbox.executions
+    DROP CONSTRAINT IF EXISTS executions_status_check;
+ALTER TABLE sandbox.executions
+    ADD CONSTRAINT executions_status_check
+    CHECK (status IN ('running', 'success', 'failed', 'timeout', 'mounted', 'cancelled'));
+
+-- +goose Down
+UPDATE sandbox.executions SET status = 'failed' WHERE status = 'cancelled';
+ALTER TABLE sandbox.executions
+    DROP CONSTRAINT IF EXISTS executions_status_check;
+ALTER TABLE sandbox.executions
+    ADD CONSTRAINT executions_status_check
+    CHECK (status IN ('running', 'success', 'failed', 'timeout', 'mounted'));
@@ -0,0 +1,22 @@
+-- +goose Up
+-- Publisher keys a tenant trusts to sign skills, the signature each
+-- signed version was uploaded with, and a per-tenant policy that rejects
+-- unsigned skills.
+CREATE TABLE sandbox.publisher_keys (
+    tenant_id  TEXT NOT NULL,
+    key_id     TEXT NOT NULL,
+    name       TEXT NOT NULL,
+    public_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, key_id)
+);
+
+ALTER TABLE sandbox.skills ADD COLUMN signature JSONB;
+
+ALTER TABLE sandbox.scanner_config
+    ADD COLUMN require_signed BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE sandbox.scanner_config DROP COLUMN IF EXISTS require_signed;
+ALTER TABLE sandbox.skills DROP COLUMN IF EXISTS signature;
+DROP TABLE IF EXISTS sandbox.publisher_keys;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Third-party packages each skill version declares, locks or vendors, as
+-- found by the scan worker when it generates the version's SBOM. Indexed
+-- by package so a tenant can find every skill that depends on one.
+CREATE TABLE sandbox.skill_dependencies (
+    tenant_id       TEXT NOT NULL,
+    name            TEXT NOT NULL,
+    version         TEXT NOT NULL,
+    ecosystem       TEXT NOT NULL,
+    package         TEXT NOT NULL,
+    package_version TEXT NOT NULL DEFAULT '',
+    specifier       TEXT NOT NULL DEFAULT '',
+    file_path       TEXT NOT NULL,
+    PRIMARY KEY (tenant_id, name, version, ecosystem, package, package_version, file_path),
+    FOREIGN KEY (tenant_id, name, version)
+        REFERENCES sandbox.skills (tenant_id, name, version) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_skill_dependencies_package
+    ON sandbox.skill_dependencies (tenant_id, package, ecosystem);
+
+-- +goose Down
+DROP TABLE IF EXISTS sandbox.skill_dependencies;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Lowest advisory severity at which a known vulnerability in a skill's
+-- dependencies blocks the skill rather than flagging it for review.
+ALTER TABLE sandbox.scanner_config
+    ADD COLUMN vuln_block_severity TEXT NOT NULL DEFAULT 'critical';
+
+-- +goose Down
+ALTER TABLE sandbox.scanner_config DROP COLUMN IF EXISTS vuln_block_severity;
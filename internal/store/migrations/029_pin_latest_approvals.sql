@@ -0,0 +1,21 @@
+-- +goose Up
+-- Approvals are checked per version. Skills approved as "latest" before
+-- that change are pinned to the newest available marketplace version, the
+-- one "latest" meant when they are next downloaded.
+INSERT INTO sandbox.tenant_approved_skills
+    (tenant_id, skill_name, skill_version, approved_by, approved_at, approval_scope, approved_for_user)
+SELECT a.tenant_id, a.skill_name, v.version, a.approved_by, a.approved_at, a.approval_scope, a.approved_for_user
+FROM sandbox.tenant_approved_skills a
+CROSS JOIN LATERAL (
+    SELECT version FROM sandbox.skills
+    WHERE tenant_id = 'public' AND name = a.skill_name AND status = 'available'
+    ORDER BY uploaded_at DESC
+    LIMIT 1
+) v
+WHERE a.skill_version = 'latest'
+ON CONFLICT (tenant_id, skill_name, skill_version) DO NOTHING;
+
+DELETE FROM sandbox.tenant_approved_skills WHERE skill_version = 'latest';
+
+-- +goose Down
+-- Pinned rows cannot be told apart from regular approvals, so they stay.
@@ -0,0 +1,116 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PreviewPort represents a row in the sandbox.preview_ports table: a
+// sandbox port exposed through the preview proxy.
+type PreviewPort struct {
+	ID         string    `json:"id"`
+	TenantID   string    `json:"tenant_id"`
+	ExternalID string    `json:"external_id"`
+	Port       int       `json:"port"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// PreviewTarget is what the preview proxy needs to route a request: the
+// registered port and the sandbox currently backing its session.
+type PreviewTarget struct {
+	PreviewPort
+	SandboxID string
+}
+
+// UpsertPreviewPort registers port for a session under tokenHash. If the
+// port is already registered its token is replaced, invalidating the old
+// preview URL. It returns ErrNotFound if the session has no sandbox.
+func (s *Store) UpsertPreviewPort(ctx context.Context, tenantID, externalID string, port int, tokenHash string) (*PreviewPort, error) {
+	p := &PreviewPort{}
+	err := s.conn().QueryRowContext(ctx, `
+		INSERT INTO sandbox.preview_ports (tenant_id, external_id, port, token_hash)
+		SELECT $1, $2, $3, $4
+		WHERE EXISTS (
+		    SELECT 1 FROM sandbox.session_sandboxes WHERE tenant_id = $1 AND external_id = $2
+		)
+		ON CONFLICT (tenant_id, external_id, port) DO UPDATE
+		SET token_hash = EXCLUDED.token_hash, created_at = NOW()
+		RETURNING id, tenant_id, external_id, port, created_at
+	`, tenantID, externalID, port, tokenHash).Scan(&p.ID, &p.TenantID, &p.ExternalID, &p.Port, &p.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("upsert preview port: %w", err)
+	}
+	return p, nil
+}
+
+// ListPreviewPorts returns the ports registered for a session, lowest
+// first.
+func (s *Store) ListPreviewPorts(ctx context.Context, tenantID, externalID string) ([]*PreviewPort, error) {
+	rows, err := s.conn().QueryContext(ctx, `
+		SELECT id, tenant_id, external_id, port, created_at
+		FROM sandbox.preview_ports
+		WHERE tenant_id = $1 AND external_id = $2
+		ORDER BY port
+	`, tenantID, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("list preview ports: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var out []*PreviewPort
+	for rows.Next() {
+		p := &PreviewPort{}
+		if err := rows.Scan(&p.ID, &p.TenantID, &p.ExternalID, &p.Port, &p.CreatedAt); err != nil {
+			return nil, fmt.Errorf("list preview ports scan: %w", err)
+		}
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list preview ports rows: %w", err)
+	}
+	return out, nil
+}
+
+// DeletePreviewPort unregisters a port and returns the hash of its token.
+// It returns ErrNotFound if the port is not registered.
+func (s *Store) DeletePreviewPort(ctx context.Context, tenantID, externalID string, port int) (string, error) {
+	var tokenHash string
+	err := s.conn().QueryRowContext(ctx, `
+		DELETE FROM sandbox.preview_ports
+		WHERE tenant_id = $1 AND external_id = $2 AND port = $3
+		RETURNING token_hash
+	`, tenantID, externalID, port).Scan(&tokenHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("delete preview port: %w", err)
+	}
+	return tokenHash, nil
+}
+
+// GetPreviewTarget resolves a preview token hash to its port and the
+// sandbox currently backing the session.
+func (s *Store) GetPreviewTarget(ctx context.Context, tokenHash string) (*PreviewTarget, error) {
+	t := &PreviewTarget{}
+	err := s.conn().QueryRowContext(ctx, `
+		SELECT p.id, p.tenant_id, p.external_id, p.port, p.created_at, sb.sandbox_id
+		FROM sandbox.preview_ports p
+		JOIN sandbox.session_sandboxes sb
+		  ON sb.tenant_id = p.tenant_id AND sb.external_id = p.external_id
+		WHERE p.token_hash = $1
+	`, tokenHash).Scan(&t.ID, &t.TenantID, &t.ExternalID, &t.Port, &t.CreatedAt, &t.SandboxID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get preview target: %w", err)
+	}
+	return t, nil
+}
@@ -0,0 +1,99 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// --- UpsertPreviewPort ---
+
+func TestUpsertPreviewPort_WithoutSandboxReturnsNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	s := &Store{db: db}
+
+	mock.ExpectQuery("INSERT INTO sandbox.preview_ports").
+		WithArgs("tenant-1", "ext-1", 3000, "hash-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "external_id", "port", "created_at"}))
+
+	_, err = s.UpsertPreviewPort(context.Background(), "tenant-1", "ext-1", 3000, "hash-1")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+// --- DeletePreviewPort ---
+
+func TestDeletePreviewPort_ReturnsTokenHash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	s := &Store{db: db}
+
+	mock.ExpectQuery("DELETE FROM sandbox.preview_ports").
+		WithArgs("tenant-1", "ext-1", 3000).
+		WillReturnRows(sqlmock.NewRows([]string{"token_hash"}).AddRow("hash-1"))
+	mock.ExpectQuery("DELETE FROM sandbox.preview_ports").
+		WithArgs("tenant-1", "ext-1", 3000).
+		WillReturnRows(sqlmock.NewRows([]string{"token_hash"}))
+
+	hash, err := s.DeletePreviewPort(context.Background(), "tenant-1", "ext-1", 3000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hash != "hash-1" {
+		t.Errorf("hash = %q, want hash-1", hash)
+	}
+
+	if _, err := s.DeletePreviewPort(context.Background(), "tenant-1", "ext-1", 3000); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second delete err = %v, want ErrNotFound", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+// --- GetPreviewTarget ---
+
+func TestGetPreviewTarget_JoinsCurrentSandbox(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	s := &Store{db: db}
+
+	mock.ExpectQuery("FROM sandbox.preview_ports p\\s+JOIN sandbox.session_sandboxes sb").
+		WithArgs("hash-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "external_id", "port", "created_at", "sandbox_id"}).
+			AddRow("pp-1", "tenant-1", "ext-1", 3000, time.Now(), "sb-2"))
+
+	target, err := s.GetPreviewTarget(context.Background(), "hash-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target.SandboxID != "sb-2" || target.Port != 3000 || target.ExternalID != "ext-1" {
+		t.Errorf("target = %+v", target)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
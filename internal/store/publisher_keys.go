@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrPublisherKeyExists is returned when a tenant already trusts a key.
+var ErrPublisherKeyExists = errors.New("publisher key already exists")
+
+// PublisherKey is a public key a tenant trusts to sign skills.
+type PublisherKey struct {
+	TenantID  string    `json:"tenant_id"`
+	KeyID     string    `json:"key_id"`
+	Name      string    `json:"name"`
+	PublicKey string    `json:"public_key"` // PEM-encoded PKIX ed25519 key
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AddPublisherKey registers a trusted publisher key for a tenant. It
+// returns ErrPublisherKeyExists if the tenant already trusts the key.
+func (s *Store) AddPublisherKey(ctx context.Context, k *PublisherKey) error {
+	err := s.conn().QueryRowContext(ctx, `
+		INSERT INTO sandbox.publisher_keys (tenant_id, key_id, name, public_key)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+		RETURNING created_at
+	`, k.TenantID, k.KeyID, k.Name, k.PublicKey).Scan(&k.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrPublisherKeyExists
+	}
+	if err != nil {
+		return fmt.Errorf("add publisher key: %w", err)
+	}
+	return nil
+}
+
+// ListPublisherKeys returns the publisher keys a tenant trusts, oldest
+// first.
+func (s *Store) ListPublisherKeys(ctx context.Context, tenantID string) ([]PublisherKey, error) {
+	rows, err := s.conn().QueryContext(ctx, `
+		SELECT tenant_id, key_id, name, public_key, created_at
+		FROM sandbox.publisher_keys
+		WHERE tenant_id = $1
+		ORDER BY created_at, key_id
+	`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list publisher keys: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	keys := []PublisherKey{}
+	for rows.Next() {
+		var k PublisherKey
+		if err := rows.Scan(&k.TenantID, &k.KeyID, &k.Name, &k.PublicKey, &k.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan publisher key: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate publisher keys: %w", err)
+	}
+	return keys, nil
+}
+
+// GetPublisherKey returns one of a tenant's trusted publisher keys. It
+// returns ErrNotFound if the tenant does not trust the key.
+func (s *Store) GetPublisherKey(ctx context.Context, tenantID, keyID string) (*PublisherKey, error) {
+	var k PublisherKey
+	err := s.conn().QueryRowContext(ctx, `
+		SELECT tenant_id, key_id, name, public_key, created_at
+		FROM sandbox.publisher_keys
+		WHERE tenant_id = $1 AND key_id = $2
+	`, tenantID, keyID).Scan(&k.TenantID, &k.KeyID, &k.Name, &k.PublicKey, &k.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get publisher key: %w", err)
+	}
+	return &k, nil
+}
+
+// DeletePublisherKey stops a tenant from trusting a key. Versions signed
+// with it no longer load unless the tenant accepts unsigned skills. It
+// returns ErrNotFound if the tenant does not trust the key.
+func (s *Store) DeletePublisherKey(ctx context.Context, tenantID, keyID string) error {
+	res, err := s.conn().ExecContext(ctx,
+		`DELETE FROM sandbox.publisher_keys WHERE tenant_id = $1 AND key_id = $2`,
+		tenantID, keyID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete publisher key: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete publisher key rows affected: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
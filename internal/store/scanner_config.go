@@ -15,16 +15,22 @@ const (
 	ApprovalPolicyNone   = "none"   // auto-approve everything (scanner still runs for logging)
 )
 
+// DefaultVulnBlockSeverity blocks skills that depend on a package with a
+// known critical vulnerability and flags the rest.
+const DefaultVulnBlockSeverity = "critical"
+
 // ScannerConfig holds per-tenant scanner configuration.
 type ScannerConfig struct {
-	TenantID       string    `json:"tenant_id"`
-	ApprovalPolicy string    `json:"approval_policy"`
-	Tier1Enabled   bool      `json:"tier1_enabled"`
-	Tier2Enabled   bool      `json:"tier2_enabled"`
-	Tier3Enabled   bool      `json:"tier3_enabled"`
-	Tier3APIKey    *string   `json:"tier3_api_key,omitempty"`
-	Tier3Model     string    `json:"tier3_model"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	TenantID          string    `json:"tenant_id"`
+	ApprovalPolicy    string    `json:"approval_policy"`
+	Tier1Enabled      bool      `json:"tier1_enabled"`
+	Tier2Enabled      bool      `json:"tier2_enabled"`
+	Tier3Enabled      bool      `json:"tier3_enabled"`
+	Tier3APIKey       *string   `json:"tier3_api_key,omitempty"`
+	Tier3Model        string    `json:"tier3_model"`
+	RequireSigned     bool      `json:"require_signed"`      // reject skills without a trusted publisher signature
+	VulnBlockSeverity string    `json:"vuln_block_severity"` // lowest known-vulnerability severity that blocks: low, medium, high, critical or none
+	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 // GetScannerConfig retrieves the scanner configuration for a tenant.
@@ -33,24 +39,27 @@ func (s *Store) GetScannerConfig(ctx context.Context, tenantID string) (*Scanner
 	cfg := &ScannerConfig{}
 	err := s.conn().QueryRowContext(ctx, `
 		SELECT tenant_id, approval_policy, tier1_enabled, tier2_enabled,
-		       tier3_enabled, tier3_api_key, tier3_model, updated_at
+		       tier3_enabled, tier3_api_key, tier3_model, require_signed,
+		       vuln_block_severity, updated_at
 		FROM sandbox.scanner_config
 		WHERE tenant_id = $1
 	`, tenantID).Scan(
 		&cfg.TenantID, &cfg.ApprovalPolicy,
 		&cfg.Tier1Enabled, &cfg.Tier2Enabled,
 		&cfg.Tier3Enabled, &cfg.Tier3APIKey,
-		&cfg.Tier3Model, &cfg.UpdatedAt,
+		&cfg.Tier3Model, &cfg.RequireSigned,
+		&cfg.VulnBlockSeverity, &cfg.UpdatedAt,
 	)
 	if errors.Is(err, sql.ErrNoRows) {
 		// Return defaults when no config exists for this tenant.
 		return &ScannerConfig{
-			TenantID:       tenantID,
-			ApprovalPolicy: ApprovalPolicyAlways,
-			Tier1Enabled:   true,
-			Tier2Enabled:   true,
-			Tier3Enabled:   false,
-			Tier3Model:     "claude-sonnet-4-5-20250514",
+			TenantID:          tenantID,
+			ApprovalPolicy:    ApprovalPolicyAlways,
+			Tier1Enabled:      true,
+			Tier2Enabled:      true,
+			Tier3Enabled:      false,
+			Tier3Model:        "claude-sonnet-4-5-20250514",
+			VulnBlockSeverity: DefaultVulnBlockSeverity,
 		}, nil
 	}
 	if err != nil {
@@ -64,8 +73,9 @@ func (s *Store) UpsertScannerConfig(ctx context.Context, cfg *ScannerConfig) err
 	_, err := s.conn().ExecContext(ctx, `
 		INSERT INTO sandbox.scanner_config
 			(tenant_id, approval_policy, tier1_enabled, tier2_enabled,
-			 tier3_enabled, tier3_api_key, tier3_model, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, now())
+			 tier3_enabled, tier3_api_key, tier3_model, require_signed,
+			 vuln_block_severity, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, now())
 		ON CONFLICT (tenant_id) DO UPDATE SET
 			approval_policy = EXCLUDED.approval_policy,
 			tier1_enabled = EXCLUDED.tier1_enabled,
@@ -73,12 +83,33 @@ func (s *Store) UpsertScannerConfig(ctx context.Context, cfg *ScannerConfig) err
 			tier3_enabled = EXCLUDED.tier3_enabled,
 			tier3_api_key = EXCLUDED.tier3_api_key,
 			tier3_model = EXCLUDED.tier3_model,
+			require_signed = EXCLUDED.require_signed,
+			vuln_block_severity = EXCLUDED.vuln_block_severity,
 			updated_at = now()
 	`, cfg.TenantID, cfg.ApprovalPolicy,
 		cfg.Tier1Enabled, cfg.Tier2Enabled,
-		cfg.Tier3Enabled, cfg.Tier3APIKey, cfg.Tier3Model)
+		cfg.Tier3Enabled, cfg.Tier3APIKey, cfg.Tier3Model, cfg.RequireSigned,
+		cfg.VulnBlockSeverity)
 	if err != nil {
 		return fmt.Errorf("upsert scanner config: %w", err)
 	}
 	return nil
 }
+
+// RequireSignedSkills reports whether the tenant rejects skills that are
+// not signed with one of its publisher keys. Tenants without a scanner
+// configuration accept unsigned skills.
+func (s *Store) RequireSignedSkills(ctx context.Context, tenantID string) (bool, error) {
+	var required bool
+	err := s.conn().QueryRowContext(ctx,
+		`SELECT require_signed FROM sandbox.scanner_config WHERE tenant_id = $1`,
+		tenantID,
+	).Scan(&required)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get signing policy: %w", err)
+	}
+	return required, nil
+}
@@ -0,0 +1,178 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SessionSandbox represents a row in the sandbox.session_sandboxes table:
+// the sandbox currently backing a session and the replica holding its lease.
+type SessionSandbox struct {
+	TenantID       string    `json:"tenant_id"`
+	ExternalID     string    `json:"external_id"`
+	SessionID      string    `json:"session_id"`
+	SandboxID      string    `json:"sandbox_id"`
+	ExecDURL       string    `json:"execd_url"`
+	Image          string    `json:"image"`
+	Owner          string    `json:"owner"`
+	LeaseExpiresAt time.Time `json:"lease_expires_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	LastUsedAt     time.Time `json:"last_used_at"`
+}
+
+const sessionSandboxColumns = `tenant_id, external_id, session_id, sandbox_id, execd_url, image,
+		       owner, lease_expires_at, created_at, last_used_at`
+
+// GetSessionSandbox retrieves the sandbox record for a session.
+func (s *Store) GetSessionSandbox(ctx context.Context, tenantID, externalID string) (*SessionSandbox, error) {
+	sb := &SessionSandbox{}
+	err := s.conn().QueryRowContext(ctx, `
+		SELECT `+sessionSandboxColumns+`
+		FROM sandbox.session_sandboxes
+		WHERE tenant_id = $1 AND external_id = $2
+	`, tenantID, externalID).Scan(
+		&sb.TenantID, &sb.ExternalID, &sb.SessionID, &sb.SandboxID, &sb.ExecDURL, &sb.Image,
+		&sb.Owner, &sb.LeaseExpiresAt, &sb.CreatedAt, &sb.LastUsedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get session sandbox: %w", err)
+	}
+	return sb, nil
+}
+
+// InsertSessionSandbox records a newly created sandbox for a session, with
+// sb.Owner holding a lease of the given duration. It returns false without
+// error if the session already has a sandbox record, i.e. another replica
+// won the race to create one.
+func (s *Store) InsertSessionSandbox(ctx context.Context, sb *SessionSandbox, lease time.Duration) (bool, error) {
+	res, err := s.conn().ExecContext(ctx, `
+		INSERT INTO sandbox.session_sandboxes
+		    (tenant_id, external_id, session_id, sandbox_id, execd_url, image, owner, lease_expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW() + $8 * INTERVAL '1 millisecond')
+		ON CONFLICT (tenant_id, external_id) DO NOTHING
+	`, sb.TenantID, sb.ExternalID, sb.SessionID, sb.SandboxID, sb.ExecDURL, sb.Image,
+		sb.Owner, lease.Milliseconds())
+	if err != nil {
+		return false, fmt.Errorf("insert session sandbox: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("insert session sandbox rows affected: %w", err)
+	}
+	return n == 1, nil
+}
+
+// ReplaceSessionSandbox points a session at a new sandbox, but only if the
+// record still references oldSandboxID. It returns false without error when
+// another replica replaced or removed the record first.
+func (s *Store) ReplaceSessionSandbox(ctx context.Context, sb *SessionSandbox, oldSandboxID string, lease time.Duration) (bool, error) {
+	res, err := s.conn().ExecContext(ctx, `
+		UPDATE sandbox.session_sandboxes
+		SET sandbox_id = $3, execd_url = $4, image = $5, session_id = $6, owner = $7,
+		    lease_expires_at = NOW() + $8 * INTERVAL '1 millisecond',
+		    created_at = NOW(), last_used_at = NOW()
+		WHERE tenant_id = $1 AND external_id = $2 AND sandbox_id = $9
+	`, sb.TenantID, sb.ExternalID, sb.SandboxID, sb.ExecDURL, sb.Image, sb.SessionID,
+		sb.Owner, lease.Milliseconds(), oldSandboxID)
+	if err != nil {
+		return false, fmt.Errorf("replace session sandbox: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("replace session sandbox rows affected: %w", err)
+	}
+	return n == 1, nil
+}
+
+// HeartbeatSessionSandbox reports a replica's use of a session sandbox.
+// It advances last_used_at to lastUsed (never backwards) and renews the
+// lease if owner holds it, or takes the lease over if it has expired.
+// It returns whether owner holds the lease afterwards, or ErrNotFound if
+// the session no longer points at sandboxID.
+func (s *Store) HeartbeatSessionSandbox(ctx context.Context, tenantID, externalID, sandboxID, owner string, lastUsed time.Time, lease time.Duration) (bool, error) {
+	var held bool
+	err := s.conn().QueryRowContext(ctx, `
+		UPDATE sandbox.session_sandboxes
+		SET last_used_at = GREATEST(last_used_at, $5),
+		    owner = CASE WHEN owner = $4 OR lease_expires_at < NOW() THEN $4 ELSE owner END,
+		    lease_expires_at = CASE WHEN owner = $4 OR lease_expires_at < NOW()
+		                            THEN NOW() + $6 * INTERVAL '1 millisecond'
+		                            ELSE lease_expires_at END
+		WHERE tenant_id = $1 AND external_id = $2 AND sandbox_id = $3
+		RETURNING owner = $4
+	`, tenantID, externalID, sandboxID, owner, lastUsed, lease.Milliseconds()).Scan(&held)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, ErrNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("heartbeat session sandbox: %w", err)
+	}
+	return held, nil
+}
+
+// ReleaseSessionSandboxLease expires owner's lease immediately so another
+// replica can take the sandbox over without waiting for it to lapse.
+func (s *Store) ReleaseSessionSandboxLease(ctx context.Context, tenantID, externalID, owner string) error {
+	_, err := s.conn().ExecContext(ctx, `
+		UPDATE sandbox.session_sandboxes
+		SET lease_expires_at = NOW()
+		WHERE tenant_id = $1 AND external_id = $2 AND owner = $3
+	`, tenantID, externalID, owner)
+	if err != nil {
+		return fmt.Errorf("release session sandbox lease: %w", err)
+	}
+	return nil
+}
+
+// ListOrphanedSessionSandboxes returns up to limit sandbox records whose
+// lease has lapsed and which no replica has used since idleBefore. Their
+// holders are gone, so some other replica must clean them up.
+func (s *Store) ListOrphanedSessionSandboxes(ctx context.Context, idleBefore time.Time, limit int) ([]*SessionSandbox, error) {
+	rows, err := s.conn().QueryContext(ctx, `
+		SELECT `+sessionSandboxColumns+`
+		FROM sandbox.session_sandboxes
+		WHERE lease_expires_at < NOW() AND last_used_at < $1
+		ORDER BY last_used_at ASC
+		LIMIT $2
+	`, idleBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list orphaned session sandboxes: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var out []*SessionSandbox
+	for rows.Next() {
+		sb := &SessionSandbox{}
+		if err := rows.Scan(
+			&sb.TenantID, &sb.ExternalID, &sb.SessionID, &sb.SandboxID, &sb.ExecDURL, &sb.Image,
+			&sb.Owner, &sb.LeaseExpiresAt, &sb.CreatedAt, &sb.LastUsedAt,
+		); err != nil {
+			return nil, fmt.Errorf("list orphaned session sandboxes scan: %w", err)
+		}
+		out = append(out, sb)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list orphaned session sandboxes rows: %w", err)
+	}
+	return out, nil
+}
+
+// DeleteSessionSandbox removes a session's sandbox record if it still
+// references sandboxID. A record already replaced by another replica is
+// left alone and no error is returned.
+func (s *Store) DeleteSessionSandbox(ctx context.Context, tenantID, externalID, sandboxID string) error {
+	_, err := s.conn().ExecContext(ctx, `
+		DELETE FROM sandbox.session_sandboxes
+		WHERE tenant_id = $1 AND external_id = $2 AND sandbox_id = $3
+	`, tenantID, externalID, sandboxID)
+	if err != nil {
+		return fmt.Errorf("delete session sandbox: %w", err)
+	}
+	return nil
+}
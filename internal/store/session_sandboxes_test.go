@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// --- InsertSessionSandbox ---
+
+func TestInsertSessionSandbox_ConflictReturnsFalse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	s := &Store{db: db}
+
+	mock.ExpectExec("INSERT INTO sandbox.session_sandboxes").
+		WithArgs("tenant-1", "ext-1", "sess-1", "sb-1", "http://execd:44772", "python:3.12",
+			"replica-a", int64(30000)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ok, err := s.InsertSessionSandbox(context.Background(), &SessionSandbox{
+		TenantID:   "tenant-1",
+		ExternalID: "ext-1",
+		SessionID:  "sess-1",
+		SandboxID:  "sb-1",
+		ExecDURL:   "http://execd:44772",
+		Image:      "python:3.12",
+		Owner:      "replica-a",
+	}, 30*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("InsertSessionSandbox = true, want false when a record already exists")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+// --- ReplaceSessionSandbox ---
+
+func TestReplaceSessionSandbox_OnlyReplacesExpectedSandbox(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	s := &Store{db: db}
+
+	mock.ExpectExec("UPDATE sandbox.session_sandboxes.*AND sandbox_id = \\$9").
+		WithArgs("tenant-1", "ext-1", "sb-2", "http://execd-2:44772", "python:3.12", "sess-1",
+			"replica-a", int64(30000), "sb-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ok, err := s.ReplaceSessionSandbox(context.Background(), &SessionSandbox{
+		TenantID:   "tenant-1",
+		ExternalID: "ext-1",
+		SessionID:  "sess-1",
+		SandboxID:  "sb-2",
+		ExecDURL:   "http://execd-2:44772",
+		Image:      "python:3.12",
+		Owner:      "replica-a",
+	}, "sb-1", 30*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Error("ReplaceSessionSandbox = false, want true")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+// --- HeartbeatSessionSandbox ---
+
+func TestHeartbeatSessionSandbox_ReportsLeaseHolder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	s := &Store{db: db}
+
+	used := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("UPDATE sandbox.session_sandboxes").
+		WithArgs("tenant-1", "ext-1", "sb-1", "replica-b", used, int64(30000)).
+		WillReturnRows(sqlmock.NewRows([]string{"held"}).AddRow(false))
+
+	held, err := s.HeartbeatSessionSandbox(context.Background(), "tenant-1", "ext-1", "sb-1",
+		"replica-b", used, 30*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if held {
+		t.Error("held = true, want false while another replica holds the lease")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestHeartbeatSessionSandbox_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	s := &Store{db: db}
+
+	mock.ExpectQuery("UPDATE sandbox.session_sandboxes").
+		WithArgs("tenant-1", "ext-1", "sb-old", "replica-a", sqlmock.AnyArg(), int64(30000)).
+		WillReturnRows(sqlmock.NewRows([]string{"held"}))
+
+	_, err = s.HeartbeatSessionSandbox(context.Background(), "tenant-1", "ext-1", "sb-old",
+		"replica-a", time.Now(), 30*time.Second)
+	if err != ErrNotFound {
+		t.Errorf("error = %v, want ErrNotFound", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
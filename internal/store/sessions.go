@@ -106,6 +106,25 @@ func (s *Store) ListSessionFiles(ctx context.Context, tenantID, sessionID string
 	})
 }
 
+// ListAllSessionFiles returns every file record of a session, newest
+// first, without the page limit of ListSessionFiles. Snapshots use it to
+// read and replace whole workspaces.
+func (s *Store) ListAllSessionFiles(ctx context.Context, tenantID, sessionID string) ([]*File, error) {
+	rows, err := s.conn().QueryContext(ctx, `
+		SELECT id, tenant_id, session_id, execution_id, name, content_type,
+		       size_bytes, s3_key, version, parent_id, created_at, updated_at
+		FROM sandbox.files
+		WHERE tenant_id = $1 AND session_id = $2
+		ORDER BY created_at DESC, id
+	`, tenantID, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("list all session files: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	return scanFiles(rows)
+}
+
 // DeleteSession removes a session record. This does NOT delete associated files —
 // callers must clean those up separately.
 func (s *Store) DeleteSession(ctx context.Context, tenantID, externalID string) error {
@@ -0,0 +1,92 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// SkillDependency is one third-party package a skill version declares,
+// locks or vendors, in one file of the skill.
+type SkillDependency struct {
+	Name           string `json:"name"`    // skill name
+	Version        string `json:"version"` // skill version
+	Status         string `json:"status,omitempty"`
+	Active         bool   `json:"active,omitempty"`
+	Ecosystem      string `json:"ecosystem"` // "PyPI" or "npm"
+	Package        string `json:"package"`
+	PackageVersion string `json:"package_version,omitempty"` // exact version, if pinned
+	Specifier      string `json:"specifier,omitempty"`       // declared range, if not pinned
+	FilePath       string `json:"file_path"`
+}
+
+// ReplaceSkillDependencies sets the dependencies of a skill version,
+// replacing any recorded before. Package and ecosystem are taken from
+// each entry; Name and Version come from the arguments.
+func (s *Store) ReplaceSkillDependencies(ctx context.Context, tenantID, name, version string, deps []SkillDependency) error {
+	return s.RunInTx(ctx, func(tx *Store) error {
+		if _, err := tx.conn().ExecContext(ctx, `
+			DELETE FROM sandbox.skill_dependencies
+			WHERE tenant_id = $1 AND name = $2 AND version = $3
+		`, tenantID, name, version); err != nil {
+			return fmt.Errorf("clear skill dependencies: %w", err)
+		}
+		for _, d := range deps {
+			if _, err := tx.conn().ExecContext(ctx, `
+				INSERT INTO sandbox.skill_dependencies
+					(tenant_id, name, version, ecosystem, package, package_version, specifier, file_path)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+				ON CONFLICT DO NOTHING
+			`, tenantID, name, version, d.Ecosystem, d.Package, d.PackageVersion, d.Specifier, d.FilePath); err != nil {
+				return fmt.Errorf("insert skill dependency: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// PackageRef names a package in an ecosystem.
+type PackageRef struct {
+	Ecosystem string
+	Package   string
+}
+
+// ListDependents returns every skill version of the tenant that depends
+// on one of pkgs, ordered by skill name and newest version first.
+func (s *Store) ListDependents(ctx context.Context, tenantID string, pkgs []PackageRef) ([]SkillDependency, error) {
+	names := make([]string, len(pkgs))
+	keys := make([]string, len(pkgs))
+	for i, p := range pkgs {
+		names[i] = p.Package
+		keys[i] = p.Ecosystem + ":" + p.Package
+	}
+	rows, err := s.conn().QueryContext(ctx, `
+		SELECT d.name, d.version, s.status, s.is_active, d.ecosystem, d.package,
+		       d.package_version, d.specifier, d.file_path
+		FROM sandbox.skill_dependencies d
+		JOIN sandbox.skills s
+		  ON s.tenant_id = d.tenant_id AND s.name = d.name AND s.version = d.version
+		WHERE d.tenant_id = $1 AND d.package = ANY($2)
+		  AND d.ecosystem || ':' || d.package = ANY($3)
+		ORDER BY d.name, s.uploaded_at DESC, d.file_path
+	`, tenantID, pq.Array(names), pq.Array(keys))
+	if err != nil {
+		return nil, fmt.Errorf("list dependents: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var deps []SkillDependency
+	for rows.Next() {
+		var d SkillDependency
+		if err := rows.Scan(&d.Name, &d.Version, &d.Status, &d.Active, &d.Ecosystem, &d.Package,
+			&d.PackageVersion, &d.Specifier, &d.FilePath); err != nil {
+			return nil, fmt.Errorf("scan dependent row: %w", err)
+		}
+		deps = append(deps, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate dependent rows: %w", err)
+	}
+	return deps, nil
+}
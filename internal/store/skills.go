@@ -11,6 +11,7 @@ import (
 	"github.com/lib/pq"
 
 	"github.com/devs-group/skillbox/internal/skill"
+	"github.com/devs-group/skillbox/internal/skilltest"
 )
 
 // Skill status constants.
@@ -21,6 +22,7 @@ const (
 	SkillStatusAvailable   = "available"
 	SkillStatusDeclined    = "declined"
 	SkillStatusQuarantined = "quarantined"
+	SkillStatusTestFailed  = "test_failed"
 )
 
 // SkillRecord represents a row in the sandbox.skills metadata table.
@@ -68,9 +70,10 @@ func (s *Store) UpsertSkill(ctx context.Context, rec *SkillRecord) error {
 	return nil
 }
 
-// ListSkills returns all skill metadata for a tenant, ordered by name
-// then version. By default only returns available skills; pass a non-empty
-// status to filter by a specific status.
+// ListSkills returns one record per skill of a tenant, ordered by name. By
+// default only available skills are listed, each at its active version, or
+// at its newest version when none is active; this is the version runs
+// resolve to. Pass a non-empty status to filter by a specific status.
 func (s *Store) ListSkills(ctx context.Context, tenantID string, statusFilter ...string) ([]SkillRecord, error) {
 	status := SkillStatusAvailable
 	if len(statusFilter) > 0 && statusFilter[0] != "" {
@@ -84,7 +87,7 @@ func (s *Store) ListSkills(ctx context.Context, tenantID string, statusFilter ..
 		FROM sandbox.skills s
 		LEFT JOIN sandbox.tenant_blocked_skills b ON b.tenant_id = s.tenant_id AND b.name = s.name
 		WHERE s.tenant_id = $1 AND s.status = $2
-		ORDER BY s.name, s.uploaded_at DESC
+		ORDER BY s.name, s.is_active DESC, s.uploaded_at DESC
 	`, tenantID, status)
 	if err != nil {
 		return nil, fmt.Errorf("list skills: %w", err)
@@ -121,7 +124,7 @@ func (s *Store) ListAllSkills(ctx context.Context, tenantID string) ([]SkillReco
 		       s.scan_result, s.scanned_at, s.reviewed_by, s.reviewed_at, s.uploaded_at, s.source_url,
 		       b.name IS NOT NULL AS blocked,
 		       EXISTS(SELECT 1 FROM sandbox.skills r WHERE r.tenant_id = s.tenant_id AND r.name = s.name AND r.status IN ('review','pending','scanning')) AS has_review,
-		       EXISTS(SELECT 1 FROM sandbox.skills r WHERE r.tenant_id = s.tenant_id AND r.name = s.name AND r.status IN ('declined','quarantined')) AS has_declined,
+		       EXISTS(SELECT 1 FROM sandbox.skills r WHERE r.tenant_id = s.tenant_id AND r.name = s.name AND r.status IN ('declined','quarantined','test_failed')) AS has_declined,
 		       EXISTS(SELECT 1 FROM sandbox.skills r WHERE r.tenant_id = s.tenant_id AND r.name = s.name AND r.status IN ('pending','scanning')) AS has_scanning
 		FROM sandbox.skills s
 		LEFT JOIN sandbox.tenant_blocked_skills b ON b.tenant_id = s.tenant_id AND b.name = s.name
@@ -185,6 +188,8 @@ type SkillVersionInfo struct {
 	UploadedAt   time.Time         `json:"uploaded_at"`
 	ScanSummary  string            `json:"scan_summary,omitempty"`
 	ScanFindings []ScanFindingInfo `json:"scan_findings,omitempty"`
+	TestResults  *skilltest.Report `json:"test_results,omitempty"`
+	SignedBy     string            `json:"signed_by,omitempty"` // publisher key id
 }
 
 // ScanFindingInfo is the reviewer-facing subset of a security scan finding.
@@ -201,8 +206,8 @@ type ScanFindingInfo struct {
 // newest first, with status and active flag.
 func (s *Store) ListSkillVersions(ctx context.Context, tenantID, name string) ([]SkillVersionInfo, error) {
 	rows, err := s.conn().QueryContext(ctx, `
-		SELECT s.version, s.status, s.is_active, s.uploaded_at, s.scan_result,
-		       b.version IS NOT NULL AS blocked
+		SELECT s.version, s.status, s.is_active, s.uploaded_at, s.scan_result, s.test_results,
+		       b.version IS NOT NULL AS blocked, COALESCE(s.signature->>'key_id', '')
 		FROM sandbox.skills s
 		LEFT JOIN sandbox.tenant_blocked_skills b
 		  ON b.tenant_id = s.tenant_id AND b.name = s.name AND b.version = s.version
@@ -217,8 +222,8 @@ func (s *Store) ListSkillVersions(ctx context.Context, tenantID, name string) ([
 	var versions []SkillVersionInfo
 	for rows.Next() {
 		var v SkillVersionInfo
-		var scanResult []byte
-		if err := rows.Scan(&v.Version, &v.Status, &v.Active, &v.UploadedAt, &scanResult, &v.Blocked); err != nil {
+		var scanResult, testResults []byte
+		if err := rows.Scan(&v.Version, &v.Status, &v.Active, &v.UploadedAt, &scanResult, &testResults, &v.Blocked, &v.SignedBy); err != nil {
 			return nil, fmt.Errorf("scan skill version row: %w", err)
 		}
 		if len(scanResult) > 0 {
@@ -243,6 +248,12 @@ func (s *Store) ListSkillVersions(ctx context.Context, tenantID, name string) ([
 				}
 			}
 		}
+		if len(testResults) > 0 {
+			var report skilltest.Report
+			if json.Unmarshal(testResults, &report) == nil {
+				v.TestResults = &report
+			}
+		}
 		versions = append(versions, v)
 	}
 	if err := rows.Err(); err != nil {
@@ -368,7 +379,7 @@ func (s *Store) UpdateSkillStatus(ctx context.Context, tenantID, name, version,
 		UPDATE sandbox.skills
 		SET status = $4,
 		    scan_result = $5,
-		    scanned_at = CASE WHEN $4 IN ('available', 'review', 'quarantined') THEN now() ELSE scanned_at END
+		    scanned_at = CASE WHEN $4 IN ('available', 'review', 'quarantined', 'test_failed') THEN now() ELSE scanned_at END
 		WHERE tenant_id = $1 AND name = $2 AND version = $3
 	`, tenantID, name, version, newStatus, nullableJSON(scanResult))
 	if err != nil {
@@ -401,6 +412,45 @@ func (s *Store) GetSkillStatus(ctx context.Context, tenantID, name, version stri
 	return status, nil
 }
 
+// SetSkillTestResults stores the results of a version's test cases.
+func (s *Store) SetSkillTestResults(ctx context.Context, tenantID, name, version string, results json.RawMessage) error {
+	res, err := s.conn().ExecContext(ctx, `
+		UPDATE sandbox.skills SET test_results = $4
+		WHERE tenant_id = $1 AND name = $2 AND version = $3
+	`, tenantID, name, version, nullableJSON(results))
+	if err != nil {
+		return fmt.Errorf("set skill test results: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("set skill test results rows affected: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SetSkillSignature records the publisher signature a version was
+// uploaded with.
+func (s *Store) SetSkillSignature(ctx context.Context, tenantID, name, version string, signature json.RawMessage) error {
+	res, err := s.conn().ExecContext(ctx, `
+		UPDATE sandbox.skills SET signature = $4
+		WHERE tenant_id = $1 AND name = $2 AND version = $3
+	`, tenantID, name, version, nullableJSON(signature))
+	if err != nil {
+		return fmt.Errorf("set skill signature: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("set skill signature rows affected: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
 // ListPendingSkills returns skills in 'pending' or 'scanning' status across
 // all tenants. Used by the background scan worker for startup recovery.
 func (s *Store) ListPendingSkills(ctx context.Context) ([]SkillRecord, error) {
@@ -438,8 +488,8 @@ func (s *Store) ListPendingSkills(ctx context.Context) ([]SkillRecord, error) {
 }
 
 // ReviewSkill records an admin review action. Transitions:
-// approve: review|declined -> available (also clears any tenant block).
-// decline: review|available -> declined (soft, user can reinstall).
+// approve: review|declined|test_failed -> available (also clears any tenant block).
+// decline: review|available|test_failed -> declined (soft, user can reinstall).
 // decline_forever: any -> declined + tenant block (user reinstall refused; admin can still reopen).
 func (s *Store) ReviewSkill(ctx context.Context, tenantID, name, version, action, reviewedBy, reason string) error {
 	var newStatus string
@@ -447,13 +497,13 @@ func (s *Store) ReviewSkill(ctx context.Context, tenantID, name, version, action
 	switch action {
 	case "approve":
 		newStatus = SkillStatusAvailable
-		allowedFrom = []string{SkillStatusReview, SkillStatusDeclined}
+		allowedFrom = []string{SkillStatusReview, SkillStatusDeclined, SkillStatusTestFailed}
 	case "decline":
 		newStatus = SkillStatusDeclined
-		allowedFrom = []string{SkillStatusReview, SkillStatusAvailable}
+		allowedFrom = []string{SkillStatusReview, SkillStatusAvailable, SkillStatusTestFailed}
 	case "decline_forever":
 		newStatus = SkillStatusDeclined
-		allowedFrom = []string{SkillStatusReview, SkillStatusAvailable, SkillStatusDeclined, SkillStatusPending, SkillStatusScanning}
+		allowedFrom = []string{SkillStatusReview, SkillStatusAvailable, SkillStatusDeclined, SkillStatusPending, SkillStatusScanning, SkillStatusTestFailed}
 	case "reopen":
 		newStatus = SkillStatusReview
 		allowedFrom = []string{SkillStatusDeclined}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 )
@@ -144,6 +146,35 @@ func TestResolveActiveVersion_FallbackPrefersAvailable(t *testing.T) {
 	}
 }
 
+func TestListSkills_PrefersActiveVersion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	s := &Store{db: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta("ORDER BY s.name, s.is_active DESC, s.uploaded_at DESC")).
+		WithArgs("tenant-1", SkillStatusAvailable).
+		WillReturnRows(sqlmock.NewRows([]string{"tenant_id", "name", "version", "description", "lang", "status", "stars",
+			"scan_result", "scanned_at", "reviewed_by", "reviewed_at", "uploaded_at", "source_url", "blocked"}).
+			AddRow("tenant-1", "my-skill", "1.0.0", "", "python", SkillStatusAvailable, 0,
+				nil, nil, nil, nil, time.Now(), nil, false))
+
+	recs, err := s.ListSkills(context.Background(), "tenant-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(recs) != 1 || recs[0].Version != "1.0.0" {
+		t.Errorf("ListSkills = %+v, want my-skill at its active version 1.0.0", recs)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
 func TestSetActiveVersion_Available(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
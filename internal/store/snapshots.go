@@ -0,0 +1,141 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Snapshot represents a row in the sandbox.session_snapshots table: a named,
+// versioned tar.gz bundle of a session workspace stored in object storage.
+type Snapshot struct {
+	ID        string    `json:"id"`
+	TenantID  string    `json:"tenant_id"`
+	SessionID string    `json:"session_id"`
+	Name      string    `json:"name"`
+	Version   int       `json:"version"`
+	S3Key     string    `json:"s3_key"`
+	SizeBytes int64     `json:"size_bytes"`
+	FileCount int       `json:"file_count"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+const snapshotColumns = `id, tenant_id, session_id, name, version, s3_key, size_bytes, file_count, created_at`
+
+// CreateSnapshot inserts a snapshot record. The caller supplies the ID (the
+// bundle is uploaded under it first); Version is assigned as one past the
+// highest existing version of the same name in the session. The Snapshot
+// is mutated in place with the assigned version and creation time.
+func (s *Store) CreateSnapshot(ctx context.Context, snap *Snapshot) (*Snapshot, error) {
+	err := s.conn().QueryRowContext(ctx, `
+		INSERT INTO sandbox.session_snapshots (id, tenant_id, session_id, name, version, s3_key, size_bytes, file_count)
+		VALUES ($1, $2, $3, $4,
+		        (SELECT COALESCE(MAX(version), 0) + 1 FROM sandbox.session_snapshots WHERE session_id = $3 AND name = $4),
+		        $5, $6, $7)
+		RETURNING version, created_at
+	`, snap.ID, snap.TenantID, snap.SessionID, snap.Name, snap.S3Key, snap.SizeBytes, snap.FileCount,
+	).Scan(&snap.Version, &snap.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("create snapshot: %w", err)
+	}
+	return snap, nil
+}
+
+// GetSnapshot retrieves a snapshot by ID, scoped to a tenant.
+func (s *Store) GetSnapshot(ctx context.Context, tenantID, id string) (*Snapshot, error) {
+	snap := &Snapshot{}
+	err := s.conn().QueryRowContext(ctx, `
+		SELECT `+snapshotColumns+`
+		FROM sandbox.session_snapshots
+		WHERE id = $1 AND tenant_id = $2
+	`, id, tenantID).Scan(
+		&snap.ID, &snap.TenantID, &snap.SessionID, &snap.Name, &snap.Version,
+		&snap.S3Key, &snap.SizeBytes, &snap.FileCount, &snap.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get snapshot: %w", err)
+	}
+	return snap, nil
+}
+
+// ListSnapshots returns a session's snapshots, newest first.
+func (s *Store) ListSnapshots(ctx context.Context, tenantID, sessionID string) ([]*Snapshot, error) {
+	return s.querySnapshots(ctx, "list snapshots", `
+		SELECT `+snapshotColumns+`
+		FROM sandbox.session_snapshots
+		WHERE tenant_id = $1 AND session_id = $2
+		ORDER BY created_at DESC, version DESC
+	`, tenantID, sessionID)
+}
+
+// ListSnapshotsOverLimit returns a tenant's snapshots beyond the newest
+// keep, oldest last. These are the candidates for retention pruning.
+func (s *Store) ListSnapshotsOverLimit(ctx context.Context, tenantID string, keep int) ([]*Snapshot, error) {
+	return s.querySnapshots(ctx, "list snapshots over limit", `
+		SELECT `+snapshotColumns+`
+		FROM sandbox.session_snapshots
+		WHERE tenant_id = $1
+		ORDER BY created_at DESC, version DESC
+		OFFSET $2
+	`, tenantID, keep)
+}
+
+// ListSnapshotsCreatedBefore returns up to limit snapshots of any tenant
+// created before the cutoff, oldest first.
+func (s *Store) ListSnapshotsCreatedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*Snapshot, error) {
+	return s.querySnapshots(ctx, "list expired snapshots", `
+		SELECT `+snapshotColumns+`
+		FROM sandbox.session_snapshots
+		WHERE created_at < $1
+		ORDER BY created_at ASC
+		LIMIT $2
+	`, cutoff, limit)
+}
+
+// DeleteSnapshot removes a snapshot record. The bundle in object storage
+// must be deleted separately by the caller.
+func (s *Store) DeleteSnapshot(ctx context.Context, tenantID, id string) error {
+	res, err := s.conn().ExecContext(ctx, `
+		DELETE FROM sandbox.session_snapshots WHERE id = $1 AND tenant_id = $2
+	`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("delete snapshot: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete snapshot rows affected: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *Store) querySnapshots(ctx context.Context, op, query string, args ...any) ([]*Snapshot, error) {
+	rows, err := s.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var snaps []*Snapshot
+	for rows.Next() {
+		snap := &Snapshot{}
+		if err := rows.Scan(
+			&snap.ID, &snap.TenantID, &snap.SessionID, &snap.Name, &snap.Version,
+			&snap.S3Key, &snap.SizeBytes, &snap.FileCount, &snap.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%s scan: %w", op, err)
+		}
+		snaps = append(snaps, snap)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s rows: %w", op, err)
+	}
+	return snaps, nil
+}
@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// columns used across all snapshot query expectations.
+var snapshotColumnNames = []string{
+	"id", "tenant_id", "session_id", "name", "version",
+	"s3_key", "size_bytes", "file_count", "created_at",
+}
+
+// --- CreateSnapshot ---
+
+func TestCreateSnapshot_AssignsVersion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	s := &Store{db: db}
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("INSERT INTO sandbox.session_snapshots").
+		WithArgs("snap-1", "tenant-1", "sess-1", "before-refactor",
+			"tenant-1/snapshots/sess-1/snap-1.tar.gz", int64(2048), 3).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "created_at"}).AddRow(2, now))
+
+	snap, err := s.CreateSnapshot(context.Background(), &Snapshot{
+		ID:        "snap-1",
+		TenantID:  "tenant-1",
+		SessionID: "sess-1",
+		Name:      "before-refactor",
+		S3Key:     "tenant-1/snapshots/sess-1/snap-1.tar.gz",
+		SizeBytes: 2048,
+		FileCount: 3,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if snap.Version != 2 {
+		t.Errorf("Version = %d, want 2", snap.Version)
+	}
+	if !snap.CreatedAt.Equal(now) {
+		t.Errorf("CreatedAt = %v, want %v", snap.CreatedAt, now)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+// --- GetSnapshot ---
+
+func TestGetSnapshot_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	s := &Store{db: db}
+
+	mock.ExpectQuery("SELECT id, tenant_id, session_id, name, version").
+		WithArgs("missing-uuid", "tenant-1").
+		WillReturnRows(sqlmock.NewRows(snapshotColumnNames))
+
+	_, err = s.GetSnapshot(context.Background(), "tenant-1", "missing-uuid")
+	if err != ErrNotFound {
+		t.Errorf("error = %v, want ErrNotFound", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+// --- ListSnapshotsOverLimit ---
+
+func TestListSnapshotsOverLimit_SkipsNewest(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	s := &Store{db: db}
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT id, tenant_id, session_id, name, version.*OFFSET \\$2").
+		WithArgs("tenant-1", 50).
+		WillReturnRows(sqlmock.NewRows(snapshotColumnNames).
+			AddRow("snap-old", "tenant-1", "sess-1", "first", 1, "key-old", int64(10), 1, now))
+
+	snaps, err := s.ListSnapshotsOverLimit(context.Background(), "tenant-1", 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(snaps) != 1 || snaps[0].ID != "snap-old" || snaps[0].S3Key != "key-old" {
+		t.Errorf("snaps = %+v, want [snap-old]", snaps)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+// --- DeleteSnapshot ---
+
+func TestDeleteSnapshot_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close() //nolint:errcheck
+
+	s := &Store{db: db}
+
+	mock.ExpectExec("DELETE FROM sandbox.session_snapshots").
+		WithArgs("missing-uuid", "tenant-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = s.DeleteSnapshot(context.Background(), "tenant-1", "missing-uuid")
+	if err != ErrNotFound {
+		t.Errorf("error = %v, want ErrNotFound", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+// Package tools describes a tenant's runnable skills as LLM tools and
+// renders them in the formats agent frameworks and model providers expect.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/devs-group/skillbox/internal/skill"
+	"github.com/devs-group/skillbox/internal/store"
+)
+
+// maxCachedSkills bounds the parsed SKILL.md cache. It is cleared when
+// full; entries are cheap to reload.
+const maxCachedSkills = 4096
+
+// SkillLoader reads and parses the SKILL.md of a skill version.
+type SkillLoader interface {
+	LoadSkillMD(ctx context.Context, tenantID, name, version string) (*skill.Skill, error)
+}
+
+// Definition is a runnable skill described as a tool.
+type Definition struct {
+	Skill       string
+	Version     string
+	Description string
+	InputSchema map[string]any
+}
+
+// Catalog lists the skills of a tenant as tool definitions.
+type Catalog struct {
+	store  *store.Store
+	loader SkillLoader
+
+	mu     sync.Mutex
+	skills map[string]*skill.Skill // keyed by tenant/name/version; versions are immutable
+}
+
+// NewCatalog creates a Catalog that lists skills from s and reads their
+// SKILL.md through loader.
+func NewCatalog(s *store.Store, loader SkillLoader) *Catalog {
+	return &Catalog{store: s, loader: loader, skills: make(map[string]*skill.Skill)}
+}
+
+// List returns a definition for every available, unblocked executable
+// skill of tenantID, at its active version, the version runs resolve to.
+// Templates are not tools. Skills whose SKILL.md cannot be loaded are
+// logged and left out.
+func (c *Catalog) List(ctx context.Context, tenantID string) ([]Definition, error) {
+	recs, err := c.store.ListSkills(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list tools: %w", err)
+	}
+
+	defs := make([]Definition, 0, len(recs))
+	for _, rec := range recs {
+		if rec.Blocked {
+			continue
+		}
+		sk, err := c.skill(ctx, tenantID, rec.Name, rec.Version)
+		if err != nil {
+			slog.Warn("tools: load skill failed", "tenant_id", tenantID, "skill", rec.Name, "version", rec.Version, "error", err)
+			continue
+		}
+		if sk.Mode == "cognitive" || sk.Kind == skill.KindTemplate {
+			continue
+		}
+		defs = append(defs, Definition{
+			Skill:       rec.Name,
+			Version:     rec.Version,
+			Description: sk.Description,
+			InputSchema: sk.ToolInputSchema(),
+		})
+	}
+	return defs, nil
+}
+
+// skill returns the parsed SKILL.md of a skill version, from the cache if
+// possible.
+func (c *Catalog) skill(ctx context.Context, tenantID, name, version string) (*skill.Skill, error) {
+	key := tenantID + "/" + name + "/" + version
+	c.mu.Lock()
+	sk, ok := c.skills[key]
+	c.mu.Unlock()
+	if ok {
+		return sk, nil
+	}
+
+	sk, err := c.loader.LoadSkillMD(ctx, tenantID, name, version)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if len(c.skills) >= maxCachedSkills {
+		clear(c.skills)
+	}
+	c.skills[key] = sk
+	c.mu.Unlock()
+	return sk, nil
+}
@@ -0,0 +1,206 @@
+package tools
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Format is a tool definition dialect.
+type Format string
+
+// Supported formats.
+const (
+	FormatOpenAI    Format = "openai"
+	FormatAnthropic Format = "anthropic"
+	FormatGemini    Format = "gemini"
+	FormatLangChain Format = "langchain"
+)
+
+// maxToolName is the longest tool name every supported provider accepts.
+const maxToolName = 64
+
+// ParseFormat validates a format name. An empty name means OpenAI.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case "":
+		return FormatOpenAI, nil
+	case FormatOpenAI, FormatAnthropic, FormatGemini, FormatLangChain:
+		return f, nil
+	}
+	return "", fmt.Errorf("unsupported format %q (use openai, anthropic, gemini, or langchain)", s)
+}
+
+// SkillRef identifies the skill version behind a tool.
+type SkillRef struct {
+	Skill   string `json:"skill"`
+	Version string `json:"version"`
+}
+
+// Export is a set of tool definitions in one format.
+type Export struct {
+	Format Format `json:"format"`
+	// Tools is the value of the provider's "tools" request field, ready to
+	// send as is.
+	Tools any `json:"tools"`
+	// Skills maps each tool name to its skill, since names are rewritten
+	// to fit provider rules.
+	Skills map[string]SkillRef `json:"skills"`
+}
+
+// Render converts defs to format f.
+func Render(defs []Definition, f Format) *Export {
+	out := &Export{Format: f, Skills: make(map[string]SkillRef, len(defs))}
+	tools := make([]map[string]any, 0, len(defs))
+	for _, d := range defs {
+		name := uniqueName(toolName(d.Skill, f), out.Skills)
+		out.Skills[name] = SkillRef{Skill: d.Skill, Version: d.Version}
+
+		switch f {
+		case FormatAnthropic:
+			tools = append(tools, map[string]any{
+				"name":         name,
+				"description":  d.Description,
+				"input_schema": d.InputSchema,
+			})
+		case FormatGemini:
+			tools = append(tools, map[string]any{
+				"name":        name,
+				"description": d.Description,
+				"parameters":  geminiSchema(d.InputSchema),
+			})
+		case FormatLangChain:
+			tools = append(tools, map[string]any{
+				"name":        name,
+				"description": d.Description,
+				"args_schema": d.InputSchema,
+			})
+		default:
+			tools = append(tools, map[string]any{
+				"type": "function",
+				"function": map[string]any{
+					"name":        name,
+					"description": d.Description,
+					"parameters":  d.InputSchema,
+				},
+			})
+		}
+	}
+
+	out.Tools = tools
+	if f == FormatGemini {
+		// Gemini groups function declarations inside a single tool.
+		out.Tools = []map[string]any{{"functionDeclarations": tools}}
+	}
+	return out
+}
+
+// toolName rewrites a skill name to the provider's tool-name rules.
+// Skill names may contain dots, which OpenAI and Anthropic reject, and
+// may start with a digit, which Gemini rejects.
+func toolName(skillName string, f Format) string {
+	var b strings.Builder
+	for _, r := range skillName {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			b.WriteRune(r)
+		case r == '.' && f == FormatGemini:
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	name := b.String()
+	if f == FormatGemini && (name == "" || name[0] >= '0' && name[0] <= '9' || name[0] == '-' || name[0] == '.') {
+		name = "_" + name
+	}
+	if len(name) > maxToolName {
+		name = name[:maxToolName]
+	}
+	return name
+}
+
+// uniqueName appends a numeric suffix to name if another tool already
+// uses it.
+func uniqueName(name string, taken map[string]SkillRef) string {
+	if _, ok := taken[name]; !ok {
+		return name
+	}
+	for i := 2; ; i++ {
+		suffix := "_" + strconv.Itoa(i)
+		candidate := name
+		if len(candidate)+len(suffix) > maxToolName {
+			candidate = candidate[:maxToolName-len(suffix)]
+		}
+		candidate += suffix
+		if _, ok := taken[candidate]; !ok {
+			return candidate
+		}
+	}
+}
+
+// geminiSchemaKeys are the JSON Schema keywords Gemini's OpenAPI-based
+// schema accepts.
+var geminiSchemaKeys = map[string]bool{
+	"type": true, "format": true, "title": true, "description": true, "nullable": true,
+	"enum": true, "items": true, "minItems": true, "maxItems": true,
+	"properties": true, "required": true, "minProperties": true, "maxProperties": true,
+	"minLength": true, "maxLength": true, "pattern": true, "example": true,
+	"anyOf": true, "propertyOrdering": true, "default": true, "minimum": true, "maximum": true,
+}
+
+// geminiSchema strips keywords Gemini rejects, such as
+// additionalProperties and $schema, and turns nullable type unions like
+// ["string", "null"] into a type plus nullable.
+func geminiSchema(v any) any {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	out := make(map[string]any, len(m))
+	for k, val := range m {
+		if !geminiSchemaKeys[k] {
+			continue
+		}
+		switch k {
+		case "properties":
+			props, ok := val.(map[string]any)
+			if !ok {
+				continue
+			}
+			clean := make(map[string]any, len(props))
+			for name, p := range props {
+				clean[name] = geminiSchema(p)
+			}
+			out[k] = clean
+		case "items":
+			out[k] = geminiSchema(val)
+		case "anyOf":
+			list, ok := val.([]any)
+			if !ok {
+				continue
+			}
+			clean := make([]any, len(list))
+			for i, s := range list {
+				clean[i] = geminiSchema(s)
+			}
+			out[k] = clean
+		case "type":
+			types, ok := val.([]any)
+			if !ok {
+				out[k] = val
+				continue
+			}
+			for _, t := range types {
+				if t == "null" {
+					out["nullable"] = true
+				} else if _, set := out[k]; !set {
+					out[k] = t
+				}
+			}
+		default:
+			out[k] = val
+		}
+	}
+	return out
+}
@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var testDefs = []Definition{
+	{Skill: "pdf.summary", Version: "1.2.0", Description: "Summarize a PDF", InputSchema: map[string]any{
+		"type":                 "object",
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"additionalProperties": false,
+		"properties": map[string]any{
+			"url":   map[string]any{"type": "string"},
+			"pages": map[string]any{"type": []any{"integer", "null"}, "additionalProperties": false},
+		},
+	}},
+	{Skill: "pdf_summary", Version: "1.0.0", Description: "Older copy", InputSchema: map[string]any{"type": "object"}},
+	{Skill: "3d-render", Version: "1.0.0", Description: "Render", InputSchema: map[string]any{"type": "object"}},
+}
+
+func TestRender_OpenAI(t *testing.T) {
+	out := Render(testDefs, FormatOpenAI)
+	raw, _ := json.Marshal(out.Tools)
+	var tools []struct {
+		Type     string `json:"type"`
+		Function struct {
+			Name       string         `json:"name"`
+			Parameters map[string]any `json:"parameters"`
+		} `json:"function"`
+	}
+	if err := json.Unmarshal(raw, &tools); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(tools) != 3 || tools[0].Type != "function" {
+		t.Fatalf("tools = %s", raw)
+	}
+	names := []string{tools[0].Function.Name, tools[1].Function.Name, tools[2].Function.Name}
+	if strings.Join(names, ",") != "pdf_summary,pdf_summary_2,3d-render" {
+		t.Errorf("names = %v", names)
+	}
+	if ref := out.Skills["pdf_summary_2"]; ref.Skill != "pdf_summary" || ref.Version != "1.0.0" {
+		t.Errorf("skills = %+v", out.Skills)
+	}
+	if tools[0].Function.Parameters["additionalProperties"] != false {
+		t.Errorf("openai parameters were altered: %v", tools[0].Function.Parameters)
+	}
+}
+
+func TestRender_Gemini(t *testing.T) {
+	out := Render(testDefs, FormatGemini)
+	raw, _ := json.Marshal(out.Tools)
+	s := string(raw)
+	if !strings.HasPrefix(s, `[{"functionDeclarations":[`) {
+		t.Fatalf("tools = %s", s)
+	}
+	if strings.Contains(s, "additionalProperties") || strings.Contains(s, "$schema") {
+		t.Errorf("unsupported keywords kept: %s", s)
+	}
+	if !strings.Contains(s, `"pages":{"nullable":true,"type":"integer"}`) {
+		t.Errorf("nullable union not converted: %s", s)
+	}
+	if _, ok := out.Skills["pdf.summary"]; !ok {
+		t.Errorf("gemini should keep dots: %v", out.Skills)
+	}
+	if ref, ok := out.Skills["_3d-render"]; !ok || ref.Skill != "3d-render" {
+		t.Errorf("gemini names must not start with a digit: %v", out.Skills)
+	}
+}
+
+func TestRender_AnthropicAndLangChain(t *testing.T) {
+	raw, _ := json.Marshal(Render(testDefs[:1], FormatAnthropic).Tools)
+	if !strings.HasPrefix(string(raw), `[{"description":"Summarize a PDF","input_schema":{`) {
+		t.Errorf("anthropic tools = %s", raw)
+	}
+	raw, _ = json.Marshal(Render(testDefs[:1], FormatLangChain).Tools)
+	if !strings.Contains(string(raw), `"args_schema":{`) || !strings.Contains(string(raw), `"name":"pdf_summary"`) {
+		t.Errorf("langchain tools = %s", raw)
+	}
+}
+
+func TestToolName_Truncates(t *testing.T) {
+	long := strings.Repeat("a", 100)
+	taken := map[string]SkillRef{}
+	first := uniqueName(toolName(long, FormatOpenAI), taken)
+	taken[first] = SkillRef{}
+	second := uniqueName(toolName(long+"b", FormatOpenAI), taken)
+	if len(first) != maxToolName || len(second) != maxToolName || first == second || !strings.HasSuffix(second, "_2") {
+		t.Errorf("first = %q, second = %q", first, second)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat(""); err != nil || f != FormatOpenAI {
+		t.Errorf(`ParseFormat("") = %q, %v`, f, err)
+	}
+	if f, err := ParseFormat("Gemini"); err != nil || f != FormatGemini {
+		t.Errorf(`ParseFormat("Gemini") = %q, %v`, f, err)
+	}
+	if _, err := ParseFormat("cohere"); err == nil {
+		t.Error(`ParseFormat("cohere") succeeded`)
+	}
+}
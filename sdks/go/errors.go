@@ -0,0 +1,95 @@
+package skillbox
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for common API failures. An [*APIError] matches them
+// with [errors.Is] based on its status and error code, so callers can
+// branch on the kind of failure without parsing messages:
+//
+//	if errors.Is(err, skillbox.ErrSkillNotFound) {
+//	    // register the skill first
+//	}
+//
+// An error may match more than one sentinel; a missing skill is both
+// ErrSkillNotFound and ErrNotFound.
+var (
+	// ErrBadRequest matches 400 and 422 responses.
+	ErrBadRequest = errors.New("skillbox: bad request")
+
+	// ErrUnauthorized matches 401 responses: the API key or token is
+	// missing, invalid, or revoked.
+	ErrUnauthorized = errors.New("skillbox: unauthorized")
+
+	// ErrForbidden matches 403 responses.
+	ErrForbidden = errors.New("skillbox: forbidden")
+
+	// ErrNotFound matches every 404 response.
+	ErrNotFound = errors.New("skillbox: not found")
+
+	// ErrSkillNotFound matches a 404 for a skill or skill version.
+	ErrSkillNotFound = errors.New("skillbox: skill not found")
+
+	// ErrSkillNotAvailable matches a run of a skill version that has not
+	// passed review or scanning yet.
+	ErrSkillNotAvailable = errors.New("skillbox: skill not available")
+
+	// ErrSkillBlocked matches a skill blocked by an administrator.
+	ErrSkillBlocked = errors.New("skillbox: skill blocked")
+
+	// ErrImageNotAllowed matches a skill whose image is not in the
+	// server's allowlist.
+	ErrImageNotAllowed = errors.New("skillbox: image not allowed")
+
+	// ErrConflict matches 409 responses.
+	ErrConflict = errors.New("skillbox: conflict")
+
+	// ErrTimeout matches an execution that exceeded its timeout, and 504
+	// responses.
+	ErrTimeout = errors.New("skillbox: timeout")
+
+	// ErrRateLimited matches 429 responses. [APIError.RetryAfter] holds
+	// the delay the server asked for, if any.
+	ErrRateLimited = errors.New("skillbox: rate limited")
+
+	// ErrUnavailable matches 503 responses.
+	ErrUnavailable = errors.New("skillbox: service unavailable")
+)
+
+// Is reports whether the error matches target, one of the sentinel
+// errors of this package.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrSkillNotFound:
+		// Most handlers report a missing skill with the generic not_found
+		// code and a "skill not found: name@version" message.
+		return e.ErrorCode == "skill_not_found" ||
+			e.StatusCode == http.StatusNotFound && strings.HasPrefix(e.Message, "skill not found")
+	case ErrSkillNotAvailable:
+		return e.ErrorCode == "skill_not_available"
+	case ErrSkillBlocked:
+		return e.ErrorCode == "skill_blocked" || e.ErrorCode == "blocked"
+	case ErrImageNotAllowed:
+		return e.ErrorCode == "image_not_allowed"
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrTimeout:
+		return e.ErrorCode == "timeout" || e.StatusCode == http.StatusGatewayTimeout
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrUnavailable:
+		return e.StatusCode == http.StatusServiceUnavailable
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package skillbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *APIError
+		want []error
+	}{
+		{"missing skill", &APIError{StatusCode: 404, ErrorCode: "not_found", Message: "skill not found: echo@1.0.0"}, []error{ErrNotFound, ErrSkillNotFound}},
+		{"missing file", &APIError{StatusCode: 404, ErrorCode: "not_found", Message: "file not found"}, []error{ErrNotFound}},
+		{"unreviewed skill", &APIError{StatusCode: 409, ErrorCode: "skill_not_available"}, []error{ErrConflict, ErrSkillNotAvailable}},
+		{"blocked skill", &APIError{StatusCode: 403, ErrorCode: "skill_blocked"}, []error{ErrForbidden, ErrSkillBlocked}},
+		{"image", &APIError{StatusCode: 400, ErrorCode: "image_not_allowed"}, []error{ErrBadRequest, ErrImageNotAllowed}},
+		{"timeout", &APIError{StatusCode: 408, ErrorCode: "timeout"}, []error{ErrTimeout}},
+		{"rate limited", &APIError{StatusCode: 429}, []error{ErrRateLimited}},
+		{"unavailable", &APIError{StatusCode: 503}, []error{ErrUnavailable}},
+		{"unauthorized", &APIError{StatusCode: 401}, []error{ErrUnauthorized}},
+	}
+	all := []error{ErrBadRequest, ErrUnauthorized, ErrForbidden, ErrNotFound, ErrSkillNotFound,
+		ErrSkillNotAvailable, ErrSkillBlocked, ErrImageNotAllowed, ErrConflict, ErrTimeout,
+		ErrRateLimited, ErrUnavailable}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, sentinel := range all {
+				want := false
+				for _, w := range tt.want {
+					want = want || w == sentinel
+				}
+				if got := errors.Is(tt.err, sentinel); got != want {
+					t.Errorf("errors.Is(%v) = %v, want %v", sentinel, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIError_IsThroughClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":"not_found","message":"skill not found: echo@latest"}`) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL, "sk-test").Run(context.Background(), RunRequest{Skill: "echo"})
+	if !errors.Is(err, ErrSkillNotFound) {
+		t.Fatalf("err = %v, want ErrSkillNotFound", err)
+	}
+}
@@ -0,0 +1,114 @@
+package skillbox
+
+import (
+	"context"
+	"iter"
+)
+
+// Default page sizes of the paginated list endpoints.
+const (
+	defaultPageSize          = 50 // /v1/files
+	defaultExecutionPageSize = 20 // /v1/executions
+)
+
+// AllFiles returns an iterator over every file matching filter, fetching
+// pages of filter.Limit files (50 if unset) starting at filter.Offset as
+// the loop advances. A failed page request yields the error once and ends
+// the iteration:
+//
+//	for f, err := range client.AllFiles(ctx, skillbox.FileFilter{SessionID: id}) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(f.Name)
+//	}
+func (c *Client) AllFiles(ctx context.Context, filter FileFilter) iter.Seq2[FileInfo, error] {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultPageSize
+	}
+	return paged(filter.Limit, filter.Offset, func(offset int) ([]FileInfo, error) {
+		filter.Offset = offset
+		return c.ListFiles(ctx, filter)
+	})
+}
+
+// AllExecutions returns an iterator over every execution matching filter,
+// newest first, fetching pages of filter.Limit executions (20 if unset) as
+// the loop advances. Errors are handled like [Client.AllFiles].
+func (c *Client) AllExecutions(ctx context.Context, filter ExecutionFilter) iter.Seq2[Execution, error] {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultExecutionPageSize
+	}
+	return paged(filter.Limit, filter.Offset, func(offset int) ([]Execution, error) {
+		filter.Offset = offset
+		return c.ListExecutions(ctx, filter)
+	})
+}
+
+// AllSkills returns an iterator over the skills of the tenant, optionally
+// filtered by review status like [Client.ListSkills].
+func (c *Client) AllSkills(ctx context.Context, statusFilter ...string) iter.Seq2[Skill, error] {
+	return seq(func() ([]Skill, error) { return c.ListSkills(ctx, statusFilter...) })
+}
+
+// AllSkillVersions returns an iterator over the versions of a skill.
+func (c *Client) AllSkillVersions(ctx context.Context, name string) iter.Seq2[SkillVersionInfo, error] {
+	return seq(func() ([]SkillVersionInfo, error) { return c.ListSkillVersions(ctx, name) })
+}
+
+// AllFileVersions returns an iterator over the versions of a file.
+func (c *Client) AllFileVersions(ctx context.Context, id string) iter.Seq2[FileInfo, error] {
+	return seq(func() ([]FileInfo, error) { return c.ListFileVersions(ctx, id) })
+}
+
+// AllSessionFiles returns an iterator over the files of a session.
+func (c *Client) AllSessionFiles(ctx context.Context, sessionID string) iter.Seq2[FileInfo, error] {
+	return seq(func() ([]FileInfo, error) { return c.ListSessionFiles(ctx, sessionID) })
+}
+
+// AllSnapshots returns an iterator over the snapshots of a session.
+func (c *Client) AllSnapshots(ctx context.Context, sessionID string) iter.Seq2[Snapshot, error] {
+	return seq(func() ([]Snapshot, error) { return c.ListSnapshots(ctx, sessionID) })
+}
+
+// seq adapts an endpoint that returns all results in one response. The
+// request is sent when iteration starts, not when seq is called.
+func seq[T any](fetch func() ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		items, err := fetch()
+		if err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+// paged adapts a limit/offset endpoint. fetch is called with successive
+// offsets until it returns fewer than limit items or fails.
+func paged[T any](limit, offset int, fetch func(offset int) ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			page, err := fetch(offset)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if len(page) < limit {
+				return
+			}
+			offset += len(page)
+		}
+	}
+}
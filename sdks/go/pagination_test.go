@@ -0,0 +1,108 @@
+package skillbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestAllFiles_Pages(t *testing.T) {
+	var offsets []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		offsets = append(offsets, q.Get("offset"))
+		if q.Get("session_id") != "s1" || q.Get("limit") != "2" {
+			t.Errorf("query = %q", r.URL.RawQuery)
+		}
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		var page []FileInfo
+		for i := offset; i < min(offset+2, 5); i++ {
+			page = append(page, FileInfo{ID: fmt.Sprintf("f%d", i)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(page) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	var ids []string
+	for f, err := range New(srv.URL, "sk-test").AllFiles(context.Background(), FileFilter{SessionID: "s1", Limit: 2}) {
+		if err != nil {
+			t.Fatalf("AllFiles: %v", err)
+		}
+		ids = append(ids, f.ID)
+	}
+	if fmt.Sprint(ids) != "[f0 f1 f2 f3 f4]" {
+		t.Errorf("ids = %v", ids)
+	}
+	if fmt.Sprint(offsets) != "[ 2 4]" {
+		t.Errorf("offsets = %q, want three pages", offsets)
+	}
+}
+
+func TestAllFiles_StopsEarly(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"id":"a"},{"id":"b"}]`) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	for range New(srv.URL, "sk-test").AllFiles(context.Background(), FileFilter{Limit: 2}) {
+		break
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestAllExecutions_DefaultPageSize(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		page := make([]Execution, 20)
+		if r.URL.Query().Get("offset") == "20" {
+			page = page[:3]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(page) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	n := 0
+	for _, err := range New(srv.URL, "sk-test").AllExecutions(context.Background(), ExecutionFilter{Status: "running"}) {
+		if err != nil {
+			t.Fatalf("AllExecutions: %v", err)
+		}
+		n++
+	}
+	if n != 23 {
+		t.Errorf("yielded %d executions, want 23", n)
+	}
+	if fmt.Sprint(queries) != "[limit=20&status=running limit=20&offset=20&status=running]" {
+		t.Errorf("queries = %q", queries)
+	}
+}
+
+func TestAllSkills_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	n := 0
+	for _, err := range New(srv.URL, "sk-test").AllSkills(context.Background()) {
+		n++
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("err = %v, want ErrUnauthorized", err)
+		}
+	}
+	if n != 1 {
+		t.Errorf("yielded %d times, want 1", n)
+	}
+}
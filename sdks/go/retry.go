@@ -0,0 +1,137 @@
+package skillbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the client retries failed requests. Retries
+// use exponential backoff with full jitter: before retry n the client
+// waits a random duration up to min(MaxBackoff, MinBackoff*2^(n-1)), or
+// longer if the server sent a Retry-After header.
+//
+// Idempotent requests (GET, HEAD, PUT, DELETE) are retried after network
+// errors and 429, 502, 503, and 504 responses. Other requests, such as
+// running a skill, are only retried after 429 and 503 responses, which
+// mean the server did not act on them.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the backoff cap before the first retry.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the computed backoff. It does not cap Retry-After.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is used by clients created without [WithRetry].
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  250 * time.Millisecond,
+	MaxBackoff:  5 * time.Second,
+}
+
+// WithRetry sets the retry policy. Pass RetryPolicy{} to disable retries.
+func WithRetry(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// send executes req, retrying according to the client's retry policy.
+// Requests whose body cannot be replayed are sent once.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.httpClient.Do(req)
+		if attempt >= c.retry.MaxAttempts || !replayable || !shouldRetry(req.Method, resp, err) {
+			return resp, err
+		}
+
+		wait := c.retry.backoff(attempt)
+		if resp != nil {
+			if ra := retryAfter(resp); ra > wait {
+				wait = ra
+			}
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("reset request body: %w", err)
+			}
+			req.Body = body
+		}
+	}
+}
+
+// backoff returns the jittered delay before retry number attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff << (attempt - 1)
+	if d <= 0 || d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d + 1) //nolint:gosec // jitter needs no cryptographic randomness
+}
+
+// shouldRetry reports whether a request with the given method and outcome
+// may be retried.
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	idempotent := method == http.MethodGet || method == http.MethodHead ||
+		method == http.MethodPut || method == http.MethodDelete
+	if err != nil {
+		// A cancelled or expired context is final.
+		return idempotent && !isContextError(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// retryAfter parses the Retry-After header of resp, given either in
+// seconds or as an HTTP date. It returns zero if the header is absent or
+// invalid.
+func retryAfter(resp *http.Response) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
@@ -26,6 +26,18 @@
 // Use [WithTenant] to scope all requests to a specific tenant:
 //
 //	client := skillbox.New(baseURL, apiKey, skillbox.WithTenant("tenant-42"))
+//
+// # Retries and errors
+//
+// Requests are retried with exponential backoff according to a
+// [RetryPolicy]; see [WithRetry]. Failed requests return an [*APIError]
+// that matches sentinel errors such as [ErrSkillNotFound] and
+// [ErrRateLimited] with [errors.Is].
+//
+// # Pagination
+//
+// The All* methods, such as [Client.AllFiles], return iter.Seq2
+// iterators that fetch further pages as the loop advances.
 package skillbox
 
 import (
@@ -34,6 +46,7 @@ import (
 	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -57,6 +70,7 @@ type Client struct {
 	apiKey     string
 	tenantID   string
 	httpClient *http.Client
+	retry      RetryPolicy
 }
 
 // RunRequest describes a skill execution. Skill is the only required field.
@@ -115,6 +129,10 @@ type RunResult struct {
 
 	// Error holds a human-readable message when Status indicates failure.
 	Error string `json:"error"`
+
+	// Partial is true when the execution timed out and Output/FilesList hold
+	// what the skill wrote before it was stopped.
+	Partial bool `json:"partial,omitempty"`
 }
 
 // HasFiles reports whether the execution produced downloadable output files.
@@ -122,6 +140,33 @@ func (r *RunResult) HasFiles() bool {
 	return r.FilesURL != ""
 }
 
+// Execution is an execution record as returned by [Client.ListExecutions].
+type Execution struct {
+	ExecutionID  string          `json:"execution_id"`
+	SkillName    string          `json:"skill_name"`
+	SkillVersion string          `json:"skill_version"`
+	TenantID     string          `json:"tenant_id"`
+	Status       string          `json:"status"` // running, success, failed, timeout or cancelled
+	Input        json.RawMessage `json:"input,omitempty"`
+	Output       json.RawMessage `json:"output,omitempty"`
+	Logs         string          `json:"logs,omitempty"`
+	FilesURL     string          `json:"files_url,omitempty"`
+	FilesList    []string        `json:"files_list,omitempty"`
+	DurationMs   int64           `json:"duration_ms"`
+	Error        string          `json:"error"`
+	Partial      bool            `json:"partial,omitempty"`
+	CreatedAt    string          `json:"created_at"`
+	FinishedAt   string          `json:"finished_at,omitempty"`
+}
+
+// ExecutionFilter specifies query parameters for listing executions.
+type ExecutionFilter struct {
+	Skill  string
+	Status string
+	Limit  int
+	Offset int
+}
+
 // Skill describes a registered skill definition as returned by list endpoints.
 type Skill struct {
 	Name        string `json:"name"`
@@ -150,6 +195,8 @@ type SkillDetail struct {
 	Timeout      string            `json:"timeout,omitempty"`
 	Resources    map[string]string `json:"resources,omitempty"`
 	Mode         string            `json:"mode"`
+	Kind         string            `json:"kind,omitempty"`         // "skill" or "template"
+	InputSchema  map[string]any    `json:"input_schema,omitempty"` // JSON Schema for Input, if declared
 }
 
 // FileInfo represents a file record from the Skillbox API.
@@ -176,6 +223,27 @@ type FileFilter struct {
 	Offset      int
 }
 
+// Snapshot is a named, versioned bundle of a session workspace.
+type Snapshot struct {
+	ID        string `json:"id"`
+	TenantID  string `json:"tenant_id"`
+	SessionID string `json:"session_id"`
+	Name      string `json:"name"`
+	Version   int    `json:"version"`
+	SizeBytes int64  `json:"size_bytes"`
+	FileCount int    `json:"file_count"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Session is a session record returned by the Skillbox API.
+type Session struct {
+	ID             string `json:"id"`
+	TenantID       string `json:"tenant_id"`
+	ExternalID     string `json:"external_id"`
+	CreatedAt      string `json:"created_at"`
+	LastAccessedAt string `json:"last_accessed_at"`
+}
+
 // SkillFileEntry represents a single source file extracted from a skill archive.
 type SkillFileEntry struct {
 	Path      string `json:"path"`
@@ -211,6 +279,124 @@ type SandboxDirEntry struct {
 	Size  int64  `json:"size"`
 }
 
+// SandboxProcess describes a background process started in a session
+// sandbox with [Client.SandboxStartProcess].
+type SandboxProcess struct {
+	ID      string `json:"id"`
+	Command string `json:"command"`
+	WorkDir string `json:"workdir"`
+	PID     int    `json:"pid"`
+
+	// State is "running", "exited" or "killed" (gone without an exit code,
+	// e.g. after SIGKILL).
+	State string `json:"state"`
+
+	// ExitCode is set once State is "exited".
+	ExitCode   *int   `json:"exit_code,omitempty"`
+	OutputSize int64  `json:"output_size"`
+	StartedAt  string `json:"started_at"`
+}
+
+// SandboxProcessOutput is a chunk of a background process's combined
+// stdout and stderr.
+type SandboxProcessOutput struct {
+	Data string `json:"data"`
+
+	// Offset is the byte offset just past Data. Pass it to the next
+	// [Client.SandboxProcessOutput] call to continue tailing.
+	Offset   int64  `json:"offset"`
+	State    string `json:"state"`
+	ExitCode *int   `json:"exit_code,omitempty"`
+
+	// Done reports that the process has finished and all of its output
+	// has been read.
+	Done bool `json:"done"`
+}
+
+// SandboxSearchRequest describes a search of a session sandbox's files.
+type SandboxSearchRequest struct {
+	// Path is the directory to search. Defaults to /sandbox/session.
+	Path string `json:"path,omitempty"`
+
+	// Query is matched against each line of the files. If empty, the
+	// search lists the files matching Glob instead.
+	Query string `json:"query,omitempty"`
+
+	// Glob limits the files searched: "*.go" matches file names, a pattern
+	// with a slash such as "src/*.ts" matches paths relative to Path.
+	Glob string `json:"glob,omitempty"`
+
+	// Regex treats Query as an RE2 regular expression instead of a
+	// literal string.
+	Regex      bool `json:"regex,omitempty"`
+	IgnoreCase bool `json:"ignore_case,omitempty"`
+
+	// ContextLines adds up to this many lines (max 5) before and after
+	// each match to its snippet.
+	ContextLines int `json:"context_lines,omitempty"`
+
+	// MaxResults caps the matches returned. Defaults to 100, max 1000.
+	MaxResults int `json:"max_results,omitempty"`
+}
+
+// SandboxSearchMatch is a search hit. Line and Snippet are empty when
+// searching file names only.
+type SandboxSearchMatch struct {
+	Path    string `json:"path"`
+	Line    int    `json:"line,omitempty"`
+	Snippet string `json:"snippet,omitempty"`
+}
+
+// SandboxSearchResult is returned by [Client.SandboxSearch].
+type SandboxSearchResult struct {
+	Matches []SandboxSearchMatch `json:"matches"`
+
+	// Truncated reports that there were more matches than returned or
+	// that some files were too large to search.
+	Truncated bool `json:"truncated"`
+}
+
+// SandboxEditReplacement replaces an exact string in a file. OldString
+// must occur exactly once unless ReplaceAll is set.
+type SandboxEditReplacement struct {
+	OldString  string `json:"old_string"`
+	NewString  string `json:"new_string"`
+	ReplaceAll bool   `json:"replace_all,omitempty"`
+}
+
+// SandboxEditConflict explains why part of an edit did not apply. Edit is
+// the 1-based index of a failed replacement, Hunk the 1-based index of a
+// failed diff hunk within its file.
+type SandboxEditConflict struct {
+	Path   string `json:"path"`
+	Edit   int    `json:"edit,omitempty"`
+	Hunk   int    `json:"hunk,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// SandboxFileEdit reports the changes made to one file by an edit.
+type SandboxFileEdit struct {
+	Path         string `json:"path"`
+	Created      bool   `json:"created,omitempty"`
+	Replacements int    `json:"replacements,omitempty"`
+	Hunks        int    `json:"hunks,omitempty"`
+	Size         int64  `json:"size"`
+}
+
+// SandboxPort is a sandbox port exposed through a preview URL with
+// [Client.SandboxExposePort].
+type SandboxPort struct {
+	Port      int    `json:"port"`
+	CreatedAt string `json:"created_at"`
+
+	// URL and Token are only set in the response to
+	// [Client.SandboxExposePort]. The token in the URL is its only
+	// credential.
+	URL   string `json:"url,omitempty"`
+	Token string `json:"token,omitempty"`
+}
+
 // ToolDefinition is an LLM-compatible tool schema. Parameters uses
 // map[string]any to match the convention in most Go LLM libraries.
 type ToolDefinition struct {
@@ -233,6 +419,14 @@ type APIError struct {
 
 	// Message is a human-readable description of what went wrong.
 	Message string `json:"message"`
+
+	// Details carries structured information for some errors, such as the
+	// conflicts of a failed sandbox edit (see [EditConflicts]).
+	Details json.RawMessage `json:"details,omitempty"`
+
+	// RetryAfter is the delay requested by the server's Retry-After
+	// header, typically on 429 and 503 responses.
+	RetryAfter time.Duration `json:"-"`
 }
 
 // Error implements the error interface.
@@ -257,7 +451,8 @@ func (e *APIError) Error() string {
 // (useful for local development without auth enabled).
 //
 // The returned client uses [http.DefaultClient] unless overridden with
-// [WithHTTPClient].
+// [WithHTTPClient], and retries with [DefaultRetryPolicy] unless
+// overridden with [WithRetry].
 func New(baseURL, apiKey string, opts ...Option) *Client {
 	if apiKey == "" {
 		apiKey = os.Getenv("SKILLBOX_API_KEY")
@@ -267,6 +462,7 @@ func New(baseURL, apiKey string, opts ...Option) *Client {
 		baseURL:    strings.TrimRight(baseURL, "/"),
 		apiKey:     apiKey,
 		httpClient: http.DefaultClient,
+		retry:      DefaultRetryPolicy,
 	}
 
 	for _, opt := range opts {
@@ -336,6 +532,59 @@ func (c *Client) GetExecution(ctx context.Context, id string) (*RunResult, error
 	return &result, nil
 }
 
+// ListExecutions returns the tenant's executions, newest first, optionally
+// narrowed to one skill or status. The server returns 20 executions per
+// page unless filter.Limit is set (maximum 100).
+func (c *Client) ListExecutions(ctx context.Context, filter ExecutionFilter) ([]Execution, error) {
+	params := url.Values{}
+	if filter.Skill != "" {
+		params.Set("skill", filter.Skill)
+	}
+	if filter.Status != "" {
+		params.Set("status", filter.Status)
+	}
+	if filter.Limit > 0 {
+		params.Set("limit", fmt.Sprintf("%d", filter.Limit))
+	}
+	if filter.Offset > 0 {
+		params.Set("offset", fmt.Sprintf("%d", filter.Offset))
+	}
+
+	path := "/v1/executions"
+	if encoded := params.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var execs []Execution
+	if err := c.decodeResponse(resp, &execs); err != nil {
+		return nil, err
+	}
+	return execs, nil
+}
+
+// CancelExecution asks the server to stop a running execution. The
+// execution ends with status "cancelled" shortly after. An execution that
+// has already finished, or that runs on another server replica, yields an
+// error matching [ErrConflict].
+func (c *Client) CancelExecution(ctx context.Context, id string) error {
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v1/executions/"+id+"/cancel", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return c.parseAPIError(resp)
+	}
+	return nil
+}
+
 // GetExecutionLogs returns the combined stdout/stderr logs for an execution.
 func (c *Client) GetExecutionLogs(ctx context.Context, id string) (string, error) {
 	resp, err := c.doRequest(ctx, http.MethodGet, "/v1/executions/"+id+"/logs", nil)
@@ -399,7 +648,7 @@ func (c *Client) RegisterSkill(ctx context.Context, zipPath string) error {
 	c.setHeaders(req)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return fmt.Errorf("skillbox: register skill: %w", err)
 	}
@@ -603,6 +852,11 @@ type SkillVersionInfo struct {
 	UploadedAt   time.Time         `json:"uploaded_at"`
 	ScanSummary  string            `json:"scan_summary,omitempty"`
 	ScanFindings []ScanFindingInfo `json:"scan_findings,omitempty"`
+
+	// TestResults holds the outcome of the test cases the version declares
+	// under tests/. Nil when it declares none or they have not run yet. A
+	// version whose cases fail has Status "test_failed".
+	TestResults *SkillTestReport `json:"test_results,omitempty"`
 }
 
 // ScanFindingInfo is the reviewer-facing subset of a security scan finding.
@@ -615,6 +869,24 @@ type ScanFindingInfo struct {
 	Remediation string `json:"remediation,omitempty"`
 }
 
+// SkillTestReport is the result of running a skill version's test cases.
+type SkillTestReport struct {
+	Passed int               `json:"passed"`
+	Failed int               `json:"failed"`
+	Cases  []SkillTestResult `json:"cases"`
+	// Error is set when the cases could not be run at all.
+	Error string `json:"error,omitempty"`
+}
+
+// SkillTestResult is the outcome of one test case.
+type SkillTestResult struct {
+	Name       string `json:"name"`
+	Passed     bool   `json:"passed"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
 // PutSkillFile replaces or adds a single file in the skill's active version.
 // The edit creates a new derived version that the server scans before it can
 // become active. The server responds with 202 Accepted.
@@ -805,7 +1077,7 @@ func (c *Client) DownloadFiles(ctx context.Context, result *RunResult, destDir s
 		return fmt.Errorf("skillbox: create download request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return fmt.Errorf("skillbox: download files: %w", err)
 	}
@@ -948,7 +1220,7 @@ func (c *Client) UpdateFile(ctx context.Context, id, filePath string) (*FileInfo
 	c.setHeaders(req)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, fmt.Errorf("skillbox: update file: %w", err)
 	}
@@ -1018,7 +1290,7 @@ func (c *Client) UploadFile(ctx context.Context, filePath string) (*FileInfo, er
 	c.setHeaders(req)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, fmt.Errorf("skillbox: upload file: %w", err)
 	}
@@ -1063,7 +1335,7 @@ func (c *Client) UploadFileFromReader(ctx context.Context, filename string, r io
 	c.setHeaders(req)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, fmt.Errorf("skillbox: upload file: %w", err)
 	}
@@ -1163,6 +1435,97 @@ func (c *Client) DeleteSession(ctx context.Context, sessionID string) error {
 	return nil
 }
 
+// CreateSnapshot captures the session workspace as a named snapshot.
+// Reusing a name creates a new version of it.
+func (c *Client) CreateSnapshot(ctx context.Context, sessionID, name string) (*Snapshot, error) {
+	body, _ := json.Marshal(struct {
+		Name string `json:"name"`
+	}{Name: name})
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v1/sessions/"+sessionID+"/snapshots", strings.NewReader(string(body)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var snap Snapshot
+	if err := c.decodeResponse(resp, &snap); err != nil {
+		return nil, err
+	}
+	return &snap, nil
+}
+
+// ListSnapshots returns the snapshots of a session, newest first.
+func (c *Client) ListSnapshots(ctx context.Context, sessionID string) ([]Snapshot, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v1/sessions/"+sessionID+"/snapshots", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var snaps []Snapshot
+	if err := c.decodeResponse(resp, &snaps); err != nil {
+		return nil, err
+	}
+	return snaps, nil
+}
+
+// DeleteSnapshot removes a snapshot of a session.
+func (c *Client) DeleteSnapshot(ctx context.Context, sessionID, snapshotID string) error {
+	resp, err := c.doRequest(ctx, http.MethodDelete, "/v1/sessions/"+sessionID+"/snapshots/"+snapshotID, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return c.parseAPIError(resp)
+	}
+	return nil
+}
+
+// RestoreSnapshot replaces the session workspace with a snapshot's contents,
+// including the files of a running sandbox.
+func (c *Client) RestoreSnapshot(ctx context.Context, sessionID, snapshotID string) (*Snapshot, error) {
+	body, _ := json.Marshal(struct {
+		SnapshotID string `json:"snapshot_id"`
+	}{SnapshotID: snapshotID})
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v1/sessions/"+sessionID+"/restore", strings.NewReader(string(body)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var snap Snapshot
+	if err := c.decodeResponse(resp, &snap); err != nil {
+		return nil, err
+	}
+	return &snap, nil
+}
+
+// ForkSession creates a new session whose workspace starts from a snapshot
+// of sessionID. If newSessionID is empty the server generates one; the
+// returned Session's ExternalID is the ID to use for the fork.
+func (c *Client) ForkSession(ctx context.Context, sessionID, snapshotID, newSessionID string) (*Session, error) {
+	body, _ := json.Marshal(struct {
+		SnapshotID string `json:"snapshot_id"`
+		SessionID  string `json:"session_id,omitempty"`
+	}{SnapshotID: snapshotID, SessionID: newSessionID})
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v1/sessions/"+sessionID+"/fork", strings.NewReader(string(body)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var sess Session
+	if err := c.decodeResponse(resp, &sess); err != nil {
+		return nil, err
+	}
+	return &sess, nil
+}
+
 // --------------------------------------------------------------------
 // Sandbox Shell
 // --------------------------------------------------------------------
@@ -1334,12 +1697,259 @@ func (c *Client) SandboxDestroy(ctx context.Context, sessionID string) error {
 	return nil
 }
 
+// SandboxStartProcess starts a command in the background inside the
+// session's sandbox and returns immediately. workdir defaults to
+// /sandbox/session when empty.
+func (c *Client) SandboxStartProcess(ctx context.Context, sessionID, command, workdir string) (*SandboxProcess, error) {
+	body, _ := json.Marshal(struct {
+		Command string `json:"command"`
+		WorkDir string `json:"workdir,omitempty"`
+	}{Command: command, WorkDir: workdir})
+
+	resp, err := c.doSessionRequest(ctx, http.MethodPost, "/v1/sandbox/processes", sessionID, strings.NewReader(string(body)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var result SandboxProcess
+	if err := c.decodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SandboxListProcesses lists the background processes started in the
+// session's sandbox, including those that have exited.
+func (c *Client) SandboxListProcesses(ctx context.Context, sessionID string) ([]SandboxProcess, error) {
+	resp, err := c.doSessionRequest(ctx, http.MethodGet, "/v1/sandbox/processes", sessionID, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var result []SandboxProcess
+	if err := c.decodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SandboxGetProcess returns the current state of a background process.
+func (c *Client) SandboxGetProcess(ctx context.Context, sessionID, processID string) (*SandboxProcess, error) {
+	resp, err := c.doSessionRequest(ctx, http.MethodGet, "/v1/sandbox/processes/"+url.PathEscape(processID), sessionID, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var result SandboxProcess
+	if err := c.decodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SandboxProcessOutput reads up to limit bytes of a background process's
+// output starting at byte offset. limit 0 uses the server default of 64 KiB.
+func (c *Client) SandboxProcessOutput(ctx context.Context, sessionID, processID string, offset int64, limit int) (*SandboxProcessOutput, error) {
+	q := url.Values{"offset": {fmt.Sprint(offset)}}
+	if limit > 0 {
+		q.Set("limit", fmt.Sprint(limit))
+	}
+	path := "/v1/sandbox/processes/" + url.PathEscape(processID) + "/output?" + q.Encode()
+
+	resp, err := c.doSessionRequest(ctx, http.MethodGet, path, sessionID, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var result SandboxProcessOutput
+	if err := c.decodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SandboxSignalProcess sends a signal such as "TERM", "INT" or "KILL" to a
+// background process and the processes it started.
+func (c *Client) SandboxSignalProcess(ctx context.Context, sessionID, processID, signal string) error {
+	body, _ := json.Marshal(struct {
+		Signal string `json:"signal"`
+	}{Signal: signal})
+
+	resp, err := c.doSessionRequest(ctx, http.MethodPost, "/v1/sandbox/processes/"+url.PathEscape(processID)+"/signal", sessionID, strings.NewReader(string(body)))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return c.parseAPIError(resp)
+	}
+	return nil
+}
+
+// SandboxWaitProcess blocks until a background process exits or timeout
+// elapses, whichever comes first, and returns its state. The server caps
+// timeout at 5 minutes; 0 uses its default of 30 seconds.
+func (c *Client) SandboxWaitProcess(ctx context.Context, sessionID, processID string, timeout time.Duration) (*SandboxProcess, error) {
+	body, _ := json.Marshal(struct {
+		TimeoutMs int64 `json:"timeout_ms,omitempty"`
+	}{TimeoutMs: timeout.Milliseconds()})
+
+	resp, err := c.doSessionRequest(ctx, http.MethodPost, "/v1/sandbox/processes/"+url.PathEscape(processID)+"/wait", sessionID, strings.NewReader(string(body)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var result SandboxProcess
+	if err := c.decodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SandboxSearch searches the files of the session's sandbox by content
+// or, when req.Query is empty, by name.
+func (c *Client) SandboxSearch(ctx context.Context, sessionID string, req SandboxSearchRequest) (*SandboxSearchResult, error) {
+	body, _ := json.Marshal(req)
+
+	resp, err := c.doSessionRequest(ctx, http.MethodPost, "/v1/sandbox/search", sessionID, strings.NewReader(string(body)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var result SandboxSearchResult
+	if err := c.decodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SandboxEditFile applies exact string replacements, in order, to a file
+// in the session's sandbox. If any replacement does not apply the file is
+// left unchanged; use [EditConflicts] to see why.
+func (c *Client) SandboxEditFile(ctx context.Context, sessionID, path string, edits []SandboxEditReplacement) (*SandboxFileEdit, error) {
+	files, err := c.sandboxEdit(ctx, sessionID, struct {
+		Path  string                   `json:"path"`
+		Edits []SandboxEditReplacement `json:"edits"`
+	}{Path: path, Edits: edits})
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("skillbox: edit response has no files")
+	}
+	return &files[0], nil
+}
+
+// SandboxApplyPatch applies a unified diff to files in the session's
+// sandbox. Relative paths in the diff headers, including git's a/ and b/
+// prefixes, are resolved against /sandbox/session. If path is non-empty
+// the diff must cover a single file and is applied to path instead. If
+// any hunk does not apply no file is changed; use [EditConflicts] to see
+// why.
+func (c *Client) SandboxApplyPatch(ctx context.Context, sessionID, diff, path string) ([]SandboxFileEdit, error) {
+	return c.sandboxEdit(ctx, sessionID, struct {
+		Path string `json:"path,omitempty"`
+		Diff string `json:"diff"`
+	}{Path: path, Diff: diff})
+}
+
+func (c *Client) sandboxEdit(ctx context.Context, sessionID string, req any) ([]SandboxFileEdit, error) {
+	body, _ := json.Marshal(req)
+
+	resp, err := c.doSessionRequest(ctx, http.MethodPost, "/v1/sandbox/edit", sessionID, strings.NewReader(string(body)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var result struct {
+		Files []SandboxFileEdit `json:"files"`
+	}
+	if err := c.decodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return result.Files, nil
+}
+
+// EditConflicts returns the conflicts reported by a failed
+// [Client.SandboxEditFile] or [Client.SandboxApplyPatch] call, or nil if
+// err is not an edit conflict.
+func EditConflicts(err error) []SandboxEditConflict {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode != "edit_conflict" {
+		return nil
+	}
+	var conflicts []SandboxEditConflict
+	_ = json.Unmarshal(apiErr.Details, &conflicts)
+	return conflicts
+}
+
+// SandboxExposePort makes a port inside the session's sandbox reachable
+// through a preview URL, e.g. for a dev server started with
+// [Client.SandboxStartProcess]. Exposing a port again issues a new URL and
+// invalidates the old one.
+func (c *Client) SandboxExposePort(ctx context.Context, sessionID string, port int) (*SandboxPort, error) {
+	body, _ := json.Marshal(struct {
+		Port int `json:"port"`
+	}{Port: port})
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v1/sandbox/"+url.PathEscape(sessionID)+"/ports", strings.NewReader(string(body)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var result SandboxPort
+	if err := c.decodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SandboxListPorts lists the ports exposed for a session. Their preview
+// URLs are not included.
+func (c *Client) SandboxListPorts(ctx context.Context, sessionID string) ([]SandboxPort, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v1/sandbox/"+url.PathEscape(sessionID)+"/ports", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var result []SandboxPort
+	if err := c.decodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SandboxUnexposePort revokes a port's preview URL.
+func (c *Client) SandboxUnexposePort(ctx context.Context, sessionID string, port int) error {
+	resp, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/v1/sandbox/%s/ports/%d", url.PathEscape(sessionID), port), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return c.parseAPIError(resp)
+	}
+	return nil
+}
+
 // --------------------------------------------------------------------
 // Internal helpers
 // --------------------------------------------------------------------
 
 // doRequest builds and executes an HTTP request against the Skillbox API.
-// It sets authentication, tenant, and content-type headers automatically.
+// It sets authentication, tenant, and content-type headers automatically
+// and retries according to the client's [RetryPolicy].
 func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
 	url := c.baseURL + path
 
@@ -1353,7 +1963,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, fmt.Errorf("skillbox: %s %s: %w", method, path, err)
 	}
@@ -1377,7 +1987,7 @@ func (c *Client) doSessionRequest(ctx context.Context, method, path, sessionID s
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, fmt.Errorf("skillbox: %s %s: %w", method, path, err)
 	}
@@ -1423,6 +2033,7 @@ func (c *Client) parseAPIError(resp *http.Response) error {
 		apiErr.Message = strings.TrimSpace(string(data))
 	}
 	apiErr.StatusCode = resp.StatusCode
+	apiErr.RetryAfter = retryAfter(resp)
 
 	return apiErr
 }
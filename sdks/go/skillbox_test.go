@@ -388,6 +388,53 @@ func TestGetExecution(t *testing.T) {
 	}
 }
 
+func TestListExecutions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/executions" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("skill") != "pdf" || q.Get("status") != "failed" || q.Get("limit") != "5" {
+			t.Errorf("query = %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"execution_id":"e1","skill_name":"pdf","status":"failed","error":"boom","created_at":"2026-01-02T03:04:05Z"},{"execution_id":"e2","skill_name":"pdf","status":"failed","error":null}]`))
+	}))
+	defer srv.Close() //nolint:errcheck
+
+	execs, err := New(srv.URL, "sk-test").ListExecutions(context.Background(), ExecutionFilter{Skill: "pdf", Status: "failed", Limit: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(execs) != 2 || execs[0].ExecutionID != "e1" || execs[0].Error != "boom" || execs[1].Error != "" {
+		t.Errorf("executions = %+v", execs)
+	}
+}
+
+func TestCancelExecution(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path == "/v1/executions/done/cancel" {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error":"not_running","message":"execution is not running"}`))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"execution_id":"e1","status":"cancelling"}`))
+	}))
+	defer srv.Close() //nolint:errcheck
+
+	client := New(srv.URL, "sk-test")
+	if err := client.CancelExecution(context.Background(), "e1"); err != nil {
+		t.Fatalf("CancelExecution: %v", err)
+	}
+	if err := client.CancelExecution(context.Background(), "done"); !errors.Is(err, ErrConflict) {
+		t.Errorf("err = %v, want ErrConflict", err)
+	}
+}
+
 // --------------------------------------------------------------------
 // TestGetExecutionLogs
 // --------------------------------------------------------------------
@@ -1448,3 +1495,107 @@ func TestListSkillVersions_Success(t *testing.T) {
 		t.Errorf("versions[0] = %+v, want 1.0.1 active", versions[0])
 	}
 }
+
+func TestListSkillVersions_TestResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"version":"1.1.0","status":"test_failed","uploaded_at":"2026-05-29T00:00:00Z","test_results":{"passed":1,"failed":1,"cases":[{"name":"basic","passed":true,"status":"success","duration_ms":40},{"name":"csv","passed":false,"status":"success","error":"$.rows: expected 3, got 2","duration_ms":52}]}}]`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL, "test-key")
+	versions, err := client.ListSkillVersions(context.Background(), "demo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tr := versions[0].TestResults
+	if versions[0].Status != "test_failed" || tr == nil || tr.Failed != 1 || len(tr.Cases) != 2 {
+		t.Fatalf("versions[0] = %+v, want test_failed with 2 case results", versions[0])
+	}
+	if tr.Cases[1].Name != "csv" || tr.Cases[1].Error == "" {
+		t.Errorf("Cases[1] = %+v, want failing csv case with error", tr.Cases[1])
+	}
+}
+
+func TestCreateSnapshot_Success(t *testing.T) {
+	var gotMethod, gotPath, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"snap-1","session_id":"sess-uuid","name":"baseline","version":2,"file_count":4}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL, "test-key")
+	snap, err := client.CreateSnapshot(context.Background(), "sess-1", "baseline")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotMethod != http.MethodPost || gotPath != "/v1/sessions/sess-1/snapshots" {
+		t.Errorf("got %s %s, want POST /v1/sessions/sess-1/snapshots", gotMethod, gotPath)
+	}
+	if !strings.Contains(gotBody, `"name":"baseline"`) {
+		t.Errorf("body = %q, missing name", gotBody)
+	}
+	if snap.ID != "snap-1" || snap.Version != 2 || snap.FileCount != 4 {
+		t.Errorf("snapshot = %+v, want snap-1 v2 with 4 files", snap)
+	}
+}
+
+func TestForkSession_Success(t *testing.T) {
+	var gotPath, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"sess-uuid-2","external_id":"sess-1-alt"}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL, "test-key")
+	sess, err := client.ForkSession(context.Background(), "sess-1", "snap-1", "sess-1-alt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/v1/sessions/sess-1/fork" {
+		t.Errorf("path = %q, want /v1/sessions/sess-1/fork", gotPath)
+	}
+	if !strings.Contains(gotBody, `"snapshot_id":"snap-1"`) || !strings.Contains(gotBody, `"session_id":"sess-1-alt"`) {
+		t.Errorf("body = %q, missing snapshot_id or session_id", gotBody)
+	}
+	if sess.ExternalID != "sess-1-alt" {
+		t.Errorf("ExternalID = %q, want sess-1-alt", sess.ExternalID)
+	}
+}
+
+func TestSandboxExposePort_Success(t *testing.T) {
+	var gotMethod, gotPath, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"pp-1","port":3000,"url":"https://skillbox.example.com/preview/abc/","token":"abc"}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL, "test-key")
+	port, err := client.SandboxExposePort(context.Background(), "sess-1", 3000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotMethod != http.MethodPost || gotPath != "/v1/sandbox/sess-1/ports" {
+		t.Errorf("got %s %s, want POST /v1/sandbox/sess-1/ports", gotMethod, gotPath)
+	}
+	if gotBody != `{"port":3000}` {
+		t.Errorf("body = %q", gotBody)
+	}
+	if port.URL != "https://skillbox.example.com/preview/abc/" || port.Token != "abc" {
+		t.Errorf("port = %+v", port)
+	}
+}
@@ -0,0 +1,464 @@
+package skillboxtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	skillbox "github.com/devs-group/skillbox/sdks/go"
+)
+
+// searchSkipDirs are never searched below the search root, as on the
+// server.
+var searchSkipDirs = map[string]bool{".git": true, "node_modules": true, "__pycache__": true}
+
+func (s *Server) sandboxSearch(w http.ResponseWriter, r *http.Request) {
+	var req skillbox.SandboxSearchRequest
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sandboxSession(w, r)
+	if !ok || !readJSON(w, r, &req) {
+		return
+	}
+	if req.Path == "" {
+		req.Path = sessionDir
+	}
+	if err := validatePath(req.Path); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "invalid path: "+err.Error())
+		return
+	}
+	if req.MaxResults <= 0 {
+		req.MaxResults = 100
+	}
+	req.MaxResults = min(req.MaxResults, 1000)
+	req.ContextLines = max(0, min(req.ContextLines, 5))
+
+	var re *regexp.Regexp
+	if req.Query != "" {
+		expr := req.Query
+		if !req.Regex {
+			expr = regexp.QuoteMeta(expr)
+		}
+		if req.IgnoreCase {
+			expr = "(?i)" + expr
+		}
+		var err error
+		if re, err = regexp.Compile(expr); err != nil {
+			writeError(w, http.StatusBadRequest, "bad_request", "invalid search pattern: "+err.Error())
+			return
+		}
+	}
+	if _, err := path.Match(req.Glob, ""); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "invalid search pattern: glob: "+err.Error())
+		return
+	}
+
+	root := path.Clean(req.Path)
+	result := skillbox.SandboxSearchResult{Matches: []skillbox.SandboxSearchMatch{}}
+	add := func(m skillbox.SandboxSearchMatch) bool {
+		if len(result.Matches) == req.MaxResults {
+			result.Truncated = true
+			return false
+		}
+		result.Matches = append(result.Matches, m)
+		return true
+	}
+files:
+	for _, p := range sortedKeys(sess.fs) {
+		rel, ok := strings.CutPrefix(p, root+"/")
+		if !ok {
+			continue
+		}
+		dirs := strings.Split(rel, "/")
+		for _, d := range dirs[:len(dirs)-1] {
+			if searchSkipDirs[d] {
+				continue files
+			}
+		}
+		if req.Glob != "" {
+			name := rel
+			if !strings.Contains(req.Glob, "/") {
+				name = path.Base(rel)
+			}
+			if ok, _ := path.Match(req.Glob, name); !ok {
+				continue
+			}
+		}
+		if re == nil {
+			if !add(skillbox.SandboxSearchMatch{Path: p}) {
+				break
+			}
+			continue
+		}
+
+		data := sess.fs[p]
+		if bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0 {
+			continue // binary
+		}
+		lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+		for i, line := range lines {
+			if !re.MatchString(line) {
+				continue
+			}
+			from, to := max(0, i-req.ContextLines), min(len(lines), i+req.ContextLines+1)
+			if !add(skillbox.SandboxSearchMatch{Path: p, Line: i + 1, Snippet: strings.Join(lines[from:to], "\n")}) {
+				break files
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// errInvalidPatch marks diffs that cannot be parsed.
+var errInvalidPatch = errors.New("invalid patch")
+
+// sandboxEdit applies string replacements to one file or a unified diff
+// to several. Edits are all-or-nothing: on any conflict nothing is written
+// and the conflicts are returned as details of a 409 edit_conflict.
+func (s *Server) sandboxEdit(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Path  string                            `json:"path"`
+		Edits []skillbox.SandboxEditReplacement `json:"edits"`
+		Diff  string                            `json:"diff"`
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sandboxSession(w, r)
+	if !ok || !readJSON(w, r, &req) {
+		return
+	}
+	if (len(req.Edits) == 0) == (req.Diff == "") {
+		writeError(w, http.StatusBadRequest, "bad_request", "exactly one of edits or diff is required")
+		return
+	}
+	if req.Path != "" {
+		if err := validatePath(req.Path); err != nil {
+			writeError(w, http.StatusBadRequest, "bad_request", "invalid path: "+err.Error())
+			return
+		}
+		req.Path = path.Clean(req.Path)
+	}
+
+	var (
+		files     []skillbox.SandboxFileEdit
+		contents  = make(map[string]string)
+		conflicts []skillbox.SandboxEditConflict
+	)
+	switch {
+	case req.Diff != "":
+		patches, err := parseUnifiedDiff(req.Diff)
+		if err == nil && req.Path != "" {
+			if len(patches) != 1 {
+				err = fmt.Errorf("%w: path is set but the diff covers %d files", errInvalidPatch, len(patches))
+			} else {
+				patches[0].path = req.Path
+			}
+		}
+		for _, p := range patches {
+			if err == nil {
+				err = validatePath(p.path)
+			}
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+			return
+		}
+
+		index := make(map[string]int)
+		for _, p := range patches {
+			content, seen := contents[p.path]
+			if !seen {
+				data, exists := sess.fs[p.path]
+				switch {
+				case p.create && exists && len(data) > 0:
+					conflicts = append(conflicts, skillbox.SandboxEditConflict{Path: p.path, Reason: "file already exists"})
+					continue
+				case !p.create && !exists:
+					writeError(w, http.StatusBadRequest, "edit_error", "failed to edit file: file not found: "+p.path)
+					return
+				}
+				content = string(data)
+				index[p.path] = len(files)
+				files = append(files, skillbox.SandboxFileEdit{Path: p.path, Created: p.create})
+			}
+			updated, fileConflicts := applyHunks(content, p.hunks)
+			for i := range fileConflicts {
+				fileConflicts[i].Path = p.path
+			}
+			conflicts = append(conflicts, fileConflicts...)
+			contents[p.path] = updated
+			files[index[p.path]].Hunks += len(p.hunks)
+		}
+	case req.Path == "":
+		writeError(w, http.StatusBadRequest, "bad_request", "path is required with edits")
+		return
+	default:
+		data, ok := sess.fs[req.Path]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "edit_error", "failed to edit file: file not found: "+req.Path)
+			return
+		}
+		content, n, editConflicts := applyReplacements(string(data), req.Edits)
+		for i := range editConflicts {
+			editConflicts[i].Path = req.Path
+		}
+		conflicts = editConflicts
+		contents[req.Path] = content
+		files = []skillbox.SandboxFileEdit{{Path: req.Path, Replacements: n}}
+	}
+
+	if len(conflicts) > 0 {
+		msg := fmt.Sprintf("%d edit conflicts", len(conflicts))
+		if len(conflicts) == 1 {
+			msg = fmt.Sprintf("edit conflict in %s: %s", conflicts[0].Path, conflicts[0].Reason)
+		}
+		details, _ := json.Marshal(conflicts)
+		writeJSON(w, http.StatusConflict, skillbox.APIError{ErrorCode: "edit_conflict", Message: msg, Details: details})
+		return
+	}
+	for i, f := range files {
+		sess.fs[f.Path] = []byte(contents[f.Path])
+		files[i].Size = int64(len(contents[f.Path]))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"files": files})
+}
+
+// applyReplacements applies edits to content in order, skipping and
+// reporting those that do not apply.
+func applyReplacements(content string, edits []skillbox.SandboxEditReplacement) (string, int, []skillbox.SandboxEditConflict) {
+	var conflicts []skillbox.SandboxEditConflict
+	total := 0
+	for i, e := range edits {
+		reason := ""
+		count := strings.Count(content, e.OldString)
+		switch {
+		case e.OldString == "":
+			reason = "old_string is empty"
+		case e.OldString == e.NewString:
+			reason = "old_string and new_string are identical"
+		case count == 0:
+			reason = "old_string not found"
+		case count > 1 && !e.ReplaceAll:
+			reason = fmt.Sprintf("old_string matches %d times; include more surrounding context or set replace_all", count)
+		}
+		if reason != "" {
+			conflicts = append(conflicts, skillbox.SandboxEditConflict{Edit: i + 1, Reason: reason})
+			continue
+		}
+		content = strings.ReplaceAll(content, e.OldString, e.NewString)
+		total += count
+	}
+	return content, total, conflicts
+}
+
+type filePatch struct {
+	path   string
+	create bool
+	hunks  []hunk
+}
+
+type hunk struct {
+	oldStart           int
+	lines              []hunkLine
+	oldNoEOL, newNoEOL bool
+}
+
+type hunkLine struct {
+	op   byte // ' ', '-' or '+'
+	text string
+}
+
+// parseUnifiedDiff splits a unified diff into per-file patches. Like the
+// server it ignores hunk line counts; a hunk ends at the next header.
+func parseUnifiedDiff(diff string) ([]filePatch, error) {
+	lines := strings.Split(strings.ReplaceAll(diff, "\r\n", "\n"), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	var patches []filePatch
+	var cur *filePatch
+	var h *hunk
+	var last byte
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ "):
+			p, err := newFilePatch(line[4:], lines[i+1][4:])
+			if err != nil {
+				return nil, err
+			}
+			patches = append(patches, p)
+			cur, h = &patches[len(patches)-1], nil
+			i++
+		case strings.HasPrefix(line, "@@"):
+			if cur == nil {
+				return nil, fmt.Errorf("%w: hunk before file header on line %d", errInvalidPatch, i+1)
+			}
+			start, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", errInvalidPatch, i+1, err)
+			}
+			cur.hunks = append(cur.hunks, hunk{oldStart: start})
+			h = &cur.hunks[len(cur.hunks)-1]
+		case h == nil:
+			// Preamble such as "diff --git" or "index" lines.
+		case strings.HasPrefix(line, `\`):
+			h.oldNoEOL = h.oldNoEOL || last == ' ' || last == '-'
+			h.newNoEOL = h.newNoEOL || last == ' ' || last == '+'
+		case strings.HasPrefix(line, "diff "):
+			h = nil
+		case line == "":
+			h.lines = append(h.lines, hunkLine{op: ' '})
+			last = ' '
+		case line[0] == ' ' || line[0] == '-' || line[0] == '+':
+			h.lines = append(h.lines, hunkLine{op: line[0], text: line[1:]})
+			last = line[0]
+		default:
+			return nil, fmt.Errorf("%w: unexpected line %d: %q", errInvalidPatch, i+1, line)
+		}
+	}
+
+	if len(patches) == 0 {
+		return nil, fmt.Errorf("%w: no file headers found", errInvalidPatch)
+	}
+	for _, p := range patches {
+		if len(p.hunks) == 0 {
+			return nil, fmt.Errorf("%w: no hunks for %s", errInvalidPatch, p.path)
+		}
+	}
+	return patches, nil
+}
+
+// newFilePatch resolves the target of a diff from its --- and +++ header
+// values.
+func newFilePatch(oldName, newName string) (filePatch, error) {
+	trim := func(s string) string {
+		if i := strings.IndexByte(s, '\t'); i >= 0 {
+			s = s[:i]
+		}
+		return strings.TrimSpace(s)
+	}
+	oldName, newName = trim(oldName), trim(newName)
+	if newName == "/dev/null" {
+		return filePatch{}, fmt.Errorf("%w: deleting %s is not supported", errInvalidPatch, oldName)
+	}
+	if (strings.HasPrefix(oldName, "a/") || oldName == "/dev/null") && strings.HasPrefix(newName, "b/") {
+		newName = newName[2:]
+	}
+	return filePatch{path: sandboxPath(newName), create: oldName == "/dev/null"}, nil
+}
+
+// parseHunkHeader returns the old-side start line of "@@ -l,s +l,s @@".
+func parseHunkHeader(line string) (int, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || !strings.HasPrefix(fields[1], "-") {
+		return 0, fmt.Errorf("malformed hunk header %q", line)
+	}
+	start, _, _ := strings.Cut(fields[1][1:], ",")
+	n, err := strconv.Atoi(start)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("malformed hunk header %q", line)
+	}
+	return n, nil
+}
+
+// applyHunks applies hunks in order, placing each at its stated line or
+// else at the nearest later position where its old lines match.
+func applyHunks(content string, hunks []hunk) (string, []skillbox.SandboxEditConflict) {
+	eol := content == "" || strings.HasSuffix(content, "\n")
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	if content == "" {
+		lines = nil
+	}
+
+	var conflicts []skillbox.SandboxEditConflict
+	offset, floor := 0, 0
+	for i, h := range hunks {
+		var oldLines, newLines []string
+		for _, l := range h.lines {
+			if l.op != '+' {
+				oldLines = append(oldLines, l.text)
+			}
+			if l.op != '-' {
+				newLines = append(newLines, l.text)
+			}
+		}
+
+		want := max(h.oldStart-1, 0) + offset
+		if len(oldLines) == 0 && h.oldStart > 0 {
+			want = h.oldStart + offset
+		}
+		at := findHunk(lines, oldLines, want, floor)
+		if at < 0 {
+			conflicts = append(conflicts, skillbox.SandboxEditConflict{Hunk: i + 1, Line: h.oldStart, Reason: hunkMismatch(lines, oldLines, want)})
+			continue
+		}
+
+		lines = append(lines[:at], append(newLines, lines[at+len(oldLines):]...)...)
+		offset += len(newLines) - len(oldLines)
+		floor = at + len(newLines)
+		switch {
+		case h.newNoEOL:
+			eol = false
+		case h.oldNoEOL:
+			eol = true
+		}
+	}
+
+	out := strings.Join(lines, "\n")
+	if eol && len(lines) > 0 {
+		out += "\n"
+	}
+	return out, conflicts
+}
+
+// findHunk returns the index at which old occurs in lines, searching
+// outward from want but never before floor, first exactly and then
+// ignoring trailing whitespace, or -1.
+func findHunk(lines, old []string, want, floor int) int {
+	want = max(min(want, len(lines)), floor)
+	for _, eq := range []func(a, b string) bool{
+		func(a, b string) bool { return a == b },
+		func(a, b string) bool { return strings.TrimRight(a, " \t\r") == strings.TrimRight(b, " \t\r") },
+	} {
+		for d := 0; want-d >= floor || want+d <= len(lines); d++ {
+			for _, at := range []int{want - d, want + d} {
+				if at < floor || at+len(old) > len(lines) {
+					continue
+				}
+				match := true
+				for j := range old {
+					match = match && eq(lines[at+j], old[j])
+				}
+				if match {
+					return at
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// hunkMismatch describes the first line where old differs from lines at
+// want.
+func hunkMismatch(lines, old []string, want int) string {
+	for i, o := range old {
+		n := want + i
+		if n >= len(lines) {
+			return fmt.Sprintf("context does not match: expected %q at line %d, found end of file", o, n+1)
+		}
+		if lines[n] != o {
+			return fmt.Sprintf("context does not match: expected %q at line %d, found %q", o, n+1, lines[n])
+		}
+	}
+	return "context does not match"
+}
@@ -0,0 +1,259 @@
+package skillboxtest
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	skillbox "github.com/devs-group/skillbox/sdks/go"
+)
+
+// storedFile is a file record and its content.
+type storedFile struct {
+	info skillbox.FileInfo
+	data []byte
+	seq  int // insertion order, for newest-first listings
+}
+
+// AddFile stores a file as if it had been uploaded with
+// [skillbox.Client.UploadFile] and returns its record.
+func (s *Server) AddFile(name string, data []byte) skillbox.FileInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addFile(name, data, "", "")
+}
+
+// FileContent returns the content of a stored file.
+func (s *Server) FileContent(id string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f := s.files[id]
+	if f == nil {
+		return nil, false
+	}
+	return slices.Clone(f.data), true
+}
+
+// addFile stores a new file record. Callers must hold s.mu.
+func (s *Server) addFile(name string, data []byte, sessionID, executionID string) skillbox.FileInfo {
+	id := s.nextID("file")
+	ts := now()
+	f := &storedFile{
+		info: skillbox.FileInfo{
+			ID:          id,
+			TenantID:    "default",
+			SessionID:   sessionID,
+			ExecutionID: executionID,
+			Name:        name,
+			ContentType: contentType(name),
+			SizeBytes:   int64(len(data)),
+			S3Key:       "default/files/" + id + "/" + name,
+			Version:     1,
+			CreatedAt:   ts,
+			UpdatedAt:   ts,
+		},
+		data: slices.Clone(data),
+		seq:  s.ids["file"],
+	}
+	s.files[id] = f
+	return f.info
+}
+
+// --------------------------------------------------------------------
+// File endpoints
+// --------------------------------------------------------------------
+
+func (s *Server) fileRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/files", s.uploadFile)
+	mux.HandleFunc("GET /v1/files", s.listFiles)
+	mux.HandleFunc("GET /v1/files/{id}", s.getFile)
+	mux.HandleFunc("GET /v1/files/{id}/download", s.downloadFile)
+	mux.HandleFunc("PUT /v1/files/{id}", s.updateFile)
+	mux.HandleFunc("DELETE /v1/files/{id}", s.deleteFile)
+	mux.HandleFunc("GET /v1/files/{id}/versions", s.listFileVersions)
+}
+
+// readUpload reads the "file" field of a multipart upload.
+func readUpload(w http.ResponseWriter, r *http.Request) (name string, data []byte, ok bool) {
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "missing 'file' field in multipart form")
+		return "", nil, false
+	}
+	defer file.Close() //nolint:errcheck
+	data, err = io.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "failed to read upload: "+err.Error())
+		return "", nil, false
+	}
+	name = r.FormValue("name")
+	if name == "" {
+		name = header.Filename
+	}
+	return name, data, true
+}
+
+func (s *Server) uploadFile(w http.ResponseWriter, r *http.Request) {
+	name, data, ok := readUpload(w, r)
+	if !ok {
+		return
+	}
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "file name is required")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusCreated, s.addFile(name, data, "", ""))
+}
+
+func (s *Server) listFiles(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit, err := strconv.Atoi(q.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 50
+	}
+	limit = min(limit, 200)
+	offset, err := strconv.Atoi(q.Get("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matches []*storedFile
+	for _, f := range s.files {
+		if sid := q.Get("session_id"); sid != "" && f.info.SessionID != sid {
+			continue
+		}
+		if eid := q.Get("execution_id"); eid != "" && f.info.ExecutionID != eid {
+			continue
+		}
+		matches = append(matches, f)
+	}
+	slices.SortFunc(matches, func(a, b *storedFile) int { return b.seq - a.seq })
+
+	out := []skillbox.FileInfo{}
+	for _, f := range matches[min(offset, len(matches)):min(offset+limit, len(matches))] {
+		out = append(out, f.info)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// lookupFile finds a file record, responding with 404 Not Found if it
+// does not exist. Callers must hold s.mu.
+func (s *Server) lookupFile(w http.ResponseWriter, id string) (*storedFile, bool) {
+	f := s.files[id]
+	if f == nil {
+		writeError(w, http.StatusNotFound, "not_found", "file not found")
+		return nil, false
+	}
+	return f, true
+}
+
+func (s *Server) getFile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f, ok := s.lookupFile(w, r.PathValue("id")); ok {
+		writeJSON(w, http.StatusOK, f.info)
+	}
+}
+
+func (s *Server) downloadFile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, ok := s.lookupFile(w, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", f.info.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", f.info.Name))
+	_, _ = w.Write(f.data)
+}
+
+// updateFile stores new content as the next version of a file. Like the
+// server, versions share the ID of the first version as their parent.
+func (s *Server) updateFile(w http.ResponseWriter, r *http.Request) {
+	name, data, ok := readUpload(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, ok := s.lookupFile(w, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	root := prev.info.ID
+	if prev.info.ParentID != nil {
+		root = *prev.info.ParentID
+	}
+	if name == "" {
+		name = prev.info.Name
+	}
+	info := s.addFile(name, data, prev.info.SessionID, prev.info.ExecutionID)
+	f := s.files[info.ID]
+	f.info.Version = s.latestVersion(root) + 1
+	f.info.ParentID = &root
+	writeJSON(w, http.StatusOK, f.info)
+}
+
+// latestVersion returns the highest version number among a file and its
+// versions. Callers must hold s.mu.
+func (s *Server) latestVersion(root string) int {
+	latest := 0
+	for _, f := range s.files {
+		if f.info.ID == root || f.info.ParentID != nil && *f.info.ParentID == root {
+			latest = max(latest, f.info.Version)
+		}
+	}
+	return latest
+}
+
+func (s *Server) deleteFile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.lookupFile(w, r.PathValue("id")); !ok {
+		return
+	}
+	delete(s.files, r.PathValue("id"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listFileVersions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, ok := s.lookupFile(w, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	root := f.info.ID
+	if f.info.ParentID != nil {
+		root = *f.info.ParentID
+	}
+	out := []skillbox.FileInfo{}
+	for _, v := range s.files {
+		if v.info.ID == root || v.info.ParentID != nil && *v.info.ParentID == root {
+			out = append(out, v.info)
+		}
+	}
+	slices.SortFunc(out, func(a, b skillbox.FileInfo) int { return b.Version - a.Version })
+	writeJSON(w, http.StatusOK, out)
+}
+
+// contentType guesses a content type from a file name.
+func contentType(name string) string {
+	if ct := mime.TypeByExtension(path.Ext(name)); ct != "" {
+		return ct
+	}
+	if strings.HasSuffix(name, ".md") {
+		return "text/markdown"
+	}
+	return "application/octet-stream"
+}
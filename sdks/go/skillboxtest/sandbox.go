@@ -0,0 +1,582 @@
+package skillboxtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	skillbox "github.com/devs-group/skillbox/sdks/go"
+)
+
+// CommandHandler answers a sandbox command run with
+// [skillbox.Client.SandboxExecute] or started as a background process.
+// req.WorkDir and req.TimeoutMs are set to the server defaults if the
+// client left them empty. The handler may read and change the sandbox
+// with [Server.SandboxFile] and [Server.WriteSandboxFile].
+//
+// A returned *skillbox.APIError is sent as the API error response; any
+// other error is reported as a 500 execution_error.
+type CommandHandler func(ctx context.Context, sessionID string, req skillbox.SandboxExecRequest) (*skillbox.SandboxExecResponse, error)
+
+// HandleCommands sets the handler for sandbox commands. Without one,
+// every command succeeds with no output.
+func (s *Server) HandleCommands(h CommandHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.commands = h
+}
+
+// SandboxFile returns the content of a file in a session's sandbox, given
+// by absolute path or relative to /sandbox/session.
+func (s *Server) SandboxFile(sessionID, name string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess := s.sessions[sessionID]
+	if sess == nil {
+		return nil, false
+	}
+	data, ok := sess.fs[sandboxPath(name)]
+	return slices.Clone(data), ok
+}
+
+// WriteSandboxFile creates or replaces a file in a session's sandbox,
+// given by absolute path or relative to /sandbox/session.
+func (s *Server) WriteSandboxFile(sessionID, name string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sandboxFS(sessionID)[sandboxPath(name)] = slices.Clone(data)
+}
+
+func sandboxPath(name string) string {
+	if !path.IsAbs(name) {
+		name = sessionDir + "/" + name
+	}
+	return path.Clean(name)
+}
+
+// validatePath applies the server's rule for sandbox paths.
+func validatePath(p string) error {
+	if p == "" {
+		return errors.New("invalid sandbox path: path is empty")
+	}
+	cleaned := path.Clean(p)
+	if !strings.HasPrefix(cleaned, sessionDir) || strings.Contains(cleaned, "..") {
+		return fmt.Errorf("invalid sandbox path: must start with /sandbox/session and not contain '..': %q", p)
+	}
+	return nil
+}
+
+// process is a background process. Commands run to completion when they
+// are started, so processes are always exited.
+type process struct {
+	info   skillbox.SandboxProcess
+	output string
+}
+
+// --------------------------------------------------------------------
+// Sandbox endpoints
+// --------------------------------------------------------------------
+
+func (s *Server) sandboxRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/sandbox/execute", s.sandboxExecute)
+	mux.HandleFunc("POST /v1/sandbox/read-file", s.sandboxReadFile)
+	mux.HandleFunc("POST /v1/sandbox/download-file", s.sandboxDownloadFile)
+	mux.HandleFunc("POST /v1/sandbox/write-file", s.sandboxWriteFile)
+	mux.HandleFunc("POST /v1/sandbox/upload-skill", s.sandboxUploadSkill)
+	mux.HandleFunc("POST /v1/sandbox/list-dir", s.sandboxListDir)
+	mux.HandleFunc("POST /v1/sandbox/sync", s.sandboxSync)
+	mux.HandleFunc("POST /v1/sandbox/search", s.sandboxSearch)
+	mux.HandleFunc("POST /v1/sandbox/edit", s.sandboxEdit)
+	mux.HandleFunc("DELETE /v1/sandbox/{session}", s.sandboxDestroy)
+
+	mux.HandleFunc("POST /v1/sandbox/processes", s.startProcess)
+	mux.HandleFunc("GET /v1/sandbox/processes", s.listProcesses)
+	mux.HandleFunc("GET /v1/sandbox/processes/{id}/output", s.processOutput)
+	mux.HandleFunc("POST /v1/sandbox/processes/{id}/signal", s.signalProcess)
+	mux.HandleFunc("POST /v1/sandbox/processes/{id}/wait", s.waitProcess)
+
+	// GET /v1/sandbox/processes/{id} and GET /v1/sandbox/{session}/ports
+	// overlap as ServeMux patterns, so one pattern serves both.
+	mux.HandleFunc("GET /v1/sandbox/{a}/{b}", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.PathValue("a") == "processes":
+			s.getProcess(w, r, r.PathValue("b"))
+		case r.PathValue("b") == "ports":
+			s.listPorts(w, r, r.PathValue("a"))
+		default:
+			writeError(w, http.StatusNotFound, "not_found", "no such endpoint: "+r.Method+" "+r.URL.Path)
+		}
+	})
+	mux.HandleFunc("POST /v1/sandbox/{session}/ports", s.registerPort)
+	mux.HandleFunc("DELETE /v1/sandbox/{session}/ports/{port}", s.unregisterPort)
+}
+
+// sandboxSession returns the session named by the X-Session-ID header,
+// creating it and its sandbox if needed. It responds with 400 Bad Request
+// if the header is missing. Callers must hold s.mu.
+func (s *Server) sandboxSession(w http.ResponseWriter, r *http.Request) (*session, bool) {
+	id := r.Header.Get("X-Session-ID")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "X-Session-ID header is required")
+		return nil, false
+	}
+	sess := s.getSession(id)
+	sess.sandbox = true
+	return sess, true
+}
+
+// runCommand answers a command with the command handler. It must be
+// called without s.mu held, since handlers may access the sandbox.
+func (s *Server) runCommand(ctx context.Context, sessionID string, req skillbox.SandboxExecRequest) (*skillbox.SandboxExecResponse, error) {
+	s.mu.Lock()
+	h := s.commands
+	s.mu.Unlock()
+	if h == nil {
+		return &skillbox.SandboxExecResponse{}, nil
+	}
+	res, err := h(ctx, sessionID, req)
+	if err == nil && res == nil {
+		res = &skillbox.SandboxExecResponse{}
+	}
+	return res, err
+}
+
+// commandRequest reads and validates a command request, applying the
+// server's defaults.
+func commandRequest(w http.ResponseWriter, r *http.Request) (skillbox.SandboxExecRequest, bool) {
+	var req skillbox.SandboxExecRequest
+	if !readJSON(w, r, &req) {
+		return req, false
+	}
+	if req.Command == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "command is required")
+		return req, false
+	}
+	if req.WorkDir == "" {
+		req.WorkDir = sessionDir
+	}
+	if err := validatePath(req.WorkDir); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "invalid workdir: "+err.Error())
+		return req, false
+	}
+	if req.TimeoutMs <= 0 {
+		req.TimeoutMs = 30000
+	}
+	return req, true
+}
+
+func (s *Server) sandboxExecute(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	sess, ok := s.sandboxSession(w, r)
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+	req, ok := commandRequest(w, r)
+	if !ok {
+		return
+	}
+	res, err := s.runCommand(r.Context(), sess.info.ExternalID, req)
+	if err != nil {
+		writeCommandError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func writeCommandError(w http.ResponseWriter, err error) {
+	var apiErr *skillbox.APIError
+	if errors.As(err, &apiErr) {
+		writeAPIError(w, apiErr)
+		return
+	}
+	writeError(w, http.StatusInternalServerError, "execution_error", "command execution failed: "+err.Error())
+}
+
+// readSandboxFile reads the file named in the request body, responding
+// with 400 Bad Request if it cannot be read. Callers must hold s.mu.
+func (s *Server) readSandboxFile(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	sess, ok := s.sandboxSession(w, r)
+	if !ok {
+		return nil, false
+	}
+	var req struct {
+		Path string `json:"path"`
+	}
+	if !readJSON(w, r, &req) {
+		return nil, false
+	}
+	if req.Path == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "path is required")
+		return nil, false
+	}
+	if err := validatePath(req.Path); err != nil {
+		writeError(w, http.StatusBadRequest, "read_error", "failed to read file: "+err.Error())
+		return nil, false
+	}
+	data, ok := sess.fs[path.Clean(req.Path)]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "read_error", "failed to read file: file not found: "+req.Path)
+		return nil, false
+	}
+	return data, true
+}
+
+func (s *Server) sandboxReadFile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if data, ok := s.readSandboxFile(w, r); ok {
+		writeJSON(w, http.StatusOK, map[string]any{"content": string(data), "size": len(data)})
+	}
+}
+
+func (s *Server) sandboxDownloadFile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if data, ok := s.readSandboxFile(w, r); ok {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(data)
+	}
+}
+
+func (s *Server) sandboxWriteFile(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Path    string `json:"path"`
+		Content string `json:"content"`
+		Append  bool   `json:"append"`
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sandboxSession(w, r)
+	if !ok || !readJSON(w, r, &req) {
+		return
+	}
+	if req.Path == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "path is required")
+		return
+	}
+	if err := validatePath(req.Path); err != nil {
+		writeError(w, http.StatusBadRequest, "write_error", "failed to write file: "+err.Error())
+		return
+	}
+	p := path.Clean(req.Path)
+	content := []byte(req.Content)
+	if req.Append {
+		content = append(slices.Clone(sess.fs[p]), content...)
+	}
+	sess.fs[p] = content
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// sandboxUploadSkill copies a skill's files to
+// /sandbox/session/skills/{name}/.
+func (s *Server) sandboxUploadSkill(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Skill   string `json:"skill"`
+		Version string `json:"version"`
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sandboxSession(w, r)
+	if !ok || !readJSON(w, r, &req) {
+		return
+	}
+	if req.Skill == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "skill is required")
+		return
+	}
+	if req.Version == "" {
+		req.Version = "latest"
+	}
+	var v *skillVersion
+	if f := s.skills[req.Skill]; f != nil {
+		v = f.version(req.Version)
+	}
+	if v == nil {
+		writeError(w, http.StatusNotFound, "skill_not_found", "skill not found: "+req.Skill+"@"+req.Version)
+		return
+	}
+	files := sortedKeys(v.Files)
+	for _, p := range files {
+		sess.fs[sessionDir+"/skills/"+req.Skill+"/"+p] = []byte(v.Files[p])
+	}
+	writeJSON(w, http.StatusOK, map[string][]string{"files": files})
+}
+
+// sandboxListDir lists the files under a directory up to a depth,
+// including the directories implied by their paths.
+func (s *Server) sandboxListDir(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Path     string `json:"path"`
+		MaxDepth int    `json:"max_depth"`
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sandboxSession(w, r)
+	if !ok || !readJSON(w, r, &req) {
+		return
+	}
+	if req.Path == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "path is required")
+		return
+	}
+	if err := validatePath(req.Path); err != nil {
+		writeError(w, http.StatusBadRequest, "list_error", "failed to list directory: "+err.Error())
+		return
+	}
+	if req.MaxDepth <= 0 {
+		req.MaxDepth = 2
+	}
+
+	dir := path.Clean(req.Path)
+	seen := make(map[string]bool)
+	entries := []skillbox.SandboxDirEntry{}
+	for _, p := range sortedKeys(sess.fs) {
+		rel, ok := strings.CutPrefix(p, dir+"/")
+		if !ok {
+			continue
+		}
+		parts := strings.Split(rel, "/")
+		if len(parts) > req.MaxDepth {
+			continue
+		}
+		for i := 1; i < len(parts); i++ {
+			d := dir + "/" + strings.Join(parts[:i], "/")
+			if !seen[d] {
+				seen[d] = true
+				entries = append(entries, skillbox.SandboxDirEntry{Path: d, IsDir: true})
+			}
+		}
+		entries = append(entries, skillbox.SandboxDirEntry{Path: p, Size: int64(len(sess.fs[p]))})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"entries": entries})
+}
+
+// sandboxSync is a no-op: the fake's workspace is always in sync.
+func (s *Server) sandboxSync(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.sandboxSession(w, r); ok {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	}
+}
+
+// sandboxDestroy stops a session's sandbox. Its processes and ports go
+// away; the /sandbox/session workspace is kept, as the server syncs it
+// before destroying the sandbox.
+func (s *Server) sandboxDestroy(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess := s.sessions[r.PathValue("session")]
+	if sess == nil || !sess.sandbox {
+		writeError(w, http.StatusNotFound, "not_found", "sandbox not found or already destroyed")
+		return
+	}
+	sess.sandbox = false
+	sess.processes = nil
+	clear(sess.ports)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// --------------------------------------------------------------------
+// Processes
+// --------------------------------------------------------------------
+
+func (s *Server) startProcess(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	sess, ok := s.sandboxSession(w, r)
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+	req, ok := commandRequest(w, r)
+	if !ok {
+		return
+	}
+	res, err := s.runCommand(r.Context(), sess.info.ExternalID, req)
+	if err != nil {
+		writeCommandError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	exitCode := res.ExitCode
+	p := &process{
+		info: skillbox.SandboxProcess{
+			ID:        s.nextID("proc"),
+			Command:   req.Command,
+			WorkDir:   req.WorkDir,
+			PID:       1000 + s.ids["proc"],
+			State:     "exited",
+			ExitCode:  &exitCode,
+			StartedAt: now(),
+		},
+		output: res.Stdout + res.Stderr,
+	}
+	p.info.OutputSize = int64(len(p.output))
+	sess.processes = append(sess.processes, p)
+	writeJSON(w, http.StatusCreated, p.info)
+}
+
+func (s *Server) listProcesses(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sandboxSession(w, r)
+	if !ok {
+		return
+	}
+	out := []skillbox.SandboxProcess{}
+	for _, p := range sess.processes {
+		out = append(out, p.info)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// lookupProcess finds a process of the request's session, responding with
+// 404 Not Found if it does not exist. Callers must hold s.mu.
+func (s *Server) lookupProcess(w http.ResponseWriter, r *http.Request, id string) (*process, bool) {
+	sess, ok := s.sandboxSession(w, r)
+	if !ok {
+		return nil, false
+	}
+	for _, p := range sess.processes {
+		if p.info.ID == id {
+			return p, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "not_found", "process not found")
+	return nil, false
+}
+
+func (s *Server) getProcess(w http.ResponseWriter, r *http.Request, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p, ok := s.lookupProcess(w, r, id); ok {
+		writeJSON(w, http.StatusOK, p.info)
+	}
+}
+
+func (s *Server) processOutput(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	offset, err := strconv.ParseInt(q.Get("offset"), 10, 64)
+	if q.Get("offset") == "" {
+		offset, err = 0, nil
+	}
+	if err != nil || offset < 0 {
+		writeError(w, http.StatusBadRequest, "bad_request", "offset must be a non-negative integer")
+		return
+	}
+	limit, err := strconv.Atoi(q.Get("limit"))
+	if q.Get("limit") == "" {
+		limit, err = 0, nil
+	}
+	if err != nil || limit < 0 {
+		writeError(w, http.StatusBadRequest, "bad_request", "limit must be a non-negative integer")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.lookupProcess(w, r, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	start := min(int(offset), len(p.output))
+	end := len(p.output)
+	if limit > 0 {
+		end = min(start+limit, end)
+	}
+	writeJSON(w, http.StatusOK, skillbox.SandboxProcessOutput{
+		Data:     p.output[start:end],
+		Offset:   int64(end),
+		State:    p.info.State,
+		ExitCode: p.info.ExitCode,
+		Done:     end == len(p.output),
+	})
+}
+
+func (s *Server) signalProcess(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.lookupProcess(w, r, r.PathValue("id")); ok {
+		writeError(w, http.StatusConflict, "process_exited", "process has already exited")
+	}
+}
+
+func (s *Server) waitProcess(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p, ok := s.lookupProcess(w, r, r.PathValue("id")); ok {
+		writeJSON(w, http.StatusOK, p.info)
+	}
+}
+
+// --------------------------------------------------------------------
+// Ports
+// --------------------------------------------------------------------
+
+func (s *Server) registerPort(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Port int `json:"port"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Port < 1 || req.Port > 65535 {
+		writeError(w, http.StatusBadRequest, "bad_request", "port must be between 1 and 65535")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess := s.getSession(r.PathValue("session"))
+	sess.sandbox = true
+	token := s.nextID("preview")
+	p := &skillbox.SandboxPort{Port: req.Port, CreatedAt: now()}
+	sess.ports[req.Port] = p
+	resp := *p
+	resp.Token = token
+	resp.URL = s.URL + "/preview/" + token + "/"
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+func (s *Server) listPorts(w http.ResponseWriter, r *http.Request, sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []skillbox.SandboxPort{}
+	if sess := s.sessions[sessionID]; sess != nil {
+		for _, p := range sess.ports {
+			out = append(out, *p)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Port < out[j].Port })
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) unregisterPort(w http.ResponseWriter, r *http.Request) {
+	port, err := strconv.Atoi(r.PathValue("port"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "port must be a number")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess := s.sessions[r.PathValue("session")]
+	if sess == nil || sess.ports[port] == nil {
+		writeError(w, http.StatusNotFound, "not_found", "port not registered")
+		return
+	}
+	delete(sess.ports, port)
+	w.WriteHeader(http.StatusNoContent)
+}
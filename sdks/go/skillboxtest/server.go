@@ -0,0 +1,488 @@
+// Package skillboxtest provides an in-memory fake of the Skillbox API for
+// testing code built on the Go SDK.
+//
+// A [Server] serves the same endpoints as [skillbox.Client] over a local
+// HTTP listener, backed by maps instead of containers and object storage.
+// Fake skills are Go functions; sandbox commands are answered by a
+// [CommandHandler]. Every request is recorded for assertions, and faults
+// such as error responses and latency can be injected per endpoint:
+//
+//	srv := skillboxtest.NewServer()
+//	defer srv.Close()
+//
+//	srv.AddSkill(skillboxtest.Skill{
+//	    Name: "summarize",
+//	    Handler: func(ctx context.Context, req skillbox.RunRequest) (*skillbox.RunResult, error) {
+//	        return &skillbox.RunResult{Output: json.RawMessage(`{"summary":"ok"}`)}, nil
+//	    },
+//	})
+//	srv.Inject(skillboxtest.Fault{Method: "POST", Path: "/v1/executions", Status: 503, Times: 1})
+//
+//	client := srv.Client()
+//	result, err := client.Run(ctx, skillbox.RunRequest{Skill: "summarize"})
+//
+// The fake does not scan or sandbox anything: uploaded skills are available
+// immediately unless the approval policy is "always", and data is not
+// scoped by tenant.
+package skillboxtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	skillbox "github.com/devs-group/skillbox/sdks/go"
+)
+
+// Server is an in-memory Skillbox API. Create one with [NewServer] and
+// close it when done. It is safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the server, for use with [skillbox.New].
+	URL string
+
+	srv    *httptest.Server
+	apiKey string
+
+	mu             sync.Mutex
+	calls          []Call
+	faults         []*Fault
+	ids            map[string]int
+	skills         map[string]*fakeSkill
+	approvalPolicy string
+	executions     map[string]*execution
+	files          map[string]*storedFile
+	sessions       map[string]*session
+	commands       CommandHandler
+}
+
+// Option configures a [Server]. Pass options to [NewServer].
+type Option func(*Server)
+
+// WithAPIKey makes the server reject requests that do not carry key as a
+// bearer token with 401 Unauthorized, like a server with auth enabled.
+// Clients from [Server.Client] send the key automatically.
+func WithAPIKey(key string) Option {
+	return func(s *Server) {
+		s.apiKey = key
+	}
+}
+
+// WithCommandHandler sets the handler for sandbox commands; see
+// [Server.HandleCommands].
+func WithCommandHandler(h CommandHandler) Option {
+	return func(s *Server) {
+		s.commands = h
+	}
+}
+
+// NewServer starts a fake Skillbox server on a local port.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		ids:            make(map[string]int),
+		skills:         make(map[string]*fakeSkill),
+		approvalPolicy: "auto",
+		executions:     make(map[string]*execution),
+		files:          make(map[string]*storedFile),
+		sessions:       make(map[string]*session),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.srv = httptest.NewServer(s.routes())
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server and blocks until all outstanding requests
+// have completed.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns a [skillbox.Client] for the server. Retries are disabled
+// so that injected faults reach the caller unchanged; pass
+// [skillbox.WithRetry] to test retry behavior.
+func (s *Server) Client(opts ...skillbox.Option) *skillbox.Client {
+	opts = append([]skillbox.Option{
+		skillbox.WithHTTPClient(s.srv.Client()),
+		skillbox.WithRetry(skillbox.RetryPolicy{}),
+	}, opts...)
+	return skillbox.New(s.URL, s.apiKey, opts...)
+}
+
+// --------------------------------------------------------------------
+// Call recording
+// --------------------------------------------------------------------
+
+// Call is a request received by the server.
+type Call struct {
+	Method string
+	Path   string
+	Query  url.Values
+
+	// SessionID and TenantID are the X-Session-ID and X-Tenant-ID headers.
+	SessionID string
+	TenantID  string
+
+	// Body is the raw request body.
+	Body []byte
+
+	// Status is the response status code.
+	Status int
+}
+
+// Calls returns the requests received so far, oldest first.
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
+
+// CallsTo returns the recorded requests with the given method and path.
+// An empty method matches any method.
+func (s *Server) CallsTo(method, path string) []Call {
+	var out []Call
+	for _, c := range s.Calls() {
+		if (method == "" || c.Method == method) && c.Path == path {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// ResetCalls forgets the recorded requests.
+func (s *Server) ResetCalls() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = nil
+}
+
+// --------------------------------------------------------------------
+// Fault injection
+// --------------------------------------------------------------------
+
+// Fault makes matching requests slow, fail, or both.
+type Fault struct {
+	// Method matches the request method; empty matches any method.
+	Method string
+
+	// Path matches the URL path exactly or, if it ends in "*", by prefix.
+	// Empty matches every path.
+	Path string
+
+	// Delay is waited before responding. The wait ends early if the
+	// client gives up on the request.
+	Delay time.Duration
+
+	// Status is the status code of the error response. If zero, the
+	// request is served normally after Delay.
+	Status int
+
+	// Code and Message form the error body. Code defaults to a code
+	// matching Status, such as "not_found" or "rate_limited".
+	Code    string
+	Message string
+
+	// RetryAfter sets the Retry-After header of the error response.
+	RetryAfter time.Duration
+
+	// Times limits the fault to the next Times matching requests. Zero
+	// applies it to every matching request until [Server.ClearFaults].
+	Times int
+}
+
+// Inject adds a fault. When several faults match a request, the one
+// injected first applies.
+func (s *Server) Inject(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// takeFault returns the first fault matching r and uses it up.
+func (s *Server) takeFault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if prefix, ok := strings.CutSuffix(f.Path, "*"); ok {
+			if !strings.HasPrefix(r.URL.Path, prefix) {
+				continue
+			}
+		} else if f.Path != "" && f.Path != r.URL.Path {
+			continue
+		}
+		match := *f
+		if f.Times > 0 {
+			if f.Times--; f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return &match
+	}
+	return nil
+}
+
+// faultCodes are the error codes used for faults without one.
+var faultCodes = map[int]string{
+	http.StatusBadRequest:          "bad_request",
+	http.StatusUnauthorized:        "unauthorized",
+	http.StatusForbidden:           "forbidden",
+	http.StatusNotFound:            "not_found",
+	http.StatusConflict:            "conflict",
+	http.StatusTooManyRequests:     "rate_limited",
+	http.StatusInternalServerError: "internal_error",
+	http.StatusBadGateway:          "bad_gateway",
+	http.StatusServiceUnavailable:  "unavailable",
+	http.StatusGatewayTimeout:      "timeout",
+}
+
+// --------------------------------------------------------------------
+// Routing
+// --------------------------------------------------------------------
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.HandleFunc("GET /v1/tools", s.listTools)
+	mux.HandleFunc("GET /v1/admin/scanner/config", s.getScannerConfig)
+	mux.HandleFunc("PUT /v1/admin/scanner/config", s.updateScannerConfig)
+	mux.HandleFunc("PUT /v1/admin/skills/{name}/{version}/review", s.reviewSkill)
+	s.skillRoutes(mux)
+	s.fileRoutes(mux)
+	s.sessionRoutes(mux)
+	s.sandboxRoutes(mux)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not_found", "no such endpoint: "+r.Method+" "+r.URL.Path)
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		defer func() {
+			s.mu.Lock()
+			s.calls = append(s.calls, Call{
+				Method:    r.Method,
+				Path:      r.URL.Path,
+				Query:     r.URL.Query(),
+				SessionID: r.Header.Get("X-Session-ID"),
+				TenantID:  r.Header.Get("X-Tenant-ID"),
+				Body:      body,
+				Status:    rec.status,
+			})
+			s.mu.Unlock()
+		}()
+
+		// Output archives stand in for pre-signed URLs, which carry no
+		// API key.
+		public := r.URL.Path == "/health" || strings.HasPrefix(r.URL.Path, "/objects/")
+		if s.apiKey != "" && !public && r.Header.Get("Authorization") != "Bearer "+s.apiKey {
+			writeError(rec, http.StatusUnauthorized, "unauthorized", "invalid or missing API key")
+			return
+		}
+
+		if f := s.takeFault(r); f != nil {
+			if f.Delay > 0 {
+				select {
+				case <-time.After(f.Delay):
+				case <-r.Context().Done():
+					return
+				}
+			}
+			if f.Status != 0 {
+				if f.RetryAfter > 0 {
+					rec.Header().Set("Retry-After", fmt.Sprint(int(f.RetryAfter.Round(time.Second)/time.Second)))
+				}
+				code := f.Code
+				if code == "" {
+					code = faultCodes[f.Status]
+				}
+				writeError(rec, f.Status, code, f.Message)
+				return
+			}
+		}
+		mux.ServeHTTP(rec, r)
+	})
+}
+
+// statusRecorder records the status code written to a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// --------------------------------------------------------------------
+// Admin and tools
+// --------------------------------------------------------------------
+
+func (s *Server) getScannerConfig(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, skillbox.ScannerConfig{ApprovalPolicy: s.approvalPolicy})
+}
+
+func (s *Server) updateScannerConfig(w http.ResponseWriter, r *http.Request) {
+	var req skillbox.ScannerConfig
+	if !readJSON(w, r, &req) {
+		return
+	}
+	switch req.ApprovalPolicy {
+	case "auto", "always", "none":
+	default:
+		writeError(w, http.StatusBadRequest, "bad_request", "approval_policy must be 'auto', 'always', or 'none'")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.approvalPolicy = req.ApprovalPolicy
+	writeJSON(w, http.StatusOK, req)
+}
+
+// listTools renders the available skills like GET /v1/tools. Tool names
+// are derived from skill names as the server does, but input schemas are
+// passed through unchanged in every format.
+func (s *Server) listTools(w http.ResponseWriter, r *http.Request) {
+	format := skillbox.ToolFormat(strings.ToLower(r.URL.Query().Get("format")))
+	switch format {
+	case "":
+		format = skillbox.ToolFormatOpenAI
+	case skillbox.ToolFormatOpenAI, skillbox.ToolFormatAnthropic, skillbox.ToolFormatGemini, skillbox.ToolFormatLangChain:
+	default:
+		writeError(w, http.StatusBadRequest, "bad_request", fmt.Sprintf("unsupported format %q (use openai, anthropic, gemini, or langchain)", format))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	set := struct {
+		Format skillbox.ToolFormat           `json:"format"`
+		Tools  any                           `json:"tools"`
+		Skills map[string]skillbox.ToolSkill `json:"skills"`
+	}{Format: format, Skills: make(map[string]skillbox.ToolSkill)}
+
+	tools := []map[string]any{}
+	for _, name := range s.skillNames() {
+		v := s.skills[name].active()
+		if v == nil || v.Status != statusAvailable {
+			continue
+		}
+		tool := toolName(name, format)
+		for i := 2; ; i++ {
+			if _, taken := set.Skills[tool]; !taken {
+				break
+			}
+			tool = fmt.Sprintf("%s_%d", toolName(name, format), i)
+		}
+		set.Skills[tool] = skillbox.ToolSkill{Skill: name, Version: v.Version}
+
+		schema := v.InputSchema
+		if schema == nil {
+			schema = map[string]any{"type": "object"}
+		}
+		switch format {
+		case skillbox.ToolFormatAnthropic:
+			tools = append(tools, map[string]any{"name": tool, "description": v.Description, "input_schema": schema})
+		case skillbox.ToolFormatGemini:
+			tools = append(tools, map[string]any{"name": tool, "description": v.Description, "parameters": schema})
+		case skillbox.ToolFormatLangChain:
+			tools = append(tools, map[string]any{"name": tool, "description": v.Description, "args_schema": schema})
+		default:
+			tools = append(tools, map[string]any{
+				"type":     "function",
+				"function": map[string]any{"name": tool, "description": v.Description, "parameters": schema},
+			})
+		}
+	}
+	set.Tools = tools
+	if format == skillbox.ToolFormatGemini {
+		set.Tools = []map[string]any{{"functionDeclarations": tools}}
+	}
+	writeJSON(w, http.StatusOK, set)
+}
+
+// toolName rewrites a skill name to a provider's tool-name rules.
+func toolName(skill string, format skillbox.ToolFormat) string {
+	name := []byte(skill)
+	for i, c := range name {
+		ok := c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-' ||
+			c == '.' && format == skillbox.ToolFormatGemini
+		if !ok {
+			name[i] = '_'
+		}
+	}
+	if format == skillbox.ToolFormatGemini && (len(name) == 0 || name[0] >= '0' && name[0] <= '9' || name[0] == '-' || name[0] == '.') {
+		name = append([]byte{'_'}, name...)
+	}
+	return string(name[:min(len(name), 64)])
+}
+
+// --------------------------------------------------------------------
+// Helpers
+// --------------------------------------------------------------------
+
+// nextID returns a new ID with the given prefix, such as "exec-1".
+// Callers must hold s.mu.
+func (s *Server) nextID(prefix string) string {
+	s.ids[prefix]++
+	return fmt.Sprintf("%s-%d", prefix, s.ids[prefix])
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, skillbox.APIError{ErrorCode: code, Message: message})
+}
+
+// writeAPIError writes err if it is a *skillbox.APIError, as returned by
+// fake skill handlers to simulate API errors.
+func writeAPIError(w http.ResponseWriter, err *skillbox.APIError) {
+	status := err.StatusCode
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	if err.RetryAfter > 0 {
+		w.Header().Set("Retry-After", fmt.Sprint(int(err.RetryAfter.Round(time.Second)/time.Second)))
+	}
+	writeJSON(w, status, err)
+}
+
+// readJSON decodes the request body into v, responding with 400 Bad
+// Request and returning false if it is not valid JSON.
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && err != io.EOF {
+		writeError(w, http.StatusBadRequest, "bad_request", "invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
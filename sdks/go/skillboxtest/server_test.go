@@ -0,0 +1,502 @@
+package skillboxtest
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	skillbox "github.com/devs-group/skillbox/sdks/go"
+)
+
+func TestRunSkill(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.AddSkill(Skill{
+		Name: "greet",
+		Handler: func(ctx context.Context, req skillbox.RunRequest) (*skillbox.RunResult, error) {
+			var in struct{ Name string }
+			_ = json.Unmarshal(req.Input, &in)
+			out, _ := json.Marshal(map[string]string{"greeting": "hello " + in.Name})
+			return &skillbox.RunResult{Output: out, Logs: "greeted\n"}, nil
+		},
+	})
+
+	client := srv.Client()
+	ctx := context.Background()
+	res, err := client.Run(ctx, skillbox.RunRequest{Skill: "greet", Input: json.RawMessage(`{"name":"ada"}`)})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if res.Status != "success" || res.ExecutionID == "" {
+		t.Errorf("result = %+v, want success with an execution ID", res)
+	}
+	if string(res.Output) != `{"greeting":"hello ada"}` {
+		t.Errorf("output = %s", res.Output)
+	}
+
+	logs, err := client.GetExecutionLogs(ctx, res.ExecutionID)
+	if err != nil || logs != "greeted\n" {
+		t.Errorf("GetExecutionLogs = %q, %v", logs, err)
+	}
+
+	runs := srv.Runs()
+	if len(runs) != 1 || runs[0].Skill != "greet" || runs[0].Version != "" {
+		t.Errorf("Runs() = %+v", runs)
+	}
+
+	_, err = client.Run(ctx, skillbox.RunRequest{Skill: "missing"})
+	if !errors.Is(err, skillbox.ErrNotFound) {
+		t.Errorf("Run(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRunSkillErrors(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+	client := srv.Client()
+
+	srv.AddSkill(Skill{Name: "pending", Status: "review"})
+	if _, err := client.Run(ctx, skillbox.RunRequest{Skill: "pending", Version: "1.0.0"}); !errors.Is(err, skillbox.ErrSkillNotAvailable) {
+		t.Errorf("Run(review) error = %v, want ErrSkillNotAvailable", err)
+	}
+
+	srv.AddSkill(Skill{
+		Name: "limited",
+		Handler: func(context.Context, skillbox.RunRequest) (*skillbox.RunResult, error) {
+			return nil, &skillbox.APIError{StatusCode: http.StatusForbidden, ErrorCode: "image_not_allowed"}
+		},
+	})
+	if _, err := client.Run(ctx, skillbox.RunRequest{Skill: "limited"}); !errors.Is(err, skillbox.ErrImageNotAllowed) {
+		t.Errorf("Run(limited) error = %v, want ErrImageNotAllowed", err)
+	}
+
+	srv.AddSkill(Skill{
+		Name: "broken",
+		Handler: func(context.Context, skillbox.RunRequest) (*skillbox.RunResult, error) {
+			return nil, errors.New("container crashed")
+		},
+	})
+	_, err := client.Run(ctx, skillbox.RunRequest{Skill: "broken"})
+	var apiErr *skillbox.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Run(broken) error = %v, want a 500 APIError", err)
+	}
+}
+
+func TestListExecutions(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.AddSkill(Skill{Name: "ok"})
+	srv.AddSkill(Skill{
+		Name: "bad",
+		Handler: func(ctx context.Context, req skillbox.RunRequest) (*skillbox.RunResult, error) {
+			return &skillbox.RunResult{Status: "failed", Error: "boom"}, nil
+		},
+	})
+
+	client := srv.Client()
+	ctx := context.Background()
+	for _, name := range []string{"ok", "bad", "ok"} {
+		if _, err := client.Run(ctx, skillbox.RunRequest{Skill: name}); err != nil {
+			t.Fatalf("Run(%s): %v", name, err)
+		}
+	}
+
+	execs, err := client.ListExecutions(ctx, skillbox.ExecutionFilter{Skill: "ok"})
+	if err != nil || len(execs) != 2 || execs[0].ExecutionID != "exec-3" || execs[0].SkillName != "ok" {
+		t.Errorf("ListExecutions(ok) = %+v, %v", execs, err)
+	}
+	execs, err = client.ListExecutions(ctx, skillbox.ExecutionFilter{Status: "failed"})
+	if err != nil || len(execs) != 1 || execs[0].Error != "boom" {
+		t.Errorf("ListExecutions(failed) = %+v, %v", execs, err)
+	}
+
+	if err := client.CancelExecution(ctx, "exec-1"); !errors.Is(err, skillbox.ErrConflict) {
+		t.Errorf("CancelExecution(finished) error = %v, want ErrConflict", err)
+	}
+	if err := client.CancelExecution(ctx, "exec-9"); !errors.Is(err, skillbox.ErrNotFound) {
+		t.Errorf("CancelExecution(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestOutputFiles(t *testing.T) {
+	srv := NewServer(WithAPIKey("sk-test"))
+	defer srv.Close()
+
+	srv.AddSkill(Skill{
+		Name: "report",
+		Handler: func(ctx context.Context, req skillbox.RunRequest) (*skillbox.RunResult, error) {
+			WriteOutputFile(ctx, "report.csv", []byte("a,b\n"))
+			WriteOutputFile(ctx, "session/state.json", []byte(`{"n":1}`))
+			return nil, nil
+		},
+	})
+
+	client := srv.Client()
+	ctx := context.Background()
+	res, err := client.Run(ctx, skillbox.RunRequest{Skill: "report", SessionID: "s1"})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !res.HasFiles() || len(res.FilesList) != 2 {
+		t.Fatalf("result files = %q %v", res.FilesURL, res.FilesList)
+	}
+
+	dir := t.TempDir()
+	if err := client.DownloadFiles(ctx, res, dir); err != nil {
+		t.Fatalf("DownloadFiles: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "report.csv")); string(data) != "a,b\n" {
+		t.Errorf("report.csv = %q", data)
+	}
+
+	files, err := client.ListFiles(ctx, skillbox.FileFilter{ExecutionID: res.ExecutionID})
+	if err != nil || len(files) != 2 {
+		t.Fatalf("ListFiles = %v, %v", files, err)
+	}
+
+	rc, err := client.GetSessionFile(ctx, "s1", "state.json")
+	if err != nil {
+		t.Fatalf("GetSessionFile: %v", err)
+	}
+	defer rc.Close() //nolint:errcheck
+	if data, _ := io.ReadAll(rc); string(data) != `{"n":1}` {
+		t.Errorf("state.json = %q", data)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.AddSkill(Skill{Name: "echo"})
+	ctx := context.Background()
+
+	srv.Inject(Fault{Method: http.MethodPost, Path: "/v1/executions", Status: http.StatusServiceUnavailable, Times: 1})
+	if _, err := srv.Client().Run(ctx, skillbox.RunRequest{Skill: "echo"}); !errors.Is(err, skillbox.ErrUnavailable) {
+		t.Fatalf("Run error = %v, want ErrUnavailable", err)
+	}
+	if _, err := srv.Client().Run(ctx, skillbox.RunRequest{Skill: "echo"}); err != nil {
+		t.Fatalf("Run after fault used up: %v", err)
+	}
+
+	// A retrying client gets past a transient fault.
+	srv.ResetCalls()
+	srv.Inject(Fault{Path: "/v1/executions", Status: http.StatusTooManyRequests, Times: 2})
+	retrying := srv.Client(skillbox.WithRetry(skillbox.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}))
+	if _, err := retrying.Run(ctx, skillbox.RunRequest{Skill: "echo"}); err != nil {
+		t.Fatalf("Run with retries: %v", err)
+	}
+	calls := srv.CallsTo(http.MethodPost, "/v1/executions")
+	if len(calls) != 3 || calls[0].Status != http.StatusTooManyRequests || calls[2].Status != http.StatusOK {
+		t.Errorf("calls = %+v", calls)
+	}
+
+	srv.Inject(Fault{Path: "/v1/skills*", Delay: time.Second})
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := srv.Client().ListSkills(short); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ListSkills error = %v, want deadline exceeded", err)
+	}
+	srv.ClearFaults()
+	if _, err := srv.Client().ListSkills(ctx); err != nil {
+		t.Errorf("ListSkills after ClearFaults: %v", err)
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	srv := NewServer(WithAPIKey("sk-test"))
+	defer srv.Close()
+	ctx := context.Background()
+
+	if _, err := srv.Client().ListSkills(ctx); err != nil {
+		t.Fatalf("ListSkills: %v", err)
+	}
+	wrong := skillbox.New(srv.URL, "sk-wrong", skillbox.WithRetry(skillbox.RetryPolicy{}))
+	if _, err := wrong.ListSkills(ctx); !errors.Is(err, skillbox.ErrUnauthorized) {
+		t.Errorf("ListSkills with wrong key error = %v, want ErrUnauthorized", err)
+	}
+	if err := wrong.Health(ctx); err != nil {
+		t.Errorf("Health: %v", err)
+	}
+}
+
+func TestSkillLifecycle(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	zipPath := filepath.Join(t.TempDir(), "skill.zip")
+	writeZip(t, zipPath, map[string]string{
+		"SKILL.md": "---\nname: csv-stats\ndescription: Summarize a CSV\nversion: \"1.0.0\"\n---\nRead the CSV.\n",
+		"main.py":  "print('v1')\n",
+	})
+	if err := client.RegisterSkill(ctx, zipPath); err != nil {
+		t.Fatalf("RegisterSkill: %v", err)
+	}
+	detail, err := client.GetSkill(ctx, "csv-stats", "latest")
+	if err != nil || detail.Description != "Summarize a CSV" || detail.Instructions != "Read the CSV." {
+		t.Fatalf("GetSkill = %+v, %v", detail, err)
+	}
+
+	if err := client.PutSkillFile(ctx, "csv-stats", "main.py", "print('v2')\n"); err != nil {
+		t.Fatalf("PutSkillFile: %v", err)
+	}
+	f, err := client.GetSkillFile(ctx, "csv-stats", "latest", "main.py")
+	if err != nil || f.Content != "print('v2')\n" {
+		t.Fatalf("GetSkillFile = %+v, %v", f, err)
+	}
+	diff, err := client.SkillDiff(ctx, "csv-stats", "1.0.0", "1.0.1")
+	if err != nil {
+		t.Fatalf("SkillDiff: %v", err)
+	}
+	for _, fd := range diff.Files {
+		if fd.Path == "main.py" && fd.Status != "modified" {
+			t.Errorf("main.py status = %q, want modified", fd.Status)
+		}
+	}
+
+	if err := client.SetActiveVersion(ctx, "csv-stats", "1.0.0"); err != nil {
+		t.Fatalf("SetActiveVersion: %v", err)
+	}
+	versions, err := client.ListSkillVersions(ctx, "csv-stats")
+	if err != nil || len(versions) != 2 || versions[0].Version != "1.0.1" || !versions[1].Active {
+		t.Errorf("ListSkillVersions = %+v, %v", versions, err)
+	}
+
+	// With approval required, edits wait for review.
+	if err := client.UpdateScannerConfig(ctx, skillbox.ScannerConfig{ApprovalPolicy: "always"}); err != nil {
+		t.Fatalf("UpdateScannerConfig: %v", err)
+	}
+	if err := client.PutSkillFile(ctx, "csv-stats", "main.py", "print('v3')\n"); err != nil {
+		t.Fatalf("PutSkillFile: %v", err)
+	}
+	if _, err := client.Run(ctx, skillbox.RunRequest{Skill: "csv-stats", Version: "1.0.2"}); !errors.Is(err, skillbox.ErrSkillNotAvailable) {
+		t.Errorf("Run(review) error = %v, want ErrSkillNotAvailable", err)
+	}
+	if err := client.ReviewSkill(ctx, "csv-stats", "1.0.2", "approve", ""); err != nil {
+		t.Fatalf("ReviewSkill: %v", err)
+	}
+	skills, err := client.ListSkills(ctx)
+	if err != nil || len(skills) != 1 || skills[0].Version != "1.0.2" {
+		t.Errorf("ListSkills = %+v, %v", skills, err)
+	}
+
+	if err := client.DeleteSkillAllVersions(ctx, "csv-stats"); err != nil {
+		t.Fatalf("DeleteSkillAllVersions: %v", err)
+	}
+	if _, err := client.GetSkill(ctx, "csv-stats", "latest"); !errors.Is(err, skillbox.ErrNotFound) {
+		t.Errorf("GetSkill after delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFiles(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	info, err := client.UploadFileFromReader(ctx, "notes.txt", strings.NewReader("v1"))
+	if err != nil {
+		t.Fatalf("UploadFileFromReader: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("v2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := client.UpdateFile(ctx, info.ID, path)
+	if err != nil || updated.Version != 2 || updated.ParentID == nil || *updated.ParentID != info.ID {
+		t.Fatalf("UpdateFile = %+v, %v", updated, err)
+	}
+	if data, _ := srv.FileContent(updated.ID); string(data) != "v2" {
+		t.Errorf("FileContent = %q", data)
+	}
+	versions, err := client.ListFileVersions(ctx, info.ID)
+	if err != nil || len(versions) != 2 || versions[0].Version != 2 {
+		t.Errorf("ListFileVersions = %+v, %v", versions, err)
+	}
+
+	for range 3 {
+		srv.AddFile("extra.txt", nil)
+	}
+	n := 0
+	for _, err := range client.AllFiles(ctx, skillbox.FileFilter{Limit: 2}) {
+		if err != nil {
+			t.Fatalf("AllFiles: %v", err)
+		}
+		n++
+	}
+	if n != 5 {
+		t.Errorf("AllFiles yielded %d files, want 5", n)
+	}
+
+	if err := client.DeleteFile(ctx, info.ID); err != nil {
+		t.Fatalf("DeleteFile: %v", err)
+	}
+	if _, err := client.GetFile(ctx, info.ID); !errors.Is(err, skillbox.ErrNotFound) {
+		t.Errorf("GetFile after delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSnapshots(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	if err := client.SandboxWriteFile(ctx, "s1", "/sandbox/session/a.txt", "one", false); err != nil {
+		t.Fatalf("SandboxWriteFile: %v", err)
+	}
+	snap, err := client.CreateSnapshot(ctx, "s1", "before")
+	if err != nil || snap.FileCount != 1 {
+		t.Fatalf("CreateSnapshot = %+v, %v", snap, err)
+	}
+	if err := client.SandboxWriteFile(ctx, "s1", "/sandbox/session/a.txt", "two", false); err != nil {
+		t.Fatalf("SandboxWriteFile: %v", err)
+	}
+
+	fork, err := client.ForkSession(ctx, "s1", snap.ID, "s2")
+	if err != nil || fork.ExternalID != "s2" {
+		t.Fatalf("ForkSession = %+v, %v", fork, err)
+	}
+	if got := string(srv.SessionFiles("s2")["a.txt"]); got != "one" {
+		t.Errorf("forked a.txt = %q, want one", got)
+	}
+
+	if _, err := client.RestoreSnapshot(ctx, "s1", snap.ID); err != nil {
+		t.Fatalf("RestoreSnapshot: %v", err)
+	}
+	if content, _ := client.SandboxReadFile(ctx, "s1", "/sandbox/session/a.txt"); content != "one" {
+		t.Errorf("restored a.txt = %q, want one", content)
+	}
+
+	if err := client.DeleteSession(ctx, "s1"); err != nil {
+		t.Fatalf("DeleteSession: %v", err)
+	}
+	if files, err := client.ListSessionFiles(ctx, "s1"); err != nil || len(files) != 0 {
+		t.Errorf("ListSessionFiles after delete = %v, %v", files, err)
+	}
+}
+
+func TestWorkspaceToolkit(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.HandleCommands(func(ctx context.Context, sessionID string, req skillbox.SandboxExecRequest) (*skillbox.SandboxExecResponse, error) {
+		if req.Command == "cat hello.txt" {
+			data, _ := srv.SandboxFile(sessionID, "hello.txt")
+			return &skillbox.SandboxExecResponse{Stdout: string(data)}, nil
+		}
+		return &skillbox.SandboxExecResponse{Stderr: "command not found", ExitCode: 127}, nil
+	})
+
+	tk := skillbox.NewWorkspaceToolkit(srv.Client(), "s1")
+	ctx := context.Background()
+	call := func(tool string, args any) string {
+		t.Helper()
+		raw, _ := json.Marshal(args)
+		out, _, err := tk.Handle(ctx, tool, raw)
+		if err != nil {
+			t.Fatalf("%s: %v", tool, err)
+		}
+		return out
+	}
+
+	call("write_file", map[string]any{"path": "/sandbox/session/hello.txt", "content": "hello world\n"})
+	if out := call("bash", map[string]any{"command": "cat hello.txt"}); out != "hello world\n" {
+		t.Errorf("bash output = %q", out)
+	}
+	if out := call("bash", map[string]any{"command": "nope"}); !strings.Contains(out, "[exit code: 127]") {
+		t.Errorf("bash output = %q, want exit code", out)
+	}
+
+	out := call("edit_file", map[string]any{"path": "/sandbox/session/hello.txt", "old_string": "world", "new_string": "there"})
+	if !strings.Contains(out, "1 replacement") {
+		t.Errorf("edit_file output = %q", out)
+	}
+	out = call("edit_file", map[string]any{"path": "/sandbox/session/hello.txt", "old_string": "missing", "new_string": "x"})
+	if !strings.Contains(out, "old_string not found") {
+		t.Errorf("edit_file conflict output = %q", out)
+	}
+	diff := "--- a/hello.txt\n+++ b/hello.txt\n@@ -1 +1 @@\n-hello there\n+goodbye there\n"
+	if out := call("edit_file", map[string]any{"path": "/sandbox/session/hello.txt", "diff": diff}); !strings.Contains(out, "Applied 1 hunk") {
+		t.Errorf("edit_file diff output = %q", out)
+	}
+	if out := call("read_file", map[string]any{"path": "/sandbox/session/hello.txt"}); out != "goodbye there\n" {
+		t.Errorf("read_file = %q", out)
+	}
+	if out := call("grep", map[string]any{"pattern": "goodbye"}); !strings.Contains(out, "hello.txt") {
+		t.Errorf("grep output = %q", out)
+	}
+	if out := call("ls", map[string]any{"path": "/sandbox/session"}); !strings.Contains(out, "hello.txt (14 bytes)") {
+		t.Errorf("ls output = %q", out)
+	}
+
+	out = call("start_process", map[string]any{"command": "cat hello.txt"})
+	procs, err := srv.Client().SandboxListProcesses(ctx, "s1")
+	if err != nil || len(procs) != 1 {
+		t.Fatalf("SandboxListProcesses = %v, %v (start_process: %q)", procs, err, out)
+	}
+	output, err := srv.Client().SandboxProcessOutput(ctx, "s1", procs[0].ID, 0, 0)
+	if err != nil || output.Data != "goodbye there\n" || !output.Done {
+		t.Errorf("SandboxProcessOutput = %+v, %v", output, err)
+	}
+}
+
+func TestSandboxPorts(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	port, err := client.SandboxExposePort(ctx, "s1", 3000)
+	if err != nil || port.URL == "" || port.Token == "" {
+		t.Fatalf("SandboxExposePort = %+v, %v", port, err)
+	}
+	ports, err := client.SandboxListPorts(ctx, "s1")
+	if err != nil || len(ports) != 1 || ports[0].Port != 3000 || ports[0].Token != "" {
+		t.Errorf("SandboxListPorts = %+v, %v", ports, err)
+	}
+	if err := client.SandboxUnexposePort(ctx, "s1", 3000); err != nil {
+		t.Fatalf("SandboxUnexposePort: %v", err)
+	}
+	if err := client.SandboxUnexposePort(ctx, "s1", 3000); !errors.Is(err, skillbox.ErrNotFound) {
+		t.Errorf("second SandboxUnexposePort error = %v, want ErrNotFound", err)
+	}
+
+	if err := client.SandboxDestroy(ctx, "s1"); err != nil {
+		t.Fatalf("SandboxDestroy: %v", err)
+	}
+	if err := client.SandboxDestroy(ctx, "s1"); !errors.Is(err, skillbox.ErrNotFound) {
+		t.Errorf("second SandboxDestroy error = %v, want ErrNotFound", err)
+	}
+}
+
+func writeZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = io.WriteString(w, content)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,339 @@
+package skillboxtest
+
+import (
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	skillbox "github.com/devs-group/skillbox/sdks/go"
+)
+
+// sessionDir is the sandbox directory that holds a session's workspace.
+const sessionDir = "/sandbox/session"
+
+// session is the state of one session: its workspace, snapshots and the
+// processes and ports of its sandbox.
+type session struct {
+	info      skillbox.Session
+	fs        map[string][]byte // absolute sandbox path -> content
+	snapshots []*snapshot
+	sandbox   bool // a sandbox endpoint has been used since the last destroy
+	processes []*process
+	ports     map[int]*skillbox.SandboxPort
+}
+
+type snapshot struct {
+	info  skillbox.Snapshot
+	files map[string][]byte
+}
+
+// getSession returns the session with the given ID, creating it if
+// needed. Callers must hold s.mu.
+func (s *Server) getSession(id string) *session {
+	sess := s.sessions[id]
+	if sess == nil {
+		ts := now()
+		sess = &session{
+			info: skillbox.Session{
+				ID:             s.nextID("session"),
+				TenantID:       "default",
+				ExternalID:     id,
+				CreatedAt:      ts,
+				LastAccessedAt: ts,
+			},
+			fs:    make(map[string][]byte),
+			ports: make(map[int]*skillbox.SandboxPort),
+		}
+		s.sessions[id] = sess
+	}
+	return sess
+}
+
+// sandboxFS returns the sandbox file system of a session, keyed by
+// absolute path. Callers must hold s.mu.
+func (s *Server) sandboxFS(sessionID string) map[string][]byte {
+	return s.getSession(sessionID).fs
+}
+
+// SessionFiles returns the workspace files of a session, keyed by path
+// relative to /sandbox/session.
+func (s *Server) SessionFiles(sessionID string) map[string][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string][]byte)
+	if sess := s.sessions[sessionID]; sess != nil {
+		for p, data := range sess.fs {
+			if rel, ok := strings.CutPrefix(p, sessionDir+"/"); ok {
+				out[rel] = slices.Clone(data)
+			}
+		}
+	}
+	return out
+}
+
+// --------------------------------------------------------------------
+// Session endpoints
+// --------------------------------------------------------------------
+
+func (s *Server) sessionRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/sessions/{id}/files", s.listSessionFiles)
+	mux.HandleFunc("GET /v1/sessions/{id}/files/{name...}", s.getSessionFile)
+	mux.HandleFunc("DELETE /v1/sessions/{id}/files/{name...}", s.deleteSessionFile)
+	mux.HandleFunc("DELETE /v1/sessions/{id}", s.deleteSession)
+	mux.HandleFunc("POST /v1/sessions/{id}/snapshots", s.createSnapshot)
+	mux.HandleFunc("GET /v1/sessions/{id}/snapshots", s.listSnapshots)
+	mux.HandleFunc("DELETE /v1/sessions/{id}/snapshots/{snapshot}", s.deleteSnapshot)
+	mux.HandleFunc("POST /v1/sessions/{id}/restore", s.restoreSnapshot)
+	mux.HandleFunc("POST /v1/sessions/{id}/fork", s.forkSession)
+}
+
+func (s *Server) listSessionFiles(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []skillbox.FileInfo{}
+	if sess := s.sessions[r.PathValue("id")]; sess != nil {
+		for _, p := range sortedKeys(sess.fs) {
+			rel, ok := strings.CutPrefix(p, sessionDir+"/")
+			if !ok {
+				continue
+			}
+			out = append(out, skillbox.FileInfo{
+				ID:          sess.info.ID + "/" + rel,
+				TenantID:    sess.info.TenantID,
+				SessionID:   sess.info.ExternalID,
+				Name:        rel,
+				ContentType: contentType(rel),
+				SizeBytes:   int64(len(sess.fs[p])),
+				Version:     1,
+				CreatedAt:   sess.info.CreatedAt,
+				UpdatedAt:   sess.info.LastAccessedAt,
+			})
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// lookupSession finds a session, responding with 404 Not Found if it does
+// not exist. Callers must hold s.mu.
+func (s *Server) lookupSession(w http.ResponseWriter, id string) (*session, bool) {
+	sess := s.sessions[id]
+	if sess == nil {
+		writeError(w, http.StatusNotFound, "not_found", "session not found")
+		return nil, false
+	}
+	return sess, true
+}
+
+func (s *Server) getSessionFile(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.lookupSession(w, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	data, ok := sess.fs[sessionDir+"/"+name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "file not found: "+name)
+		return
+	}
+	w.Header().Set("Content-Type", contentType(name))
+	_, _ = w.Write(data)
+}
+
+func (s *Server) deleteSessionFile(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.lookupSession(w, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	if _, ok := sess.fs[sessionDir+"/"+name]; !ok {
+		writeError(w, http.StatusNotFound, "not_found", "file not found: "+name)
+		return
+	}
+	delete(sess.fs, sessionDir+"/"+name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deleteSession removes a session with its workspace, snapshots, sandbox
+// and output files.
+func (s *Server) deleteSession(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.lookupSession(w, id); !ok {
+		return
+	}
+	delete(s.sessions, id)
+	maps.DeleteFunc(s.files, func(_ string, f *storedFile) bool { return f.info.SessionID == id })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+var snapshotNameRe = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+func (s *Server) createSnapshot(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name string `json:"name"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if !snapshotNameRe.MatchString(req.Name) {
+		writeError(w, http.StatusBadRequest, "bad_request", "name must be 1-128 characters of letters, digits, '.', '_' or '-'")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess := s.sessions[r.PathValue("id")]
+	if sess == nil {
+		writeError(w, http.StatusNotFound, "not_found", "session or snapshot not found")
+		return
+	}
+	version := 1
+	for _, snap := range sess.snapshots {
+		if snap.info.Name == req.Name {
+			version = max(version, snap.info.Version+1)
+		}
+	}
+	files := s.workspace(sess)
+	snap := &snapshot{
+		info: skillbox.Snapshot{
+			ID:        s.nextID("snap"),
+			TenantID:  sess.info.TenantID,
+			SessionID: sess.info.ID,
+			Name:      req.Name,
+			Version:   version,
+			FileCount: len(files),
+			CreatedAt: now(),
+		},
+		files: files,
+	}
+	for _, data := range files {
+		snap.info.SizeBytes += int64(len(data))
+	}
+	sess.snapshots = append(sess.snapshots, snap)
+	writeJSON(w, http.StatusCreated, snap.info)
+}
+
+// workspace copies the /sandbox/session files of a session. Callers must
+// hold s.mu.
+func (s *Server) workspace(sess *session) map[string][]byte {
+	files := make(map[string][]byte)
+	for p, data := range sess.fs {
+		if strings.HasPrefix(p, sessionDir+"/") {
+			files[p] = slices.Clone(data)
+		}
+	}
+	return files
+}
+
+func (s *Server) listSnapshots(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []skillbox.Snapshot{}
+	if sess := s.sessions[r.PathValue("id")]; sess != nil {
+		for i := len(sess.snapshots) - 1; i >= 0; i-- {
+			out = append(out, sess.snapshots[i].info)
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// lookupSnapshot finds a snapshot of a session, responding with 404 Not
+// Found if either does not exist. Callers must hold s.mu.
+func (s *Server) lookupSnapshot(w http.ResponseWriter, sessionID, snapshotID string) (*session, *snapshot, bool) {
+	if sess := s.sessions[sessionID]; sess != nil {
+		for _, snap := range sess.snapshots {
+			if snap.info.ID == snapshotID {
+				return sess, snap, true
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, "not_found", "session or snapshot not found")
+	return nil, nil, false
+}
+
+func (s *Server) deleteSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, snap, ok := s.lookupSnapshot(w, r.PathValue("id"), r.PathValue("snapshot"))
+	if !ok {
+		return
+	}
+	sess.snapshots = slices.DeleteFunc(sess.snapshots, func(sn *snapshot) bool { return sn == snap })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// restoreSnapshot replaces the session workspace with the snapshot's
+// files.
+func (s *Server) restoreSnapshot(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		SnapshotID string `json:"snapshot_id"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.SnapshotID == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "snapshot_id is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, snap, ok := s.lookupSnapshot(w, r.PathValue("id"), req.SnapshotID)
+	if !ok {
+		return
+	}
+	maps.DeleteFunc(sess.fs, func(p string, _ []byte) bool { return strings.HasPrefix(p, sessionDir+"/") })
+	for p, data := range snap.files {
+		sess.fs[p] = slices.Clone(data)
+	}
+	writeJSON(w, http.StatusOK, snap.info)
+}
+
+// forkSession creates a session whose workspace is a copy of a snapshot.
+func (s *Server) forkSession(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		SnapshotID string `json:"snapshot_id"`
+		SessionID  string `json:"session_id"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.SnapshotID == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "snapshot_id is required")
+		return
+	}
+	source := r.PathValue("id")
+	if req.SessionID == source {
+		writeError(w, http.StatusBadRequest, "bad_request", "session_id must differ from the source session")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, snap, ok := s.lookupSnapshot(w, source, req.SnapshotID)
+	if !ok {
+		return
+	}
+	if req.SessionID == "" {
+		req.SessionID = s.nextID("fork")
+	}
+	if s.sessions[req.SessionID] != nil {
+		writeError(w, http.StatusConflict, "session_exists", "session already exists: "+req.SessionID)
+		return
+	}
+	fork := s.getSession(req.SessionID)
+	for p, data := range snap.files {
+		fork.fs[p] = slices.Clone(data)
+	}
+	writeJSON(w, http.StatusCreated, fork.info)
+}
@@ -0,0 +1,1009 @@
+package skillboxtest
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	skillbox "github.com/devs-group/skillbox/sdks/go"
+)
+
+const (
+	statusAvailable = "available"
+	statusReview    = "review"
+	statusDeclined  = "declined"
+	defaultVersion  = "1.0.0"
+)
+
+// SkillHandler implements a fake skill. It receives the run request as
+// sent by the client, with Version resolved to the version being run.
+//
+// Empty fields of the returned result are filled in: ExecutionID is
+// generated, Status defaults to "success" and DurationMs to the time the
+// handler took. Return a result with Status "failed" to simulate a skill
+// that exits with an error. A returned *skillbox.APIError is sent as the
+// API error response; any other error is reported like an internal runner
+// failure, as a 500 response with Status "failed".
+type SkillHandler func(ctx context.Context, req skillbox.RunRequest) (*skillbox.RunResult, error)
+
+// Skill is a fake skill version added with [Server.AddSkill].
+type Skill struct {
+	Name         string
+	Version      string // defaults to "1.0.0"
+	Description  string
+	Lang         string // defaults to "python"
+	Instructions string
+	InputSchema  map[string]any
+
+	// Files are the skill's source files as returned by
+	// [skillbox.Client.GetSkillFiles]. A SKILL.md built from the fields
+	// above is added if missing.
+	Files map[string]string
+
+	// Status is the review status, "available" by default. Only
+	// available versions can run or become active.
+	Status string
+
+	// Handler runs the skill. If nil, runs succeed with a null output.
+	Handler SkillHandler
+}
+
+// fakeSkill holds every version of one skill.
+type fakeSkill struct {
+	versions []*skillVersion // oldest first
+	activeV  string
+}
+
+type skillVersion struct {
+	Skill
+	uploadedAt time.Time
+}
+
+func (f *fakeSkill) version(v string) *skillVersion {
+	if v == "" || v == "latest" {
+		return f.active()
+	}
+	for _, sv := range f.versions {
+		if sv.Version == v {
+			return sv
+		}
+	}
+	return nil
+}
+
+func (f *fakeSkill) active() *skillVersion {
+	for _, sv := range f.versions {
+		if sv.Version == f.activeV {
+			return sv
+		}
+	}
+	return nil
+}
+
+// AddSkill adds a skill version, replacing any version with the same name
+// and version. An available version becomes the skill's active version,
+// which runs when no version is requested.
+func (s *Server) AddSkill(sk Skill) {
+	if sk.Version == "" {
+		sk.Version = defaultVersion
+	}
+	if sk.Lang == "" {
+		sk.Lang = "python"
+	}
+	if sk.Status == "" {
+		sk.Status = statusAvailable
+	}
+	files := maps.Clone(sk.Files)
+	if files == nil {
+		files = make(map[string]string)
+	}
+	if _, ok := files["SKILL.md"]; !ok {
+		files["SKILL.md"] = skillMD(sk)
+	}
+	sk.Files = files
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.putSkill(sk)
+}
+
+// putSkill stores a skill version. Callers must hold s.mu.
+func (s *Server) putSkill(sk Skill) {
+	f := s.skills[sk.Name]
+	if f == nil {
+		f = &fakeSkill{}
+		s.skills[sk.Name] = f
+	}
+	f.versions = slices.DeleteFunc(f.versions, func(v *skillVersion) bool { return v.Version == sk.Version })
+	f.versions = append(f.versions, &skillVersion{Skill: sk, uploadedAt: time.Now()})
+	if sk.Status == statusAvailable {
+		f.activeV = sk.Version
+	}
+}
+
+// Runs returns the run requests received for fake skills, oldest first.
+func (s *Server) Runs() []skillbox.RunRequest {
+	var runs []skillbox.RunRequest
+	for _, c := range s.CallsTo(http.MethodPost, "/v1/executions") {
+		var req skillbox.RunRequest
+		if json.Unmarshal(c.Body, &req) == nil {
+			runs = append(runs, req)
+		}
+	}
+	return runs
+}
+
+// skillNames returns the names of all skills, sorted. Callers must hold
+// s.mu.
+func (s *Server) skillNames() []string {
+	names := make([]string, 0, len(s.skills))
+	for name := range s.skills {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// intakeStatus is the status of newly uploaded or edited versions. There
+// is no scanner, so they are available unless every upload needs review.
+// Callers must hold s.mu.
+func (s *Server) intakeStatus() string {
+	if s.approvalPolicy == "always" {
+		return statusReview
+	}
+	return statusAvailable
+}
+
+// nextFreeVersion bumps the patch number of base until it names an unused
+// version. Callers must hold s.mu.
+func (s *Server) nextFreeVersion(name, base string) string {
+	next := base
+	for {
+		next = nextPatch(next)
+		if f := s.skills[name]; f == nil || f.version(next) == nil {
+			return next
+		}
+	}
+}
+
+var versionRe = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)`)
+
+func nextPatch(v string) string {
+	m := versionRe.FindStringSubmatch(v)
+	if m == nil {
+		return defaultVersion
+	}
+	patch, _ := strconv.Atoi(m[3])
+	return fmt.Sprintf("%s.%s.%d", m[1], m[2], patch+1)
+}
+
+// --------------------------------------------------------------------
+// Skill endpoints
+// --------------------------------------------------------------------
+
+func (s *Server) skillRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/executions", s.run)
+	mux.HandleFunc("GET /v1/executions", s.listExecutions)
+	mux.HandleFunc("GET /v1/executions/{id}", s.getExecution)
+	mux.HandleFunc("POST /v1/executions/{id}/cancel", s.cancelExecution)
+	mux.HandleFunc("GET /v1/executions/{id}/logs", s.getExecutionLogs)
+	mux.HandleFunc("GET /objects/{id}/files.tar.gz", s.getExecutionFiles)
+
+	mux.HandleFunc("GET /v1/skills", s.listSkills)
+	mux.HandleFunc("POST /v1/skills", s.uploadSkill)
+	mux.HandleFunc("POST /v1/skills/from-fields", s.createSkillFromFields)
+	mux.HandleFunc("GET /v1/skills/{name}/{version}", s.getSkill)
+	mux.HandleFunc("GET /v1/skills/{name}/{version}/files", s.getSkillFiles)
+	mux.HandleFunc("PUT /v1/skills/{name}/files", s.putSkillFile)
+	mux.HandleFunc("PUT /v1/skills/{name}/files-batch", s.putSkillFiles)
+	mux.HandleFunc("GET /v1/skills/{name}/diff", s.skillDiff)
+	mux.HandleFunc("PUT /v1/skills/{name}/active", s.setActiveVersion)
+	mux.HandleFunc("GET /v1/skills/{name}/versions", s.listSkillVersions)
+	mux.HandleFunc("DELETE /v1/skills/{name}/{version}", s.deleteSkillVersion)
+	mux.HandleFunc("DELETE /v1/skills/{name}", s.deleteSkill)
+}
+
+func (s *Server) listSkills(w http.ResponseWriter, r *http.Request) {
+	filter := r.URL.Query().Get("status")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []skillbox.Skill{}
+	for _, name := range s.skillNames() {
+		f := s.skills[name]
+		// Like the server, list the active version or else the newest
+		// version with the requested status.
+		var pick *skillVersion
+		for i := len(f.versions) - 1; i >= 0; i-- {
+			v := f.versions[i]
+			match := filter == "all" || v.Status == filter || filter == "" && v.Status == statusAvailable
+			if match && (pick == nil || v.Version == f.activeV) {
+				pick = v
+			}
+		}
+		if pick == nil {
+			continue
+		}
+		sk := skillbox.Skill{
+			Name:        name,
+			Version:     pick.Version,
+			Description: pick.Description,
+			Lang:        pick.Lang,
+			Mode:        "executable",
+			Status:      pick.Status,
+		}
+		if filter == "all" {
+			for _, v := range f.versions {
+				sk.HasReview = sk.HasReview || v.Status == statusReview
+				sk.HasDeclined = sk.HasDeclined || v.Status == statusDeclined
+			}
+		}
+		out = append(out, sk)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getSkill(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.lookupVersion(w, r.PathValue("name"), r.PathValue("version"))
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, skillbox.SkillDetail{
+		Name:         v.Name,
+		Version:      v.Version,
+		Description:  v.Description,
+		Lang:         v.Lang,
+		Instructions: v.Instructions,
+		Mode:         "executable",
+		InputSchema:  v.InputSchema,
+	})
+}
+
+func (s *Server) getSkillFiles(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.lookupVersion(w, r.PathValue("name"), r.PathValue("version"))
+	if !ok {
+		return
+	}
+	only := r.URL.Query().Get("path")
+	out := []skillbox.SkillFileEntry{}
+	for _, p := range sortedKeys(v.Files) {
+		if only == "" || p == only {
+			out = append(out, skillbox.SkillFileEntry{Path: p, Content: v.Files[p], SizeBytes: len(v.Files[p])})
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// lookupVersion finds a skill version, responding with 404 Not Found if
+// it does not exist. Callers must hold s.mu.
+func (s *Server) lookupVersion(w http.ResponseWriter, name, version string) (*skillVersion, bool) {
+	if f := s.skills[name]; f != nil {
+		if v := f.version(version); v != nil {
+			return v, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "not_found", "skill not found: "+name+"@"+version)
+	return nil, false
+}
+
+func (s *Server) uploadSkill(w http.ResponseWriter, r *http.Request) {
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "missing 'file' field in multipart form")
+		return
+	}
+	defer file.Close() //nolint:errcheck
+	data, err := io.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "failed to read upload: "+err.Error())
+		return
+	}
+	sk, err := parseSkillZip(data)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_skill", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.intake(&sk)
+	writeJSON(w, http.StatusAccepted, map[string]string{"name": sk.Name, "version": sk.Version, "status": sk.Status})
+}
+
+func (s *Server) createSkillFromFields(w http.ResponseWriter, r *http.Request) {
+	var req skillbox.CreateFromFieldsRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" || req.Description == "" || strings.TrimSpace(req.Code) == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "name, description and code are required")
+		return
+	}
+	sk := Skill{
+		Name:         req.Name,
+		Version:      req.Version,
+		Description:  req.Description,
+		Lang:         req.Lang,
+		Instructions: req.Instructions,
+	}
+	if sk.Version == "" {
+		sk.Version = defaultVersion
+	}
+	if sk.Lang == "" {
+		sk.Lang = "python"
+	}
+	entry := map[string]string{"node": "main.js", "bash": "run.sh"}[sk.Lang]
+	if entry == "" {
+		entry = "main.py"
+	}
+	sk.Files = map[string]string{entry: req.Code}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.intake(&sk)
+	sk.Files["SKILL.md"] = skillMD(sk)
+	writeJSON(w, http.StatusAccepted, skillbox.Skill{
+		Name:        sk.Name,
+		Version:     sk.Version,
+		Description: sk.Description,
+		Lang:        sk.Lang,
+		Mode:        "executable",
+		Status:      sk.Status,
+	})
+}
+
+// intake stores a new upload. An upload of an existing skill becomes the
+// next free version and inherits the handler of the active version.
+// Callers must hold s.mu.
+func (s *Server) intake(sk *Skill) {
+	if f := s.skills[sk.Name]; f != nil && len(f.versions) > 0 {
+		base := f.active()
+		if base == nil {
+			base = f.versions[len(f.versions)-1]
+		}
+		if f.version(sk.Version) != nil {
+			sk.Version = s.nextFreeVersion(sk.Name, base.Version)
+		}
+		if sk.Handler == nil {
+			sk.Handler = base.Handler
+		}
+	}
+	sk.Status = s.intakeStatus()
+	s.putSkill(*sk)
+}
+
+func (s *Server) putSkillFile(w http.ResponseWriter, r *http.Request) {
+	var req skillbox.SkillFileWrite
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Path == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "path is required")
+		return
+	}
+	s.editSkill(w, r.PathValue("name"), func(files map[string]string) map[string]string {
+		files[req.Path] = req.Content
+		return files
+	})
+}
+
+func (s *Server) putSkillFiles(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Files []skillbox.SkillFileWrite `json:"files"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	files := make(map[string]string, len(req.Files))
+	for _, f := range req.Files {
+		files[f.Path] = f.Content
+	}
+	if _, ok := files["SKILL.md"]; !ok {
+		writeError(w, http.StatusBadRequest, "bad_request", "SKILL.md is required")
+		return
+	}
+	s.editSkill(w, r.PathValue("name"), func(map[string]string) map[string]string { return files })
+}
+
+// editSkill derives a new version from the active version of a skill with
+// the files returned by edit.
+func (s *Server) editSkill(w http.ResponseWriter, name string, edit func(map[string]string) map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f := s.skills[name]
+	var base *skillVersion
+	if f != nil {
+		base = f.active()
+	}
+	if base == nil {
+		writeError(w, http.StatusNotFound, "not_found", "skill not found: "+name)
+		return
+	}
+
+	sk := base.Skill
+	sk.Version = s.nextFreeVersion(name, base.Version)
+	sk.Files = edit(maps.Clone(base.Files))
+	sk.Status = s.intakeStatus()
+	s.putSkill(sk)
+	writeJSON(w, http.StatusAccepted, map[string]string{"name": name, "version": sk.Version, "status": sk.Status})
+}
+
+func (s *Server) skillDiff(w http.ResponseWriter, r *http.Request) {
+	name, from, to := r.PathValue("name"), r.URL.Query().Get("from"), r.URL.Query().Get("to")
+	if to == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "'to' is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f := s.skills[name]
+	if f == nil || f.version(to) == nil || to == "latest" {
+		writeError(w, http.StatusNotFound, "not_found", "version not found: "+name+"@"+to)
+		return
+	}
+	newFiles := f.version(to).Files
+	oldFiles := map[string]string{}
+	if from == "" {
+		from = f.activeV
+	}
+	if v := f.version(from); v != nil && from != "" {
+		oldFiles = v.Files
+	}
+
+	diffs := []skillbox.FileDiff{}
+	for _, p := range sortedKeys(mergeKeys(oldFiles, newFiles)) {
+		oldC, hadOld := oldFiles[p]
+		newC, hadNew := newFiles[p]
+		switch {
+		case hadOld && !hadNew:
+			diffs = append(diffs, skillbox.FileDiff{Path: p, Status: "removed", Lines: lineDiff(oldC, "")})
+		case !hadOld && hadNew:
+			diffs = append(diffs, skillbox.FileDiff{Path: p, Status: "added", Lines: lineDiff("", newC)})
+		case oldC == newC:
+			diffs = append(diffs, skillbox.FileDiff{Path: p, Status: "unchanged"})
+		default:
+			diffs = append(diffs, skillbox.FileDiff{Path: p, Status: "modified", Lines: lineDiff(oldC, newC)})
+		}
+	}
+	writeJSON(w, http.StatusOK, skillbox.SkillDiffResult{Name: name, From: from, To: to, Files: diffs})
+}
+
+func (s *Server) setActiveVersion(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Version string `json:"version"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	name := r.PathValue("name")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f := s.skills[name]
+	if f == nil || req.Version == "" || f.version(req.Version) == nil {
+		writeError(w, http.StatusNotFound, "not_found", "version not found: "+name+"@"+req.Version)
+		return
+	}
+	if v := f.version(req.Version); v.Status != statusAvailable {
+		writeError(w, http.StatusConflict, "invalid_status", fmt.Sprintf("version %s is %s, not available", v.Version, v.Status))
+		return
+	}
+	f.activeV = req.Version
+	writeJSON(w, http.StatusOK, map[string]string{"name": name, "active": req.Version})
+}
+
+func (s *Server) listSkillVersions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []skillbox.SkillVersionInfo{}
+	if f := s.skills[r.PathValue("name")]; f != nil {
+		for i := len(f.versions) - 1; i >= 0; i-- {
+			v := f.versions[i]
+			out = append(out, skillbox.SkillVersionInfo{
+				Version:    v.Version,
+				Status:     v.Status,
+				Active:     v.Version == f.activeV,
+				UploadedAt: v.uploadedAt,
+			})
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) deleteSkillVersion(w http.ResponseWriter, r *http.Request) {
+	name, version := r.PathValue("name"), r.PathValue("version")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.lookupVersion(w, name, version)
+	if !ok {
+		return
+	}
+	f := s.skills[name]
+	f.versions = slices.DeleteFunc(f.versions, func(sv *skillVersion) bool { return sv == v })
+	if len(f.versions) == 0 {
+		delete(s.skills, name)
+	} else if f.activeV == v.Version {
+		// Fall back to the newest remaining available version.
+		f.activeV = ""
+		for _, sv := range f.versions {
+			if sv.Status == statusAvailable {
+				f.activeV = sv.Version
+			}
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteSkill(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.skills[name] == nil {
+		writeError(w, http.StatusNotFound, "not_found", "skill not found: "+name)
+		return
+	}
+	delete(s.skills, name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) reviewSkill(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Action string `json:"action"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	status := map[string]string{"approve": statusAvailable, "decline": statusDeclined}[req.Action]
+	if status == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "action must be 'approve' or 'decline'")
+		return
+	}
+	name, version := r.PathValue("name"), r.PathValue("version")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.lookupVersion(w, name, version)
+	if !ok {
+		return
+	}
+	v.Status = status
+	if status == statusAvailable {
+		s.skills[name].activeV = v.Version
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"name": name, "version": v.Version, "status": status})
+}
+
+// --------------------------------------------------------------------
+// Executions
+// --------------------------------------------------------------------
+
+type execution struct {
+	result  skillbox.RunResult
+	archive []byte
+	skill   string
+	version string
+	created string
+	seq     int // insertion order, for newest-first listings
+}
+
+// outputKey is the context key of a run's output file collector.
+type outputKey struct{}
+
+// outputFiles collects the files a fake skill writes with
+// [WriteOutputFile].
+type outputFiles struct {
+	mu    sync.Mutex
+	names []string
+	data  map[string][]byte
+}
+
+// WriteOutputFile adds a file to the output of the fake skill run whose
+// context is ctx, as if the skill had written it to /sandbox/out/name.
+// The files are available through [skillbox.Client.DownloadFiles] and
+// [skillbox.Client.ListFiles]; those under "session/" are also stored in
+// the run's session workspace. WriteOutputFile does nothing outside a
+// [SkillHandler].
+func WriteOutputFile(ctx context.Context, name string, data []byte) {
+	out, ok := ctx.Value(outputKey{}).(*outputFiles)
+	if !ok {
+		return
+	}
+	name = path.Clean(strings.TrimPrefix(name, "/"))
+	out.mu.Lock()
+	defer out.mu.Unlock()
+	if _, exists := out.data[name]; !exists {
+		out.names = append(out.names, name)
+	}
+	out.data[name] = bytes.Clone(data)
+}
+
+func (s *Server) run(w http.ResponseWriter, r *http.Request) {
+	var req skillbox.RunRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Skill == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "'skill' is required")
+		return
+	}
+	if req.Version == "" {
+		req.Version = "latest"
+	}
+
+	s.mu.Lock()
+	f := s.skills[req.Skill]
+	var v *skillVersion
+	if f != nil {
+		v = f.version(req.Version)
+	}
+	if v == nil {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, "not_found", "skill not found: "+req.Skill+"@"+req.Version)
+		return
+	}
+	if v.Status != statusAvailable {
+		s.mu.Unlock()
+		writeError(w, http.StatusConflict, "skill_not_available",
+			fmt.Sprintf("skill not available: %s@%s is %s", req.Skill, v.Version, v.Status))
+		return
+	}
+	for _, id := range req.InputFiles {
+		if s.files[id] == nil {
+			s.mu.Unlock()
+			writeError(w, http.StatusBadRequest, "bad_request", "input file not found: "+id)
+			return
+		}
+	}
+	handler := v.Handler
+	req.Version = v.Version
+	s.mu.Unlock()
+
+	out := &outputFiles{data: make(map[string][]byte)}
+	ctx := context.WithValue(r.Context(), outputKey{}, out)
+	start := time.Now()
+	result := &skillbox.RunResult{Output: json.RawMessage("null")}
+	if handler != nil {
+		res, err := handler(ctx, req)
+		if err != nil {
+			var apiErr *skillbox.APIError
+			if errors.As(err, &apiErr) {
+				writeAPIError(w, apiErr)
+				return
+			}
+			writeJSON(w, http.StatusInternalServerError, skillbox.RunResult{Status: "failed", Error: err.Error()})
+			return
+		}
+		if res != nil {
+			result = res
+		}
+	}
+	if result.Status == "" {
+		result.Status = "success"
+	}
+	if result.DurationMs == 0 {
+		result.DurationMs = time.Since(start).Milliseconds()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if result.ExecutionID == "" {
+		result.ExecutionID = s.nextID("exec")
+	}
+	exec := &execution{result: *result, skill: req.Skill, version: req.Version, created: now(), seq: len(s.executions)}
+	if len(out.names) > 0 {
+		exec.archive = tarGz(out.names, out.data)
+		exec.result.FilesURL = s.URL + "/objects/" + result.ExecutionID + "/files.tar.gz"
+		exec.result.FilesList = out.names
+		for _, name := range out.names {
+			s.addFile(name, out.data[name], req.SessionID, result.ExecutionID)
+			if rel, ok := strings.CutPrefix(name, "session/"); ok && req.SessionID != "" {
+				s.sandboxFS(req.SessionID)[sessionDir+"/"+rel] = out.data[name]
+			}
+		}
+	}
+	s.executions[result.ExecutionID] = exec
+	writeJSON(w, http.StatusOK, exec.result)
+}
+
+func (s *Server) getExecution(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	exec := s.executions[r.PathValue("id")]
+	if exec == nil {
+		writeError(w, http.StatusNotFound, "not_found", "execution not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, exec.result)
+}
+
+func (s *Server) listExecutions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit, err := strconv.Atoi(q.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+	limit = min(limit, 100)
+	offset, err := strconv.Atoi(q.Get("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matches []*execution
+	for _, e := range s.executions {
+		if skill := q.Get("skill"); skill != "" && e.skill != skill {
+			continue
+		}
+		if status := q.Get("status"); status != "" && e.result.Status != status {
+			continue
+		}
+		matches = append(matches, e)
+	}
+	slices.SortFunc(matches, func(a, b *execution) int { return b.seq - a.seq })
+
+	out := []skillbox.Execution{}
+	for _, e := range matches[min(offset, len(matches)):min(offset+limit, len(matches))] {
+		out = append(out, skillbox.Execution{
+			ExecutionID:  e.result.ExecutionID,
+			SkillName:    e.skill,
+			SkillVersion: e.version,
+			Status:       e.result.Status,
+			Output:       e.result.Output,
+			Logs:         e.result.Logs,
+			FilesURL:     e.result.FilesURL,
+			FilesList:    e.result.FilesList,
+			DurationMs:   e.result.DurationMs,
+			Error:        e.result.Error,
+			Partial:      e.result.Partial,
+			CreatedAt:    e.created,
+			FinishedAt:   e.created,
+		})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// cancelExecution always answers 409: fake runs complete before the run
+// request returns, so there is never a running execution to stop.
+func (s *Server) cancelExecution(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.executions[r.PathValue("id")] == nil {
+		writeError(w, http.StatusNotFound, "not_found", "execution not found")
+		return
+	}
+	writeError(w, http.StatusConflict, "not_running", "execution is not running")
+}
+
+func (s *Server) getExecutionLogs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	exec := s.executions[r.PathValue("id")]
+	if exec == nil {
+		writeError(w, http.StatusNotFound, "not_found", "execution not found")
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = io.WriteString(w, exec.result.Logs)
+}
+
+// getExecutionFiles serves the output archive that FilesURL points to,
+// standing in for a pre-signed object storage URL.
+func (s *Server) getExecutionFiles(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	exec := s.executions[r.PathValue("id")]
+	if exec == nil || exec.archive == nil {
+		writeError(w, http.StatusNotFound, "not_found", "no output files")
+		return
+	}
+	w.Header().Set("Content-Type", "application/gzip")
+	_, _ = w.Write(exec.archive)
+}
+
+// --------------------------------------------------------------------
+// Helpers
+// --------------------------------------------------------------------
+
+// skillMD renders the SKILL.md of a skill like the server's builder.
+func skillMD(sk Skill) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "name: %q\n", sk.Name)
+	fmt.Fprintf(&b, "description: %q\n", sk.Description)
+	if sk.Lang != "" {
+		fmt.Fprintf(&b, "lang: %q\n", sk.Lang)
+	}
+	fmt.Fprintf(&b, "version: %q\n", sk.Version)
+	b.WriteString("---\n\n")
+	if sk.Instructions != "" {
+		b.WriteString(sk.Instructions + "\n")
+	}
+	return b.String()
+}
+
+// parseSkillZip reads a skill archive. Only flat "key: value" frontmatter
+// fields are understood.
+func parseSkillZip(data []byte) (Skill, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return Skill{}, fmt.Errorf("invalid zip archive: %w", err)
+	}
+	files := make(map[string]string)
+	for _, zf := range zr.File {
+		if zf.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return Skill{}, err
+		}
+		content, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return Skill{}, err
+		}
+		files[strings.TrimPrefix(zf.Name, "./")] = string(content)
+	}
+
+	// Archives often wrap everything in one top-level directory.
+	md, ok := files["SKILL.md"]
+	if !ok {
+		for p, c := range files {
+			if path.Base(p) == "SKILL.md" && strings.Count(p, "/") == 1 {
+				prefix := path.Dir(p) + "/"
+				md = c
+				unwrapped := make(map[string]string, len(files))
+				for q, qc := range files {
+					unwrapped[strings.TrimPrefix(q, prefix)] = qc
+				}
+				files = unwrapped
+				ok = true
+				break
+			}
+		}
+	}
+	if !ok {
+		return Skill{}, errors.New("SKILL.md not found in archive")
+	}
+
+	sk := Skill{Files: files, Version: defaultVersion, Lang: "python"}
+	body, found := strings.CutPrefix(md, "---\n")
+	if !found {
+		return Skill{}, errors.New("SKILL.md has no frontmatter")
+	}
+	front, rest, _ := strings.Cut(body, "\n---")
+	sk.Instructions = strings.TrimSpace(rest)
+	for _, line := range strings.Split(front, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if unq, err := strconv.Unquote(value); err == nil {
+			value = unq
+		} else {
+			value = strings.Trim(value, `'`)
+		}
+		switch strings.TrimSpace(key) {
+		case "name":
+			sk.Name = value
+		case "version":
+			sk.Version = value
+		case "description":
+			sk.Description = value
+		case "lang":
+			sk.Lang = value
+		}
+	}
+	if sk.Name == "" {
+		return Skill{}, errors.New("SKILL.md frontmatter has no name")
+	}
+	return sk, nil
+}
+
+// tarGz archives files in order as a gzipped tarball.
+func tarGz(names []string, data map[string][]byte) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range names {
+		_ = tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data[name])), Typeflag: tar.TypeReg})
+		_, _ = tw.Write(data[name])
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+	return buf.Bytes()
+}
+
+// lineDiff computes a line-level diff of two texts from their longest
+// common subsequence.
+func lineDiff(oldText, newText string) []skillbox.DiffLine {
+	var a, b []string
+	if oldText != "" {
+		a = strings.Split(oldText, "\n")
+	}
+	if newText != "" {
+		b = strings.Split(newText, "\n")
+	}
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out []skillbox.DiffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, skillbox.DiffLine{Op: " ", Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, skillbox.DiffLine{Op: "-", Text: a[i]})
+			i++
+		default:
+			out = append(out, skillbox.DiffLine{Op: "+", Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, skillbox.DiffLine{Op: "-", Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		out = append(out, skillbox.DiffLine{Op: "+", Text: b[j]})
+	}
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func mergeKeys(a, b map[string]string) map[string]struct{} {
+	out := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		out[k] = struct{}{}
+	}
+	for k := range b {
+		out[k] = struct{}{}
+	}
+	return out
+}
@@ -0,0 +1,132 @@
+package skillbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ErrUnknownSkillTool is returned when a [SkillDispatcher] receives a tool
+// name that does not belong to a skill.
+var ErrUnknownSkillTool = errors.New("unknown skill tool")
+
+// ToolFormat selects the provider dialect of the definitions returned by
+// [Client.ListTools].
+type ToolFormat string
+
+// Supported tool formats.
+const (
+	ToolFormatOpenAI    ToolFormat = "openai"
+	ToolFormatAnthropic ToolFormat = "anthropic"
+	ToolFormatGemini    ToolFormat = "gemini"
+	ToolFormatLangChain ToolFormat = "langchain"
+)
+
+// ToolSet is the tenant's available skills as tool definitions in one
+// provider format.
+type ToolSet struct {
+	Format ToolFormat `json:"format"`
+
+	// Tools is the value of the provider's "tools" request field, ready to
+	// send as is.
+	Tools json.RawMessage `json:"tools"`
+
+	// Skills maps each tool name to the skill version it runs. Tool names
+	// can differ from skill names because each provider restricts them.
+	Skills map[string]ToolSkill `json:"skills"`
+}
+
+// ToolSkill identifies the skill version behind a tool.
+type ToolSkill struct {
+	Skill   string `json:"skill"`
+	Version string `json:"version"`
+}
+
+// ListTools returns the tenant's available skills as tool definitions in
+// the given format.
+func (c *Client) ListTools(ctx context.Context, format ToolFormat) (*ToolSet, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v1/tools?format="+url.QueryEscape(string(format)), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var set ToolSet
+	if err := c.decodeResponse(resp, &set); err != nil {
+		return nil, err
+	}
+	return &set, nil
+}
+
+// SkillDispatcher maps LLM tool calls for skills back to [Client.Run].
+// Create one from the [ToolSet] whose definitions were sent to the model:
+//
+//	set, _ := client.ListTools(ctx, skillbox.ToolFormatOpenAI)
+//	d := skillbox.NewSkillDispatcher(client, set)
+//	// ... send set.Tools to the model, then for each tool call:
+//	if d.IsSkillTool(call.Name) {
+//	    result, err := d.Dispatch(ctx, call.Name, call.Arguments)
+//	}
+type SkillDispatcher struct {
+	client    *Client
+	tools     *ToolSet
+	sessionID string
+}
+
+// DispatchOption configures a [SkillDispatcher].
+type DispatchOption func(*SkillDispatcher)
+
+// WithDispatchSession links every dispatched run to a session workspace.
+func WithDispatchSession(sessionID string) DispatchOption {
+	return func(d *SkillDispatcher) { d.sessionID = sessionID }
+}
+
+// NewSkillDispatcher creates a dispatcher for the tools in set.
+func NewSkillDispatcher(client *Client, set *ToolSet, opts ...DispatchOption) *SkillDispatcher {
+	d := &SkillDispatcher{client: client, tools: set}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// IsSkillTool reports whether name is one of the dispatcher's skill tools.
+func (d *SkillDispatcher) IsSkillTool(name string) bool {
+	_, ok := d.tools.Skills[name]
+	return ok
+}
+
+// Dispatch runs the skill behind a tool call with args as its input. args
+// may be a JSON object or, as OpenAI sends them, a JSON string containing
+// one. The skill runs at the version the definitions were listed for.
+func (d *SkillDispatcher) Dispatch(ctx context.Context, name string, args json.RawMessage) (*RunResult, error) {
+	ref, ok := d.tools.Skills[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSkillTool, name)
+	}
+
+	input := args
+	if len(input) > 0 && input[0] == '"' {
+		var s string
+		if err := json.Unmarshal(input, &s); err != nil {
+			return nil, fmt.Errorf("skillbox: decode tool arguments: %w", err)
+		}
+		input = json.RawMessage(s)
+	}
+	if len(input) == 0 {
+		input = json.RawMessage("{}")
+	}
+	if !json.Valid(input) {
+		return nil, fmt.Errorf("skillbox: tool arguments for %s are not valid JSON", name)
+	}
+
+	return d.client.Run(ctx, RunRequest{
+		Skill:     ref.Skill,
+		Version:   ref.Version,
+		Input:     input,
+		SessionID: d.sessionID,
+	})
+}
@@ -0,0 +1,66 @@
+package skillbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSkillDispatcher(t *testing.T) {
+	var runs []RunRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/tools":
+			if got := r.URL.Query().Get("format"); got != "anthropic" {
+				t.Errorf("format = %q, want anthropic", got)
+			}
+			_, _ = w.Write([]byte(`{"format":"anthropic","tools":[{"name":"pdf_summary","description":"d","input_schema":{"type":"object"}}],` +
+				`"skills":{"pdf_summary":{"skill":"pdf.summary","version":"1.2.0"}}}`))
+		case "/v1/executions":
+			var req RunRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode request body: %v", err)
+			}
+			runs = append(runs, req)
+			_, _ = w.Write([]byte(`{"execution_id":"exec-1","status":"success"}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client := New(srv.URL, "sk-test")
+	set, err := client.ListTools(context.Background(), ToolFormatAnthropic)
+	if err != nil {
+		t.Fatalf("ListTools: %v", err)
+	}
+	d := NewSkillDispatcher(client, set, WithDispatchSession("sess-1"))
+	if !d.IsSkillTool("pdf_summary") || d.IsSkillTool("bash") {
+		t.Error("IsSkillTool mismatch")
+	}
+
+	// OpenAI sends arguments as a JSON-encoded string.
+	if _, err := d.Dispatch(context.Background(), "pdf_summary", json.RawMessage(`"{\"url\":\"https://x\"}"`)); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if _, err := d.Dispatch(context.Background(), "pdf_summary", nil); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("got %d runs, want 2", len(runs))
+	}
+	if r := runs[0]; r.Skill != "pdf.summary" || r.Version != "1.2.0" || r.SessionID != "sess-1" || string(r.Input) != `{"url":"https://x"}` {
+		t.Errorf("run = %+v", r)
+	}
+	if string(runs[1].Input) != `{}` {
+		t.Errorf("empty args input = %s", runs[1].Input)
+	}
+
+	if _, err := d.Dispatch(context.Background(), "bash", nil); !errors.Is(err, ErrUnknownSkillTool) {
+		t.Errorf("err = %v, want ErrUnknownSkillTool", err)
+	}
+}
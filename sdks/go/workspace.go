@@ -53,7 +53,9 @@ type FileRef struct {
 }
 
 // ToolDefinitions returns LLM tool definitions for workspace tools.
-// Returns: bash, read_file, write_file, ls, present_files.
+// Returns: bash, read_file, write_file, edit_file, ls, grep, present_files,
+// start_process, list_processes, read_process_output, signal_process,
+// wait_process.
 func (t *WorkspaceToolkit) ToolDefinitions() []ToolDefinition {
 	return workspaceToolDefs
 }
@@ -68,10 +70,24 @@ func (t *WorkspaceToolkit) Handle(ctx context.Context, toolName string, args jso
 		output, err = t.handleReadFile(ctx, args)
 	case "write_file":
 		output, err = t.handleWriteFile(ctx, args)
+	case "edit_file":
+		output, err = t.handleEditFile(ctx, args)
 	case "ls":
 		output, err = t.handleListDir(ctx, args)
+	case "grep":
+		output, err = t.handleGrep(ctx, args)
 	case "present_files":
 		return t.handlePresentFiles(ctx, args)
+	case "start_process":
+		output, err = t.handleStartProcess(ctx, args)
+	case "list_processes":
+		output, err = t.handleListProcesses(ctx)
+	case "read_process_output":
+		output, err = t.handleReadProcessOutput(ctx, args)
+	case "signal_process":
+		output, err = t.handleSignalProcess(ctx, args)
+	case "wait_process":
+		output, err = t.handleWaitProcess(ctx, args)
 	default:
 		return "", nil, ErrUnknownTool
 	}
@@ -86,7 +102,8 @@ func (t *WorkspaceToolkit) IsWorkspaceTool(toolName string) bool {
 // IsWorkspaceTool is a package-level convenience function.
 func IsWorkspaceTool(name string) bool {
 	switch name {
-	case "bash", "read_file", "write_file", "ls", "present_files":
+	case "bash", "read_file", "write_file", "edit_file", "ls", "grep", "present_files",
+		"start_process", "list_processes", "read_process_output", "signal_process", "wait_process":
 		return true
 	}
 	return false
@@ -196,6 +213,45 @@ func (t *WorkspaceToolkit) handleWriteFile(ctx context.Context, args json.RawMes
 	return fmt.Sprintf("File written to %s", a.Path), nil
 }
 
+func (t *WorkspaceToolkit) handleEditFile(ctx context.Context, args json.RawMessage) (string, error) {
+	var a struct {
+		Path       string  `json:"path"`
+		OldString  *string `json:"old_string"`
+		NewString  string  `json:"new_string"`
+		ReplaceAll bool    `json:"replace_all"`
+		Diff       string  `json:"diff"`
+	}
+	if err := json.Unmarshal(args, &a); err != nil {
+		return "invalid arguments: " + err.Error(), nil
+	}
+	if err := validateSandboxPath(a.Path); err != nil {
+		return err.Error(), nil
+	}
+	if (a.OldString == nil) == (a.Diff == "") {
+		return "provide either old_string and new_string, or diff", nil
+	}
+
+	if a.Diff != "" {
+		edits, err := t.client.SandboxApplyPatch(ctx, t.sessionID, a.Diff, a.Path)
+		if err != nil {
+			return describeEditFailure(err), nil
+		}
+		hunks := 0
+		for _, e := range edits {
+			hunks += e.Hunks
+		}
+		return fmt.Sprintf("Applied %d hunk(s) to %s", hunks, a.Path), nil
+	}
+
+	edit, err := t.client.SandboxEditFile(ctx, t.sessionID, a.Path, []SandboxEditReplacement{
+		{OldString: *a.OldString, NewString: a.NewString, ReplaceAll: a.ReplaceAll},
+	})
+	if err != nil {
+		return describeEditFailure(err), nil
+	}
+	return fmt.Sprintf("Edited %s (%d replacement(s))", a.Path, edit.Replacements), nil
+}
+
 func (t *WorkspaceToolkit) handleListDir(ctx context.Context, args json.RawMessage) (string, error) {
 	var a struct {
 		Path string `json:"path"`
@@ -234,6 +290,56 @@ func (t *WorkspaceToolkit) handleListDir(ctx context.Context, args json.RawMessa
 	return out.String(), nil
 }
 
+func (t *WorkspaceToolkit) handleGrep(ctx context.Context, args json.RawMessage) (string, error) {
+	var a struct {
+		Pattern      string `json:"pattern"`
+		Path         string `json:"path"`
+		Glob         string `json:"glob"`
+		IgnoreCase   bool   `json:"ignore_case"`
+		ContextLines int    `json:"context_lines"`
+	}
+	if err := json.Unmarshal(args, &a); err != nil {
+		return "invalid arguments: " + err.Error(), nil
+	}
+	if a.Pattern == "" {
+		return "pattern is required", nil
+	}
+	if a.Path == "" {
+		a.Path = "/sandbox/session"
+	}
+	if err := validateSandboxPath(a.Path); err != nil {
+		return err.Error(), nil
+	}
+
+	res, err := t.client.SandboxSearch(ctx, t.sessionID, SandboxSearchRequest{
+		Path:         a.Path,
+		Query:        a.Pattern,
+		Glob:         a.Glob,
+		Regex:        true,
+		IgnoreCase:   a.IgnoreCase,
+		ContextLines: a.ContextLines,
+	})
+	if err != nil {
+		return fmt.Sprintf("search failed: %s", err), nil
+	}
+	if len(res.Matches) == 0 {
+		return "(no matches)", nil
+	}
+
+	var out strings.Builder
+	for _, m := range res.Matches {
+		if a.ContextLines > 0 {
+			fmt.Fprintf(&out, "%s:%d:\n%s\n--\n", m.Path, m.Line, m.Snippet)
+		} else {
+			fmt.Fprintf(&out, "%s:%d: %s\n", m.Path, m.Line, m.Snippet)
+		}
+	}
+	if res.Truncated {
+		out.WriteString("[results truncated; narrow the search with path or glob]\n")
+	}
+	return out.String(), nil
+}
+
 // presentableSources maps the `source` enum exposed to the LLM to the absolute sandbox dir it resolves to. Adding a new presentable surface (drive/, scratch/, ...) is a single map entry — no new tool, no schema migration, no allowlist string-prefix gotchas.
 var presentableSources = map[string]string{
 	"outputs": "/sandbox/session/outputs/",
@@ -314,10 +420,147 @@ func (t *WorkspaceToolkit) handlePresentFiles(ctx context.Context, args json.Raw
 	return output, refs, nil
 }
 
+func (t *WorkspaceToolkit) handleStartProcess(ctx context.Context, args json.RawMessage) (string, error) {
+	var a struct {
+		Command string `json:"command"`
+		WorkDir string `json:"workdir"`
+	}
+	if err := json.Unmarshal(args, &a); err != nil {
+		return "invalid arguments: " + err.Error(), nil
+	}
+	if strings.TrimSpace(a.Command) == "" {
+		return "command is required", nil
+	}
+	if a.WorkDir != "" {
+		if err := validateSandboxPath(a.WorkDir); err != nil {
+			return err.Error(), nil
+		}
+	}
+
+	p, err := t.client.SandboxStartProcess(ctx, t.sessionID, a.Command, a.WorkDir)
+	if err != nil {
+		return fmt.Sprintf("failed to start process: %s", err), nil
+	}
+	return fmt.Sprintf("Started process %s (pid %d). Use read_process_output to see its output.", p.ID, p.PID), nil
+}
+
+func (t *WorkspaceToolkit) handleListProcesses(ctx context.Context) (string, error) {
+	procs, err := t.client.SandboxListProcesses(ctx, t.sessionID)
+	if err != nil {
+		return fmt.Sprintf("failed to list processes: %s", err), nil
+	}
+	if len(procs) == 0 {
+		return "(no processes)", nil
+	}
+
+	var out strings.Builder
+	for _, p := range procs {
+		fmt.Fprintf(&out, "%s  %s  %s\n", p.ID, describeProcessState(p.State, p.ExitCode), p.Command)
+	}
+	return out.String(), nil
+}
+
+func (t *WorkspaceToolkit) handleReadProcessOutput(ctx context.Context, args json.RawMessage) (string, error) {
+	var a struct {
+		ProcessID string `json:"process_id"`
+		Offset    int64  `json:"offset"`
+	}
+	if err := json.Unmarshal(args, &a); err != nil {
+		return "invalid arguments: " + err.Error(), nil
+	}
+	if a.ProcessID == "" {
+		return "process_id is required", nil
+	}
+
+	chunk, err := t.client.SandboxProcessOutput(ctx, t.sessionID, a.ProcessID, a.Offset, 0)
+	if err != nil {
+		return fmt.Sprintf("failed to read process output: %s", err), nil
+	}
+
+	var out strings.Builder
+	if chunk.Data == "" {
+		out.WriteString("(no new output)")
+	} else {
+		out.WriteString(chunk.Data)
+	}
+	fmt.Fprintf(&out, "\n[%s; next offset: %d", describeProcessState(chunk.State, chunk.ExitCode), chunk.Offset)
+	if chunk.Done {
+		out.WriteString("; all output read")
+	}
+	out.WriteString("]")
+	return out.String(), nil
+}
+
+func (t *WorkspaceToolkit) handleSignalProcess(ctx context.Context, args json.RawMessage) (string, error) {
+	var a struct {
+		ProcessID string `json:"process_id"`
+		Signal    string `json:"signal"`
+	}
+	if err := json.Unmarshal(args, &a); err != nil {
+		return "invalid arguments: " + err.Error(), nil
+	}
+	if a.ProcessID == "" {
+		return "process_id is required", nil
+	}
+	if a.Signal == "" {
+		a.Signal = "TERM"
+	}
+
+	if err := t.client.SandboxSignalProcess(ctx, t.sessionID, a.ProcessID, a.Signal); err != nil {
+		return fmt.Sprintf("failed to signal process: %s", err), nil
+	}
+	return fmt.Sprintf("Sent SIG%s to process %s", strings.TrimPrefix(strings.ToUpper(a.Signal), "SIG"), a.ProcessID), nil
+}
+
+func (t *WorkspaceToolkit) handleWaitProcess(ctx context.Context, args json.RawMessage) (string, error) {
+	var a struct {
+		ProcessID      string `json:"process_id"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	if err := json.Unmarshal(args, &a); err != nil {
+		return "invalid arguments: " + err.Error(), nil
+	}
+	if a.ProcessID == "" {
+		return "process_id is required", nil
+	}
+
+	p, err := t.client.SandboxWaitProcess(ctx, t.sessionID, a.ProcessID, time.Duration(a.TimeoutSeconds)*time.Second)
+	if err != nil {
+		return fmt.Sprintf("failed to wait for process: %s", err), nil
+	}
+	return fmt.Sprintf("Process %s: %s", p.ID, describeProcessState(p.State, p.ExitCode)), nil
+}
+
 // --------------------------------------------------------------------
 // Helpers
 // --------------------------------------------------------------------
 
+func describeProcessState(state string, exitCode *int) string {
+	if state == "exited" && exitCode != nil {
+		return fmt.Sprintf("exited with code %d", *exitCode)
+	}
+	return state
+}
+
+// describeEditFailure turns a failed edit into tool output, listing each
+// conflict so the model can correct its edit.
+func describeEditFailure(err error) string {
+	conflicts := EditConflicts(err)
+	if len(conflicts) == 0 {
+		return fmt.Sprintf("failed to edit file: %s", err)
+	}
+	var out strings.Builder
+	out.WriteString("Edit not applied; the file is unchanged:")
+	for _, c := range conflicts {
+		out.WriteString("\n- ")
+		if c.Hunk > 0 {
+			fmt.Fprintf(&out, "hunk %d (line %d): ", c.Hunk, c.Line)
+		}
+		out.WriteString(c.Reason)
+	}
+	return out.String()
+}
+
 func validateSandboxPath(p string) error {
 	if p == "" {
 		return fmt.Errorf("invalid path: path is empty")
@@ -429,6 +672,36 @@ var workspaceToolDefs = []ToolDefinition{
 			"required": []string{"path", "content"},
 		},
 	},
+	{
+		Name:        "edit_file",
+		Description: "Edit a file in the workspace without rewriting it. Either replace old_string with new_string, or apply a unified diff. old_string must match the file exactly, including whitespace, and occur once unless replace_all is true. If the edit does not apply, the file is left unchanged and the reason is returned.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"path": map[string]any{
+					"type":        "string",
+					"description": "Absolute path to the file (e.g. /sandbox/session/app.py).",
+				},
+				"old_string": map[string]any{
+					"type":        "string",
+					"description": "Exact text to replace. Include enough surrounding lines to make it unique.",
+				},
+				"new_string": map[string]any{
+					"type":        "string",
+					"description": "Replacement text.",
+				},
+				"replace_all": map[string]any{
+					"type":        "boolean",
+					"description": "Replace every occurrence of old_string. Defaults to false.",
+				},
+				"diff": map[string]any{
+					"type":        "string",
+					"description": "A unified diff for this file, as an alternative to old_string and new_string.",
+				},
+			},
+			"required": []string{"path"},
+		},
+	},
 	{
 		Name:        "ls",
 		Description: "List files and directories at the given workspace path.",
@@ -443,6 +716,36 @@ var workspaceToolDefs = []ToolDefinition{
 			"required": []string{"path"},
 		},
 	},
+	{
+		Name:        "grep",
+		Description: "Search file contents in the workspace with a regular expression. Returns matching lines as path:line: text. Skips binary files and .git, node_modules and __pycache__ directories.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"pattern": map[string]any{
+					"type":        "string",
+					"description": "RE2 regular expression to search for.",
+				},
+				"path": map[string]any{
+					"type":        "string",
+					"description": "Directory to search. Defaults to /sandbox/session.",
+				},
+				"glob": map[string]any{
+					"type":        "string",
+					"description": "Only search matching files, e.g. \"*.py\" or \"src/*.ts\".",
+				},
+				"ignore_case": map[string]any{
+					"type":        "boolean",
+					"description": "Match case-insensitively. Defaults to false.",
+				},
+				"context_lines": map[string]any{
+					"type":        "integer",
+					"description": "Lines of context to show around each match (max 5). Defaults to 0.",
+				},
+			},
+			"required": []string{"pattern"},
+		},
+	},
 	{
 		Name:        "present_files",
 		Description: "Present files to the user as downloadable artifacts. Pick the source by intent: 'outputs' for files you generated this turn, 'uploads' to re-display a file the user uploaded earlier in this session.",
@@ -463,4 +766,85 @@ var workspaceToolDefs = []ToolDefinition{
 			"required": []string{"source", "filenames"},
 		},
 	},
+	{
+		Name:        "start_process",
+		Description: "Start a long-running command (dev server, file watcher, build) in the background and return immediately with a process ID. Use bash instead for commands that finish quickly.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"command": map[string]any{
+					"type":        "string",
+					"description": "The bash command to run in the background.",
+				},
+				"workdir": map[string]any{
+					"type":        "string",
+					"description": "Working directory under /sandbox/session. Defaults to /sandbox/session.",
+				},
+			},
+			"required": []string{"command"},
+		},
+	},
+	{
+		Name:        "list_processes",
+		Description: "List background processes started in this workspace with their state.",
+		Parameters: map[string]any{
+			"type":       "object",
+			"properties": map[string]any{},
+		},
+	},
+	{
+		Name:        "read_process_output",
+		Description: "Read a background process's combined stdout and stderr. Each call returns the next offset; pass it back to read only new output.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"process_id": map[string]any{
+					"type":        "string",
+					"description": "ID returned by start_process.",
+				},
+				"offset": map[string]any{
+					"type":        "integer",
+					"description": "Byte offset to read from. Defaults to 0 (the beginning).",
+				},
+			},
+			"required": []string{"process_id"},
+		},
+	},
+	{
+		Name:        "signal_process",
+		Description: "Send a signal to a background process, e.g. TERM to stop it or INT to interrupt it.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"process_id": map[string]any{
+					"type":        "string",
+					"description": "ID returned by start_process.",
+				},
+				"signal": map[string]any{
+					"type":        "string",
+					"enum":        []string{"TERM", "INT", "KILL", "HUP", "QUIT", "USR1", "USR2", "STOP", "CONT"},
+					"description": "Signal to send. Defaults to TERM.",
+				},
+			},
+			"required": []string{"process_id"},
+		},
+	},
+	{
+		Name:        "wait_process",
+		Description: "Wait for a background process to exit and report its exit code. Returns early with state 'running' if it is still running when the timeout elapses.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"process_id": map[string]any{
+					"type":        "string",
+					"description": "ID returned by start_process.",
+				},
+				"timeout_seconds": map[string]any{
+					"type":        "integer",
+					"description": "Maximum time to wait (max 300). Defaults to 30.",
+				},
+			},
+			"required": []string{"process_id"},
+		},
+	},
 }
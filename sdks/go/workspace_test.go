@@ -11,7 +11,8 @@ import (
 )
 
 func TestIsWorkspaceTool(t *testing.T) {
-	yes := []string{"bash", "read_file", "write_file", "ls", "present_files"}
+	yes := []string{"bash", "read_file", "write_file", "edit_file", "ls", "grep", "present_files",
+		"start_process", "list_processes", "read_process_output", "signal_process", "wait_process"}
 	for _, name := range yes {
 		if !IsWorkspaceTool(name) {
 			t.Errorf("IsWorkspaceTool(%q) = false, want true", name)
@@ -30,8 +31,8 @@ func TestToolDefinitions(t *testing.T) {
 	toolkit := NewWorkspaceToolkit(client, "sess-1")
 	defs := toolkit.ToolDefinitions()
 
-	if len(defs) != 5 {
-		t.Fatalf("got %d tool definitions, want 5", len(defs))
+	if len(defs) != 12 {
+		t.Fatalf("got %d tool definitions, want 12", len(defs))
 	}
 
 	names := map[string]bool{}
@@ -45,7 +46,8 @@ func TestToolDefinitions(t *testing.T) {
 		}
 	}
 
-	expected := []string{"bash", "read_file", "write_file", "ls", "present_files"}
+	expected := []string{"bash", "read_file", "write_file", "edit_file", "ls", "grep", "present_files",
+		"start_process", "list_processes", "read_process_output", "signal_process", "wait_process"}
 	for _, name := range expected {
 		if !names[name] {
 			t.Errorf("missing tool definition for %q", name)
@@ -237,6 +239,170 @@ func TestHandle_ListDir(t *testing.T) {
 	}
 }
 
+func TestHandle_StartProcess(t *testing.T) {
+	var gotCommand string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/sandbox/processes" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		gotCommand, _ = body["command"].(string)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(SandboxProcess{ID: "proc-1", PID: 42, State: "running"})
+	}))
+	defer srv.Close() //nolint:errcheck
+
+	toolkit := NewWorkspaceToolkit(New(srv.URL, "sk-test"), "sess-1")
+	output, _, err := toolkit.Handle(context.Background(), "start_process",
+		json.RawMessage(`{"command": "npm run dev"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotCommand != "npm run dev" {
+		t.Errorf("command = %q", gotCommand)
+	}
+	if !strings.Contains(output, "proc-1") || !strings.Contains(output, "pid 42") {
+		t.Errorf("output = %q", output)
+	}
+}
+
+func TestHandle_StartProcess_BadWorkDir(t *testing.T) {
+	toolkit := NewWorkspaceToolkit(New("http://localhost", "sk-test"), "sess-1")
+	output, _, _ := toolkit.Handle(context.Background(), "start_process",
+		json.RawMessage(`{"command": "ls", "workdir": "/etc"}`))
+	if !strings.Contains(output, "invalid path") {
+		t.Errorf("output = %q", output)
+	}
+}
+
+func TestHandle_ReadProcessOutput(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/sandbox/processes/proc-1/output" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("offset"); got != "128" {
+			t.Errorf("offset = %q, want 128", got)
+		}
+		code := 0
+		_ = json.NewEncoder(w).Encode(SandboxProcessOutput{
+			Data: "server stopped\n", Offset: 143, State: "exited", ExitCode: &code, Done: true,
+		})
+	}))
+	defer srv.Close() //nolint:errcheck
+
+	toolkit := NewWorkspaceToolkit(New(srv.URL, "sk-test"), "sess-1")
+	output, _, _ := toolkit.Handle(context.Background(), "read_process_output",
+		json.RawMessage(`{"process_id": "proc-1", "offset": 128}`))
+
+	if !strings.Contains(output, "server stopped") {
+		t.Errorf("output missing process output: %q", output)
+	}
+	if !strings.Contains(output, "exited with code 0") || !strings.Contains(output, "next offset: 143") || !strings.Contains(output, "all output read") {
+		t.Errorf("output missing status footer: %q", output)
+	}
+}
+
+func TestHandle_WaitProcess(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/sandbox/processes/proc-1/wait" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if got, _ := body["timeout_ms"].(float64); got != 10000 {
+			t.Errorf("timeout_ms = %v, want 10000", body["timeout_ms"])
+		}
+		_ = json.NewEncoder(w).Encode(SandboxProcess{ID: "proc-1", State: "running"})
+	}))
+	defer srv.Close() //nolint:errcheck
+
+	toolkit := NewWorkspaceToolkit(New(srv.URL, "sk-test"), "sess-1")
+	output, _, _ := toolkit.Handle(context.Background(), "wait_process",
+		json.RawMessage(`{"process_id": "proc-1", "timeout_seconds": 10}`))
+
+	if output != "Process proc-1: running" {
+		t.Errorf("output = %q", output)
+	}
+}
+
+func TestHandle_Grep(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/sandbox/search" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		var req SandboxSearchRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Query != "def \\w+" || !req.Regex || req.Path != "/sandbox/session" || req.Glob != "*.py" {
+			t.Errorf("request = %+v", req)
+		}
+		_ = json.NewEncoder(w).Encode(SandboxSearchResult{
+			Matches:   []SandboxSearchMatch{{Path: "/sandbox/session/app.py", Line: 3, Snippet: "def main():"}},
+			Truncated: true,
+		})
+	}))
+	defer srv.Close() //nolint:errcheck
+
+	toolkit := NewWorkspaceToolkit(New(srv.URL, "sk-test"), "sess-1")
+	output, _, err := toolkit.Handle(context.Background(), "grep",
+		json.RawMessage(`{"pattern": "def \\w+", "glob": "*.py"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(output, "/sandbox/session/app.py:3: def main():\n") || !strings.Contains(output, "results truncated") {
+		t.Errorf("output = %q", output)
+	}
+}
+
+func TestHandle_EditFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/sandbox/edit" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		var body struct {
+			Path  string                   `json:"path"`
+			Edits []SandboxEditReplacement `json:"edits"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Path != "/sandbox/session/app.py" || len(body.Edits) != 1 || body.Edits[0].OldString != "x = 1" {
+			t.Errorf("body = %+v", body)
+		}
+		_, _ = w.Write([]byte(`{"files":[{"path":"/sandbox/session/app.py","replacements":1,"size":6}]}`))
+	}))
+	defer srv.Close() //nolint:errcheck
+
+	toolkit := NewWorkspaceToolkit(New(srv.URL, "sk-test"), "sess-1")
+	output, _, _ := toolkit.Handle(context.Background(), "edit_file",
+		json.RawMessage(`{"path": "/sandbox/session/app.py", "old_string": "x = 1", "new_string": "x = 2"}`))
+
+	if output != "Edited /sandbox/session/app.py (1 replacement(s))" {
+		t.Errorf("output = %q", output)
+	}
+}
+
+func TestHandle_EditFile_Conflict(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error":"edit_conflict","message":"edit conflict","details":[` +
+			`{"path":"/sandbox/session/app.py","hunk":2,"line":14,"reason":"context does not match"}]}`))
+	}))
+	defer srv.Close() //nolint:errcheck
+
+	toolkit := NewWorkspaceToolkit(New(srv.URL, "sk-test"), "sess-1")
+	output, _, _ := toolkit.Handle(context.Background(), "edit_file",
+		json.RawMessage(`{"path": "/sandbox/session/app.py", "diff": "--- a/app.py\n+++ b/app.py\n"}`))
+
+	want := "Edit not applied; the file is unchanged:\n- hunk 2 (line 14): context does not match"
+	if output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+
+	output, _, _ = toolkit.Handle(context.Background(), "edit_file", json.RawMessage(`{"path": "/sandbox/session/app.py"}`))
+	if output != "provide either old_string and new_string, or diff" {
+		t.Errorf("output without edit = %q", output)
+	}
+}
+
 func TestHandle_PresentFiles(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
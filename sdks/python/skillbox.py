@@ -54,6 +54,7 @@ class RunResult:
     logs: str = ""
     duration_ms: int = 0
     error: str = ""
+    partial: bool = False
 
     @property
     def has_files(self) -> bool:
@@ -551,6 +552,7 @@ def _parse_run_result(data: Any) -> RunResult:
         logs=data.get("logs", "") or "",
         duration_ms=data.get("duration_ms", 0) or 0,
         error=data.get("error", "") or "",
+        partial=bool(data.get("partial", False)),
     )
 
 